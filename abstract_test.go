@@ -372,7 +372,9 @@ func TestResolveTypeOnInterfaceYieldsUsefulError(t *testing.T) {
 			{
 				Type:      gqlerrors.ErrorTypeInternal,
 				Message:   `Runtime Object type "Human" is not a possible type for "Pet".`,
-				Locations: []location.SourceLocation{},
+				Code:      gqlerrors.CodeImpossibleType,
+				Locations: []location.SourceLocation{{Line: 2, Column: 7}},
+				Path:      []any{"pets", 2},
 			},
 		},
 	}
@@ -492,7 +494,9 @@ func TestResolveTypeOnUnionYieldsUsefulError(t *testing.T) {
 			{
 				Type:      gqlerrors.ErrorTypeInternal,
 				Message:   `Runtime Object type "Human" is not a possible type for "Pet".`,
-				Locations: []location.SourceLocation{},
+				Code:      gqlerrors.CodeImpossibleType,
+				Locations: []location.SourceLocation{{Line: 2, Column: 7}},
+				Path:      []any{"pets", 2},
 			},
 		},
 	}
@@ -510,3 +514,79 @@ func TestResolveTypeOnUnionYieldsUsefulError(t *testing.T) {
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
 	}
 }
+
+func TestIsTypeOfResultsAreCachedByGoType(t *testing.T) {
+	var calls int
+	newType := func(name string, match func(any) bool) *graphql.Object {
+		return graphql.NewObject(graphql.ObjectConfig{
+			Name: name,
+			Fields: graphql.Fields{
+				"name": &graphql.Field{Type: graphql.String},
+			},
+			IsTypeOf: func(p graphql.IsTypeOfParams) bool {
+				calls++
+				return match(p.Value)
+			},
+		})
+	}
+	dogType := newType("Dog", func(v any) bool { _, ok := v.(*testDog); return ok })
+	catType := newType("Cat", func(v any) bool { _, ok := v.(*testCat); return ok })
+	humanType := newType("Human", func(v any) bool { _, ok := v.(*testHuman); return ok })
+	petType := graphql.NewUnion(graphql.UnionConfig{
+		Name:  "Pet",
+		Types: []*graphql.Object{dogType, catType, humanType},
+	})
+	var pets []any
+	for i := 0; i < 30; i++ {
+		pets = append(pets, &testDog{Name: "d"}, &testCat{Name: "c"}, &testHuman{Name: "h"})
+	}
+
+	for _, byGoType := range []bool{false, true} {
+		schema, err := graphql.NewSchema(graphql.SchemaConfig{
+			Query: graphql.NewObject(graphql.ObjectConfig{
+				Name: "Query",
+				Fields: graphql.Fields{
+					"pets": &graphql.Field{
+						Type: graphql.NewList(petType),
+						Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+							return pets, nil
+						},
+					},
+				},
+			}),
+			IsTypeOfByGoType: byGoType,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for run := 0; run < 2; run++ {
+			calls = 0
+			result := graphql.Do(context.Background(), graphql.Params{
+				Schema:        schema,
+				RequestString: `{ pets { __typename } }`,
+			})
+			if len(result.Errors) != 0 {
+				t.Fatalf("Unexpected errors %+v", result.Errors)
+			}
+			data := result.Data.(map[string]any)["pets"].([]any)
+			if len(data) != len(pets) || data[2].(map[string]any)["__typename"] != "Human" {
+				t.Fatalf("Unexpected result %v", data)
+			}
+			// Resolving the first value of each Go type checks 1 + 2 + 3 types
+			// and later values only check the type resolved for their Go type.
+			// Completing the objects doesn't check their type again as only
+			// __typename is requested.
+			expected := 6 + len(pets) - 3
+			if byGoType {
+				// The schema caches the types after the first request
+				expected = 6
+				if run != 0 {
+					expected = 0
+				}
+			}
+			if calls != expected {
+				t.Fatalf("Expected %d IsTypeOf calls (byGoType=%t, run=%d), got %d", expected, byGoType, run, calls)
+			}
+		}
+	}
+}
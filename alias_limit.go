@@ -0,0 +1,136 @@
+package graphql
+
+import (
+	"strings"
+
+	"github.com/sprucehealth/graphql/gqlerrors"
+	"github.com/sprucehealth/graphql/language/ast"
+	"github.com/sprucehealth/graphql/language/printer"
+)
+
+// AliasLimitError is returned when a selection set selects the same field under
+// more aliases than allowed.
+type AliasLimitError struct {
+	// Field is the name of the aliased field.
+	Field string
+	// Limit is the maximum number of aliases of a field in a selection set.
+	Limit int
+}
+
+func (e *AliasLimitError) Error() string {
+	return gqlerrors.CodeAliasLimit.Message(e.Field, e.Limit)
+}
+
+// aliasCounter checks the number of response names of every field in the
+// selection sets of an operation. The selections of inline fragments and spread
+// fragments count towards the selection set they're in.
+type aliasCounter struct {
+	fragments map[string]*ast.FragmentDefinition
+	max       int
+	// checked holds the selection sets that have already been checked as a
+	// fragment may be spread many times.
+	checked map[*ast.SelectionSet]bool
+}
+
+func newAliasCounter(fragments map[string]*ast.FragmentDefinition, max int) *aliasCounter {
+	return &aliasCounter{
+		fragments: fragments,
+		max:       max,
+		checked:   make(map[*ast.SelectionSet]bool),
+	}
+}
+
+// check returns an *AliasLimitError if the selection set or any of the selection
+// sets of its fields select a field with more than max aliases.
+func (c *aliasCounter) check(ss *ast.SelectionSet) error {
+	if ss == nil || c.checked[ss] {
+		return nil
+	}
+	c.checked[ss] = true
+	aliases := make(map[string]map[string]struct{})
+	var fields []*ast.Field
+	c.collect(ss, aliases, &fields, make(map[string]bool))
+	for name, responseNames := range aliases {
+		if len(responseNames) > c.max {
+			return &AliasLimitError{Field: name, Limit: c.max}
+		}
+	}
+	for _, field := range fields {
+		if err := c.check(field.SelectionSet); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *aliasCounter) collect(ss *ast.SelectionSet, aliases map[string]map[string]struct{}, fields *[]*ast.Field, visited map[string]bool) {
+	if ss == nil {
+		return
+	}
+	for _, sel := range ss.Selections {
+		switch sel := sel.(type) {
+		case *ast.Field:
+			if sel.Name == nil {
+				continue
+			}
+			name := sel.Name.Value
+			responseName := name
+			if sel.Alias != nil {
+				responseName = sel.Alias.Value
+			}
+			if aliases[name] == nil {
+				aliases[name] = make(map[string]struct{})
+			}
+			aliases[name][responseName] = struct{}{}
+			*fields = append(*fields, sel)
+		case *ast.InlineFragment:
+			c.collect(sel.SelectionSet, aliases, fields, visited)
+		case *ast.FragmentSpread:
+			if sel.Name == nil || visited[sel.Name.Value] {
+				continue
+			}
+			visited[sel.Name.Value] = true
+			if fragment := c.fragments[sel.Name.Value]; fragment != nil {
+				c.collect(fragment.SelectionSet, aliases, fields, visited)
+			}
+		}
+	}
+}
+
+// aliasLimitError returns a BAD_QUERY error for an AliasLimitError.
+func aliasLimitError(err error) gqlerrors.FormattedError {
+	e := gqlerrors.NewError(gqlerrors.ErrorTypeBadQuery, err.Error(), nil, "", nil, nil, err)
+	e.Code = gqlerrors.CodeAliasLimit
+	return gqlerrors.FormatError(e)
+}
+
+// aliasedFieldKeys returns a key for every response name that selects a field
+// which is also selected under another response name. Response names with the
+// same key select the field with the same arguments, directives, and selections
+// so they resolve to the same value.
+func aliasedFieldKeys(fields map[string][]*ast.Field) map[string]string {
+	counts := make(map[string]int, len(fields))
+	for _, fieldASTs := range fields {
+		if len(fieldASTs) != 0 && fieldASTs[0].Name != nil {
+			counts[fieldASTs[0].Name.Value]++
+		}
+	}
+	var keys map[string]string
+	for responseName, fieldASTs := range fields {
+		if len(fieldASTs) == 0 || fieldASTs[0].Name == nil || counts[fieldASTs[0].Name.Value] < 2 {
+			continue
+		}
+		var b strings.Builder
+		for _, f := range fieldASTs {
+			unaliased := *f
+			unaliased.Alias = nil
+			b.WriteString(printer.Print(&unaliased))
+			b.WriteByte('\n')
+		}
+		if keys == nil {
+			keys = make(map[string]string)
+		}
+		keys[responseName] = b.String()
+	}
+	return keys
+}
@@ -0,0 +1,141 @@
+package graphql_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/gqlerrors"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+func TestMaxAliasesPerField(t *testing.T) {
+	schema := testSchema(t, &graphql.Field{
+		Type: graphql.String,
+		Args: graphql.FieldConfigArgument{
+			"arg": &graphql.ArgumentConfig{Type: graphql.String},
+		},
+		Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+			return "ok", nil
+		},
+	})
+
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema:             schema,
+		RequestString:      `{ a: test b: test ...F } fragment F on Query { c: test }`,
+		MaxAliasesPerField: 2,
+	})
+	if len(result.Errors) != 1 {
+		t.Fatalf("Expected 1 error, got %+v", result.Errors)
+	}
+	var limitErr *graphql.AliasLimitError
+	if e := result.Errors[0]; e.Type != gqlerrors.ErrorTypeBadQuery || !errors.As(e.OriginalError, &limitErr) {
+		t.Fatalf("Unexpected error %+v", e)
+	}
+	if limitErr.Field != "test" || limitErr.Limit != 2 {
+		t.Fatalf("Unexpected limit error %+v", limitErr)
+	}
+
+	// Selecting the same response name more than once counts once
+	result = graphql.Do(context.Background(), graphql.Params{
+		Schema:             schema,
+		RequestString:      `{ a: test a: test test ...F } fragment F on Query { test }`,
+		MaxAliasesPerField: 2,
+	})
+	if len(result.Errors) != 0 {
+		t.Fatalf("Unexpected errors %+v", result.Errors)
+	}
+}
+
+func TestDedupeAliasedFields(t *testing.T) {
+	var calls int
+	schema := testSchema(t, &graphql.Field{
+		Type: graphql.String,
+		Args: graphql.FieldConfigArgument{
+			"arg": &graphql.ArgumentConfig{Type: graphql.String},
+		},
+		Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+			calls++
+			arg, _ := p.Args["arg"].(string)
+			return "ok" + arg, nil
+		},
+	})
+
+	query := `{ a: test(arg: "1") b: test(arg: "1") c: test(arg: "2") d: test(arg: "1") @include(if: true) }`
+	expected := &graphql.Result{
+		Data: map[string]any{
+			"a": "ok1",
+			"b": "ok1",
+			"c": "ok2",
+			"d": "ok1",
+		},
+	}
+
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: query,
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	if calls != 4 {
+		t.Fatalf("Expected 4 calls without deduplication, got %d", calls)
+	}
+
+	calls = 0
+	result = graphql.Do(context.Background(), graphql.Params{
+		Schema:              schema,
+		RequestString:       query,
+		DedupeAliasedFields: true,
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	// The directive on d makes it differ from a and b
+	if calls != 3 {
+		t.Fatalf("Expected 3 calls with deduplication, got %d", calls)
+	}
+}
+
+func TestDedupeAliasedFields_MutationRoot(t *testing.T) {
+	var calls int
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name:   "Query",
+			Fields: graphql.Fields{"a": &graphql.Field{Type: graphql.String}},
+		}),
+		Mutation: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Mutation",
+			Fields: graphql.Fields{
+				"inc": &graphql.Field{
+					Type: graphql.Int,
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						calls++
+						return calls, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Every alias of a mutation field has its side effect
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema:              schema,
+		RequestString:       `mutation { x: inc y: inc }`,
+		DedupeAliasedFields: true,
+	})
+	if len(result.Errors) != 0 {
+		t.Fatalf("Unexpected errors %+v", result.Errors)
+	}
+	if data := result.Data.(map[string]any); data["x"] == data["y"] {
+		t.Fatalf("Expected the aliases to have different values, got %+v", data)
+	}
+	if calls != 2 {
+		t.Fatalf("Expected 2 calls, got %d", calls)
+	}
+}
@@ -0,0 +1,80 @@
+package graphql
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/sprucehealth/graphql/gqlerrors"
+)
+
+// hasName returns true if the name is the name of the argument or one of its
+// aliases.
+func (st *Argument) hasName(name string) bool {
+	return st.PrivateName == name || slices.Contains(st.Aliases, name)
+}
+
+// findArgument returns the argument with the name or alias or nil if there's none.
+func findArgument(args []*Argument, name string) *Argument {
+	for _, arg := range args {
+		if arg.PrivateName == name {
+			return arg
+		}
+	}
+	for _, arg := range args {
+		if slices.Contains(arg.Aliases, name) {
+			return arg
+		}
+	}
+	return nil
+}
+
+// findInputField returns the field of the input object with the name or alias or
+// nil if there's none.
+func findInputField(ttype *InputObject, name string) *InputObjectField {
+	fields := ttype.Fields()
+	if field, ok := fields[name]; ok {
+		return field
+	}
+	for _, field := range fields {
+		if slices.Contains(field.Aliases, name) {
+			return field
+		}
+	}
+	return nil
+}
+
+// lookupAlias returns the value of the map for the name or the first of the
+// aliases that's set. The name takes precedence over the aliases.
+func lookupAlias[V any](m map[string]V, name string, aliases []string) (V, bool) {
+	if v, ok := m[name]; ok {
+		return v, true
+	}
+	for _, alias := range aliases {
+		if v, ok := m[alias]; ok {
+			return v, true
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// checkAliases returns an error if an alias of an argument or input field is the
+// name or alias of another one.
+func checkAliases(owner string, names map[string][]string) error {
+	seen := make(map[string]string, len(names))
+	for name := range names {
+		seen[name] = name
+	}
+	for _, name := range sortedKeys(names) {
+		for _, alias := range names[name] {
+			if err := assertValidName(alias); err != nil {
+				return err
+			}
+			if other, ok := seen[alias]; ok && other != name {
+				return gqlerrors.NewFormattedError(fmt.Sprintf("%s alias %q of %q conflicts with %q.", owner, alias, name, other))
+			}
+			seen[alias] = name
+		}
+	}
+	return nil
+}
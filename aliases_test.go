@@ -0,0 +1,122 @@
+package graphql_test
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+func TestArgumentAliases(t *testing.T) {
+	filter := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "Filter",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"nameContains": &graphql.InputObjectFieldConfig{Type: graphql.String, Aliases: []string{"name"}},
+			"limit":        &graphql.InputObjectFieldConfig{Type: graphql.Int},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"user": &graphql.Field{
+					Type: graphql.String,
+					Args: graphql.FieldConfigArgument{
+						"id":     &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID), Aliases: []string{"userId"}},
+						"filter": &graphql.ArgumentConfig{Type: filter},
+					},
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						b, err := json.Marshal(p.Args)
+						return string(b), err
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name     string
+		query    string
+		vars     map[string]any
+		expected string
+		warnings []graphql.DeprecationWarning
+	}{
+		{
+			name:     "names",
+			query:    `{ user(id: "1", filter: {nameContains: "a"}) }`,
+			expected: `{"filter":{"nameContains":"a"},"id":"1"}`,
+		},
+		{
+			name:     "aliases",
+			query:    `{ user(userId: "1", filter: {name: "a", limit: 2}) }`,
+			expected: `{"filter":{"limit":2,"nameContains":"a"},"id":"1"}`,
+			warnings: []graphql.DeprecationWarning{
+				{Coordinate: "Query.user(userId:)", Reason: `Use "id" instead.`, Message: `Query.user(userId:) is deprecated: Use "id" instead.`},
+				{Coordinate: "Filter.name", Reason: `Use "nameContains" instead.`, Message: `Filter.name is deprecated: Use "nameContains" instead.`},
+			},
+		},
+		{
+			name:     "variables",
+			query:    `query ($id: ID!, $filter: Filter) { user(userId: $id, filter: $filter) }`,
+			vars:     map[string]any{"id": "1", "filter": map[string]any{"name": "a"}},
+			expected: `{"filter":{"nameContains":"a"},"id":"1"}`,
+			warnings: []graphql.DeprecationWarning{
+				{Coordinate: "Query.user(userId:)", Reason: `Use "id" instead.`, Message: `Query.user(userId:) is deprecated: Use "id" instead.`},
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			result := graphql.Do(context.Background(), graphql.Params{
+				Schema:              schema,
+				RequestString:       c.query,
+				VariableValues:      c.vars,
+				DeprecationWarnings: true,
+			})
+			if len(result.Errors) != 0 {
+				t.Fatal(result.Errors)
+			}
+			if user := result.Data.(map[string]any)["user"]; user != c.expected {
+				t.Fatalf("Expected %s, got %v", c.expected, user)
+			}
+			warnings, _ := result.Extensions[graphql.DeprecationsExtensionKey].([]graphql.DeprecationWarning)
+			if !reflect.DeepEqual(c.warnings, warnings) {
+				t.Fatalf("Unexpected warnings, Diff: %v", testutil.Diff(c.warnings, warnings))
+			}
+		})
+	}
+
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: `{ user(filter: {name: "a"}) }`,
+	})
+	expected := `Field "user" argument "id" of type "ID!" is required but not provided.`
+	if len(result.Errors) != 1 || result.Errors[0].Message != expected {
+		t.Fatalf("Expected error %q, got %v", expected, result.Errors)
+	}
+
+	_, err = graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"user": &graphql.Field{
+					Type: graphql.String,
+					Args: graphql.FieldConfigArgument{
+						"id":   &graphql.ArgumentConfig{Type: graphql.ID, Aliases: []string{"name"}},
+						"name": &graphql.ArgumentConfig{Type: graphql.String},
+					},
+				},
+			},
+		}),
+	})
+	expected = `Query.user argument alias "name" of "id" conflicts with "name".`
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected error %q, got %v", expected, err)
+	}
+}
@@ -0,0 +1,124 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/sprucehealth/graphql/language/ast"
+)
+
+// AnalysisExtensionKey is the key in Result.Extensions under which the analysis
+// of the operation is returned when AnalysisPolicy.Extensions is set.
+const AnalysisExtensionKey = "analysis"
+
+// AnalysisPolicy configures the report-only analysis of the complexity and depth
+// of operations. Nothing is rejected which lets the distributions of production
+// traffic be collected before limits such as MaxExpandedSelections are enforced.
+type AnalysisPolicy struct {
+	// Extensions if true returns the analysis in the result extensions under
+	// AnalysisExtensionKey.
+	Extensions bool
+	// Report if set is called with the analysis of every operation before it's executed.
+	Report func(ctx context.Context, info *OperationInfo, analysis *OperationAnalysis)
+	// MaxComplexity if greater than 0 is a candidate limit of the complexity. Operations
+	// above it are flagged in the analysis but still executed.
+	MaxComplexity int
+	// MaxDepth if greater than 0 is a candidate limit of the depth. Operations above
+	// it are flagged in the analysis but still executed.
+	MaxDepth int
+}
+
+// OperationAnalysis is the complexity and depth of an operation.
+type OperationAnalysis struct {
+	// Complexity is the number of fields the operation selects with fragments
+	// counted every time they're spread. It's what MaxExpandedSelections limits.
+	Complexity int `json:"complexity"`
+	// Depth is the largest number of nested fields (e.g. 2 for `{ user { name } }`).
+	Depth int `json:"depth"`
+	// ExceedsMaxComplexity is true if the complexity is above AnalysisPolicy.MaxComplexity.
+	ExceedsMaxComplexity bool `json:"exceedsMaxComplexity,omitempty"`
+	// ExceedsMaxDepth is true if the depth is above AnalysisPolicy.MaxDepth.
+	ExceedsMaxDepth bool `json:"exceedsMaxDepth,omitempty"`
+}
+
+// AnalyzeDocument returns the analysis of the named operation of a document, or
+// of its only operation if operationName is empty, without executing it (e.g. to
+// estimate the complexity of the operations of a client before they're
+// deployed). The policy sets the candidate limits and may be nil. It returns nil
+// if the document doesn't have the operation.
+func AnalyzeDocument(doc *ast.Document, operationName string, policy *AnalysisPolicy) *OperationAnalysis {
+	operation, fragments := selectOperation(doc, operationName)
+	if operation == nil {
+		return nil
+	}
+	if policy == nil {
+		policy = &AnalysisPolicy{}
+	}
+	return analyzeOperation(policy, operation, fragments)
+}
+
+// analyzeOperation returns the analysis of the operation.
+func analyzeOperation(policy *AnalysisPolicy, operation ast.Definition, fragments map[string]*ast.FragmentDefinition) *OperationAnalysis {
+	a := &OperationAnalysis{
+		Complexity: operationCost(operation, fragments),
+		Depth:      newDepthCounter(fragments).depth(operation.GetSelectionSet()),
+	}
+	a.ExceedsMaxComplexity = policy.MaxComplexity > 0 && a.Complexity > policy.MaxComplexity
+	a.ExceedsMaxDepth = policy.MaxDepth > 0 && a.Depth > policy.MaxDepth
+	return a
+}
+
+// depthCounter computes the depth of selection sets including the fields of
+// spread fragments. The depth is memoized per fragment.
+type depthCounter struct {
+	fragments map[string]*ast.FragmentDefinition
+	memo      map[string]int
+	// visiting tracks the fragments being measured in case the document hasn't
+	// been validated and contains cycles.
+	visiting map[string]bool
+}
+
+func newDepthCounter(fragments map[string]*ast.FragmentDefinition) *depthCounter {
+	return &depthCounter{
+		fragments: fragments,
+		memo:      make(map[string]int),
+		visiting:  make(map[string]bool),
+	}
+}
+
+func (c *depthCounter) depth(ss *ast.SelectionSet) int {
+	if ss == nil {
+		return 0
+	}
+	var d int
+	for _, sel := range ss.Selections {
+		var sd int
+		switch sel := sel.(type) {
+		case *ast.Field:
+			sd = 1 + c.depth(sel.SelectionSet)
+		case *ast.InlineFragment:
+			sd = c.depth(sel.SelectionSet)
+		case *ast.FragmentSpread:
+			if sel.Name == nil || c.visiting[sel.Name.Value] {
+				continue
+			}
+			sd = c.fragmentDepth(sel.Name.Value)
+		}
+		d = max(d, sd)
+	}
+	return d
+}
+
+func (c *depthCounter) fragmentDepth(name string) int {
+	if d, ok := c.memo[name]; ok {
+		return d
+	}
+	fragment := c.fragments[name]
+	if fragment == nil {
+		return 0
+	}
+	c.visiting[name] = true
+	d := c.depth(fragment.SelectionSet)
+	c.visiting[name] = false
+	c.memo[name] = d
+	return d
+}
@@ -0,0 +1,88 @@
+package graphql_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+func TestAnalysis(t *testing.T) {
+	userType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.String},
+		},
+	})
+	userType.AddFieldConfig("friend", &graphql.Field{
+		Type: userType,
+		Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+			return map[string]any{"name": "friend"}, nil
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"user": &graphql.Field{
+					Type: userType,
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						return map[string]any{"name": "user"}, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var reported []string
+	policy := &graphql.AnalysisPolicy{
+		Extensions: true,
+		Report: func(ctx context.Context, info *graphql.OperationInfo, analysis *graphql.OperationAnalysis) {
+			reported = append(reported, info.Name)
+		},
+		MaxComplexity: 5,
+		MaxDepth:      3,
+	}
+	cases := []struct {
+		name     string
+		query    string
+		expected *graphql.OperationAnalysis
+	}{
+		{
+			name:     "shallow",
+			query:    `query Shallow { user { name } }`,
+			expected: &graphql.OperationAnalysis{Complexity: 2, Depth: 2},
+		},
+		{
+			name: "fragments",
+			query: `
+				query Fragments { user { ...F friend { ...F } } }
+				fragment F on User { name ... on User { friend { name } } }`,
+			expected: &graphql.OperationAnalysis{Complexity: 8, Depth: 4, ExceedsMaxComplexity: true, ExceedsMaxDepth: true},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			reported = nil
+			result := graphql.Do(context.Background(), graphql.Params{
+				Schema:        schema,
+				RequestString: c.query,
+				Analysis:      policy,
+			})
+			if len(result.Errors) != 0 {
+				t.Fatalf("Unexpected errors: %v", result.Errors)
+			}
+			if a := result.Extensions[graphql.AnalysisExtensionKey]; !reflect.DeepEqual(a, c.expected) {
+				t.Fatal(testutil.Diff(c.expected, a))
+			}
+			if len(reported) != 1 {
+				t.Fatalf("Expected one report, got %v", reported)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package graphql
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/sprucehealth/graphql/gqlerrors"
+)
+
+// ClampedArgumentsExtensionKey is the key in Result.Extensions under which the
+// arguments that were clamped to the maximum set by Field.MaxArgs are returned.
+const ClampedArgumentsExtensionKey = "clampedArguments"
+
+// ClampedArgument is an argument of a field that was larger than its maximum and
+// was replaced by the maximum.
+type ClampedArgument struct {
+	Path      []string `json:"path"`
+	Argument  string   `json:"argument"`
+	Requested int      `json:"requested"`
+	Max       int      `json:"max"`
+}
+
+// checkMaxArgs returns an error if a maximum is set for an argument of the field
+// that isn't an Int argument.
+func checkMaxArgs(ttype Named, fieldDef *FieldDefinition, maxArgs map[string]int) error {
+	for _, name := range sortedKeys(maxArgs) {
+		i := slices.IndexFunc(fieldDef.Args, func(arg *Argument) bool { return arg.PrivateName == name })
+		if i < 0 || GetNamed(fieldDef.Args[i].Type) != Int {
+			return gqlerrors.NewFormattedError(fmt.Sprintf(`%v.%v(%v:) has a maximum but is not an Int argument.`, ttype, fieldDef.Name, name))
+		}
+	}
+	return nil
+}
+
+// argumentClamps records the arguments clamped during the execution of an operation.
+type argumentClamps struct {
+	mu      sync.Mutex
+	clamped map[string]ClampedArgument
+}
+
+// clampArgs replaces the values of the arguments that are larger than their
+// maximum by the maximum and records them if c isn't nil.
+func (c *argumentClamps) clampArgs(maxArgs map[string]int, args map[string]any, path []string) {
+	for name, limit := range maxArgs {
+		v, ok := args[name].(int)
+		if !ok || v <= limit {
+			continue
+		}
+		args[name] = limit
+		if c == nil {
+			continue
+		}
+		key := strings.Join(path, ".") + "(" + name + ":)"
+		c.mu.Lock()
+		if c.clamped == nil {
+			c.clamped = make(map[string]ClampedArgument)
+		}
+		if _, ok := c.clamped[key]; !ok {
+			c.clamped[key] = ClampedArgument{
+				Path:      slices.Clone(path),
+				Argument:  name,
+				Requested: v,
+				Max:       limit,
+			}
+		}
+		c.mu.Unlock()
+	}
+}
+
+// arguments returns the clamped arguments sorted by path and argument. An argument
+// of a field in a list is only returned once.
+func (c *argumentClamps) arguments() []ClampedArgument {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	keys := sortedKeys(c.clamped)
+	clamped := make([]ClampedArgument, len(keys))
+	for i, key := range keys {
+		clamped[i] = c.clamped[key]
+	}
+	return clamped
+}
@@ -0,0 +1,95 @@
+package graphql_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+func TestMaxArgs(t *testing.T) {
+	itemsField := &graphql.Field{
+		Type: graphql.NewList(graphql.Int),
+		Args: graphql.FieldConfigArgument{
+			"first": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 10},
+		},
+		MaxArgs: map[string]int{"first": 3},
+		Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+			items := make([]any, p.Args["first"].(int))
+			for i := range items {
+				items[i] = i
+			}
+			return items, nil
+		},
+	}
+	listType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "List",
+		Fields: graphql.Fields{
+			"items": itemsField,
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"items": itemsField,
+				"lists": &graphql.Field{
+					Type: graphql.NewList(listType),
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						return []any{struct{}{}, struct{}{}}, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: `{ small: items(first: 2) items lists { items(first: 5) } }`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]any{
+			"small": []any{0, 1},
+			"items": []any{0, 1, 2},
+			"lists": []any{
+				map[string]any{"items": []any{0, 1, 2}},
+				map[string]any{"items": []any{0, 1, 2}},
+			},
+		},
+		Extensions: map[string]any{
+			graphql.ClampedArgumentsExtensionKey: []graphql.ClampedArgument{
+				{Path: []string{"items"}, Argument: "first", Requested: 10, Max: 3},
+				{Path: []string{"lists", "items"}, Argument: "first", Requested: 5, Max: 3},
+			},
+		},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatal(testutil.Diff(expected, result))
+	}
+}
+
+func TestMaxArgs_NotInt(t *testing.T) {
+	_, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"items": &graphql.Field{
+					Type: graphql.String,
+					Args: graphql.FieldConfigArgument{
+						"after": &graphql.ArgumentConfig{Type: graphql.String},
+					},
+					MaxArgs: map[string]int{"after": 3},
+				},
+			},
+		}),
+	})
+	expected := `Query.items(after:) has a maximum but is not an Int argument.`
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected error %q, got %v", expected, err)
+	}
+}
@@ -0,0 +1,354 @@
+// Package astbuilder provides fluent constructors for building GraphQL documents
+// programmatically instead of assembling ast struct literals by hand:
+//
+//	doc, err := astbuilder.NewDocument().
+//		Operation(astbuilder.NewOperation(ast.OperationTypeQuery).
+//			Name("Hero").
+//			Var("episode", astbuilder.Named("Episode"), nil).
+//			Select(astbuilder.NewField("hero").
+//				Arg("episode", astbuilder.Variable("episode")).
+//				Select(astbuilder.NewField("name")))).
+//		Build()
+//
+// Builders modify the node they build in place so a builder must not be used once
+// its node has been built.
+package astbuilder
+
+import (
+	"strconv"
+
+	"github.com/sprucehealth/graphql/language/ast"
+	"github.com/sprucehealth/graphql/language/parser"
+	"github.com/sprucehealth/graphql/language/printer"
+	"github.com/sprucehealth/graphql/language/source"
+)
+
+// Selection is implemented by the builders of fields, inline fragments, and
+// fragment spreads.
+type Selection interface {
+	BuildSelection() ast.Selection
+}
+
+var (
+	_ Selection = (*FieldBuilder)(nil)
+	_ Selection = (*InlineFragmentBuilder)(nil)
+	_ Selection = (*FragmentSpreadBuilder)(nil)
+)
+
+// DocumentBuilder builds an ast.Document.
+type DocumentBuilder struct {
+	doc *ast.Document
+}
+
+// NewDocument returns a builder of an empty document.
+func NewDocument() *DocumentBuilder {
+	return &DocumentBuilder{doc: &ast.Document{}}
+}
+
+// Operation adds an operation to the document.
+func (b *DocumentBuilder) Operation(op *OperationBuilder) *DocumentBuilder {
+	b.doc.Definitions = append(b.doc.Definitions, op.Build())
+	return b
+}
+
+// Fragment adds a fragment definition to the document.
+func (b *DocumentBuilder) Fragment(frag *FragmentBuilder) *DocumentBuilder {
+	b.doc.Definitions = append(b.doc.Definitions, frag.Build())
+	return b
+}
+
+// Build returns the document as if it had been parsed from its printed form so
+// that every node has a location and errors reported against the document point
+// at the printed source. It returns a syntax error if the document isn't valid
+// (e.g. a selection set is empty).
+func (b *DocumentBuilder) Build() (*ast.Document, error) {
+	return parser.Parse(parser.ParseParams{
+		Source: source.New("GraphQL request", printer.Print(b.doc)),
+	})
+}
+
+// OperationBuilder builds an ast.OperationDefinition.
+type OperationBuilder struct {
+	op *ast.OperationDefinition
+}
+
+// NewOperation returns a builder of an anonymous operation of the operation type
+// (e.g. ast.OperationTypeQuery).
+func NewOperation(operation string) *OperationBuilder {
+	return &OperationBuilder{op: &ast.OperationDefinition{
+		Operation:    operation,
+		SelectionSet: &ast.SelectionSet{},
+	}}
+}
+
+// Name sets the name of the operation.
+func (b *OperationBuilder) Name(name string) *OperationBuilder {
+	b.op.Name = newName(name)
+	return b
+}
+
+// Var adds a variable definition to the operation. The default value may be nil.
+func (b *OperationBuilder) Var(name string, ttype ast.Type, defaultValue ast.Value) *OperationBuilder {
+	b.op.VariableDefinitions = append(b.op.VariableDefinitions, &ast.VariableDefinition{
+		Variable:     &ast.Variable{Name: newName(name)},
+		Type:         ttype,
+		DefaultValue: defaultValue,
+	})
+	return b
+}
+
+// Directive adds a directive to the operation.
+func (b *OperationBuilder) Directive(directive *DirectiveBuilder) *OperationBuilder {
+	b.op.Directives = append(b.op.Directives, directive.Build())
+	return b
+}
+
+// Select adds selections to the operation.
+func (b *OperationBuilder) Select(selections ...Selection) *OperationBuilder {
+	b.op.SelectionSet.Selections = appendSelections(b.op.SelectionSet.Selections, selections)
+	return b
+}
+
+// Build returns the operation.
+func (b *OperationBuilder) Build() *ast.OperationDefinition {
+	return b.op
+}
+
+// FragmentBuilder builds an ast.FragmentDefinition.
+type FragmentBuilder struct {
+	frag *ast.FragmentDefinition
+}
+
+// NewFragment returns a builder of a fragment definition on the type.
+func NewFragment(name, typeCondition string) *FragmentBuilder {
+	return &FragmentBuilder{frag: &ast.FragmentDefinition{
+		Name:          newName(name),
+		TypeCondition: Named(typeCondition),
+		SelectionSet:  &ast.SelectionSet{},
+	}}
+}
+
+// Directive adds a directive to the fragment.
+func (b *FragmentBuilder) Directive(directive *DirectiveBuilder) *FragmentBuilder {
+	b.frag.Directives = append(b.frag.Directives, directive.Build())
+	return b
+}
+
+// Select adds selections to the fragment.
+func (b *FragmentBuilder) Select(selections ...Selection) *FragmentBuilder {
+	b.frag.SelectionSet.Selections = appendSelections(b.frag.SelectionSet.Selections, selections)
+	return b
+}
+
+// Build returns the fragment definition.
+func (b *FragmentBuilder) Build() *ast.FragmentDefinition {
+	return b.frag
+}
+
+// FieldBuilder builds an ast.Field.
+type FieldBuilder struct {
+	field *ast.Field
+}
+
+// NewField returns a builder of a field selection.
+func NewField(name string) *FieldBuilder {
+	return &FieldBuilder{field: &ast.Field{Name: newName(name)}}
+}
+
+// Alias sets the alias of the field.
+func (b *FieldBuilder) Alias(alias string) *FieldBuilder {
+	b.field.Alias = newName(alias)
+	return b
+}
+
+// Arg adds an argument to the field.
+func (b *FieldBuilder) Arg(name string, value ast.Value) *FieldBuilder {
+	b.field.Arguments = append(b.field.Arguments, &ast.Argument{
+		Name:  newName(name),
+		Value: value,
+	})
+	return b
+}
+
+// Directive adds a directive to the field.
+func (b *FieldBuilder) Directive(directive *DirectiveBuilder) *FieldBuilder {
+	b.field.Directives = append(b.field.Directives, directive.Build())
+	return b
+}
+
+// Select adds selections to the selection set of the field.
+func (b *FieldBuilder) Select(selections ...Selection) *FieldBuilder {
+	if b.field.SelectionSet == nil {
+		b.field.SelectionSet = &ast.SelectionSet{}
+	}
+	b.field.SelectionSet.Selections = appendSelections(b.field.SelectionSet.Selections, selections)
+	return b
+}
+
+// Build returns the field.
+func (b *FieldBuilder) Build() *ast.Field {
+	return b.field
+}
+
+// BuildSelection implements Selection.
+func (b *FieldBuilder) BuildSelection() ast.Selection {
+	return b.Build()
+}
+
+// InlineFragmentBuilder builds an ast.InlineFragment.
+type InlineFragmentBuilder struct {
+	frag *ast.InlineFragment
+}
+
+// NewInlineFragment returns a builder of an inline fragment. The type condition
+// may be empty.
+func NewInlineFragment(typeCondition string) *InlineFragmentBuilder {
+	frag := &ast.InlineFragment{SelectionSet: &ast.SelectionSet{}}
+	if typeCondition != "" {
+		frag.TypeCondition = Named(typeCondition)
+	}
+	return &InlineFragmentBuilder{frag: frag}
+}
+
+// Directive adds a directive to the inline fragment.
+func (b *InlineFragmentBuilder) Directive(directive *DirectiveBuilder) *InlineFragmentBuilder {
+	b.frag.Directives = append(b.frag.Directives, directive.Build())
+	return b
+}
+
+// Select adds selections to the inline fragment.
+func (b *InlineFragmentBuilder) Select(selections ...Selection) *InlineFragmentBuilder {
+	b.frag.SelectionSet.Selections = appendSelections(b.frag.SelectionSet.Selections, selections)
+	return b
+}
+
+// Build returns the inline fragment.
+func (b *InlineFragmentBuilder) Build() *ast.InlineFragment {
+	return b.frag
+}
+
+// BuildSelection implements Selection.
+func (b *InlineFragmentBuilder) BuildSelection() ast.Selection {
+	return b.Build()
+}
+
+// FragmentSpreadBuilder builds an ast.FragmentSpread.
+type FragmentSpreadBuilder struct {
+	spread *ast.FragmentSpread
+}
+
+// NewFragmentSpread returns a builder of a spread of the named fragment.
+func NewFragmentSpread(name string) *FragmentSpreadBuilder {
+	return &FragmentSpreadBuilder{spread: &ast.FragmentSpread{Name: newName(name)}}
+}
+
+// Directive adds a directive to the fragment spread.
+func (b *FragmentSpreadBuilder) Directive(directive *DirectiveBuilder) *FragmentSpreadBuilder {
+	b.spread.Directives = append(b.spread.Directives, directive.Build())
+	return b
+}
+
+// Build returns the fragment spread.
+func (b *FragmentSpreadBuilder) Build() *ast.FragmentSpread {
+	return b.spread
+}
+
+// BuildSelection implements Selection.
+func (b *FragmentSpreadBuilder) BuildSelection() ast.Selection {
+	return b.Build()
+}
+
+// DirectiveBuilder builds an ast.Directive.
+type DirectiveBuilder struct {
+	directive *ast.Directive
+}
+
+// NewDirective returns a builder of a directive without the leading @.
+func NewDirective(name string) *DirectiveBuilder {
+	return &DirectiveBuilder{directive: &ast.Directive{Name: newName(name)}}
+}
+
+// Arg adds an argument to the directive.
+func (b *DirectiveBuilder) Arg(name string, value ast.Value) *DirectiveBuilder {
+	b.directive.Arguments = append(b.directive.Arguments, &ast.Argument{
+		Name:  newName(name),
+		Value: value,
+	})
+	return b
+}
+
+// Build returns the directive.
+func (b *DirectiveBuilder) Build() *ast.Directive {
+	return b.directive
+}
+
+// Named returns a reference to a named type.
+func Named(name string) *ast.Named {
+	return &ast.Named{Name: newName(name)}
+}
+
+// NonNull returns a non-null reference to the type.
+func NonNull(ttype ast.Type) *ast.NonNull {
+	return &ast.NonNull{Type: ttype}
+}
+
+// ListType returns a list of the type.
+func ListType(ttype ast.Type) *ast.List {
+	return &ast.List{Type: ttype}
+}
+
+// Variable returns a reference to a variable without the leading $.
+func Variable(name string) *ast.Variable {
+	return &ast.Variable{Name: newName(name)}
+}
+
+// String returns a string value.
+func String(value string) *ast.StringValue {
+	return &ast.StringValue{Value: value}
+}
+
+// Int returns an int value.
+func Int(value int) *ast.IntValue {
+	return &ast.IntValue{Value: strconv.Itoa(value)}
+}
+
+// Float returns a float value.
+func Float(value float64) *ast.FloatValue {
+	return &ast.FloatValue{Value: strconv.FormatFloat(value, 'g', -1, 64)}
+}
+
+// Boolean returns a boolean value.
+func Boolean(value bool) *ast.BooleanValue {
+	return &ast.BooleanValue{Value: value}
+}
+
+// Enum returns an enum value.
+func Enum(value string) *ast.EnumValue {
+	return &ast.EnumValue{Value: value}
+}
+
+// List returns a list value.
+func List(values ...ast.Value) *ast.ListValue {
+	return &ast.ListValue{Values: values}
+}
+
+// Object returns an input object value.
+func Object(fields ...*ast.ObjectField) *ast.ObjectValue {
+	return &ast.ObjectValue{Fields: fields}
+}
+
+// ObjectField returns a field of an input object value.
+func ObjectField(name string, value ast.Value) *ast.ObjectField {
+	return &ast.ObjectField{Name: newName(name), Value: value}
+}
+
+func newName(name string) *ast.Name {
+	return &ast.Name{Value: name}
+}
+
+func appendSelections(dst []ast.Selection, selections []Selection) []ast.Selection {
+	for _, s := range selections {
+		dst = append(dst, s.BuildSelection())
+	}
+	return dst
+}
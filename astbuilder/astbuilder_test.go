@@ -0,0 +1,77 @@
+package astbuilder
+
+import (
+	"testing"
+
+	"github.com/sprucehealth/graphql/language/ast"
+	"github.com/sprucehealth/graphql/language/printer"
+)
+
+func TestBuildDocument(t *testing.T) {
+	doc, err := NewDocument().
+		Operation(NewOperation(ast.OperationTypeQuery).
+			Name("Hero").
+			Var("episode", NonNull(Named("Episode")), nil).
+			Var("first", Named("Int"), Int(10)).
+			Select(
+				NewField("hero").
+					Alias("h").
+					Arg("episode", Variable("episode")).
+					Select(
+						NewField("name"),
+						NewFragmentSpread("Friends"),
+						NewInlineFragment("Droid").Select(NewField("primaryFunction")),
+					),
+				NewField("search").
+					Arg("filter", Object(
+						ObjectField("names", List(String("Luke"), String("Leia"))),
+						ObjectField("minHeight", Float(1.5)),
+						ObjectField("human", Boolean(true)),
+						ObjectField("side", Enum("LIGHT")),
+					)).
+					Directive(NewDirective("include").Arg("if", Boolean(true))).
+					Select(NewField("__typename")),
+			)).
+		Fragment(NewFragment("Friends", "Character").
+			Select(NewField("friends").Arg("first", Variable("first")).Select(NewField("name")))).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `query Hero($episode: Episode!, $first: Int = 10) {
+  h: hero(episode: $episode) {
+    name
+    ...Friends
+    ... on Droid {
+      primaryFunction
+    }
+  }
+  search(filter: {names: ["Luke", "Leia"], minHeight: 1.5, human: true, side: LIGHT}) @include(if: true) {
+    __typename
+  }
+}
+
+fragment Friends on Character {
+  friends(first: $first) {
+    name
+  }
+}
+`
+	if printed := printer.Print(doc); printed != expected {
+		t.Fatalf("Expected:\n%s\nGot:\n%s", expected, printed)
+	}
+	op := doc.Definitions[0].(*ast.OperationDefinition)
+	search := op.SelectionSet.Selections[1].(*ast.Field)
+	if loc := search.GetLoc(); loc.Source == nil || loc.Start == 0 || loc.End <= loc.Start {
+		t.Fatalf("Expected a location for the field, got %+v", loc)
+	}
+}
+
+func TestBuildDocumentInvalid(t *testing.T) {
+	_, err := NewDocument().
+		Operation(NewOperation(ast.OperationTypeQuery).Select(NewField("hero").Select())).
+		Build()
+	if err == nil {
+		t.Fatal("Expected an error for an empty selection set")
+	}
+}
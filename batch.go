@@ -0,0 +1,100 @@
+package graphql
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/sprucehealth/graphql/language/ast"
+)
+
+// BatchResolveFn resolves all requested fields of an object at once. The returned
+// map is keyed by the response name of each field. A missing key resolves the
+// field to null.
+type BatchResolveFn func(ctx context.Context, p BatchResolveParams) (map[string]any, error)
+
+// BatchResolveParams are the parameters for a BatchResolveFn.
+type BatchResolveParams struct {
+	// Source is the value of the object being resolved.
+	Source any
+	// Fields are the requested fields that don't have their own resolve function.
+	Fields []BatchResolveField
+}
+
+// BatchResolveField is a single field requested from a BatchResolveFn.
+type BatchResolveField struct {
+	// ResponseName is the alias or name of the field and the key for its result.
+	ResponseName string
+	Args         map[string]any
+	Info         ResolveInfo
+}
+
+// batchResult is the result of calling a batch resolver for an object.
+type batchResult struct {
+	values map[string]any
+	err    error
+}
+
+// batchResolve calls the batch resolve function of the parent type for all
+// requested fields that don't have their own resolver. It returns nil if there's
+// nothing to batch.
+func batchResolve(ctx context.Context, eCtx *ExecutionContext, parentType *Object, source any, fields map[string][]*ast.Field, responseNames []string, path []string) *batchResult {
+	if parentType.BatchResolve == nil || eCtx.replay != nil {
+		return nil
+	}
+	if responseNames == nil {
+		// Sort to call the batch resolver with a stable order of fields.
+		responseNames = make([]string, 0, len(fields))
+		for responseName := range fields {
+			responseNames = append(responseNames, responseName)
+		}
+		sort.Strings(responseNames)
+	}
+
+	var batchFields []BatchResolveField
+	for _, responseName := range responseNames {
+		fieldASTs := fields[responseName]
+		if len(fieldASTs) == 0 || fieldASTs[0].Name == nil {
+			continue
+		}
+		fieldDef := getFieldDef(eCtx.Schema, parentType, fieldASTs[0].Name.Value, eCtx.DisallowIntrospection)
+		if fieldDef == nil || fieldDef.Resolve != nil {
+			continue
+		}
+		args := getArgumentValues(fieldDef.Args, fieldASTs[0].Arguments, eCtx.VariableValues)
+		args = sanitizeArgs(eCtx.Schema.sanitizers, fieldDef.Args, args)
+		// Fields with invalid arguments are reported when the field is resolved.
+		if fieldDef.ValidateArgs != nil && fieldDef.ValidateArgs(ctx, args) != nil {
+			continue
+		}
+		batchFields = append(batchFields, BatchResolveField{
+			ResponseName: responseName,
+			Args:         args,
+			Info:         newResolveInfo(eCtx, parentType, fieldDef, fieldASTs),
+		})
+	}
+	if len(batchFields) == 0 {
+		return nil
+	}
+
+	if parentType.Bulkhead != nil {
+		release, err := acquireBulkhead(ctx, parentType, nil, nil)
+		if err != nil {
+			return &batchResult{err: err}
+		}
+		defer release()
+	}
+
+	var st time.Time
+	if eCtx.Tracer != nil {
+		st = time.Now()
+	}
+	values, err := parentType.BatchResolve(ctx, BatchResolveParams{
+		Source: source,
+		Fields: batchFields,
+	})
+	if !st.IsZero() {
+		eCtx.Tracer.Trace(ctx, path, time.Since(st))
+	}
+	return &batchResult{values: values, err: err}
+}
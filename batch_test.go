@@ -0,0 +1,110 @@
+package graphql_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+func TestBatchResolve(t *testing.T) {
+	var calls [][]string
+	var fail bool
+	userType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.ID),
+				Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+					return p.Source.(string), nil
+				},
+			},
+			"name": &graphql.Field{Type: graphql.String},
+			"greeting": &graphql.Field{
+				Type: graphql.String,
+				Args: graphql.FieldConfigArgument{
+					"prefix": &graphql.ArgumentConfig{Type: graphql.String},
+				},
+			},
+		},
+		BatchResolve: func(ctx context.Context, p graphql.BatchResolveParams) (map[string]any, error) {
+			if fail {
+				return nil, errors.New("backend unavailable")
+			}
+			var names []string
+			values := make(map[string]any, len(p.Fields))
+			for _, f := range p.Fields {
+				names = append(names, f.ResponseName)
+				switch f.Info.FieldName {
+				case "name":
+					values[f.ResponseName] = "Name " + p.Source.(string)
+				case "greeting":
+					values[f.ResponseName] = f.Args["prefix"].(string) + " " + p.Source.(string)
+				}
+			}
+			calls = append(calls, names)
+			return values, nil
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"user": &graphql.Field{
+					Type: userType,
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						return "1", nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: `{ user { id name hi: greeting(prefix: "Hi") hello: greeting(prefix: "Hello") __typename } }`,
+	})
+	if len(result.Errors) != 0 {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+	expected := map[string]any{
+		"user": map[string]any{
+			"id":         "1",
+			"name":       "Name 1",
+			"hi":         "Hi 1",
+			"hello":      "Hello 1",
+			"__typename": "User",
+		},
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.Data))
+	}
+	expectedCalls := [][]string{{"hello", "hi", "name"}}
+	if !reflect.DeepEqual(calls, expectedCalls) {
+		t.Fatalf("Expected batch calls %v, got %v", expectedCalls, calls)
+	}
+
+	fail = true
+	result = graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: `{ user { id name } }`,
+	})
+	if len(result.Errors) != 1 || result.Errors[0].Message != "backend unavailable" {
+		t.Fatalf("Expected backend unavailable error, got %v", result.Errors)
+	}
+	expected = map[string]any{
+		"user": map[string]any{
+			"id":   "1",
+			"name": nil,
+		},
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.Data))
+	}
+}
@@ -0,0 +1,226 @@
+// Package bench provides representative schemas and operations for benchmarking
+// the executor along with helpers to run them as benchmarks and to assert the
+// number of allocations per execution. Consumers can run the cases against their
+// own build to measure performance changes:
+//
+//	func BenchmarkExecute(b *testing.B) {
+//		for _, c := range bench.Cases() {
+//			b.Run(c.Name, func(b *testing.B) { bench.Run(b, c) })
+//		}
+//	}
+package bench
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/language/ast"
+	"github.com/sprucehealth/graphql/language/parser"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+const (
+	// WideFields is the number of fields of the object in the wide case.
+	WideFields = 100
+	// DeepLevels is the nesting depth of the deep case.
+	DeepLevels = 50
+	// ListItems is the number of items of the list in the list case.
+	ListItems = 10000
+)
+
+// Case is an operation to execute against a schema.
+type Case struct {
+	Name      string
+	Schema    graphql.Schema
+	Query     string
+	Variables map[string]any
+	Root      any
+}
+
+// Cases returns the representative cases: the star wars schema, an object with
+// many fields, deeply nested objects, and a list with many items.
+func Cases() []Case {
+	return []Case{
+		StarWarsCase(),
+		WideCase(),
+		DeepCase(),
+		ListCase(),
+	}
+}
+
+// StarWarsCase queries the heroes of the star wars schema with their friends.
+func StarWarsCase() Case {
+	return Case{
+		Name:   "StarWars",
+		Schema: testutil.StarWarsSchema,
+		Query: `
+			query HeroAndFriends($episode: Episode) {
+				hero(episode: $episode) {
+					id
+					name
+					friends {
+						id
+						name
+						appearsIn
+						friends {
+							name
+						}
+					}
+				}
+			}
+		`,
+		Variables: map[string]any{"episode": "EMPIRE"},
+	}
+}
+
+// WideCase selects every field of an object with WideFields fields.
+func WideCase() Case {
+	fields := graphql.Fields{}
+	root := make(map[string]any, WideFields)
+	var query strings.Builder
+	query.WriteString("{ wide {")
+	for i := 0; i < WideFields; i++ {
+		name := fmt.Sprintf("field%d", i)
+		fields[name] = &graphql.Field{Type: graphql.String}
+		root[name] = name
+		query.WriteString(" " + name)
+	}
+	query.WriteString(" } }")
+	wideType := graphql.NewObject(graphql.ObjectConfig{
+		Name:   "Wide",
+		Fields: fields,
+	})
+	return Case{
+		Name:   "Wide",
+		Schema: mustSchema(wideType, "wide"),
+		Query:  query.String(),
+		Root:   map[string]any{"wide": root},
+	}
+}
+
+// DeepCase selects an object nested DeepLevels levels deep.
+func DeepCase() Case {
+	nodeType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Node",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.Int},
+		},
+	})
+	nodeType.AddFieldConfig("child", &graphql.Field{Type: nodeType})
+
+	var root map[string]any
+	for i := DeepLevels; i >= 0; i-- {
+		node := map[string]any{"id": i}
+		if root != nil {
+			node["child"] = root
+		}
+		root = node
+	}
+	query := "{ deep { id" + strings.Repeat(" child { id", DeepLevels) + strings.Repeat(" }", DeepLevels) + " } }"
+	return Case{
+		Name:   "Deep",
+		Schema: mustSchema(nodeType, "deep"),
+		Query:  query,
+		Root:   map[string]any{"deep": root},
+	}
+}
+
+// ListCase selects a list of ListItems objects.
+func ListCase() Case {
+	itemType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Item",
+		Fields: graphql.Fields{
+			"id":    &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"name":  &graphql.Field{Type: graphql.String},
+			"price": &graphql.Field{Type: graphql.Float},
+		},
+	})
+	items := make([]any, ListItems)
+	for i := range items {
+		items[i] = map[string]any{
+			"id":    fmt.Sprintf("item%d", i),
+			"name":  "Item",
+			"price": float64(i) / 100,
+		}
+	}
+	return Case{
+		Name:   "List",
+		Schema: mustSchema(graphql.NewList(itemType), "list"),
+		Query:  "{ list { id name price } }",
+		Root:   map[string]any{"list": items},
+	}
+}
+
+func mustSchema(fieldType graphql.Output, fieldName string) graphql.Schema {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				fieldName: &graphql.Field{Type: fieldType},
+			},
+		}),
+	})
+	if err != nil {
+		panic(err)
+	}
+	return schema
+}
+
+// Parse parses and validates the query of the case.
+func (c Case) Parse(tb testing.TB) *ast.Document {
+	tb.Helper()
+	doc, err := parser.Parse(parser.ParseParams{Source: c.Query})
+	if err != nil {
+		tb.Fatalf("%s: %s", c.Name, err)
+	}
+	if r := graphql.ValidateDocument(&c.Schema, doc, nil); !r.IsValid {
+		tb.Fatalf("%s: %+v", c.Name, r.Errors)
+	}
+	return doc
+}
+
+// Execute executes the parsed query of the case and fails if there are errors.
+func (c Case) Execute(tb testing.TB, doc *ast.Document) *graphql.Result {
+	result := graphql.Execute(context.Background(), graphql.ExecuteParams{
+		Schema: c.Schema,
+		Root:   c.Root,
+		AST:    doc,
+		Args:   c.Variables,
+	})
+	if len(result.Errors) != 0 {
+		tb.Fatalf("%s: %+v", c.Name, result.Errors)
+	}
+	return result
+}
+
+// Run benchmarks the execution of the case. The query is parsed and validated
+// once before the timer starts so only execution is measured.
+func Run(b *testing.B, c Case) {
+	doc := c.Parse(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Execute(b, doc)
+	}
+}
+
+// AllocsPerRun returns the average number of allocations to execute the case.
+func AllocsPerRun(tb testing.TB, c Case, runs int) float64 {
+	doc := c.Parse(tb)
+	return testing.AllocsPerRun(runs, func() {
+		c.Execute(tb, doc)
+	})
+}
+
+// AssertAllocs fails if executing the case allocates more than max times on
+// average. It's meant to catch regressions in CI so the maximum should leave some
+// headroom over the current number of allocations.
+func AssertAllocs(tb testing.TB, c Case, max float64) {
+	tb.Helper()
+	if allocs := AllocsPerRun(tb, c, 10); allocs > max {
+		tb.Errorf("%s: %.0f allocations per run exceeds the maximum of %.0f", c.Name, allocs, max)
+	}
+}
@@ -0,0 +1,38 @@
+package bench
+
+import (
+	"testing"
+)
+
+func BenchmarkExecute(b *testing.B) {
+	for _, c := range Cases() {
+		b.Run(c.Name, func(b *testing.B) { Run(b, c) })
+	}
+}
+
+func TestCases(t *testing.T) {
+	for _, c := range Cases() {
+		result := c.Execute(t, c.Parse(t))
+		if result.Data == nil {
+			t.Fatalf("%s: expected data", c.Name)
+		}
+	}
+	result := ListCase().Execute(t, ListCase().Parse(t))
+	if items := result.Data.(map[string]any)["list"].([]any); len(items) != ListItems {
+		t.Fatalf("Expected %d items, got %d", ListItems, len(items))
+	}
+}
+
+// TestAllocs guards against allocation regressions. The maximums leave about 25%
+// headroom over the allocations at the time they were set.
+func TestAllocs(t *testing.T) {
+	maxAllocs := map[string]float64{
+		"StarWars": 215,
+		"Wide":     430,
+		"Deep":     740,
+		"List":     200000,
+	}
+	for _, c := range Cases() {
+		AssertAllocs(t, c, maxAllocs[c.Name])
+	}
+}
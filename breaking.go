@@ -0,0 +1,320 @@
+package graphql
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sprucehealth/graphql/language/ast"
+	"github.com/sprucehealth/graphql/language/parser"
+	"github.com/sprucehealth/graphql/language/printer"
+	"github.com/sprucehealth/graphql/language/source"
+)
+
+// SchemaChangeType is the category of a change between two schemas.
+type SchemaChangeType string
+
+// Schema change types
+const (
+	SchemaChangeTypeRemoved              SchemaChangeType = "TYPE_REMOVED"
+	SchemaChangeTypeChangedKind          SchemaChangeType = "TYPE_CHANGED_KIND"
+	SchemaChangeFieldRemoved             SchemaChangeType = "FIELD_REMOVED"
+	SchemaChangeFieldChangedType         SchemaChangeType = "FIELD_CHANGED_TYPE"
+	SchemaChangeArgRemoved               SchemaChangeType = "ARG_REMOVED"
+	SchemaChangeArgChangedType           SchemaChangeType = "ARG_CHANGED_TYPE"
+	SchemaChangeRequiredArgAdded         SchemaChangeType = "REQUIRED_ARG_ADDED"
+	SchemaChangeOptionalArgAdded         SchemaChangeType = "OPTIONAL_ARG_ADDED"
+	SchemaChangeRequiredInputFieldAdded  SchemaChangeType = "REQUIRED_INPUT_FIELD_ADDED"
+	SchemaChangeEnumValueRemoved         SchemaChangeType = "ENUM_VALUE_REMOVED"
+	SchemaChangeEnumValueAdded           SchemaChangeType = "ENUM_VALUE_ADDED"
+	SchemaChangeUnionMemberRemoved       SchemaChangeType = "UNION_MEMBER_REMOVED"
+	SchemaChangeUnionMemberAdded         SchemaChangeType = "UNION_MEMBER_ADDED"
+	SchemaChangeInterfaceRemovedFromType SchemaChangeType = "INTERFACE_REMOVED_FROM_OBJECT"
+)
+
+// SchemaChange describes a single change between a previous schema and the current one.
+type SchemaChange struct {
+	Type        SchemaChangeType
+	Description string
+}
+
+// FindBreakingChanges compares a schema against a previous version of the schema
+// provided in the schema definition language. It returns the changes that will
+// break existing clients, and dangerous changes that are compatible but may
+// change the behavior of clients (e.g. a new enum value).
+func FindBreakingChanges(previousSDL string, schema *Schema) (breaking, dangerous []SchemaChange, err error) {
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: source.New("Previous schema", previousSDL),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	c := &schemaComparer{schema: schema}
+	for _, def := range doc.Definitions {
+		c.compareDefinition(def)
+	}
+	return c.breaking, c.dangerous, nil
+}
+
+// CheckBreakingChanges returns an error listing all breaking changes between a
+// previous version of the schema, provided in the schema definition language,
+// and the current schema. It's intended to be called at startup so services can
+// refuse to run with accidental breaking changes.
+func CheckBreakingChanges(previousSDL string, schema *Schema) error {
+	breaking, _, err := FindBreakingChanges(previousSDL, schema)
+	if err != nil {
+		return err
+	}
+	if len(breaking) == 0 {
+		return nil
+	}
+	descriptions := make([]string, len(breaking))
+	for i, c := range breaking {
+		descriptions[i] = c.Description
+	}
+	return errors.New("Schema contains breaking changes:\n" + strings.Join(descriptions, "\n"))
+}
+
+type schemaComparer struct {
+	schema    *Schema
+	breaking  []SchemaChange
+	dangerous []SchemaChange
+}
+
+func (c *schemaComparer) addBreaking(typ SchemaChangeType, format string, args ...any) {
+	c.breaking = append(c.breaking, SchemaChange{Type: typ, Description: fmt.Sprintf(format, args...)})
+}
+
+func (c *schemaComparer) addDangerous(typ SchemaChangeType, format string, args ...any) {
+	c.dangerous = append(c.dangerous, SchemaChange{Type: typ, Description: fmt.Sprintf(format, args...)})
+}
+
+func (c *schemaComparer) compareDefinition(def ast.Node) {
+	var name, kind string
+	switch def := def.(type) {
+	case *ast.ScalarDefinition:
+		name, kind = def.Name.Value, TypeKindScalar
+	case *ast.ObjectDefinition:
+		name, kind = def.Name.Value, TypeKindObject
+	case *ast.InterfaceDefinition:
+		name, kind = def.Name.Value, TypeKindInterface
+	case *ast.UnionDefinition:
+		name, kind = def.Name.Value, TypeKindUnion
+	case *ast.EnumDefinition:
+		name, kind = def.Name.Value, TypeKindEnum
+	case *ast.InputObjectDefinition:
+		name, kind = def.Name.Value, TypeKindInputObject
+	default:
+		return
+	}
+	newType := c.schema.Type(name)
+	if newType == nil {
+		c.addBreaking(SchemaChangeTypeRemoved, "%s was removed.", name)
+		return
+	}
+	if newKind := typeKind(newType); newKind != kind {
+		c.addBreaking(SchemaChangeTypeChangedKind, "%s changed from %s to %s.", name, kind, newKind)
+		return
+	}
+	switch def := def.(type) {
+	case *ast.ObjectDefinition:
+		obj := newType.(*Object)
+		c.compareFields(name, def.Fields, obj.Fields())
+		for _, iface := range def.Interfaces {
+			found := false
+			for _, newIface := range obj.Interfaces() {
+				if newIface.Name() == iface.Name.Value {
+					found = true
+					break
+				}
+			}
+			if !found {
+				c.addBreaking(SchemaChangeInterfaceRemovedFromType, "%s no longer implements interface %s.", name, iface.Name.Value)
+			}
+		}
+	case *ast.InterfaceDefinition:
+		c.compareFields(name, def.Fields, newType.(*Interface).Fields())
+	case *ast.UnionDefinition:
+		newTypes := newType.(*Union).Types()
+		oldTypes := make(map[string]struct{}, len(def.Types))
+		for _, t := range def.Types {
+			oldTypes[t.Name.Value] = struct{}{}
+		}
+		newNames := make(map[string]struct{}, len(newTypes))
+		for _, t := range newTypes {
+			newNames[t.Name()] = struct{}{}
+			if _, ok := oldTypes[t.Name()]; !ok {
+				c.addDangerous(SchemaChangeUnionMemberAdded, "%s was added to union type %s.", t.Name(), name)
+			}
+		}
+		for _, t := range def.Types {
+			if _, ok := newNames[t.Name.Value]; !ok {
+				c.addBreaking(SchemaChangeUnionMemberRemoved, "%s was removed from union type %s.", t.Name.Value, name)
+			}
+		}
+	case *ast.EnumDefinition:
+		newValues := newType.(*Enum).Values()
+		oldValues := make(map[string]struct{}, len(def.Values))
+		for _, v := range def.Values {
+			oldValues[v.Name.Value] = struct{}{}
+		}
+		newNames := make(map[string]struct{}, len(newValues))
+		for _, v := range newValues {
+			newNames[v.Name] = struct{}{}
+			if _, ok := oldValues[v.Name]; !ok {
+				c.addDangerous(SchemaChangeEnumValueAdded, "%s was added to enum type %s.", v.Name, name)
+			}
+		}
+		for _, v := range def.Values {
+			if _, ok := newNames[v.Name.Value]; !ok {
+				c.addBreaking(SchemaChangeEnumValueRemoved, "%s was removed from enum type %s.", v.Name.Value, name)
+			}
+		}
+	case *ast.InputObjectDefinition:
+		newFields := newType.(*InputObject).Fields()
+		oldFields := make(map[string]*ast.InputValueDefinition, len(def.Fields))
+		for _, f := range def.Fields {
+			oldFields[f.Name.Value] = f
+			newField, ok := newFields[f.Name.Value]
+			if !ok {
+				c.addBreaking(SchemaChangeFieldRemoved, "%s.%s was removed.", name, f.Name.Value)
+				continue
+			}
+			if !isSafeInputTypeChange(f.Type, newField.Type) {
+				c.addBreaking(SchemaChangeFieldChangedType, "%s.%s changed type from %s to %s.", name, f.Name.Value, printer.Print(f.Type), newField.Type)
+			}
+		}
+		newFieldNames := make([]string, 0, len(newFields))
+		for fieldName := range newFields {
+			newFieldNames = append(newFieldNames, fieldName)
+		}
+		sort.Strings(newFieldNames)
+		for _, fieldName := range newFieldNames {
+			if _, ok := oldFields[fieldName]; ok {
+				continue
+			}
+			f := newFields[fieldName]
+			if _, ok := f.Type.(*NonNull); ok && f.DefaultValue == nil {
+				c.addBreaking(SchemaChangeRequiredInputFieldAdded, "A required field %s on input type %s was added.", fieldName, name)
+			}
+		}
+	}
+}
+
+func (c *schemaComparer) compareFields(typeName string, oldFields []*ast.FieldDefinition, newFields FieldDefinitionMap) {
+	for _, f := range oldFields {
+		newField, ok := newFields[f.Name.Value]
+		if !ok {
+			c.addBreaking(SchemaChangeFieldRemoved, "%s.%s was removed.", typeName, f.Name.Value)
+			continue
+		}
+		if !isSafeOutputTypeChange(f.Type, newField.Type) {
+			c.addBreaking(SchemaChangeFieldChangedType, "%s.%s changed type from %s to %s.", typeName, f.Name.Value, printer.Print(f.Type), newField.Type)
+		}
+		oldArgs := make(map[string]*ast.InputValueDefinition, len(f.Arguments))
+		for _, arg := range f.Arguments {
+			oldArgs[arg.Name.Value] = arg
+			var newArg *Argument
+			for _, a := range newField.Args {
+				if a.PrivateName == arg.Name.Value {
+					newArg = a
+					break
+				}
+			}
+			if newArg == nil {
+				c.addBreaking(SchemaChangeArgRemoved, "%s.%s(%s:) was removed.", typeName, f.Name.Value, arg.Name.Value)
+				continue
+			}
+			if !isSafeInputTypeChange(arg.Type, newArg.Type) {
+				c.addBreaking(SchemaChangeArgChangedType, "%s.%s(%s:) changed type from %s to %s.", typeName, f.Name.Value, arg.Name.Value, printer.Print(arg.Type), newArg.Type)
+			}
+		}
+		newArgs := append([]*Argument(nil), newField.Args...)
+		sort.Slice(newArgs, func(i, j int) bool {
+			return newArgs[i].PrivateName < newArgs[j].PrivateName
+		})
+		for _, arg := range newArgs {
+			if _, ok := oldArgs[arg.PrivateName]; ok {
+				continue
+			}
+			if _, ok := arg.Type.(*NonNull); ok && arg.DefaultValue == nil {
+				c.addBreaking(SchemaChangeRequiredArgAdded, "A required arg %s on %s.%s was added.", arg.PrivateName, typeName, f.Name.Value)
+			} else {
+				c.addDangerous(SchemaChangeOptionalArgAdded, "An optional arg %s on %s.%s was added.", arg.PrivateName, typeName, f.Name.Value)
+			}
+		}
+	}
+}
+
+func typeKind(t Type) string {
+	switch t.(type) {
+	case *Scalar:
+		return TypeKindScalar
+	case *Object:
+		return TypeKindObject
+	case *Interface:
+		return TypeKindInterface
+	case *Union:
+		return TypeKindUnion
+	case *Enum:
+		return TypeKindEnum
+	case *InputObject:
+		return TypeKindInputObject
+	case *List:
+		return TypeKindList
+	case *NonNull:
+		return TypeKindNonNull
+	}
+	return ""
+}
+
+// isSafeOutputTypeChange returns true if clients expecting the old type of a
+// field can handle the new type. Making a field non-null is safe.
+func isSafeOutputTypeChange(oldType ast.Type, newType Type) bool {
+	switch oldType := oldType.(type) {
+	case *ast.NonNull:
+		newType, ok := newType.(*NonNull)
+		return ok && isSafeOutputTypeChange(oldType.Type, newType.OfType)
+	case *ast.List:
+		if nn, ok := newType.(*NonNull); ok {
+			newType = nn.OfType
+		}
+		newType, ok := newType.(*List)
+		return ok && isSafeOutputTypeChange(oldType.Type, newType.OfType)
+	case *ast.Named:
+		if nn, ok := newType.(*NonNull); ok {
+			newType = nn.OfType
+		}
+		return isSameNamedType(oldType, newType)
+	}
+	return false
+}
+
+// isSafeInputTypeChange returns true if values valid for the old type of an
+// argument or input field are valid for the new type. Making an input nullable is safe.
+func isSafeInputTypeChange(oldType ast.Type, newType Type) bool {
+	if nn, ok := newType.(*NonNull); ok {
+		oldType, ok := oldType.(*ast.NonNull)
+		return ok && isSafeInputTypeChange(oldType.Type, nn.OfType)
+	}
+	if nn, ok := oldType.(*ast.NonNull); ok {
+		oldType = nn.Type
+	}
+	switch oldType := oldType.(type) {
+	case *ast.List:
+		newType, ok := newType.(*List)
+		return ok && isSafeInputTypeChange(oldType.Type, newType.OfType)
+	case *ast.Named:
+		return isSameNamedType(oldType, newType)
+	}
+	return false
+}
+
+func isSameNamedType(oldType *ast.Named, newType Type) bool {
+	switch newType.(type) {
+	case *List, *NonNull:
+		return false
+	}
+	return oldType.Name != nil && newType != nil && oldType.Name.Value == newType.Name()
+}
@@ -0,0 +1,96 @@
+package graphql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+func TestFindBreakingChanges(t *testing.T) {
+	colorType := graphql.NewEnum(graphql.EnumConfig{
+		Name: "Color",
+		Values: graphql.EnumValueConfigMap{
+			"RED":  &graphql.EnumValueConfig{Value: "RED"},
+			"BLUE": &graphql.EnumValueConfig{Value: "BLUE"},
+		},
+	})
+	filterType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "Filter",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"name":  &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"limit": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.Int)},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"name": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"age":  &graphql.Field{Type: graphql.String},
+				"color": &graphql.Field{
+					Type: colorType,
+					Args: graphql.FieldConfigArgument{
+						"a":      &graphql.ArgumentConfig{Type: graphql.String},
+						"b":      &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+						"filter": &graphql.ArgumentConfig{Type: filterType},
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	previous := `
+type Query {
+  name: String
+  age: Int
+  removed: String
+  color(a: String!, c: Int): Color
+}
+
+enum Color {
+  RED
+  GREEN
+}
+
+input Filter {
+  name: String
+}
+
+scalar Removed
+`
+	breaking, dangerous, err := graphql.FindBreakingChanges(previous, &schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedBreaking := []graphql.SchemaChange{
+		{Type: graphql.SchemaChangeFieldChangedType, Description: "Query.age changed type from Int to String."},
+		{Type: graphql.SchemaChangeFieldRemoved, Description: "Query.removed was removed."},
+		{Type: graphql.SchemaChangeArgRemoved, Description: "Query.color(c:) was removed."},
+		{Type: graphql.SchemaChangeRequiredArgAdded, Description: "A required arg b on Query.color was added."},
+		{Type: graphql.SchemaChangeEnumValueRemoved, Description: "GREEN was removed from enum type Color."},
+		{Type: graphql.SchemaChangeRequiredInputFieldAdded, Description: "A required field limit on input type Filter was added."},
+		{Type: graphql.SchemaChangeTypeRemoved, Description: "Removed was removed."},
+	}
+	if !reflect.DeepEqual(expectedBreaking, breaking) {
+		t.Fatalf("Unexpected breaking changes, Diff: %v", testutil.Diff(expectedBreaking, breaking))
+	}
+	expectedDangerous := []graphql.SchemaChange{
+		{Type: graphql.SchemaChangeOptionalArgAdded, Description: "An optional arg filter on Query.color was added."},
+		{Type: graphql.SchemaChangeEnumValueAdded, Description: "BLUE was added to enum type Color."},
+	}
+	if !reflect.DeepEqual(expectedDangerous, dangerous) {
+		t.Fatalf("Unexpected dangerous changes, Diff: %v", testutil.Diff(expectedDangerous, dangerous))
+	}
+
+	if err := graphql.CheckBreakingChanges(previous, &schema); err == nil {
+		t.Fatal("Expected breaking changes error")
+	}
+	if err := graphql.CheckBreakingChanges(`type Query { name: String }`, &schema); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
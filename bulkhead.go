@@ -0,0 +1,129 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/sprucehealth/graphql/gqlerrors"
+	"github.com/sprucehealth/graphql/language/ast"
+)
+
+var (
+	// ErrBulkheadFull is the error of a field whose resolver can't run because the
+	// bulkhead of its type has no free slot and its queue is full.
+	ErrBulkheadFull = errors.New(gqlerrors.CodeBulkheadFull.Message())
+	// ErrBulkheadTimeout is the error of a field whose resolver waited longer than
+	// the timeout of the bulkhead of its type for a free slot.
+	ErrBulkheadTimeout = errors.New(gqlerrors.CodeBulkheadTimeout.Message())
+)
+
+// BulkheadConfig is the configuration of a Bulkhead.
+type BulkheadConfig struct {
+	// MaxConcurrent is the maximum number of resolvers that run at once. It must
+	// be greater than 0.
+	MaxConcurrent int
+	// MaxQueue is the maximum number of resolvers that wait for a free slot. Once
+	// the queue is full resolvers fail immediately with ErrBulkheadFull. If 0
+	// resolvers never wait and a negative value doesn't limit the queue.
+	MaxQueue int
+	// Timeout if greater than 0 is the maximum time a resolver waits for a free
+	// slot before it fails with ErrBulkheadTimeout. Waiting always stops when the
+	// context of the request is done.
+	Timeout time.Duration
+}
+
+// Bulkhead limits the number of resolvers of an object type that run at once
+// across all requests (e.g. to protect a fragile downstream service from wide
+// queries). It's set with ObjectConfig.Bulkhead and may be shared by several types
+// that call the same service. Resolvers that can't run fail with a
+// RESOURCE_EXHAUSTED field error.
+//
+// A slot is held while the resolver runs, including a func() any it returns. Work
+// the resolver starts in other goroutines (e.g. the producer of StreamList) isn't
+// limited once the resolver returns.
+type Bulkhead struct {
+	cfg     BulkheadConfig
+	slots   chan struct{}
+	waiting atomic.Int64
+}
+
+// NewBulkhead returns a bulkhead with the configuration. It panics if
+// MaxConcurrent isn't greater than 0.
+func NewBulkhead(cfg BulkheadConfig) *Bulkhead {
+	if cfg.MaxConcurrent <= 0 {
+		panic("graphql: BulkheadConfig.MaxConcurrent must be greater than 0")
+	}
+	return &Bulkhead{
+		cfg:   cfg,
+		slots: make(chan struct{}, cfg.MaxConcurrent),
+	}
+}
+
+// Acquire waits for a free slot and returns a function that releases it.
+func (b *Bulkhead) Acquire(ctx context.Context) (release func(), err error) {
+	select {
+	case b.slots <- struct{}{}:
+		return b.release, nil
+	default:
+	}
+	if b.cfg.MaxQueue == 0 {
+		return nil, ErrBulkheadFull
+	}
+	if n := b.waiting.Add(1); b.cfg.MaxQueue > 0 && n > int64(b.cfg.MaxQueue) {
+		b.waiting.Add(-1)
+		return nil, ErrBulkheadFull
+	}
+	defer b.waiting.Add(-1)
+	var timeout <-chan time.Time
+	if b.cfg.Timeout > 0 {
+		t := time.NewTimer(b.cfg.Timeout)
+		defer t.Stop()
+		timeout = t.C
+	}
+	select {
+	case b.slots <- struct{}{}:
+		return b.release, nil
+	case <-timeout:
+		return nil, ErrBulkheadTimeout
+	case <-ctx.Done():
+		return nil, context.Cause(ctx)
+	}
+}
+
+func (b *Bulkhead) release() {
+	<-b.slots
+}
+
+// acquireBulkhead acquires a slot of the bulkhead of the type returning a
+// RESOURCE_EXHAUSTED error for the field if none is available.
+func acquireBulkhead(ctx context.Context, parentType *Object, fieldASTs []*ast.Field, path []string) (func(), error) {
+	release, err := parentType.Bulkhead.Acquire(ctx)
+	if err == nil {
+		return release, nil
+	}
+	e := gqlerrors.NewError(
+		gqlerrors.ErrorTypeResourceExhausted,
+		err.Error(),
+		FieldASTsToNodeASTs(fieldASTs),
+		"",
+		nil,
+		nil,
+		err,
+	)
+	switch {
+	case errors.Is(err, ErrBulkheadFull):
+		e.Code = gqlerrors.CodeBulkheadFull
+	case errors.Is(err, ErrBulkheadTimeout):
+		e.Code = gqlerrors.CodeBulkheadTimeout
+	}
+	fe := gqlerrors.FormatError(e)
+	if path != nil {
+		fe.Path = make([]any, len(path))
+		for i, p := range path {
+			fe.Path[i] = p
+		}
+	}
+	return nil, fe
+}
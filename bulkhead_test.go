@@ -0,0 +1,158 @@
+package graphql_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/gqlerrors"
+)
+
+func TestBulkhead(t *testing.T) {
+	started := make(chan struct{}, 1)
+	newSchema := func(bulkhead *graphql.Bulkhead, unblock chan struct{}) graphql.Schema {
+		billing := graphql.NewObject(graphql.ObjectConfig{
+			Name:     "Billing",
+			Bulkhead: bulkhead,
+			Fields: graphql.Fields{
+				"balance": &graphql.Field{
+					Type: graphql.Int,
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						if p.Source.(string) == "slow" {
+							started <- struct{}{}
+							<-unblock
+						}
+						return 100, nil
+					},
+				},
+			},
+		})
+		schema, err := graphql.NewSchema(graphql.SchemaConfig{
+			Query: graphql.NewObject(graphql.ObjectConfig{
+				Name: "Query",
+				Fields: graphql.Fields{
+					"billing": &graphql.Field{
+						Type: billing,
+						Args: graphql.FieldConfigArgument{
+							"speed": &graphql.ArgumentConfig{Type: graphql.String},
+						},
+						Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+							return p.Args["speed"], nil
+						},
+					},
+				},
+			}),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return schema
+	}
+	do := func(schema graphql.Schema, speed string) *graphql.Result {
+		return graphql.Do(context.Background(), graphql.Params{
+			Schema:        schema,
+			RequestString: `query ($speed: String) { billing(speed: $speed) { balance } }`,
+			VariableValues: map[string]any{
+				"speed": speed,
+			},
+		})
+	}
+
+	for _, c := range []struct {
+		name string
+		cfg  graphql.BulkheadConfig
+		// expected is the error of a request made while a slow request holds the
+		// only slot or empty if it waits for the slot.
+		expected string
+	}{
+		{name: "full", cfg: graphql.BulkheadConfig{MaxConcurrent: 1}, expected: graphql.ErrBulkheadFull.Error()},
+		{name: "timeout", cfg: graphql.BulkheadConfig{MaxConcurrent: 1, MaxQueue: 1, Timeout: 10 * time.Millisecond}, expected: graphql.ErrBulkheadTimeout.Error()},
+		{name: "queue", cfg: graphql.BulkheadConfig{MaxConcurrent: 1, MaxQueue: -1}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			unblock := make(chan struct{})
+			schema := newSchema(graphql.NewBulkhead(c.cfg), unblock)
+			slow := make(chan *graphql.Result)
+			go func() { slow <- do(schema, "slow") }()
+			<-started
+
+			fast := make(chan *graphql.Result)
+			go func() { fast <- do(schema, "fast") }()
+			if c.expected == "" {
+				select {
+				case r := <-fast:
+					t.Fatalf("Expected the request to wait for the slot, got %+v", r)
+				case <-time.After(10 * time.Millisecond):
+				}
+				close(unblock)
+				if r := <-fast; len(r.Errors) != 0 {
+					t.Fatalf("Unexpected errors %+v", r.Errors)
+				}
+			} else {
+				r := <-fast
+				if len(r.Errors) != 1 || r.Errors[0].Message != c.expected || r.Errors[0].Type != gqlerrors.ErrorTypeResourceExhausted {
+					t.Fatalf("Expected a %q error, got %+v", c.expected, r.Errors)
+				}
+				if balance := r.Data.(map[string]any)["billing"].(map[string]any)["balance"]; balance != nil {
+					t.Fatalf("Expected a null balance, got %v", balance)
+				}
+				close(unblock)
+			}
+			if r := <-slow; len(r.Errors) != 0 {
+				t.Fatalf("Unexpected errors %+v", r.Errors)
+			}
+		})
+	}
+}
+
+func TestBulkhead_FuncResult(t *testing.T) {
+	bulkhead := graphql.NewBulkhead(graphql.BulkheadConfig{MaxConcurrent: 1})
+	var acquireErr error
+	billing := graphql.NewObject(graphql.ObjectConfig{
+		Name:     "Billing",
+		Bulkhead: bulkhead,
+		Fields: graphql.Fields{
+			"balance": &graphql.Field{
+				Type: graphql.Int,
+				Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+					return func() any {
+						// The slot is still held by the field
+						release, err := bulkhead.Acquire(ctx)
+						if err == nil {
+							release()
+						}
+						acquireErr = err
+						return 100
+					}, nil
+				},
+			},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"billing": &graphql.Field{
+					Type: billing,
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						return struct{}{}, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: `{ billing { balance } }`,
+	})
+	if len(result.Errors) != 0 {
+		t.Fatalf("Unexpected errors %+v", result.Errors)
+	}
+	if acquireErr != graphql.ErrBulkheadFull {
+		t.Fatalf("Expected the slot to be held while the func is called, got %v", acquireErr)
+	}
+}
@@ -0,0 +1,155 @@
+package graphql
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// CacheControlExtensionKey is the key in Result.Extensions under which the cache
+// hints are returned when ExecuteParams.CacheControl is set.
+const CacheControlExtensionKey = "cacheControl"
+
+// CacheScope is the scope of a cache hint.
+type CacheScope string
+
+const (
+	// CacheScopePublic responses may be cached by shared caches.
+	CacheScopePublic CacheScope = "PUBLIC"
+	// CacheScopePrivate responses are specific to a user and may only be cached privately.
+	CacheScopePrivate CacheScope = "PRIVATE"
+)
+
+// CacheControlScopeEnum is the type of the scope argument of the @cacheControl directive.
+var CacheControlScopeEnum = NewEnum(EnumConfig{
+	Name: "CacheControlScope",
+	Values: EnumValueConfigMap{
+		string(CacheScopePublic):  &EnumValueConfig{Value: string(CacheScopePublic)},
+		string(CacheScopePrivate): &EnumValueConfig{Value: string(CacheScopePrivate)},
+	},
+})
+
+// CacheControlDirective is used on field definitions to provide a cache hint for
+// the field. It's not part of SpecifiedDirectives and must be added to the schema
+// directives to be included in introspection.
+var CacheControlDirective = NewDirective(DirectiveConfig{
+	Name:        "cacheControl",
+	Description: "Provides a cache hint for the field.",
+	Locations: []string{
+		DirectiveLocationFieldDefinition,
+	},
+	Args: FieldConfigArgument{
+		"maxAge": &ArgumentConfig{
+			Type:        Int,
+			Description: "Maximum age in seconds that the field may be cached.",
+		},
+		"scope": &ArgumentConfig{
+			Type:        CacheControlScopeEnum,
+			Description: "Scope of the cache. Defaults to PUBLIC.",
+		},
+	},
+})
+
+// CacheHint is the cache hint of a single field in the response.
+type CacheHint struct {
+	Path   []string   `json:"path"`
+	MaxAge int        `json:"maxAge"`
+	Scope  CacheScope `json:"scope,omitempty"`
+}
+
+// CachePolicy is the overall cache policy of a response.
+type CachePolicy struct {
+	// MaxAge is the minimum max age of all hints in seconds.
+	MaxAge int
+	// Scope is private if any hint is private.
+	Scope CacheScope
+}
+
+// HeaderValue returns the value for a Cache-Control header or an empty
+// string if the response should not be cached.
+func (p *CachePolicy) HeaderValue() string {
+	if p == nil || p.MaxAge <= 0 {
+		return ""
+	}
+	return "max-age=" + strconv.Itoa(p.MaxAge) + ", " + p.scope()
+}
+
+func (p *CachePolicy) scope() string {
+	if p.Scope == CacheScopePrivate {
+		return "private"
+	}
+	return "public"
+}
+
+// cacheControl accumulates cache hints during execution.
+type cacheControl struct {
+	defaultMaxAge int
+
+	mu     sync.Mutex
+	hints  []CacheHint
+	policy CachePolicy
+	hinted bool
+}
+
+// fieldHint records the cache hint for a field. Fields without a @cacheControl
+// directive get the default max age if they're root fields or return a composite
+// type. Other fields inherit the policy of their parent so are not recorded.
+func (c *cacheControl) fieldHint(schema Schema, parentType *Object, fieldDef *FieldDefinition, path []string) {
+	// Introspection fields don't affect the cache policy.
+	if strings.HasPrefix(fieldDef.Name, "__") {
+		return
+	}
+	hint := CacheHint{MaxAge: c.defaultMaxAge}
+	var found bool
+	for _, d := range fieldDef.Directives {
+		if d.Name == nil || d.Name.Value != CacheControlDirective.Name {
+			continue
+		}
+		found = true
+		args := getArgumentValues(CacheControlDirective.Args, d.Arguments, nil)
+		if maxAge, ok := args["maxAge"].(int); ok {
+			hint.MaxAge = maxAge
+		}
+		if scope, ok := args["scope"].(string); ok {
+			hint.Scope = CacheScope(scope)
+		}
+		break
+	}
+	if !found && !isCacheRootType(schema, parentType) {
+		switch GetNamed(fieldDef.Type).(type) {
+		case *Object, *Interface, *Union:
+		default:
+			return
+		}
+	}
+	hint.Path = slices.Clone(path)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.hints = append(c.hints, hint)
+	if !c.hinted || hint.MaxAge < c.policy.MaxAge {
+		c.policy.MaxAge = hint.MaxAge
+	}
+	c.hinted = true
+	if hint.Scope == CacheScopePrivate {
+		c.policy.Scope = CacheScopePrivate
+	}
+}
+
+func isCacheRootType(schema Schema, t *Object) bool {
+	return t == schema.QueryType() || t == schema.MutationType()
+}
+
+func (c *cacheControl) result() (*CachePolicy, map[string]any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	policy := c.policy
+	if policy.Scope == "" {
+		policy.Scope = CacheScopePublic
+	}
+	return &policy, map[string]any{
+		"version": 1,
+		"hints":   slices.Clone(c.hints),
+	}
+}
@@ -0,0 +1,123 @@
+package graphql_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/language/ast"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+func cacheControlDirective(maxAge string, scope string) []*ast.Directive {
+	d := &ast.Directive{Name: &ast.Name{Value: "cacheControl"}}
+	if maxAge != "" {
+		d.Arguments = append(d.Arguments, &ast.Argument{
+			Name:  &ast.Name{Value: "maxAge"},
+			Value: &ast.IntValue{Value: maxAge},
+		})
+	}
+	if scope != "" {
+		d.Arguments = append(d.Arguments, &ast.Argument{
+			Name:  &ast.Name{Value: "scope"},
+			Value: &ast.EnumValue{Value: scope},
+		})
+	}
+	return []*ast.Directive{d}
+}
+
+func TestCacheControl(t *testing.T) {
+	postType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Post",
+		Fields: graphql.Fields{
+			"title": &graphql.Field{Type: graphql.String},
+			"votes": &graphql.Field{
+				Type:       graphql.Int,
+				Directives: cacheControlDirective("30", ""),
+			},
+			"viewerHasVoted": &graphql.Field{
+				Type:       graphql.Boolean,
+				Directives: cacheControlDirective("", "PRIVATE"),
+			},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"post": &graphql.Field{
+					Type:       postType,
+					Directives: cacheControlDirective("240", ""),
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						return map[string]any{"title": "Hello", "votes": 1, "viewerHasVoted": true}, nil
+					},
+				},
+			},
+		}),
+		Directives: append([]*graphql.Directive{graphql.CacheControlDirective}, graphql.SpecifiedDirectives...),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		query  string
+		policy *graphql.CachePolicy
+		hints  []graphql.CacheHint
+		header string
+	}{
+		{
+			query:  `{ post { title } }`,
+			policy: &graphql.CachePolicy{MaxAge: 240, Scope: graphql.CacheScopePublic},
+			hints:  []graphql.CacheHint{{Path: []string{"post"}, MaxAge: 240}},
+			header: "max-age=240, public",
+		},
+		{
+			query:  `{ post { title votes } }`,
+			policy: &graphql.CachePolicy{MaxAge: 30, Scope: graphql.CacheScopePublic},
+			hints: []graphql.CacheHint{
+				{Path: []string{"post"}, MaxAge: 240},
+				{Path: []string{"post", "votes"}, MaxAge: 30},
+			},
+			header: "max-age=30, public",
+		},
+		{
+			query:  `{ post { viewerHasVoted } }`,
+			policy: &graphql.CachePolicy{MaxAge: 0, Scope: graphql.CacheScopePrivate},
+			hints: []graphql.CacheHint{
+				{Path: []string{"post"}, MaxAge: 240},
+				{Path: []string{"post", "viewerHasVoted"}, MaxAge: 0, Scope: graphql.CacheScopePrivate},
+			},
+			header: "",
+		},
+	}
+	for _, c := range cases {
+		result := graphql.Do(context.Background(), graphql.Params{
+			Schema:        schema,
+			RequestString: c.query,
+			CacheControl:  true,
+		})
+		if len(result.Errors) != 0 {
+			t.Fatalf("%s: unexpected errors: %v", c.query, result.Errors)
+		}
+		if !reflect.DeepEqual(result.CachePolicy, c.policy) {
+			t.Fatalf("%s: expected policy %+v, got %+v", c.query, c.policy, result.CachePolicy)
+		}
+		ext := result.Extensions[graphql.CacheControlExtensionKey].(map[string]any)
+		if !reflect.DeepEqual(ext["hints"], c.hints) {
+			t.Fatalf("%s: unexpected hints, Diff: %v", c.query, testutil.Diff(c.hints, ext["hints"]))
+		}
+		if h := result.CachePolicy.HeaderValue(); h != c.header {
+			t.Fatalf("%s: expected header %q, got %q", c.query, c.header, h)
+		}
+	}
+
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: `{ post { title } }`,
+	})
+	if result.CachePolicy != nil || result.Extensions != nil {
+		t.Fatalf("Expected no cache policy without cache control, got %+v", result.CachePolicy)
+	}
+}
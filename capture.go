@@ -0,0 +1,185 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/sprucehealth/graphql/gqlerrors"
+	"github.com/sprucehealth/graphql/language/parser"
+	"github.com/sprucehealth/graphql/language/printer"
+)
+
+// RedactedValue replaces the value of variables that are redacted from a capture.
+const RedactedValue = "[REDACTED]"
+
+// CaptureSink receives the capture of an executed request (e.g. to log it or to
+// store it for later replay). It's called once per request after execution.
+type CaptureSink interface {
+	Capture(ctx context.Context, c *Capture)
+}
+
+// CapturePolicy configures the capture of a request.
+type CapturePolicy struct {
+	// Sink receives the capture. It's required.
+	Sink CaptureSink
+	// Redact if set is called for every variable and input object field of the
+	// variables. The path is the variable name followed by the names of the input
+	// object fields. Values for which it returns true are recorded as RedactedValue.
+	// The path must not be retained.
+	Redact func(path []string) bool
+}
+
+// Capture is the record of an executed request. It holds enough to re-execute the
+// request with Replay without calling the original resolvers.
+type Capture struct {
+	// Query is the printed request document.
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+	// SchemaHash is the hash of the schema the request was executed against.
+	SchemaHash string `json:"schemaHash"`
+	// Outcomes are the values returned by field and batch resolvers in the order
+	// they were resolved.
+	Outcomes []*ResolverOutcome `json:"outcomes"`
+
+	mu sync.Mutex
+}
+
+// ResolverOutcome is the value or error returned by the resolver of a field.
+type ResolverOutcome struct {
+	// Path is the response path of the field without list indices.
+	Path  []string `json:"path"`
+	Value any      `json:"value,omitempty"`
+	Error string   `json:"error,omitempty"`
+}
+
+func newCapture(p ExecuteParams) *Capture {
+	c := &Capture{
+		OperationName: p.OperationName,
+		Variables:     redactVariables(p.Args, p.Capture.Redact),
+		SchemaHash:    p.Schema.Hash(),
+	}
+	if p.AST != nil {
+		c.Query = printer.Print(p.AST)
+	}
+	return c
+}
+
+func (c *Capture) add(path []string, value any, err error) {
+	o := &ResolverOutcome{Path: append([]string(nil), path...), Value: value}
+	if err != nil {
+		o.Value = nil
+		o.Error = err.Error()
+	}
+	c.mu.Lock()
+	c.Outcomes = append(c.Outcomes, o)
+	c.mu.Unlock()
+}
+
+func redactVariables(vars map[string]any, redact func([]string) bool) map[string]any {
+	if len(vars) == 0 {
+		return nil
+	}
+	if redact == nil {
+		return vars
+	}
+	var redactValue func(path []string, v any) any
+	redactValue = func(path []string, v any) any {
+		if redact(path) {
+			return RedactedValue
+		}
+		switch v := v.(type) {
+		case map[string]any:
+			m := make(map[string]any, len(v))
+			for k, fv := range v {
+				m[k] = redactValue(append(path, k), fv)
+			}
+			return m
+		case []any:
+			// List items have the path of the list
+			l := make([]any, len(v))
+			for i, iv := range v {
+				l[i] = redactValue(path, iv)
+			}
+			return l
+		}
+		return v
+	}
+	redacted := make(map[string]any, len(vars))
+	for name, v := range vars {
+		redacted[name] = redactValue([]string{name}, v)
+	}
+	return redacted
+}
+
+// replayOutcomes feeds captured resolver outcomes to fields by path during Replay.
+type replayOutcomes struct {
+	mu       sync.Mutex
+	outcomes map[string][]*ResolverOutcome
+}
+
+func newReplayOutcomes(c *Capture) *replayOutcomes {
+	r := &replayOutcomes{outcomes: make(map[string][]*ResolverOutcome)}
+	for _, o := range c.Outcomes {
+		key := strings.Join(o.Path, ".")
+		r.outcomes[key] = append(r.outcomes[key], o)
+	}
+	return r
+}
+
+// next returns the next captured outcome for the path. Fields inside lists share
+// a path so their outcomes are returned in the order they were captured.
+func (r *replayOutcomes) next(path []string) (*ResolverOutcome, bool) {
+	key := strings.Join(path, ".")
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	outcomes := r.outcomes[key]
+	if len(outcomes) == 0 {
+		return nil, false
+	}
+	r.outcomes[key] = outcomes[1:]
+	return outcomes[0], true
+}
+
+// Replay re-executes a captured request against the schema. Fields that had
+// their value captured are resolved from the capture instead of calling their
+// resolve function (or the batch resolver of their parent) and all other fields
+// use the default resolver. A field with a resolve function that has no captured
+// outcome fails with an error. The schema should have the same hash as the
+// capture but it isn't required which allows replaying against a fixed schema.
+//
+// Captured values are the values returned by resolvers. When a capture is
+// serialized the values must still be understood by the default resolver of
+// the child fields (e.g. maps keyed by field name).
+func Replay(ctx context.Context, schema Schema, c *Capture) *Result {
+	doc, errs := parseAndValidate(&schema, c.Query, parser.ParseOptions{}, 0)
+	if len(errs) != 0 {
+		return &Result{Errors: errs}
+	}
+	return Execute(ctx, ExecuteParams{
+		Schema:        schema,
+		AST:           doc,
+		OperationName: c.OperationName,
+		Args:          c.Variables,
+		Deterministic: true,
+		replay:        newReplayOutcomes(c),
+	})
+}
+
+// replayFieldValue returns the captured outcome for a field during a replay.
+func replayFieldValue(eCtx *ExecutionContext, customResolver bool, path []string) (any, bool) {
+	o, ok := eCtx.replay.next(path)
+	if !ok {
+		if customResolver {
+			panic(gqlerrors.FormatError(fmt.Errorf("graphql: no captured outcome for field %q", strings.Join(path, "."))))
+		}
+		return nil, false
+	}
+	if o.Error != "" {
+		panic(gqlerrors.FormatError(errors.New(o.Error)))
+	}
+	return o.Value, true
+}
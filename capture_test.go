@@ -0,0 +1,103 @@
+package graphql_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+)
+
+type testCaptureSink struct {
+	captures []*graphql.Capture
+}
+
+func (s *testCaptureSink) Capture(ctx context.Context, c *graphql.Capture) {
+	s.captures = append(s.captures, c)
+}
+
+func TestCaptureAndReplay(t *testing.T) {
+	var calls int
+	itemType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Item",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.String},
+			"price": &graphql.Field{
+				Type: graphql.Int,
+				Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+					calls++
+					item := p.Source.(map[string]any)
+					if item["name"] == "b" {
+						return nil, errors.New("price unavailable")
+					}
+					return len(item["name"].(string)), nil
+				},
+			},
+		},
+	})
+	schema := testSchema(t, &graphql.Field{
+		Type: graphql.NewList(itemType),
+		Args: graphql.FieldConfigArgument{
+			"filter": &graphql.ArgumentConfig{Type: graphql.NewInputObject(graphql.InputObjectConfig{
+				Name: "Filter",
+				Fields: graphql.InputObjectConfigFieldMap{
+					"category": &graphql.InputObjectFieldConfig{Type: graphql.String},
+					"token":    &graphql.InputObjectFieldConfig{Type: graphql.String},
+				},
+			})},
+		},
+		Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+			calls++
+			return []any{map[string]any{"name": "a"}, map[string]any{"name": "b"}}, nil
+		},
+	})
+
+	sink := &testCaptureSink{}
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema:         schema,
+		RequestString:  `query Q($filter: Filter) { test(filter: $filter) { name price } }`,
+		OperationName:  "Q",
+		VariableValues: map[string]any{"filter": map[string]any{"category": "c", "token": "secret"}},
+		Capture: &graphql.CapturePolicy{
+			Sink: sink,
+			Redact: func(path []string) bool {
+				return path[len(path)-1] == "token"
+			},
+		},
+	})
+	if len(sink.captures) != 1 {
+		t.Fatalf("Expected 1 capture, got %d", len(sink.captures))
+	}
+	c := sink.captures[0]
+	if c.SchemaHash != schema.Hash() || c.OperationName != "Q" {
+		t.Fatalf("Unexpected capture %+v", c)
+	}
+	expectedVars := map[string]any{"filter": map[string]any{"category": "c", "token": graphql.RedactedValue}}
+	if !reflect.DeepEqual(expectedVars, c.Variables) {
+		t.Fatalf("Expected variables %v, got %v", expectedVars, c.Variables)
+	}
+	// Only fields with a resolve function are captured
+	if len(c.Outcomes) != 3 {
+		t.Fatalf("Expected 3 outcomes, got %d", len(c.Outcomes))
+	}
+	if o := c.Outcomes[2]; !reflect.DeepEqual([]string{"test", "price"}, o.Path) || o.Error != "price unavailable" {
+		t.Fatalf("Unexpected outcome %+v", o)
+	}
+
+	calls = 0
+	replayed := graphql.Replay(context.Background(), schema, c)
+	if calls != 0 {
+		t.Fatalf("Expected resolvers to not be called during replay, got %d calls", calls)
+	}
+	if !reflect.DeepEqual(result.Data, replayed.Data) || len(replayed.Errors) != 1 || replayed.Errors[0].Message != "price unavailable" {
+		t.Fatalf("Expected replay to match result %+v, got %+v", result, replayed)
+	}
+
+	// Fields with a resolve function fail without a captured outcome
+	c.Outcomes = c.Outcomes[:1]
+	replayed = graphql.Replay(context.Background(), schema, c)
+	if calls != 0 || len(replayed.Errors) != 2 {
+		t.Fatalf("Expected missing outcomes to fail, got %+v", replayed)
+	}
+}
@@ -0,0 +1,149 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/sprucehealth/graphql/gqlerrors"
+	"github.com/sprucehealth/graphql/language/ast"
+	"github.com/sprucehealth/graphql/language/parser"
+	"github.com/sprucehealth/graphql/language/printer"
+)
+
+// ErrSnapshotSchemaMismatch is returned by Resume when the snapshot was taken
+// with a schema that has a different hash.
+var ErrSnapshotSchemaMismatch = errors.New("graphql: snapshot was taken with a different schema")
+
+// Snapshot is the state of a partially executed operation. It's EXPERIMENTAL and
+// may change.
+//
+// Root fields are the unit of progress: a snapshot is taken every time a root
+// field completes and Resume executes the operation again skipping the root fields
+// that have completed. It suits job-style operations (e.g. reports) that select
+// several expensive root fields, and mutations as completed mutation fields
+// aren't executed again.
+type Snapshot struct {
+	// Query is the printed request document.
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+	// SchemaHash is the hash of the schema the operation is executed against.
+	SchemaHash string `json:"schemaHash"`
+	// Completed are the response names of the root fields that have completed in
+	// the order they completed.
+	Completed []string `json:"completed"`
+	// Data are the completed values of the root fields keyed by response name.
+	Data map[string]any `json:"data"`
+	// Errors are the field errors reported so far.
+	Errors []gqlerrors.FormattedError `json:"errors,omitempty"`
+}
+
+// CheckpointFn is called with a snapshot every time a root field of the operation
+// completes (e.g. to store it so that the operation can be resumed if the process
+// stops). The snapshot may be retained. Returning an error aborts the operation:
+// the error is reported for the root field that completed and the root fields that
+// haven't started are skipped. The data of the completed root fields is kept. It
+// may be called after Execute returns if the context is done.
+type CheckpointFn func(ctx context.Context, s *Snapshot) error
+
+// checkpoints tracks the progress of an operation for CheckpointFn and Resume.
+type checkpoints struct {
+	// mu guards the snapshot as root fields may be executed concurrently.
+	mu         sync.Mutex
+	snapshot   *Snapshot
+	checkpoint CheckpointFn
+	// aborted is true once checkpoint has returned an error.
+	aborted bool
+}
+
+func newCheckpoints(p ExecuteParams) *checkpoints {
+	if p.resume != nil {
+		s := p.resume.clone()
+		if s.Data == nil {
+			s.Data = make(map[string]any)
+		}
+		return &checkpoints{snapshot: s, checkpoint: p.Checkpoint}
+	}
+	s := &Snapshot{
+		OperationName: p.OperationName,
+		Variables:     maps.Clone(p.Args),
+		SchemaHash:    p.Schema.Hash(),
+		Data:          make(map[string]any),
+	}
+	if p.AST != nil {
+		s.Query = printer.Print(p.AST)
+	}
+	return &checkpoints{snapshot: s, checkpoint: p.Checkpoint}
+}
+
+// wrap returns a function that executes a root field unless it has completed
+// already and takes a snapshot once the field completes.
+func (c *checkpoints) wrap(eCtx *ExecutionContext, executeField func(context.Context, string, []*ast.Field) (any, bool)) func(context.Context, string, []*ast.Field) (any, bool) {
+	return func(ctx context.Context, responseName string, fieldASTs []*ast.Field) (any, bool) {
+		c.mu.Lock()
+		if slices.Contains(c.snapshot.Completed, responseName) {
+			defer c.mu.Unlock()
+			return c.snapshot.Data[responseName], true
+		}
+		aborted := c.aborted
+		c.mu.Unlock()
+		if aborted {
+			return nil, false
+		}
+		resolved, ok := executeField(ctx, responseName, fieldASTs)
+		if !ok {
+			return resolved, ok
+		}
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.snapshot.Completed = append(c.snapshot.Completed, responseName)
+		c.snapshot.Data[responseName] = resolved
+		c.snapshot.Errors = slices.Clone(eCtx.errors())
+		if c.checkpoint != nil {
+			if err := c.checkpoint(ctx, c.snapshot.clone()); err != nil {
+				c.aborted = true
+				fe := gqlerrors.FormatError(NewLocatedError(err, FieldASTsToNodeASTs(fieldASTs)))
+				fe.Path = []any{responseName}
+				eCtx.addError(fe)
+			}
+		}
+		return resolved, ok
+	}
+}
+
+func (s *Snapshot) clone() *Snapshot {
+	c := *s
+	c.Completed = slices.Clone(s.Completed)
+	c.Data = maps.Clone(s.Data)
+	c.Errors = slices.Clone(s.Errors)
+	return &c
+}
+
+// Resume executes the operation of a snapshot skipping the root fields that have
+// completed. The data of the result includes the values of the completed fields
+// from the snapshot. The root value isn't part of the snapshot so root should be
+// the same as the ExecuteParams.Root of the first execution. If checkpoint is set
+// it's called every time another root field completes. It's EXPERIMENTAL and may
+// change. The schema must have the same hash as the snapshot.
+func Resume(ctx context.Context, schema Schema, s *Snapshot, root any, checkpoint CheckpointFn) *Result {
+	if s.SchemaHash != schema.Hash() {
+		return &Result{Errors: gqlerrors.FormatErrors(ErrSnapshotSchemaMismatch)}
+	}
+	doc, errs := parseAndValidate(&schema, s.Query, parser.ParseOptions{}, 0)
+	if len(errs) != 0 {
+		return &Result{Errors: errs}
+	}
+	return Execute(ctx, ExecuteParams{
+		Schema:        schema,
+		Root:          root,
+		AST:           doc,
+		OperationName: s.OperationName,
+		Args:          s.Variables,
+		Deterministic: true,
+		Checkpoint:    checkpoint,
+		resume:        s,
+	})
+}
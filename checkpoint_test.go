@@ -0,0 +1,111 @@
+package graphql_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+func TestCheckpointAndResume(t *testing.T) {
+	calls := make(map[string]int)
+	field := func(name string) *graphql.Field {
+		return &graphql.Field{
+			Type: graphql.String,
+			Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+				calls[name]++
+				if name == "b" {
+					return nil, errors.New("b failed")
+				}
+				return name + p.Info.RootValue.(map[string]any)["suffix"].(string), nil
+			},
+		}
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"a": field("a"),
+				"b": field("b"),
+				"c": field("c"),
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Abort the operation after the second field as if the process had stopped.
+	var snapshots []*graphql.Snapshot
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: `query Report { a b c }`,
+		RootObject:    map[string]any{"suffix": "!"},
+		Deterministic: true,
+		Checkpoint: func(ctx context.Context, s *graphql.Snapshot) error {
+			snapshots = append(snapshots, s)
+			if len(snapshots) == 2 {
+				return errors.New("stopped")
+			}
+			return nil
+		},
+	})
+	if len(result.Errors) != 2 || result.Errors[1].Message != "stopped" || !reflect.DeepEqual(result.Errors[1].Path, []any{"b"}) {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+	// The completed fields are kept and the remaining fields are skipped
+	if expected := map[string]any{"a": "a!", "b": nil}; !reflect.DeepEqual(result.Data, expected) {
+		t.Fatal(testutil.Diff(expected, result.Data))
+	}
+	if len(snapshots) != 2 {
+		t.Fatalf("Expected 2 snapshots, got %d", len(snapshots))
+	}
+	if c := snapshots[0].Completed; !reflect.DeepEqual(c, []string{"a"}) {
+		t.Fatalf("Unexpected completed fields of the first snapshot %v", c)
+	}
+
+	// Snapshots are meant to be stored so resume from a decoded one.
+	b, err := json.Marshal(snapshots[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var snapshot *graphql.Snapshot
+	if err := json.Unmarshal(b, &snapshot); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(snapshot.Completed, []string{"a", "b"}) {
+		t.Fatalf("Unexpected completed fields %v", snapshot.Completed)
+	}
+
+	var resumed []*graphql.Snapshot
+	// The root value is the same as for the first execution
+	result = graphql.Resume(context.Background(), schema, snapshot, map[string]any{"suffix": "!"}, func(ctx context.Context, s *graphql.Snapshot) error {
+		resumed = append(resumed, s)
+		return nil
+	})
+	expectedData := map[string]any{"a": "a!", "b": nil, "c": "c!"}
+	if !reflect.DeepEqual(result.Data, expectedData) {
+		t.Fatal(testutil.Diff(expectedData, result.Data))
+	}
+	if len(result.Errors) != 1 || result.Errors[0].Message != "b failed" {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+	if expected := map[string]int{"a": 1, "b": 1, "c": 1}; !reflect.DeepEqual(calls, expected) {
+		t.Fatal(testutil.Diff(expected, calls))
+	}
+	if len(resumed) != 1 || !reflect.DeepEqual(resumed[0].Completed, []string{"a", "b", "c"}) {
+		t.Fatalf("Unexpected snapshots after resuming %+v", resumed)
+	}
+}
+
+func TestResume_SchemaMismatch(t *testing.T) {
+	schema := testSchema(t, &graphql.Field{Type: graphql.String})
+	result := graphql.Resume(context.Background(), schema, &graphql.Snapshot{Query: `{ test }`, SchemaHash: "other"}, nil, nil)
+	if len(result.Errors) != 1 || result.Errors[0].Message != graphql.ErrSnapshotSchemaMismatch.Error() {
+		t.Fatalf("Unexpected result %+v", result)
+	}
+}
@@ -53,12 +53,14 @@ func generateClient(g *generator) {
 			g.printf("\n")
 		case *ast.InterfaceDefinition:
 			g.genInterfaceModel(def)
+			g.genMatchHelper(def.Name.Value, g.resolveImplementingTypes(def))
 			g.printf("\n")
 		case *ast.InputObjectDefinition:
 			g.genInputModel(def)
 			g.printf("\n")
 		case *ast.UnionDefinition:
 			g.genUnionModel(def)
+			g.genMatchHelper(def.Name.Value, g.resolveUnionTypes(def))
 			g.printf("\n")
 		case *ast.EnumDefinition:
 			g.genEnumConstants(def)
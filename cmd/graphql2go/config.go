@@ -0,0 +1,260 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sprucehealth/graphql/language/ast"
+)
+
+// schemaIncludePrefix starts a line of a schema file that includes another schema
+// file (e.g. `#include "common.graphql"`). The path is relative to the file.
+const schemaIncludePrefix = "#include "
+
+// configLoader loads a config file and the files it includes and merges them
+// remembering which file set every value to report conflicts.
+type configLoader struct {
+	cfg     config
+	origins map[string]string
+	loading []string
+	loaded  map[string]bool
+}
+
+// loadConfig reads the config file at path. The Include list of a config file has
+// the paths of other config files relative to it (e.g. one per team) that are
+// merged in order before the file itself. A value that's set to different values
+// by two files is an error naming both files. Each file is merged once.
+func loadConfig(path string) (*config, error) {
+	l := &configLoader{origins: make(map[string]string), loaded: make(map[string]bool)}
+	if err := l.load(path); err != nil {
+		return nil, err
+	}
+	return &l.cfg, nil
+}
+
+func (l *configLoader) load(path string) error {
+	path = filepath.Clean(path)
+	if slices.Contains(l.loading, path) {
+		return fmt.Errorf("config include cycle: %s -> %s", strings.Join(l.loading, " -> "), path)
+	}
+	if l.loaded[path] {
+		return nil
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var cfg config
+	if err := json.Unmarshal(b, &cfg); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	l.loading = append(l.loading, path)
+	for _, inc := range cfg.Include {
+		if !filepath.IsAbs(inc) {
+			inc = filepath.Join(filepath.Dir(path), inc)
+		}
+		if err := l.load(inc); err != nil {
+			return err
+		}
+	}
+	l.loading = l.loading[:len(l.loading)-1]
+	l.loaded[path] = true
+	return l.merge(path, &cfg)
+}
+
+func (l *configLoader) merge(path string, cfg *config) error {
+	for _, typeName := range sortedKeys(cfg.Resolvers) {
+		if l.cfg.Resolvers == nil {
+			l.cfg.Resolvers = make(map[string][]string)
+		}
+		for _, field := range cfg.Resolvers[typeName] {
+			if !slices.Contains(l.cfg.Resolvers[typeName], field) {
+				l.cfg.Resolvers[typeName] = append(l.cfg.Resolvers[typeName], field)
+			}
+		}
+	}
+	if err := mergeConfigMap(l, path, "CustomFieldTypes", &l.cfg.CustomFieldTypes, cfg.CustomFieldTypes); err != nil {
+		return err
+	}
+	for _, typeName := range sortedKeys(cfg.ExtraFields) {
+		if l.cfg.ExtraFields == nil {
+			l.cfg.ExtraFields = make(map[string]map[string]string)
+		}
+		fields := l.cfg.ExtraFields[typeName]
+		if err := mergeConfigMap(l, path, "ExtraFields."+typeName, &fields, cfg.ExtraFields[typeName]); err != nil {
+			return err
+		}
+		l.cfg.ExtraFields[typeName] = fields
+	}
+	if err := mergeConfigMap(l, path, "Initialisms", &l.cfg.Initialisms, cfg.Initialisms); err != nil {
+		return err
+	}
+	if err := mergeConfigMap(l, path, "CustomScalarTypes", &l.cfg.CustomScalarTypes, cfg.CustomScalarTypes); err != nil {
+		return err
+	}
+	if err := mergeConfigMap(l, path, "NullableInputTypes", &l.cfg.NullableInputTypes, cfg.NullableInputTypes); err != nil {
+		return err
+	}
+	l.cfg.InstrumentResolvers = l.cfg.InstrumentResolvers || cfg.InstrumentResolvers
+	l.cfg.ResolverProviders = l.cfg.ResolverProviders || cfg.ResolverProviders
+	return nil
+}
+
+// mergeConfigMap adds the values of src to dst returning an error if a key already
+// has a different value.
+func mergeConfigMap[V comparable](l *configLoader, path, section string, dst *map[string]V, src map[string]V) error {
+	for _, k := range sortedKeys(src) {
+		v := src[k]
+		key := section + "." + k
+		if old, ok := (*dst)[k]; ok && old != v {
+			return fmt.Errorf("config conflict for %s: %s sets %v but %s sets %v", key, l.origins[key], old, path, v)
+		}
+		if *dst == nil {
+			*dst = make(map[string]V)
+		}
+		(*dst)[k] = v
+		if _, ok := l.origins[key]; !ok {
+			l.origins[key] = path
+		}
+	}
+	return nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// schemaFile is a schema file that's part of the combined schema source.
+type schemaFile struct {
+	path string
+	// start is the offset of the content of the file in the combined source.
+	start int
+}
+
+// schemaSource is the content of schema files combined into one source.
+type schemaSource struct {
+	body    []byte
+	files   []schemaFile
+	loading []string
+	loaded  map[string]bool
+}
+
+// readSchemaFiles combines the schema files and the files they include. Include
+// lines are replaced by the content of the included file the first time it's
+// included.
+func readSchemaFiles(paths []string) (*schemaSource, error) {
+	s := &schemaSource{loaded: make(map[string]bool)}
+	for _, p := range paths {
+		if err := s.read(strings.TrimSpace(p)); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func (s *schemaSource) read(path string) error {
+	path = filepath.Clean(path)
+	if slices.Contains(s.loading, path) {
+		return fmt.Errorf("schema include cycle: %s -> %s", strings.Join(s.loading, " -> "), path)
+	}
+	if s.loaded[path] {
+		return nil
+	}
+	s.loaded[path] = true
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read schema file %q: %w", path, err)
+	}
+	s.loading = append(s.loading, path)
+	defer func() { s.loading = s.loading[:len(s.loading)-1] }()
+
+	sc := bufio.NewScanner(bytes.NewReader(b))
+	sc.Buffer(nil, len(b)+1)
+	var chunk []byte
+	flush := func() {
+		if len(chunk) != 0 {
+			if len(s.body) != 0 && s.body[len(s.body)-1] != '\n' {
+				s.body = append(s.body, '\n')
+			}
+			s.files = append(s.files, schemaFile{path: path, start: len(s.body)})
+			s.body = append(s.body, chunk...)
+			chunk = nil
+		}
+	}
+	for sc.Scan() {
+		line := sc.Text()
+		inc, ok := strings.CutPrefix(line, schemaIncludePrefix)
+		if !ok {
+			chunk = append(append(chunk, line...), '\n')
+			continue
+		}
+		flush()
+		inc, err := strconv.Unquote(strings.TrimSpace(inc))
+		if err != nil {
+			return fmt.Errorf("%s: invalid include %q", path, line)
+		}
+		if !filepath.IsAbs(inc) {
+			inc = filepath.Join(filepath.Dir(path), inc)
+		}
+		if err := s.read(inc); err != nil {
+			return err
+		}
+	}
+	flush()
+	return sc.Err()
+}
+
+// fileAt returns the path of the file of the content at the offset of the source.
+func (s *schemaSource) fileAt(offset int) string {
+	i := sort.Search(len(s.files), func(i int) bool { return s.files[i].start > offset })
+	if i == 0 {
+		return ""
+	}
+	return s.files[i-1].path
+}
+
+// checkDuplicateDefinitions returns an error naming both files if a type or
+// directive is defined twice.
+func (s *schemaSource) checkDuplicateDefinitions(doc *ast.Document) error {
+	defined := make(map[string]ast.Node)
+	for _, def := range doc.Definitions {
+		var name *ast.Name
+		switch def := def.(type) {
+		case *ast.ObjectDefinition:
+			name = def.Name
+		case *ast.InputObjectDefinition:
+			name = def.Name
+		case *ast.EnumDefinition:
+			name = def.Name
+		case *ast.InterfaceDefinition:
+			name = def.Name
+		case *ast.UnionDefinition:
+			name = def.Name
+		case *ast.ScalarDefinition:
+			name = def.Name
+		case *ast.DirectiveDefinition:
+			name = def.Name
+		}
+		if name == nil {
+			continue
+		}
+		if other, ok := defined[name.Value]; ok {
+			return fmt.Errorf("duplicate definition of %q in %s and %s", name.Value, s.fileAt(other.GetLoc().Start), s.fileAt(def.GetLoc().Start))
+		}
+		defined[name.Value] = def
+	}
+	return nil
+}
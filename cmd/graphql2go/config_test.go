@@ -0,0 +1,135 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sprucehealth/graphql/language/parser"
+)
+
+func writeTestFiles(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir := writeTestFiles(t, map[string]string{
+		"config.json": `{
+			"Include": ["teams/a.json", "teams/b.json"],
+			"Resolvers": {"Query": ["me"]},
+			"Initialisms": {"API": "API"}
+		}`,
+		"teams/a.json": `{
+			"Include": ["common.json"],
+			"Resolvers": {"Query": ["accounts"], "Account": ["owner"]},
+			"CustomFieldTypes": {"Account.id": "uint64"}
+		}`,
+		"teams/b.json": `{
+			"Include": ["common.json"],
+			"Resolvers": {"Query": ["patients", "accounts"]},
+			"ExtraFields": {"Patient": {"raw": "*models.Patient"}},
+			"InstrumentResolvers": true
+		}`,
+		"teams/common.json": `{
+			"CustomScalarTypes": {"Time": "time.Time"},
+			"NullableInputTypes": {"Filter": true},
+			"ResolverProviders": true
+		}`,
+	})
+	cfg, err := loadConfig(filepath.Join(dir, "config.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &config{
+		Resolvers: map[string][]string{
+			"Query":   {"accounts", "patients", "me"},
+			"Account": {"owner"},
+		},
+		CustomFieldTypes:    map[string]string{"Account.id": "uint64"},
+		ExtraFields:         map[string]map[string]string{"Patient": {"raw": "*models.Patient"}},
+		Initialisms:         map[string]string{"API": "API"},
+		CustomScalarTypes:   map[string]string{"Time": "time.Time"},
+		NullableInputTypes:  map[string]bool{"Filter": true},
+		InstrumentResolvers: true,
+		ResolverProviders:   true,
+	}
+	if !reflect.DeepEqual(cfg, expected) {
+		t.Fatalf("Expected %+v, got %+v", expected, cfg)
+	}
+}
+
+func TestLoadConfig_Errors(t *testing.T) {
+	dir := writeTestFiles(t, map[string]string{
+		"conflict.json": `{"Include": ["a.json", "b.json"]}`,
+		"a.json":        `{"CustomFieldTypes": {"Account.id": "uint64"}}`,
+		"b.json":        `{"CustomFieldTypes": {"Account.id": "string"}}`,
+		"cycle.json":    `{"Include": ["cycle2.json"]}`,
+		"cycle2.json":   `{"Include": ["cycle.json"]}`,
+	})
+	cases := map[string]string{
+		"conflict.json": "config conflict for CustomFieldTypes.Account.id: " + filepath.Join(dir, "a.json") + " sets uint64 but " + filepath.Join(dir, "b.json") + " sets string",
+		"cycle.json":    "config include cycle: " + filepath.Join(dir, "cycle.json") + " -> " + filepath.Join(dir, "cycle2.json") + " -> " + filepath.Join(dir, "cycle.json"),
+	}
+	for name, expected := range cases {
+		_, err := loadConfig(filepath.Join(dir, name))
+		if err == nil || err.Error() != expected {
+			t.Errorf("%s: expected error %q, got %v", name, expected, err)
+		}
+	}
+}
+
+func TestReadSchemaFiles(t *testing.T) {
+	dir := writeTestFiles(t, map[string]string{
+		"schema.graphql": "#include \"common.graphql\"\n" +
+			"type Query {\n  account: Account\n}\n" +
+			"#include \"accounts/account.graphql\"\n",
+		"common.graphql":           "scalar Time\n",
+		"accounts/account.graphql": "#include \"../common.graphql\"\n# An account.\ntype Account {\n  created: Time\n}\n",
+		"dupe.graphql":             "#include \"common.graphql\"\nscalar Time\n",
+	})
+	source, err := readSchemaFiles([]string{filepath.Join(dir, "schema.graphql")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "scalar Time\n" +
+		"type Query {\n  account: Account\n}\n" +
+		"# An account.\ntype Account {\n  created: Time\n}\n"
+	if string(source.body) != expected {
+		t.Fatalf("Expected:\n%s\nGot:\n%s", expected, source.body)
+	}
+	doc, err := parser.Parse(parser.ParseParams{Source: string(source.body)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := source.checkDuplicateDefinitions(doc); err != nil {
+		t.Fatal(err)
+	}
+	if file := source.fileAt(strings.Index(string(source.body), "type Account")); file != filepath.Join(dir, "accounts/account.graphql") {
+		t.Fatalf("Unexpected file %q", file)
+	}
+
+	source, err = readSchemaFiles([]string{filepath.Join(dir, "dupe.graphql")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc, err = parser.Parse(parser.ParseParams{Source: string(source.body)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedErr := `duplicate definition of "Time" in ` + filepath.Join(dir, "common.graphql") + " and " + filepath.Join(dir, "dupe.graphql")
+	if err := source.checkDuplicateDefinitions(doc); err == nil || err.Error() != expectedErr {
+		t.Fatalf("Expected error %q, got %v", expectedErr, err)
+	}
+}
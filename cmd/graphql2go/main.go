@@ -3,7 +3,8 @@ package main
 // TODO: default values for input fields and arguments
 
 import (
-	"encoding/json"
+	"bytes"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
@@ -19,17 +20,21 @@ import (
 
 	"github.com/sprucehealth/graphql/language/ast"
 	"github.com/sprucehealth/graphql/language/parser"
+	"github.com/sprucehealth/graphql/language/printer"
 )
 
 var (
-	flagArtifact                 = flag.String("artifact", "server", "The artifact to generate from the schema (server or client)")
+	flagArtifact                 = flag.String("artifact", "server", "The artifact to generate from the schema (server, client, or sdl)")
 	flagClientTypes              = flag.String("client_types", "Query,Mutation", "The types that should be used to create client methods")
 	flagConfigFile               = flag.String("config", "", "Path to config file")
-	flagOutFile                  = flag.String("out", "", "Path to output file (stdout if not set)")
-	flagSchemaFile               = flag.String("schema", "", "Path to schema file (stdin if not set)")
+	flagOutFile                  = flag.String("out", "", "Path to output file (stdout if not set). The file is only written if its content changed.")
+	flagSchemaFile               = flag.String("schema", "", "Path to schema file (stdin if not set). Lines like #include \"path\" include other schema files.")
 	flagNullableInputs           = flag.Bool("nullable_inputs", false, "Flag to determine if nullable inputs should be serialized into pointers")
 	flagVerbose                  = flag.Bool("v", false, "Verbose output")
+	flagVerify                   = flag.Bool("verify", false, "Exit with an error if the output file is not up to date instead of writing it")
 	flagAssertIdentityAssumption = flag.Bool("assert_identity", false, "Asserts specific usage of the allowIdentityAssumption directive")
+	flagInstrumentResolvers      = flag.Bool("instrument_resolvers", false, "Flag to determine if custom resolvers should call the resolver instrumentation of the context")
+	flagResolverProviders        = flag.Bool("resolver_providers", false, "Flag to determine if custom resolvers should be looked up with graphql.MustProvider instead of in the root value")
 )
 
 var initialisms = map[string]string{
@@ -55,12 +60,22 @@ var initialisms = map[string]string{
 }
 
 type config struct {
+	// Include are paths of config files relative to this one that are merged
+	// into it (e.g. to split the config by domain). See loadConfig.
+	Include            []string
 	Resolvers          map[string][]string          // type -> fields
 	CustomFieldTypes   map[string]string            // Type.Field -> go type
 	ExtraFields        map[string]map[string]string // type -> field -> go type
 	Initialisms        map[string]string
 	CustomScalarTypes  map[string]string // Type.Field -> go type
 	NullableInputTypes map[string]bool
+	// InstrumentResolvers makes the generated resolvers call graphql.StartResolver
+	// (also enabled by the instrument_resolvers flag).
+	InstrumentResolvers bool
+	// ResolverProviders makes the generated resolvers look up their implementation
+	// with graphql.MustProvider instead of in the root value by XResolversKey (also
+	// enabled by the resolver_providers flag).
+	ResolverProviders bool
 }
 
 func main() {
@@ -68,6 +83,7 @@ func main() {
 	flag.Parse()
 
 	var schema []byte
+	var source *schemaSource
 	if *flagSchemaFile != "" {
 		var paths []string
 		if strings.Contains(*flagSchemaFile, "*") {
@@ -79,17 +95,12 @@ func main() {
 		} else {
 			paths = strings.Split(*flagSchemaFile, " ")
 		}
-		for _, p := range paths {
-			p = strings.TrimSpace(p)
-			b, err := os.ReadFile(p)
-			if err != nil {
-				log.Fatalf("Failed to read schema file %q: %s", p, err)
-			}
-			if len(schema) != 0 && schema[len(schema)-1] != '\n' {
-				schema = append(schema, '\n')
-			}
-			schema = append(schema, b...)
+		var err error
+		source, err = readSchemaFiles(paths)
+		if err != nil {
+			log.Fatal(err)
 		}
+		schema = source.body
 	} else {
 		var err error
 		schema, err = io.ReadAll(os.Stdin)
@@ -107,6 +118,14 @@ func main() {
 	if err != nil {
 		log.Fatal(err)
 	}
+	if source != nil {
+		if err := source.checkDuplicateDefinitions(root); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if *flagArtifact != "sdl" {
+		useDescriptions(root)
+	}
 
 	// Validate schema
 	for _, def := range root.Definitions {
@@ -130,16 +149,15 @@ func main() {
 		}
 	}
 
-	var outWriter io.Writer
-	if *flagOutFile == "" {
-		outWriter = os.Stdout
-	} else {
-		fo, err := os.Create(*flagOutFile)
-		if err != nil {
-			log.Fatalf("Failed to create output file: %s", err)
-		}
-		defer fo.Close()
-		outWriter = fo
+	if *flagVerify && *flagOutFile == "" {
+		log.Fatal("-verify requires -out")
+	}
+
+	var outWriter io.Writer = os.Stdout
+	out := &bytes.Buffer{}
+	if *flagOutFile != "" {
+		// Buffer the output to only write the file if it changed
+		outWriter = out
 	}
 
 	g := newGenerator(outWriter, root)
@@ -153,9 +171,28 @@ func main() {
 		generateServer(g)
 	case "client":
 		generateClient(g)
+	case "sdl":
+		generateSDL(g)
 	default:
 		log.Fatalf("Unknown output artifact type %s", *flagArtifact)
 	}
+
+	if *flagOutFile != "" {
+		changed, err := writeOutput(*flagOutFile, out.Bytes(), *flagVerify)
+		if errors.Is(err, errStaleOutput) {
+			log.Fatalf("%s (run graphql2go without -verify to regenerate it)", err)
+		} else if err != nil {
+			log.Fatalf("Failed to write output file: %s", err)
+		}
+		if *flagVerbose && !changed {
+			log.Printf("%s is up to date", *flagOutFile)
+		}
+	}
+}
+
+// generateSDL prints the schema with doc comments promoted to descriptions.
+func generateSDL(g *generator) {
+	g.print(printer.PrintWithOptions(g.doc, printer.PrintOptions{CommentDescriptions: true}))
 }
 
 type resolver struct {
@@ -204,7 +241,9 @@ func generateServer(g *generator) {
 			assertionType = "map[string]any"
 		}
 		sort.Strings(fields)
-		g.printf("const %sResolversKey = %q\n\n", exportedName(typeName), exportedName(typeName)+"Resolvers")
+		if !g.cfg.ResolverProviders {
+			g.printf("const %sResolversKey = %q\n\n", exportedName(typeName), exportedName(typeName)+"Resolvers")
+		}
 		g.printf("type %sResolvers interface {\n", exportedName(typeName))
 		for _, fieldName := range fields {
 			objDef, ok := g.types[typeName].(*ast.ObjectDefinition)
@@ -280,17 +319,21 @@ func newGenerator(outWriter io.Writer, root *ast.Document) *generator {
 		cycleBreaks:  make(map[string]map[string]struct{}),
 	}
 	if *flagConfigFile != "" {
-		b, err := os.ReadFile(*flagConfigFile)
+		cfg, err := loadConfig(*flagConfigFile)
 		if err != nil {
-			log.Fatalf("Failed to read config file: %s", err)
-		}
-		if err := json.Unmarshal(b, &g.cfg); err != nil {
-			log.Fatalf("Failed to decode config file: %s", err)
+			log.Fatalf("Failed to load config file: %s", err)
 		}
+		g.cfg = *cfg
 		for k, v := range g.cfg.Initialisms {
 			initialisms[k] = v
 		}
 	}
+	if *flagInstrumentResolvers {
+		g.cfg.InstrumentResolvers = true
+	}
+	if *flagResolverProviders {
+		g.cfg.ResolverProviders = true
+	}
 
 	// Generate index of type name to definition and make sure all names are unique
 	for _, def := range root.Definitions {
@@ -640,10 +683,13 @@ func (g *generator) genNode(node ast.Node) {
 		g.genInterfaceDefinition(def)
 		g.printf("\n")
 		g.genInterfaceModel(def)
+		g.genMatchHelper(def.Name.Value, g.resolveImplementingTypes(def))
 	case *ast.UnionDefinition:
 		g.genUnionDefinition(def)
 		g.printf("\n")
 		g.genUnionModel(def)
+		g.genMatchHelper(def.Name.Value, g.resolveUnionTypes(def))
+		g.genErrorAsDataHelper(def)
 	case *ast.ScalarDefinition:
 		g.genScalarDefinition(def)
 		g.printf("\n")
@@ -737,6 +783,76 @@ func (g *generator) genUnionModel(def *ast.UnionDefinition) {
 	g.printf("}\n")
 }
 
+// genErrorAsDataHelper generates a function for resolvers of fields of a result
+// union (e.g. `union UpdateUserResult = User | NotFoundError`) that returns the
+// errors of the union's error types as data and other errors as field errors.
+func (g *generator) genErrorAsDataHelper(def *ast.UnionDefinition) {
+	var hasErrorType bool
+	for _, m := range g.resolveUnionTypes(def) {
+		if g.isErrorType(m) {
+			hasErrorType = true
+			break
+		}
+	}
+	if !hasErrorType {
+		return
+	}
+	goName := exportedName(def.Name.Value)
+	g.printf("\n// %sFromError returns err as a %s value if it's one of its error types and\n", goName, goName)
+	g.printf("// returns it as a field error otherwise.\n")
+	g.printf("func %sFromError(err error) (any, error) {\n", goName)
+	g.printf("\treturn graphql.ErrorAsData(err, %s)\n", goUnionDefName(def.Name.Value))
+	g.printf("}\n")
+}
+
+// isErrorType returns true if the object type is an error returned as data which
+// is the case if its name ends with Error and it has a `message: String!` field.
+// Its model then implements the error interface.
+func (g *generator) isErrorType(def *ast.ObjectDefinition) bool {
+	if !strings.HasSuffix(def.Name.Value, "Error") {
+		return false
+	}
+	for _, f := range def.Fields {
+		if f.Name.Value != "message" || g.hasCustomResolver(def.Name.Value, f.Name.Value) {
+			continue
+		}
+		if nn, ok := f.Type.(*ast.NonNull); ok {
+			if named, ok := nn.Type.(*ast.Named); ok && named.Name.Value == "String" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// genMatchHelper generates a function that calls the handler for the member type
+// of a union or interface value. There's a handler argument for every member type
+// so adding a member type to the schema fails to compile where a handler is missing.
+func (g *generator) genMatchHelper(name string, members []*ast.ObjectDefinition) {
+	if len(members) == 0 {
+		return
+	}
+	goName := exportedName(name)
+	g.printf("\n// Match%s calls the handler for the type of the %s value and returns its result.\n", goName, goName)
+	g.printf("// The zero value is returned for a nil value.\n")
+	g.printf("func Match%s[T any](v %s", goName, goName)
+	for _, m := range members {
+		g.printf(", on%s func(*%s) T", exportedName(m.Name.Value), exportedName(m.Name.Value))
+	}
+	g.printf(") T {\n")
+	g.printf("\tswitch v := v.(type) {\n")
+	for _, m := range members {
+		g.printf("\tcase *%s:\n", exportedName(m.Name.Value))
+		g.printf("\t\treturn on%s(v)\n", exportedName(m.Name.Value))
+	}
+	g.printf("\tcase nil:\n")
+	g.printf("\t\tvar zero T\n")
+	g.printf("\t\treturn zero\n")
+	g.printf("\t}\n")
+	g.printf("\tpanic(\"unexpected %s value\")\n", goName)
+	g.printf("}\n")
+}
+
 func (g *generator) genDirectiveDefinition(def *ast.DirectiveDefinition) {
 	g.printf("var %s = graphql.NewDirective(graphql.DirectiveConfig{\n", goDirectiveDefName(def.Name.Value))
 	g.printf("\tName: %q,\n", def.Name.Value)
@@ -834,24 +950,30 @@ func (g *generator) genObjectDefinition(def *ast.ObjectDefinition) {
 	goName := goObjectDefName(def.Name.Value)
 	cycleTypes := g.cycleBreaks[def.Name.Value]
 	fieldDefNamesByFieldName := make(map[string]string, len(def.Fields))
-	var stubFields []*ast.FieldDefinition
+	var cycle bool
 	for _, f := range def.Fields {
-		fieldDefName := unexportedName(goName) + "Field" + exportedName(f.Name.Value)
-		fieldDefNamesByFieldName[f.Name.Value] = fieldDefName
 		if _, ok := cycleTypes[g.baseTypeName(f.Type)]; ok {
-			// Use a placeholder and set the actual type in an init function to break the cycle
-			g.printf("// Placeholder to break cycle. Actual type defined during init.\n")
-			g.printf("var %s = %s\n", fieldDefName, g.renderFieldDefinition("",
-				&ast.FieldDefinition{
-					Name: f.Name,
-					Type: &ast.Named{Name: &ast.Name{Value: "String"}},
-				}, "", true))
-			stubFields = append(stubFields, f)
-		} else {
-			g.printf("var %s = %s\n", fieldDefName, g.renderFieldDefinition(def.Name.Value, f, "", true))
+			// Defined in the fields thunk as the type refers back to this one
+			cycle = true
+			continue
 		}
+		fieldDefName := unexportedName(goName) + "Field" + exportedName(f.Name.Value)
+		fieldDefNamesByFieldName[f.Name.Value] = fieldDefName
+		g.printf("var %s = %s\n", fieldDefName, g.renderFieldDefinition(def.Name.Value, f, "", true))
 		g.print("\n")
 	}
+	var thunkName string
+	if cycle {
+		thunkName = g.genFieldsThunk(goName, "graphql.Fields", func() {
+			for _, f := range def.Fields {
+				if fieldDefName, ok := fieldDefNamesByFieldName[f.Name.Value]; ok {
+					g.printf("\t\t%q: %s,\n", f.Name.Value, fieldDefName)
+				} else {
+					g.printf("%s,\n", g.renderFieldDefinition(def.Name.Value, f, "\t\t", false))
+				}
+			}
+		})
+	}
 	if def.Doc != nil {
 		g.printf("%s\n", renderLineComments(def.Doc, ""))
 	} else if strings.HasSuffix(def.Name.Value, "Payload") {
@@ -869,25 +991,39 @@ func (g *generator) genObjectDefinition(def *ast.ObjectDefinition) {
 		}
 		g.printf("\t},\n")
 	}
-	g.printf("\tFields: graphql.Fields{\n")
-	for _, f := range def.Fields {
-		g.printf("\t%q: %s,\n", f.Name.Value, fieldDefNamesByFieldName[f.Name.Value])
+	if thunkName != "" {
+		g.printf("\tFields: graphql.FieldsThunk(func() graphql.Fields { return %s.fields() }),\n", thunkName)
+	} else {
+		g.printf("\tFields: graphql.Fields{\n")
+		for _, f := range def.Fields {
+			g.printf("\t%q: %s,\n", f.Name.Value, fieldDefNamesByFieldName[f.Name.Value])
+		}
+		g.printf("\t},\n")
 	}
-	g.printf("\t},\n")
 	g.printf("\tIsTypeOf: func(p graphql.IsTypeOfParams) bool {\n")
 	g.printf("\t\t_, ok := p.Value.(*%s)\n", exportedName(def.Name.Value))
 	g.printf("\t\treturn ok\n")
 	g.printf("\t},\n")
 	g.printf("})\n")
+}
 
-	if len(stubFields) != 0 {
-		g.printf("func init() {\n")
-		g.printf("\t// Create actual types for fields that can't be created during declaration because they're recursive\n")
-		for _, f := range stubFields {
-			g.printf("\t%s.AddFieldConfig(%q, %s)\n", goName, f.Name.Value, g.renderFieldDefinition(def.Name.Value, f, "\t\t", true))
-		}
-		g.printf("}\n")
-	}
+// genFieldsThunk generates the function called by the fields thunk of a type that
+// is part of a cycle and returns the name of the variable to call it through. The
+// function is a method called through an interface because Go reports references
+// from the initializers of package level variables as initialization cycles even
+// when they're in a function literal, but not calls of interface methods.
+func (g *generator) genFieldsThunk(goDefName, mapType string, genFields func()) string {
+	typeName := unexportedName(goDefName) + "Fields"
+	g.printf("// %s returns the fields of %s which is part of a cycle of types.\n", typeName, goDefName)
+	g.printf("type %s struct{}\n\n", typeName)
+	g.printf("func (%s) fields() %s {\n", typeName, mapType)
+	g.printf("\treturn %s{\n", mapType)
+	genFields()
+	g.printf("\t}\n")
+	g.printf("}\n\n")
+	g.printf("// Called through an interface to avoid an initialization cycle.\n")
+	g.printf("var %sThunk interface{ fields() %s } = %s{}\n\n", typeName, mapType, typeName)
+	return typeName + "Thunk"
 }
 
 func (g *generator) genObjectModel(def *ast.ObjectDefinition) {
@@ -925,11 +1061,18 @@ func (g *generator) genObjectModel(def *ast.ObjectDefinition) {
 		}
 	}
 
+	if g.isErrorType(def) {
+		g.printf("\nfunc (e *%s) Error() string {\n", goName)
+		g.printf("\treturn e.Message\n")
+		g.printf("}\n")
+	}
+
 	// Generate any argument structs
 	for _, f := range def.Fields {
 		if len(f.Arguments) != 0 {
 			g.printf("\ntype %s%sArgs struct {\n", goName, exportedName(f.Name.Value))
 			for _, a := range f.Arguments {
+				g.genDeprecatedComment(a.Directives, fmt.Sprintf("%s.%s(%s:)", def.Name.Value, f.Name.Value, a.Name.Value))
 				opts := []string{a.Name.Value}
 				if _, ok := a.Type.(*ast.NonNull); ok {
 					opts = append(opts, "nonempty")
@@ -950,6 +1093,14 @@ func (g *generator) hasCustomResolver(typeName, fieldName string) bool {
 	return false
 }
 
+// genDeprecatedComment prints a Deprecated: doc comment for a struct field if the
+// directives include @deprecated so that IDEs warn about its use.
+func (g *generator) genDeprecatedComment(dirs []*ast.Directive, parent string) {
+	if reason := g.deprecationReasonFromDirectives(dirs, parent); reason != "" {
+		g.printf("\t// Deprecated: %s\n", strings.Join(strings.Fields(reason), " "))
+	}
+}
+
 func isTopLevelObject(o string) bool {
 	switch o {
 	case "Mutation", "Query":
@@ -972,7 +1123,7 @@ func (g *generator) deprecationReasonFromDirectives(dirs []*ast.Directive, paren
 					if v, ok := a.Value.(*ast.StringValue); ok && v != nil {
 						deprecationReason = v.Value
 					}
-				} else {
+				} else if aName != "sunset" {
 					g.failf("Unsupport argument %q directive %q on %s", derefName(a.Name, ""), derefName(d.Name, ""), parent)
 				}
 			}
@@ -981,6 +1132,21 @@ func (g *generator) deprecationReasonFromDirectives(dirs []*ast.Directive, paren
 	return deprecationReason
 }
 
+// deprecationSunsetFromDirectives returns the sunset argument of the deprecated directive.
+func deprecationSunsetFromDirectives(dirs []*ast.Directive) string {
+	for _, d := range dirs {
+		if derefName(d.Name, "") != "deprecated" {
+			continue
+		}
+		for _, a := range d.Arguments {
+			if v, ok := a.Value.(*ast.StringValue); ok && v != nil && derefName(a.Name, "") == "sunset" {
+				return v.Value
+			}
+		}
+	}
+	return ""
+}
+
 //nolint:unparam
 func (g *generator) renderFieldDefinition(objName string, def *ast.FieldDefinition, indent string, noName bool) string {
 	comments := def.Doc
@@ -1046,6 +1212,9 @@ func (g *generator) renderFieldDefinition(objName string, def *ast.FieldDefiniti
 	if deprecationReason != "" {
 		lines = append(lines, fmt.Sprintf("%s\tDeprecationReason: %s,", indent, renderDeprecationReason(deprecationReason)))
 	}
+	if sunset := deprecationSunsetFromDirectives(def.Directives); sunset != "" {
+		lines = append(lines, fmt.Sprintf("%s\tDeprecationSunset: %q,", indent, sunset))
+	}
 	if directivesDef != "" {
 		lines = append(lines, directivesDef)
 	}
@@ -1056,9 +1225,19 @@ func (g *generator) renderFieldDefinition(objName string, def *ast.FieldDefiniti
 		if isTopLevelObject(goObjName) {
 			assertionType = "map[string]any"
 		}
-		lines = append(lines,
-			fmt.Sprintf("%s\tResolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {", indent),
-			fmt.Sprintf("%s\t\tr := p.Info.RootValue.(map[string]any)[%s].(%s)", indent, goObjName+"ResolversKey", goObjName+"Resolvers"))
+		if g.cfg.InstrumentResolvers {
+			lines = append(lines,
+				fmt.Sprintf("%s\tResolve: func(ctx context.Context, p graphql.ResolveParams) (_ any, err error) {", indent),
+				fmt.Sprintf("%s\t\tctx, end := graphql.StartResolver(ctx, %q)", indent, objName+"."+def.Name.Value),
+				fmt.Sprintf("%s\t\tdefer func() { end(err) }()", indent))
+		} else {
+			lines = append(lines, fmt.Sprintf("%s\tResolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {", indent))
+		}
+		if g.cfg.ResolverProviders {
+			lines = append(lines, fmt.Sprintf("%s\t\tr := graphql.MustProvider[%s](ctx, p)", indent, goObjName+"Resolvers"))
+		} else {
+			lines = append(lines, fmt.Sprintf("%s\t\tr := p.Info.RootValue.(map[string]any)[%s].(%s)", indent, goObjName+"ResolversKey", goObjName+"Resolvers"))
+		}
 		if len(def.Arguments) == 0 {
 			lines = append(lines, fmt.Sprintf("%s\t\treturn r.%s(ctx, p.Source.(%s), p)", indent, goFieldName, assertionType))
 		} else {
@@ -1086,45 +1265,41 @@ func (g *generator) renderFieldDefinition(objName string, def *ast.FieldDefiniti
 
 func (g *generator) genInputObjectDefinition(def *ast.InputObjectDefinition) {
 	goDefName := goInputObjectDefName(def.Name.Value)
+	cycleTypes := g.cycleBreaks[def.Name.Value]
+	var cycle bool
+	for _, f := range def.Fields {
+		if _, ok := cycleTypes[g.baseTypeName(f.Type)]; ok {
+			cycle = true
+		}
+	}
+	var thunkName string
+	if cycle {
+		thunkName = g.genFieldsThunk(goDefName, "graphql.InputObjectConfigFieldMap", func() {
+			for _, f := range def.Fields {
+				g.printf("%s,\n", g.renderInputValueDefinition(def, f, "\t\t", false))
+			}
+		})
+	}
 	if def.Doc != nil {
 		g.printf("%s\n", renderLineComments(def.Doc, ""))
 	} else if strings.HasSuffix(def.Name.Value, "Input") {
 		g.printf("// %s is the input type for the %s mutation.\n", goDefName, unexportedName(def.Name.Value[:len(def.Name.Value)-5]))
 	}
-	cycleTypes := g.cycleBreaks[def.Name.Value]
 	g.printf("var %s = graphql.NewInputObject(graphql.InputObjectConfig{\n", goDefName)
 	g.printf("\tName: %s,\n", strconv.Quote(def.Name.Value))
 	if def.Doc != nil {
 		g.printf("\tDescription: %s,\n", renderQuotedComments(def.Doc))
 	}
-	g.printf("\tFields: graphql.InputObjectConfigFieldMap{\n")
-	var stubFields []*ast.InputValueDefinition
-	for _, f := range def.Fields {
-		if _, ok := cycleTypes[g.baseTypeName(f.Type)]; ok {
-			// Use a placeholder and set the actual type in an init function to break the cycle
-			g.printf("\t\t// Placeholder to break cycle. Actual type defined during init.\n")
-			g.printf("%s,\n", g.renderInputValueDefinition(
-				def,
-				&ast.InputValueDefinition{
-					Name: f.Name,
-					Type: &ast.Named{Name: &ast.Name{Value: "String"}},
-				}, "\t\t", false))
-			stubFields = append(stubFields, f)
-		} else {
+	if thunkName != "" {
+		g.printf("\tFields: graphql.InputObjectConfigFieldMapThunk(func() graphql.InputObjectConfigFieldMap { return %s.fields() }),\n", thunkName)
+	} else {
+		g.printf("\tFields: graphql.InputObjectConfigFieldMap{\n")
+		for _, f := range def.Fields {
 			g.printf("%s,\n", g.renderInputValueDefinition(def, f, "\t\t", false))
 		}
+		g.printf("\t},\n")
 	}
-	g.printf("\t},\n")
 	g.printf("})\n")
-
-	if len(stubFields) != 0 {
-		g.printf("func init() {\n")
-		g.printf("\t// Create actual types for fields that can't be created during declaration because they're recursive\n")
-		for _, f := range stubFields {
-			g.printf("\t%s.AddInputField(%q, %s)\n", goDefName, f.Name.Value, g.renderInputValueDefinition(def, f, "\t\t", true))
-		}
-		g.printf("}\n")
-	}
 }
 
 func (g *generator) genInputModel(def *ast.InputObjectDefinition) {
@@ -1139,6 +1314,7 @@ func (g *generator) genInputModel(def *ast.InputObjectDefinition) {
 		if n, ok := g.cfg.NullableInputTypes[def.Name.Value]; (ok && n) || (!ok && *flagNullableInputs) {
 			iType = g.goInputType(f.Type, def.Name.Value+"."+f.Name.Value, true)
 		}
+		g.genDeprecatedComment(f.Directives, def.Name.Value+"."+f.Name.Value)
 		g.printf("\t%s %s `gql:%q json:%q`\n", exportedName(f.Name.Value), iType, f.Name.Value, f.Name.Value)
 	}
 	g.printf("}\n")
@@ -1146,7 +1322,8 @@ func (g *generator) genInputModel(def *ast.InputObjectDefinition) {
 
 func (g *generator) renderInputValueDefinition(objDef *ast.InputObjectDefinition, def *ast.InputValueDefinition, indent string, noName bool) string {
 	comment := renderLineComments(def.Comment, indent)
-	if def.Doc == nil && def.DefaultValue == nil {
+	deprecationReason := g.deprecationReasonFromDirectives(def.Directives, fmt.Sprintf("%s.%s", derefName(objDef.Name, ""), derefName(def.Name, "")))
+	if def.Doc == nil && def.DefaultValue == nil && deprecationReason == "" {
 		if comment != "" {
 			comment += "\n"
 		}
@@ -1172,6 +1349,9 @@ func (g *generator) renderInputValueDefinition(objDef *ast.InputObjectDefinition
 	if def.DefaultValue != nil {
 		lines = append(lines, fmt.Sprintf("%s\tDefaultValue: %s,", indent, g.renderValue(objDef.Name.Value+"."+def.Name.Value, def.Type, def.DefaultValue)))
 	}
+	if deprecationReason != "" {
+		lines = append(lines, fmt.Sprintf("%s\tDeprecationReason: %s,", indent, renderDeprecationReason(deprecationReason)))
+	}
 	lines = append(lines, indent+"}")
 	return strings.Join(lines, "\n")
 }
@@ -1207,7 +1387,8 @@ func (g *generator) renderASTArgument(def *ast.Argument, indent string, inSliceL
 
 func (g *generator) renderArgumentConfig(def *ast.InputValueDefinition, indent string) string {
 	comment := renderLineComments(def.Comment, indent)
-	if def.Doc == nil && def.DefaultValue == nil {
+	deprecationReason := g.deprecationReasonFromDirectives(def.Directives, derefName(def.Name, ""))
+	if def.Doc == nil && def.DefaultValue == nil && deprecationReason == "" {
 		if comment != "" {
 			comment += "\n"
 		}
@@ -1226,6 +1407,9 @@ func (g *generator) renderArgumentConfig(def *ast.InputValueDefinition, indent s
 	if def.DefaultValue != nil {
 		lines = append(lines, fmt.Sprintf("%s\tDefaultValue: %s,", indent, g.renderValue("", def.Type, def.DefaultValue)))
 	}
+	if deprecationReason != "" {
+		lines = append(lines, fmt.Sprintf("%s\tDeprecationReason: %s,", indent, renderDeprecationReason(deprecationReason)))
+	}
 	lines = append(lines, indent+"}")
 	return strings.Join(lines, "\n")
 }
@@ -1387,6 +1571,66 @@ func (g *generator) goType(t ast.Type, fieldName string) string {
 	return ""
 }
 
+// useDescriptions replaces the doc comments of definitions with their
+// descriptions so that descriptions are preferred over # comments.
+func useDescriptions(root *ast.Document) {
+	for _, def := range root.Definitions {
+		switch def := def.(type) {
+		case *ast.ObjectDefinition:
+			def.Doc = descriptionComments(def.Description, def.Doc)
+			for _, f := range def.Fields {
+				useFieldDescriptions(f)
+			}
+		case *ast.InterfaceDefinition:
+			def.Doc = descriptionComments(def.Description, def.Doc)
+			for _, f := range def.Fields {
+				useFieldDescriptions(f)
+			}
+		case *ast.InputObjectDefinition:
+			def.Doc = descriptionComments(def.Description, def.Doc)
+			for _, f := range def.Fields {
+				f.Doc = descriptionComments(f.Description, f.Doc)
+			}
+		case *ast.UnionDefinition:
+			def.Doc = descriptionComments(def.Description, def.Doc)
+		case *ast.EnumDefinition:
+			def.Doc = descriptionComments(def.Description, def.Doc)
+			for _, v := range def.Values {
+				if v.Description != nil {
+					// The line comment of an enum value is part of its description
+					v.Doc = descriptionComments(v.Description, v.Doc)
+					v.Comment = nil
+				}
+			}
+		case *ast.DirectiveDefinition:
+			for _, a := range def.Arguments {
+				a.Doc = descriptionComments(a.Description, a.Doc)
+			}
+		}
+	}
+}
+
+func useFieldDescriptions(def *ast.FieldDefinition) {
+	def.Doc = descriptionComments(def.Description, def.Doc)
+	for _, a := range def.Arguments {
+		a.Doc = descriptionComments(a.Description, a.Doc)
+	}
+}
+
+// descriptionComments returns the description as a comment group or the doc
+// comments if there's no description.
+func descriptionComments(desc *ast.StringValue, doc *ast.CommentGroup) *ast.CommentGroup {
+	if desc == nil || desc.Value == "" {
+		return doc
+	}
+	lines := strings.Split(desc.Value, "\n")
+	cg := &ast.CommentGroup{List: make([]*ast.Comment, len(lines))}
+	for i, line := range lines {
+		cg.List[i] = &ast.Comment{Text: "# " + line}
+	}
+	return cg
+}
+
 func renderLineComments(cg *ast.CommentGroup, indent string) string {
 	if cg == nil {
 		return ""
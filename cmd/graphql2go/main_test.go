@@ -1,6 +1,12 @@
 package main
 
-import "testing"
+import (
+	"bytes"
+	"testing"
+
+	"github.com/sprucehealth/graphql/language/ast"
+	"github.com/sprucehealth/graphql/language/parser"
+)
 
 func TestUnexportedName(t *testing.T) {
 	cases := []struct {
@@ -95,3 +101,257 @@ func TestUpperInitialisms(t *testing.T) {
 		})
 	}
 }
+
+func TestGenMatchHelper(t *testing.T) {
+	doc, err := parser.Parse(parser.ParseParams{Source: `
+		type Dog { name: String }
+		type Cat { name: String }
+		union Pet = Dog | Cat
+	`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := &bytes.Buffer{}
+	g := &generator{w: buf, doc: doc}
+	g.genMatchHelper("Pet", g.resolveUnionTypes(doc.Definitions[2].(*ast.UnionDefinition)))
+	expected := `
+// MatchPet calls the handler for the type of the Pet value and returns its result.
+// The zero value is returned for a nil value.
+func MatchPet[T any](v Pet, onDog func(*Dog) T, onCat func(*Cat) T) T {
+	switch v := v.(type) {
+	case *Dog:
+		return onDog(v)
+	case *Cat:
+		return onCat(v)
+	case nil:
+		var zero T
+		return zero
+	}
+	panic("unexpected Pet value")
+}
+`
+	if buf.String() != expected {
+		t.Fatalf("Expected:\n%s\nGot:\n%s", expected, buf.String())
+	}
+
+	// No helper for types without members
+	buf.Reset()
+	g.genMatchHelper("Empty", nil)
+	if buf.Len() != 0 {
+		t.Fatalf("Expected no output, got %s", buf.String())
+	}
+}
+
+func TestRenderDeprecatedInputValues(t *testing.T) {
+	doc, err := parser.Parse(parser.ParseParams{Source: `
+		input Filter {
+			name: String @deprecated(reason: "Use query")
+			query: String
+		}
+		type Query {
+			search(name: String @deprecated, query: String): String
+		}
+	`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := &generator{doc: doc}
+	input := doc.Definitions[0].(*ast.InputObjectDefinition)
+	expected := `		"name": &graphql.InputObjectFieldConfig{
+			Type: graphql.String,
+			DeprecationReason: "Use query",
+		}`
+	if s := g.renderInputValueDefinition(input, input.Fields[0], "\t\t", false); s != expected {
+		t.Fatalf("Expected:\n%s\nGot:\n%s", expected, s)
+	}
+	expected = `		"query": &graphql.InputObjectFieldConfig{Type: graphql.String}`
+	if s := g.renderInputValueDefinition(input, input.Fields[1], "\t\t", false); s != expected {
+		t.Fatalf("Expected:\n%s\nGot:\n%s", expected, s)
+	}
+	field := doc.Definitions[1].(*ast.ObjectDefinition).Fields[0]
+	expected = `		"name": &graphql.ArgumentConfig{
+			Type: graphql.String,
+			DeprecationReason: "No reason given",
+		}`
+	if s := g.renderArgumentConfig(field.Arguments[0], "\t\t"); s != expected {
+		t.Fatalf("Expected:\n%s\nGot:\n%s", expected, s)
+	}
+}
+
+func TestGenDeprecatedModelFields(t *testing.T) {
+	doc, err := parser.Parse(parser.ParseParams{Source: `
+		input Filter {
+			name: String @deprecated(reason: "Use query")
+			query: String
+		}
+		type Query {
+			search(name: String @deprecated, query: String): String
+		}
+	`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := &bytes.Buffer{}
+	g := &generator{w: buf, doc: doc}
+	g.genInputModel(doc.Definitions[0].(*ast.InputObjectDefinition))
+	expected := `type Filter struct {
+	// Deprecated: Use query
+	Name string ` + "`gql:\"name\" json:\"name\"`" + `
+	Query string ` + "`gql:\"query\" json:\"query\"`" + `
+}
+`
+	if buf.String() != expected {
+		t.Fatalf("Expected:\n%s\nGot:\n%s", expected, buf.String())
+	}
+
+	buf.Reset()
+	g.genObjectModel(doc.Definitions[1].(*ast.ObjectDefinition))
+	expected = `type Query struct {
+	Search string ` + "`json:\"search,omitempty\"`" + `
+}
+
+type QuerySearchArgs struct {
+	// Deprecated: No reason given
+	Name string ` + "`gql:\"name\"`" + `
+	Query string ` + "`gql:\"query\"`" + `
+}
+`
+	if buf.String() != expected {
+		t.Fatalf("Expected:\n%s\nGot:\n%s", expected, buf.String())
+	}
+}
+
+func TestRenderInstrumentedResolver(t *testing.T) {
+	doc, err := parser.Parse(parser.ParseParams{Source: `
+		type User {
+			name: String
+		}
+	`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := &generator{doc: doc}
+	g.cfg.Resolvers = map[string][]string{"User": {"name"}}
+	g.cfg.InstrumentResolvers = true
+	field := doc.Definitions[0].(*ast.ObjectDefinition).Fields[0]
+	expected := `		"name": &graphql.Field{
+			Type: graphql.String,
+			Resolve: func(ctx context.Context, p graphql.ResolveParams) (_ any, err error) {
+				ctx, end := graphql.StartResolver(ctx, "User.name")
+				defer func() { end(err) }()
+				r := p.Info.RootValue.(map[string]any)[UserResolversKey].(UserResolvers)
+				return r.Name(ctx, p.Source.(*User), p)
+			},
+		}`
+	if s := g.renderFieldDefinition("User", field, "\t\t", false); s != expected {
+		t.Fatalf("Expected:\n%s\nGot:\n%s", expected, s)
+	}
+}
+
+func TestRenderProviderResolver(t *testing.T) {
+	doc, err := parser.Parse(parser.ParseParams{Source: `
+		type User {
+			name: String
+		}
+	`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := &generator{doc: doc}
+	g.cfg.Resolvers = map[string][]string{"User": {"name"}}
+	g.cfg.ResolverProviders = true
+	field := doc.Definitions[0].(*ast.ObjectDefinition).Fields[0]
+	expected := `		"name": &graphql.Field{
+			Type: graphql.String,
+			Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+				r := graphql.MustProvider[UserResolvers](ctx, p)
+				return r.Name(ctx, p.Source.(*User), p)
+			},
+		}`
+	if s := g.renderFieldDefinition("User", field, "\t\t", false); s != expected {
+		t.Fatalf("Expected:\n%s\nGot:\n%s", expected, s)
+	}
+}
+
+func TestGenErrorsAsData(t *testing.T) {
+	doc, err := parser.Parse(parser.ParseParams{Source: `
+		type User { name: String }
+		type NotFoundError { message: String! }
+		union UpdateUserResult = User | NotFoundError
+		union Named = User
+	`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := &bytes.Buffer{}
+	g := &generator{w: buf, doc: doc}
+	g.genObjectModel(doc.Definitions[1].(*ast.ObjectDefinition))
+	expected := `type NotFoundError struct {
+	Message string ` + "`json:\"message\"`" + `
+}
+
+func (e *NotFoundError) Error() string {
+	return e.Message
+}
+`
+	if buf.String() != expected {
+		t.Fatalf("Expected:\n%s\nGot:\n%s", expected, buf.String())
+	}
+
+	buf.Reset()
+	g.genErrorAsDataHelper(doc.Definitions[2].(*ast.UnionDefinition))
+	expected = `
+// UpdateUserResultFromError returns err as a UpdateUserResult value if it's one of its error types and
+// returns it as a field error otherwise.
+func UpdateUserResultFromError(err error) (any, error) {
+	return graphql.ErrorAsData(err, UpdateUserResultDef)
+}
+`
+	if buf.String() != expected {
+		t.Fatalf("Expected:\n%s\nGot:\n%s", expected, buf.String())
+	}
+
+	// No helper for unions without error types
+	buf.Reset()
+	g.genErrorAsDataHelper(doc.Definitions[3].(*ast.UnionDefinition))
+	if buf.Len() != 0 {
+		t.Fatalf("Expected no output, got %s", buf.String())
+	}
+}
+
+func TestGenCycleFieldsThunk(t *testing.T) {
+	doc, err := parser.Parse(parser.ParseParams{Source: `
+		input FilterInput {
+			name: String
+			and: [FilterInput!]
+		}
+	`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := &bytes.Buffer{}
+	g := newGenerator(buf, doc)
+	g.genInputObjectDefinition(doc.Definitions[0].(*ast.InputObjectDefinition))
+	expected := `// filterInputDefFields returns the fields of FilterInputDef which is part of a cycle of types.
+type filterInputDefFields struct{}
+
+func (filterInputDefFields) fields() graphql.InputObjectConfigFieldMap {
+	return graphql.InputObjectConfigFieldMap{
+		"name": &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"and": &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(FilterInputDef))},
+	}
+}
+
+// Called through an interface to avoid an initialization cycle.
+var filterInputDefFieldsThunk interface{ fields() graphql.InputObjectConfigFieldMap } = filterInputDefFields{}
+
+// FilterInputDef is the input type for the filter mutation.
+var FilterInputDef = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "FilterInput",
+	Fields: graphql.InputObjectConfigFieldMapThunk(func() graphql.InputObjectConfigFieldMap { return filterInputDefFieldsThunk.fields() }),
+})
+`
+	if buf.String() != expected {
+		t.Fatalf("Expected:\n%s\nGot:\n%s", expected, buf.String())
+	}
+}
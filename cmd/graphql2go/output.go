@@ -0,0 +1,42 @@
+package main
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+)
+
+// errStaleOutput is returned by writeOutput in verify mode if the file on disk
+// doesn't match the generated content.
+var errStaleOutput = errors.New("generated output is stale")
+
+// writeOutput writes the generated content to path unless the file already has
+// the same content in which case it's left untouched to preserve its timestamp
+// and avoid needless rebuilds. If verify is true nothing is written and
+// errStaleOutput is returned if the file is missing or its content differs.
+// The returned bool is true if the file was (or would have been) written.
+func writeOutput(path string, content []byte, verify bool) (bool, error) {
+	existing, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return false, err
+	}
+	if err == nil && sha256.Sum256(existing) == sha256.Sum256(content) {
+		return false, nil
+	}
+	if verify {
+		return true, fmt.Errorf("%s: %w", path, errStaleOutput)
+	}
+	// Write to a temporary file and rename it so that readers never see a
+	// partially written file.
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, content, 0o666); err != nil {
+		return false, err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWriteOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schema.go")
+
+	// Verify fails if the file doesn't exist
+	if _, err := writeOutput(path, []byte("a"), true); !errors.Is(err, errStaleOutput) {
+		t.Fatalf("Expected stale output error, got %v", err)
+	}
+
+	changed, err := writeOutput(path, []byte("a"), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !changed {
+		t.Fatal("Expected new file to be written")
+	}
+
+	// Set the modification time in the past to detect rewrites
+	past := time.Now().Add(-time.Hour).Truncate(time.Second)
+	if err := os.Chtimes(path, past, past); err != nil {
+		t.Fatal(err)
+	}
+	changed, err = writeOutput(path, []byte("a"), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changed {
+		t.Fatal("Expected unchanged file to not be written")
+	}
+	if fi, err := os.Stat(path); err != nil {
+		t.Fatal(err)
+	} else if !fi.ModTime().Equal(past) {
+		t.Fatalf("Expected modification time %s, got %s", past, fi.ModTime())
+	}
+	if _, err := writeOutput(path, []byte("a"), true); err != nil {
+		t.Fatalf("Expected up to date output to verify, got %v", err)
+	}
+
+	if _, err := writeOutput(path, []byte("b"), true); !errors.Is(err, errStaleOutput) {
+		t.Fatalf("Expected stale output error, got %v", err)
+	}
+	if b, err := os.ReadFile(path); err != nil {
+		t.Fatal(err)
+	} else if string(b) != "a" {
+		t.Fatalf("Expected verify to not write the file, got %q", b)
+	}
+
+	changed, err = writeOutput(path, []byte("b"), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, err := os.ReadFile(path); err != nil {
+		t.Fatal(err)
+	} else if !changed || string(b) != "b" {
+		t.Fatalf("Expected changed file to be written, got %q", b)
+	}
+}
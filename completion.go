@@ -0,0 +1,398 @@
+package graphql
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/sprucehealth/graphql/language/ast"
+	"github.com/sprucehealth/graphql/language/lexer"
+	"github.com/sprucehealth/graphql/language/source"
+)
+
+// CompletionKind is the kind of schema element of a completion or hover.
+type CompletionKind string
+
+// Kinds of completions.
+const (
+	CompletionField    CompletionKind = "field"
+	CompletionArgument CompletionKind = "argument"
+	CompletionType     CompletionKind = "type"
+)
+
+// Completion is a candidate to complete the name at the cursor of a document.
+type Completion struct {
+	Label string
+	Kind  CompletionKind
+	// Detail is the type of a field or argument or the kind of a type.
+	Detail        string
+	Documentation string
+	Deprecated    bool
+}
+
+// HoverInfo describes the schema element under the cursor of a document.
+type HoverInfo struct {
+	Kind CompletionKind
+	// Name is the name of the type, "Type.field" for a field, or
+	// "Type.field(arg:)" for an argument.
+	Name string
+	// Detail is the type of a field or argument or the kind of a type.
+	Detail            string
+	Documentation     string
+	DeprecationReason string
+}
+
+// Complete returns the fields, arguments, or types that can be used at the cursor
+// of a document that may be incomplete or invalid (e.g. as it's being typed in an
+// editor). The offset of the cursor is in runes. Candidates are filtered by the
+// part of the name before the cursor and sorted by label. It's meant as a
+// building block for editor tooling such as a language server.
+func Complete(schema *Schema, document string, offset int) []Completion {
+	tokens := lexTokens(document)
+	i, prefix := 0, ""
+	for ; i < len(tokens) && tokens[i].Start < offset; i++ {
+		if tokens[i].Kind == lexer.NAME && tokens[i].End >= offset {
+			prefix = string([]rune(tokens[i].Value)[:offset-tokens[i].Start])
+			break
+		}
+	}
+	s := &cursorScanner{schema: schema}
+	for _, tok := range tokens[:i] {
+		s.scan(tok)
+	}
+
+	var completions []Completion
+	switch frame := s.top(); {
+	case s.expectTypeCondition:
+		for _, name := range sortedKeys(schema.TypeMap()) {
+			if ttype := schema.Type(name); IsCompositeType(ttype) && !strings.HasPrefix(name, "__") {
+				completions = append(completions, typeCompletion(ttype))
+			}
+		}
+	case frame == nil:
+	case frame.kind == selectionFrame && s.prev.Kind != lexer.SPREAD && s.prev.Kind != lexer.AT:
+		if frame.ttype == nil {
+			break
+		}
+		completions = append(completions, Completion{
+			Label:         TypeNameMetaFieldDef.Name,
+			Kind:          CompletionField,
+			Detail:        TypeNameMetaFieldDef.Type.String(),
+			Documentation: TypeNameMetaFieldDef.Description,
+		})
+		var fields FieldDefinitionMap
+		switch ttype := frame.ttype.(type) {
+		case *Object:
+			fields = ttype.Fields()
+		case *Interface:
+			fields = ttype.Fields()
+		}
+		for _, name := range sortedKeys(fields) {
+			field := fields[name]
+			completions = append(completions, Completion{
+				Label:         field.Name,
+				Kind:          CompletionField,
+				Detail:        field.Type.String(),
+				Documentation: field.Description,
+				Deprecated:    field.DeprecationReason != "",
+			})
+		}
+	case frame.kind == argumentsFrame && s.prev.Kind != lexer.COLON && s.prev.Kind != lexer.DOLLAR:
+		for _, arg := range frame.args {
+			if !frame.used[arg.Name()] {
+				completions = append(completions, Completion{
+					Label:         arg.Name(),
+					Kind:          CompletionArgument,
+					Detail:        arg.Type.String(),
+					Documentation: arg.Description(),
+					Deprecated:    arg.DeprecationReason != "",
+				})
+			}
+		}
+	case frame.kind == variablesFrame && frame.typePosition:
+		for _, name := range sortedKeys(schema.TypeMap()) {
+			if ttype := schema.Type(name); IsInputType(ttype) && !strings.HasPrefix(name, "__") {
+				completions = append(completions, typeCompletion(ttype))
+			}
+		}
+	}
+
+	filtered := completions[:0]
+	for _, c := range completions {
+		if strings.HasPrefix(strings.ToLower(c.Label), strings.ToLower(prefix)) {
+			filtered = append(filtered, c)
+		}
+	}
+	sort.SliceStable(filtered, func(i, j int) bool { return filtered[i].Label < filtered[j].Label })
+	return filtered
+}
+
+// Hover returns a description of the field, argument, or type whose name is at
+// the cursor of a document that may be incomplete or invalid. The offset of the
+// cursor is in runes. It returns false if there's no known schema element at
+// the cursor.
+func Hover(schema *Schema, document string, offset int) (HoverInfo, bool) {
+	tokens := lexTokens(document)
+	i := 0
+	for ; i < len(tokens) && tokens[i].End < offset; i++ {
+	}
+	if i == len(tokens) || tokens[i].Kind != lexer.NAME || tokens[i].Start > offset {
+		return HoverInfo{}, false
+	}
+	tok := tokens[i]
+	var next lexer.Token
+	if i+1 < len(tokens) {
+		next = tokens[i+1]
+	}
+	s := &cursorScanner{schema: schema}
+	for _, tok := range tokens[:i] {
+		s.scan(tok)
+	}
+
+	switch frame := s.top(); {
+	case s.expectTypeCondition || (frame != nil && frame.kind == variablesFrame && frame.typePosition):
+		if ttype := schema.Type(tok.Value); ttype != nil {
+			return typeHover(ttype), true
+		}
+	case frame == nil:
+	case frame.kind == selectionFrame && s.prev.Kind != lexer.SPREAD && s.prev.Kind != lexer.AT && next.Kind != lexer.COLON:
+		if frame.ttype == nil {
+			break
+		}
+		if field := DefaultTypeInfoFieldDef(schema, frame.ttype, &ast.Field{Name: &ast.Name{Value: tok.Value}}); field != nil {
+			return HoverInfo{
+				Kind:              CompletionField,
+				Name:              frame.ttype.Name() + "." + field.Name,
+				Detail:            field.Type.String(),
+				Documentation:     field.Description,
+				DeprecationReason: field.DeprecationReason,
+			}, true
+		}
+	case frame.kind == argumentsFrame && next.Kind == lexer.COLON && s.prev.Kind != lexer.COLON:
+		for _, arg := range frame.args {
+			if arg.Name() == tok.Value {
+				return HoverInfo{
+					Kind:              CompletionArgument,
+					Name:              frame.owner + "(" + arg.Name() + ":)",
+					Detail:            arg.Type.String(),
+					Documentation:     arg.Description(),
+					DeprecationReason: arg.DeprecationReason,
+				}, true
+			}
+		}
+	}
+	return HoverInfo{}, false
+}
+
+func typeCompletion(ttype Type) Completion {
+	return Completion{
+		Label:         ttype.Name(),
+		Kind:          CompletionType,
+		Detail:        typeKind(ttype),
+		Documentation: ttype.Description(),
+	}
+}
+
+func typeHover(ttype Type) HoverInfo {
+	return HoverInfo{
+		Kind:          CompletionType,
+		Name:          ttype.Name(),
+		Detail:        typeKind(ttype),
+		Documentation: ttype.Description(),
+	}
+}
+
+// lexTokens returns the tokens of a document up to the first lexing error
+// without comments.
+func lexTokens(document string) []lexer.Token {
+	lex := lexer.New(source.New("GraphQL", document))
+	var tokens []lexer.Token
+	for {
+		tok, err := lex.NextToken()
+		if err != nil || tok.Kind == lexer.EOF {
+			return tokens
+		}
+		if tok.Kind != lexer.COMMENT {
+			tokens = append(tokens, tok)
+		}
+	}
+}
+
+type cursorFrameKind int
+
+const (
+	selectionFrame cursorFrameKind = iota
+	argumentsFrame
+	variablesFrame
+	valueFrame
+)
+
+type cursorFrame struct {
+	kind cursorFrameKind
+	// ttype is the type of a selection set.
+	ttype Type
+	// args are the arguments of the field or directive of an arguments frame
+	// named owner, and used the ones already given.
+	args  []*Argument
+	owner string
+	used  map[string]bool
+	// typePosition is true in a variables frame after the colon of a variable.
+	typePosition bool
+}
+
+// cursorScanner follows the tokens of a document to track the type of the
+// selection set, the field or directive of the arguments, or the variable
+// definitions at the end of the tokens scanned so far. It doesn't need the
+// document to be complete or valid.
+type cursorScanner struct {
+	schema *Schema
+	frames []*cursorFrame
+	prev   lexer.Token
+	// pendingType is the type of the next selection set.
+	pendingType Type
+	// args and owner are the arguments of the last field or directive.
+	args                []*Argument
+	owner               string
+	inOperation         bool
+	inFragment          bool
+	expectTypeCondition bool
+}
+
+func (s *cursorScanner) top() *cursorFrame {
+	if len(s.frames) == 0 {
+		return nil
+	}
+	return s.frames[len(s.frames)-1]
+}
+
+func (s *cursorScanner) push(frame *cursorFrame) {
+	s.frames = append(s.frames, frame)
+}
+
+func (s *cursorScanner) pop() *cursorFrame {
+	frame := s.top()
+	if frame != nil {
+		s.frames = s.frames[:len(s.frames)-1]
+	}
+	return frame
+}
+
+func (s *cursorScanner) scan(tok lexer.Token) {
+	defer func() { s.prev = tok }()
+	frame := s.top()
+	switch tok.Kind {
+	case lexer.BRACE_L:
+		if frame != nil && frame.kind != selectionFrame {
+			s.push(&cursorFrame{kind: valueFrame})
+			return
+		}
+		ttype := s.pendingType
+		if frame == nil && ttype == nil && !s.inOperation && !s.inFragment {
+			ttype = s.schema.QueryType()
+		}
+		s.push(&cursorFrame{kind: selectionFrame, ttype: ttype})
+		s.pendingType = nil
+		s.args = nil
+	case lexer.BRACE_R, lexer.PAREN_R:
+		if frame != nil && frame.kind == selectionFrame {
+			s.pendingType = nil
+			s.args = nil
+		}
+		s.pop()
+		if len(s.frames) == 0 && tok.Kind == lexer.BRACE_R {
+			s.inOperation, s.inFragment = false, false
+		}
+	case lexer.PAREN_L:
+		switch {
+		case s.prev.Kind == lexer.NAME && strings.HasPrefix(s.owner, "@"):
+			s.push(&cursorFrame{kind: argumentsFrame, args: s.args, owner: s.owner, used: make(map[string]bool)})
+		case frame == nil && s.inOperation:
+			s.push(&cursorFrame{kind: variablesFrame})
+		case s.prev.Kind == lexer.NAME && frame != nil && frame.kind == selectionFrame:
+			s.push(&cursorFrame{kind: argumentsFrame, args: s.args, owner: s.owner, used: make(map[string]bool)})
+		default:
+			s.push(&cursorFrame{kind: valueFrame})
+		}
+	case lexer.BRACKET_L:
+		if frame == nil || frame.kind != variablesFrame || !frame.typePosition {
+			s.push(&cursorFrame{kind: valueFrame})
+		}
+	case lexer.BRACKET_R:
+		if frame == nil || frame.kind != variablesFrame || !frame.typePosition {
+			s.pop()
+		}
+	case lexer.COLON:
+		if frame == nil {
+			return
+		}
+		switch frame.kind {
+		case argumentsFrame:
+			if s.prev.Kind == lexer.NAME {
+				frame.used[s.prev.Value] = true
+			}
+		case variablesFrame:
+			frame.typePosition = true
+		}
+	case lexer.EQUALS, lexer.DOLLAR:
+		if frame != nil && frame.kind == variablesFrame {
+			frame.typePosition = false
+		}
+	case lexer.NAME:
+		s.scanName(tok, frame)
+	}
+}
+
+func (s *cursorScanner) scanName(tok lexer.Token, frame *cursorFrame) {
+	switch {
+	case s.expectTypeCondition:
+		s.expectTypeCondition = false
+		s.pendingType = s.schema.Type(tok.Value)
+	case s.prev.Kind == lexer.AT:
+		s.args, s.owner = nil, "@"+tok.Value
+		if d := s.schema.Directive(tok.Value); d != nil {
+			s.args = d.Args
+		}
+	case frame == nil:
+		switch tok.Value {
+		case "query", "mutation", "subscription":
+			if s.prev.Kind != lexer.NAME {
+				s.inOperation = true
+				s.pendingType = s.rootType(tok.Value)
+			}
+		case "fragment":
+			s.inFragment = true
+		case "on":
+			s.expectTypeCondition = true
+		}
+	case frame.kind == selectionFrame:
+		if s.prev.Kind == lexer.SPREAD {
+			s.expectTypeCondition = tok.Value == "on"
+			s.pendingType = nil
+			return
+		}
+		s.pendingType, s.args, s.owner = nil, nil, ""
+		if frame.ttype == nil {
+			return
+		}
+		if field := DefaultTypeInfoFieldDef(s.schema, frame.ttype, &ast.Field{Name: &ast.Name{Value: tok.Value}}); field != nil {
+			s.pendingType, _ = GetNamed(field.Type).(Type)
+			s.args = field.Args
+			s.owner = frame.ttype.Name() + "." + field.Name
+		}
+	}
+}
+
+func (s *cursorScanner) rootType(operation string) Type {
+	var root *Object
+	switch operation {
+	case "query":
+		root = s.schema.QueryType()
+	case "mutation":
+		root = s.schema.MutationType()
+	case "subscription":
+		root = s.schema.SubscriptionType()
+	}
+	if root == nil {
+		return nil
+	}
+	return root
+}
@@ -0,0 +1,128 @@
+package graphql_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+func completionTestSchema(t *testing.T) *graphql.Schema {
+	petType := graphql.NewInterface(graphql.InterfaceConfig{
+		Name:        "Pet",
+		Description: "A pet.",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.String},
+		},
+	})
+	dogType := graphql.NewObject(graphql.ObjectConfig{
+		Name:       "Dog",
+		Interfaces: []*graphql.Interface{petType},
+		Fields: graphql.Fields{
+			"name":  &graphql.Field{Type: graphql.String},
+			"barks": &graphql.Field{Type: graphql.Boolean, Description: "Whether the dog barks."},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"pet": &graphql.Field{
+					Type:        petType,
+					Description: "Returns a pet by ID.",
+					Args: graphql.FieldConfigArgument{
+						"id":   &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID), Description: "The ID of the pet."},
+						"name": &graphql.ArgumentConfig{Type: graphql.String, DeprecationReason: "Use id."},
+					},
+				},
+				"pets": &graphql.Field{
+					Type:              graphql.NewList(petType),
+					DeprecationReason: "Use pet.",
+				},
+			},
+		}),
+		Types: []graphql.Type{dogType},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &schema
+}
+
+// splitCursor returns the document without the "|" marking the cursor and the
+// rune offset of the cursor.
+func splitCursor(document string) (string, int) {
+	i := strings.Index(document, "|")
+	return document[:i] + document[i+1:], len([]rune(document[:i]))
+}
+
+func TestComplete(t *testing.T) {
+	schema := completionTestSchema(t)
+	cases := map[string][]string{
+		`{ | }`:                                    {"__typename", "pet", "pets"},
+		`{ pe| }`:                                  {"pet", "pets"},
+		`query Q { pet(id: "1") { n| } }`:          {"name"},
+		`{ pet(id: "1") { ... on Dog { | } } }`:    {"__typename", "barks", "name"},
+		`{ pet(id: "1") { ... on | } }`:            {"Dog", "Pet", "Query"},
+		`{ pet(| }`:                                {"id", "name"},
+		`{ pet(id: "1", |) }`:                      {"name"},
+		`{ pet(id: |) }`:                           nil,
+		`{ pet(id: "1") @include(|) { name } }`:    {"if"},
+		`query ($id: |) { pet(id: $id) { name } }`: {"Boolean", "ID", "String"},
+		`fragment F on Dog { b| }`:                 {"barks"},
+		`{ pet(id: "1") { name } } |`:              nil,
+		`{ pet(id: "1") { unknown { | } } }`:       nil,
+	}
+	for doc, expected := range cases {
+		document, offset := splitCursor(doc)
+		var labels []string
+		for _, c := range graphql.Complete(schema, document, offset) {
+			labels = append(labels, c.Label)
+		}
+		if !reflect.DeepEqual(expected, labels) {
+			t.Errorf("Complete(%q) = %v, expected %v", doc, labels, expected)
+		}
+	}
+
+	document, offset := splitCursor(`{ pe| }`)
+	expected := []graphql.Completion{
+		{Label: "pet", Kind: graphql.CompletionField, Detail: "Pet", Documentation: "Returns a pet by ID."},
+		{Label: "pets", Kind: graphql.CompletionField, Detail: "[Pet]", Deprecated: true},
+	}
+	if completions := graphql.Complete(schema, document, offset); !reflect.DeepEqual(expected, completions) {
+		t.Fatalf("Unexpected completions, Diff: %v", testutil.Diff(expected, completions))
+	}
+}
+
+func TestHover(t *testing.T) {
+	schema := completionTestSchema(t)
+	cases := map[string]graphql.HoverInfo{
+		`{ p|et(id: "1") { name } }`: {
+			Kind: graphql.CompletionField, Name: "Query.pet", Detail: "Pet", Documentation: "Returns a pet by ID.",
+		},
+		`{ pet(i|d: "1") { name } }`: {
+			Kind: graphql.CompletionArgument, Name: "Query.pet(id:)", Detail: "ID!", Documentation: "The ID of the pet.",
+		},
+		`{ pet(name: "Rex") { ... on Dog { bark|s } } }`: {
+			Kind: graphql.CompletionField, Name: "Dog.barks", Detail: "Boolean", Documentation: "Whether the dog barks.",
+		},
+		`fragment F on P|et { name }`: {
+			Kind: graphql.CompletionType, Name: "Pet", Detail: "INTERFACE", Documentation: "A pet.",
+		},
+	}
+	for doc, expected := range cases {
+		document, offset := splitCursor(doc)
+		if info, ok := graphql.Hover(schema, document, offset); !ok || !reflect.DeepEqual(expected, info) {
+			t.Errorf("Hover(%q) = %+v, %t, expected %+v", doc, info, ok, expected)
+		}
+	}
+
+	for _, doc := range []string{`{ al|ias: pet(id: "1") { name } }`, `{ pet(id: "1") { unkn|own } }`, `{ | }`} {
+		document, offset := splitCursor(doc)
+		if info, ok := graphql.Hover(schema, document, offset); ok {
+			t.Errorf("Hover(%q) = %+v, expected none", doc, info)
+		}
+	}
+}
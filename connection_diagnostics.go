@@ -0,0 +1,114 @@
+package graphql
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DiagnosticConnectionShape is a type named like a Relay connection, edge, or page
+// info type that doesn't follow the shape of the Relay cursor connections
+// specification. It's only reported when SchemaConfig.ValidateConnections is set.
+const DiagnosticConnectionShape SchemaDiagnosticKind = "connectionShape"
+
+const connectionSpecSuggestion = "See https://relay.dev/graphql/connections.htm for the expected shape."
+
+// connectionDiagnostics checks that the object types named "*Connection" and
+// "*Edge" and the "PageInfo" type have the fields required by the Relay cursor
+// connections specification:
+//
+//   - A connection has an `edges` field that returns a list of edge objects and a
+//     `pageInfo` field that returns a non-null PageInfo.
+//   - An edge has a `node` field that doesn't return a list and a `cursor` field
+//     that returns a String or custom scalar.
+//   - PageInfo has non-null Boolean `hasPreviousPage` and `hasNextPage` fields and
+//     `startCursor` and `endCursor` fields that return a String or custom scalar.
+func connectionDiagnostics(types map[string]Type) SchemaDiagnostics {
+	var diagnostics SchemaDiagnostics
+	add := func(typeName, fieldName, format string, args ...any) {
+		diagnostics = append(diagnostics, SchemaDiagnostic{
+			Kind:       DiagnosticConnectionShape,
+			Type:       typeName,
+			Field:      fieldName,
+			Message:    fmt.Sprintf(format, args...),
+			Suggestion: connectionSpecSuggestion,
+		})
+	}
+	for _, name := range sortedKeys(types) {
+		isConnection := strings.HasSuffix(name, "Connection") && name != "Connection"
+		isEdge := strings.HasSuffix(name, "Edge") && name != "Edge"
+		if !isConnection && !isEdge && name != "PageInfo" {
+			continue
+		}
+		obj, ok := types[name].(*Object)
+		if !ok {
+			add(name, "", "%s must be an object type.", name)
+			continue
+		}
+		fields := make(map[string]Type)
+		for _, f := range diagnosticFields(obj) {
+			if !isNilType(f.typ) {
+				fields[f.name] = f.typ
+			}
+		}
+		switch {
+		case isConnection:
+			edges, ok := fields["edges"]
+			if !ok {
+				add(name, "edges", "%s must have an edges field.", name)
+			} else if list, ok := nullableType(edges).(*List); !ok {
+				add(name, "edges", "%s.edges must return a list but returns %s.", name, edges)
+			} else if _, ok := nullableType(list.OfType).(*Object); !ok {
+				add(name, "edges", "%s.edges must return a list of edge objects but returns %s.", name, edges)
+			}
+			pageInfo, ok := fields["pageInfo"]
+			_, nonNull := pageInfo.(*NonNull)
+			if !ok {
+				add(name, "pageInfo", "%s must have a pageInfo field.", name)
+			} else if obj, ok := nullableType(pageInfo).(*Object); !ok || obj.Name() != "PageInfo" || !nonNull {
+				add(name, "pageInfo", "%s.pageInfo must return PageInfo! but returns %s.", name, pageInfo)
+			}
+		case isEdge:
+			node, ok := fields["node"]
+			if !ok {
+				add(name, "node", "%s must have a node field.", name)
+			} else if _, ok := nullableType(node).(*List); ok {
+				add(name, "node", "%s.node must not return a list but returns %s.", name, node)
+			}
+			checkCursorField(name, "cursor", fields, add)
+		default:
+			for _, fieldName := range []string{"hasPreviousPage", "hasNextPage"} {
+				t, ok := fields[fieldName]
+				if !ok {
+					add(name, fieldName, "%s must have a %s field.", name, fieldName)
+				} else if nonNull, ok := t.(*NonNull); !ok || nonNull.OfType != Boolean {
+					add(name, fieldName, "%s.%s must return Boolean! but returns %s.", name, fieldName, t)
+				}
+			}
+			checkCursorField(name, "startCursor", fields, add)
+			checkCursorField(name, "endCursor", fields, add)
+		}
+	}
+	return diagnostics
+}
+
+// checkCursorField checks that a cursor field returns a String or a custom scalar
+// that serializes as a string.
+func checkCursorField(typeName, fieldName string, fields map[string]Type, add func(typeName, fieldName, format string, args ...any)) {
+	t, ok := fields[fieldName]
+	if !ok {
+		add(typeName, fieldName, "%s must have a %s field.", typeName, fieldName)
+		return
+	}
+	scalar, ok := nullableType(t).(*Scalar)
+	if !ok || scalar == Int || scalar == Float || scalar == Boolean {
+		add(typeName, fieldName, "%s.%s must return a String or a custom scalar but returns %s.", typeName, fieldName, t)
+	}
+}
+
+// nullableType returns the type without its non-null wrapper.
+func nullableType(t Type) Type {
+	if nonNull, ok := t.(*NonNull); ok {
+		return nonNull.OfType
+	}
+	return t
+}
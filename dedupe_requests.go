@@ -0,0 +1,161 @@
+package graphql
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/sprucehealth/graphql/gqlerrors"
+	"github.com/sprucehealth/graphql/language/ast"
+	"github.com/sprucehealth/graphql/language/printer"
+)
+
+// RequestDeduplicator shares the execution of identical concurrent read-only
+// queries to protect backends from thundering herds. Requests are identical if
+// they have the same schema hash, document, operation name, variables, caller
+// identity, and enabled features (see WithFeatures). Requests with a root value
+// or with options that change the result (e.g. RestrictedTypes or
+// MaxResultBytes) aren't deduplicated. Only the first request executes the operation and the
+// others wait for and receive a copy of its result. The data of the result is
+// shared by all requests so it must not be modified.
+//
+// The shared execution isn't canceled when the context of the first request is
+// canceled as other requests may be waiting for it. Each request stops waiting
+// when its own context is done. Use ExecuteParams.Timeout to bound the execution.
+type RequestDeduplicator struct {
+	// CallerIdentity returns the identity of the caller of the request (e.g. the
+	// account ID) so that results are only shared between requests that are
+	// authorized the same way. It's required. Requests for which it returns an
+	// empty string aren't deduplicated.
+	CallerIdentity func(ctx context.Context) string
+
+	mu    sync.Mutex
+	calls map[string]*dedupedRequest
+}
+
+type dedupedRequest struct {
+	done   chan struct{}
+	result *Result
+}
+
+// execute returns the result of the shared execution of the request or false
+// if the request can't be deduplicated.
+func (d *RequestDeduplicator) execute(ctx context.Context, p ExecuteParams) (*Result, bool) {
+	key, ok := d.key(ctx, p)
+	if !ok {
+		return nil, false
+	}
+	d.mu.Lock()
+	call := d.calls[key]
+	if call == nil {
+		call = &dedupedRequest{done: make(chan struct{})}
+		if d.calls == nil {
+			d.calls = make(map[string]*dedupedRequest)
+		}
+		d.calls[key] = call
+		p.Deduplicator = nil
+		go func() {
+			result := Execute(context.WithoutCancel(ctx), p)
+			d.mu.Lock()
+			delete(d.calls, key)
+			call.result = result
+			d.mu.Unlock()
+			close(call.done)
+		}()
+	}
+	d.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.result.clone(), true
+	case <-ctx.Done():
+		return &Result{Errors: gqlerrors.FormatErrors(ctx.Err())}, true
+	}
+}
+
+// key returns the key of identical requests or false if the request isn't a
+// read-only query, overrides resolvers, or sets an option that isn't part of the
+// key.
+func (d *RequestDeduplicator) key(ctx context.Context, p ExecuteParams) (string, bool) {
+	if p.AST == nil || len(p.ResolverOverrides) != 0 || changesResult(p) {
+		return "", false
+	}
+	identity := d.CallerIdentity(ctx)
+	if identity == "" {
+		return "", false
+	}
+	operation, fragments := selectOperation(p.AST, p.OperationName)
+	if operation == nil || !newOperationInfo(&p.Schema, operation, fragments).ReadOnly() {
+		return "", false
+	}
+	vars, err := json.Marshal(p.Args)
+	if err != nil {
+		return "", false
+	}
+	h := sha256.New()
+	features := strings.Join(enabledFeatures(ctx), ",")
+	for _, s := range []string{p.Schema.Hash(), identity, features, p.OperationName, string(vars), printer.Print(p.AST)} {
+		h.Write([]byte(s))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil)), true
+}
+
+// changesResult returns true if the request has a root value or sets an option
+// that changes its data, errors, or extensions.
+func changesResult(p ExecuteParams) bool {
+	return p.Root != nil ||
+		len(p.RestrictedTypes) != 0 ||
+		p.MaxResultBytes > 0 ||
+		p.MaxErrors > 0 ||
+		p.StrictVariables ||
+		p.StrictJSON ||
+		p.DisallowIntrospection ||
+		p.Explain ||
+		p.OrderedData ||
+		p.Deterministic ||
+		p.CacheControl ||
+		p.GroupErrors ||
+		p.Masking != nil ||
+		p.Sunset != nil ||
+		p.Checkpoint != nil ||
+		p.replay != nil ||
+		p.resume != nil
+}
+
+// selectOperation returns the operation of the document with the name (or the
+// only operation if the name is empty) and the fragments. The operation is nil if
+// it isn't found.
+func selectOperation(doc *ast.Document, operationName string) (ast.Definition, map[string]*ast.FragmentDefinition) {
+	var operation ast.Definition
+	fragments := make(map[string]*ast.FragmentDefinition)
+	for _, def := range doc.Definitions {
+		switch def := def.(type) {
+		case *ast.OperationDefinition:
+			if operationName == "" && operation != nil {
+				return nil, nil
+			}
+			if operationName == "" || def.Name != nil && def.Name.Value == operationName {
+				operation = def
+			}
+		case *ast.FragmentDefinition:
+			if def.Name != nil {
+				fragments[def.Name.Value] = def
+			}
+		}
+	}
+	return operation, fragments
+}
+
+// clone returns a copy of the result that shares the data.
+func (r *Result) clone() *Result {
+	c := *r
+	c.Errors = slices.Clone(r.Errors)
+	c.Extensions = maps.Clone(r.Extensions)
+	return &c
+}
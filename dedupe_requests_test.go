@@ -0,0 +1,186 @@
+package graphql_test
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+type testAccountKey struct{}
+
+func TestRequestDeduplicator(t *testing.T) {
+	var calls atomic.Int32
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"slow": &graphql.Field{
+					Type: graphql.String,
+					Args: graphql.FieldConfigArgument{
+						"id": &graphql.ArgumentConfig{Type: graphql.String},
+					},
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						calls.Add(1)
+						select {
+						case started <- struct{}{}:
+						default:
+						}
+						<-release
+						return p.Args["id"], nil
+					},
+				},
+			},
+		}),
+		Mutation: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Mutation",
+			Fields: graphql.Fields{
+				"update": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						calls.Add(1)
+						return "updated", nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dedupe := &graphql.RequestDeduplicator{
+		CallerIdentity: func(ctx context.Context) string {
+			account, _ := ctx.Value(testAccountKey{}).(string)
+			return account
+		},
+	}
+	do := func(account, query string, vars map[string]any) *graphql.Result {
+		return graphql.Do(context.WithValue(context.Background(), testAccountKey{}, account), graphql.Params{
+			Schema:         schema,
+			RequestString:  query,
+			VariableValues: vars,
+			Deduplicator:   dedupe,
+		})
+	}
+
+	const query = `query Slow($id: String) { slow(id: $id) }`
+	results := make([]*graphql.Result, 5)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		results[0] = do("a1", query, map[string]any{"id": "1"})
+	}()
+	<-started
+	for i := 1; i < len(results); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = do("a1", query, map[string]any{"id": "1"})
+		}()
+	}
+	// Give the identical requests time to join the first one.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("Expected 1 call, got %d", n)
+	}
+	expected := &graphql.Result{Data: map[string]any{"slow": "1"}}
+	for _, r := range results {
+		if !reflect.DeepEqual(r, expected) {
+			t.Fatal(testutil.Diff(expected, r))
+		}
+	}
+
+	// Different variables, callers, and mutations aren't shared.
+	do("a1", query, map[string]any{"id": "2"})
+	do("a2", query, map[string]any{"id": "1"})
+	do("", query, map[string]any{"id": "1"})
+	do("a1", `mutation { update }`, nil)
+	if n := calls.Load(); n != 5 {
+		t.Fatalf("Expected 5 calls, got %d", n)
+	}
+}
+
+func TestRequestDeduplicator_NotShared(t *testing.T) {
+	started := make(chan struct{}, 2)
+	var release chan struct{}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"slow": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						started <- struct{}{}
+						<-release
+						return "ok", nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dedupe := &graphql.RequestDeduplicator{
+		CallerIdentity: func(ctx context.Context) string { return "a1" },
+	}
+	base := func(p graphql.Params) graphql.Params {
+		p.Schema = schema
+		p.RequestString = `{ slow }`
+		p.Deduplicator = dedupe
+		return p
+	}
+	ctx := context.Background()
+	cases := map[string]struct {
+		firstCtx, secondCtx context.Context
+		first, second       graphql.Params
+	}{
+		"features": {
+			firstCtx: graphql.WithFeatures(ctx, "beta"), secondCtx: ctx,
+			first: base(graphql.Params{}), second: base(graphql.Params{}),
+		},
+		"root": {
+			firstCtx: ctx, secondCtx: ctx,
+			first:  base(graphql.Params{RootObject: map[string]any{}}),
+			second: base(graphql.Params{RootObject: map[string]any{}}),
+		},
+		"options": {
+			firstCtx: ctx, secondCtx: ctx,
+			first:  base(graphql.Params{MaxResultBytes: 1 << 20}),
+			second: base(graphql.Params{MaxResultBytes: 1 << 20}),
+		},
+	}
+	for name, c := range cases {
+		release = make(chan struct{})
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			graphql.Do(c.firstCtx, c.first)
+		}()
+		<-started
+		go func() {
+			defer wg.Done()
+			graphql.Do(c.secondCtx, c.second)
+		}()
+		// The second request only resolves the field if it isn't shared
+		select {
+		case <-started:
+		case <-time.After(time.Second):
+			t.Errorf("%s: expected the requests not to be shared", name)
+		}
+		close(release)
+		wg.Wait()
+	}
+}
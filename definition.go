@@ -5,8 +5,11 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"sort"
+	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/sprucehealth/graphql/gqlerrors"
 	"github.com/sprucehealth/graphql/language/ast"
@@ -238,6 +241,10 @@ type Scalar struct {
 // SerializeFn is a function type for serializing a GraphQLScalar type value
 type SerializeFn func(value any) any
 
+// SerializeCtxFn is a function type for serializing a GraphQLScalar type value with
+// access to the request context (e.g. for per-request time zones or locales).
+type SerializeCtxFn func(ctx context.Context, value any) any
+
 // ParseValueFn is a function type for parsing the value of a GraphQLScalar type
 type ParseValueFn func(value any) any
 
@@ -246,9 +253,15 @@ type ParseLiteralFn func(valueAST ast.Value) any
 
 // ScalarConfig options for creating a new GraphQLScalar
 type ScalarConfig struct {
-	Name         string `json:"name"`
-	Description  string `json:"description"`
-	Serialize    SerializeFn
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// SpecifiedByURL is an optional URL of a specification of the scalar's behavior.
+	SpecifiedByURL string `json:"specifiedByURL"`
+	Serialize      SerializeFn
+	// SerializeCtx if provided is used instead of Serialize during execution. Serialize
+	// is optional if SerializeCtx is provided, in which case it's called with a
+	// background context outside of execution.
+	SerializeCtx SerializeCtxFn
 	ParseValue   ParseValueFn
 	ParseLiteral ParseLiteralFn
 }
@@ -269,7 +282,7 @@ func NewScalar(config ScalarConfig) *Scalar {
 	st.PrivateName = config.Name
 	st.PrivateDescription = config.Description
 
-	if config.Serialize == nil {
+	if config.Serialize == nil && config.SerializeCtx == nil {
 		st.err = gqlerrors.NewFormattedError(fmt.Sprintf(`%v must provide "serialize" function. If this custom Scalar is `+
 			`also used as an input type, ensure "parseValue" and "parseLiteral" `+
 			`functions are also provided.`, st))
@@ -287,10 +300,22 @@ func NewScalar(config ScalarConfig) *Scalar {
 }
 func (st *Scalar) Serialize(value any) any {
 	if st.scalarConfig.Serialize == nil {
+		if st.scalarConfig.SerializeCtx != nil {
+			return st.scalarConfig.SerializeCtx(context.Background(), value)
+		}
 		return value
 	}
 	return st.scalarConfig.Serialize(value)
 }
+
+// SerializeCtx serializes the value using the context aware serialize function
+// if one is provided and falls back to Serialize otherwise.
+func (st *Scalar) SerializeCtx(ctx context.Context, value any) any {
+	if st.scalarConfig.SerializeCtx != nil {
+		return st.scalarConfig.SerializeCtx(ctx, value)
+	}
+	return st.Serialize(value)
+}
 func (st *Scalar) ParseValue(value any) any {
 	if st.scalarConfig.ParseValue == nil {
 		return value
@@ -306,6 +331,11 @@ func (st *Scalar) ParseLiteral(valueAST ast.Value) any {
 func (st *Scalar) Name() string {
 	return st.PrivateName
 }
+
+// SpecifiedByURL returns the URL of a specification of the scalar's behavior if provided.
+func (st *Scalar) SpecifiedByURL() string {
+	return st.scalarConfig.SpecifiedByURL
+}
 func (st *Scalar) Description() string {
 	return st.PrivateDescription
 
@@ -356,11 +386,19 @@ type Object struct {
 	PrivateName        string `json:"name"`
 	PrivateDescription string `json:"description"`
 	IsTypeOf           IsTypeOfFn
+	// BatchResolve if set resolves all requested fields without a resolve function at once.
+	BatchResolve BatchResolveFn
+	// OnResolved if set is called with every value of the object before its fields are resolved.
+	OnResolved OnResolvedFn
+	// Bulkhead if set limits the number of resolvers of the type that run at once.
+	Bulkhead *Bulkhead
 
 	mu         sync.RWMutex
 	typeConfig ObjectConfig
 	fields     FieldDefinitionMap
 	interfaces []*Interface
+	// dependentFields is true if a field has dependencies.
+	dependentFields bool
 	// Interim alternative to throwing an error during schema definition at run-time
 	err atomic.Value
 }
@@ -385,7 +423,31 @@ type ObjectConfig struct {
 	Fields      any        `json:"fields"`
 	IsTypeOf    IsTypeOfFn `json:"isTypeOf"`
 	Description string     `json:"description"`
-}
+	// BatchResolve if set is called once per object value with all requested fields
+	// that don't have their own resolve function, instead of resolving each of them
+	// separately. This suits backends where a single call returns many fields.
+	BatchResolve BatchResolveFn `json:"-"`
+	// OnResolved if set is called with every value of the object type before its
+	// fields are resolved. The returned value is used as the source for the fields.
+	// It allows transformations such as redaction to be applied to a type in one
+	// place rather than in every resolver that returns it.
+	OnResolved OnResolvedFn `json:"-"`
+	// Bulkhead if set limits the number of resolvers of the fields of the type, and
+	// calls to BatchResolve, that run at once across all requests.
+	Bulkhead *Bulkhead `json:"-"`
+}
+
+// OnResolvedFn transforms the value of an object before its fields are resolved.
+// Returning an error fails the field that produced the value.
+type OnResolvedFn func(ctx context.Context, value any) (any, error)
+
+// FieldsThunk may be used as ObjectConfig.Fields or InterfaceConfig.Fields to define
+// the fields lazily. It's called once when the fields are first needed (at the latest
+// when the schema is built) which allows types that are created in a function to
+// refer to each other. Go reports references from the initializers of package level
+// variables as initialization cycles even in function literals, so the thunks of
+// package level types that refer to each other must call an interface method that
+// returns the fields (as the code generated by graphql2go does).
 type FieldsThunk func() Fields
 
 type errWrapper struct{ err error }
@@ -395,6 +457,9 @@ func NewObject(config ObjectConfig) *Object {
 		PrivateName:        config.Name,
 		PrivateDescription: config.Description,
 		IsTypeOf:           config.IsTypeOf,
+		BatchResolve:       config.BatchResolve,
+		OnResolved:         config.OnResolved,
+		Bulkhead:           config.Bulkhead,
 		typeConfig:         config,
 	}
 	objectType.setErr(nil)
@@ -460,9 +525,24 @@ func (gt *Object) Fields() FieldDefinitionMap {
 	fields, err := defineFieldMap(gt, configureFields)
 	gt.setErr(err)
 	gt.fields = fields
+	gt.dependentFields = false
+	for _, f := range fields {
+		if len(f.DependsOn) != 0 {
+			gt.dependentFields = true
+			break
+		}
+	}
 	return gt.fields
 }
 
+// hasDependentFields returns true if a field of the object depends on other fields.
+func (gt *Object) hasDependentFields() bool {
+	gt.Fields()
+	gt.mu.RLock()
+	defer gt.mu.RUnlock()
+	return gt.dependentFields
+}
+
 func (gt *Object) Interfaces() []*Interface {
 	gt.mu.RLock()
 	interfaces := gt.interfaces
@@ -547,10 +627,25 @@ func defineFieldMap(ttype Named, fields Fields) (FieldDefinitionMap, error) {
 			Resolve:           field.Resolve,
 			DeprecationReason: field.DeprecationReason,
 			Directives:        field.Directives,
+			Metadata:          field.Metadata,
+			Passthrough:       field.Passthrough,
+			ValidateArgs:      field.ValidateArgs,
+			Subscribe:         field.Subscribe,
+			DependsOn:         field.DependsOn,
+			resultType:        field.ResultType,
+			maxArgs:           field.MaxArgs,
+			DeprecationSunset: field.DeprecationSunset,
+			detachedTimeout:   field.DetachedTimeout,
+			rawJSON:           field.RawJSON,
+		}
+		fieldDef.hidden, fieldDef.feature = fieldVisibility(field.Directives)
+		if err := fieldDef.setSunset(field.DeprecationSunset, field.Directives); err != nil {
+			return resultFieldMap, gqlerrors.NewFormattedError(fmt.Sprintf(`%v.%v %v`, ttype, fieldName, err))
 		}
 
 		if len(field.Args) != 0 {
 			fieldDef.Args = make([]*Argument, 0, len(field.Args))
+			var hasAliases bool
 			for argName, arg := range field.Args {
 				err := assertValidName(argName)
 				if err != nil {
@@ -559,6 +654,10 @@ func defineFieldMap(ttype Named, fields Fields) (FieldDefinitionMap, error) {
 				if arg == nil {
 					return resultFieldMap, gqlerrors.NewFormattedError(fmt.Sprintf(`%v.%v args must be an object with argument names as keys.`, ttype, fieldName))
 				}
+				if arg.Injected != "" {
+					fieldDef.injectedArgs = append(fieldDef.injectedArgs, injectedArgument{name: argName, injector: arg.Injected})
+					continue
+				}
 				if arg.Type == nil {
 					return resultFieldMap, gqlerrors.NewFormattedError(fmt.Sprintf(`%v.%v(%v:) argument type must be Input Type but got: %v.`, ttype, fieldName, argName, arg.Type))
 				}
@@ -567,12 +666,34 @@ func defineFieldMap(ttype Named, fields Fields) (FieldDefinitionMap, error) {
 					PrivateDescription: arg.Description,
 					Type:               arg.Type,
 					DefaultValue:       arg.DefaultValue,
+					DeprecationReason:  arg.DeprecationReason,
+					Aliases:            arg.Aliases,
 				}
 				fieldDef.Args = append(fieldDef.Args, fieldArg)
+				if len(arg.Aliases) != 0 {
+					hasAliases = true
+				}
+			}
+			if hasAliases {
+				aliases := make(map[string][]string, len(fieldDef.Args))
+				for _, arg := range fieldDef.Args {
+					aliases[arg.PrivateName] = arg.Aliases
+				}
+				if err := checkAliases(fmt.Sprintf("%v.%v argument", ttype, fieldName), aliases); err != nil {
+					return resultFieldMap, err
+				}
+			}
+		}
+		if len(field.MaxArgs) != 0 {
+			if err := checkMaxArgs(ttype, fieldDef, field.MaxArgs); err != nil {
+				return resultFieldMap, err
 			}
 		}
 		resultFieldMap[fieldName] = fieldDef
 	}
+	if err := checkFieldDependencies(ttype, resultFieldMap); err != nil {
+		return resultFieldMap, err
+	}
 	return resultFieldMap, nil
 }
 
@@ -581,15 +702,27 @@ type ResolveParams struct {
 	// Source is the source value
 	Source any
 
-	// Args is a map of arguments for current GraphQL request
+	// Args is a map of arguments for current GraphQL request. The map and the input
+	// object and list values in it are copies that the resolver may modify, unless
+	// ExecuteParams.ShareArgs is set in which case they must be treated as read-only
+	// since the values of variables and default values are shared by all fields.
 	Args map[string]any
 
 	// Info is a collection of information about the current execution state.
 	Info ResolveInfo
+
+	// Dependencies are the values returned by the resolvers of the fields in
+	// Field.DependsOn keyed by field name. The values aren't completed.
+	Dependencies map[string]any
 }
 
 type FieldResolveFn func(ctx context.Context, p ResolveParams) (any, error)
 
+// FieldHookFn is called with the context of a field, its resolve info, and the
+// response keys of the path to the field before it's resolved. The path must not
+// be retained.
+type FieldHookFn func(ctx context.Context, info ResolveInfo, path []string)
+
 type ResolveInfo struct {
 	FieldName      string
 	FieldASTs      []*ast.Field
@@ -600,6 +733,59 @@ type ResolveInfo struct {
 	RootValue      any
 	Operation      ast.Definition
 	VariableValues map[string]any
+	// Metadata is the metadata of the field being resolved.
+	Metadata map[string]any
+}
+
+// OperationComments returns the text of the comments immediately preceding the
+// operation (e.g. "owner: team-x"). Comments are only available if the document
+// was parsed with comments (see Params.KeepComments).
+func (info ResolveInfo) OperationComments() []string {
+	if op, ok := info.Operation.(*ast.OperationDefinition); ok {
+		return commentLines(op.Doc)
+	}
+	return nil
+}
+
+// FieldComments returns the text of the comments immediately preceding the field
+// being resolved. If the field is selected more than once the comments of all
+// selections are returned.
+func (info ResolveInfo) FieldComments() []string {
+	var lines []string
+	for _, f := range info.FieldASTs {
+		lines = append(lines, commentLines(f.Doc)...)
+	}
+	return lines
+}
+
+// DirectiveArgs returns the coerced arguments of the named directive on the field
+// being resolved. Variables are replaced by their values and default values are
+// applied. It returns false if the directive isn't used on the field or isn't
+// defined in the schema.
+func (info ResolveInfo) DirectiveArgs(name string) (map[string]any, bool) {
+	directive := info.Schema.Directive(name)
+	if directive == nil {
+		return nil, false
+	}
+	for _, f := range info.FieldASTs {
+		for _, d := range f.Directives {
+			if d != nil && d.Name != nil && d.Name.Value == name {
+				return getArgumentValues(directive.Args, d.Arguments, info.VariableValues), true
+			}
+		}
+	}
+	return nil, false
+}
+
+func commentLines(cg *ast.CommentGroup) []string {
+	if cg == nil {
+		return nil
+	}
+	lines := make([]string, len(cg.List))
+	for i, c := range cg.List {
+		lines[i] = strings.TrimSpace(strings.TrimPrefix(c.Text, "#"))
+	}
+	return lines
 }
 
 type Fields map[string]*Field
@@ -612,14 +798,73 @@ type Field struct {
 	DeprecationReason string           `json:"deprecationReason,omitempty"`
 	Description       string           `json:"description"`
 	Directives        []*ast.Directive `json:"directives,omitempty"`
-}
+	// DeprecationSunset if set is the date (YYYY-MM-DD) after which the deprecated
+	// field is removed. If it's not set the sunset argument of a @deprecated
+	// directive in Directives (e.g. from an SDL document) is used. The argument
+	// isn't part of the definition of the specified directive so it isn't
+	// introspected. See SunsetPolicy.
+	DeprecationSunset string `json:"-"`
+	// Metadata is arbitrary machine-readable data attached to the field
+	// that is made available to resolvers through ResolveInfo.
+	Metadata map[string]any `json:"-"`
+	// Passthrough if true treats the resolved value as pre-resolved data (e.g. a
+	// result proxied from another GraphQL API). Nested resolvers are not called
+	// and the value is only projected onto the requested selection set.
+	Passthrough bool `json:"-"`
+	// ValidateArgs if set is called with the coerced arguments before the field is
+	// resolved. A returned error is reported as invalid input for the field. It's
+	// meant for constraints between arguments (e.g. exactly one of two arguments).
+	ValidateArgs ValidateArgsFn `json:"-"`
+	// Subscribe returns the source stream of a root field of the subscription type.
+	// It's called once by Subscribe and every event of the stream executes the
+	// operation with the event as the root value. See SubscribeFn.
+	Subscribe SubscribeFn `json:"-"`
+	// DependsOn are the names of sibling fields of the object whose values the
+	// resolver needs (e.g. the line items of an order to compute its total). They're
+	// resolved first with their default arguments, whether or not they're selected,
+	// and passed in ResolveParams.Dependencies. A dependency is resolved once per
+	// object value: when it's also selected without arguments its resolver isn't
+	// called again.
+	DependsOn []string `json:"-"`
+	// ResultType if set is the Go type of the values returned by Resolve. It's
+	// checked against Type when SchemaConfig.CheckResolverTypes is set. See
+	// TypedResolver.
+	ResultType reflect.Type `json:"-"`
+	// MaxArgs are the maximum values of Int arguments keyed by argument name (e.g.
+	// first or limit for pagination). Larger values are replaced by the maximum
+	// before the field is resolved and reported in the result extensions under
+	// ClampedArgumentsExtensionKey.
+	MaxArgs map[string]int `json:"-"`
+	// DetachedTimeout if greater than 0 calls the resolver with a context that
+	// isn't canceled with the request but has its own timeout instead (e.g. for a
+	// field with a side effect such as an audit log entry that must complete even
+	// if the request times out). The values of the request context are kept. The
+	// result of a resolver that returns after the request is done is discarded.
+	DetachedTimeout time.Duration `json:"-"`
+	// RawJSON if true accepts a RawJSON value from the resolver of a scalar field
+	// that isn't named JSON (for which it's always accepted). See RawJSON.
+	RawJSON bool `json:"-"`
+}
+
+// ValidateArgsFn validates the coerced arguments of a field.
+type ValidateArgsFn func(ctx context.Context, args map[string]any) error
 
 type FieldConfigArgument map[string]*ArgumentConfig
 
 type ArgumentConfig struct {
-	Type         Input  `json:"type"`
-	DefaultValue any    `json:"defaultValue"`
-	Description  string `json:"description"`
+	Type              Input  `json:"type"`
+	DefaultValue      any    `json:"defaultValue"`
+	Description       string `json:"description"`
+	DeprecationReason string `json:"deprecationReason,omitempty"`
+	// Aliases are former names of the argument that are still accepted (e.g. during
+	// the deprecation window of a rename). They aren't part of the introspected
+	// schema and their use is reported as a deprecation warning.
+	Aliases []string `json:"-"`
+	// Injected if set is the name of the injector in SchemaConfig.ArgumentInjectors
+	// that provides the value of the argument from the context before the resolver
+	// is called (e.g. the organization of the authenticated account). The argument
+	// isn't part of the schema so clients can't provide it, and Type is ignored.
+	Injected string `json:"-"`
 }
 
 type FieldDefinitionMap map[string]*FieldDefinition
@@ -631,6 +876,32 @@ type FieldDefinition struct {
 	Resolve           FieldResolveFn   `json:"-"`
 	DeprecationReason string           `json:"deprecationReason,omitempty"`
 	Directives        []*ast.Directive `json:"directives,omitempty"`
+	Metadata          map[string]any   `json:"-"`
+	Passthrough       bool             `json:"-"`
+	ValidateArgs      ValidateArgsFn   `json:"-"`
+	Subscribe         SubscribeFn      `json:"-"`
+	DependsOn         []string         `json:"-"`
+	DeprecationSunset string           `json:"-"`
+
+	// dependedOn is true if another field of the type depends on the field.
+	dependedOn bool
+	// injectedArgs are the arguments provided by argument injectors.
+	injectedArgs []injectedArgument
+	// resultType is the Go type returned by the resolver if it's known.
+	resultType reflect.Type
+	// maxArgs are the maximum values of Int arguments.
+	maxArgs map[string]int
+	// detachedTimeout is the timeout of the context of the resolver if it's
+	// detached from the request.
+	detachedTimeout time.Duration
+	// hidden is true if the field has the @hidden directive.
+	hidden bool
+	// feature is the name of the feature of the @feature directive of the field.
+	feature string
+	// rawJSON is true if the field accepts RawJSON values.
+	rawJSON bool
+	// sunset is the parsed DeprecationSunset.
+	sunset time.Time
 }
 
 type FieldArgument struct {
@@ -641,10 +912,12 @@ type FieldArgument struct {
 }
 
 type Argument struct {
-	PrivateName        string `json:"name"`
-	Type               Input  `json:"type"`
-	DefaultValue       any    `json:"defaultValue"`
-	PrivateDescription string `json:"description"`
+	PrivateName        string   `json:"name"`
+	Type               Input    `json:"type"`
+	DefaultValue       any      `json:"defaultValue"`
+	PrivateDescription string   `json:"description"`
+	DeprecationReason  string   `json:"deprecationReason,omitempty"`
+	Aliases            []string `json:"-"`
 }
 
 func (st *Argument) Name() string {
@@ -911,6 +1184,10 @@ type EnumValueConfig struct {
 	Value             any    `json:"value"`
 	DeprecationReason string `json:"deprecationReason,omitempty"`
 	Description       string `json:"description"`
+	// VisibleFn if set is called with the context of the request to decide whether
+	// the value is visible to the caller. Hidden values are left out of introspection
+	// and are rejected in arguments (e.g. for values behind a feature flag).
+	VisibleFn func(ctx context.Context) bool `json:"-"`
 }
 type EnumConfig struct {
 	Name        string             `json:"name"`
@@ -918,10 +1195,16 @@ type EnumConfig struct {
 	Description string             `json:"description"`
 }
 type EnumValueDefinition struct {
-	Name              string `json:"name"`
-	Value             any    `json:"value"`
-	DeprecationReason string `json:"deprecationReason,omitempty"`
-	Description       string `json:"description"`
+	Name              string                         `json:"name"`
+	Value             any                            `json:"value"`
+	DeprecationReason string                         `json:"deprecationReason,omitempty"`
+	Description       string                         `json:"description"`
+	VisibleFn         func(ctx context.Context) bool `json:"-"`
+}
+
+// IsVisible returns whether the value is visible to the caller of the request.
+func (v *EnumValueDefinition) IsVisible(ctx context.Context) bool {
+	return v.VisibleFn == nil || v.VisibleFn(ctx)
 }
 
 func NewEnum(config EnumConfig) *Enum {
@@ -963,12 +1246,16 @@ func (gt *Enum) defineEnumValues(valueMap EnumValueConfigMap) ([]*EnumValueDefin
 			Value:             valueConfig.Value,
 			DeprecationReason: valueConfig.DeprecationReason,
 			Description:       valueConfig.Description,
+			VisibleFn:         valueConfig.VisibleFn,
 		}
 		if value.Value == nil {
 			value.Value = valueName
 		}
 		values = append(values, value)
 	}
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].Name < values[j].Name
+	})
 	return values, nil
 }
 func (gt *Enum) Values() []*EnumValueDefinition {
@@ -1082,18 +1369,24 @@ type InputObject struct {
 	err error
 }
 type InputObjectFieldConfig struct {
-	Type         Input  `json:"type"`
-	DefaultValue any    `json:"defaultValue"`
-	Description  string `json:"description"`
+	Type              Input  `json:"type"`
+	DefaultValue      any    `json:"defaultValue"`
+	Description       string `json:"description"`
+	DeprecationReason string `json:"deprecationReason,omitempty"`
+	// Aliases are former names of the field that are still accepted. See
+	// ArgumentConfig.Aliases.
+	Aliases []string `json:"-"`
 }
 
 type InputObjectFields map[string]*InputObjectField
 
 type InputObjectField struct {
-	PrivateName        string `json:"name"`
-	Type               Input  `json:"type"`
-	DefaultValue       any    `json:"defaultValue"`
-	PrivateDescription string `json:"description"`
+	PrivateName        string   `json:"name"`
+	Type               Input    `json:"type"`
+	DefaultValue       any      `json:"defaultValue"`
+	PrivateDescription string   `json:"description"`
+	DeprecationReason  string   `json:"deprecationReason,omitempty"`
+	Aliases            []string `json:"-"`
 }
 
 func (st *InputObjectField) Name() string {
@@ -1112,6 +1405,9 @@ func (st *InputObjectField) Error() error {
 
 type InputObjectConfigFieldMap map[string]*InputObjectFieldConfig
 type InputObjectFieldMap map[string]*InputObjectField
+
+// InputObjectConfigFieldMapThunk may be used as InputObjectConfig.Fields to define
+// the fields lazily in the same way as FieldsThunk.
 type InputObjectConfigFieldMapThunk func() InputObjectConfigFieldMap
 type InputObjectConfig struct {
 	Name        string `json:"name"`
@@ -1128,6 +1424,10 @@ func NewInputObject(config InputObjectConfig) *InputObject {
 	gt.PrivateName = config.Name
 	gt.PrivateDescription = config.Description
 	gt.typeConfig = config
+	if _, ok := config.Fields.(InputObjectConfigFieldMapThunk); ok {
+		// Defined when the fields are first needed
+		return gt
+	}
 	gt.mu.Lock()
 	defer gt.mu.Unlock()
 	gt.fields = gt.defineFieldMap()
@@ -1155,6 +1455,7 @@ func (gt *InputObject) defineFieldMap() InputObjectFieldMap {
 		fieldMap = gt.typeConfig.Fields.(InputObjectConfigFieldMapThunk)()
 	}
 	resultFieldMap := InputObjectFieldMap{}
+	var hasAliases bool
 
 	if len(fieldMap) == 0 {
 		gt.err = gqlerrors.NewFormattedError(fmt.Sprintf(`%v fields must be an object with field names as keys or a function which return such an object.`, gt))
@@ -1177,6 +1478,20 @@ func (gt *InputObject) defineFieldMap() InputObjectFieldMap {
 			Type:               fieldConfig.Type,
 			PrivateDescription: fieldConfig.Description,
 			DefaultValue:       fieldConfig.DefaultValue,
+			DeprecationReason:  fieldConfig.DeprecationReason,
+			Aliases:            fieldConfig.Aliases,
+		}
+		if len(fieldConfig.Aliases) != 0 {
+			hasAliases = true
+		}
+	}
+	if hasAliases {
+		aliases := make(map[string][]string, len(resultFieldMap))
+		for name, field := range resultFieldMap {
+			aliases[name] = field.Aliases
+		}
+		if err := checkAliases(fmt.Sprintf("%v field", gt), aliases); err != nil {
+			gt.err = err
 		}
 	}
 	return resultFieldMap
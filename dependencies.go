@@ -0,0 +1,130 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/sprucehealth/graphql/gqlerrors"
+	"github.com/sprucehealth/graphql/language/ast"
+)
+
+// checkFieldDependencies returns an error if a field depends on a field that
+// doesn't exist or has required arguments, or if dependencies form a cycle. It
+// marks the fields that other fields depend on.
+func checkFieldDependencies(ttype Named, fields FieldDefinitionMap) error {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int)
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case visiting:
+			return gqlerrors.NewFormattedError(fmt.Sprintf(`%v.%v depends on itself.`, ttype, name))
+		case visited:
+			return nil
+		}
+		state[name] = visiting
+		for _, dep := range fields[name].DependsOn {
+			depDef, ok := fields[dep]
+			if !ok {
+				return gqlerrors.NewFormattedError(fmt.Sprintf(`%v.%v depends on unknown field "%v".`, ttype, name, dep))
+			}
+			for _, arg := range depDef.Args {
+				if _, ok := arg.Type.(*NonNull); ok && arg.DefaultValue == nil {
+					return gqlerrors.NewFormattedError(fmt.Sprintf(`%v.%v depends on %v which has the required argument "%v".`, ttype, name, dep, arg.PrivateName))
+				}
+			}
+			depDef.dependedOn = true
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+		return nil
+	}
+	for _, name := range sortedKeys(fields) {
+		if err := visit(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fieldDependencies memoizes the values of the fields of an object value that
+// other fields depend on so that each is resolved once per object value.
+type fieldDependencies struct {
+	mu     sync.Mutex
+	values map[string]*dependencyValue
+}
+
+type dependencyValue struct {
+	once  sync.Once
+	value any
+	// panicked is the value recovered from a panic while resolving the field.
+	panicked any
+}
+
+// newFieldDependencies returns the dependencies of a value of the object type or
+// nil if no field of the type has dependencies.
+func newFieldDependencies(parentType *Object) *fieldDependencies {
+	if !parentType.hasDependentFields() {
+		return nil
+	}
+	return &fieldDependencies{values: make(map[string]*dependencyValue)}
+}
+
+// shares returns true if the value of a selected field is shared with the fields
+// that depend on it. Fields selected with arguments are resolved separately as
+// their value may differ.
+func (d *fieldDependencies) shares(fieldDef *FieldDefinition, fieldASTs []*ast.Field) bool {
+	return d != nil && fieldDef.dependedOn && len(fieldASTs[0].Arguments) == 0
+}
+
+// resolve returns the value of the field resolving it the first time. A panic
+// while resolving the field is raised again every time.
+func (d *fieldDependencies) resolve(ctx context.Context, eCtx *ExecutionContext, parentType *Object, source any, fieldDef *FieldDefinition, fieldASTs []*ast.Field, path []string) any {
+	d.mu.Lock()
+	v, ok := d.values[fieldDef.Name]
+	if !ok {
+		v = &dependencyValue{}
+		d.values[fieldDef.Name] = v
+	}
+	d.mu.Unlock()
+	// Fields executed concurrently wait for the first one to resolve the value
+	v.once.Do(func() {
+		defer func() {
+			v.panicked = recover()
+		}()
+		v.value, _ = resolveFieldValue(ctx, eCtx, parentType, fieldDef, source, fieldASTs, path, nil, d)
+	})
+	if v.panicked != nil {
+		panic(v.panicked)
+	}
+	return v.value
+}
+
+// resolveDependencies returns the values of the dependencies of the field. It
+// panics with an error for the field if a dependency fails.
+func (d *fieldDependencies) resolveDependencies(ctx context.Context, eCtx *ExecutionContext, parentType *Object, source any, fieldDef *FieldDefinition, fieldASTs []*ast.Field, path []string) map[string]any {
+	fields := parentType.Fields()
+	values := make(map[string]any, len(fieldDef.DependsOn))
+	for _, name := range fieldDef.DependsOn {
+		depASTs := []*ast.Field{{Loc: fieldASTs[0].Loc, Name: &ast.Name{Loc: fieldASTs[0].Loc, Value: name}}}
+		depPath := append(path[:len(path)-1:len(path)-1], name)
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					panic(gqlerrors.FormatError(NewLocatedError(
+						fmt.Sprintf("Dependency %q of field %q failed: %v", name, fieldDef.Name, r),
+						FieldASTsToNodeASTs(fieldASTs))))
+				}
+			}()
+			values[name] = d.resolve(ctx, eCtx, parentType, source, fields[name], depASTs, depPath)
+		}()
+	}
+	return values
+}
@@ -0,0 +1,169 @@
+package graphql_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+func TestFieldDependencies(t *testing.T) {
+	var itemsCalls int
+	var failItems bool
+	order := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Order",
+		Fields: graphql.Fields{
+			"items": &graphql.Field{
+				Type: graphql.NewList(graphql.Int),
+				Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+					itemsCalls++
+					if failItems {
+						return nil, errors.New("no items")
+					}
+					return p.Source.(map[string]any)["items"], nil
+				},
+			},
+			"total": &graphql.Field{
+				Type:      graphql.Int,
+				DependsOn: []string{"items"},
+				Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+					var total int
+					for _, v := range p.Dependencies["items"].([]int) {
+						total += v
+					}
+					return total, nil
+				},
+			},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"orders": &graphql.Field{
+					Type: graphql.NewList(order),
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						return []any{
+							map[string]any{"items": []int{1, 2}},
+							map[string]any{"items": []int{3}},
+						}, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name       string
+		query      string
+		failItems  bool
+		expected   map[string]any
+		itemsCalls int
+		errors     []string
+	}{
+		{
+			name:       "dependency not selected",
+			query:      `{ orders { total } }`,
+			expected:   map[string]any{"orders": []any{map[string]any{"total": 3}, map[string]any{"total": 3}}},
+			itemsCalls: 2,
+		},
+		{
+			name:  "dependency selected",
+			query: `{ orders { items total } }`,
+			expected: map[string]any{"orders": []any{
+				map[string]any{"items": []any{1, 2}, "total": 3},
+				map[string]any{"items": []any{3}, "total": 3},
+			}},
+			itemsCalls: 2,
+		},
+		{
+			name:      "dependency failed",
+			query:     `{ orders { total } }`,
+			failItems: true,
+			expected: map[string]any{"orders": []any{
+				map[string]any{"total": nil},
+				map[string]any{"total": nil},
+			}},
+			itemsCalls: 2,
+			errors: []string{
+				`Dependency "items" of field "total" failed: no items`,
+				`Dependency "items" of field "total" failed: no items`,
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			itemsCalls = 0
+			failItems = c.failItems
+			result := graphql.Do(context.Background(), graphql.Params{
+				Schema:        schema,
+				RequestString: c.query,
+			})
+			var errs []string
+			for _, e := range result.Errors {
+				errs = append(errs, e.Message)
+			}
+			if !reflect.DeepEqual(errs, c.errors) {
+				t.Fatalf("Unexpected errors: %v", errs)
+			}
+			if !reflect.DeepEqual(result.Data, c.expected) {
+				t.Fatal(testutil.Diff(c.expected, result.Data))
+			}
+			if itemsCalls != c.itemsCalls {
+				t.Fatalf("Expected items to be resolved %d times, got %d", c.itemsCalls, itemsCalls)
+			}
+		})
+	}
+}
+
+func TestFieldDependencies_InvalidSchema(t *testing.T) {
+	cases := []struct {
+		name     string
+		fields   graphql.Fields
+		expected string
+	}{
+		{
+			name: "unknown field",
+			fields: graphql.Fields{
+				"a": &graphql.Field{Type: graphql.Int, DependsOn: []string{"b"}},
+			},
+			expected: `Order.a depends on unknown field "b".`,
+		},
+		{
+			name: "cycle",
+			fields: graphql.Fields{
+				"a": &graphql.Field{Type: graphql.Int, DependsOn: []string{"b"}},
+				"b": &graphql.Field{Type: graphql.Int, DependsOn: []string{"a"}},
+			},
+			expected: `Order.a depends on itself.`,
+		},
+		{
+			name: "required argument",
+			fields: graphql.Fields{
+				"a": &graphql.Field{Type: graphql.Int, DependsOn: []string{"b"}},
+				"b": &graphql.Field{
+					Type: graphql.Int,
+					Args: graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)}},
+				},
+			},
+			expected: `Order.a depends on b which has the required argument "id".`,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := graphql.NewSchema(graphql.SchemaConfig{
+				Query: graphql.NewObject(graphql.ObjectConfig{Name: "Order", Fields: c.fields}),
+			})
+			if err == nil || !strings.Contains(err.Error(), c.expected) {
+				t.Fatalf("Expected error %q, got %v", c.expected, err)
+			}
+		})
+	}
+}
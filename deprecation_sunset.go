@@ -0,0 +1,166 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sprucehealth/graphql/gqlerrors"
+	"github.com/sprucehealth/graphql/language/ast"
+	"github.com/sprucehealth/graphql/language/visitor"
+)
+
+// SunsetExtensionKey is the key in Result.Extensions under which the deprecated
+// fields that were resolved to null after their sunset date are returned when
+// the SunsetPolicy mode is SunsetModeNull.
+const SunsetExtensionKey = "sunset"
+
+// SunsetMode is how the use of a deprecated field after its sunset date (see
+// Field.DeprecationSunset) is handled.
+type SunsetMode int
+
+const (
+	// SunsetModeReport only reports the use to SunsetPolicy.Report. It's the
+	// default.
+	SunsetModeReport SunsetMode = iota
+	// SunsetModeError fails the field with an error instead of resolving it.
+	SunsetModeError
+	// SunsetModeNull resolves the field to null without calling its resolver and
+	// adds a warning to the result extensions under SunsetExtensionKey.
+	SunsetModeNull
+)
+
+// SunsetPolicy enforces the sunset dates of deprecated fields so that fields can
+// be removed on schedule. A field is past its sunset once the sunset date is
+// over in UTC. See ExecuteParams.Sunset.
+type SunsetPolicy struct {
+	Mode SunsetMode
+	// Report if set is called before the operation is executed with the operation
+	// and the fields it uses that are past their sunset date (e.g. to log the
+	// clients that still need to migrate). It isn't called if there are none.
+	Report func(ctx context.Context, operation *OperationInfo, fields []DeprecationWarning)
+	// Now if set returns the current time. The default is time.Now.
+	Now func() time.Time
+}
+
+func (p *SunsetPolicy) now() time.Time {
+	if p.Now != nil {
+		return p.Now()
+	}
+	return time.Now()
+}
+
+// setSunset sets the sunset of the field from its config or from the sunset
+// argument of its @deprecated directive.
+func (fd *FieldDefinition) setSunset(sunset string, directives []*ast.Directive) error {
+	if sunset == "" {
+		for _, d := range directives {
+			if d.Name != nil && d.Name.Value == DeprecatedDirective.Name {
+				for _, arg := range d.Arguments {
+					if v, ok := arg.Value.(*ast.StringValue); ok && arg.Name != nil && arg.Name.Value == "sunset" {
+						sunset = v.Value
+					}
+				}
+			}
+		}
+	}
+	if sunset == "" {
+		return nil
+	}
+	t, err := time.Parse(time.DateOnly, sunset)
+	if err != nil {
+		return fmt.Errorf("sunset must be a date (YYYY-MM-DD) but got: %q.", sunset)
+	}
+	fd.DeprecationSunset = sunset
+	fd.sunset = t
+	return nil
+}
+
+// pastSunset returns true if the field has a sunset date that's over at the time.
+func (fd *FieldDefinition) pastSunset(now time.Time) bool {
+	return !fd.sunset.IsZero() && !now.Before(fd.sunset.AddDate(0, 0, 1))
+}
+
+// sunsetWarning returns the warning for the use of a field after its sunset date.
+func sunsetWarning(parent Type, fieldDef *FieldDefinition) DeprecationWarning {
+	coordinate := SchemaCoordinate{Name: parent.Name(), Member: fieldDef.Name}.String()
+	return DeprecationWarning{
+		Coordinate: coordinate,
+		Reason:     fieldDef.DeprecationReason,
+		Sunset:     fieldDef.DeprecationSunset,
+		Message:    fmt.Sprintf("%s was removed after %s: %s", coordinate, fieldDef.DeprecationSunset, fieldDef.DeprecationReason),
+	}
+}
+
+// sunsetFields returns the warnings for the fields used by the operation that are
+// past their sunset date in the order they first appear.
+func sunsetFields(schema *Schema, operation ast.Definition, fragments map[string]*ast.FragmentDefinition, now time.Time) []DeprecationWarning {
+	var warnings sunsetWarnings
+	typeInfo := NewTypeInfo(&TypeInfoConfig{Schema: schema})
+	opts := &visitor.VisitorOptions{
+		Enter: func(p visitor.VisitFuncParams) (string, any) {
+			node, ok := p.Node.(ast.Node)
+			if !ok {
+				return visitor.ActionNoChange, nil
+			}
+			typeInfo.Enter(node)
+			if _, ok := node.(*ast.Field); ok {
+				if fieldDef := typeInfo.FieldDef(); fieldDef != nil && fieldDef.pastSunset(now) {
+					if parent := typeInfo.ParentType(); parent != nil {
+						warnings.add(sunsetWarning(parent, fieldDef))
+					}
+				}
+			}
+			return visitor.ActionNoChange, nil
+		},
+		Leave: func(p visitor.VisitFuncParams) (string, any) {
+			if node, ok := p.Node.(ast.Node); ok {
+				typeInfo.Leave(node)
+			}
+			return visitor.ActionNoChange, nil
+		},
+	}
+	_ = visitor.Visit(operation, opts)
+	for _, name := range sortedKeys(fragments) {
+		_ = visitor.Visit(fragments[name], opts)
+	}
+	return warnings.warnings
+}
+
+// sunsetWarnings collects warnings once per coordinate. It's safe for concurrent use.
+type sunsetWarnings struct {
+	mu       sync.Mutex
+	seen     map[string]struct{}
+	warnings []DeprecationWarning
+}
+
+func (w *sunsetWarnings) add(warning DeprecationWarning) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, ok := w.seen[warning.Coordinate]; ok {
+		return
+	}
+	if w.seen == nil {
+		w.seen = make(map[string]struct{})
+	}
+	w.seen[warning.Coordinate] = struct{}{}
+	w.warnings = append(w.warnings, warning)
+}
+
+// enforceSunset handles the use of a field after its sunset date according to
+// the policy. It returns true if the field resolves to null.
+func (eCtx *ExecutionContext) enforceSunset(parentType *Object, fieldDef *FieldDefinition, fieldASTs []*ast.Field) bool {
+	if eCtx.sunset == nil || !fieldDef.pastSunset(eCtx.sunsetNow) {
+		return false
+	}
+	switch eCtx.sunset.Mode {
+	case SunsetModeError:
+		w := sunsetWarning(parentType, fieldDef)
+		panic(gqlerrors.FormatError(NewLocatedError(w.Message, FieldASTsToNodeASTs(fieldASTs))))
+	case SunsetModeNull:
+		eCtx.sunsetNulls.add(sunsetWarning(parentType, fieldDef))
+		return true
+	}
+	return false
+}
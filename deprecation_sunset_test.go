@@ -0,0 +1,89 @@
+package graphql_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+func TestSunsetPolicy(t *testing.T) {
+	schema, err := testutil.SchemaFromSDL(`
+		type Query {
+			name: String
+			fullName: String @deprecated(reason: "Use name.", sunset: "2025-12-31")
+			title: String! @deprecated(reason: "Unused.", sunset: "2026-06-30")
+		}
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	root := map[string]any{"name": "a", "fullName": "a", "title": "b"}
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var reports []string
+	policy := &graphql.SunsetPolicy{
+		Now: func() time.Time { return now },
+		Report: func(ctx context.Context, operation *graphql.OperationInfo, fields []graphql.DeprecationWarning) {
+			for _, f := range fields {
+				reports = append(reports, operation.Name+" "+f.Coordinate)
+			}
+		},
+	}
+	query := `query Names { name fullName title }`
+	warning := graphql.DeprecationWarning{
+		Coordinate: "Query.fullName",
+		Reason:     "Use name.",
+		Sunset:     "2025-12-31",
+		Message:    "Query.fullName was removed after 2025-12-31: Use name.",
+	}
+
+	// Uses are only reported by default
+	result := graphql.Do(context.Background(), graphql.Params{Schema: *schema, RootObject: root, RequestString: query, Sunset: policy})
+	if len(result.Errors) != 0 || result.Data.(map[string]any)["fullName"] != "a" {
+		t.Fatalf("Unexpected result %+v", result)
+	}
+	if expected := []string{"Names Query.fullName"}; !reflect.DeepEqual(expected, reports) {
+		t.Fatalf("Expected reports %v, got %v", expected, reports)
+	}
+
+	policy.Mode = graphql.SunsetModeNull
+	result = graphql.Do(context.Background(), graphql.Params{Schema: *schema, RootObject: root, RequestString: query, Sunset: policy})
+	expected := &graphql.Result{
+		Data:       map[string]any{"name": "a", "fullName": nil, "title": "b"},
+		Extensions: map[string]any{graphql.SunsetExtensionKey: []graphql.DeprecationWarning{warning}},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	// The sunset date is over at the end of the day
+	policy.Mode = graphql.SunsetModeError
+	now = time.Date(2026, 6, 30, 23, 59, 0, 0, time.UTC)
+	result = graphql.Do(context.Background(), graphql.Params{Schema: *schema, RootObject: root, RequestString: query, Sunset: policy})
+	if len(result.Errors) != 1 || result.Errors[0].Message != warning.Message || result.Data.(map[string]any)["title"] != "b" {
+		t.Fatalf("Expected an error for fullName only, got %+v", result)
+	}
+	now = now.Add(time.Minute)
+	// The error of the non-null title nulls the query so fullName must be resolved
+	// before it for both errors to be returned.
+	result = graphql.Do(context.Background(), graphql.Params{Schema: *schema, RootObject: root, RequestString: query, Sunset: policy, Deterministic: true})
+	if len(result.Errors) != 2 || result.Data != nil {
+		t.Fatalf("Expected errors for fullName and the non-null title, got %+v", result)
+	}
+
+	// Sunset dates must be dates
+	_, err = graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"a": &graphql.Field{Type: graphql.String, DeprecationReason: "Unused.", DeprecationSunset: "soon"},
+			},
+		}),
+	})
+	if err == nil || err.Error() != `Query.a sunset must be a date (YYYY-MM-DD) but got: "soon".` {
+		t.Fatalf("Expected an invalid sunset error, got %v", err)
+	}
+}
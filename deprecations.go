@@ -0,0 +1,127 @@
+package graphql
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/sprucehealth/graphql/language/ast"
+	"github.com/sprucehealth/graphql/language/visitor"
+)
+
+// DeprecationsExtensionKey is the key in Result.Extensions under which the
+// deprecation warnings are returned when ExecuteParams.DeprecationWarnings is set.
+const DeprecationsExtensionKey = "deprecations"
+
+// DeprecationWarning is a deprecated field, argument, or enum value used by a request.
+type DeprecationWarning struct {
+	// Coordinate is the schema coordinate of the deprecated element
+	// (e.g. "Query.user", "Query.user(id:)", or "Episode.JEDI").
+	Coordinate string `json:"coordinate"`
+	Reason     string `json:"reason"`
+	// Sunset is the date after which a deprecated field is removed if it's set.
+	Sunset  string `json:"sunset,omitempty"`
+	Message string `json:"message"`
+}
+
+// DocumentDeprecations returns the deprecated fields, arguments, and enum value
+// literals used by the named operation of a validated document, or by its only
+// operation if operationName is empty, as returned in the result extensions with
+// ExecuteParams.DeprecationWarnings. It returns nil if the document doesn't have
+// the operation.
+func DocumentDeprecations(schema *Schema, doc *ast.Document, operationName string) []DeprecationWarning {
+	operation, fragments := selectOperation(doc, operationName)
+	if operation == nil {
+		return nil
+	}
+	return collectDeprecations(schema, operation, fragments)
+}
+
+// collectDeprecations returns the deprecated fields, arguments, and enum value
+// literals used by the operation and fragments in the order they first appear.
+// Enum values provided through variables are not included.
+func collectDeprecations(schema *Schema, operation ast.Definition, fragments map[string]*ast.FragmentDefinition) []DeprecationWarning {
+	var warnings []DeprecationWarning
+	seen := make(map[string]struct{})
+	add := func(c SchemaCoordinate, reason string) {
+		coordinate := c.String()
+		if _, ok := seen[coordinate]; ok {
+			return
+		}
+		seen[coordinate] = struct{}{}
+		warnings = append(warnings, DeprecationWarning{
+			Coordinate: coordinate,
+			Reason:     reason,
+			Message:    fmt.Sprintf("%s is deprecated: %s", coordinate, reason),
+		})
+	}
+
+	typeInfo := NewTypeInfo(&TypeInfoConfig{Schema: schema})
+	opts := &visitor.VisitorOptions{
+		Enter: func(p visitor.VisitFuncParams) (string, any) {
+			node, ok := p.Node.(ast.Node)
+			if !ok {
+				return visitor.ActionNoChange, nil
+			}
+			// The input object of an object field is only known before entering it.
+			var inputObject *InputObject
+			if _, ok := node.(*ast.ObjectField); ok {
+				inputObject, _ = GetNamed(typeInfo.InputType()).(*InputObject)
+			}
+			typeInfo.Enter(node)
+			switch node := node.(type) {
+			case *ast.Field:
+				if fieldDef := typeInfo.FieldDef(); fieldDef != nil && fieldDef.DeprecationReason != "" {
+					if parent := typeInfo.ParentType(); parent != nil {
+						add(SchemaCoordinate{Name: parent.Name(), Member: fieldDef.Name}, fieldDef.DeprecationReason)
+					}
+				}
+			case *ast.Argument:
+				fieldDef := typeInfo.FieldDef()
+				arg := typeInfo.Argument()
+				if typeInfo.Directive() == nil && fieldDef != nil && arg != nil && arg.DeprecationReason != "" {
+					if parent := typeInfo.ParentType(); parent != nil {
+						add(SchemaCoordinate{Name: parent.Name(), Member: fieldDef.Name, Argument: arg.Name()}, arg.DeprecationReason)
+					}
+				}
+				if arg != nil && node.Name != nil && node.Name.Value != arg.Name() {
+					reason := fmt.Sprintf("Use %q instead.", arg.Name())
+					if directive := typeInfo.Directive(); directive != nil {
+						add(SchemaCoordinate{Directive: true, Name: directive.Name, Argument: node.Name.Value}, reason)
+					} else if parent := typeInfo.ParentType(); parent != nil && fieldDef != nil {
+						add(SchemaCoordinate{Name: parent.Name(), Member: fieldDef.Name, Argument: node.Name.Value}, reason)
+					}
+				}
+			case *ast.ObjectField:
+				if inputObject != nil && node.Name != nil {
+					if field := findInputField(inputObject, node.Name.Value); field != nil && field.PrivateName != node.Name.Value {
+						add(SchemaCoordinate{Name: inputObject.Name(), Member: node.Name.Value}, fmt.Sprintf("Use %q instead.", field.PrivateName))
+					}
+				}
+			case *ast.EnumValue:
+				if enum, ok := GetNamed(typeInfo.InputType()).(*Enum); ok {
+					if value, ok := enum.getNameLookup()[node.Value]; ok && value.DeprecationReason != "" {
+						add(SchemaCoordinate{Name: enum.Name(), Member: value.Name}, value.DeprecationReason)
+					}
+				}
+			}
+			return visitor.ActionNoChange, nil
+		},
+		Leave: func(p visitor.VisitFuncParams) (string, any) {
+			if node, ok := p.Node.(ast.Node); ok {
+				typeInfo.Leave(node)
+			}
+			return visitor.ActionNoChange, nil
+		},
+	}
+
+	_ = visitor.Visit(operation, opts)
+	names := make([]string, 0, len(fragments))
+	for name := range fragments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		_ = visitor.Visit(fragments[name], opts)
+	}
+	return warnings
+}
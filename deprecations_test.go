@@ -0,0 +1,92 @@
+package graphql_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+func TestDeprecationWarnings(t *testing.T) {
+	episode := graphql.NewEnum(graphql.EnumConfig{
+		Name: "Episode",
+		Values: graphql.EnumValueConfigMap{
+			"NEWHOPE": &graphql.EnumValueConfig{Value: 4},
+			"JEDI":    &graphql.EnumValueConfig{Value: 6, DeprecationReason: "Use NEWHOPE."},
+		},
+	})
+	resolve := func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+		return "ok", nil
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"old": &graphql.Field{
+					Type:              graphql.String,
+					DeprecationReason: "Use new.",
+					Resolve:           resolve,
+				},
+				"new": &graphql.Field{
+					Type: graphql.String,
+					Args: graphql.FieldConfigArgument{
+						"episode": &graphql.ArgumentConfig{Type: episode},
+						"legacy":  &graphql.ArgumentConfig{Type: graphql.Int, DeprecationReason: "No longer used."},
+					},
+					Resolve: resolve,
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	query := `
+		query Q {
+			old
+			...F
+			again: old
+		}
+		fragment F on Query {
+			new(episode: JEDI, legacy: 1)
+			other: new(episode: NEWHOPE)
+		}
+	`
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: query,
+	})
+	if result.Extensions != nil {
+		t.Fatalf("Expected no extensions without DeprecationWarnings, got %v", result.Extensions)
+	}
+
+	result = graphql.Do(context.Background(), graphql.Params{
+		Schema:              schema,
+		RequestString:       query,
+		DeprecationWarnings: true,
+	})
+	if len(result.Errors) != 0 {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+	expected := []graphql.DeprecationWarning{
+		{Coordinate: "Query.old", Reason: "Use new.", Message: "Query.old is deprecated: Use new."},
+		{Coordinate: "Episode.JEDI", Reason: "Use NEWHOPE.", Message: "Episode.JEDI is deprecated: Use NEWHOPE."},
+		{Coordinate: "Query.new(legacy:)", Reason: "No longer used.", Message: "Query.new(legacy:) is deprecated: No longer used."},
+	}
+	warnings := result.Extensions[graphql.DeprecationsExtensionKey]
+	if !reflect.DeepEqual(expected, warnings) {
+		t.Fatalf("Unexpected warnings, Diff: %v", testutil.Diff(expected, warnings))
+	}
+
+	result = graphql.Do(context.Background(), graphql.Params{
+		Schema:              schema,
+		RequestString:       `{ new }`,
+		DeprecationWarnings: true,
+	})
+	if result.Extensions != nil {
+		t.Fatalf("Expected no extensions without deprecated usage, got %v", result.Extensions)
+	}
+}
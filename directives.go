@@ -43,6 +43,10 @@ type Directive struct {
 	Description string      `json:"description"`
 	Locations   []string    `json:"locations"`
 	Args        []*Argument `json:"args"`
+	// IsRepeatable is true if the directive may be used more than once at a single location.
+	IsRepeatable bool `json:"isRepeatable"`
+	// NonIdempotent is true if using the directive in an operation has side effects.
+	NonIdempotent bool `json:"-"`
 
 	err error
 }
@@ -53,6 +57,11 @@ type DirectiveConfig struct {
 	Description string              `json:"description"`
 	Locations   []string            `json:"locations"`
 	Args        FieldConfigArgument `json:"args"`
+	// IsRepeatable if true allows the directive to be used more than once at a single location.
+	IsRepeatable bool `json:"isRepeatable"`
+	// NonIdempotent if true marks the directive as having side effects when used in an
+	// operation so that a query using it is not reported as read-only (see OperationInfo).
+	NonIdempotent bool `json:"-"`
 }
 
 func NewDirective(config DirectiveConfig) *Directive {
@@ -90,6 +99,8 @@ func NewDirective(config DirectiveConfig) *Directive {
 			PrivateDescription: argConfig.Description,
 			Type:               argConfig.Type,
 			DefaultValue:       argConfig.DefaultValue,
+			DeprecationReason:  argConfig.DeprecationReason,
+			Aliases:            argConfig.Aliases,
 		})
 	}
 
@@ -97,6 +108,8 @@ func NewDirective(config DirectiveConfig) *Directive {
 	dir.Description = config.Description
 	dir.Locations = config.Locations
 	dir.Args = args
+	dir.IsRepeatable = config.IsRepeatable
+	dir.NonIdempotent = config.NonIdempotent
 	return dir
 }
 
@@ -151,6 +164,8 @@ var DeprecatedDirective = NewDirective(DirectiveConfig{
 	},
 	Locations: []string{
 		DirectiveLocationFieldDefinition,
+		DirectiveLocationArgumentDefinition,
+		DirectiveLocationInputFieldDefinition,
 		DirectiveLocationEnumValue,
 	},
 })
@@ -680,3 +680,68 @@ func TestFieldDefinitionDirectiveHandler(t *testing.T) {
 		t.Fatalf("B was never checked by handler")
 	}
 }
+
+func TestResolveInfoDirectiveArgs(t *testing.T) {
+	formatDirective := graphql.NewDirective(graphql.DirectiveConfig{
+		Name:      "format",
+		Locations: []string{graphql.DirectiveLocationField},
+		Args: graphql.FieldConfigArgument{
+			"upper": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Boolean)},
+			"limit": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 3},
+			"tags":  &graphql.ArgumentConfig{Type: graphql.NewList(graphql.String)},
+		},
+	})
+	var args map[string]any
+	var found bool
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"a": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						args, found = p.Info.DirectiveArgs("format")
+						return "a", nil
+					},
+				},
+			},
+		}),
+		Directives: []*graphql.Directive{graphql.IncludeDirective, graphql.SkipDirective, formatDirective},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema:         schema,
+		RequestString:  `query Q($upper: Boolean!, $tags: [String]) { a @format(upper: $upper, tags: $tags) }`,
+		VariableValues: map[string]any{"upper": true, "tags": []any{"x", "y"}},
+	})
+	if len(result.Errors) != 0 {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+	expected := map[string]any{"upper": true, "limit": 3, "tags": []any{"x", "y"}}
+	if !found || !reflect.DeepEqual(expected, args) {
+		t.Fatalf("Unexpected directive args, Diff: %v", testutil.Diff(expected, args))
+	}
+
+	result = graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: `{ a }`,
+	})
+	if len(result.Errors) != 0 {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+	if found || args != nil {
+		t.Fatalf("Expected no directive args, got %v", args)
+	}
+
+	result = graphql.Do(context.Background(), graphql.Params{
+		Schema:         schema,
+		RequestString:  `query Q($upper: String) { a @format(upper: $upper) }`,
+		VariableValues: map[string]any{"upper": "yes"},
+	})
+	if len(result.Errors) != 1 || result.Errors[0].Message != `Variable "$upper" of type "String" used in position expecting type "Boolean!".` {
+		t.Fatalf("Expected variable position error, got %v", result.Errors)
+	}
+}
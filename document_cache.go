@@ -0,0 +1,128 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sprucehealth/graphql/gqlerrors"
+	"github.com/sprucehealth/graphql/language/ast"
+	"github.com/sprucehealth/graphql/language/parser"
+	"github.com/sprucehealth/graphql/language/source"
+)
+
+// DocumentCache stores parsed and validated request documents keyed by the
+// request string so that repeated requests skip parsing and validation. A cache
+// must only be used with a single schema as documents are validated against
+// the schema of the request that added them, unless the schema is selected by
+// Params.SchemaResolver in which case keys are prefixed by the schema hash.
+// Cached documents are shared between requests and must not be modified.
+type DocumentCache interface {
+	Get(query string) (*ast.Document, bool)
+	Add(query string, doc *ast.Document)
+}
+
+// MapDocumentCache is a DocumentCache backed by a map. Once the cache holds
+// MaxSize documents new documents aren't added which keeps documents added at
+// startup (e.g. from a manifest of persisted operations) in the cache.
+type MapDocumentCache struct {
+	// MaxSize is the maximum number of cached documents. If 0 the size isn't limited.
+	MaxSize int
+
+	mu   sync.RWMutex
+	docs map[string]*ast.Document
+}
+
+// Get implements DocumentCache.
+func (c *MapDocumentCache) Get(query string) (*ast.Document, bool) {
+	c.mu.RLock()
+	doc, ok := c.docs[query]
+	c.mu.RUnlock()
+	return doc, ok
+}
+
+// Add implements DocumentCache.
+func (c *MapDocumentCache) Add(query string, doc *ast.Document) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.docs == nil {
+		c.docs = make(map[string]*ast.Document)
+	}
+	if c.MaxSize > 0 && len(c.docs) >= c.MaxSize {
+		return
+	}
+	c.docs[query] = doc
+}
+
+// Len returns the number of cached documents.
+func (c *MapDocumentCache) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.docs)
+}
+
+// PrepareDocument parses and validates a request. If cache is not nil the
+// document is taken from the cache when present and added to it when valid.
+func PrepareDocument(schema *Schema, query string, cache DocumentCache) (*ast.Document, []gqlerrors.FormattedError) {
+	doc, errs := prepareDocument(schema, query, cache, nil, 0)
+	if len(errs) != 0 {
+		return nil, errs
+	}
+	return doc, nil
+}
+
+// prepareDocument is PrepareDocument with the library fragments spread by the
+// request added to the document if fragments is not nil. Cached documents aren't
+// modified so the library fragments are shared instead of copied.
+func prepareDocument(schema *Schema, query string, cache DocumentCache, fragments *FragmentLibrary, maxSelections int) (*ast.Document, []gqlerrors.FormattedError) {
+	if cache != nil {
+		if doc, ok := cache.Get(query); ok {
+			return doc, nil
+		}
+	}
+	var rewrite DocumentRewriterFn
+	if fragments != nil {
+		rewrite = func(ctx context.Context, doc *ast.Document) (*ast.Document, error) {
+			return fragments.Resolve(doc), nil
+		}
+	}
+	doc, errs := parseRewriteAndValidate(context.Background(), schema, query, parser.ParseOptions{}, rewrite, maxSelections)
+	if len(errs) == 0 && cache != nil {
+		cache.Add(query, doc)
+	}
+	return doc, errs
+}
+
+// parseAndValidate parses and validates a request. If maxSelections is greater than 0
+// then the expanded selections of the operations are checked before validation as
+// validating a document that expands to a very large number of fields is expensive.
+// The document is returned with the errors if it was parsed but isn't valid.
+func parseAndValidate(schema *Schema, query string, opts parser.ParseOptions, maxSelections int) (*ast.Document, []gqlerrors.FormattedError) {
+	return parseRewriteAndValidate(context.Background(), schema, query, opts, nil, maxSelections)
+}
+
+// parseRewriteAndValidate is parseAndValidate with the document rewritten by the
+// rewriter, if not nil, before it's validated.
+func parseRewriteAndValidate(ctx context.Context, schema *Schema, query string, opts parser.ParseOptions, rewrite DocumentRewriterFn, maxSelections int) (*ast.Document, []gqlerrors.FormattedError) {
+	doc, err := parser.Parse(parser.ParseParams{
+		Source:  source.New("GraphQL request", query),
+		Options: opts,
+	})
+	if err != nil {
+		return nil, gqlerrors.FormatErrors(err)
+	}
+	if rewrite != nil {
+		doc, err = rewrite(ctx, doc)
+		if err != nil {
+			return nil, gqlerrors.FormatErrors(err)
+		}
+	}
+	if maxSelections > 0 {
+		if err := CheckExpandedSelections(doc, maxSelections); err != nil {
+			return doc, []gqlerrors.FormattedError{selectionLimitError(err)}
+		}
+	}
+	if res := ValidateDocument(schema, doc, nil); !res.IsValid {
+		return doc, res.Errors
+	}
+	return doc, nil
+}
@@ -0,0 +1,65 @@
+package graphql_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/language/parser"
+)
+
+func TestDo_DocumentCache(t *testing.T) {
+	schema := testSchema(t, &graphql.Field{
+		Type: graphql.String,
+		Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+			return "ok", nil
+		},
+	})
+	cache := &graphql.MapDocumentCache{MaxSize: 2}
+
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: `{ test }`,
+		DocumentCache: cache,
+	})
+	expected := map[string]any{"test": "ok"}
+	if len(result.Errors) != 0 || !reflect.DeepEqual(expected, result.Data) {
+		t.Fatalf("Unexpected result %+v", result)
+	}
+	if cache.Len() != 1 {
+		t.Fatalf("Expected valid document to be cached, got %d documents", cache.Len())
+	}
+
+	// Invalid documents aren't cached
+	result = graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: `{ unknown }`,
+		DocumentCache: cache,
+	})
+	if len(result.Errors) != 1 || cache.Len() != 1 {
+		t.Fatalf("Expected validation error and no new cached document, got %v and %d documents", result.Errors, cache.Len())
+	}
+
+	// A cached document is used without parsing the request again
+	doc, err := parser.Parse(parser.ParseParams{Source: `{ alias: test }`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache.Add("not a query", doc)
+	result = graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: "not a query",
+		DocumentCache: cache,
+	})
+	expected = map[string]any{"alias": "ok"}
+	if len(result.Errors) != 0 || !reflect.DeepEqual(expected, result.Data) {
+		t.Fatalf("Unexpected result %+v", result)
+	}
+
+	// The cache is full
+	cache.Add("other", doc)
+	if cache.Len() != 2 {
+		t.Fatalf("Expected cache to be limited to 2 documents, got %d", cache.Len())
+	}
+}
@@ -0,0 +1,142 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/sprucehealth/graphql/language/ast"
+	"github.com/sprucehealth/graphql/language/parser"
+	"github.com/sprucehealth/graphql/language/printer"
+	"github.com/sprucehealth/graphql/language/source"
+)
+
+// DocumentMergeConflictError is returned when documents can't be merged because
+// they define operations with the same name or an anonymous operation.
+type DocumentMergeConflictError struct {
+	// Operation is the name of the conflicting operation or empty if it's anonymous.
+	Operation string
+}
+
+func (e *DocumentMergeConflictError) Error() string {
+	if e.Operation == "" {
+		return "an anonymous operation can't be merged with other operations"
+	}
+	return fmt.Sprintf("operation %q is defined more than once", e.Operation)
+}
+
+// MergeDocuments combines the operations and fragments of documents into a single
+// document (e.g. to execute a batch of persisted operations that share fragments).
+//
+// Fragments that are defined identically in several documents are included once.
+// A fragment that has the same name as a different fragment of a previous document
+// is namespaced by renaming it, along with the spreads of its document, to
+// "<name>_<index of the document>" so that every operation keeps using its own
+// fragment. Variables are scoped to their operation so they never collide.
+// Operations must have unique names and can only be anonymous if there's a single
+// operation, otherwise a DocumentMergeConflictError is returned.
+//
+// Definitions of the documents are reused when they don't have to be renamed so
+// the documents should not be modified after merging.
+func MergeDocuments(docs ...*ast.Document) (*ast.Document, error) {
+	// The printed form of the first definition of each fragment name.
+	fragments := make(map[string]string)
+	var numOperations int
+	for _, doc := range docs {
+		for _, def := range doc.Definitions {
+			switch def := def.(type) {
+			case *ast.OperationDefinition:
+				numOperations++
+			case *ast.FragmentDefinition:
+				if _, ok := fragments[def.Name.Value]; !ok {
+					fragments[def.Name.Value] = printer.Print(def)
+				}
+			}
+		}
+	}
+
+	merged := &ast.Document{}
+	operations := make(map[string]bool)
+	included := make(map[string]bool)
+	for i, doc := range docs {
+		renames := make(map[string]string)
+		for _, def := range doc.Definitions {
+			if def, ok := def.(*ast.FragmentDefinition); ok && fragments[def.Name.Value] != printer.Print(def) {
+				name := def.Name.Value + "_" + strconv.Itoa(i)
+				for {
+					if _, ok := fragments[name]; !ok {
+						break
+					}
+					name += "_"
+				}
+				// Reserve the name so it isn't reused by a later document.
+				fragments[name] = ""
+				renames[def.Name.Value] = name
+			}
+		}
+		if len(renames) != 0 {
+			var err error
+			doc, err = renameFragments(doc, renames)
+			if err != nil {
+				return nil, err
+			}
+		}
+		for _, def := range doc.Definitions {
+			switch def := def.(type) {
+			case *ast.OperationDefinition:
+				name := ""
+				if def.Name != nil {
+					name = def.Name.Value
+				}
+				if (name == "" && numOperations > 1) || operations[name] {
+					return nil, &DocumentMergeConflictError{Operation: name}
+				}
+				operations[name] = true
+			case *ast.FragmentDefinition:
+				if included[def.Name.Value] {
+					continue
+				}
+				included[def.Name.Value] = true
+			}
+			merged.Definitions = append(merged.Definitions, def)
+		}
+	}
+	return merged, nil
+}
+
+// renameFragments returns a copy of the document with the fragments and their
+// spreads renamed.
+func renameFragments(doc *ast.Document, renames map[string]string) (*ast.Document, error) {
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: source.New("GraphQL request", printer.Print(doc)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	var renameSpreads func(ss *ast.SelectionSet)
+	renameSpreads = func(ss *ast.SelectionSet) {
+		if ss == nil {
+			return
+		}
+		for _, sel := range ss.Selections {
+			if spread, ok := sel.(*ast.FragmentSpread); ok {
+				if name, ok := renames[spread.Name.Value]; ok {
+					spread.Name.Value = name
+				}
+				continue
+			}
+			renameSpreads(sel.GetSelectionSet())
+		}
+	}
+	for _, def := range doc.Definitions {
+		switch def := def.(type) {
+		case *ast.OperationDefinition:
+			renameSpreads(def.SelectionSet)
+		case *ast.FragmentDefinition:
+			if name, ok := renames[def.Name.Value]; ok {
+				def.Name.Value = name
+			}
+			renameSpreads(def.SelectionSet)
+		}
+	}
+	return doc, nil
+}
@@ -0,0 +1,95 @@
+package graphql_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/language/ast"
+	"github.com/sprucehealth/graphql/language/parser"
+	"github.com/sprucehealth/graphql/language/printer"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+func parseDocuments(t *testing.T, queries ...string) []*ast.Document {
+	docs := make([]*ast.Document, len(queries))
+	for i, q := range queries {
+		doc, err := parser.Parse(parser.ParseParams{Source: q})
+		if err != nil {
+			t.Fatal(err)
+		}
+		docs[i] = doc
+	}
+	return docs
+}
+
+func TestMergeDocuments(t *testing.T) {
+	docs := parseDocuments(t,
+		`query A($id: String!) { human(id: $id) { ...Name } } fragment Name on Human { name }`,
+		`query B($id: String!) { droid(id: $id) { ...Name ...Friends } }
+		 fragment Name on Droid { name }
+		 fragment Friends on Character { friends { name } }`,
+		`query C { hero { ...Friends } } fragment Friends on Character { friends { name } }`,
+	)
+	merged, err := graphql.MergeDocuments(docs...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `query A($id: String!) {
+  human(id: $id) {
+    ...Name
+  }
+}
+
+fragment Name on Human {
+  name
+}
+
+query B($id: String!) {
+  droid(id: $id) {
+    ...Name_1
+    ...Friends
+  }
+}
+
+fragment Name_1 on Droid {
+  name
+}
+
+fragment Friends on Character {
+  friends {
+    name
+  }
+}
+
+query C {
+  hero {
+    ...Friends
+  }
+}
+`
+	if printed := printer.Print(merged); printed != expected {
+		t.Fatalf("Expected:\n%s\nGot:\n%s", expected, printed)
+	}
+	if r := graphql.ValidateDocument(&testutil.StarWarsSchema, merged, nil); !r.IsValid {
+		t.Fatalf("Expected the merged document to be valid, got %+v", r.Errors)
+	}
+	// The documents aren't modified.
+	if name := docs[1].Definitions[1].(*ast.FragmentDefinition).Name.Value; name != "Name" {
+		t.Fatalf("Expected the fragment of the document to keep its name, got %s", name)
+	}
+
+	for _, c := range []struct {
+		queries   []string
+		operation string
+	}{
+		{queries: []string{`query A { hero { name } }`, `query A { hero { id } }`}, operation: "A"},
+		{queries: []string{`{ hero { name } }`, `query A { hero { id } }`}, operation: ""},
+	} {
+		_, err := graphql.MergeDocuments(parseDocuments(t, c.queries...)...)
+		var conflict *graphql.DocumentMergeConflictError
+		if !errors.As(err, &conflict) || conflict.Operation != c.operation {
+			t.Errorf("Expected a conflict for operation %q, got %v", c.operation, err)
+		}
+	}
+}
@@ -0,0 +1,14 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/sprucehealth/graphql/language/ast"
+)
+
+// DocumentRewriterFn rewrites a parsed request before it's validated (e.g. to inject
+// tenant specific fields, alias deprecated fields, or mutate the operation for an
+// experiment). It may modify the document in place and return it or return a new
+// document. Returning an error fails the request with the error. The returned
+// document is validated so a rewrite can't introduce an invalid operation.
+type DocumentRewriterFn func(ctx context.Context, doc *ast.Document) (*ast.Document, error)
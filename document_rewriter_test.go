@@ -0,0 +1,77 @@
+package graphql_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/astbuilder"
+	"github.com/sprucehealth/graphql/language/ast"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+func TestDocumentRewriter(t *testing.T) {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"name": &graphql.Field{Type: graphql.String},
+				"tenant": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						return "acme", nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rewriteField := func(field string) graphql.DocumentRewriterFn {
+		return func(ctx context.Context, doc *ast.Document) (*ast.Document, error) {
+			for _, def := range doc.Definitions {
+				if op, ok := def.(*ast.OperationDefinition); ok {
+					op.SelectionSet.Selections = append(op.SelectionSet.Selections, astbuilder.NewField(field).Build())
+				}
+			}
+			return doc, nil
+		}
+	}
+
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema:           schema,
+		RequestString:    `{ name }`,
+		RootObject:       map[string]any{"name": "bob"},
+		DocumentRewriter: rewriteField("tenant"),
+	})
+	expected := &graphql.Result{
+		Data: map[string]any{"name": "bob", "tenant": "acme"},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	// The rewritten document is validated.
+	result = graphql.Do(context.Background(), graphql.Params{
+		Schema:           schema,
+		RequestString:    `{ name }`,
+		DocumentRewriter: rewriteField("unknown"),
+	})
+	if len(result.Errors) != 1 || result.Errors[0].Message != `Cannot query field "unknown" on type "Query".` {
+		t.Fatalf("Expected a validation error, got %+v", result.Errors)
+	}
+
+	result = graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: `{ name }`,
+		DocumentRewriter: func(ctx context.Context, doc *ast.Document) (*ast.Document, error) {
+			return nil, errors.New("rewrite failed")
+		},
+	})
+	if len(result.Errors) != 1 || result.Errors[0].Message != "rewrite failed" || result.Data != nil {
+		t.Fatalf("Expected the rewriter error, got %+v", result)
+	}
+}
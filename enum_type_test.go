@@ -378,3 +378,92 @@ func TestTypeSystem_EnumValues_EnumValueMayBeNullable(t *testing.T) {
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
 	}
 }
+
+type enumBetaKey struct{}
+
+func TestTypeSystem_EnumValues_HiddenValues(t *testing.T) {
+	sizeType := graphql.NewEnum(graphql.EnumConfig{
+		Name: "Size",
+		Values: graphql.EnumValueConfigMap{
+			"SMALL": &graphql.EnumValueConfig{},
+			"LARGE": &graphql.EnumValueConfig{
+				VisibleFn: func(ctx context.Context) bool {
+					return ctx.Value(enumBetaKey{}) != nil
+				},
+			},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"size": &graphql.Field{
+					Type: sizeType,
+					Args: graphql.FieldConfigArgument{
+						"sizes": &graphql.ArgumentConfig{
+							Type: graphql.NewList(sizeType),
+						},
+					},
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						sizes, _ := p.Args["sizes"].([]any)
+						if len(sizes) == 0 {
+							return nil, nil
+						}
+						return sizes[len(sizes)-1], nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	introspection := `{ __type(name: "Size") { enumValues { name } } }`
+	query := `{ size(sizes: [SMALL, LARGE]) }`
+	betaCtx := context.WithValue(context.Background(), enumBetaKey{}, true)
+
+	result := graphql.Do(betaCtx, graphql.Params{Schema: schema, RequestString: introspection})
+	expected := &graphql.Result{
+		Data: map[string]any{
+			"__type": map[string]any{
+				"enumValues": []any{
+					map[string]any{"name": "LARGE"},
+					map[string]any{"name": "SMALL"},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	result = graphql.Do(betaCtx, graphql.Params{Schema: schema, RequestString: query})
+	expected = &graphql.Result{
+		Data: map[string]any{"size": "LARGE"},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	result = graphql.Do(context.Background(), graphql.Params{Schema: schema, RequestString: introspection})
+	expected = &graphql.Result{
+		Data: map[string]any{
+			"__type": map[string]any{
+				"enumValues": []any{
+					map[string]any{"name": "SMALL"},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	result = graphql.Do(context.Background(), graphql.Params{Schema: schema, RequestString: query})
+	if len(result.Errors) != 1 {
+		t.Fatalf("Expected 1 error, got %+v", result.Errors)
+	}
+	if e := result.Errors[0]; e.Type != gqlerrors.ErrorTypeInvalidInput ||
+		e.Message != `Argument "sizes" has invalid value: Value "LARGE" does not exist in "Size" enum.` {
+		t.Fatalf("Unexpected error %+v", e)
+	}
+}
@@ -0,0 +1,72 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+)
+
+// visibleEnumValues returns the values of the enum that are visible to the caller.
+func visibleEnumValues(ctx context.Context, values []*EnumValueDefinition) []*EnumValueDefinition {
+	for i, v := range values {
+		if v.IsVisible(ctx) {
+			continue
+		}
+		// Only copy the values when one is hidden
+		visible := append([]*EnumValueDefinition(nil), values[:i]...)
+		for _, v := range values[i+1:] {
+			if v.IsVisible(ctx) {
+				visible = append(visible, v)
+			}
+		}
+		return visible
+	}
+	return values
+}
+
+// checkEnumVisibility returns an error if an argument value includes an enum
+// value that's hidden from the caller.
+func checkEnumVisibility(ctx context.Context, argDefs []*Argument, args map[string]any) error {
+	for _, argDef := range argDefs {
+		if value, ok := args[argDef.PrivateName]; ok {
+			if err := checkEnumValueVisibility(ctx, argDef.Type, value); err != nil {
+				return fmt.Errorf("Argument %q has invalid value: %w", argDef.PrivateName, err)
+			}
+		}
+	}
+	return nil
+}
+
+func checkEnumValueVisibility(ctx context.Context, ttype Type, value any) error {
+	if isNullish(value) {
+		return nil
+	}
+	switch ttype := ttype.(type) {
+	case *NonNull:
+		return checkEnumValueVisibility(ctx, ttype.OfType, value)
+	case *List:
+		if items, ok := value.([]any); ok {
+			for _, item := range items {
+				if err := checkEnumValueVisibility(ctx, ttype.OfType, item); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		return checkEnumValueVisibility(ctx, ttype.OfType, value)
+	case *InputObject:
+		fields, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		for name, field := range ttype.Fields() {
+			if err := checkEnumValueVisibility(ctx, field.Type, fields[name]); err != nil {
+				return err
+			}
+		}
+	case *Enum:
+		if v, ok := ttype.getValueLookup()[value]; ok && !v.IsVisible(ctx) {
+			return fmt.Errorf("Value %q does not exist in %q enum.", v.Name, ttype.Name())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package graphql
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/sprucehealth/graphql/gqlerrors"
+)
+
+const (
+	// ErrorCountExtensionKey is the key of the extension of a grouped error with the
+	// number of errors in the group. See ExecuteParams.GroupErrors.
+	ErrorCountExtensionKey = "count"
+	// ErrorSamplePathsExtensionKey is the key of the extension of a grouped error with
+	// up to maxErrorSamplePaths distinct paths of the errors in the group.
+	ErrorSamplePathsExtensionKey = "samplePaths"
+)
+
+const maxErrorSamplePaths = 5
+
+// errorGroups maps the key of each group of errors to the index of the first error
+// of the group in the errors of the execution context.
+type errorGroups map[string]int
+
+// errorGroupKey returns the key of the group of the error. The path prefix is the
+// path up to the first list index so that errors of the items of a list are
+// grouped together. As resolver errors don't always have a path the locations are
+// part of the key so that errors of different fields aren't grouped.
+func errorGroupKey(err gqlerrors.FormattedError) string {
+	var b strings.Builder
+	b.WriteString(string(err.Type))
+	b.WriteByte(0)
+	b.WriteString(err.Message)
+	for _, loc := range err.Locations {
+		fmt.Fprintf(&b, "\x00%d:%d", loc.Line, loc.Column)
+	}
+	b.WriteString("\x00\x00")
+	for _, p := range err.Path {
+		if _, ok := p.(int); ok {
+			break
+		}
+		b.WriteByte(0)
+		fmt.Fprint(&b, p)
+	}
+	return b.String()
+}
+
+// merge adds the error to the group of the key returning false if the group doesn't
+// exist yet. The first error of a group has the number of errors of the group and
+// sample paths in its extensions.
+func (g errorGroups) merge(errs []gqlerrors.FormattedError, key string, err gqlerrors.FormattedError) bool {
+	i, ok := g[key]
+	if !ok {
+		return false
+	}
+	first := &errs[i]
+	count, _ := first.Extensions[ErrorCountExtensionKey].(int)
+	if count == 0 {
+		// Copy the extensions as they may be shared with the original error.
+		first.Extensions = maps.Clone(first.Extensions)
+		if first.Extensions == nil {
+			first.Extensions = make(map[string]any)
+		}
+		count = 1
+		if first.Path != nil {
+			first.Extensions[ErrorSamplePathsExtensionKey] = [][]any{first.Path}
+		}
+	}
+	first.Extensions[ErrorCountExtensionKey] = count + 1
+	if err.Path != nil {
+		samples, _ := first.Extensions[ErrorSamplePathsExtensionKey].([][]any)
+		if len(samples) < maxErrorSamplePaths && !containsPath(samples, err.Path) {
+			first.Extensions[ErrorSamplePathsExtensionKey] = append(samples, err.Path)
+		}
+	}
+	return true
+}
+
+func containsPath(paths [][]any, path []any) bool {
+	for _, p := range paths {
+		if fmt.Sprint(p) == fmt.Sprint(path) {
+			return true
+		}
+	}
+	return false
+}
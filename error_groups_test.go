@@ -0,0 +1,111 @@
+package graphql_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/gqlerrors"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+func TestGroupErrors(t *testing.T) {
+	item := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Item",
+		Fields: graphql.Fields{
+			"value": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+					return nil, errors.New("failed")
+				},
+			},
+			"other": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+					return nil, gqlerrors.FormattedError{
+						Message:    "other failed",
+						Path:       []any{"items", p.Source.(map[string]any)["index"], "other"},
+						Extensions: map[string]any{"code": "OTHER"},
+					}
+				},
+			},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"items": &graphql.Field{
+					Type: graphql.NewList(item),
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						items := make([]any, 200)
+						for i := range items {
+							items[i] = map[string]any{"index": i}
+						}
+						return items, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		result := graphql.Do(context.Background(), graphql.Params{
+			Schema:        schema,
+			RequestString: `{ items { value } }`,
+		})
+		if len(result.Errors) != graphql.DefaultMaxErrors+1 {
+			t.Fatalf("Expected %d errors, got %d", graphql.DefaultMaxErrors+1, len(result.Errors))
+		}
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		result := graphql.Do(context.Background(), graphql.Params{
+			Schema:        schema,
+			RequestString: `{ items { value other } }`,
+			GroupErrors:   true,
+			Deterministic: true,
+		})
+		type groupedError struct {
+			Message    string
+			Extensions map[string]any
+		}
+		var got []groupedError
+		for _, e := range result.Errors {
+			got = append(got, groupedError{Message: e.Message, Extensions: e.Extensions})
+		}
+		expected := []groupedError{
+			{
+				Message: "failed",
+				Extensions: map[string]any{
+					graphql.ErrorCountExtensionKey: 200,
+				},
+			},
+			{
+				Message: "other failed",
+				Extensions: map[string]any{
+					"code":                         "OTHER",
+					graphql.ErrorCountExtensionKey: 200,
+					graphql.ErrorSamplePathsExtensionKey: [][]any{
+						{"items", 0, "other"},
+						{"items", 1, "other"},
+						{"items", 2, "other"},
+						{"items", 3, "other"},
+						{"items", 4, "other"},
+					},
+				},
+			},
+		}
+		if !reflect.DeepEqual(expected, got) {
+			t.Fatalf("Unexpected errors, Diff: %v", testutil.Diff(expected, got))
+		}
+		if items := result.Data.(map[string]any)["items"].([]any); len(items) != 200 {
+			t.Fatalf("Expected 200 items, got %d", len(items))
+		}
+	})
+}
@@ -0,0 +1,100 @@
+package graphql
+
+// ErrorData is an error returned as data in a result union (e.g.
+// `union UpdateUserResult = User | ValidationError | NotFoundError`) instead of
+// as a field error. Type is the name of its object type as created by
+// NewErrorObject.
+type ErrorData struct {
+	Type    string `json:"-"`
+	Message string `json:"message"`
+	Code    string `json:"code,omitempty"`
+}
+
+func (e *ErrorData) Error() string {
+	return e.Message
+}
+
+// ErrorObjectConfig is the configuration of an error object type.
+type ErrorObjectConfig struct {
+	Name        string
+	Description string
+	// Fields are added to the standard `message: String!` and `code: String`
+	// fields.
+	Fields Fields
+	// IsTypeOf defaults to matching ErrorData values of the type.
+	IsTypeOf IsTypeOfFn
+}
+
+// NewErrorObject returns an object type for errors returned as data with the
+// standard `message: String!` and `code: String` fields.
+func NewErrorObject(config ErrorObjectConfig) *Object {
+	fields := Fields{
+		"message": &Field{
+			Type:        NewNonNull(String),
+			Description: "Message describes the error to the user.",
+		},
+		"code": &Field{
+			Type:        String,
+			Description: "Code is a machine-readable identifier of the error.",
+		},
+	}
+	for name, field := range config.Fields {
+		fields[name] = field
+	}
+	isTypeOf := config.IsTypeOf
+	if isTypeOf == nil {
+		isTypeOf = func(p IsTypeOfParams) bool {
+			e, ok := p.Value.(*ErrorData)
+			return ok && e.Type == config.Name
+		}
+	}
+	return NewObject(ObjectConfig{
+		Name:        config.Name,
+		Description: config.Description,
+		Fields:      fields,
+		IsTypeOf:    isTypeOf,
+	})
+}
+
+// ErrorAsData returns the first error in the tree of err (as with errors.As)
+// that's a value of one of the object types of the result union so that it's
+// returned as data. Any other error is returned as is to be reported as a field
+// error. It's meant to be returned by resolvers of fields of result unions:
+//
+//	user, err := updateUser(ctx, args)
+//	if err != nil {
+//		return graphql.ErrorAsData(err, UpdateUserResultDef)
+//	}
+func ErrorAsData(err error, union *Union) (any, error) {
+	if err == nil {
+		return nil, nil
+	}
+	if v := findErrorOfUnion(err, union); v != nil {
+		return v, nil
+	}
+	return nil, err
+}
+
+func findErrorOfUnion(err error, union *Union) error {
+	for _, ttype := range union.Types() {
+		if ttype.IsTypeOf != nil && ttype.IsTypeOf(IsTypeOfParams{Value: err}) {
+			return err
+		}
+	}
+	switch u := err.(type) {
+	case interface{ Unwrap() error }:
+		if err := u.Unwrap(); err != nil {
+			return findErrorOfUnion(err, union)
+		}
+	case interface{ Unwrap() []error }:
+		for _, err := range u.Unwrap() {
+			if err == nil {
+				continue
+			}
+			if v := findErrorOfUnion(err, union); v != nil {
+				return v
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package graphql_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+type errorsAsDataUser struct {
+	Name string `json:"name"`
+}
+
+func TestErrorAsData(t *testing.T) {
+	userType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.String},
+		},
+		IsTypeOf: func(p graphql.IsTypeOfParams) bool {
+			_, ok := p.Value.(*errorsAsDataUser)
+			return ok
+		},
+	})
+	notFoundType := graphql.NewErrorObject(graphql.ErrorObjectConfig{
+		Name: "NotFoundError",
+	})
+	validationType := graphql.NewErrorObject(graphql.ErrorObjectConfig{
+		Name: "ValidationError",
+		Fields: graphql.Fields{
+			"field": &graphql.Field{Type: graphql.String},
+		},
+	})
+	resultUnion := graphql.NewUnion(graphql.UnionConfig{
+		Name:  "UpdateUserResult",
+		Types: []*graphql.Object{userType, notFoundType, validationType},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"updateUser": &graphql.Field{
+					Type: resultUnion,
+					Args: graphql.FieldConfigArgument{
+						"name": &graphql.ArgumentConfig{Type: graphql.String},
+					},
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						var err error
+						switch name, _ := p.Args["name"].(string); name {
+						case "":
+							err = &graphql.ErrorData{Type: "ValidationError", Message: "Name is required.", Code: "REQUIRED"}
+						case "unknown":
+							err = fmt.Errorf("update user: %w", &graphql.ErrorData{Type: "NotFoundError", Message: "User not found."})
+						case "fail":
+							err = errors.New("database unavailable")
+						default:
+							return &errorsAsDataUser{Name: name}, nil
+						}
+						return graphql.ErrorAsData(err, resultUnion)
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema: schema,
+		RequestString: `{
+			ok: updateUser(name: "Ann") { __typename ...on User { name } }
+			invalid: updateUser(name: "") { __typename ...on ValidationError { message code } }
+			unknown: updateUser(name: "unknown") { __typename ...on NotFoundError { message code } }
+		}`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]any{
+			"ok":      map[string]any{"__typename": "User", "name": "Ann"},
+			"invalid": map[string]any{"__typename": "ValidationError", "message": "Name is required.", "code": "REQUIRED"},
+			"unknown": map[string]any{"__typename": "NotFoundError", "message": "User not found.", "code": nil},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	result = graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: `{ updateUser(name: "fail") { __typename } }`,
+	})
+	if len(result.Errors) != 1 || result.Errors[0].Message != "database unavailable" {
+		t.Fatalf("Expected field error, got %+v", result)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"os"
 
 	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/gqlhttp"
 )
 
 type user struct {
@@ -70,16 +71,20 @@ var queryType = graphql.NewObject(
 		},
 	})
 
+var encoder = &gqlhttp.Encoder{Compress: true}
+
 var schema, _ = graphql.NewSchema(
 	graphql.SchemaConfig{
 		Query: queryType,
 	},
 )
 
-func executeQuery(ctx context.Context, query string, schema graphql.Schema) *graphql.Result {
+func executeQuery(ctx context.Context, method, query string, schema graphql.Schema) *graphql.Result {
 	result := graphql.Do(ctx, graphql.Params{
 		Schema:        schema,
 		RequestString: query,
+		CacheControl:  true,
+		HTTPMethod:    method,
 	})
 	if len(result.Errors) > 0 {
 		fmt.Printf("wrong result, unexpected errors: %v", result.Errors)
@@ -91,8 +96,12 @@ func main() {
 	_ = importJSONDataFromFile("data.json", &data)
 
 	http.HandleFunc("/graphql", func(w http.ResponseWriter, r *http.Request) {
-		result := executeQuery(r.Context(), r.URL.Query()["query"][0], schema)
-		_ = json.NewEncoder(w).Encode(result)
+		result := executeQuery(r.Context(), r.Method, r.URL.Query()["query"][0], schema)
+		w.Header().Set(graphql.SchemaVersionHeader, schema.Hash())
+		if cc := result.CachePolicy.HeaderValue(); cc != "" && len(result.Errors) == 0 {
+			w.Header().Set("Cache-Control", cc)
+		}
+		_ = encoder.WriteResult(w, r, result)
 	})
 
 	fmt.Println("Now server is running on port 8080")
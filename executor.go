@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"reflect"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -26,13 +28,220 @@ type ExecuteParams struct {
 	// TimeoutWait is the amount of time to allow for resolvers to handle
 	// a context deadline error before the executor does.
 	TimeoutWait time.Duration
-	Tracer      Tracer
+	// Timeout if greater than 0 is the maximum time to execute the operation. The
+	// context is wrapped with a deadline regardless of any deadline it already has
+	// and its cancellation cause is ErrOperationTimeout when the timeout is reached.
+	// TimeoutWait still applies after the timeout.
+	Timeout time.Duration
+	Tracer  Tracer
+	// RestrictedTypes is a list of object type names that may not be returned for
+	// an interface or union in this request (e.g. feature-flagged types). Values
+	// that resolve to a restricted type are completed as null with an error, and
+	// the types are omitted from possibleTypes in introspection.
+	RestrictedTypes []string
+	// UseJSONNumber if true converts numeric variable values to json.Number before they're
+	// coerced, mirroring json.Decoder.UseNumber. This lets custom scalars parse large
+	// integers (e.g. into a *big.Int) from variables without going through float64.
+	// Variables that are already json.Number are always passed through as is.
+	UseJSONNumber bool
+	// Explain if true records an ordered log of execution events (field start and end,
+	// coerced variables and arguments, abstract type resolution) and returns it in
+	// the result extensions under ExplainExtensionKey. It's meant for debugging and
+	// adds overhead so it should not be enabled for all requests.
+	Explain bool
+	// OrderedData if true builds objects in Result.Data as OrderedMap instead of
+	// map[string]any so that fields are in the order they were requested and
+	// are encoded to JSON in that order. Fields are also executed in that order.
+	OrderedData bool
+	// CacheControl if true accumulates cache hints from @cacheControl directives on
+	// field definitions. The overall policy is returned in Result.CachePolicy and the
+	// hints in the result extensions under CacheControlExtensionKey.
+	CacheControl bool
+	// CacheControlDefaultMaxAge is the max age for root fields and fields returning
+	// composite types that don't have a cache hint. Defaults to 0 (not cacheable).
+	CacheControlDefaultMaxAge int
+	// Deterministic if true resolves fields in the order they're requested rather than
+	// in map iteration order so that resolver calls and errors are in the same order on
+	// every run. It's meant for golden tests. Result.Data is still built from maps.
+	Deterministic bool
+	// OperationHook if set is called with the selected operation before it's executed.
+	// It can be used to reject operations (e.g. mutations in a GET request) or to
+	// record whether the request was read-only.
+	OperationHook OperationHookFn
+	// RateLimiter if set is consulted with the signature and cost of the operation
+	// before it's executed. A request that isn't allowed fails with a RESOURCE_EXHAUSTED
+	// error and the time to wait before retrying in the error extensions.
+	RateLimiter RateLimiter
+	// DeprecationWarnings if true returns the deprecated fields, arguments, and enum
+	// values used by the operation in the result extensions under DeprecationsExtensionKey.
+	DeprecationWarnings bool
+	// MaxErrors is the maximum number of field errors collected during execution. Further
+	// errors are dropped and a single error with the number of dropped errors is appended
+	// instead. Defaults to DefaultMaxErrors if 0. A negative value disables the limit.
+	MaxErrors int
+	// Capture if set records the request, the schema hash, and the outcomes of field
+	// and batch resolvers, and passes the record to the sink of the policy after
+	// execution. The record can be re-executed with Replay to reproduce a request.
+	Capture *CapturePolicy
+	// MaxExpandedSelections if greater than 0 is the maximum number of fields the operation
+	// may select once fragment spreads are expanded (a fragment counts every time it's
+	// spread). Larger operations fail with a *SelectionLimitError before execution.
+	MaxExpandedSelections int
+	// Logger if set receives warnings about anomalies during execution such as values
+	// that can't be serialized. Defaults to the logger of the schema.
+	Logger Logger
+	// MaxResultBytes if greater than 0 is the approximate maximum size in bytes of the
+	// result data, counting the length of strings, the items of lists, and the names of
+	// fields as they're completed. Once it's exceeded the value being completed and all
+	// remaining fields are null, and a single *ResultSizeError is returned with the
+	// partial data.
+	MaxResultBytes int
+	// StrictJSON if true completes values the way graphql-js does so that the result
+	// encoded with MarshalStrictJSON matches the response of a graphql-js service byte
+	// for byte. Enum values are serialized to their names when the value returned by
+	// the resolver has a different type than the internal value but the same kind and
+	// value (e.g. a named string type), and Float values that aren't finite are errors.
+	StrictJSON bool
+	// MaxAliasesPerField if greater than 0 is the maximum number of response names a
+	// field may be selected under in a selection set, including the selections of
+	// the fragments in the selection set. Operations that alias a field more often
+	// fail with an *AliasLimitError before execution.
+	MaxAliasesPerField int
+	// DedupeAliasedFields if true resolves a field that's selected under several
+	// aliases with the same arguments, directives, and selections only once and uses
+	// the value for every alias. Errors are only reported for the first alias. The
+	// root fields of a mutation are always executed for every alias.
+	DedupeAliasedFields bool
+	// OperationLog if set logs the operation, its duration, and the number of errors
+	// after execution. Requests that fail before an operation is selected are logged
+	// without the operation.
+	OperationLog *OperationLogPolicy
+	// ExecutionStrategies if set are the strategies used to execute the fields of
+	// selection sets keyed by operation type (ast.OperationTypeQuery,
+	// ast.OperationTypeMutation, or ast.OperationTypeSubscription). Operation types
+	// without a strategy execute fields one at a time in the requested order if
+	// Deterministic or OrderedData is set and in map order otherwise.
+	ExecutionStrategies map[string]ExecutionStrategy
+	// StreamStrings if not StreamEncodingNone completes String fields that resolve
+	// to an io.Reader to a *StreamedString with the encoding instead of reading the
+	// content. Results should be encoded with WriteJSON to stream the content to
+	// the response without buffering it (e.g. for large exports or documents).
+	StreamStrings StreamEncoding
+	// GroupErrors if true collapses field errors with the same message, type, and
+	// path up to the first list index into the first of them. Its extensions have
+	// the number of errors under ErrorCountExtensionKey and some of their paths
+	// under ErrorSamplePathsExtensionKey. It keeps the errors of large lists
+	// manageable and grouped errors count once towards MaxErrors.
+	GroupErrors bool
+	// StrictVariables if true fails the request with an INVALID_INPUT error for each
+	// entry of Args that isn't a variable defined by the operation instead of
+	// ignoring it. It catches clients whose variable names drifted from the
+	// operation.
+	StrictVariables bool
+	// HTTPMethod is the method of the HTTP request that carries the operation if
+	// any. Following the GraphQL over HTTP guidance, mutations and subscriptions
+	// sent with GET fail with a METHOD_NOT_ALLOWED error so that they can't be
+	// triggered by links or cached by proxies.
+	HTTPMethod string
+	// AllowMutationsOverGET if true executes mutations and subscriptions sent with
+	// GET for legacy clients.
+	AllowMutationsOverGET bool
+	// Checkpoint if set is called with a snapshot of the progress of the operation
+	// every time a root field completes. The operation can be resumed from a
+	// snapshot with Resume. It's EXPERIMENTAL and may change.
+	Checkpoint CheckpointFn
+	// Analysis if set computes the complexity and depth of the operation and reports
+	// them without rejecting the operation.
+	Analysis *AnalysisPolicy
+	// ExtensionMergers are the functions that merge the values added by resolvers
+	// with AddExtension keyed by extension key.
+	ExtensionMergers map[string]ExtensionMergeFn
+	// Deduplicator if set shares the execution of identical concurrent read-only
+	// queries. See RequestDeduplicator.
+	Deduplicator *RequestDeduplicator
+	// ResolverOverrides are resolvers keyed by "Type.field" that are used instead
+	// of the resolvers of the schema for this request (e.g. to stub a field in a
+	// test or to try a new implementation on a fraction of the traffic). The
+	// request fails if a key isn't a field of an object type of the schema.
+	ResolverOverrides map[string]FieldResolveFn
+	// GoroutineAudit if set tracks the goroutines started with Go and the goroutine
+	// executing the operation and reports the ones still running after the
+	// operation completes (e.g. after a timeout). It's meant for debugging.
+	GoroutineAudit *GoroutineAudit
+	// ShareArgs if true passes input object and list argument values to resolvers
+	// without copying them. Values from variables and default values are then
+	// shared by all fields using them and resolvers must not modify them. It
+	// avoids the copies for operations with large input values.
+	ShareArgs bool
+	// Masking if set masks the values of fields in the result according to the
+	// rules of the policy and audits the masked fields.
+	Masking *MaskingPolicy
+	// Sunset if set enforces the sunset dates of deprecated fields according to
+	// the policy.
+	Sunset *SunsetPolicy
+	// FieldHook if set is called before each field is resolved. It's meant for
+	// tests (e.g. to cancel the context at a field with testutil.CancelAt).
+	FieldHook FieldHookFn
+
+	// replay is set by Replay to resolve fields from a capture.
+	replay *replayOutcomes
+	// resume is set by Resume to skip the root fields that have completed.
+	resume *Snapshot
 }
 
+// DefaultMaxErrors is the default value for ExecuteParams.MaxErrors.
+const DefaultMaxErrors = 100
+
+// ErrOperationTimeout is the error returned when ExecuteParams.Timeout is reached.
+var ErrOperationTimeout = errors.New(gqlerrors.CodeOperationTimeout.Message())
+
 func Execute(ctx context.Context, p ExecuteParams) *Result {
+	if p.Deduplicator != nil {
+		if result, ok := p.Deduplicator.execute(ctx, p); ok {
+			return result
+		}
+	}
+	if p.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, p.Timeout, ErrOperationTimeout)
+		defer cancel()
+	}
+
 	resultChannel := make(chan *Result, 1)
 
+	var start time.Time
+	var logEntry chan *operationLogEntry
+	if p.OperationLog != nil {
+		start = time.Now()
+		logEntry = make(chan *operationLogEntry, 1)
+	}
+	var analysis chan *OperationAnalysis
+	if p.Analysis != nil && p.Analysis.Extensions {
+		analysis = make(chan *OperationAnalysis, 1)
+	}
+
+	var explain *explainLog
+	if p.Explain {
+		explain = &explainLog{}
+		ctx = context.WithValue(ctx, explainLogKey{}, explain)
+	}
+	extensions := &resolverExtensions{}
+	ctx = context.WithValue(ctx, resolverExtensionsKey{}, extensions)
+	ctx = withProviderSet(ctx, p.Schema.providerRegistry)
+	var cc *cacheControl
+	if p.CacheControl {
+		cc = &cacheControl{defaultMaxAge: p.CacheControlDefaultMaxAge}
+	}
+	executionDone := func() {}
+	if p.GoroutineAudit != nil {
+		tracker := &goroutineTracker{}
+		ctx = context.WithValue(ctx, goroutineTrackerKey{}, tracker)
+		executionDone = tracker.start("execution", "")
+		defer func() { go p.GoroutineAudit.audit(ctx, tracker) }()
+	}
+
 	go func(out chan<- *Result) {
+		defer executionDone()
 		result := &Result{}
 
 		exeContext, err := buildExecutionContext(BuildExecutionCtxParams{
@@ -47,6 +256,10 @@ func Execute(ctx context.Context, p ExecuteParams) *Result {
 			FieldDefinitionDirectiveHandler: p.FieldDefinitionDirectiveHandler,
 			DisallowIntrospection:           p.DisallowIntrospection,
 			Tracer:                          p.Tracer,
+			RestrictedTypes:                 p.RestrictedTypes,
+			UseJSONNumber:                   p.UseJSONNumber,
+			OrderedData:                     p.OrderedData,
+			Deterministic:                   p.Deterministic,
 		})
 
 		if err != nil {
@@ -54,6 +267,127 @@ func Execute(ctx context.Context, p ExecuteParams) *Result {
 			out <- result
 			return
 		}
+		if p.StrictVariables {
+			if errs := unknownVariableErrors(exeContext.Operation.(*ast.OperationDefinition), p.Args); len(errs) != 0 {
+				result.Errors = errs
+				out <- result
+				return
+			}
+		}
+		if exeContext.Schema.gatedFields {
+			if errs := checkFieldVisibility(ctx, &exeContext.Schema, exeContext.Operation, exeContext.Fragments); len(errs) != 0 {
+				result.Errors = errs
+				out <- result
+				return
+			}
+		}
+		if len(p.ResolverOverrides) != 0 {
+			if err := checkResolverOverrides(&exeContext.Schema, p.ResolverOverrides); err != nil {
+				result.Errors = append(result.Errors, gqlerrors.FormatError(err))
+				out <- result
+				return
+			}
+			exeContext.resolverOverrides = p.ResolverOverrides
+		}
+		exeContext.cacheControl = cc
+		exeContext.argumentClamps = &argumentClamps{}
+		exeContext.maxErrors = p.MaxErrors
+		if exeContext.maxErrors == 0 {
+			exeContext.maxErrors = DefaultMaxErrors
+		}
+		if explain != nil {
+			exeContext.explain = explain
+			explain.add(ExplainEvent{Type: ExplainVariables, Values: exeContext.VariableValues})
+		}
+
+		if p.Analysis != nil {
+			a := analyzeOperation(p.Analysis, exeContext.Operation, exeContext.Fragments)
+			if p.Analysis.Report != nil {
+				p.Analysis.Report(ctx, newOperationInfo(&exeContext.Schema, exeContext.Operation, exeContext.Fragments), a)
+			}
+			if analysis != nil {
+				analysis <- a
+			}
+		}
+		if p.MaxExpandedSelections > 0 {
+			counter := newSelectionCounter(exeContext.Fragments, p.MaxExpandedSelections)
+			if err := checkExpandedSelections(counter, exeContext.Operation); err != nil {
+				result.Errors = append(result.Errors, selectionLimitError(err))
+				out <- result
+				return
+			}
+		}
+		if p.MaxAliasesPerField > 0 {
+			counter := newAliasCounter(exeContext.Fragments, p.MaxAliasesPerField)
+			if err := counter.check(exeContext.Operation.GetSelectionSet()); err != nil {
+				result.Errors = append(result.Errors, aliasLimitError(err))
+				out <- result
+				return
+			}
+		}
+		if logEntry != nil {
+			logEntry <- newOperationLogEntry(ctx, p.OperationLog, &exeContext.Schema, exeContext.Operation, exeContext.Fragments)
+		}
+		if strings.EqualFold(p.HTTPMethod, "GET") && !p.AllowMutationsOverGET {
+			if err := checkGETOperation(exeContext.Operation); err != nil {
+				result.Errors = append(result.Errors, gqlerrors.FormatError(err))
+				out <- result
+				return
+			}
+		}
+		if p.OperationHook != nil {
+			operation := newOperationInfo(&exeContext.Schema, exeContext.Operation, exeContext.Fragments)
+			if err := p.OperationHook(ctx, operation); err != nil {
+				result.Errors = append(result.Errors, gqlerrors.FormatError(err))
+				out <- result
+				return
+			}
+		}
+		if p.RateLimiter != nil {
+			if err := rateLimit(ctx, p.RateLimiter, exeContext.Operation, exeContext.Fragments); err != nil {
+				result.Errors = append(result.Errors, gqlerrors.FormatError(err))
+				out <- result
+				return
+			}
+		}
+
+		if p.Capture != nil {
+			exeContext.capture = newCapture(p)
+		}
+		exeContext.replay = p.replay
+		if p.Checkpoint != nil || p.resume != nil {
+			exeContext.checkpoints = newCheckpoints(p)
+			exeContext.Errors = slices.Clone(exeContext.checkpoints.snapshot.Errors)
+		}
+		exeContext.strictJSON = p.StrictJSON
+		exeContext.dedupeAliases = p.DedupeAliasedFields
+		exeContext.shareArgs = p.ShareArgs
+		if p.Masking != nil {
+			exeContext.masking = newMasking(p.Masking)
+		}
+		if p.Sunset != nil {
+			exeContext.sunset = p.Sunset
+			exeContext.sunsetNow = p.Sunset.now()
+			exeContext.sunsetNulls = &sunsetWarnings{}
+			if p.Sunset.Report != nil {
+				if fields := sunsetFields(&exeContext.Schema, exeContext.Operation, exeContext.Fragments, exeContext.sunsetNow); len(fields) != 0 {
+					p.Sunset.Report(ctx, newOperationInfo(&exeContext.Schema, exeContext.Operation, exeContext.Fragments), fields)
+				}
+			}
+		}
+		exeContext.fieldHook = p.FieldHook
+		exeContext.strategy = p.ExecutionStrategies[exeContext.Operation.GetOperation()]
+		exeContext.streamStrings = p.StreamStrings
+		if p.GroupErrors {
+			exeContext.errorGroups = make(errorGroups)
+		}
+		if p.MaxResultBytes > 0 {
+			exeContext.resultSize = &resultSize{limit: p.MaxResultBytes}
+		}
+		exeContext.logger = p.Logger
+		if exeContext.logger == nil {
+			exeContext.logger = p.Schema.logger
+		}
 
 		defer func() {
 			if r := recover(); r != nil {
@@ -61,6 +395,36 @@ func Execute(ctx context.Context, p ExecuteParams) *Result {
 				exeContext.Errors = append(exeContext.Errors, gqlerrors.FormatError(err))
 				result.Errors = exeContext.Errors
 			}
+			if p.DeprecationWarnings {
+				if warnings := collectDeprecations(&exeContext.Schema, exeContext.Operation, exeContext.Fragments); len(warnings) != 0 {
+					if result.Extensions == nil {
+						result.Extensions = make(map[string]any)
+					}
+					result.Extensions[DeprecationsExtensionKey] = warnings
+				}
+			}
+			if exeContext.sunsetNulls != nil && len(exeContext.sunsetNulls.warnings) != 0 {
+				if result.Extensions == nil {
+					result.Extensions = make(map[string]any)
+				}
+				result.Extensions[SunsetExtensionKey] = exeContext.sunsetNulls.warnings
+			}
+			if clamped := exeContext.argumentClamps.arguments(); len(clamped) != 0 {
+				if result.Extensions == nil {
+					result.Extensions = make(map[string]any)
+				}
+				result.Extensions[ClampedArgumentsExtensionKey] = clamped
+			}
+			if exeContext.droppedErrors != 0 {
+				result.Errors = append(result.Errors, gqlerrors.FormatError(
+					gqlerrors.CodeTooManyErrors.New(nil, nil, exeContext.droppedErrors)))
+			}
+			if exeContext.capture != nil {
+				p.Capture.Sink.Capture(ctx, exeContext.capture)
+			}
+			if exeContext.masking != nil {
+				exeContext.masking.audit(ctx, exeContext.Operation)
+			}
 			out <- result
 		}()
 
@@ -77,7 +441,10 @@ func Execute(ctx context.Context, p ExecuteParams) *Result {
 		result = r
 	case <-ctx.Done():
 		err := ctx.Err()
-		if errors.Is(err, context.DeadlineExceeded) && p.TimeoutWait != 0 {
+		if errors.Is(context.Cause(ctx), ErrOperationTimeout) {
+			err = ErrOperationTimeout
+		}
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) && p.TimeoutWait != 0 {
 			select {
 			case r := <-resultChannel:
 				result = r
@@ -86,8 +453,45 @@ func Execute(ctx context.Context, p ExecuteParams) *Result {
 		}
 		if result == nil {
 			result = &Result{}
-			result.Errors = append(result.Errors, gqlerrors.FormatError(err))
+			if err == ErrOperationTimeout {
+				result.Errors = append(result.Errors, gqlerrors.FormatError(gqlerrors.CodeOperationTimeout.New(nil, err)))
+			} else {
+				result.Errors = append(result.Errors, gqlerrors.FormatError(err))
+			}
+		}
+	}
+	if explain != nil {
+		if result.Extensions == nil {
+			result.Extensions = make(map[string]any)
+		}
+		result.Extensions[ExplainExtensionKey] = explain.Events()
+	}
+	if cc != nil {
+		policy, ext := cc.result()
+		result.CachePolicy = policy
+		if result.Extensions == nil {
+			result.Extensions = make(map[string]any)
 		}
+		result.Extensions[CacheControlExtensionKey] = ext
+	}
+	if analysis != nil {
+		select {
+		case a := <-analysis:
+			if result.Extensions == nil {
+				result.Extensions = make(map[string]any)
+			}
+			result.Extensions[AnalysisExtensionKey] = a
+		default:
+		}
+	}
+	result.Extensions = extensions.merge(result.Extensions, p.ExtensionMergers)
+	if logEntry != nil {
+		var entry *operationLogEntry
+		select {
+		case entry = <-logEntry:
+		default:
+		}
+		logOperation(ctx, p.OperationLog, entry, p.Args, result, time.Since(start))
 	}
 	return result
 }
@@ -105,6 +509,10 @@ type BuildExecutionCtxParams struct {
 	FieldDefinitionDirectiveHandler func(context.Context, *ast.Directive, *FieldDefinition) error
 	DisallowIntrospection           bool
 	Tracer                          Tracer
+	RestrictedTypes                 []string
+	UseJSONNumber                   bool
+	OrderedData                     bool
+	Deterministic                   bool
 }
 
 type ExecutionContext struct {
@@ -119,6 +527,71 @@ type ExecutionContext struct {
 	FieldDefinitionDirectiveHandler func(context.Context, *ast.Directive, *FieldDefinition) error
 	DisallowIntrospection           bool
 	Tracer                          Tracer
+	OrderedData                     bool
+	// Deterministic if true executes fields in the requested order.
+	Deterministic bool
+
+	// mu guards the state updated by fields that an ExecutionStrategy may execute
+	// concurrently: Errors, droppedErrors, errorGroups, and isTypeOfHints.
+	mu            sync.Mutex
+	explain       *explainLog
+	cacheControl  *cacheControl
+	maxErrors     int
+	droppedErrors int
+	capture       *Capture
+	replay        *replayOutcomes
+	logger        Logger
+	resultSize    *resultSize
+	strictJSON    bool
+	isTypeOfHints map[isTypeOfKey]*Object
+	dedupeAliases bool
+	strategy      ExecutionStrategy
+	streamStrings StreamEncoding
+	errorGroups   errorGroups
+	checkpoints   *checkpoints
+	shareArgs     bool
+	masking       *masking
+	sunset        *SunsetPolicy
+	sunsetNow     time.Time
+	sunsetNulls   *sunsetWarnings
+	fieldHook     FieldHookFn
+	// resolverOverrides are the resolvers replacing the resolvers of the schema
+	// keyed by "Type.field".
+	resolverOverrides map[string]FieldResolveFn
+	argumentClamps    *argumentClamps
+}
+
+// addError records a field error unless the maximum number of errors has been
+// reached in which case the error is only counted. If errors are grouped an error
+// that belongs to an existing group is merged into the first error of the group.
+func (eCtx *ExecutionContext) addError(err gqlerrors.FormattedError) {
+	eCtx.mu.Lock()
+	defer eCtx.mu.Unlock()
+	if !eCtx.resultSize.shouldReport(err) {
+		return
+	}
+	var groupKey string
+	if eCtx.errorGroups != nil {
+		groupKey = errorGroupKey(err)
+		if eCtx.errorGroups.merge(eCtx.Errors, groupKey, err) {
+			return
+		}
+	}
+	if eCtx.maxErrors > 0 && len(eCtx.Errors) >= eCtx.maxErrors {
+		eCtx.droppedErrors++
+		return
+	}
+	if eCtx.errorGroups != nil {
+		eCtx.errorGroups[groupKey] = len(eCtx.Errors)
+	}
+	eCtx.Errors = append(eCtx.Errors, err)
+}
+
+// errors returns the field errors recorded so far.
+func (eCtx *ExecutionContext) errors() []gqlerrors.FormattedError {
+	eCtx.mu.Lock()
+	defer eCtx.mu.Unlock()
+	return eCtx.Errors
 }
 
 func safeNodeType(n ast.Node) string {
@@ -132,7 +605,7 @@ func buildExecutionContext(p BuildExecutionCtxParams) (*ExecutionContext, error)
 		switch definition := definition.(type) {
 		case *ast.OperationDefinition:
 			if p.OperationName == "" && operation != nil {
-				return nil, errors.New("Must provide operation name if query contains multiple operations.")
+				return nil, gqlerrors.CodeOperationNameRequired.New(nil, nil)
 			}
 			if p.OperationName == "" || definition.GetName() != nil && definition.GetName().Value == p.OperationName {
 				operation = definition
@@ -144,24 +617,33 @@ func buildExecutionContext(p BuildExecutionCtxParams) (*ExecutionContext, error)
 			}
 			fragments[key] = definition
 		default:
-			return nil, fmt.Errorf("GraphQL cannot execute a request containing a %s", safeNodeType(definition))
+			return nil, gqlerrors.CodeUnexecutableDefinition.New([]ast.Node{definition}, nil, safeNodeType(definition))
 		}
 	}
 
 	if operation == nil {
 		if p.OperationName != "" {
-			return nil, fmt.Errorf("Unknown operation named %q.", p.OperationName)
+			return nil, gqlerrors.CodeUnknownOperation.New(nil, nil, p.OperationName)
 		}
-		return nil, errors.New("Must provide an operation.")
+		return nil, gqlerrors.CodeMissingOperation.New(nil, nil)
 	}
 
-	variableValues, err := getVariableValues(p.Schema, operation.GetVariableDefinitions(), p.Args)
+	args := p.Args
+	if p.UseJSONNumber {
+		args = jsonNumberVariables(args)
+	}
+	variableValues, err := getVariableValues(p.Schema, operation.GetVariableDefinitions(), args)
 	if err != nil {
 		return nil, err
 	}
 
+	schema := p.Schema
+	if len(p.RestrictedTypes) != 0 {
+		schema = schema.withRestrictedTypes(p.RestrictedTypes)
+	}
+
 	return &ExecutionContext{
-		Schema:                          p.Schema,
+		Schema:                          schema,
 		Fragments:                       fragments,
 		Root:                            p.Root,
 		Operation:                       operation,
@@ -171,6 +653,8 @@ func buildExecutionContext(p BuildExecutionCtxParams) (*ExecutionContext, error)
 		FieldDefinitionDirectiveHandler: p.FieldDefinitionDirectiveHandler,
 		DisallowIntrospection:           p.DisallowIntrospection,
 		Tracer:                          p.Tracer,
+		OrderedData:                     p.OrderedData,
+		Deterministic:                   p.Deterministic,
 	}, nil
 }
 
@@ -186,10 +670,15 @@ func executeOperation(ctx context.Context, p ExecuteOperationParams) *Result {
 		return &Result{Errors: gqlerrors.FormatErrors(err)}
 	}
 
+	var responseNames *[]string
+	if p.ExecutionContext.OrderedData || p.ExecutionContext.Deterministic {
+		responseNames = &[]string{}
+	}
 	fields := collectFields(CollectFieldsParams{
-		ExeContext:   p.ExecutionContext,
-		RuntimeType:  operationType,
-		SelectionSet: p.Operation.GetSelectionSet(),
+		ExeContext:    p.ExecutionContext,
+		RuntimeType:   operationType,
+		SelectionSet:  p.Operation.GetSelectionSet(),
+		ResponseNames: responseNames,
 	})
 
 	executeFieldsParams := ExecuteFieldsParams{
@@ -198,6 +687,9 @@ func executeOperation(ctx context.Context, p ExecuteOperationParams) *Result {
 		Source:           p.Root,
 		Fields:           fields,
 	}
+	if responseNames != nil {
+		executeFieldsParams.ResponseNames = *responseNames
+	}
 
 	return executeFieldsSerially(ctx, executeFieldsParams, nil)
 }
@@ -205,7 +697,7 @@ func executeOperation(ctx context.Context, p ExecuteOperationParams) *Result {
 // Extracts the root type of the operation from the schema.
 func getOperationRootType(schema Schema, operation ast.Definition) (*Object, error) {
 	if operation == nil {
-		return nil, errors.New("Can only execute queries and mutations")
+		return nil, gqlerrors.CodeInvalidOperationType.New(nil, nil)
 	}
 
 	switch operation.GetOperation() {
@@ -213,42 +705,18 @@ func getOperationRootType(schema Schema, operation ast.Definition) (*Object, err
 		return schema.QueryType(), nil
 	case ast.OperationTypeMutation:
 		mutationType := schema.MutationType()
-		if mutationType.PrivateName == "" {
-			return nil, gqlerrors.NewError(
-				gqlerrors.ErrorTypeBadQuery,
-				"Schema is not configured for mutations",
-				[]ast.Node{operation},
-				"",
-				nil,
-				[]int{},
-				nil,
-			)
+		if mutationType == nil || mutationType.PrivateName == "" {
+			return nil, gqlerrors.CodeUnsupportedOperation.New([]ast.Node{operation}, nil, ast.OperationTypeMutation)
 		}
 		return mutationType, nil
 	case ast.OperationTypeSubscription:
 		subscriptionType := schema.SubscriptionType()
-		if subscriptionType.PrivateName == "" {
-			return nil, gqlerrors.NewError(
-				gqlerrors.ErrorTypeBadQuery,
-				"Schema is not configured for subscriptions",
-				[]ast.Node{operation},
-				"",
-				nil,
-				[]int{},
-				nil,
-			)
+		if subscriptionType == nil || subscriptionType.PrivateName == "" {
+			return nil, gqlerrors.CodeUnsupportedOperation.New([]ast.Node{operation}, nil, ast.OperationTypeSubscription)
 		}
 		return subscriptionType, nil
 	}
-	return nil, gqlerrors.NewError(
-		gqlerrors.ErrorTypeBadQuery,
-		"Can only execute queries, mutations and subscription",
-		[]ast.Node{operation},
-		"",
-		nil,
-		[]int{},
-		nil,
-	)
+	return nil, gqlerrors.CodeInvalidOperationType.New([]ast.Node{operation}, nil)
 }
 
 type ExecuteFieldsParams struct {
@@ -256,6 +724,9 @@ type ExecuteFieldsParams struct {
 	ParentType       *Object
 	Source           any
 	Fields           map[string][]*ast.Field
+	// ResponseNames if set is the order in which the fields are executed. The data is
+	// built as an OrderedMap if the execution context has OrderedData set.
+	ResponseNames []string
 }
 
 func executeFieldsSerially(ctx context.Context, p ExecuteFieldsParams, path []string) *Result {
@@ -266,22 +737,94 @@ func executeFieldsSerially(ctx context.Context, p ExecuteFieldsParams, path []st
 		p.Fields = make(map[string][]*ast.Field)
 	}
 
-	finalResults := make(map[string]any)
-	for responseName, fieldASTs := range p.Fields {
+	batch := batchResolve(ctx, p.ExecutionContext, p.ParentType, p.Source, p.Fields, p.ResponseNames, path)
+	deps := newFieldDependencies(p.ParentType)
+
+	executeField := func(ctx context.Context, responseName string, fieldASTs []*ast.Field) (any, bool) {
 		name := responseName
 		if len(fieldASTs) != 0 && fieldASTs[0].Name != nil {
 			name = fieldASTs[0].Name.Value
 		}
-		resolved, state := resolveField(ctx, p.ExecutionContext, p.ParentType, p.Source, fieldASTs, append(path, name))
-		if state.hasNoFieldDefs {
-			continue
+		// The path is copied as sibling fields may be executed concurrently
+		resolved, state := resolveField(ctx, p.ExecutionContext, p.ParentType, p.Source, fieldASTs, append(path[:len(path):len(path)], name), batch, deps)
+		return resolved, !state.hasNoFieldDefs
+	}
+
+	// The root fields of a mutation have side effects so each alias must execute.
+	mutationRoot := len(path) == 0 && p.ExecutionContext.Operation.GetOperation() == ast.OperationTypeMutation
+	if p.ExecutionContext.dedupeAliases && !mutationRoot {
+		if keys := aliasedFieldKeys(p.Fields); len(keys) != 0 {
+			type dedupedField struct {
+				resolved any
+				ok       bool
+			}
+			var mu sync.Mutex
+			deduped := make(map[string]dedupedField, len(keys))
+			resolve := executeField
+			executeField = func(ctx context.Context, responseName string, fieldASTs []*ast.Field) (any, bool) {
+				key, aliased := keys[responseName]
+				if !aliased {
+					return resolve(ctx, responseName, fieldASTs)
+				}
+				mu.Lock()
+				f, ok := deduped[key]
+				mu.Unlock()
+				if ok {
+					return f.resolved, f.ok
+				}
+				resolved, ok := resolve(ctx, responseName, fieldASTs)
+				mu.Lock()
+				deduped[key] = dedupedField{resolved: resolved, ok: ok}
+				mu.Unlock()
+				return resolved, ok
+			}
+		}
+	}
+
+	if len(path) == 0 && p.ExecutionContext.checkpoints != nil {
+		executeField = p.ExecutionContext.checkpoints.wrap(p.ExecutionContext, executeField)
+	}
+
+	if p.ExecutionContext.strategy != nil {
+		return executeFieldsWithStrategy(ctx, p, executeField)
+	}
+
+	if p.ResponseNames != nil && !p.ExecutionContext.OrderedData {
+		finalResults := make(map[string]any, len(p.ResponseNames))
+		for _, responseName := range p.ResponseNames {
+			if resolved, ok := executeField(ctx, responseName, p.Fields[responseName]); ok {
+				finalResults[responseName] = resolved
+			}
+		}
+		return &Result{
+			Data:   finalResults,
+			Errors: p.ExecutionContext.errors(),
+		}
+	}
+
+	if p.ResponseNames != nil {
+		orderedResults := make(OrderedMap, 0, len(p.ResponseNames))
+		for _, responseName := range p.ResponseNames {
+			if resolved, ok := executeField(ctx, responseName, p.Fields[responseName]); ok {
+				orderedResults = append(orderedResults, KeyValue{Key: responseName, Value: resolved})
+			}
+		}
+		return &Result{
+			Data:   orderedResults,
+			Errors: p.ExecutionContext.errors(),
+		}
+	}
+
+	finalResults := make(map[string]any)
+	for responseName, fieldASTs := range p.Fields {
+		if resolved, ok := executeField(ctx, responseName, fieldASTs); ok {
+			finalResults[responseName] = resolved
 		}
-		finalResults[responseName] = resolved
 	}
 
 	return &Result{
 		Data:   finalResults,
-		Errors: p.ExecutionContext.Errors,
+		Errors: p.ExecutionContext.errors(),
 	}
 }
 
@@ -291,6 +834,8 @@ type CollectFieldsParams struct {
 	SelectionSet         *ast.SelectionSet
 	Fields               map[string][]*ast.Field
 	VisitedFragmentNames map[string]struct{}
+	// ResponseNames if set has the response names appended in the order they're first collected.
+	ResponseNames *[]string
 }
 
 // Given a selectionSet, adds all of the fields in that selection to
@@ -316,6 +861,9 @@ func collectFields(p CollectFieldsParams) map[string][]*ast.Field {
 				continue
 			}
 			name := getFieldEntryKey(selection)
+			if _, ok := fields[name]; !ok && p.ResponseNames != nil {
+				*p.ResponseNames = append(*p.ResponseNames, name)
+			}
 			fields[name] = append(fields[name], selection)
 		case *ast.InlineFragment:
 			if !shouldIncludeNode(p.ExeContext, selection.Directives) ||
@@ -328,6 +876,7 @@ func collectFields(p CollectFieldsParams) map[string][]*ast.Field {
 				SelectionSet:         selection.SelectionSet,
 				Fields:               fields,
 				VisitedFragmentNames: p.VisitedFragmentNames,
+				ResponseNames:        p.ResponseNames,
 			}
 			collectFields(innerParams)
 		case *ast.FragmentSpread:
@@ -354,6 +903,7 @@ func collectFields(p CollectFieldsParams) map[string][]*ast.Field {
 				SelectionSet:         fragment.GetSelectionSet(),
 				Fields:               fields,
 				VisitedFragmentNames: p.VisitedFragmentNames,
+				ResponseNames:        p.ResponseNames,
 			}
 			collectFields(innerParams)
 		}
@@ -417,47 +967,33 @@ func shouldIncludeNode(eCtx *ExecutionContext, directives []*ast.Directive) bool
 func doesFragmentConditionMatch(eCtx *ExecutionContext, fragment ast.Node, ttype *Object) bool {
 	switch fragment := fragment.(type) {
 	case *ast.FragmentDefinition:
-		if fragment.TypeCondition == nil {
-			return true
-		}
-		conditionalType, err := typeFromAST(eCtx.Schema, fragment.TypeCondition)
-		if err != nil {
-			return false
-		}
-		if conditionalType == ttype {
-			return true
-		}
-		if conditionalType.Name() == ttype.Name() {
-			return true
-		}
-		if conditionalType, ok := conditionalType.(*Interface); ok {
-			return eCtx.Schema.IsPossibleType(conditionalType, ttype)
-		}
-		if conditionalType, ok := conditionalType.(*Union); ok {
-			return eCtx.Schema.IsPossibleType(conditionalType, ttype)
-		}
+		return typeConditionMatches(eCtx.Schema, fragment.TypeCondition, ttype)
 	case *ast.InlineFragment:
-		if fragment.TypeCondition == nil {
-			return true
-		}
-		conditionalType, err := typeFromAST(eCtx.Schema, fragment.TypeCondition)
-		if err != nil {
-			return false
-		}
-		if conditionalType == ttype {
-			return true
-		}
-		if conditionalType.Name() == ttype.Name() {
-			return true
-		}
-		if conditionalType, ok := conditionalType.(*Interface); ok {
-			return eCtx.Schema.IsPossibleType(conditionalType, ttype)
-		}
-		if conditionalType, ok := conditionalType.(*Union); ok {
-			return eCtx.Schema.IsPossibleType(conditionalType, ttype)
-		}
+		return typeConditionMatches(eCtx.Schema, fragment.TypeCondition, ttype)
 	}
+	return false
+}
 
+// typeConditionMatches returns true if a fragment with the type condition applies
+// to the runtime type. Fragments below abstract types (e.g. "... on Dog") mostly
+// name object types so the name is compared first and a condition naming any
+// other object type is skipped without checking possible types.
+func typeConditionMatches(schema Schema, condition *ast.Named, ttype *Object) bool {
+	if condition == nil {
+		return true
+	}
+	if condition.Name == nil {
+		return false
+	}
+	if condition.Name.Value == ttype.Name() {
+		return true
+	}
+	switch conditionalType := schema.Type(condition.Name.Value).(type) {
+	case *Interface:
+		return schema.IsPossibleType(conditionalType, ttype)
+	case *Union:
+		return schema.IsPossibleType(conditionalType, ttype)
+	}
 	return false
 }
 
@@ -481,7 +1017,7 @@ type resolveFieldResultState struct {
 // figures out the value that the field returns by calling its resolve function,
 // then calls completeValue to complete promises, serialize scalars, or execute
 // the sub-selection-set for objects.
-func resolveField(ctx context.Context, eCtx *ExecutionContext, parentType *Object, source any, fieldASTs []*ast.Field, path []string) (result any, resultState resolveFieldResultState) {
+func resolveField(ctx context.Context, eCtx *ExecutionContext, parentType *Object, source any, fieldASTs []*ast.Field, path []string, batch *batchResult, deps *fieldDependencies) (result any, resultState resolveFieldResultState) {
 	if err := ctx.Err(); err != nil {
 		// Jump straight to the top-level recover to void anymore work.
 		panic(gqlerrors.FormatError(err))
@@ -489,6 +1025,7 @@ func resolveField(ctx context.Context, eCtx *ExecutionContext, parentType *Objec
 
 	// catch panic from resolveFn
 	var returnType Output
+	var explaining bool
 	defer func() (any, resolveFieldResultState) {
 		if r := recover(); r != nil {
 			var err error
@@ -497,16 +1034,25 @@ func resolveField(ctx context.Context, eCtx *ExecutionContext, parentType *Objec
 			} else {
 				err = gqlerrors.FormatPanic(r)
 			}
+			if explaining {
+				eCtx.explain.add(ExplainEvent{Type: ExplainFieldEnd, Path: path, Error: err.Error()})
+			}
 			// send panic upstream
 			if _, ok := returnType.(*NonNull); ok {
 				panic(gqlerrors.FormatError(err))
 			}
-			eCtx.Errors = append(eCtx.Errors, gqlerrors.FormatError(err))
+			eCtx.addError(gqlerrors.FormatError(err))
 			return result, resultState
 		}
+		if explaining {
+			eCtx.explain.add(ExplainEvent{Type: ExplainFieldEnd, Path: path})
+		}
 		return result, resultState
 	}()
 
+	// Don't resolve any more fields once the result is too large
+	eCtx.resultSize.check(fieldASTs, path)
+
 	fieldAST := fieldASTs[0]
 	fieldName := ""
 	if fieldAST.Name != nil {
@@ -518,12 +1064,64 @@ func resolveField(ctx context.Context, eCtx *ExecutionContext, parentType *Objec
 		resultState.hasNoFieldDefs = true
 		return nil, resultState
 	}
+	if fieldDef == TypeNameMetaFieldDef {
+		// The name of the runtime type is known so there's nothing to resolve
+		return parentType.Name(), resultState
+	}
 
+	returnType = fieldDef.Type
+	if fieldAST.Nullability != "" {
+		returnType = fieldNullabilityType(returnType, fieldAST.Nullability).(Output)
+	}
+	if eCtx.cacheControl != nil {
+		eCtx.cacheControl.fieldHint(eCtx.Schema, parentType, fieldDef, path)
+	}
+	if _, ok := GetNullable(returnType).(*List); ok && (fieldDef.Resolve != nil || eCtx.resolverOverrides != nil) {
+		// The resolver may return a channel fed by a producer that stops once its
+		// context is done (see StreamList). Completing the list can stop receiving
+		// early (e.g. a non-null item is null) so cancel the context once the
+		// field is completed or the producer would block sending forever.
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		defer cancel()
+	}
+	if eCtx.explain != nil {
+		explaining = true
+		eCtx.explain.add(ExplainEvent{Type: ExplainFieldStart, Path: path, TypeName: parentType.Name(), FieldName: fieldDef.Name})
+	}
+	var info ResolveInfo
+	if deps.shares(fieldDef, fieldASTs) {
+		result = deps.resolve(ctx, eCtx, parentType, source, fieldDef, fieldASTs, path)
+		info = newResolveInfo(eCtx, parentType, fieldDef, fieldASTs)
+	} else {
+		result, info = resolveFieldValue(ctx, eCtx, parentType, fieldDef, source, fieldASTs, path, batch, deps)
+	}
+
+	var completed any
+	if fieldDef.Passthrough {
+		completed = completePassthroughValueCatchingError(ctx, eCtx, returnType, fieldASTs, info, result, path)
+	} else {
+		completed = completeValueCatchingError(ctx, eCtx, returnType, fieldASTs, info, result, path)
+	}
+	if eCtx.masking != nil {
+		completed = eCtx.masking.apply(ctx, parentType, fieldDef, returnType, fieldASTs, path, completed)
+	}
+	return completed, resultState
+}
+
+// resolveFieldValue runs the field middleware and resolve function for a field
+// returning the uncompleted value. If the field was resolved by the batch resolver
+// of the parent type then the batched value is used instead. The dependencies of the
+// field are resolved first using deps if it's set. Errors are raised as panics.
+func resolveFieldValue(ctx context.Context, eCtx *ExecutionContext, parentType *Object, fieldDef *FieldDefinition, source any, fieldASTs []*ast.Field, path []string, batch *batchResult, deps *fieldDependencies) (any, ResolveInfo) {
 	if fieldDef.DeprecationReason != "" && eCtx.DeprecatedFieldFn != nil {
 		if err := eCtx.DeprecatedFieldFn(ctx, parentType, fieldDef); err != nil {
 			panic(gqlerrors.FormatError(err))
 		}
 	}
+	if eCtx.enforceSunset(parentType, fieldDef, fieldASTs) {
+		return nil, newResolveInfo(eCtx, parentType, fieldDef, fieldASTs)
+	}
 
 	if len(fieldDef.Directives) != 0 && eCtx.FieldDefinitionDirectiveHandler != nil {
 		for _, d := range fieldDef.Directives {
@@ -534,52 +1132,179 @@ func resolveField(ctx context.Context, eCtx *ExecutionContext, parentType *Objec
 	}
 
 	var customResolver bool
-	returnType = fieldDef.Type
 	resolveFn := fieldDef.Resolve
+	if eCtx.resolverOverrides != nil {
+		if override := eCtx.resolverOverrides[parentType.Name()+"."+fieldDef.Name]; override != nil {
+			resolveFn = override
+		}
+	}
 	if resolveFn == nil {
 		resolveFn = defaultResolveFn
 	} else {
 		customResolver = true
 	}
 
-	// Build a map of arguments from the field.arguments AST, using the
-	// variables scope to fulfill any variable references.
-	// TODO: find a way to memoize, in case this field is within a List type.
-	args := getArgumentValues(fieldDef.Args, fieldAST.Arguments, eCtx.VariableValues)
+	args, err := fieldArgumentValues(ctx, eCtx, fieldDef, fieldASTs, path)
+	if err != nil {
+		panic(invalidArgsError(err, fieldASTs, path))
+	}
 
-	info := ResolveInfo{
-		FieldName:      fieldName,
-		FieldASTs:      fieldASTs,
-		ReturnType:     returnType,
-		ParentType:     parentType,
-		Schema:         eCtx.Schema,
-		Fragments:      eCtx.Fragments,
-		RootValue:      eCtx.Root,
-		Operation:      eCtx.Operation,
-		VariableValues: eCtx.VariableValues,
+	info := newResolveInfo(eCtx, parentType, fieldDef, fieldASTs)
+	if eCtx.fieldHook != nil {
+		eCtx.fieldHook(ctx, info, path)
 	}
 
-	var resolveFnError error
+	if eCtx.replay != nil {
+		if value, ok := replayFieldValue(eCtx, customResolver, path); ok {
+			return value, info
+		}
+	}
+
+	if batch != nil && !customResolver {
+		if batch.err != nil {
+			if eCtx.capture != nil {
+				eCtx.capture.add(path, nil, batch.err)
+			}
+			panic(gqlerrors.FormatError(batch.err))
+		}
+		value := batch.values[getFieldEntryKey(fieldASTs[0])]
+		if eCtx.capture != nil {
+			eCtx.capture.add(path, value, nil)
+		}
+		return value, info
+	}
+
+	var dependencies map[string]any
+	if customResolver && len(fieldDef.DependsOn) != 0 {
+		if deps == nil {
+			deps = &fieldDependencies{values: make(map[string]*dependencyValue)}
+		}
+		dependencies = deps.resolveDependencies(ctx, eCtx, parentType, source, fieldDef, fieldASTs, path)
+	}
+
+	var bulkhead bool
+	if customResolver && parentType.Bulkhead != nil {
+		release, err := acquireBulkhead(ctx, parentType, fieldASTs, path)
+		if err != nil {
+			panic(err)
+		}
+		defer release()
+		bulkhead = true
+	}
+
+	resolveCtx := ctx
+	if customResolver && fieldDef.detachedTimeout > 0 {
+		var cancel context.CancelFunc
+		resolveCtx, cancel = context.WithTimeout(context.WithoutCancel(ctx), fieldDef.detachedTimeout)
+		defer cancel()
+	}
 
 	var st time.Time
 	if customResolver && eCtx.Tracer != nil {
 		st = time.Now()
 	}
-	result, resolveFnError = resolveFn(ctx, ResolveParams{
-		Source: source,
-		Args:   args,
-		Info:   info,
+	result, resolveFnError := resolveFn(resolveCtx, ResolveParams{
+		Source:       source,
+		Args:         args,
+		Info:         info,
+		Dependencies: dependencies,
 	})
 	if !st.IsZero() {
 		eCtx.Tracer.Trace(ctx, path, time.Since(st))
 	}
+	if customResolver && eCtx.capture != nil {
+		eCtx.capture.add(path, result, resolveFnError)
+	}
+	if !customResolver && result == nil && eCtx.logger != nil {
+		if msg := defaultResolveAnomaly(source, fieldDef.Name, eCtx.Schema.fieldNameCaseFallback); msg != "" {
+			eCtx.logger.WarnContext(ctx, msg, "type", parentType.Name(), "field", fieldDef.Name,
+				"path", append([]string(nil), path...), "sourceType", fmt.Sprintf("%T", source))
+		}
+	}
 
 	if resolveFnError != nil {
 		panic(gqlerrors.FormatError(resolveFnError))
 	}
 
-	completed := completeValueCatchingError(ctx, eCtx, returnType, fieldASTs, info, result, path)
-	return completed, resultState
+	if bulkhead {
+		// A func() any result is called when the value is completed which would be
+		// after the slot is released so call it now.
+		if fn, ok := result.(func() any); ok && fn != nil {
+			value := fn()
+			result = func() any { return value }
+		}
+	}
+
+	return result, info
+}
+
+// fieldArgumentValues returns the arguments passed to the resolver (or subscribe
+// function) of a field. The values are coerced from the field.arguments AST using
+// the variables, checked, sanitized, clamped, and the injected arguments added.
+// The returned error is the first invalid argument.
+func fieldArgumentValues(ctx context.Context, eCtx *ExecutionContext, fieldDef *FieldDefinition, fieldASTs []*ast.Field, path []string) (map[string]any, error) {
+	// TODO: find a way to memoize, in case this field is within a List type.
+	args := getArgumentValues(fieldDef.Args, fieldASTs[0].Arguments, eCtx.VariableValues)
+	if !eCtx.shareArgs {
+		copyArgValues(args)
+	}
+	if err := checkNullVariableArguments(fieldDef.Args, fieldASTs[0].Arguments, eCtx.VariableValues); err != nil {
+		return nil, err
+	}
+	if len(args) != 0 {
+		if err := checkEnumVisibility(ctx, fieldDef.Args, args); err != nil {
+			return nil, err
+		}
+	}
+	args = sanitizeArgs(eCtx.Schema.sanitizers, fieldDef.Args, args)
+	if len(fieldDef.maxArgs) != 0 {
+		eCtx.argumentClamps.clampArgs(fieldDef.maxArgs, args, path)
+	}
+	if len(args) != 0 {
+		eCtx.explain.add(ExplainEvent{Type: ExplainArguments, Path: path, Values: args})
+	}
+	if len(fieldDef.injectedArgs) != 0 {
+		args = injectArguments(ctx, eCtx.Schema.argumentInjectors, fieldDef.injectedArgs, args)
+	}
+	if fieldDef.ValidateArgs != nil {
+		if err := fieldDef.ValidateArgs(ctx, args); err != nil {
+			return nil, err
+		}
+	}
+	return args, nil
+}
+
+// invalidArgsError returns an invalid input error for a field including the path to the field.
+func invalidArgsError(err error, fieldASTs []*ast.Field, path []string) gqlerrors.FormattedError {
+	fe := gqlerrors.FormatError(gqlerrors.NewError(
+		gqlerrors.ErrorTypeInvalidInput,
+		err.Error(),
+		FieldASTsToNodeASTs(fieldASTs),
+		"",
+		nil,
+		nil,
+		err,
+	))
+	fe.Path = make([]any, len(path))
+	for i, p := range path {
+		fe.Path[i] = p
+	}
+	return fe
+}
+
+func newResolveInfo(eCtx *ExecutionContext, parentType *Object, fieldDef *FieldDefinition, fieldASTs []*ast.Field) ResolveInfo {
+	return ResolveInfo{
+		FieldName:      fieldDef.Name,
+		FieldASTs:      fieldASTs,
+		ReturnType:     fieldDef.Type,
+		ParentType:     parentType,
+		Schema:         eCtx.Schema,
+		Fragments:      eCtx.Fragments,
+		RootValue:      eCtx.Root,
+		Operation:      eCtx.Operation,
+		VariableValues: eCtx.VariableValues,
+		Metadata:       fieldDef.Metadata,
+	}
 }
 
 func completeValueCatchingError(ctx context.Context, eCtx *ExecutionContext, returnType Type, fieldASTs []*ast.Field, info ResolveInfo, result any, path []string) (completed any) {
@@ -591,7 +1316,7 @@ func completeValueCatchingError(ctx context.Context, eCtx *ExecutionContext, ret
 				panic(r)
 			}
 			if err, ok := r.(gqlerrors.FormattedError); ok {
-				eCtx.Errors = append(eCtx.Errors, err)
+				eCtx.addError(err)
 			}
 			return completed
 		}
@@ -612,7 +1337,7 @@ func completeValue(ctx context.Context, eCtx *ExecutionContext, returnType Type,
 	}
 
 	resultVal := reflect.ValueOf(result)
-	if resultVal.IsValid() && resultVal.Type().Kind() == reflect.Func {
+	if resultVal.IsValid() && resultVal.Type().Kind() == reflect.Func && !resultVal.IsNil() {
 		if propertyFn, ok := result.(func() any); ok {
 			return propertyFn()
 		}
@@ -624,10 +1349,7 @@ func completeValue(ctx context.Context, eCtx *ExecutionContext, returnType Type,
 	if returnType, ok := returnType.(*NonNull); ok {
 		completed := completeValue(ctx, eCtx, returnType.OfType, fieldASTs, info, result, path)
 		if completed == nil {
-			err := NewLocatedError(
-				fmt.Sprintf("Cannot return null for non-nullable field %v.%v.", info.ParentType, info.FieldName),
-				FieldASTsToNodeASTs(fieldASTs),
-			)
+			err := gqlerrors.CodeNonNullViolation.New(FieldASTsToNodeASTs(fieldASTs), nil, info.ParentType, info.FieldName)
 			panic(gqlerrors.FormatError(err))
 		}
 		return completed
@@ -646,10 +1368,19 @@ func completeValue(ctx context.Context, eCtx *ExecutionContext, returnType Type,
 	// If field type is a leaf type, Scalar or Enum, serialize to a valid value,
 	// returning null if serialization is not possible.
 	if returnType, ok := returnType.(*Scalar); ok {
-		return completeLeafValue(returnType, result)
+		if raw, ok := result.(RawJSON); ok && acceptsRawJSON(returnType, info) {
+			completed := completeRawJSONValue(info, fieldASTs, raw)
+			eCtx.resultSize.add(len(raw)+resultValueOverhead, fieldASTs, path)
+			return completed
+		}
+		completed := completeLeafValue(ctx, eCtx, returnType, result, path)
+		eCtx.resultSize.add(leafValueSize(completed), fieldASTs, path)
+		return completed
 	}
 	if returnType, ok := returnType.(*Enum); ok {
-		return completeLeafValue(returnType, result)
+		completed := completeLeafValue(ctx, eCtx, returnType, result, path)
+		eCtx.resultSize.add(leafValueSize(completed), fieldASTs, path)
+		return completed
 	}
 
 	// If field type is an abstract type, Interface or Union, determine the
@@ -679,26 +1410,37 @@ func completeAbstractValue(ctx context.Context, eCtx *ExecutionContext, returnTy
 		Value: result,
 		Info:  info,
 	}
-	if unionReturnType, ok := returnType.(*Union); ok && unionReturnType.ResolveType != nil {
+	if nv, ok := result.(*nodeValue); ok {
+		// The node field knows the type from the global ID
+		runtimeType = nv.typ
+		result = nv.value
+	} else if unionReturnType, ok := returnType.(*Union); ok && unionReturnType.ResolveType != nil {
 		runtimeType = unionReturnType.ResolveType(ctx, resolveTypeParams)
 	} else if interfaceReturnType, ok := returnType.(*Interface); ok && interfaceReturnType.ResolveType != nil {
 		runtimeType = interfaceReturnType.ResolveType(ctx, resolveTypeParams)
 	} else {
-		runtimeType = defaultResolveTypeFn(resolveTypeParams, returnType)
+		runtimeType = resolveTypeByIsTypeOf(eCtx, resolveTypeParams, returnType)
+	}
+
+	if eCtx.explain != nil {
+		e := ExplainEvent{Type: ExplainResolveType, Path: path, TypeName: returnType.Name()}
+		if runtimeType != nil {
+			e.RuntimeType = runtimeType.Name()
+		}
+		eCtx.explain.add(e)
 	}
 
 	if runtimeType == nil {
-		panic(gqlerrors.NewFormattedError(
-			fmt.Sprintf(`Abstract type %v must resolve to an Object type at runtime `+
-				`for field %v.%v with value "%v", received "%v".`,
-				returnType, info.ParentType, info.FieldName, result, runtimeType)))
+		panic(gqlerrors.FormatError(gqlerrors.CodeUnresolvedAbstractType.New(FieldASTsToNodeASTs(fieldASTs), nil,
+			returnType, info.ParentType, info.FieldName, result, runtimeType)))
+	}
+
+	if eCtx.Schema.IsRestrictedType(runtimeType) {
+		panic(gqlerrors.FormatError(gqlerrors.CodeRestrictedType.New(FieldASTsToNodeASTs(fieldASTs), nil, runtimeType, returnType)))
 	}
 
 	if !eCtx.Schema.IsPossibleType(returnType, runtimeType) {
-		panic(gqlerrors.NewFormattedError(
-			fmt.Sprintf(`Runtime Object type "%v" is not a possible type `+
-				`for "%v".`, runtimeType, returnType),
-		))
+		panic(gqlerrors.FormatError(gqlerrors.CodeImpossibleType.New(FieldASTsToNodeASTs(fieldASTs), nil, runtimeType, returnType)))
 	}
 
 	return completeObjectValue(ctx, eCtx, runtimeType, fieldASTs, info, result, path)
@@ -708,22 +1450,33 @@ func completeAbstractValue(ctx context.Context, eCtx *ExecutionContext, returnTy
 func completeObjectValue(ctx context.Context, eCtx *ExecutionContext, returnType *Object, fieldASTs []*ast.Field, info ResolveInfo, result any, path []string) any {
 	// If there is an isTypeOf predicate function, call it with the
 	// current result. If isTypeOf returns false, then raise an error rather
-	// than continuing execution.
-	if returnType.IsTypeOf != nil {
+	// than continuing execution. It's not needed if only __typename is
+	// requested as the type is known.
+	if returnType.IsTypeOf != nil && !selectsOnlyTypename(fieldASTs) {
 		p := IsTypeOfParams{
 			Value: result,
 			Info:  info,
 		}
 		if !returnType.IsTypeOf(p) {
-			panic(gqlerrors.NewFormattedError(
-				fmt.Sprintf(`Expected value of type "%v" but got: %T.`, returnType, result),
-			))
+			panic(gqlerrors.FormatError(gqlerrors.CodeUnexpectedValue.New(FieldASTsToNodeASTs(fieldASTs), nil, returnType, result)))
+		}
+	}
+
+	if returnType.OnResolved != nil {
+		var err error
+		result, err = returnType.OnResolved(ctx, result)
+		if err != nil {
+			panic(gqlerrors.FormatError(NewLocatedError(err, FieldASTsToNodeASTs(fieldASTs))))
 		}
 	}
 
 	// Collect sub-fields to execute to complete this value.
 	subFieldASTs := make(map[string][]*ast.Field)
 	visitedFragmentNames := make(map[string]struct{})
+	var responseNames *[]string
+	if eCtx.OrderedData || eCtx.Deterministic {
+		responseNames = &[]string{}
+	}
 	for _, fieldAST := range fieldASTs {
 		if fieldAST == nil {
 			continue
@@ -736,16 +1489,27 @@ func completeObjectValue(ctx context.Context, eCtx *ExecutionContext, returnType
 				SelectionSet:         selectionSet,
 				Fields:               subFieldASTs,
 				VisitedFragmentNames: visitedFragmentNames,
+				ResponseNames:        responseNames,
 			}
 			subFieldASTs = collectFields(innerParams)
 		}
 	}
+	if eCtx.resultSize != nil {
+		var size int
+		for name := range subFieldASTs {
+			size += len(name) + resultValueOverhead
+		}
+		eCtx.resultSize.add(size, fieldASTs, path)
+	}
 	executeFieldsParams := ExecuteFieldsParams{
 		ExecutionContext: eCtx,
 		ParentType:       returnType,
 		Source:           result,
 		Fields:           subFieldASTs,
 	}
+	if responseNames != nil {
+		executeFieldsParams.ResponseNames = *responseNames
+	}
 	results := executeFieldsSerially(ctx, executeFieldsParams, path)
 
 	return results.Data
@@ -753,9 +1517,28 @@ func completeObjectValue(ctx context.Context, eCtx *ExecutionContext, returnType
 }
 
 // completeLeafValue complete a leaf value (Scalar / Enum) by serializing to a valid value, returning nil if serialization is not possible.
-func completeLeafValue(returnType Leaf, result any) any {
-	serializedResult := returnType.Serialize(result)
+func completeLeafValue(ctx context.Context, eCtx *ExecutionContext, returnType Leaf, result any, path []string) any {
+	var serializedResult any
+	if scalar, ok := returnType.(*Scalar); ok {
+		if scalar == String && eCtx.streamStrings != StreamEncodingNone {
+			if r, ok := result.(io.Reader); ok {
+				return &StreamedString{Reader: r, Encoding: eCtx.streamStrings}
+			}
+		}
+		serializedResult = scalar.SerializeCtx(ctx, result)
+		if eCtx.strictJSON && scalar == Float {
+			checkStrictFloat(serializedResult)
+		}
+	} else if enum, ok := returnType.(*Enum); ok && eCtx.strictJSON {
+		serializedResult = serializeEnumStrict(enum, result)
+	} else {
+		serializedResult = returnType.Serialize(result)
+	}
 	if isNullish(serializedResult) {
+		if eCtx.logger != nil {
+			eCtx.logger.WarnContext(ctx, "graphql: value could not be serialized",
+				"type", returnType.Name(), "path", append([]string(nil), path...), "valueType", fmt.Sprintf("%T", result))
+		}
 		return nil
 	}
 	return serializedResult
@@ -768,20 +1551,55 @@ func completeListValue(ctx context.Context, eCtx *ExecutionContext, returnType *
 	if info.ParentType != nil {
 		parentTypeName = info.ParentType.Name()
 	}
+	if resultVal.IsValid() && resultVal.Kind() == reflect.Chan && !resultVal.IsNil() {
+		return completeChanListValue(ctx, eCtx, returnType, fieldASTs, info, resultVal, path)
+	}
 	if !resultVal.IsValid() || resultVal.Type().Kind() != reflect.Slice {
-		panic(gqlerrors.NewFormattedError(fmt.Sprintf("User Error: expected iterable, but did not find one for field %v.%v.", parentTypeName, info.FieldName)))
+		panic(gqlerrors.FormatError(gqlerrors.CodeListExpected.New(nil, nil, parentTypeName, info.FieldName)))
 	}
 
+	eCtx.resultSize.add(resultVal.Len()*resultValueOverhead, fieldASTs, path)
+
 	itemType := returnType.OfType
 	completedResults := make([]any, 0, resultVal.Len())
 	for i := 0; i < resultVal.Len(); i++ {
 		val := resultVal.Index(i).Interface()
-		completedItem := completeValueCatchingError(ctx, eCtx, itemType, fieldASTs, info, val, path)
+		completedItem := completeListItemCatchingError(ctx, eCtx, itemType, fieldASTs, info, val, path, i)
 		completedResults = append(completedResults, completedItem)
 	}
 	return completedResults
 }
 
+// completeListItemCatchingError completes an item of a list. An error completing
+// the item (e.g. resolving the type of a value of an abstract type) is reported
+// with the path of the item unless it already has a path, and only nulls the item
+// unless the item type is non-null in which case it propagates to the list.
+func completeListItemCatchingError(ctx context.Context, eCtx *ExecutionContext, itemType Type, fieldASTs []*ast.Field, info ResolveInfo, item any, path []string, index int) (completed any) {
+	defer func() {
+		if r := recover(); r != nil {
+			var err gqlerrors.FormattedError
+			if s, ok := r.(string); ok {
+				err = gqlerrors.FormatError(NewLocatedError(s, FieldASTsToNodeASTs(fieldASTs)))
+			} else {
+				err = gqlerrors.FormatPanic(r)
+			}
+			if err.Path == nil {
+				err.Path = make([]any, 0, len(path)+1)
+				for _, p := range path {
+					err.Path = append(err.Path, p)
+				}
+				err.Path = append(err.Path, index)
+			}
+			if _, ok := itemType.(*NonNull); ok {
+				panic(err)
+			}
+			eCtx.addError(err)
+			completed = nil
+		}
+	}()
+	return completeValue(ctx, eCtx, itemType, fieldASTs, info, item, path)
+}
+
 type structFieldInfo struct {
 	index     int
 	omitempty bool
@@ -839,7 +1657,7 @@ func fieldInfoForStruct(structType reflect.Type) map[string]structFieldInfo {
 // used which tests each possible type for the abstract type by calling
 // isTypeOf for the object being coerced, returning the first type that matches.
 func defaultResolveTypeFn(p ResolveTypeParams, abstractType Abstract) *Object {
-	possibleTypes := p.Info.Schema.PossibleTypes(abstractType)
+	possibleTypes := p.Info.Schema.allPossibleTypes(abstractType)
 	for _, possibleType := range possibleTypes {
 		if possibleType.IsTypeOf == nil {
 			continue
@@ -851,23 +1669,76 @@ func defaultResolveTypeFn(p ResolveTypeParams, abstractType Abstract) *Object {
 	return nil
 }
 
+// isTypeOfKey is the key of an object type resolved by IsTypeOf for a Go type.
+type isTypeOfKey struct {
+	abstractType string
+	goType       reflect.Type
+}
+
+// resolveTypeByIsTypeOf resolves the object type of a value of an abstract type
+// using the IsTypeOf functions of its possible types. Heterogeneous lists would
+// otherwise call IsTypeOf for every possible type of every element, so the type
+// resolved for a Go type is remembered for the request and its IsTypeOf is tried
+// first for the next value of the same Go type. If the schema declares that
+// IsTypeOf only depends on the Go type then the resolved type is cached in the
+// schema and IsTypeOf isn't called again.
+func resolveTypeByIsTypeOf(eCtx *ExecutionContext, p ResolveTypeParams, abstractType Abstract) *Object {
+	goType := reflect.TypeOf(p.Value)
+	if goType == nil || goType.Kind() == reflect.Map {
+		return defaultResolveTypeFn(p, abstractType)
+	}
+	key := isTypeOfKey{abstractType: abstractType.Name(), goType: goType}
+	if cache := eCtx.Schema.isTypeOfCache; cache != nil {
+		if runtimeType, ok := cache.Load(key); ok {
+			return runtimeType.(*Object)
+		}
+	}
+	eCtx.mu.Lock()
+	hint := eCtx.isTypeOfHints[key]
+	eCtx.mu.Unlock()
+	if hint != nil && hint.IsTypeOf(IsTypeOfParams(p)) {
+		return hint
+	}
+	runtimeType := defaultResolveTypeFn(p, abstractType)
+	if runtimeType == nil {
+		return nil
+	}
+	eCtx.mu.Lock()
+	if eCtx.isTypeOfHints == nil {
+		eCtx.isTypeOfHints = make(map[isTypeOfKey]*Object)
+	}
+	eCtx.isTypeOfHints[key] = runtimeType
+	eCtx.mu.Unlock()
+	if cache := eCtx.Schema.isTypeOfCache; cache != nil {
+		cache.Store(key, runtimeType)
+	}
+	return runtimeType
+}
+
 // defaultResolveFn If a resolve function is not given, then a default resolve behavior is used
 // which takes the property of the source object of the same name as the field
 // and returns it as the result, or if it's a function, returns the result
-// of calling that function.
+// of calling that function. If SchemaConfig.FieldNameCaseFallback is set a
+// property with the snake_case or camelCase form of the name is used when
+// there's no exact match.
 func defaultResolveFn(ctx context.Context, p ResolveParams) (any, error) {
+	caseFallback := p.Info.Schema.fieldNameCaseFallback
 	// try p.Source as a map[string]interface
 	if sourceMap, ok := p.Source.(map[string]any); ok {
-		property := sourceMap[p.Info.FieldName]
-		if fn, ok := property.(func() any); ok {
+		property := lookupMapValue(sourceMap, p.Info.FieldName, caseFallback)
+		if fn, ok := property.(func() any); ok && fn != nil {
 			return fn(), nil
 		}
 		return property, nil
 	}
 
-	// try to resolve p.Source as a struct first
+	// try to resolve p.Source as a struct first, dereferencing any pointers
+	// and treating a typed nil as having no fields.
 	sourceVal := reflect.ValueOf(p.Source)
-	if sourceVal.IsValid() && sourceVal.Type().Kind() == reflect.Ptr {
+	for sourceVal.Kind() == reflect.Ptr || sourceVal.Kind() == reflect.Interface {
+		if sourceVal.IsNil() {
+			return nil, nil
+		}
 		sourceVal = sourceVal.Elem()
 	}
 	if !sourceVal.IsValid() {
@@ -876,11 +1747,16 @@ func defaultResolveFn(ctx context.Context, p ResolveParams) (any, error) {
 	sourceType := sourceVal.Type()
 	if sourceType.Kind() == reflect.Struct {
 		sm := fieldInfoForStruct(sourceType)
-		if field, ok := sm[p.Info.FieldName]; ok {
+		if field, ok := lookupStructField(sm, p.Info.FieldName, caseFallback); ok {
 			valueField := sourceVal.Field(field.index)
 			if field.omitempty && isEmptyValue(valueField) {
 				return nil, nil
 			}
+			// Dereference pointers to basic values so that intentional empty values
+			// (e.g. a *string pointing at "") are serialized rather than the pointer.
+			if valueField.Kind() == reflect.Ptr && !valueField.IsNil() && isBasicKind(valueField.Elem().Kind()) {
+				return valueField.Elem().Interface(), nil
+			}
 			return valueField.Interface(), nil
 		}
 		return nil, nil
@@ -890,6 +1766,17 @@ func defaultResolveFn(ctx context.Context, p ResolveParams) (any, error) {
 	return nil, nil
 }
 
+func isBasicKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
 // This method looks up the field on the given type definition.
 // It has special casing for the two introspection fields, __schema
 // and __typename. __typename is special because it can always be
@@ -915,5 +1802,15 @@ func getFieldDef(schema Schema, parentType *Object, fieldName string, disallowIn
 	if fieldName == TypeNameMetaFieldDef.Name {
 		return TypeNameMetaFieldDef
 	}
+	if schema.QueryType() == parentType {
+		if fieldDef := optInMetaFieldDef(&schema, fieldName); fieldDef != nil {
+			return fieldDef
+		}
+	}
+	if schema.introspectionPagination {
+		if fieldDef := pagedIntrospectionFieldDef(parentType, fieldName); fieldDef != nil {
+			return fieldDef
+		}
+	}
 	return parentType.Fields()[fieldName]
 }
@@ -3,10 +3,15 @@ package graphql_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/gqlerrors"
+	"github.com/sprucehealth/graphql/language/location"
 	"github.com/sprucehealth/graphql/testutil"
 )
 
@@ -265,3 +270,241 @@ func TestExecutesResolveFunction_UsesProvidedResolveFunction_SourceIsStruct_With
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.Data))
 	}
 }
+
+func TestExecutesResolveFunction_ExposesMetadata(t *testing.T) {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"test": &graphql.Field{
+					Type:     graphql.String,
+					Metadata: map[string]any{"cost": 5},
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						return fmt.Sprintf("%v %v", p.Info.Metadata["cost"], p.Info.Schema.Metadata()["owner"]), nil
+					},
+				},
+			},
+		}),
+		Metadata: map[string]any{"owner": "core"},
+	})
+	if err != nil {
+		t.Fatalf("Invalid schema: %v", err)
+	}
+
+	expected := map[string]any{
+		"test": "5 core",
+	}
+
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: `{ test }`,
+	})
+	if !reflect.DeepEqual(expected, result.Data) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.Data))
+	}
+}
+
+func TestResolveInfoComments(t *testing.T) {
+	var operationComments, fieldComments []string
+	schema := testSchema(t, &graphql.Field{
+		Type: graphql.String,
+		Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+			operationComments = p.Info.OperationComments()
+			fieldComments = p.Info.FieldComments()
+			return "ok", nil
+		},
+	})
+	query := `
+		# owner: team-x
+		# trace:on
+		query Example {
+			# field comment
+			test
+		}
+	`
+
+	graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: query,
+	})
+	if operationComments != nil || fieldComments != nil {
+		t.Fatalf("Expected no comments without KeepComments, got %v %v", operationComments, fieldComments)
+	}
+
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: query,
+		KeepComments:  true,
+	})
+	if len(result.Errors) != 0 {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+	if expected := []string{"owner: team-x", "trace:on"}; !reflect.DeepEqual(operationComments, expected) {
+		t.Fatalf("Expected operation comments %v, got %v", expected, operationComments)
+	}
+	if expected := []string{"field comment"}; !reflect.DeepEqual(fieldComments, expected) {
+		t.Fatalf("Expected field comments %v, got %v", expected, fieldComments)
+	}
+}
+
+func TestExecutesResolveFunction_ValidateArgs(t *testing.T) {
+	var resolved bool
+	schema := testSchema(t, &graphql.Field{
+		Type: graphql.String,
+		Args: graphql.FieldConfigArgument{
+			"id":    &graphql.ArgumentConfig{Type: graphql.ID},
+			"email": &graphql.ArgumentConfig{Type: graphql.String},
+		},
+		ValidateArgs: func(ctx context.Context, args map[string]any) error {
+			_, hasID := args["id"]
+			_, hasEmail := args["email"]
+			if hasID == hasEmail {
+				return errors.New("Exactly one of id or email is required.")
+			}
+			return nil
+		},
+		Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+			resolved = true
+			return "ok", nil
+		},
+	})
+
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: `{ test(id: "1", email: "a@example.com") }`,
+	})
+	if resolved {
+		t.Fatal("Expected resolve to not be called")
+	}
+	expected := &graphql.Result{
+		Data: map[string]any{
+			"test": nil,
+		},
+		Errors: []gqlerrors.FormattedError{
+			{
+				Message: "Exactly one of id or email is required.",
+				Type:    gqlerrors.ErrorTypeInvalidInput,
+				Locations: []location.SourceLocation{
+					{Line: 1, Column: 3},
+				},
+				Path: []any{"test"},
+			},
+		},
+	}
+	for i := range result.Errors {
+		result.Errors[i].OriginalError = nil
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	result = graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: `{ test(id: "1") }`,
+	})
+	if len(result.Errors) != 0 {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+	if !resolved {
+		t.Fatal("Expected resolve to be called")
+	}
+}
+
+func TestExecutesResolveFunction_DefaultFunctionFieldNameCaseFallback(t *testing.T) {
+	type patient struct {
+		FirstName string
+		UserID    string `json:"user_id"`
+		Nickname  string `json:"nick_name"`
+	}
+	objectType := func(name string, fields ...string) *graphql.Object {
+		config := graphql.Fields{}
+		for _, f := range fields {
+			config[f] = &graphql.Field{Type: graphql.String}
+		}
+		return graphql.NewObject(graphql.ObjectConfig{Name: name, Fields: config})
+	}
+	query := func(fallback bool) graphql.Schema {
+		schema, err := graphql.NewSchema(graphql.SchemaConfig{
+			Query: graphql.NewObject(graphql.ObjectConfig{
+				Name: "Query",
+				Fields: graphql.Fields{
+					"patient": &graphql.Field{
+						Type: objectType("Patient", "firstName", "userID", "nick_name", "missing"),
+						Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+							return &patient{FirstName: "Ann", UserID: "u1", Nickname: "annie"}, nil
+						},
+					},
+					"record": &graphql.Field{
+						Type: objectType("Record", "createdAt", "updated_at"),
+						Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+							return map[string]any{"created_at": "yesterday", "updatedAt": "today"}, nil
+						},
+					},
+				},
+			}),
+			FieldNameCaseFallback: fallback,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return schema
+	}
+	request := `{
+		patient { firstName userID nick_name missing }
+		record { createdAt updated_at }
+	}`
+
+	result := graphql.Do(context.Background(), graphql.Params{Schema: query(true), RequestString: request})
+	if len(result.Errors) != 0 {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+	expected := map[string]any{
+		"patient": map[string]any{"firstName": "Ann", "userID": "u1", "nick_name": "annie", "missing": nil},
+		"record":  map[string]any{"createdAt": "yesterday", "updated_at": "today"},
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatal(testutil.Diff(expected, result.Data))
+	}
+
+	result = graphql.Do(context.Background(), graphql.Params{Schema: query(false), RequestString: request})
+	expected = map[string]any{
+		"patient": map[string]any{"firstName": nil, "userID": nil, "nick_name": "annie", "missing": nil},
+		"record":  map[string]any{"createdAt": nil, "updated_at": nil},
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatal(testutil.Diff(expected, result.Data))
+	}
+}
+
+func TestExecutesResolveFunction_DetachedTimeout(t *testing.T) {
+	type ctxKey struct{}
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), ctxKey{}, "value"))
+	defer cancel()
+	resolved := make(chan error, 1)
+	schema := testSchema(t, &graphql.Field{
+		Type:            graphql.String,
+		DetachedTimeout: time.Minute,
+		Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+			// Canceling the request doesn't cancel the context of the resolver
+			cancel()
+			switch deadline, ok := ctx.Deadline(); {
+			case ctx.Err() != nil:
+				resolved <- ctx.Err()
+			case !ok || time.Until(deadline) > time.Minute:
+				resolved <- fmt.Errorf("unexpected deadline %s", deadline)
+			case ctx.Value(ctxKey{}) != "value":
+				resolved <- errors.New("expected the values of the request context")
+			default:
+				resolved <- nil
+			}
+			return "ok", nil
+		},
+	})
+	graphql.Do(ctx, graphql.Params{
+		Schema:        schema,
+		RequestString: `{ test }`,
+	})
+	if err := <-resolved; err != nil {
+		t.Fatal(err)
+	}
+}
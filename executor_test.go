@@ -473,6 +473,90 @@ func TestOmitEmpty(t *testing.T) {
 	}
 }
 
+func TestNullSentinel(t *testing.T) {
+	query := `query Example { a {
+		b
+		c
+		d
+		e
+	} }`
+
+	aType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "A",
+		Fields: graphql.Fields{
+			"b": &graphql.Field{Type: graphql.String},
+			"c": &graphql.Field{Type: graphql.String},
+			"d": &graphql.Field{Type: graphql.String},
+			"e": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+					return graphql.Null, nil
+				},
+			},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Type",
+			Fields: graphql.Fields{
+				"a": &graphql.Field{
+					Type: aType,
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						empty := ""
+						return &struct {
+							B any     `json:"b,omitempty"`
+							C *string `json:"c,omitempty"`
+							D any     `json:"d"`
+						}{
+							B: graphql.Null,
+							C: &empty,
+							D: graphql.Null,
+						}, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+
+	ast := testutil.TestParse(t, query)
+	ep := graphql.ExecuteParams{
+		Schema: schema,
+		AST:    ast,
+	}
+	result := testutil.TestExecute(t, context.Background(), ep)
+	if len(result.Errors) != 1 {
+		t.Fatalf("Expected 1 error, got %v", result.Errors)
+	}
+	if msg := result.Errors[0].Message; msg != "Cannot return null for non-nullable field A.e." {
+		t.Fatalf("Unexpected error: %s", msg)
+	}
+	expected := map[string]any{
+		"a": nil,
+	}
+	if !reflect.DeepEqual(expected, result.Data) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.Data))
+	}
+
+	ep.AST = testutil.TestParse(t, `query Example { a { b c d } }`)
+	result = testutil.TestExecute(t, context.Background(), ep)
+	if len(result.Errors) > 0 {
+		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+	}
+	expected = map[string]any{
+		"a": map[string]any{
+			"b": nil,
+			"c": "",
+			"d": nil,
+		},
+	}
+	if !reflect.DeepEqual(expected, result.Data) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.Data))
+	}
+}
+
 func TestCorrectlyThreadsArguments(t *testing.T) {
 	query := `
       query Example {
@@ -842,8 +926,9 @@ func TestThrowsIfNoOperationIsProvided(t *testing.T) {
 	expectedErrors := []gqlerrors.FormattedError{
 		{
 			Message:   "Must provide an operation.",
+			Code:      gqlerrors.CodeMissingOperation,
 			Locations: []location.SourceLocation{},
-			Type:      "INTERNAL",
+			Type:      "BAD_QUERY",
 		},
 	}
 
@@ -892,8 +977,9 @@ func TestThrowsIfNoOperationNameIsProvidedWithMultipleOperations(t *testing.T) {
 
 	expectedErrors := []gqlerrors.FormattedError{
 		{
-			Type:      "INTERNAL",
+			Type:      "BAD_QUERY",
 			Message:   "Must provide operation name if query contains multiple operations.",
+			Code:      gqlerrors.CodeOperationNameRequired,
 			Locations: []location.SourceLocation{},
 		},
 	}
@@ -944,8 +1030,9 @@ func TestThrowsIfUnknownOperationNameIsProvided(t *testing.T) {
 	expectedErrors := []gqlerrors.FormattedError{
 		{
 			Message:   `Unknown operation named "UnknownExample".`,
+			Code:      gqlerrors.CodeUnknownOperation,
 			Locations: []location.SourceLocation{},
-			Type:      "INTERNAL",
+			Type:      "BAD_QUERY",
 		},
 	}
 
@@ -983,6 +1070,47 @@ func TestThrowsIfUnknownOperationNameIsProvided(t *testing.T) {
 		t.Fatalf("unexpected result, Diff: %v", testutil.Diff(expectedErrors, result.Errors))
 	}
 }
+
+func TestThrowsIfSchemaIsNotConfiguredForMutations(t *testing.T) {
+	doc := `mutation M { a }`
+
+	expectedErrors := []gqlerrors.FormattedError{
+		{
+			Message:   "Schema is not configured for mutations.",
+			Code:      gqlerrors.CodeUnsupportedOperation,
+			Locations: []location.SourceLocation{{Line: 1, Column: 1}},
+			Type:      "BAD_QUERY",
+		},
+	}
+
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Type",
+			Fields: graphql.Fields{
+				"a": &graphql.Field{
+					Type: graphql.String,
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+
+	result := graphql.Execute(context.Background(), graphql.ExecuteParams{
+		Schema: schema,
+		AST:    testutil.TestParse(t, doc),
+	})
+	if result.Data != nil {
+		t.Fatalf("wrong result, expected nil result.Data, got %v", result.Data)
+	}
+	result.Errors[0].OriginalError = nil
+	result.Errors[0].StackTrace = ""
+	if !reflect.DeepEqual(expectedErrors, result.Errors) {
+		t.Fatalf("unexpected result, Diff: %v", testutil.Diff(expectedErrors, result.Errors))
+	}
+}
+
 func TestUsesTheQuerySchemaForQueries(t *testing.T) {
 	doc := `query Q { a } mutation M { c } subscription S { a }`
 	data := map[string]any{
@@ -1437,7 +1565,9 @@ func TestFailsWhenAnIsTypeOfCheckIsNotMet(t *testing.T) {
 			{
 				Type:      "INTERNAL",
 				Message:   `Expected value of type "SpecialType" but got: graphql_test.testNotSpecialType.`,
-				Locations: []location.SourceLocation{},
+				Code:      gqlerrors.CodeUnexpectedValue,
+				Locations: []location.SourceLocation{{Line: 1, Column: 3}},
+				Path:      []any{"specials", 1},
 			},
 		},
 	}
@@ -1506,9 +1636,10 @@ func TestFailsToExecuteQueryContainingATypeDefinition(t *testing.T) {
 		Data: nil,
 		Errors: []gqlerrors.FormattedError{
 			{
-				Type:      "INTERNAL",
-				Message:   "GraphQL cannot execute a request containing a ObjectDefinition",
-				Locations: []location.SourceLocation{},
+				Type:      "BAD_QUERY",
+				Message:   "GraphQL cannot execute a request containing a ObjectDefinition.",
+				Code:      gqlerrors.CodeUnexecutableDefinition,
+				Locations: []location.SourceLocation{{Line: 4, Column: 7}},
 			},
 		},
 	}
@@ -1969,6 +2100,53 @@ func TestContextDeadlineWait(t *testing.T) {
 	}
 }
 
+func TestOperationTimeout(t *testing.T) {
+	timeout := 50 * time.Millisecond
+	acceptableDelay := 10 * time.Millisecond
+
+	var resolverCtxErr error
+	done := make(chan struct{})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"hello": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						defer close(done)
+						<-ctx.Done()
+						resolverCtxErr = context.Cause(ctx)
+						return nil, ctx.Err()
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+
+	// The timeout applies even though the context has no deadline
+	startTime := time.Now()
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: "{hello}",
+		Timeout:       timeout,
+	})
+	duration := time.Since(startTime)
+	<-done
+
+	if duration > timeout+acceptableDelay {
+		t.Fatalf("graphql.Do completed in %s, should have completed in %s", duration, timeout)
+	}
+	if len(result.Errors) != 1 || !errors.Is(result.Errors[0].OriginalError, graphql.ErrOperationTimeout) {
+		t.Fatalf("Expected operation timeout error, got %+v", result.Errors)
+	}
+	if !errors.Is(resolverCtxErr, graphql.ErrOperationTimeout) {
+		t.Fatalf("Expected resolver context cause to be the operation timeout, got %v", resolverCtxErr)
+	}
+}
+
 func TestContextCancel(t *testing.T) {
 	expectedErrors := []gqlerrors.FormattedError{
 		{
@@ -2122,3 +2300,231 @@ func TestDeprecatedField(t *testing.T) {
 		t.Fatalf("Expected \"deprecated field\" error got %+#v", result.Errors[0])
 	}
 }
+
+func TestMaxErrors(t *testing.T) {
+	item := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Item",
+		Fields: graphql.Fields{
+			"value": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+					return nil, errors.New("failed")
+				},
+			},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"items": &graphql.Field{
+					Type: graphql.NewList(item),
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						items := make([]any, 10)
+						for i := range items {
+							items[i] = map[string]any{}
+						}
+						return items, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		maxErrors int
+		errors    int
+		last      string
+	}{
+		{maxErrors: 3, errors: 4, last: "And 7 more errors."},
+		{maxErrors: 10, errors: 10, last: "failed"},
+		{maxErrors: -1, errors: 10, last: "failed"},
+	}
+	for _, c := range cases {
+		result := graphql.Do(context.Background(), graphql.Params{
+			Schema:        schema,
+			RequestString: `{ items { value } }`,
+			MaxErrors:     c.maxErrors,
+		})
+		if len(result.Errors) != c.errors {
+			t.Fatalf("MaxErrors %d: expected %d errors, got %d", c.maxErrors, c.errors, len(result.Errors))
+		}
+		if last := result.Errors[len(result.Errors)-1].Message; last != c.last {
+			t.Fatalf("MaxErrors %d: expected last error %q, got %q", c.maxErrors, c.last, last)
+		}
+		if items := result.Data.(map[string]any)["items"].([]any); len(items) != 10 {
+			t.Fatalf("MaxErrors %d: expected 10 items, got %d", c.maxErrors, len(items))
+		}
+	}
+}
+
+func TestObjectOnResolved(t *testing.T) {
+	type patient struct {
+		Name string `json:"name"`
+		SSN  string `json:"ssn"`
+	}
+	patientType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Patient",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.String},
+			"ssn":  &graphql.Field{Type: graphql.String},
+		},
+		OnResolved: func(ctx context.Context, value any) (any, error) {
+			p := *value.(*patient)
+			if p.Name == "" {
+				return nil, errors.New("patient has no name")
+			}
+			p.SSN = "***-**-" + p.SSN[len(p.SSN)-4:]
+			return &p, nil
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"patient": &graphql.Field{
+					Type: patientType,
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						return &patient{Name: "Alice", SSN: "123-45-6789"}, nil
+					},
+				},
+				"patients": &graphql.Field{
+					Type: graphql.NewList(patientType),
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						return []*patient{{Name: "Bob", SSN: "987-65-4321"}, {SSN: "000-00-0000"}}, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: `{ patient { name ssn } patients { ssn } }`,
+	})
+	expected := map[string]any{
+		"patient": map[string]any{
+			"name": "Alice",
+			"ssn":  "***-**-6789",
+		},
+		"patients": []any{
+			map[string]any{"ssn": "***-**-4321"},
+			nil,
+		},
+	}
+	if !reflect.DeepEqual(expected, result.Data) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.Data))
+	}
+	if len(result.Errors) != 1 || result.Errors[0].Message != "patient has no name" {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+}
+
+func TestDeterministicExecution(t *testing.T) {
+	var calls []string
+	fields := graphql.Fields{}
+	for _, name := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		fields[name] = &graphql.Field{
+			Type: graphql.String,
+			Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+				calls = append(calls, p.Info.FieldName)
+				return nil, errors.New(p.Info.FieldName)
+			},
+		}
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name:   "Query",
+			Fields: fields,
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"h", "c", "a", "g", "b", "f", "e", "d"}
+	for i := 0; i < 10; i++ {
+		calls = nil
+		result := graphql.Do(context.Background(), graphql.Params{
+			Schema:        schema,
+			RequestString: `{ h c a g b f e d }`,
+			Deterministic: true,
+		})
+		if !reflect.DeepEqual(expected, calls) {
+			t.Fatalf("Expected resolvers to be called in order %v, got %v", expected, calls)
+		}
+		messages := make([]string, len(result.Errors))
+		for i, e := range result.Errors {
+			messages[i] = e.Message
+		}
+		if !reflect.DeepEqual(expected, messages) {
+			t.Fatalf("Expected errors in order %v, got %v", expected, messages)
+		}
+		if _, ok := result.Data.(map[string]any); !ok {
+			t.Fatalf("Expected map data, got %T", result.Data)
+		}
+	}
+}
+
+func TestArgumentsAreCopied(t *testing.T) {
+	inputType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "Input",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"names": &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.String)},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"first": &graphql.Field{
+					Type: graphql.NewList(graphql.String),
+					Args: graphql.FieldConfigArgument{"input": &graphql.ArgumentConfig{Type: inputType}},
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						names := p.Args["input"].(map[string]any)["names"].([]any)
+						names[0] = "changed"
+						return names, nil
+					},
+				},
+				"second": &graphql.Field{
+					Type: graphql.NewList(graphql.String),
+					Args: graphql.FieldConfigArgument{"input": &graphql.ArgumentConfig{Type: inputType}},
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						return p.Args["input"].(map[string]any)["names"], nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, shareArgs := range []bool{false, true} {
+		result := graphql.Do(context.Background(), graphql.Params{
+			Schema:         schema,
+			RequestString:  `query ($input: Input) { first(input: $input) second(input: $input) }`,
+			VariableValues: map[string]any{"input": map[string]any{"names": []any{"a", "b"}}},
+			Deterministic:  true,
+			ShareArgs:      shareArgs,
+		})
+		second := "a"
+		if shareArgs {
+			// The resolvers see the same value when it's shared
+			second = "changed"
+		}
+		expected := &graphql.Result{Data: map[string]any{
+			"first":  []any{"changed", "b"},
+			"second": []any{second, "b"},
+		}}
+		if !reflect.DeepEqual(expected, result) {
+			t.Fatalf("ShareArgs %t: unexpected result, Diff: %v", shareArgs, testutil.Diff(expected, result))
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package graphql
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// ExplainExtensionKey is the key in Result.Extensions under which the
+// explain log is returned when ExecuteParams.Explain is set.
+const ExplainExtensionKey = "explain"
+
+// ExplainEventType is the type of an event in the explain log.
+type ExplainEventType string
+
+const (
+	// ExplainVariables records the coerced variable values for the operation.
+	ExplainVariables ExplainEventType = "variables"
+	// ExplainFieldStart is recorded before a field is resolved.
+	ExplainFieldStart ExplainEventType = "fieldStart"
+	// ExplainArguments records the coerced argument values for a field.
+	ExplainArguments ExplainEventType = "arguments"
+	// ExplainResolveType records the object type an abstract value resolved to.
+	ExplainResolveType ExplainEventType = "resolveType"
+	// ExplainFieldEnd is recorded after a field is resolved and completed.
+	ExplainFieldEnd ExplainEventType = "fieldEnd"
+	// ExplainCacheHit may be recorded by resolvers using AddExplainEvent when a
+	// value is served from a cache.
+	ExplainCacheHit ExplainEventType = "cacheHit"
+)
+
+// ExplainEvent is a single entry in the explain log.
+type ExplainEvent struct {
+	Type ExplainEventType `json:"type"`
+	Path []string         `json:"path,omitempty"`
+	// TypeName is the parent type for field events and the abstract type for resolveType.
+	TypeName  string `json:"typeName,omitempty"`
+	FieldName string `json:"fieldName,omitempty"`
+	// RuntimeType is the resolved object type for resolveType.
+	RuntimeType string         `json:"runtimeType,omitempty"`
+	Values      map[string]any `json:"values,omitempty"`
+	Message     string         `json:"message,omitempty"`
+	Error       string         `json:"error,omitempty"`
+}
+
+type explainLogKey struct{}
+
+// explainLog records execution events in order. A nil log discards all events.
+type explainLog struct {
+	mu     sync.Mutex
+	events []ExplainEvent
+}
+
+func (l *explainLog) add(e ExplainEvent) {
+	if l == nil {
+		return
+	}
+	// Paths are shared between siblings during execution so they must be copied.
+	e.Path = slices.Clone(e.Path)
+	l.mu.Lock()
+	l.events = append(l.events, e)
+	l.mu.Unlock()
+}
+
+func (l *explainLog) Events() []ExplainEvent {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return slices.Clone(l.events)
+}
+
+// AddExplainEvent appends an application event (e.g. a cache hit) to the
+// explain log of the request. It's a no-op if the request is not being explained.
+func AddExplainEvent(ctx context.Context, e ExplainEvent) {
+	l, _ := ctx.Value(explainLogKey{}).(*explainLog)
+	l.add(e)
+}
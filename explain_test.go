@@ -0,0 +1,109 @@
+package graphql_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+func TestExplain(t *testing.T) {
+	dogType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Dog",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+					graphql.AddExplainEvent(ctx, graphql.ExplainEvent{
+						Type:    graphql.ExplainCacheHit,
+						Path:    []string{"pet", "name"},
+						Message: "dog:1",
+					})
+					return "Odie", nil
+				},
+			},
+		},
+	})
+	petType := graphql.NewUnion(graphql.UnionConfig{
+		Name:  "Pet",
+		Types: []*graphql.Object{dogType},
+		ResolveType: func(ctx context.Context, p graphql.ResolveTypeParams) *graphql.Object {
+			return dogType
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"pet": &graphql.Field{
+					Type: petType,
+					Args: graphql.FieldConfigArgument{
+						"id": &graphql.ArgumentConfig{Type: graphql.ID},
+					},
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						return struct{}{}, nil
+					},
+				},
+				"fail": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						return nil, errors.New("boom")
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, query := range []string{
+		`query ($id: ID) { pet(id: $id) { ... on Dog { name } } }`,
+		`{ fail }`,
+	} {
+		result := graphql.Do(context.Background(), graphql.Params{
+			Schema:        schema,
+			RequestString: query,
+		})
+		if result.Extensions != nil {
+			t.Fatalf("Expected no extensions without explain, got %v", result.Extensions)
+		}
+	}
+
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema:         schema,
+		RequestString:  `query ($id: ID) { pet(id: $id) { ... on Dog { name } } }`,
+		VariableValues: map[string]any{"id": 1},
+		Explain:        true,
+	})
+	expected := []graphql.ExplainEvent{
+		{Type: graphql.ExplainVariables, Values: map[string]any{"id": "1"}},
+		{Type: graphql.ExplainFieldStart, Path: []string{"pet"}, TypeName: "Query", FieldName: "pet"},
+		{Type: graphql.ExplainArguments, Path: []string{"pet"}, Values: map[string]any{"id": "1"}},
+		{Type: graphql.ExplainResolveType, Path: []string{"pet"}, TypeName: "Pet", RuntimeType: "Dog"},
+		{Type: graphql.ExplainFieldStart, Path: []string{"pet", "name"}, TypeName: "Dog", FieldName: "name"},
+		{Type: graphql.ExplainCacheHit, Path: []string{"pet", "name"}, Message: "dog:1"},
+		{Type: graphql.ExplainFieldEnd, Path: []string{"pet", "name"}},
+		{Type: graphql.ExplainFieldEnd, Path: []string{"pet"}},
+	}
+	if !reflect.DeepEqual(result.Extensions[graphql.ExplainExtensionKey], expected) {
+		t.Fatalf("Unexpected explain log, Diff: %v", testutil.Diff(expected, result.Extensions[graphql.ExplainExtensionKey]))
+	}
+
+	result = graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: `{ fail }`,
+		Explain:       true,
+	})
+	expected = []graphql.ExplainEvent{
+		{Type: graphql.ExplainVariables, Values: map[string]any{}},
+		{Type: graphql.ExplainFieldStart, Path: []string{"fail"}, TypeName: "Query", FieldName: "fail"},
+		{Type: graphql.ExplainFieldEnd, Path: []string{"fail"}, Error: "boom"},
+	}
+	if !reflect.DeepEqual(result.Extensions[graphql.ExplainExtensionKey], expected) {
+		t.Fatalf("Unexpected explain log, Diff: %v", testutil.Diff(expected, result.Extensions[graphql.ExplainExtensionKey]))
+	}
+}
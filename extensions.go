@@ -0,0 +1,110 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"sync"
+)
+
+// ExtensionMergeFn merges the values added by resolvers for an extension key
+// into the value returned in the result extensions (e.g. the minimum of the
+// remaining rate limits). Values are sorted by their JSON encoding so that the
+// order doesn't depend on the order in which resolvers ran.
+type ExtensionMergeFn func(values []any) any
+
+type resolverExtensionsKey struct{}
+
+// resolverExtensions collects the extensions added by resolvers of a request.
+type resolverExtensions struct {
+	mu     sync.Mutex
+	values map[string][]any
+}
+
+func (e *resolverExtensions) add(key string, value any) {
+	e.mu.Lock()
+	if e.values == nil {
+		e.values = make(map[string][]any)
+	}
+	e.values[key] = append(e.values[key], value)
+	e.mu.Unlock()
+}
+
+// AddExtension adds a value to the extensions of the response of the request (e.g.
+// the remaining rate limit of a downstream service or a debug hint). The values
+// added for a key are merged at the end of the request by the ExtensionMergeFn
+// of the key in ExecuteParams.ExtensionMergers. Without a merge function equal
+// values are returned once and different values are returned as a list sorted
+// by their JSON encoding. Extensions set by the executor (e.g. ExplainExtensionKey)
+// take precedence over values added for the same key. It's a no-op outside of
+// the execution of a request.
+func AddExtension(ctx context.Context, key string, value any) {
+	if e, ok := ctx.Value(resolverExtensionsKey{}).(*resolverExtensions); ok {
+		e.add(key, value)
+	}
+}
+
+// merge adds the merged values to the extensions without replacing existing keys.
+func (e *resolverExtensions) merge(extensions map[string]any, mergers map[string]ExtensionMergeFn) map[string]any {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for key, values := range e.values {
+		if _, ok := extensions[key]; ok {
+			continue
+		}
+		values = sortedExtensionValues(values)
+		var v any
+		if merge := mergers[key]; merge != nil {
+			v = merge(values)
+		} else {
+			v = mergeExtensionValues(values)
+		}
+		if extensions == nil {
+			extensions = make(map[string]any)
+		}
+		extensions[key] = v
+	}
+	return extensions
+}
+
+// sortedExtensionValues returns a copy of the values sorted by their JSON
+// encoding. Values that can't be encoded sort first in the order they were added.
+func sortedExtensionValues(values []any) []any {
+	if len(values) == 1 {
+		return values
+	}
+	type encodedValue struct {
+		value any
+		b     []byte
+	}
+	encoded := make([]encodedValue, len(values))
+	for i, v := range values {
+		b, _ := json.Marshal(v)
+		encoded[i] = encodedValue{value: v, b: b}
+	}
+	slices.SortStableFunc(encoded, func(a, b encodedValue) int {
+		return bytes.Compare(a.b, b.b)
+	})
+	sorted := make([]any, len(values))
+	for i, ev := range encoded {
+		sorted[i] = ev.value
+	}
+	return sorted
+}
+
+// mergeExtensionValues returns the value if all values are equal or the distinct
+// values as a list otherwise.
+func mergeExtensionValues(values []any) any {
+	distinct := values[:1:1]
+	for _, v := range values[1:] {
+		if !reflect.DeepEqual(v, distinct[len(distinct)-1]) {
+			distinct = append(distinct, v)
+		}
+	}
+	if len(distinct) == 1 {
+		return distinct[0]
+	}
+	return distinct
+}
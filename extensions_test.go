@@ -0,0 +1,71 @@
+package graphql_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+func TestAddExtension(t *testing.T) {
+	field := func(remaining int, cache string) *graphql.Field {
+		return &graphql.Field{
+			Type: graphql.String,
+			Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+				graphql.AddExtension(ctx, "rateLimitRemaining", remaining)
+				graphql.AddExtension(ctx, "cacheStatus", cache)
+				graphql.AddExtension(ctx, "region", "us-east")
+				graphql.AddExtension(ctx, graphql.ExplainExtensionKey, "ignored")
+				return "ok", nil
+			},
+		}
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"a": field(10, "miss"),
+				"b": field(3, "hit"),
+				"c": field(7, "miss"),
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: `{ a b c }`,
+		Explain:       true,
+		ExtensionMergers: map[string]graphql.ExtensionMergeFn{
+			"rateLimitRemaining": func(values []any) any {
+				remaining := values[0].(int)
+				for _, v := range values[1:] {
+					remaining = min(remaining, v.(int))
+				}
+				return remaining
+			},
+		},
+	})
+	if len(result.Errors) != 0 {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+	if _, ok := result.Extensions[graphql.ExplainExtensionKey].([]graphql.ExplainEvent); !ok {
+		t.Fatalf("Expected the explain log to take precedence, got %v", result.Extensions[graphql.ExplainExtensionKey])
+	}
+	delete(result.Extensions, graphql.ExplainExtensionKey)
+	expected := map[string]any{
+		"rateLimitRemaining": 3,
+		"cacheStatus":        []any{"hit", "miss"},
+		"region":             "us-east",
+	}
+	if !reflect.DeepEqual(result.Extensions, expected) {
+		t.Fatal(testutil.Diff(expected, result.Extensions))
+	}
+
+	// Outside of a request it's a no-op.
+	graphql.AddExtension(context.Background(), "key", "value")
+}
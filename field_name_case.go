@@ -0,0 +1,105 @@
+package graphql
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// alternateFieldNames returns the names under which the default resolver looks for
+// a field when SchemaConfig.FieldNameCaseFallback is set and there's no exact
+// match: the snake_case form of a camelCase name or the camelCase form of a
+// snake_case name, followed by the exported Go form of the camelCase name
+// (e.g. "FirstName" for "first_name" or "firstName").
+func alternateFieldNames(name string) []string {
+	if strings.Contains(strings.Trim(name, "_"), "_") {
+		camel := snakeToCamelCase(name)
+		return []string{camel, exportedName(camel)}
+	}
+	var names []string
+	if snake := camelToSnakeCase(name); snake != name {
+		names = append(names, snake)
+	}
+	if exported := exportedName(name); exported != name {
+		names = append(names, exported)
+	}
+	return names
+}
+
+// lookupStructField returns the field of the struct with the name falling back to
+// the alternate names if caseFallback is true.
+func lookupStructField(sm map[string]structFieldInfo, name string, caseFallback bool) (structFieldInfo, bool) {
+	if field, ok := sm[name]; ok || !caseFallback {
+		return field, ok
+	}
+	for _, alt := range alternateFieldNames(name) {
+		if field, ok := sm[alt]; ok {
+			return field, true
+		}
+	}
+	return structFieldInfo{}, false
+}
+
+// lookupMapValue returns the value of the map with the key falling back to the
+// snake_case or camelCase form of the key if caseFallback is true.
+func lookupMapValue(m map[string]any, key string, caseFallback bool) any {
+	if v, ok := m[key]; ok || !caseFallback {
+		return v
+	}
+	for _, alt := range alternateFieldNames(key) {
+		if v, ok := m[alt]; ok {
+			return v
+		}
+	}
+	return nil
+}
+
+// snakeToCamelCase converts a snake_case name to camelCase (e.g. "first_name" to
+// "firstName"). Leading underscores are kept.
+func snakeToCamelCase(name string) string {
+	trimmed := strings.TrimLeft(name, "_")
+	var b strings.Builder
+	b.Grow(len(name))
+	b.WriteString(name[:len(name)-len(trimmed)])
+	upper := false
+	for _, r := range trimmed {
+		switch {
+		case r == '_':
+			upper = true
+		case upper:
+			b.WriteRune(unicode.ToUpper(r))
+			upper = false
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// camelToSnakeCase converts a camelCase name to snake_case treating runs of
+// capitals as a word (e.g. "userID" to "user_id" and "httpURLPath" to
+// "http_url_path").
+func camelToSnakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	b.Grow(len(name) + 4)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && runes[i-1] != '_' && (!unicode.IsUpper(runes[i-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// exportedName returns the name with its first letter in upper case.
+func exportedName(name string) string {
+	r, size := utf8.DecodeRuneInString(name)
+	if r == utf8.RuneError || unicode.IsUpper(r) {
+		return name
+	}
+	return string(unicode.ToUpper(r)) + name[size:]
+}
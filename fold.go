@@ -0,0 +1,124 @@
+package graphql
+
+import (
+	"fmt"
+
+	"github.com/sprucehealth/graphql/gqlerrors"
+	"github.com/sprucehealth/graphql/language/ast"
+)
+
+// FoldedSelectionsExtensionKey is the key in Result.Extensions under which Do
+// returns the warnings of FoldConstantConditionals.
+const FoldedSelectionsExtensionKey = "foldedSelections"
+
+// FoldConstantConditionals rewrites a document in place removing all selections
+// that are statically excluded by a @skip or @include directive with a literal
+// (non-variable) `if` argument. Conditional directives with a literal argument
+// that always includes the selection are dropped since they have no effect.
+// Directives using variables are left untouched.
+//
+// The document should already have been validated. A warning is returned for
+// each selection that was removed as it can never be included.
+func FoldConstantConditionals(doc *ast.Document) []gqlerrors.FormattedError {
+	var warnings []gqlerrors.FormattedError
+	for _, node := range doc.Definitions {
+		switch def := node.(type) {
+		case *ast.OperationDefinition:
+			warnings = foldSelectionSet(def.SelectionSet, warnings)
+		case *ast.FragmentDefinition:
+			warnings = foldSelectionSet(def.SelectionSet, warnings)
+		}
+	}
+	return warnings
+}
+
+func foldSelectionSet(ss *ast.SelectionSet, warnings []gqlerrors.FormattedError) []gqlerrors.FormattedError {
+	if ss == nil {
+		return warnings
+	}
+	selections := ss.Selections[:0]
+	for _, selection := range ss.Selections {
+		var name string
+		var directives *[]*ast.Directive
+		switch sel := selection.(type) {
+		case *ast.Field:
+			name = getFieldEntryKey(sel)
+			directives = &sel.Directives
+		case *ast.FragmentSpread:
+			name = "..."
+			if sel.Name != nil {
+				name += sel.Name.Value
+			}
+			directives = &sel.Directives
+		case *ast.InlineFragment:
+			name = "..."
+			if sel.TypeCondition != nil && sel.TypeCondition.Name != nil {
+				name += " on " + sel.TypeCondition.Name.Value
+			}
+			directives = &sel.Directives
+		default:
+			selections = append(selections, selection)
+			continue
+		}
+		include, dir := foldConditionalDirectives(directives)
+		if !include {
+			warnings = append(warnings, gqlerrors.FormatError(gqlerrors.NewError(
+				gqlerrors.ErrorTypeBadQuery,
+				fmt.Sprintf(`Selection "%s" can never be included because of @%s.`, name, dir.Name.Value),
+				[]ast.Node{selection},
+				"",
+				nil,
+				[]int{},
+				nil,
+			)))
+			continue
+		}
+		warnings = foldSelectionSet(selection.GetSelectionSet(), warnings)
+		selections = append(selections, selection)
+	}
+	// Clear the tail to avoid retaining removed selections.
+	for i := len(selections); i < len(ss.Selections); i++ {
+		ss.Selections[i] = nil
+	}
+	ss.Selections = selections
+	return warnings
+}
+
+// foldConditionalDirectives removes any @skip and @include directives that have a
+// literal `if` argument. It returns false and the excluding directive if the
+// selection can never be included.
+func foldConditionalDirectives(directives *[]*ast.Directive) (bool, *ast.Directive) {
+	kept := (*directives)[:0]
+	for _, directive := range *directives {
+		if directive == nil || directive.Name == nil {
+			kept = append(kept, directive)
+			continue
+		}
+		var skipValue bool
+		switch directive.Name.Value {
+		case SkipDirective.Name:
+			skipValue = true
+		case IncludeDirective.Name:
+			skipValue = false
+		default:
+			kept = append(kept, directive)
+			continue
+		}
+		var value *ast.BooleanValue
+		for _, arg := range directive.Arguments {
+			if arg.Name != nil && arg.Name.Value == "if" {
+				value, _ = arg.Value.(*ast.BooleanValue)
+			}
+		}
+		if value == nil {
+			kept = append(kept, directive)
+			continue
+		}
+		if value.Value == skipValue {
+			// The selection is dropped so there's no need to finish rewriting its directives.
+			return false, directive
+		}
+	}
+	*directives = kept
+	return true, nil
+}
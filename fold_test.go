@@ -0,0 +1,118 @@
+package graphql_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/gqlerrors"
+	"github.com/sprucehealth/graphql/language/location"
+	"github.com/sprucehealth/graphql/language/printer"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+func TestFoldConstantConditionals(t *testing.T) {
+	doc := testutil.TestParse(t, `query Q($v: Boolean!) {
+  a @skip(if: true)
+  b @include(if: true)
+  c @include(if: $v)
+  d @include(if: false) {
+    e
+  }
+  ... on Query @skip(if: false) {
+    f @skip(if: true)
+  }
+  ...Frag @include(if: false)
+}
+
+fragment Frag on Query {
+  g @skip(if: true)
+  h
+}
+`)
+	warnings := graphql.FoldConstantConditionals(doc)
+	expectedWarnings := []gqlerrors.FormattedError{
+		{
+			Message:   `Selection "a" can never be included because of @skip.`,
+			Type:      gqlerrors.ErrorTypeBadQuery,
+			Locations: []location.SourceLocation{{Line: 2, Column: 3}},
+		},
+		{
+			Message:   `Selection "d" can never be included because of @include.`,
+			Type:      gqlerrors.ErrorTypeBadQuery,
+			Locations: []location.SourceLocation{{Line: 5, Column: 3}},
+		},
+		{
+			Message:   `Selection "f" can never be included because of @skip.`,
+			Type:      gqlerrors.ErrorTypeBadQuery,
+			Locations: []location.SourceLocation{{Line: 9, Column: 5}},
+		},
+		{
+			Message:   `Selection "...Frag" can never be included because of @include.`,
+			Type:      gqlerrors.ErrorTypeBadQuery,
+			Locations: []location.SourceLocation{{Line: 11, Column: 3}},
+		},
+		{
+			Message:   `Selection "g" can never be included because of @skip.`,
+			Type:      gqlerrors.ErrorTypeBadQuery,
+			Locations: []location.SourceLocation{{Line: 15, Column: 3}},
+		},
+	}
+	if !reflect.DeepEqual(expectedWarnings, warnings) {
+		t.Fatalf("Unexpected warnings, Diff: %v", testutil.Diff(expectedWarnings, warnings))
+	}
+
+	expected := `query Q($v: Boolean!) {
+  b
+  c @include(if: $v)
+  ... on Query {}
+}
+
+fragment Frag on Query {
+  h
+}
+`
+	if printed := printer.Print(doc); printed != expected {
+		t.Fatalf("Unexpected folded document:\n%s\nexpected:\n%s", printed, expected)
+	}
+}
+
+func TestDo_FoldConstantConditionals(t *testing.T) {
+	var resolved []string
+	resolve := func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+		resolved = append(resolved, p.Info.FieldName)
+		return p.Info.FieldName, nil
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"a": &graphql.Field{Type: graphql.String, Resolve: resolve},
+				"b": &graphql.Field{Type: graphql.String, Resolve: resolve},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema:                   schema,
+		RequestString:            `{ a @skip(if: true) b @include(if: true) }`,
+		FoldConstantConditionals: true,
+	})
+	if len(result.Errors) != 0 {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+	expected := map[string]any{"b": "b"}
+	if !reflect.DeepEqual(expected, result.Data) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.Data))
+	}
+	if !reflect.DeepEqual([]string{"b"}, resolved) {
+		t.Fatalf("Expected only b to be resolved, got %v", resolved)
+	}
+	warnings, _ := result.Extensions[graphql.FoldedSelectionsExtensionKey].([]gqlerrors.FormattedError)
+	if len(warnings) != 1 || warnings[0].Message != `Selection "a" can never be included because of @skip.` {
+		t.Fatalf("Expected a warning for the skipped selection, got %+v", result.Extensions)
+	}
+}
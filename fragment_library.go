@@ -0,0 +1,203 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/sprucehealth/graphql/language/ast"
+	"github.com/sprucehealth/graphql/language/parser"
+	"github.com/sprucehealth/graphql/language/printer"
+	"github.com/sprucehealth/graphql/language/source"
+)
+
+// FragmentLibrary holds persisted fragments that are registered once (e.g. at
+// startup from the fragments shared by a client's operations) so that requests
+// can spread them without defining them. Set Params.FragmentLibrary to add the
+// library fragments spread by a request to its document before it's validated
+// and executed. A fragment defined by the request takes precedence over the
+// library fragment with the same name. The zero value is ready to use.
+//
+// Fragments can't be redefined once registered which keeps the documents of a
+// DocumentCache valid as the library grows. A cache must only be used with a
+// single library.
+type FragmentLibrary struct {
+	mu        sync.RWMutex
+	fragments map[string]*libraryFragment
+}
+
+type libraryFragment struct {
+	def *ast.FragmentDefinition
+	// printed is the definition in the query language which is compared to
+	// detect conflicting registrations and parsed to copy the definition.
+	printed string
+}
+
+// FragmentConflictError is returned when a fragment is registered with a
+// different definition than the fragment of the library with the same name.
+type FragmentConflictError struct {
+	Fragment string
+}
+
+func (e *FragmentConflictError) Error() string {
+	return fmt.Sprintf("fragment %q is already registered with a different definition", e.Fragment)
+}
+
+// Register parses a document of fragment definitions and adds them to the library.
+func (l *FragmentLibrary) Register(document string) error {
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: source.New("GraphQL fragments", document),
+	})
+	if err != nil {
+		return err
+	}
+	return l.RegisterDocument(doc)
+}
+
+// RegisterDocument adds the fragments of a document to the library. The document
+// must only define fragments. Registering a fragment again with the same
+// definition is a no-op while a different definition fails with a
+// FragmentConflictError. Either all or none of the fragments are added. The
+// fragments are shared by the documents they're added to so the document must
+// not be modified afterwards.
+func (l *FragmentLibrary) RegisterDocument(doc *ast.Document) error {
+	fragments := make(map[string]*libraryFragment, len(doc.Definitions))
+	for _, def := range doc.Definitions {
+		fragment, ok := def.(*ast.FragmentDefinition)
+		if !ok {
+			return errors.New("a fragment library document must only define fragments")
+		}
+		printed := printer.Print(fragment)
+		if f, ok := fragments[fragment.Name.Value]; ok && f.printed != printed {
+			return &FragmentConflictError{Fragment: fragment.Name.Value}
+		}
+		fragments[fragment.Name.Value] = &libraryFragment{def: fragment, printed: printed}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for name, f := range fragments {
+		if registered, ok := l.fragments[name]; ok && registered.printed != f.printed {
+			return &FragmentConflictError{Fragment: name}
+		}
+	}
+	if l.fragments == nil {
+		l.fragments = make(map[string]*libraryFragment, len(fragments))
+	}
+	for name, f := range fragments {
+		if _, ok := l.fragments[name]; !ok {
+			l.fragments[name] = f
+		}
+	}
+	return nil
+}
+
+// Fragment returns the registered fragment with the name.
+func (l *FragmentLibrary) Fragment(name string) (*ast.FragmentDefinition, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	f, ok := l.fragments[name]
+	if !ok {
+		return nil, false
+	}
+	return f.def, true
+}
+
+// Len returns the number of registered fragments.
+func (l *FragmentLibrary) Len() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return len(l.fragments)
+}
+
+// Resolve returns the document with the library fragments that it spreads,
+// directly or through other library fragments, but doesn't define added to its
+// definitions. The document is returned as is if it doesn't need any library
+// fragment. Spreads of fragments that aren't in the library are left for
+// validation to report. The added definitions are shared and must not be
+// modified. It's used to validate and execute documents without Do.
+func (l *FragmentLibrary) Resolve(doc *ast.Document) *ast.Document {
+	resolved, _ := l.resolve(doc, false)
+	return resolved
+}
+
+// resolve is Resolve with the added fragments parsed from their printed form if
+// copyFragments is true so that they can be modified with the document.
+func (l *FragmentLibrary) resolve(doc *ast.Document, copyFragments bool) (*ast.Document, error) {
+	defined := make(map[string]bool)
+	var pending []string
+	var collectSpreads func(ss *ast.SelectionSet)
+	collectSpreads = func(ss *ast.SelectionSet) {
+		if ss == nil {
+			return
+		}
+		for _, sel := range ss.Selections {
+			if spread, ok := sel.(*ast.FragmentSpread); ok {
+				pending = append(pending, spread.Name.Value)
+				continue
+			}
+			collectSpreads(sel.GetSelectionSet())
+		}
+	}
+	for _, def := range doc.Definitions {
+		switch def := def.(type) {
+		case *ast.OperationDefinition:
+			collectSpreads(def.SelectionSet)
+		case *ast.FragmentDefinition:
+			defined[def.Name.Value] = true
+			collectSpreads(def.SelectionSet)
+		}
+	}
+
+	var added []*libraryFragment
+	l.mu.RLock()
+	for len(pending) != 0 {
+		name := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if defined[name] {
+			continue
+		}
+		defined[name] = true
+		if f, ok := l.fragments[name]; ok {
+			added = append(added, f)
+			collectSpreads(f.def.SelectionSet)
+		}
+	}
+	l.mu.RUnlock()
+	if len(added) == 0 {
+		return doc, nil
+	}
+
+	defs := make([]ast.Node, len(doc.Definitions), len(doc.Definitions)+len(added))
+	copy(defs, doc.Definitions)
+	for _, f := range added {
+		def := f.def
+		if copyFragments {
+			copied, err := parser.Parse(parser.ParseParams{
+				Source: source.New("GraphQL fragments", f.printed),
+			})
+			if err != nil {
+				return nil, err
+			}
+			def = copied.Definitions[0].(*ast.FragmentDefinition)
+		}
+		defs = append(defs, def)
+	}
+	return &ast.Document{Loc: doc.Loc, Definitions: defs}, nil
+}
+
+// rewriter returns a DocumentRewriterFn that adds copies of the library
+// fragments spread by the document before calling next if not nil.
+func (l *FragmentLibrary) rewriter(next DocumentRewriterFn) DocumentRewriterFn {
+	return func(ctx context.Context, doc *ast.Document) (*ast.Document, error) {
+		doc, err := l.resolve(doc, true)
+		if err != nil {
+			return nil, err
+		}
+		if next != nil {
+			return next(ctx, doc)
+		}
+		return doc, nil
+	}
+}
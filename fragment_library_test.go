@@ -0,0 +1,161 @@
+package graphql_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/language/parser"
+	"github.com/sprucehealth/graphql/language/printer"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+const libraryFragments = `
+fragment HeroFields on Character {
+  name
+  ...FriendNames
+}
+
+fragment FriendNames on Character {
+  friends { name }
+}
+
+fragment DroidFields on Droid {
+  primaryFunction
+}
+`
+
+func TestFragmentLibrary_Do(t *testing.T) {
+	library := &graphql.FragmentLibrary{}
+	if err := library.Register(libraryFragments); err != nil {
+		t.Fatal(err)
+	}
+	if library.Len() != 3 {
+		t.Fatalf("Expected 3 fragments, got %d", library.Len())
+	}
+
+	expected := &graphql.Result{
+		Data: map[string]any{
+			"hero": map[string]any{
+				"name": "R2-D2",
+				"friends": []any{
+					map[string]any{"name": "Luke Skywalker"},
+					map[string]any{"name": "Han Solo"},
+					map[string]any{"name": "Leia Organa"},
+				},
+			},
+		},
+	}
+	// Fragments spread through library fragments are added as well
+	for _, cache := range []graphql.DocumentCache{nil, &graphql.MapDocumentCache{}} {
+		result := graphql.Do(context.Background(), graphql.Params{
+			Schema:          testutil.StarWarsSchema,
+			RequestString:   `{ hero { ...HeroFields } }`,
+			FragmentLibrary: library,
+			DocumentCache:   cache,
+		})
+		if !reflect.DeepEqual(expected, result) {
+			t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+		}
+	}
+
+	// A fragment defined by the request takes precedence
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema:          testutil.StarWarsSchema,
+		RequestString:   `{ hero { ...HeroFields } } fragment FriendNames on Character { id }`,
+		FragmentLibrary: library,
+	})
+	expected = &graphql.Result{
+		Data: map[string]any{
+			"hero": map[string]any{"name": "R2-D2", "id": "2001"},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	// Unknown fragments fail validation
+	result = graphql.Do(context.Background(), graphql.Params{
+		Schema:          testutil.StarWarsSchema,
+		RequestString:   `{ hero { ...Unknown } }`,
+		FragmentLibrary: library,
+	})
+	if len(result.Errors) != 1 || result.Errors[0].Message != `Unknown fragment "Unknown".` {
+		t.Fatalf("Expected an unknown fragment error, got %+v", result.Errors)
+	}
+}
+
+func TestFragmentLibrary_FragmentsAreNotModified(t *testing.T) {
+	library := &graphql.FragmentLibrary{}
+	if err := library.Register(`fragment HeroName on Character { name @include(if: true) }`); err != nil {
+		t.Fatal(err)
+	}
+	fragment, _ := library.Fragment("HeroName")
+	before := printer.Print(fragment)
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema:                   testutil.StarWarsSchema,
+		RequestString:            `{ hero { ...HeroName } }`,
+		FragmentLibrary:          library,
+		FoldConstantConditionals: true,
+	})
+	expected := &graphql.Result{Data: map[string]any{"hero": map[string]any{"name": "R2-D2"}}}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	if after := printer.Print(fragment); after != before {
+		t.Fatalf("Expected the library fragment to be unchanged, Diff: %v", testutil.Diff(before, after))
+	}
+}
+
+func TestFragmentLibrary_Register(t *testing.T) {
+	library := &graphql.FragmentLibrary{}
+	if err := library.Register(libraryFragments); err != nil {
+		t.Fatal(err)
+	}
+	// Registering the same definitions again is a no-op
+	if err := library.Register(libraryFragments); err != nil {
+		t.Fatal(err)
+	}
+
+	var conflict *graphql.FragmentConflictError
+	err := library.Register(`fragment New on Droid { name } fragment DroidFields on Droid { name }`)
+	if !errors.As(err, &conflict) || conflict.Fragment != "DroidFields" {
+		t.Fatalf("Expected a conflict error, got %v", err)
+	}
+	if _, ok := library.Fragment("New"); ok {
+		t.Fatal("Expected no fragment to be added when one conflicts")
+	}
+
+	if err := library.Register(`query Q { hero { name } }`); err == nil {
+		t.Fatal("Expected an error for a document with an operation")
+	}
+}
+
+func TestFragmentLibrary_Resolve(t *testing.T) {
+	library := &graphql.FragmentLibrary{}
+	if err := library.Register(libraryFragments); err != nil {
+		t.Fatal(err)
+	}
+	doc, err := parser.Parse(parser.ParseParams{Source: `{ hero { ...HeroFields ... on Droid { ...DroidFields } } }`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resolved := library.Resolve(doc)
+	if r := graphql.ValidateDocument(&testutil.StarWarsSchema, resolved, nil); !r.IsValid {
+		t.Fatalf("Expected the resolved document to be valid, got %v", r.Errors)
+	}
+	if len(doc.Definitions) != 1 || len(resolved.Definitions) != 4 {
+		t.Fatalf("Expected 3 fragments to be added to a copy of the document, got %d and %d definitions", len(doc.Definitions), len(resolved.Definitions))
+	}
+
+	// Documents that don't need the library are returned as is
+	doc, err = parser.Parse(parser.ParseParams{Source: `{ hero { name } }`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if library.Resolve(doc) != doc {
+		t.Fatal("Expected the document to be returned as is")
+	}
+}
@@ -0,0 +1,125 @@
+package graphql
+
+import (
+	"context"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultGoroutineAuditGrace is the default value for GoroutineAudit.Grace.
+const DefaultGoroutineAuditGrace = 100 * time.Millisecond
+
+// GoroutineAudit is a debug mode that tracks the goroutines started for a request
+// with Go, and the goroutine executing the operation, and reports the ones still
+// running after the operation completes. They're usually resolvers that ignore
+// the cancellation of their context.
+type GoroutineAudit struct {
+	// Grace is how long to wait after the operation completes before reporting the
+	// goroutines that are still running. Defaults to DefaultGoroutineAuditGrace.
+	Grace time.Duration
+	// Report is called with the goroutines still running after the grace period.
+	// It's only called if there are any and is called from its own goroutine.
+	Report func(ctx context.Context, leaks []GoroutineLeak)
+}
+
+// GoroutineLeak is a goroutine still running after the operation that started it
+// completed.
+type GoroutineLeak struct {
+	// Name is the name given to Go or "execution" for the goroutine executing the
+	// operation.
+	Name string
+	// Running is how long the goroutine has been running.
+	Running time.Duration
+	// Stack is the stack of the goroutine that started it.
+	Stack string
+}
+
+// Go starts fn in a new goroutine. If the request is executed with a
+// GoroutineAudit the goroutine is reported if it's still running after the
+// operation completes.
+func Go(ctx context.Context, name string, fn func(ctx context.Context)) {
+	t, _ := ctx.Value(goroutineTrackerKey{}).(*goroutineTracker)
+	if t == nil {
+		go fn(ctx)
+		return
+	}
+	done := t.start(name, callerStack())
+	go func() {
+		defer done()
+		fn(ctx)
+	}()
+}
+
+type goroutineTrackerKey struct{}
+
+// goroutineTracker records the goroutines running for a request.
+type goroutineTracker struct {
+	mu      sync.Mutex
+	next    int
+	running map[int]*trackedGoroutine
+}
+
+type trackedGoroutine struct {
+	name    string
+	started time.Time
+	stack   string
+}
+
+// start records a running goroutine and returns the function to call when it
+// returns.
+func (t *goroutineTracker) start(name, stack string) func() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.running == nil {
+		t.running = make(map[int]*trackedGoroutine)
+	}
+	id := t.next
+	t.next++
+	t.running[id] = &trackedGoroutine{name: name, started: time.Now(), stack: stack}
+	return func() {
+		t.mu.Lock()
+		delete(t.running, id)
+		t.mu.Unlock()
+	}
+}
+
+// leaks returns the goroutines still running in the order they were started.
+func (t *goroutineTracker) leaks() []GoroutineLeak {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	ids := make([]int, 0, len(t.running))
+	for id := range t.running {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	leaks := make([]GoroutineLeak, len(ids))
+	for i, id := range ids {
+		g := t.running[id]
+		leaks[i] = GoroutineLeak{
+			Name:    g.name,
+			Running: time.Since(g.started),
+			Stack:   g.stack,
+		}
+	}
+	return leaks
+}
+
+// audit reports the goroutines still running after the grace period.
+func (a *GoroutineAudit) audit(ctx context.Context, t *goroutineTracker) {
+	grace := a.Grace
+	if grace <= 0 {
+		grace = DefaultGoroutineAuditGrace
+	}
+	time.Sleep(grace)
+	if leaks := t.leaks(); len(leaks) != 0 && a.Report != nil {
+		a.Report(ctx, leaks)
+	}
+}
+
+func callerStack() string {
+	buf := make([]byte, 4096)
+	n := runtime.Stack(buf, false)
+	return string(buf[:n])
+}
@@ -0,0 +1,98 @@
+package graphql_test
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sprucehealth/graphql"
+)
+
+func TestGoroutineAudit(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	schema := testSchema(t, &graphql.Field{
+		Type: graphql.String,
+		Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+			graphql.Go(ctx, "cancellable", func(ctx context.Context) {
+				<-ctx.Done()
+			})
+			graphql.Go(ctx, "leaky", func(ctx context.Context) {
+				<-release
+			})
+			return "ok", nil
+		},
+	})
+
+	reports := make(chan []graphql.GoroutineLeak, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	result := graphql.Do(ctx, graphql.Params{
+		Schema:        schema,
+		RequestString: `{ test }`,
+		GoroutineAudit: &graphql.GoroutineAudit{
+			Grace: 10 * time.Millisecond,
+			Report: func(ctx context.Context, leaks []graphql.GoroutineLeak) {
+				reports <- leaks
+			},
+		},
+	})
+	cancel()
+	if len(result.Errors) != 0 {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+
+	select {
+	case leaks := <-reports:
+		names := make([]string, len(leaks))
+		for i, leak := range leaks {
+			names[i] = leak.Name
+		}
+		if expected := []string{"leaky"}; !reflect.DeepEqual(expected, names) {
+			t.Fatalf("Expected leaks %v, got %v", expected, names)
+		}
+		if !strings.Contains(leaks[0].Stack, "TestGoroutineAudit") {
+			t.Fatalf("Expected stack of the resolver, got %s", leaks[0].Stack)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected leaks to be reported")
+	}
+}
+
+func TestGoroutineAudit_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	schema := testSchema(t, &graphql.Field{
+		Type: graphql.String,
+		Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+			<-release
+			return "ok", nil
+		},
+	})
+
+	reports := make(chan []graphql.GoroutineLeak, 1)
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: `{ test }`,
+		Timeout:       10 * time.Millisecond,
+		GoroutineAudit: &graphql.GoroutineAudit{
+			Grace: 10 * time.Millisecond,
+			Report: func(ctx context.Context, leaks []graphql.GoroutineLeak) {
+				reports <- leaks
+			},
+		},
+	})
+	if len(result.Errors) != 1 {
+		t.Fatalf("Expected timeout error, got %v", result.Errors)
+	}
+
+	select {
+	case leaks := <-reports:
+		if len(leaks) != 1 || leaks[0].Name != "execution" {
+			t.Fatalf("Expected execution goroutine to leak, got %+v", leaks)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected leaks to be reported")
+	}
+}
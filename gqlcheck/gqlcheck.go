@@ -0,0 +1,264 @@
+// Package gqlcheck validates the operation documents of a client against a
+// schema and reports the errors of every file along with the deprecated schema
+// elements and the complexity of every operation. It's meant to back a pre-merge
+// check of the repositories of clients so that operations that don't work with
+// the schema, or that rely on fields about to be removed, are caught before
+// they're deployed.
+package gqlcheck
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"slices"
+	"sort"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/gqlerrors"
+	"github.com/sprucehealth/graphql/language/ast"
+	"github.com/sprucehealth/graphql/language/parser"
+	"github.com/sprucehealth/graphql/language/printer"
+	"github.com/sprucehealth/graphql/language/source"
+)
+
+// DefaultExtensions are the extensions of the files that are checked when
+// Options.Extensions is empty.
+var DefaultExtensions = []string{".graphql", ".gql"}
+
+// Options configures a check. The zero value checks the operations with the
+// specified validation rules only.
+type Options struct {
+	// Extensions are the extensions of the files of a directory that are checked.
+	// Defaults to DefaultExtensions.
+	Extensions []string
+	// Rules are the validation rules. Defaults to graphql.SpecifiedRules.
+	Rules []graphql.ValidationRuleFn
+	// MaxComplexity if greater than 0 fails the operations with a higher complexity
+	// (see graphql.OperationAnalysis).
+	MaxComplexity int
+	// MaxDepth if greater than 0 fails the operations with a higher depth.
+	MaxDepth int
+}
+
+// Report is the result of a check.
+type Report struct {
+	// Files are the reports of the checked files sorted by path.
+	Files []*FileReport `json:"files"`
+}
+
+// FileReport is the report of a file.
+type FileReport struct {
+	Path string `json:"path"`
+	// Errors are the errors that aren't specific to an operation (e.g. syntax
+	// errors or conflicting fragment definitions).
+	Errors     []gqlerrors.FormattedError `json:"errors,omitempty"`
+	Operations []*OperationReport         `json:"operations,omitempty"`
+}
+
+// OperationReport is the report of an operation. The deprecations and the
+// analysis are only set if the operation is valid.
+type OperationReport struct {
+	// Name is the name of the operation or empty if it's anonymous.
+	Name string `json:"name,omitempty"`
+	// Type is the operation type (query, mutation, or subscription).
+	Type         string                       `json:"type"`
+	Errors       []gqlerrors.FormattedError   `json:"errors,omitempty"`
+	Deprecations []graphql.DeprecationWarning `json:"deprecations,omitempty"`
+	Analysis     *graphql.OperationAnalysis   `json:"analysis,omitempty"`
+}
+
+// Valid returns true if none of the files and operations have errors.
+func (r *Report) Valid() bool {
+	for _, f := range r.Files {
+		if len(f.Errors) != 0 {
+			return false
+		}
+		for _, op := range f.Operations {
+			if len(op.Errors) != 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Deprecations returns the deprecated schema elements used by any operation
+// sorted by coordinate.
+func (r *Report) Deprecations() []graphql.DeprecationWarning {
+	seen := make(map[string]bool)
+	var warnings []graphql.DeprecationWarning
+	for _, f := range r.Files {
+		for _, op := range f.Operations {
+			for _, w := range op.Deprecations {
+				if !seen[w.Coordinate] {
+					seen[w.Coordinate] = true
+					warnings = append(warnings, w)
+				}
+			}
+		}
+	}
+	sort.Slice(warnings, func(i, j int) bool {
+		return warnings[i].Coordinate < warnings[j].Coordinate
+	})
+	return warnings
+}
+
+// CheckDir checks the files with one of the extensions of the options in the
+// directory and its subdirectories.
+func CheckDir(schema *graphql.Schema, dir string, opts *Options) (*Report, error) {
+	return CheckFS(schema, os.DirFS(dir), opts)
+}
+
+// CheckFS checks the files with one of the extensions of the options in the
+// file system. The paths of the report are the paths in the file system.
+func CheckFS(schema *graphql.Schema, fsys fs.FS, opts *Options) (*Report, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	extensions := opts.Extensions
+	if len(extensions) == 0 {
+		extensions = DefaultExtensions
+	}
+	var sources []*source.Source
+	err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !slices.Contains(extensions, path.Ext(p)) {
+			return nil
+		}
+		b, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return err
+		}
+		sources = append(sources, source.New(p, string(b)))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("gqlcheck: %w", err)
+	}
+	return Check(schema, opts, sources...), nil
+}
+
+// Check checks the operations of the sources. Fragments may be defined in any
+// of the sources and every operation is validated along with the fragments it
+// uses. Operation names must be unique across the sources.
+func Check(schema *graphql.Schema, opts *Options, sources ...*source.Source) *Report {
+	if opts == nil {
+		opts = &Options{}
+	}
+	sources = slices.Clone(sources)
+	sort.SliceStable(sources, func(i, j int) bool {
+		return sources[i].Name() < sources[j].Name()
+	})
+
+	type fileOperation struct {
+		file *FileReport
+		def  *ast.OperationDefinition
+	}
+	report := &Report{Files: make([]*FileReport, 0, len(sources))}
+	fragments := make(map[string]*ast.FragmentDefinition)
+	fragmentFiles := make(map[string]string)
+	var operations []fileOperation
+	for _, src := range sources {
+		file := &FileReport{Path: src.Name()}
+		report.Files = append(report.Files, file)
+		doc, err := parser.Parse(parser.ParseParams{Source: src})
+		if err != nil {
+			file.Errors = append(file.Errors, gqlerrors.FormatError(err))
+			continue
+		}
+		for _, def := range doc.Definitions {
+			switch def := def.(type) {
+			case *ast.OperationDefinition:
+				operations = append(operations, fileOperation{file: file, def: def})
+			case *ast.FragmentDefinition:
+				name := def.Name.Value
+				if f, ok := fragments[name]; ok {
+					if printer.Print(f) != printer.Print(def) {
+						file.Errors = append(file.Errors, gqlerrors.NewFormattedError(
+							fmt.Sprintf("Fragment %q is already defined in %s.", name, fragmentFiles[name])))
+					}
+					continue
+				}
+				fragments[name] = def
+				fragmentFiles[name] = src.Name()
+			}
+		}
+	}
+
+	policy := &graphql.AnalysisPolicy{MaxComplexity: opts.MaxComplexity, MaxDepth: opts.MaxDepth}
+	names := make(map[string]string)
+	for _, op := range operations {
+		r := &OperationReport{Type: op.def.Operation}
+		op.file.Operations = append(op.file.Operations, r)
+		if op.def.Name != nil {
+			r.Name = op.def.Name.Value
+			if file, ok := names[r.Name]; ok {
+				r.Errors = append(r.Errors, gqlerrors.NewFormattedError(
+					fmt.Sprintf("Operation %q is already defined in %s.", r.Name, file)))
+				continue
+			}
+			names[r.Name] = op.file.Path
+		}
+
+		doc := &ast.Document{Definitions: []ast.Node{op.def}}
+		for _, f := range usedFragments(op.def, fragments) {
+			doc.Definitions = append(doc.Definitions, f)
+		}
+		if res := graphql.ValidateDocument(schema, doc, opts.Rules); !res.IsValid {
+			r.Errors = append(r.Errors, res.Errors...)
+			continue
+		}
+		r.Deprecations = graphql.DocumentDeprecations(schema, doc, "")
+		r.Analysis = graphql.AnalyzeDocument(doc, "", policy)
+		if r.Analysis.ExceedsMaxComplexity {
+			r.Errors = append(r.Errors, gqlerrors.NewFormattedError(
+				fmt.Sprintf("Operation complexity %d exceeds the maximum of %d.", r.Analysis.Complexity, opts.MaxComplexity)))
+		}
+		if r.Analysis.ExceedsMaxDepth {
+			r.Errors = append(r.Errors, gqlerrors.NewFormattedError(
+				fmt.Sprintf("Operation depth %d exceeds the maximum of %d.", r.Analysis.Depth, opts.MaxDepth)))
+		}
+	}
+	return report
+}
+
+// usedFragments returns the fragments transitively spread by the operation
+// sorted by name. Unknown fragments are left for validation to report.
+func usedFragments(op *ast.OperationDefinition, fragments map[string]*ast.FragmentDefinition) []*ast.FragmentDefinition {
+	used := make(map[string]*ast.FragmentDefinition)
+	var visit func(ss *ast.SelectionSet)
+	visit = func(ss *ast.SelectionSet) {
+		if ss == nil {
+			return
+		}
+		for _, sel := range ss.Selections {
+			if spread, ok := sel.(*ast.FragmentSpread); ok {
+				name := spread.Name.Value
+				if _, ok := used[name]; ok {
+					continue
+				}
+				if f, ok := fragments[name]; ok {
+					used[name] = f
+					visit(f.SelectionSet)
+				}
+				continue
+			}
+			visit(sel.GetSelectionSet())
+		}
+	}
+	visit(op.SelectionSet)
+
+	names := make([]string, 0, len(used))
+	for name := range used {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	defs := make([]*ast.FragmentDefinition, len(names))
+	for i, name := range names {
+		defs[i] = used[name]
+	}
+	return defs
+}
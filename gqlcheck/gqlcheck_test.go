@@ -0,0 +1,120 @@
+package gqlcheck
+
+import (
+	"reflect"
+	"testing"
+	"testing/fstest"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+func testSchema(t *testing.T) *graphql.Schema {
+	t.Helper()
+	schema, err := testutil.SchemaFromSDL(`
+		type Query {
+			hero: Hero
+			legacyHero: Hero @deprecated(reason: "Use hero.")
+		}
+		type Hero {
+			name: String
+			friends: [Hero]
+		}
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+func TestCheckFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"fragments.graphql":         {Data: []byte(`fragment HeroName on Hero { name }`)},
+		"screens/home.graphql":      {Data: []byte(`query Home { hero { ...HeroName friends { ...HeroName } } }`)},
+		"screens/legacy.gql":        {Data: []byte(`query Legacy { legacyHero { name } } query Unknown { villain }`)},
+		"screens/broken.graphql":    {Data: []byte(`query Broken {`)},
+		"screens/other.gql":         {Data: []byte(`query Home { hero { name } } fragment HeroName on Hero { friends { name } }`)},
+		"screens/notes.txt":         {Data: []byte(`not checked`)},
+		"screens/deep/deep.graphql": {Data: []byte(`{ hero { friends { friends { name } } } }`)},
+	}
+	report, err := CheckFS(testSchema(t), fsys, &Options{MaxDepth: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Valid() {
+		t.Fatal("Expected the report not to be valid")
+	}
+
+	expectedPaths := []string{
+		"fragments.graphql",
+		"screens/broken.graphql",
+		"screens/deep/deep.graphql",
+		"screens/home.graphql",
+		"screens/legacy.gql",
+		"screens/other.gql",
+	}
+	var paths []string
+	for _, f := range report.Files {
+		paths = append(paths, f.Path)
+	}
+	if !reflect.DeepEqual(expectedPaths, paths) {
+		t.Fatalf("Expected files %v, got %v", expectedPaths, paths)
+	}
+
+	if errs := report.Files[1].Errors; len(errs) != 1 || len(report.Files[1].Operations) != 0 {
+		t.Fatalf("Expected a syntax error, got %+v", report.Files[1])
+	}
+
+	deep := report.Files[2].Operations[0]
+	if len(deep.Errors) != 1 || deep.Errors[0].Message != "Operation depth 4 exceeds the maximum of 3." {
+		t.Fatalf("Expected a depth error, got %+v", deep.Errors)
+	}
+
+	home := report.Files[3].Operations[0]
+	expectedHome := &OperationReport{
+		Name:     "Home",
+		Type:     "query",
+		Analysis: &graphql.OperationAnalysis{Complexity: 4, Depth: 3},
+	}
+	if !reflect.DeepEqual(expectedHome, home) {
+		t.Fatalf("Unexpected report, Diff: %v", testutil.Diff(expectedHome, home))
+	}
+
+	// Unknown is invalid and Legacy uses a deprecated field
+	legacy := report.Files[4].Operations
+	if len(legacy) != 2 || len(legacy[0].Errors) != 0 || len(legacy[1].Errors) != 1 || legacy[1].Analysis != nil {
+		t.Fatalf("Expected Legacy to be valid and Unknown invalid, got %+v", legacy)
+	}
+	expectedDeprecations := []graphql.DeprecationWarning{{
+		Coordinate: "Query.legacyHero",
+		Reason:     "Use hero.",
+		Message:    "Query.legacyHero is deprecated: Use hero.",
+	}}
+	if !reflect.DeepEqual(expectedDeprecations, legacy[0].Deprecations) {
+		t.Fatalf("Unexpected deprecations, Diff: %v", testutil.Diff(expectedDeprecations, legacy[0].Deprecations))
+	}
+	if deprecations := report.Deprecations(); !reflect.DeepEqual(expectedDeprecations, deprecations) {
+		t.Fatalf("Unexpected deprecations, Diff: %v", testutil.Diff(expectedDeprecations, deprecations))
+	}
+
+	// Names are unique across files and fragments must have a single definition
+	other := report.Files[5]
+	if len(other.Errors) != 1 || other.Errors[0].Message != `Fragment "HeroName" is already defined in fragments.graphql.` {
+		t.Fatalf("Expected a fragment conflict, got %+v", other.Errors)
+	}
+	if errs := other.Operations[0].Errors; len(errs) != 1 || errs[0].Message != `Operation "Home" is already defined in screens/home.graphql.` {
+		t.Fatalf("Expected an operation conflict, got %+v", errs)
+	}
+}
+
+func TestCheck_Valid(t *testing.T) {
+	report, err := CheckFS(testSchema(t), fstest.MapFS{
+		"a.graphql": {Data: []byte(`query A { hero { name } }`)},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.Valid() || len(report.Deprecations()) != 0 {
+		t.Fatalf("Expected a valid report, got %+v", report.Files[0])
+	}
+}
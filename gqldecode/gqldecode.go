@@ -100,7 +100,14 @@ func decodeValue(v any, out reflect.Value, fi *structFieldInfo) {
 		}
 		out.SetString(strings.TrimSpace(s))
 	case reflect.Int, reflect.Int64:
-		out.SetInt(int64(v.(int)))
+		if out.Type() == durationType {
+			out.Set(reflect.ValueOf(decodeDuration(v, fi)))
+		} else if vv := reflect.ValueOf(v); vv.Type() == out.Type() {
+			// Already decoded by a custom scalar
+			out.Set(vv)
+		} else {
+			out.SetInt(int64(v.(int)))
+		}
 	case reflect.Bool:
 		out.SetBool(v.(bool))
 	case reflect.Float64:
@@ -176,6 +183,25 @@ func decodeValue(v any, out reflect.Value, fi *structFieldInfo) {
 	}
 }
 
+var durationType = reflect.TypeFor[time.Duration]()
+
+// decodeDuration decodes a time.Duration parsed by a Duration scalar or a string
+// as accepted by time.ParseDuration.
+func decodeDuration(v any, fi *structFieldInfo) time.Duration {
+	switch v := v.(type) {
+	case time.Duration:
+		return v
+	case string:
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			panic(&ValidationFailedError{Field: fi.name, Reason: fmt.Sprintf("invalid duration %q", v)})
+		}
+		return d
+	}
+	errf("invalid input type for time.Duration %T", v)
+	return 0
+}
+
 func errf(msg string, v ...any) {
 	panic(fmt.Errorf("gqldecode: "+msg, v...))
 }
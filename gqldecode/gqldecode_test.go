@@ -245,6 +245,38 @@ func TestTimestamp(t *testing.T) {
 	}
 }
 
+func TestDuration(t *testing.T) {
+	in := map[string]any{
+		"parsed":   5 * time.Minute,
+		"string":   "1h30m",
+		"optional": "30s",
+	}
+	type outStruct struct {
+		Parsed   time.Duration  `gql:"parsed"`
+		String   time.Duration  `gql:"string"`
+		Optional *time.Duration `gql:"optional"`
+	}
+	var out outStruct
+	if err := Decode(in, &out); err != nil {
+		t.Fatal(err)
+	}
+	optional := 30 * time.Second
+	exp := outStruct{
+		Parsed:   5 * time.Minute,
+		String:   90 * time.Minute,
+		Optional: &optional,
+	}
+	if !reflect.DeepEqual(exp, out) {
+		t.Fatalf("Expected %+v got %+v", exp, out)
+	}
+
+	err := Decode(map[string]any{"string": "5 minutes"}, &out)
+	var verr *ValidationFailedError
+	if !errors.As(err, &verr) || verr.Field != "string" {
+		t.Fatalf("Expected validation error for field string, got %v", err)
+	}
+}
+
 func TestEmbeddedStruct(t *testing.T) {
 	type outSubStruct struct {
 		A string `gql:"a"`
@@ -25,3 +25,14 @@ var unicodeSanitizeReplacer = strings.NewReplacer(
 func sanitizeUnicode(s string) string {
 	return unicodeSanitizeReplacer.Replace(s)
 }
+
+// SanitizeUnicode removes the characters from a string value that are removed
+// when decoding strings (e.g. byte order marks). Other values are returned as
+// is. It can be used as a scalar sanitizer (graphql.SanitizeFn) for String so
+// that all string arguments are sanitized whether or not they're decoded.
+func SanitizeUnicode(v any) any {
+	if s, ok := v.(string); ok {
+		return sanitizeUnicode(s)
+	}
+	return v
+}
@@ -23,5 +23,11 @@ func TestSanitizeUnicode(t *testing.T) {
 		if out := sanitizeUnicode(in); out != exp {
 			t.Errorf("sanitizeUnicode(%q) = %q, expected %q", in, out, exp)
 		}
+		if out := SanitizeUnicode(in); out != exp {
+			t.Errorf("SanitizeUnicode(%q) = %q, expected %q", in, out, exp)
+		}
+	}
+	if out := SanitizeUnicode(1); out != 1 {
+		t.Errorf("SanitizeUnicode(1) = %v, expected 1", out)
 	}
 }
@@ -0,0 +1,301 @@
+package gqlerrors
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/sprucehealth/graphql/language/ast"
+)
+
+// Code identifies an error in the catalog. Codes are stable and are returned
+// in the code field of errors so that API consumers can program against them
+// instead of the messages.
+type Code string
+
+// Codes of the errors in the catalog.
+const (
+	// CodeSelectionLimit is used when an operation selects more fields than
+	// allowed once its fragments are expanded.
+	CodeSelectionLimit Code = "SELECTION_LIMIT"
+	// CodeAliasLimit is used when a field is selected with more aliases than allowed.
+	CodeAliasLimit Code = "ALIAS_LIMIT"
+	// CodeUndefinedVariable is used when a variable value isn't defined by the
+	// operation and variables are strict.
+	CodeUndefinedVariable Code = "UNDEFINED_VARIABLE"
+	// CodeMethodNotAllowed is used when an operation other than a query is sent
+	// with an HTTP GET request.
+	CodeMethodNotAllowed Code = "METHOD_NOT_ALLOWED"
+	// CodeRateLimited is used when a request is rejected by a rate limiter.
+	CodeRateLimited Code = "RATE_LIMITED"
+	// CodeResultTooLarge is used when the result exceeds the maximum size.
+	CodeResultTooLarge Code = "RESULT_TOO_LARGE"
+	// CodeBulkheadFull is used when a resolver can't run because the bulkhead of
+	// its type has no free slot.
+	CodeBulkheadFull Code = "BULKHEAD_FULL"
+	// CodeBulkheadTimeout is used when a resolver times out waiting for a slot of
+	// the bulkhead of its type.
+	CodeBulkheadTimeout Code = "BULKHEAD_TIMEOUT"
+	// CodeRestrictedType is used when a value resolves to an object type that has
+	// been restricted for the request.
+	CodeRestrictedType Code = "RESTRICTED_TYPE"
+	// CodeOperationTimeout is used when the operation exceeds its timeout.
+	CodeOperationTimeout Code = "OPERATION_TIMEOUT"
+	// CodeMissingOperation is used when the document doesn't contain an operation.
+	CodeMissingOperation Code = "MISSING_OPERATION"
+	// CodeOperationNameRequired is used when the document contains several
+	// operations and no operation name is given.
+	CodeOperationNameRequired Code = "OPERATION_NAME_REQUIRED"
+	// CodeUnknownOperation is used when the document doesn't contain an operation
+	// with the given name.
+	CodeUnknownOperation Code = "UNKNOWN_OPERATION"
+	// CodeUnexecutableDefinition is used when the document contains a definition
+	// other than an operation or a fragment.
+	CodeUnexecutableDefinition Code = "UNEXECUTABLE_DEFINITION"
+	// CodeUnsupportedOperation is used when the schema doesn't have a root type for
+	// the operation type.
+	CodeUnsupportedOperation Code = "UNSUPPORTED_OPERATION"
+	// CodeInvalidOperationType is used when the type of the operation isn't a
+	// query, mutation, or subscription.
+	CodeInvalidOperationType Code = "INVALID_OPERATION_TYPE"
+	// CodeVariableNotInputType is used when a variable is defined with a type that
+	// isn't an input type.
+	CodeVariableNotInputType Code = "VARIABLE_NOT_INPUT_TYPE"
+	// CodeNullVariable is used when null is given for a variable of non-null type.
+	CodeNullVariable Code = "NULL_VARIABLE"
+	// CodeMissingVariable is used when no value is given for a variable of
+	// non-null type without a default value.
+	CodeMissingVariable Code = "MISSING_VARIABLE"
+	// CodeInvalidVariable is used when the value of a variable can't be coerced to
+	// its type.
+	CodeInvalidVariable Code = "INVALID_VARIABLE"
+	// CodeNonNullViolation is used when a field of non-null type resolves to null.
+	CodeNonNullViolation Code = "NON_NULL_VIOLATION"
+	// CodeUnresolvedAbstractType is used when the runtime object type of a value of
+	// an interface or union can't be determined.
+	CodeUnresolvedAbstractType Code = "UNRESOLVED_ABSTRACT_TYPE"
+	// CodeImpossibleType is used when a value of an interface or union resolves to
+	// an object type that isn't one of its possible types.
+	CodeImpossibleType Code = "IMPOSSIBLE_TYPE"
+	// CodeUnexpectedValue is used when the IsTypeOf function of an object type
+	// rejects the value of a field.
+	CodeUnexpectedValue Code = "UNEXPECTED_VALUE"
+	// CodeListExpected is used when the resolver of a list field doesn't return a
+	// slice or a channel.
+	CodeListExpected Code = "LIST_EXPECTED"
+	// CodeChannelExpected is used when the resolver of a list field returns a
+	// channel that can't be received from.
+	CodeChannelExpected Code = "CHANNEL_EXPECTED"
+	// CodeNotSubscription is used when Subscribe is called with an operation that
+	// isn't a subscription.
+	CodeNotSubscription Code = "NOT_SUBSCRIPTION"
+	// CodeSubscriptionFieldCount is used when a subscription doesn't select exactly
+	// one top level field.
+	CodeSubscriptionFieldCount Code = "SUBSCRIPTION_FIELD_COUNT"
+	// CodeMissingSubscribe is used when the field of a subscription has no
+	// subscribe function.
+	CodeMissingSubscribe Code = "MISSING_SUBSCRIBE"
+	// CodePassthroughTypeMismatch is used when the value of a passthrough field
+	// doesn't have the Go type expected for its GraphQL type.
+	CodePassthroughTypeMismatch Code = "PASSTHROUGH_TYPE_MISMATCH"
+	// CodeUnresolvedPassthroughType is used when the __typename of the value of a
+	// passthrough field of an interface or union isn't an object type.
+	CodeUnresolvedPassthroughType Code = "UNRESOLVED_PASSTHROUGH_TYPE"
+	// CodeTooManyErrors is used for the error that replaces the errors dropped once
+	// the maximum number of errors is reached.
+	CodeTooManyErrors Code = "TOO_MANY_ERRORS"
+)
+
+// CatalogEntry describes an error the library can produce.
+type CatalogEntry struct {
+	Code Code
+	Type ErrorType
+	// Message is the template of the message formatted with fmt.Sprintf. Changing
+	// a template is a breaking change.
+	Message string
+	// Retryable is true if the same request may succeed when retried later.
+	Retryable bool
+}
+
+var catalog = map[Code]CatalogEntry{
+	CodeSelectionLimit: {
+		Type:    ErrorTypeBadQuery,
+		Message: "Operation selects more than %d fields once fragments are expanded.",
+	},
+	CodeAliasLimit: {
+		Type:    ErrorTypeBadQuery,
+		Message: "Field %q is selected with more than %d aliases.",
+	},
+	CodeUndefinedVariable: {
+		Type:    ErrorTypeInvalidInput,
+		Message: `Variable "$%v" is not defined by operation%s.`,
+	},
+	CodeMethodNotAllowed: {
+		Type:    ErrorTypeMethodNotAllowed,
+		Message: "Can only perform a %s operation from a POST request.",
+	},
+	CodeRateLimited: {
+		Type:      ErrorTypeResourceExhausted,
+		Message:   "Rate limit exceeded.",
+		Retryable: true,
+	},
+	CodeResultTooLarge: {
+		Type:    ErrorTypeResourceExhausted,
+		Message: "Result exceeds the maximum size of %d bytes.",
+	},
+	CodeBulkheadFull: {
+		Type:      ErrorTypeResourceExhausted,
+		Message:   "Too many concurrent resolvers.",
+		Retryable: true,
+	},
+	CodeBulkheadTimeout: {
+		Type:      ErrorTypeResourceExhausted,
+		Message:   "Timed out waiting for a concurrent resolver slot.",
+		Retryable: true,
+	},
+	CodeRestrictedType: {
+		Type:    ErrorTypeRestrictedType,
+		Message: `Runtime Object type "%v" is restricted for "%v" in this request.`,
+	},
+	CodeOperationTimeout: {
+		Type:      ErrorTypeInternal,
+		Message:   "Operation timed out.",
+		Retryable: true,
+	},
+	CodeMissingOperation: {
+		Type:    ErrorTypeBadQuery,
+		Message: "Must provide an operation.",
+	},
+	CodeOperationNameRequired: {
+		Type:    ErrorTypeBadQuery,
+		Message: "Must provide operation name if query contains multiple operations.",
+	},
+	CodeUnknownOperation: {
+		Type:    ErrorTypeBadQuery,
+		Message: "Unknown operation named %q.",
+	},
+	CodeUnexecutableDefinition: {
+		Type:    ErrorTypeBadQuery,
+		Message: "GraphQL cannot execute a request containing a %s.",
+	},
+	CodeUnsupportedOperation: {
+		Type:    ErrorTypeBadQuery,
+		Message: "Schema is not configured for %ss.",
+	},
+	CodeInvalidOperationType: {
+		Type:    ErrorTypeBadQuery,
+		Message: "Can only execute queries, mutations and subscriptions.",
+	},
+	CodeVariableNotInputType: {
+		Type:    ErrorTypeInvalidInput,
+		Message: `Variable "$%v" expected value of type "%v" which cannot be used as an input type.`,
+	},
+	CodeNullVariable: {
+		Type:    ErrorTypeInvalidInput,
+		Message: `Variable "$%v" of non-null type "%v" must not be null.`,
+	},
+	CodeMissingVariable: {
+		Type:    ErrorTypeInvalidInput,
+		Message: `Variable "$%v" of required type "%v" was not provided.`,
+	},
+	CodeInvalidVariable: {
+		Type:    ErrorTypeInvalidInput,
+		Message: `Variable "$%v" got invalid value %v.%v`,
+	},
+	CodeNonNullViolation: {
+		Type:    ErrorTypeInternal,
+		Message: "Cannot return null for non-nullable field %v.%v.",
+	},
+	CodeUnresolvedAbstractType: {
+		Type:    ErrorTypeInternal,
+		Message: `Abstract type %v must resolve to an Object type at runtime for field %v.%v with value "%v", received "%v".`,
+	},
+	CodeImpossibleType: {
+		Type:    ErrorTypeInternal,
+		Message: `Runtime Object type "%v" is not a possible type for "%v".`,
+	},
+	CodeUnexpectedValue: {
+		Type:    ErrorTypeInternal,
+		Message: `Expected value of type "%v" but got: %T.`,
+	},
+	CodeListExpected: {
+		Type:    ErrorTypeInternal,
+		Message: "User Error: expected iterable, but did not find one for field %v.%v.",
+	},
+	CodeChannelExpected: {
+		Type:    ErrorTypeInternal,
+		Message: "User Error: expected a channel that can be received from for field %v.%v.",
+	},
+	CodeNotSubscription: {
+		Type:    ErrorTypeBadQuery,
+		Message: "Subscribe can only execute subscription operations.",
+	},
+	CodeSubscriptionFieldCount: {
+		Type:    ErrorTypeBadQuery,
+		Message: "Subscription operations must select exactly one top level field.",
+	},
+	CodeMissingSubscribe: {
+		Type:    ErrorTypeBadQuery,
+		Message: `Subscription field "%s" has no subscribe function.`,
+	},
+	CodePassthroughTypeMismatch: {
+		Type:    ErrorTypeInternal,
+		Message: "User Error: expected passthrough value of type %s for field %v.%v but got %T.",
+	},
+	CodeUnresolvedPassthroughType: {
+		Type:    ErrorTypeInternal,
+		Message: `Abstract type %v must resolve to an Object type at runtime for passthrough field %v.%v with __typename "%v".`,
+	},
+	CodeTooManyErrors: {
+		Type:    ErrorTypeInternal,
+		Message: "And %d more errors.",
+	},
+}
+
+func init() {
+	for code, entry := range catalog {
+		entry.Code = code
+		catalog[code] = entry
+	}
+}
+
+// Catalog returns the entries of the catalog sorted by code. Errors of validation
+// rules and errors returned by resolvers aren't in the catalog.
+func Catalog() []CatalogEntry {
+	entries := make([]CatalogEntry, 0, len(catalog))
+	for _, entry := range catalog {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Code < entries[j].Code })
+	return entries
+}
+
+// LookupCode returns the catalog entry of the code.
+func LookupCode(code Code) (CatalogEntry, bool) {
+	entry, ok := catalog[code]
+	return entry, ok
+}
+
+// Message returns the message of the catalog entry of the code formatted with the
+// arguments.
+func (c Code) Message(args ...any) string {
+	entry, ok := catalog[c]
+	if !ok {
+		return string(c)
+	}
+	if len(args) == 0 {
+		return entry.Message
+	}
+	return fmt.Sprintf(entry.Message, args...)
+}
+
+// New returns an error for the catalog entry of the code with its type and the
+// message formatted with the arguments.
+func (c Code) New(nodes []ast.Node, origError error, args ...any) *Error {
+	typ := ErrorTypeInternal
+	if entry, ok := catalog[c]; ok {
+		typ = entry.Type
+	}
+	err := NewError(typ, c.Message(args...), nodes, "", nil, nil, origError)
+	err.Code = c
+	return err
+}
@@ -0,0 +1,64 @@
+package gqlerrors_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/sprucehealth/graphql/gqlerrors"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+// TestCatalog guards the catalog against accidental changes as codes, types, and
+// message templates are part of the API.
+func TestCatalog(t *testing.T) {
+	expected := []gqlerrors.CatalogEntry{
+		{Code: "ALIAS_LIMIT", Type: gqlerrors.ErrorTypeBadQuery, Message: "Field %q is selected with more than %d aliases."},
+		{Code: "BULKHEAD_FULL", Type: gqlerrors.ErrorTypeResourceExhausted, Message: "Too many concurrent resolvers.", Retryable: true},
+		{Code: "BULKHEAD_TIMEOUT", Type: gqlerrors.ErrorTypeResourceExhausted, Message: "Timed out waiting for a concurrent resolver slot.", Retryable: true},
+		{Code: "CHANNEL_EXPECTED", Type: gqlerrors.ErrorTypeInternal, Message: "User Error: expected a channel that can be received from for field %v.%v."},
+		{Code: "IMPOSSIBLE_TYPE", Type: gqlerrors.ErrorTypeInternal, Message: `Runtime Object type "%v" is not a possible type for "%v".`},
+		{Code: "INVALID_OPERATION_TYPE", Type: gqlerrors.ErrorTypeBadQuery, Message: "Can only execute queries, mutations and subscriptions."},
+		{Code: "INVALID_VARIABLE", Type: gqlerrors.ErrorTypeInvalidInput, Message: `Variable "$%v" got invalid value %v.%v`},
+		{Code: "LIST_EXPECTED", Type: gqlerrors.ErrorTypeInternal, Message: "User Error: expected iterable, but did not find one for field %v.%v."},
+		{Code: "METHOD_NOT_ALLOWED", Type: gqlerrors.ErrorTypeMethodNotAllowed, Message: "Can only perform a %s operation from a POST request."},
+		{Code: "MISSING_OPERATION", Type: gqlerrors.ErrorTypeBadQuery, Message: "Must provide an operation."},
+		{Code: "MISSING_SUBSCRIBE", Type: gqlerrors.ErrorTypeBadQuery, Message: `Subscription field "%s" has no subscribe function.`},
+		{Code: "MISSING_VARIABLE", Type: gqlerrors.ErrorTypeInvalidInput, Message: `Variable "$%v" of required type "%v" was not provided.`},
+		{Code: "NON_NULL_VIOLATION", Type: gqlerrors.ErrorTypeInternal, Message: "Cannot return null for non-nullable field %v.%v."},
+		{Code: "NOT_SUBSCRIPTION", Type: gqlerrors.ErrorTypeBadQuery, Message: "Subscribe can only execute subscription operations."},
+		{Code: "NULL_VARIABLE", Type: gqlerrors.ErrorTypeInvalidInput, Message: `Variable "$%v" of non-null type "%v" must not be null.`},
+		{Code: "OPERATION_NAME_REQUIRED", Type: gqlerrors.ErrorTypeBadQuery, Message: "Must provide operation name if query contains multiple operations."},
+		{Code: "OPERATION_TIMEOUT", Type: gqlerrors.ErrorTypeInternal, Message: "Operation timed out.", Retryable: true},
+		{Code: "PASSTHROUGH_TYPE_MISMATCH", Type: gqlerrors.ErrorTypeInternal, Message: "User Error: expected passthrough value of type %s for field %v.%v but got %T."},
+		{Code: "RATE_LIMITED", Type: gqlerrors.ErrorTypeResourceExhausted, Message: "Rate limit exceeded.", Retryable: true},
+		{Code: "RESTRICTED_TYPE", Type: gqlerrors.ErrorTypeRestrictedType, Message: `Runtime Object type "%v" is restricted for "%v" in this request.`},
+		{Code: "RESULT_TOO_LARGE", Type: gqlerrors.ErrorTypeResourceExhausted, Message: "Result exceeds the maximum size of %d bytes."},
+		{Code: "SELECTION_LIMIT", Type: gqlerrors.ErrorTypeBadQuery, Message: "Operation selects more than %d fields once fragments are expanded."},
+		{Code: "SUBSCRIPTION_FIELD_COUNT", Type: gqlerrors.ErrorTypeBadQuery, Message: "Subscription operations must select exactly one top level field."},
+		{Code: "TOO_MANY_ERRORS", Type: gqlerrors.ErrorTypeInternal, Message: "And %d more errors."},
+		{Code: "UNDEFINED_VARIABLE", Type: gqlerrors.ErrorTypeInvalidInput, Message: `Variable "$%v" is not defined by operation%s.`},
+		{Code: "UNEXECUTABLE_DEFINITION", Type: gqlerrors.ErrorTypeBadQuery, Message: "GraphQL cannot execute a request containing a %s."},
+		{Code: "UNEXPECTED_VALUE", Type: gqlerrors.ErrorTypeInternal, Message: `Expected value of type "%v" but got: %T.`},
+		{Code: "UNKNOWN_OPERATION", Type: gqlerrors.ErrorTypeBadQuery, Message: "Unknown operation named %q."},
+		{Code: "UNRESOLVED_ABSTRACT_TYPE", Type: gqlerrors.ErrorTypeInternal, Message: `Abstract type %v must resolve to an Object type at runtime for field %v.%v with value "%v", received "%v".`},
+		{Code: "UNRESOLVED_PASSTHROUGH_TYPE", Type: gqlerrors.ErrorTypeInternal, Message: `Abstract type %v must resolve to an Object type at runtime for passthrough field %v.%v with __typename "%v".`},
+		{Code: "UNSUPPORTED_OPERATION", Type: gqlerrors.ErrorTypeBadQuery, Message: "Schema is not configured for %ss."},
+		{Code: "VARIABLE_NOT_INPUT_TYPE", Type: gqlerrors.ErrorTypeInvalidInput, Message: `Variable "$%v" expected value of type "%v" which cannot be used as an input type.`},
+	}
+	if entries := gqlerrors.Catalog(); !reflect.DeepEqual(entries, expected) {
+		t.Fatal(testutil.Diff(expected, entries))
+	}
+}
+
+func TestCode_New(t *testing.T) {
+	err := gqlerrors.CodeAliasLimit.New(nil, nil, "user", 3)
+	if err.Code != gqlerrors.CodeAliasLimit || err.Type != gqlerrors.ErrorTypeBadQuery || err.Message != `Field "user" is selected with more than 3 aliases.` {
+		t.Fatalf("Unexpected error %+v", err)
+	}
+	if fe := gqlerrors.FormatError(err); fe.Code != gqlerrors.CodeAliasLimit {
+		t.Fatalf("Expected the formatted error to have the code, got %q", fe.Code)
+	}
+	if entry, ok := gqlerrors.LookupCode(gqlerrors.CodeRateLimited); !ok || !entry.Retryable {
+		t.Fatalf("Unexpected entry %+v", entry)
+	}
+}
@@ -17,6 +17,15 @@ const (
 	ErrorTypeInternal     ErrorType = "INTERNAL"
 	ErrorTypeInvalidInput ErrorType = "INVALID_INPUT"
 	ErrorTypeSyntax       ErrorType = "SYNTAX"
+	// ErrorTypeRestrictedType is used when a value resolves to an object type
+	// that has been restricted for the request.
+	ErrorTypeRestrictedType ErrorType = "RESTRICTED_TYPE"
+	// ErrorTypeResourceExhausted is used when a request is rejected by a rate limiter
+	// or exceeds a resource limit such as the maximum size of the result.
+	ErrorTypeResourceExhausted ErrorType = "RESOURCE_EXHAUSTED"
+	// ErrorTypeMethodNotAllowed is used when an operation other than a query is
+	// sent with an HTTP GET request.
+	ErrorTypeMethodNotAllowed ErrorType = "METHOD_NOT_ALLOWED"
 )
 
 // Error is a structured error.
@@ -29,6 +38,10 @@ type Error struct {
 	Positions     []int
 	Locations     []location.SourceLocation
 	OriginalError error
+	// Extensions is additional structured information about the error.
+	Extensions map[string]any
+	// Code is the code of the catalog entry of the error if it's in the catalog.
+	Code Code
 }
 
 // Error implements Golang's built-in `error` interface
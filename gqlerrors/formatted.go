@@ -11,8 +11,11 @@ import (
 type FormattedError struct {
 	Message       string                    `json:"message"`
 	Type          ErrorType                 `json:"type,omitempty"`
+	Code          Code                      `json:"code,omitempty"`
 	UserMessage   string                    `json:"userMessage,omitempty"`
 	Locations     []location.SourceLocation `json:"locations"`
+	Path          []any                     `json:"path,omitempty"`
+	Extensions    map[string]any            `json:"extensions,omitempty"`
 	StackTrace    string                    `json:"-"`
 	OriginalError error                     `json:"-"`
 }
@@ -21,6 +24,11 @@ func (g FormattedError) Error() string {
 	return g.Message
 }
 
+// Unwrap returns the original error.
+func (g FormattedError) Unwrap() error {
+	return g.OriginalError
+}
+
 func NewFormattedError(message string) FormattedError {
 	return FormatError(errors.New(message))
 }
@@ -41,15 +49,19 @@ func FormatError(err error) FormattedError {
 	case *Error:
 		return FormattedError{
 			Type:          err.Type,
+			Code:          err.Code,
 			Message:       err.Error(),
 			Locations:     err.Locations,
+			Extensions:    err.Extensions,
 			OriginalError: err.OriginalError,
 		}
 	case Error:
 		return FormattedError{
 			Type:          err.Type,
+			Code:          err.Code,
 			Message:       err.Error(),
 			Locations:     err.Locations,
+			Extensions:    err.Extensions,
 			OriginalError: err.OriginalError,
 		}
 	default:
@@ -81,9 +93,3 @@ func FormatErrors(errs ...error) []FormattedError {
 	}
 	return formattedErrors
 }
-
-func stackTrace() string {
-	buf := make([]byte, 4096)
-	n := runtime.Stack(buf, false)
-	return string(buf[:n])
-}
@@ -0,0 +1,92 @@
+package gqlerrors
+
+import (
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// DefaultStackTraceMaxFrames is the maximum number of frames of a stack trace
+// when StackTraceConfig.MaxFrames isn't set.
+const DefaultStackTraceMaxFrames = 32
+
+// StackTraceConfig controls the stack traces captured in FormattedError.StackTrace
+// for internal errors and panics. The original error is kept either way.
+type StackTraceConfig struct {
+	// Disabled if true doesn't capture stack traces (e.g. in production where
+	// they're not logged and capturing them for every error is costly).
+	Disabled bool
+	// MaxFrames if greater than 0 is the maximum number of frames of a stack
+	// trace. The default is DefaultStackTraceMaxFrames.
+	MaxFrames int
+	// SkipLibraryFrames if true omits the frames of the functions of this module
+	// (e.g. the executor) so that a stack trace only shows the code of the
+	// application such as resolvers.
+	SkipLibraryFrames bool
+}
+
+var stackTraceConfig atomic.Pointer[StackTraceConfig]
+
+// SetStackTraceConfig sets how stack traces are captured by the package from then
+// on. It's safe to call concurrently with error formatting but is meant to be
+// called once at startup.
+func SetStackTraceConfig(cfg StackTraceConfig) {
+	stackTraceConfig.Store(&cfg)
+}
+
+// modulePath is the import path of the module (github.com/sprucehealth/graphql).
+var modulePath = strings.TrimSuffix(reflect.TypeOf(FormattedError{}).PkgPath(), "/gqlerrors")
+
+// stackTrace returns the stack trace of the caller of the function that formats
+// an error with one function and file:line pair per frame.
+func stackTrace() string {
+	var cfg StackTraceConfig
+	if c := stackTraceConfig.Load(); c != nil {
+		cfg = *c
+	}
+	if cfg.Disabled {
+		return ""
+	}
+	maxFrames := cfg.MaxFrames
+	if maxFrames <= 0 {
+		maxFrames = DefaultStackTraceMaxFrames
+	}
+	// The library frames can't be skipped before they're known so collect more
+	// program counters than frames when they're omitted.
+	pcs := make([]uintptr, maxFrames)
+	if cfg.SkipLibraryFrames {
+		pcs = make([]uintptr, maxFrames+64)
+	}
+	// Skip runtime.Callers, stackTrace, and the formatting function
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	var b strings.Builder
+	for count := 0; count < maxFrames; {
+		frame, more := frames.Next()
+		if !cfg.SkipLibraryFrames || !isLibraryFunction(frame.Function) {
+			b.WriteString(frame.Function)
+			b.WriteString("\n\t")
+			b.WriteString(frame.File)
+			b.WriteByte(':')
+			b.WriteString(strconv.Itoa(frame.Line))
+			b.WriteByte('\n')
+			count++
+		}
+		if !more {
+			break
+		}
+	}
+	return b.String()
+}
+
+// isLibraryFunction returns true if the fully qualified function name is in a
+// package of the module. Tests of the module aren't library functions.
+func isLibraryFunction(function string) bool {
+	rest, ok := strings.CutPrefix(function, modulePath)
+	if !ok || (!strings.HasPrefix(rest, ".") && !strings.HasPrefix(rest, "/")) {
+		return false
+	}
+	return !strings.Contains(rest, "_test.")
+}
@@ -0,0 +1,63 @@
+package gqlerrors_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/gqlerrors"
+)
+
+func TestStackTraceConfig(t *testing.T) {
+	t.Cleanup(func() { gqlerrors.SetStackTraceConfig(gqlerrors.StackTraceConfig{}) })
+	errTest := errors.New("test")
+
+	trace := gqlerrors.FormatError(errTest).StackTrace
+	if !strings.HasPrefix(trace, "github.com/sprucehealth/graphql/gqlerrors_test.TestStackTraceConfig\n") {
+		t.Fatalf("Expected the trace to start at the caller, got:\n%s", trace)
+	}
+	if n := strings.Count(trace, "\n\t"); n == 0 || n > gqlerrors.DefaultStackTraceMaxFrames {
+		t.Fatalf("Expected at most %d frames, got %d", gqlerrors.DefaultStackTraceMaxFrames, n)
+	}
+
+	gqlerrors.SetStackTraceConfig(gqlerrors.StackTraceConfig{MaxFrames: 1})
+	trace = gqlerrors.FormatPanic("boom").StackTrace
+	if n := strings.Count(trace, "\n\t"); n != 1 {
+		t.Fatalf("Expected 1 frame, got:\n%s", trace)
+	}
+
+	// Frames of the library are omitted
+	gqlerrors.SetStackTraceConfig(gqlerrors.StackTraceConfig{SkipLibraryFrames: true})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"fail": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						return nil, errTest
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := graphql.Do(context.Background(), graphql.Params{Schema: schema, RequestString: `{ fail }`})
+	if len(result.Errors) != 1 {
+		t.Fatalf("Expected an error, got %+v", result)
+	}
+	trace = result.Errors[0].StackTrace
+	if strings.Contains(trace, "github.com/sprucehealth/graphql.") {
+		t.Fatalf("Expected only frames outside of the library, got:\n%s", trace)
+	}
+
+	gqlerrors.SetStackTraceConfig(gqlerrors.StackTraceConfig{Disabled: true})
+	formatted := gqlerrors.FormatError(errTest)
+	if formatted.StackTrace != "" || formatted.OriginalError != errTest {
+		t.Fatalf("Expected no stack trace and the original error, got %+v", formatted)
+	}
+}
@@ -0,0 +1,275 @@
+// Package gqlexport exports the input types and enums of a schema as JSON Schema
+// or TypeScript type definitions. It allows clients that aren't written in Go to
+// validate variables before sending them to the server.
+//
+// Output types aren't exported as their shape depends on the selection set of
+// the operation.
+package gqlexport
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sprucehealth/graphql"
+)
+
+// JSONSchemaDraft is the value of the $schema keyword of exported documents.
+const JSONSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// Config controls how types are exported.
+type Config struct {
+	// JSONScalars maps the name of a custom scalar to its JSON Schema. Custom
+	// scalars that aren't in the map accept any value.
+	JSONScalars map[string]*JSONSchema
+	// TypeScriptScalars maps the name of a custom scalar to a TypeScript type.
+	// Custom scalars that aren't in the map have the type unknown.
+	TypeScriptScalars map[string]string
+}
+
+// JSONSchema is the subset of JSON Schema used to describe GraphQL input types.
+type JSONSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Ref                  string                 `json:"$ref,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Enum                 []string               `json:"enum,omitempty"`
+	Minimum              *int64                 `json:"minimum,omitempty"`
+	Maximum              *int64                 `json:"maximum,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties *bool                  `json:"additionalProperties,omitempty"`
+	AnyOf                []*JSONSchema          `json:"anyOf,omitempty"`
+	Deprecated           bool                   `json:"deprecated,omitempty"`
+	Defs                 map[string]*JSONSchema `json:"$defs,omitempty"`
+}
+
+// DefRef returns the reference to a definition of an exported document.
+func DefRef(name string) string {
+	return "#/$defs/" + name
+}
+
+// ToJSONSchema returns a JSON Schema document with a definition under $defs for
+// every input object and enum of the schema. Nullable types accept null and
+// input fields are required only if they're non-null without a default value.
+func ToJSONSchema(schema *graphql.Schema, cfg Config) *JSONSchema {
+	doc := &JSONSchema{
+		Schema: JSONSchemaDraft,
+		Defs:   make(map[string]*JSONSchema),
+	}
+	for _, t := range inputTypes(schema) {
+		switch t := t.(type) {
+		case *graphql.Enum:
+			doc.Defs[t.Name()] = &JSONSchema{
+				Description: t.Description(),
+				Type:        "string",
+				Enum:        enumValueNames(t),
+			}
+		case *graphql.InputObject:
+			def := &JSONSchema{
+				Description:          t.Description(),
+				Type:                 "object",
+				Properties:           make(map[string]*JSONSchema),
+				AdditionalProperties: new(bool),
+			}
+			fields := t.Fields()
+			for _, name := range sortedFieldNames(fields) {
+				f := fields[name]
+				prop := jsonSchemaType(f.Type, cfg)
+				if f.PrivateDescription != "" || f.DeprecationReason != "" {
+					// Annotations next to a $ref are allowed since draft 2019-09.
+					prop.Description = f.PrivateDescription
+					prop.Deprecated = f.DeprecationReason != ""
+				}
+				def.Properties[name] = prop
+				if isRequired(f) {
+					def.Required = append(def.Required, name)
+				}
+			}
+			doc.Defs[t.Name()] = def
+		}
+	}
+	return doc
+}
+
+func jsonSchemaType(t graphql.Type, cfg Config) *JSONSchema {
+	if nn, ok := t.(*graphql.NonNull); ok {
+		return jsonSchemaNonNullType(nn.OfType, cfg)
+	}
+	s := jsonSchemaNonNullType(t, cfg)
+	if s.Type == "" && s.Ref == "" && s.AnyOf == nil && s.Enum == nil {
+		// Already accepts any value including null
+		return s
+	}
+	return &JSONSchema{AnyOf: []*JSONSchema{s, {Type: "null"}}}
+}
+
+func jsonSchemaNonNullType(t graphql.Type, cfg Config) *JSONSchema {
+	switch t := t.(type) {
+	case *graphql.List:
+		return &JSONSchema{Type: "array", Items: jsonSchemaType(t.OfType, cfg)}
+	case *graphql.Enum, *graphql.InputObject:
+		return &JSONSchema{Ref: DefRef(t.Name())}
+	case *graphql.Scalar:
+		switch t {
+		case graphql.Int:
+			minInt, maxInt := int64(math.MinInt32), int64(math.MaxInt32)
+			return &JSONSchema{Type: "integer", Minimum: &minInt, Maximum: &maxInt}
+		case graphql.Float:
+			return &JSONSchema{Type: "number"}
+		case graphql.String:
+			return &JSONSchema{Type: "string"}
+		case graphql.Boolean:
+			return &JSONSchema{Type: "boolean"}
+		case graphql.ID:
+			return &JSONSchema{AnyOf: []*JSONSchema{{Type: "string"}, {Type: "integer"}}}
+		}
+		if s, ok := cfg.JSONScalars[t.Name()]; ok {
+			// Copy so that annotations added to properties don't modify the config.
+			sc := *s
+			return &sc
+		}
+		return &JSONSchema{}
+	}
+	return &JSONSchema{}
+}
+
+// ToTypeScript returns TypeScript type definitions for every input object and
+// enum of the schema. Enums are exported as unions of string literal types so
+// that they don't require any runtime code. Input fields that may be omitted
+// are optional properties and nullable types include null.
+func ToTypeScript(schema *graphql.Schema, cfg Config) string {
+	b := &strings.Builder{}
+	for i, t := range inputTypes(schema) {
+		if i != 0 {
+			b.WriteString("\n")
+		}
+		switch t := t.(type) {
+		case *graphql.Enum:
+			writeTSDoc(b, "", t.Description(), "")
+			names := enumValueNames(t)
+			for i, name := range names {
+				names[i] = strconv.Quote(name)
+			}
+			fmt.Fprintf(b, "export type %s = %s;\n", t.Name(), strings.Join(names, " | "))
+		case *graphql.InputObject:
+			writeTSDoc(b, "", t.Description(), "")
+			fmt.Fprintf(b, "export interface %s {\n", t.Name())
+			fields := t.Fields()
+			for _, name := range sortedFieldNames(fields) {
+				f := fields[name]
+				writeTSDoc(b, "  ", f.PrivateDescription, f.DeprecationReason)
+				optional := ""
+				if !isRequired(f) {
+					optional = "?"
+				}
+				fmt.Fprintf(b, "  %s%s: %s;\n", name, optional, tsType(f.Type, cfg))
+			}
+			b.WriteString("}\n")
+		}
+	}
+	return b.String()
+}
+
+func tsType(t graphql.Type, cfg Config) string {
+	if nn, ok := t.(*graphql.NonNull); ok {
+		return tsNonNullType(nn.OfType, cfg)
+	}
+	s := tsNonNullType(t, cfg)
+	if s == "unknown" {
+		return s
+	}
+	return s + " | null"
+}
+
+func tsNonNullType(t graphql.Type, cfg Config) string {
+	switch t := t.(type) {
+	case *graphql.List:
+		return "Array<" + tsType(t.OfType, cfg) + ">"
+	case *graphql.Enum, *graphql.InputObject:
+		return t.Name()
+	case *graphql.Scalar:
+		switch t {
+		case graphql.Int, graphql.Float:
+			return "number"
+		case graphql.String:
+			return "string"
+		case graphql.Boolean:
+			return "boolean"
+		case graphql.ID:
+			return "string | number"
+		}
+		if s, ok := cfg.TypeScriptScalars[t.Name()]; ok {
+			return s
+		}
+	}
+	return "unknown"
+}
+
+func writeTSDoc(b *strings.Builder, indent, description, deprecationReason string) {
+	var lines []string
+	if description != "" {
+		lines = strings.Split(description, "\n")
+	}
+	if deprecationReason != "" {
+		lines = append(lines, "@deprecated "+deprecationReason)
+	}
+	if len(lines) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "%s/**\n", indent)
+	for _, line := range lines {
+		line = strings.ReplaceAll(line, "*/", "*\\/")
+		fmt.Fprintf(b, "%s * %s\n", indent, line)
+	}
+	fmt.Fprintf(b, "%s */\n", indent)
+}
+
+// inputTypes returns the enums and input objects of the schema sorted by name
+// excluding the types used by introspection.
+func inputTypes(schema *graphql.Schema) []graphql.Type {
+	typeMap := schema.TypeMap()
+	names := make([]string, 0, len(typeMap))
+	for name, t := range typeMap {
+		if strings.HasPrefix(name, "__") {
+			continue
+		}
+		switch t.(type) {
+		case *graphql.Enum, *graphql.InputObject:
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	types := make([]graphql.Type, len(names))
+	for i, name := range names {
+		types[i] = typeMap[name]
+	}
+	return types
+}
+
+func enumValueNames(t *graphql.Enum) []string {
+	values := t.Values()
+	names := make([]string, len(values))
+	for i, v := range values {
+		names[i] = v.Name
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sortedFieldNames(fields graphql.InputObjectFieldMap) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func isRequired(f *graphql.InputObjectField) bool {
+	_, nonNull := f.Type.(*graphql.NonNull)
+	return nonNull && f.DefaultValue == nil
+}
@@ -0,0 +1,154 @@
+package gqlexport
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+func testSchema(t *testing.T) *graphql.Schema {
+	color := graphql.NewEnum(graphql.EnumConfig{
+		Name:        "Color",
+		Description: "A color.",
+		Values: graphql.EnumValueConfigMap{
+			"RED":   &graphql.EnumValueConfig{Value: 0},
+			"GREEN": &graphql.EnumValueConfig{Value: 1},
+		},
+	})
+	date := graphql.NewScalar(graphql.ScalarConfig{
+		Name:       "Date",
+		Serialize:  func(v any) any { return v },
+		ParseValue: func(v any) any { return v },
+	})
+	input := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "PaintInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"color":  &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(color)},
+			"coats":  &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.Int), DefaultValue: 1},
+			"tags":   &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+			"date":   &graphql.InputObjectFieldConfig{Type: date},
+			"finish": &graphql.InputObjectFieldConfig{Type: graphql.String, Description: "Glossy or matte.", DeprecationReason: "Unused."},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"paint": &graphql.Field{
+					Type: graphql.Boolean,
+					Args: graphql.FieldConfigArgument{
+						"input": &graphql.ArgumentConfig{Type: input},
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &schema
+}
+
+func TestToJSONSchema(t *testing.T) {
+	doc := ToJSONSchema(testSchema(t), Config{
+		JSONScalars: map[string]*JSONSchema{"Date": {Type: "string"}},
+	})
+	b, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "$defs": {
+    "Color": {
+      "description": "A color.",
+      "type": "string",
+      "enum": [
+        "GREEN",
+        "RED"
+      ]
+    },
+    "PaintInput": {
+      "type": "object",
+      "properties": {
+        "coats": {
+          "type": "integer",
+          "minimum": -2147483648,
+          "maximum": 2147483647
+        },
+        "color": {
+          "$ref": "#/$defs/Color"
+        },
+        "date": {
+          "anyOf": [
+            {
+              "type": "string"
+            },
+            {
+              "type": "null"
+            }
+          ]
+        },
+        "finish": {
+          "description": "Glossy or matte.",
+          "anyOf": [
+            {
+              "type": "string"
+            },
+            {
+              "type": "null"
+            }
+          ],
+          "deprecated": true
+        },
+        "tags": {
+          "anyOf": [
+            {
+              "type": "array",
+              "items": {
+                "type": "string"
+              }
+            },
+            {
+              "type": "null"
+            }
+          ]
+        }
+      },
+      "required": [
+        "color"
+      ],
+      "additionalProperties": false
+    }
+  }
+}`
+	if string(b) != expected {
+		t.Fatalf("Unexpected JSON Schema, Diff: %v", testutil.Diff(expected, string(b)))
+	}
+}
+
+func TestToTypeScript(t *testing.T) {
+	ts := ToTypeScript(testSchema(t), Config{})
+	expected := `/**
+ * A color.
+ */
+export type Color = "GREEN" | "RED";
+
+export interface PaintInput {
+  coats?: number;
+  color: Color;
+  date?: unknown;
+  /**
+   * Glossy or matte.
+   * @deprecated Unused.
+   */
+  finish?: string | null;
+  tags?: Array<string> | null;
+}
+`
+	if ts != expected {
+		t.Fatalf("Unexpected TypeScript, Diff: %v", testutil.Diff(expected, ts))
+	}
+}
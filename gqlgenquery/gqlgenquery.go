@@ -0,0 +1,282 @@
+// Package gqlgenquery generates random valid GraphQL operations for a schema. It's
+// meant for fuzz style load testing of the executor and of resolvers.
+//
+// Generated operations only use literal argument values and are always valid
+// against the schema they were generated from.
+package gqlgenquery
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/astbuilder"
+	"github.com/sprucehealth/graphql/language/ast"
+	"github.com/sprucehealth/graphql/language/printer"
+)
+
+const (
+	defaultMaxDepth   = 3
+	defaultMaxFields  = 5
+	defaultMaxListLen = 3
+)
+
+// Config controls the shape of generated operations.
+type Config struct {
+	// Rand is the source of randomness. If nil a source seeded with 1 is used
+	// so that the generated operations are deterministic.
+	Rand *rand.Rand
+	// MaxDepth is the maximum nesting of selection sets. Defaults to 3.
+	MaxDepth int
+	// MaxFields is the maximum number of fields selected in a selection set. Defaults to 5.
+	MaxFields int
+	// MaxListLen is the maximum number of items in a generated list argument. Defaults to 3.
+	MaxListLen int
+	// Mutations if true generates mutation operations instead of queries.
+	Mutations bool
+	// ScalarValue if set is used to generate values for custom scalars. If it returns
+	// nil the value is omitted if possible or the field is not selected.
+	ScalarValue func(r *rand.Rand, scalar *graphql.Scalar) ast.Value
+}
+
+// Generator generates random operations for a schema.
+type Generator struct {
+	schema graphql.Schema
+	cfg    Config
+	rand   *rand.Rand
+	alias  int
+}
+
+// New returns a generator for operations against the schema.
+func New(schema graphql.Schema, cfg Config) *Generator {
+	if cfg.Rand == nil {
+		cfg.Rand = rand.New(rand.NewSource(1))
+	}
+	if cfg.MaxDepth <= 0 {
+		cfg.MaxDepth = defaultMaxDepth
+	}
+	if cfg.MaxFields <= 0 {
+		cfg.MaxFields = defaultMaxFields
+	}
+	if cfg.MaxListLen <= 0 {
+		cfg.MaxListLen = defaultMaxListLen
+	}
+	return &Generator{
+		schema: schema,
+		cfg:    cfg,
+		rand:   cfg.Rand,
+	}
+}
+
+// Document generates a document containing a single random operation.
+func (g *Generator) Document() (*ast.Document, error) {
+	operation := ast.OperationTypeQuery
+	root := g.schema.QueryType()
+	if g.cfg.Mutations {
+		operation = ast.OperationTypeMutation
+		root = g.schema.MutationType()
+		if root == nil {
+			return nil, fmt.Errorf("gqlgenquery: schema has no mutation type")
+		}
+	}
+	g.alias = 0
+	return &ast.Document{
+		Definitions: []ast.Node{
+			&ast.OperationDefinition{
+				Operation:    operation,
+				SelectionSet: g.selectionSet(root, 1),
+			},
+		},
+	}, nil
+}
+
+// Query generates a random operation and returns it as a string.
+func (g *Generator) Query() (string, error) {
+	doc, err := g.Document()
+	if err != nil {
+		return "", err
+	}
+	return printer.Print(doc), nil
+}
+
+func (g *Generator) selectionSet(parent graphql.Composite, depth int) *ast.SelectionSet {
+	ss := &ast.SelectionSet{}
+	switch parent := parent.(type) {
+	case *graphql.Object:
+		ss.Selections = g.fields(parent.Fields(), depth, false)
+	case *graphql.Interface:
+		ss.Selections = g.fields(parent.Fields(), depth, false)
+		ss.Selections = append(ss.Selections, g.inlineFragments(parent, depth)...)
+	case *graphql.Union:
+		ss.Selections = g.inlineFragments(parent, depth)
+	}
+	if len(ss.Selections) == 0 {
+		// __typename is valid on every composite type so it guarantees a non-empty selection set.
+		ss.Selections = append(ss.Selections, astbuilder.NewField("__typename").Build())
+	}
+	return ss
+}
+
+func (g *Generator) inlineFragments(parent graphql.Abstract, depth int) []ast.Selection {
+	possibleTypes := g.schema.PossibleTypes(parent)
+	var selections []ast.Selection
+	for _, pt := range possibleTypes {
+		if g.rand.Intn(2) == 0 {
+			continue
+		}
+		// Alias all fields in fragments to avoid conflicts between possible types
+		// that define the same field with different types.
+		fields := g.fields(pt.Fields(), depth, true)
+		if len(fields) == 0 {
+			continue
+		}
+		selections = append(selections, &ast.InlineFragment{
+			TypeCondition: astbuilder.Named(pt.Name()),
+			SelectionSet:  &ast.SelectionSet{Selections: fields},
+		})
+	}
+	return selections
+}
+
+func (g *Generator) fields(fieldMap graphql.FieldDefinitionMap, depth int, alias bool) []ast.Selection {
+	// Sort to keep generation deterministic for a given random source.
+	names := make([]string, 0, len(fieldMap))
+	for name := range fieldMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	g.rand.Shuffle(len(names), func(i, j int) { names[i], names[j] = names[j], names[i] })
+
+	n := 1 + g.rand.Intn(g.cfg.MaxFields)
+	var selections []ast.Selection
+	for _, name := range names {
+		if len(selections) >= n {
+			break
+		}
+		field := g.field(fieldMap[name], depth)
+		if field == nil {
+			continue
+		}
+		if alias {
+			g.alias++
+			field.Alias = &ast.Name{Value: fmt.Sprintf("%s_%d", name, g.alias)}
+		}
+		selections = append(selections, field)
+	}
+	return selections
+}
+
+func (g *Generator) field(def *graphql.FieldDefinition, depth int) *ast.Field {
+	var composite graphql.Composite
+	switch named := graphql.GetNamed(def.Type).(type) {
+	case *graphql.Object:
+		composite = named
+	case *graphql.Interface:
+		composite = named
+	case *graphql.Union:
+		composite = named
+	}
+	isComposite := composite != nil
+	if isComposite && depth >= g.cfg.MaxDepth {
+		return nil
+	}
+	field := astbuilder.NewField(def.Name).Build()
+	for _, arg := range def.Args {
+		_, required := arg.Type.(*graphql.NonNull)
+		required = required && arg.DefaultValue == nil
+		if !required && g.rand.Intn(2) == 0 {
+			continue
+		}
+		value := g.value(arg.Type, 0)
+		if value == nil {
+			if required {
+				return nil
+			}
+			continue
+		}
+		field.Arguments = append(field.Arguments, &ast.Argument{
+			Name:  &ast.Name{Value: arg.PrivateName},
+			Value: value,
+		})
+	}
+	if isComposite {
+		field.SelectionSet = g.selectionSet(composite, depth+1)
+	}
+	return field
+}
+
+// value returns a random literal for an input type or nil if one can't be generated.
+func (g *Generator) value(t graphql.Input, depth int) ast.Value {
+	switch t := t.(type) {
+	case *graphql.NonNull:
+		return g.value(t.OfType.(graphql.Input), depth)
+	case *graphql.List:
+		n := g.rand.Intn(g.cfg.MaxListLen + 1)
+		list := &ast.ListValue{Values: make([]ast.Value, 0, n)}
+		for i := 0; i < n; i++ {
+			v := g.value(t.OfType.(graphql.Input), depth)
+			if v == nil {
+				return nil
+			}
+			list.Values = append(list.Values, v)
+		}
+		return list
+	case *graphql.Enum:
+		values := t.Values()
+		if len(values) == 0 {
+			return nil
+		}
+		return &ast.EnumValue{Value: values[g.rand.Intn(len(values))].Name}
+	case *graphql.InputObject:
+		// Bound the recursion for self referencing input objects.
+		if depth >= g.cfg.MaxDepth {
+			return nil
+		}
+		fieldMap := t.Fields()
+		names := make([]string, 0, len(fieldMap))
+		for name := range fieldMap {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		obj := &ast.ObjectValue{}
+		for _, name := range names {
+			f := fieldMap[name]
+			_, required := f.Type.(*graphql.NonNull)
+			required = required && f.DefaultValue == nil
+			if !required && g.rand.Intn(2) == 0 {
+				continue
+			}
+			v := g.value(f.Type, depth+1)
+			if v == nil {
+				if required {
+					return nil
+				}
+				continue
+			}
+			obj.Fields = append(obj.Fields, &ast.ObjectField{
+				Name:  &ast.Name{Value: name},
+				Value: v,
+			})
+		}
+		return obj
+	case *graphql.Scalar:
+		switch t {
+		case graphql.Int:
+			return &ast.IntValue{Value: strconv.Itoa(g.rand.Intn(1000))}
+		case graphql.Float:
+			return &ast.FloatValue{Value: strconv.FormatFloat(g.rand.Float64()*1000, 'f', 2, 64)}
+		case graphql.String:
+			return &ast.StringValue{Value: fmt.Sprintf("s%d", g.rand.Intn(1000))}
+		case graphql.ID:
+			return &ast.StringValue{Value: strconv.Itoa(g.rand.Intn(1000))}
+		case graphql.Boolean:
+			return &ast.BooleanValue{Value: g.rand.Intn(2) == 0}
+		}
+		if g.cfg.ScalarValue != nil {
+			return g.cfg.ScalarValue(g.rand, t)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package gqlgenquery
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/language/parser"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+func TestGeneratesValidQueries(t *testing.T) {
+	for _, schema := range []*graphql.Schema{testutil.TestSchema, &testutil.StarWarsSchema} {
+		g := New(*schema, Config{Rand: rand.New(rand.NewSource(42))})
+		for i := 0; i < 200; i++ {
+			query, err := g.Query()
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Round trip through the parser to make sure the printed query is well formed.
+			doc, err := parser.Parse(parser.ParseParams{Source: query})
+			if err != nil {
+				t.Fatalf("Failed to parse generated query: %s\n%s", err, query)
+			}
+			if res := graphql.ValidateDocument(schema, doc, nil); !res.IsValid {
+				t.Fatalf("Generated invalid query: %v\n%s", res.Errors, query)
+			}
+		}
+	}
+}
+
+func TestGeneratedQueriesExecute(t *testing.T) {
+	g := New(testutil.StarWarsSchema, Config{Rand: rand.New(rand.NewSource(7)), MaxDepth: 4})
+	for i := 0; i < 50; i++ {
+		query, err := g.Query()
+		if err != nil {
+			t.Fatal(err)
+		}
+		result := graphql.Do(context.Background(), graphql.Params{
+			Schema:        testutil.StarWarsSchema,
+			RequestString: query,
+		})
+		if result.Data == nil {
+			t.Fatalf("Expected data for query %s, got errors %v", query, result.Errors)
+		}
+	}
+}
+
+func TestDeterministic(t *testing.T) {
+	q1, err := New(testutil.StarWarsSchema, Config{}).Query()
+	if err != nil {
+		t.Fatal(err)
+	}
+	q2, err := New(testutil.StarWarsSchema, Config{}).Query()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q1 != q2 {
+		t.Fatalf("Expected the same query from the same seed:\n%s\n%s", q1, q2)
+	}
+}
+
+func TestMutationsRequireMutationType(t *testing.T) {
+	if _, err := New(testutil.StarWarsSchema, Config{Mutations: true}).Query(); err == nil {
+		t.Fatal("Expected error for schema without a mutation type")
+	}
+}
@@ -0,0 +1,191 @@
+// Package gqlhttp writes the results of GraphQL requests to HTTP responses. It
+// encodes results into pooled buffers so that a server doesn't allocate an
+// encoder and a growing buffer for every request, and it optionally compresses
+// responses with gzip or deflate when the client accepts it.
+package gqlhttp
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/gqlerrors"
+)
+
+// DefaultCompressMinSize is the default value for Encoder.CompressMinSize.
+const DefaultCompressMinSize = 1024
+
+// maxPooledBufferSize is the capacity above which a buffer isn't returned to the
+// pool so that a single large response doesn't pin its memory.
+const maxPooledBufferSize = 1 << 20
+
+// Encoder writes results as JSON. The zero value writes uncompressed responses.
+// It's safe for concurrent use and must not be copied after first use.
+type Encoder struct {
+	// Compress if true compresses responses with gzip or deflate if the request
+	// accepts either of them (gzip is preferred).
+	Compress bool
+	// CompressMinSize is the minimum size in bytes of the encoded result for the
+	// response to be compressed as compressing small responses costs more than it
+	// saves. Defaults to DefaultCompressMinSize if 0.
+	CompressMinSize int
+	// CompressLevel is the level of compression from flate.BestSpeed to
+	// flate.BestCompression. Defaults to flate.DefaultCompression if 0.
+	CompressLevel int
+
+	gzipWriters sync.Pool
+	zlibWriters sync.Pool
+}
+
+// encodeBuffer is a buffer with an encoder that writes to it.
+type encodeBuffer struct {
+	buf bytes.Buffer
+	enc *json.Encoder
+}
+
+var encodeBuffers = sync.Pool{
+	New: func() any {
+		b := &encodeBuffer{}
+		b.enc = json.NewEncoder(&b.buf)
+		return b
+	},
+}
+
+// WriteResult encodes the result like json.NewEncoder(w).Encode(result) and writes
+// it to the response with a Content-Length or a Content-Encoding header. The status
+// code is 200 unless the operation was rejected because of the HTTP method (see
+// graphql.ExecuteParams.HTTPMethod) in which case it's 405 and the Allow header is
+// set. Headers that are set before it's called are kept.
+// Results that have StreamedString values should be written with graphql.WriteJSON
+// instead as they'd be buffered.
+func (e *Encoder) WriteResult(w http.ResponseWriter, r *http.Request, result *graphql.Result) error {
+	b := encodeBuffers.Get().(*encodeBuffer)
+	defer func() {
+		if b.buf.Cap() <= maxPooledBufferSize {
+			b.buf.Reset()
+			encodeBuffers.Put(b)
+		}
+	}()
+	if err := b.enc.Encode(result); err != nil {
+		return err
+	}
+
+	h := w.Header()
+	h.Set("Content-Type", "application/json")
+	status := statusCode(result)
+	if status == http.StatusMethodNotAllowed {
+		h.Set("Allow", http.MethodPost)
+	}
+	if e.Compress {
+		h.Add("Vary", "Accept-Encoding")
+		minSize := e.CompressMinSize
+		if minSize == 0 {
+			minSize = DefaultCompressMinSize
+		}
+		if b.buf.Len() >= minSize {
+			if encoding := acceptedEncoding(r.Header.Values("Accept-Encoding")); encoding != "" {
+				h.Set("Content-Encoding", encoding)
+				h.Del("Content-Length")
+				w.WriteHeader(status)
+				return e.writeCompressed(w, encoding, b.buf.Bytes())
+			}
+		}
+	}
+	h.Set("Content-Length", strconv.Itoa(b.buf.Len()))
+	w.WriteHeader(status)
+	_, err := w.Write(b.buf.Bytes())
+	return err
+}
+
+func (e *Encoder) writeCompressed(w io.Writer, encoding string, body []byte) error {
+	level := e.CompressLevel
+	if level == 0 {
+		level = flate.DefaultCompression
+	}
+	var cw interface {
+		io.WriteCloser
+		Reset(io.Writer)
+	}
+	var pool *sync.Pool
+	switch encoding {
+	case "gzip":
+		pool = &e.gzipWriters
+		if zw, ok := pool.Get().(*gzip.Writer); ok {
+			cw = zw
+		} else {
+			zw, err := gzip.NewWriterLevel(w, level)
+			if err != nil {
+				return err
+			}
+			cw = zw
+		}
+	default:
+		// The deflate content coding is the zlib format rather than raw DEFLATE
+		pool = &e.zlibWriters
+		if zw, ok := pool.Get().(*zlib.Writer); ok {
+			cw = zw
+		} else {
+			zw, err := zlib.NewWriterLevel(w, level)
+			if err != nil {
+				return err
+			}
+			cw = zw
+		}
+	}
+	cw.Reset(w)
+	defer func() {
+		cw.Reset(nil)
+		pool.Put(cw)
+	}()
+	if _, err := cw.Write(body); err != nil {
+		return err
+	}
+	return cw.Close()
+}
+
+// statusCode returns the HTTP status code of the response for the result.
+func statusCode(result *graphql.Result) int {
+	if result.Data == nil && len(result.Errors) == 1 && result.Errors[0].Type == gqlerrors.ErrorTypeMethodNotAllowed {
+		return http.StatusMethodNotAllowed
+	}
+	return http.StatusOK
+}
+
+// acceptedEncoding returns the compression to use for the values of the
+// Accept-Encoding header of a request or an empty string if neither gzip nor
+// deflate is accepted.
+func acceptedEncoding(values []string) string {
+	var gzipOK, deflateOK bool
+	for _, v := range values {
+		for _, part := range strings.Split(v, ",") {
+			name, params, _ := strings.Cut(part, ";")
+			name = strings.ToLower(strings.TrimSpace(name))
+			if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+				if f, err := strconv.ParseFloat(q, 64); err == nil && f == 0 {
+					continue
+				}
+			}
+			switch name {
+			case "gzip", "*":
+				gzipOK = true
+			case "deflate":
+				deflateOK = true
+			}
+		}
+	}
+	switch {
+	case gzipOK:
+		return "gzip"
+	case deflateOK:
+		return "deflate"
+	}
+	return ""
+}
@@ -0,0 +1,193 @@
+package gqlhttp
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/gqlerrors"
+)
+
+func testResult(n int) *graphql.Result {
+	users := make([]any, n)
+	for i := range users {
+		users[i] = map[string]any{"id": i, "name": "User <" + strings.Repeat("x", i%10) + ">"}
+	}
+	return &graphql.Result{
+		Data:   map[string]any{"users": users},
+		Errors: []gqlerrors.FormattedError{{Message: "Something went wrong"}},
+	}
+}
+
+func TestWriteResult(t *testing.T) {
+	result := testResult(100)
+	var expected bytes.Buffer
+	if err := json.NewEncoder(&expected).Encode(result); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name           string
+		compress       bool
+		minSize        int
+		acceptEncoding string
+		encoding       string
+	}{
+		{name: "no compression", acceptEncoding: "gzip"},
+		{name: "not accepted", compress: true},
+		{name: "gzip", compress: true, acceptEncoding: "deflate, gzip;q=0.5", encoding: "gzip"},
+		{name: "deflate", compress: true, acceptEncoding: "deflate, gzip;q=0", encoding: "deflate"},
+		{name: "any", compress: true, acceptEncoding: "*", encoding: "gzip"},
+		{name: "too small", compress: true, minSize: 1 << 20, acceptEncoding: "gzip"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			e := &Encoder{Compress: c.compress, CompressMinSize: c.minSize}
+			// Write twice to use pooled buffers and writers.
+			for i := 0; i < 2; i++ {
+				r := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+				if c.acceptEncoding != "" {
+					r.Header.Set("Accept-Encoding", c.acceptEncoding)
+				}
+				w := httptest.NewRecorder()
+				if err := e.WriteResult(w, r, result); err != nil {
+					t.Fatal(err)
+				}
+				if w.Code != http.StatusOK {
+					t.Fatalf("Expected status 200, got %d", w.Code)
+				}
+				if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+					t.Fatalf("Expected JSON content type, got %q", ct)
+				}
+				if enc := w.Header().Get("Content-Encoding"); enc != c.encoding {
+					t.Fatalf("Expected encoding %q, got %q", c.encoding, enc)
+				}
+				var body io.Reader = w.Body
+				switch c.encoding {
+				case "gzip":
+					zr, err := gzip.NewReader(body)
+					if err != nil {
+						t.Fatal(err)
+					}
+					body = zr
+				case "deflate":
+					zr, err := zlib.NewReader(body)
+					if err != nil {
+						t.Fatal(err)
+					}
+					body = zr
+				default:
+					if cl := w.Header().Get("Content-Length"); cl != "" && cl != "0" && w.Body.Len() != expected.Len() {
+						t.Fatalf("Expected length %d, got %s", expected.Len(), cl)
+					}
+				}
+				b, err := io.ReadAll(body)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !bytes.Equal(b, expected.Bytes()) {
+					t.Fatalf("Expected %s, got %s", expected.Bytes(), b)
+				}
+			}
+		})
+	}
+}
+
+func TestWriteResult_MethodNotAllowed(t *testing.T) {
+	result := &graphql.Result{
+		Errors: []gqlerrors.FormattedError{{
+			Type:    gqlerrors.ErrorTypeMethodNotAllowed,
+			Message: "Can only perform a mutation operation from a POST request.",
+		}},
+	}
+	w := httptest.NewRecorder()
+	if err := (&Encoder{}).WriteResult(w, httptest.NewRequest(http.MethodGet, "/graphql", nil), result); err != nil {
+		t.Fatal(err)
+	}
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("Expected status 405, got %d", w.Code)
+	}
+	if allow := w.Header().Get("Allow"); allow != http.MethodPost {
+		t.Fatalf("Expected Allow POST, got %q", allow)
+	}
+}
+
+func TestAcceptedEncoding(t *testing.T) {
+	cases := map[string]string{
+		"":                     "",
+		"identity":             "",
+		"GZIP":                 "gzip",
+		"deflate":              "deflate",
+		"br, deflate;q=1":      "deflate",
+		"gzip;q=0, deflate":    "deflate",
+		"gzip;q=0.0, *;q=0":    "",
+		"deflate, gzip;q=0.01": "gzip",
+	}
+	for header, expected := range cases {
+		if enc := acceptedEncoding([]string{header}); enc != expected {
+			t.Errorf("%q: expected %q, got %q", header, expected, enc)
+		}
+	}
+}
+
+// discardResponseWriter is a response writer that discards the body so that the
+// benchmarks only measure the encoding.
+type discardResponseWriter struct {
+	header http.Header
+}
+
+func (w *discardResponseWriter) Header() http.Header         { return w.header }
+func (w *discardResponseWriter) Write(b []byte) (int, error) { return len(b), nil }
+func (w *discardResponseWriter) WriteHeader(int)             {}
+
+func benchmarkWrite(b *testing.B, write func(w http.ResponseWriter, r *http.Request, result *graphql.Result) error) {
+	result := testResult(100)
+	r := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+	r.Header.Set("Accept-Encoding", "gzip")
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		w := &discardResponseWriter{header: make(http.Header)}
+		for pb.Next() {
+			clear(w.header)
+			if err := write(w, r, result); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkJSONEncoder(b *testing.B) {
+	benchmarkWrite(b, func(w http.ResponseWriter, r *http.Request, result *graphql.Result) error {
+		w.Header().Set("Content-Type", "application/json")
+		return json.NewEncoder(w).Encode(result)
+	})
+}
+
+func BenchmarkWriteResult(b *testing.B) {
+	e := &Encoder{}
+	benchmarkWrite(b, e.WriteResult)
+}
+
+func BenchmarkJSONEncoderGzip(b *testing.B) {
+	benchmarkWrite(b, func(w http.ResponseWriter, r *http.Request, result *graphql.Result) error {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+		if err := json.NewEncoder(zw).Encode(result); err != nil {
+			return err
+		}
+		return zw.Close()
+	})
+}
+
+func BenchmarkWriteResultGzip(b *testing.B) {
+	e := &Encoder{Compress: true}
+	benchmarkWrite(b, e.WriteResult)
+}
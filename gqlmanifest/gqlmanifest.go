@@ -0,0 +1,221 @@
+// Package gqlmanifest builds a manifest of persisted operations from GraphQL
+// operation documents. Each operation is validated against the schema and
+// identified by the SHA-256 hash of its body so that the server and clients can
+// share a single list of allowed operations.
+//
+// The manifest uses the format of the Apollo persisted query manifest.
+package gqlmanifest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/gqlerrors"
+	"github.com/sprucehealth/graphql/language/ast"
+	"github.com/sprucehealth/graphql/language/parser"
+	"github.com/sprucehealth/graphql/language/printer"
+	"github.com/sprucehealth/graphql/language/source"
+)
+
+const (
+	// Format is the value of the format field of a manifest.
+	Format = "apollo-persisted-query-manifest"
+	// Version is the version of the manifest format.
+	Version = 1
+)
+
+// Manifest is a list of persisted operations.
+type Manifest struct {
+	Format     string      `json:"format"`
+	Version    int         `json:"version"`
+	Operations []Operation `json:"operations"`
+}
+
+// Operation is a single persisted operation.
+type Operation struct {
+	// ID is the hex encoded SHA-256 hash of the body.
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Type is the operation type (query, mutation, or subscription).
+	Type string `json:"type"`
+	// Body is the printed operation followed by all fragments it uses.
+	Body string `json:"body"`
+}
+
+// Build parses the sources, validates every operation against the schema, and
+// returns a manifest of the operations sorted by name. Fragments may be defined
+// in any of the sources. All operations must be named and names must be unique.
+func Build(schema *graphql.Schema, sources ...*source.Source) (*Manifest, error) {
+	fragments := make(map[string]*ast.FragmentDefinition)
+	var operations []*ast.OperationDefinition
+	for _, src := range sources {
+		doc, err := parser.Parse(parser.ParseParams{Source: src})
+		if err != nil {
+			return nil, fmt.Errorf("gqlmanifest: failed to parse %s: %w", src.Name(), err)
+		}
+		for _, def := range doc.Definitions {
+			switch def := def.(type) {
+			case *ast.OperationDefinition:
+				if def.Name == nil || def.Name.Value == "" {
+					return nil, fmt.Errorf("gqlmanifest: anonymous operation in %s", src.Name())
+				}
+				operations = append(operations, def)
+			case *ast.FragmentDefinition:
+				if _, ok := fragments[def.Name.Value]; ok {
+					return nil, fmt.Errorf("gqlmanifest: duplicate fragment %s in %s", def.Name.Value, src.Name())
+				}
+				fragments[def.Name.Value] = def
+			}
+		}
+	}
+
+	m := &Manifest{
+		Format:     Format,
+		Version:    Version,
+		Operations: make([]Operation, 0, len(operations)),
+	}
+	names := make(map[string]struct{}, len(operations))
+	for _, op := range operations {
+		name := op.Name.Value
+		if _, ok := names[name]; ok {
+			return nil, fmt.Errorf("gqlmanifest: duplicate operation %s", name)
+		}
+		names[name] = struct{}{}
+
+		doc := &ast.Document{Definitions: []ast.Node{op}}
+		for _, f := range usedFragments(op, fragments) {
+			doc.Definitions = append(doc.Definitions, f)
+		}
+		if res := graphql.ValidateDocument(schema, doc, nil); !res.IsValid {
+			msgs := make([]string, len(res.Errors))
+			for i, e := range res.Errors {
+				msgs[i] = e.Message
+			}
+			return nil, fmt.Errorf("gqlmanifest: operation %s is invalid: %s", name, strings.Join(msgs, "; "))
+		}
+		body := printer.Print(doc)
+		m.Operations = append(m.Operations, Operation{
+			ID:   Hash(body),
+			Name: name,
+			Type: op.Operation,
+			Body: body,
+		})
+	}
+	sort.Slice(m.Operations, func(i, j int) bool {
+		return m.Operations[i].Name < m.Operations[j].Name
+	})
+	return m, nil
+}
+
+// BuildFiles reads the named files and builds a manifest from them.
+func BuildFiles(schema *graphql.Schema, paths ...string) (*Manifest, error) {
+	sources := make([]*source.Source, 0, len(paths))
+	for _, path := range paths {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("gqlmanifest: %w", err)
+		}
+		sources = append(sources, source.New(path, string(b)))
+	}
+	return Build(schema, sources...)
+}
+
+// Hash returns the ID of an operation body.
+func Hash(body string) string {
+	h := sha256.Sum256([]byte(body))
+	return hex.EncodeToString(h[:])
+}
+
+// Queries returns the operation bodies keyed by ID. It's suitable for use as a
+// safelist or a persisted query store.
+func (m *Manifest) Queries() map[string]string {
+	queries := make(map[string]string, len(m.Operations))
+	for _, op := range m.Operations {
+		queries[op.ID] = op.Body
+	}
+	return queries
+}
+
+// MarshalIndent returns the manifest as indented JSON.
+func (m *Manifest) MarshalIndent() ([]byte, error) {
+	return json.MarshalIndent(m, "", "  ")
+}
+
+// WarmError describes an operation of a manifest that isn't valid against the schema.
+type WarmError struct {
+	ID     string
+	Name   string
+	Errors []gqlerrors.FormattedError
+}
+
+func (e *WarmError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Message
+	}
+	return fmt.Sprintf("gqlmanifest: operation %s (%s) is invalid: %s", e.Name, e.ID, strings.Join(msgs, "; "))
+}
+
+// Warm parses and validates every operation of the manifest against the schema
+// and adds the valid ones to the cache. It's meant to be called at startup so
+// that the first requests don't pay for parsing and validation and so that
+// operations that are incompatible with the current schema are reported before
+// clients use them. The returned errors are the incompatible operations.
+func (m *Manifest) Warm(schema *graphql.Schema, cache graphql.DocumentCache) []*WarmError {
+	var errs []*WarmError
+	for _, op := range m.Operations {
+		if _, opErrs := graphql.PrepareDocument(schema, op.Body, cache); len(opErrs) != 0 {
+			errs = append(errs, &WarmError{ID: op.ID, Name: op.Name, Errors: opErrs})
+		}
+	}
+	return errs
+}
+
+// usedFragments returns the fragments transitively spread by the operation
+// sorted by name. Unknown fragments are left for validation to report.
+func usedFragments(op *ast.OperationDefinition, fragments map[string]*ast.FragmentDefinition) []*ast.FragmentDefinition {
+	used := make(map[string]*ast.FragmentDefinition)
+	var visit func(ss *ast.SelectionSet)
+	visit = func(ss *ast.SelectionSet) {
+		if ss == nil {
+			return
+		}
+		for _, sel := range ss.Selections {
+			switch sel := sel.(type) {
+			case *ast.Field:
+				visit(sel.SelectionSet)
+			case *ast.InlineFragment:
+				visit(sel.SelectionSet)
+			case *ast.FragmentSpread:
+				name := sel.Name.Value
+				if _, ok := used[name]; ok {
+					continue
+				}
+				f, ok := fragments[name]
+				if !ok {
+					continue
+				}
+				used[name] = f
+				visit(f.SelectionSet)
+			}
+		}
+	}
+	visit(op.SelectionSet)
+
+	names := make([]string, 0, len(used))
+	for name := range used {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	defs := make([]*ast.FragmentDefinition, len(names))
+	for i, name := range names {
+		defs[i] = used[name]
+	}
+	return defs
+}
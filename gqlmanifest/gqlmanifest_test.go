@@ -0,0 +1,100 @@
+package gqlmanifest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/language/source"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+func TestBuild(t *testing.T) {
+	m, err := Build(&testutil.StarWarsSchema,
+		source.New("hero.graphql", `
+			query Hero {
+				hero {
+					...CharacterName
+					friends { ...CharacterName }
+				}
+			}
+			query Droid { droid(id: "2001") { primaryFunction } }
+		`),
+		source.New("fragments.graphql", `
+			fragment CharacterName on Character { name }
+			fragment Other on Character { id }
+		`),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Format != Format || m.Version != Version {
+		t.Fatalf("Unexpected format %q version %d", m.Format, m.Version)
+	}
+	if len(m.Operations) != 2 {
+		t.Fatalf("Expected 2 operations, got %d", len(m.Operations))
+	}
+	droid, hero := m.Operations[0], m.Operations[1]
+	if droid.Name != "Droid" || hero.Name != "Hero" {
+		t.Fatalf("Expected operations sorted by name, got %s, %s", droid.Name, hero.Name)
+	}
+	if hero.Type != "query" {
+		t.Fatalf("Expected query, got %s", hero.Type)
+	}
+	if !strings.Contains(hero.Body, "fragment CharacterName") || strings.Contains(hero.Body, "fragment Other") {
+		t.Fatalf("Expected only used fragments in body:\n%s", hero.Body)
+	}
+	if strings.Contains(droid.Body, "fragment") {
+		t.Fatalf("Expected no fragments in body:\n%s", droid.Body)
+	}
+	if hero.ID != Hash(hero.Body) {
+		t.Fatalf("Expected ID to be the hash of the body")
+	}
+	if q := m.Queries(); q[droid.ID] != droid.Body || q[hero.ID] != hero.Body {
+		t.Fatalf("Unexpected queries %v", q)
+	}
+}
+
+func TestBuildErrors(t *testing.T) {
+	cases := map[string][]*source.Source{
+		"anonymous": {source.New("a.graphql", `{ hero { name } }`)},
+		"invalid":   {source.New("a.graphql", `query A { hero { unknown } }`)},
+		"duplicate": {
+			source.New("a.graphql", `query A { hero { name } }`),
+			source.New("b.graphql", `query A { hero { id } }`),
+		},
+		"syntax": {source.New("a.graphql", `query A {`)},
+	}
+	for name, sources := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Build(&testutil.StarWarsSchema, sources...); err == nil {
+				t.Fatal("Expected error")
+			}
+		})
+	}
+}
+
+func TestWarm(t *testing.T) {
+	m := &Manifest{
+		Format:  Format,
+		Version: Version,
+		Operations: []Operation{
+			{ID: Hash(`query A { hero { name } }`), Name: "A", Type: "query", Body: `query A { hero { name } }`},
+			{ID: Hash(`query B { hero { removed } }`), Name: "B", Type: "query", Body: `query B { hero { removed } }`},
+		},
+	}
+	cache := &graphql.MapDocumentCache{}
+	errs := m.Warm(&testutil.StarWarsSchema, cache)
+	if len(errs) != 1 || errs[0].Name != "B" || errs[0].ID != m.Operations[1].ID {
+		t.Fatalf("Expected operation B to be reported, got %v", errs)
+	}
+	if !strings.Contains(errs[0].Error(), `Cannot query field "removed"`) {
+		t.Fatalf("Unexpected error %s", errs[0])
+	}
+	if cache.Len() != 1 {
+		t.Fatalf("Expected 1 cached document, got %d", cache.Len())
+	}
+	if _, ok := cache.Get(m.Operations[0].Body); !ok {
+		t.Fatal("Expected operation A to be cached")
+	}
+}
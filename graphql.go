@@ -2,17 +2,26 @@ package graphql
 
 import (
 	"context"
+	"io"
+	"time"
 
+	"github.com/sprucehealth/graphql/astbuilder"
 	"github.com/sprucehealth/graphql/gqlerrors"
 	"github.com/sprucehealth/graphql/language/ast"
 	"github.com/sprucehealth/graphql/language/parser"
-	"github.com/sprucehealth/graphql/language/source"
 )
 
 type Params struct {
 	// Schema is the GraphQL type system to use when validating and executing a query.
 	Schema Schema
 
+	// SchemaResolver if set returns the schema to use for the request instead of
+	// Schema (e.g. the variant of the schema for the tenant of the request). The
+	// request fails with the returned error if it can't be resolved. When set the
+	// DocumentCache is keyed by the hash of the resolved schema so that it can be
+	// shared by the variants. See SchemaVariants.
+	SchemaResolver SchemaResolverFn
+
 	// RequestString is a GraphQL language formatted string representing the requested operation.
 	RequestString string
 
@@ -24,6 +33,14 @@ type Params struct {
 	// defined in the requestString.
 	VariableValues map[string]any
 
+	// VariablesJSON if set is decoded as the variable values instead of using VariableValues.
+	// It lets an HTTP handler pass the encoded variables through without decoding them
+	// itself. Numbers are decoded as json.Number and VariablesLimits are enforced.
+	VariablesJSON io.Reader
+
+	// VariablesLimits are the limits applied when decoding VariablesJSON.
+	VariablesLimits VariablesLimits
+
 	// OperationName is the name of the operation to use if requestString contains multiple
 	// possible operations. Can be omitted if requestString contains only
 	// one operation.
@@ -31,32 +48,338 @@ type Params struct {
 
 	// Tracer if set is called after each invocation of a custom resolver with the duration.
 	Tracer Tracer
+
+	// FoldConstantConditionals if true removes selections that are statically excluded by
+	// @skip or @include directives with literal arguments before execution. A warning
+	// for each removed selection is returned in the result extensions under
+	// FoldedSelectionsExtensionKey.
+	FoldConstantConditionals bool
+
+	// RestrictedTypes is a list of object type names that may not be returned for
+	// an interface or union in this request.
+	RestrictedTypes []string
+
+	// UseJSONNumber if true provides numeric variable values to scalars as json.Number
+	// as if the variables had been decoded with json.Decoder.UseNumber.
+	UseJSONNumber bool
+
+	// Explain if true returns an ordered log of execution events in the result extensions.
+	Explain bool
+
+	// OrderedData if true builds objects in the result data as OrderedMap in the requested field order.
+	OrderedData bool
+
+	// CacheControl if true computes the cache policy of the response from @cacheControl hints.
+	CacheControl bool
+
+	// CacheControlDefaultMaxAge is the max age for root and composite fields without a cache hint.
+	CacheControlDefaultMaxAge int
+
+	// KeepComments if true keeps comments when parsing the request so that the comments
+	// preceding the operation and fields are available through ResolveInfo.
+	KeepComments bool
+
+	// ClientControlledNullability if true enables the experimental `field!` and `field?`
+	// syntax. A field followed by `!` is treated as non-null so a null value or error
+	// propagates to its parent, and a field followed by `?` is treated as nullable so
+	// an error stops at the field.
+	ClientControlledNullability bool
+
+	// VariableWidthUnicodeEscapes if true accepts `\u{...}` escape sequences (e.g.
+	// "\u{1F600}") in strings of the request in addition to `\uXXXX`.
+	VariableWidthUnicodeEscapes bool
+
+	// Deterministic if true resolves fields in the requested order so that resolver calls
+	// and errors are in the same order on every run.
+	Deterministic bool
+
+	// DocumentRewriter if set is called with the parsed request before it's validated
+	// and executed and the returned document is used instead.
+	DocumentRewriter DocumentRewriterFn
+
+	// SchemaRouter if set selects the schema to validate and execute the request
+	// with from its operation instead of using Schema. It's applied before the
+	// DocumentRewriter.
+	SchemaRouter *SchemaRouter
+
+	// OperationHook if set is called with the selected operation before it's executed.
+	OperationHook OperationHookFn
+
+	// RateLimiter if set is consulted before the operation is executed. A request that
+	// isn't allowed fails with a RESOURCE_EXHAUSTED error.
+	RateLimiter RateLimiter
+
+	// DeprecationWarnings if true lists the deprecated fields, arguments, and enum values
+	// used by the request in the result extensions.
+	DeprecationWarnings bool
+
+	// MaxErrors is the maximum number of field errors in the result. Defaults to
+	// DefaultMaxErrors if 0 and a negative value disables the limit.
+	MaxErrors int
+
+	// FieldErrorsAsError if set makes DoE return an error instead of a result with
+	// partial data if any field failed.
+	FieldErrorsAsError bool
+
+	// DocumentCache if set is used to skip parsing and validation of requests that
+	// have been seen before. It's not used when KeepComments, FoldConstantConditionals,
+	// ClientControlledNullability, VariableWidthUnicodeEscapes, DocumentRewriter, or
+	// SchemaRouter is set as those change how the document is parsed or modify it.
+	DocumentCache DocumentCache
+
+	// FragmentLibrary if set provides the fragments spread by the request that it
+	// doesn't define (persisted fragments shared by the operations of a client).
+	FragmentLibrary *FragmentLibrary
+
+	// Capture if set records the request and the outcomes of resolvers for Replay.
+	Capture *CapturePolicy
+
+	// MaxExpandedSelections if greater than 0 is the maximum number of fields an operation
+	// may select once its fragments are expanded. It's checked before validation.
+	MaxExpandedSelections int
+
+	// Timeout if greater than 0 is the maximum time to execute the operation regardless
+	// of the deadline of the context. See ExecuteParams.Timeout.
+	Timeout time.Duration
+
+	// Logger if set receives warnings about anomalies during execution. Defaults to
+	// the logger of the schema.
+	Logger Logger
+
+	// MaxResultBytes if greater than 0 is the approximate maximum size of the result
+	// data. See ExecuteParams.MaxResultBytes.
+	MaxResultBytes int
+
+	// StrictJSON if true completes values the way graphql-js does. Results should be
+	// encoded with MarshalStrictJSON. See ExecuteParams.StrictJSON.
+	StrictJSON bool
+
+	// MaxAliasesPerField if greater than 0 is the maximum number of aliases of a field
+	// in a selection set. See ExecuteParams.MaxAliasesPerField.
+	MaxAliasesPerField int
+
+	// DedupeAliasedFields if true resolves identical aliased selections of a field
+	// once. See ExecuteParams.DedupeAliasedFields.
+	DedupeAliasedFields bool
+
+	// OperationLog if set logs every request including requests that fail to parse
+	// or validate. See ExecuteParams.OperationLog.
+	OperationLog *OperationLogPolicy
+
+	// ExecutionStrategies if set are the strategies used to execute fields keyed by
+	// operation type. See ExecuteParams.ExecutionStrategies.
+	ExecutionStrategies map[string]ExecutionStrategy
+
+	// StreamStrings if set completes String fields that resolve to an io.Reader to a
+	// *StreamedString. Results should be encoded with WriteJSON. See
+	// ExecuteParams.StreamStrings.
+	StreamStrings StreamEncoding
+
+	// GroupErrors if true collapses field errors that only differ by their list
+	// index into one error with their number and sample paths in its extensions.
+	// See ExecuteParams.GroupErrors.
+	GroupErrors bool
+
+	// StrictVariables if true fails the request with an error for each variable value
+	// that isn't defined by the operation. See ExecuteParams.StrictVariables.
+	StrictVariables bool
+
+	// HTTPMethod is the method of the HTTP request that carries the operation.
+	// Operations other than queries fail if it's GET. See ExecuteParams.HTTPMethod.
+	HTTPMethod string
+
+	// AllowMutationsOverGET if true executes mutations and subscriptions sent with
+	// GET for legacy clients.
+	AllowMutationsOverGET bool
+
+	// Checkpoint if set is called with a snapshot of the progress of the operation
+	// every time a root field completes. See ExecuteParams.Checkpoint.
+	Checkpoint CheckpointFn
+
+	// Analysis if set computes the complexity and depth of the operation and reports
+	// them without rejecting the operation. See ExecuteParams.Analysis.
+	Analysis *AnalysisPolicy
+
+	// ExtensionMergers are the functions that merge the values added by resolvers
+	// with AddExtension keyed by extension key. See AddExtension.
+	ExtensionMergers map[string]ExtensionMergeFn
+
+	// Deduplicator if set shares the execution of identical concurrent read-only
+	// queries. See ExecuteParams.Deduplicator.
+	Deduplicator *RequestDeduplicator
+
+	// ResolverOverrides are resolvers keyed by "Type.field" that are used instead
+	// of the resolvers of the schema for this request. See ExecuteParams.ResolverOverrides.
+	ResolverOverrides map[string]FieldResolveFn
+
+	// GoroutineAudit if set reports the goroutines started for the request that are
+	// still running after it completes. See ExecuteParams.GoroutineAudit.
+	GoroutineAudit *GoroutineAudit
+
+	// ShareArgs if true passes argument values to resolvers without copying them.
+	// See ExecuteParams.ShareArgs.
+	ShareArgs bool
+
+	// Masking if set masks the values of fields in the result by policy. See
+	// ExecuteParams.Masking.
+	Masking *MaskingPolicy
+
+	// Sunset if set enforces the sunset dates of deprecated fields. See
+	// ExecuteParams.Sunset.
+	Sunset *SunsetPolicy
+
+	// FieldHook if set is called before each field is resolved. See
+	// ExecuteParams.FieldHook.
+	FieldHook FieldHookFn
 }
 
 func Do(ctx context.Context, p Params) *Result {
-	source := source.New("GraphQL request", p.RequestString)
-	ast, err := parser.Parse(parser.ParseParams{Source: source})
-	if err != nil {
-		return &Result{
-			Errors: gqlerrors.FormatErrors(err),
+	result, _ := DoWithDocument(ctx, p)
+	return result
+}
+
+// RequestDocument is the parsed request returned by DoWithDocument.
+type RequestDocument struct {
+	// Document is the parsed request after DocumentRewriter and
+	// FoldConstantConditionals are applied. It's nil if the variables couldn't be
+	// decoded or the request couldn't be parsed. It may be shared with the
+	// DocumentCache so it must not be modified.
+	Document *ast.Document
+	// Valid is true if the document passed validation and was executed.
+	Valid bool
+}
+
+// DoWithDocument is like Do but also returns the parsed request so that callers
+// can log its signature, compute its complexity, or cache it without parsing it
+// again.
+func DoWithDocument(ctx context.Context, p Params) (*Result, RequestDocument) {
+	start := time.Now()
+	doc, warnings, result := p.prepare(ctx)
+	if result != nil {
+		if p.OperationLog != nil {
+			logOperation(ctx, p.OperationLog, nil, p.VariableValues, result, time.Since(start))
 		}
+		return result, RequestDocument{Document: doc}
 	}
-	validationResult := ValidateDocument(&p.Schema, ast, nil)
+	result = Execute(ctx, p.executeParams(doc))
+	if len(warnings) != 0 {
+		if result.Extensions == nil {
+			result.Extensions = make(map[string]any)
+		}
+		result.Extensions[FoldedSelectionsExtensionKey] = warnings
+	}
+	return result, RequestDocument{Document: doc, Valid: true}
+}
 
-	if !validationResult.IsValid {
-		return &Result{
-			Errors: validationResult.Errors,
+// prepare decodes the variables and parses and validates the request returning
+// a result with the errors if it can't be executed. The document is returned
+// with the errors if it was parsed but isn't valid. The warnings are those of
+// FoldConstantConditionals.
+func (p *Params) prepare(ctx context.Context) (*ast.Document, []gqlerrors.FormattedError, *Result) {
+	if p.SchemaResolver != nil {
+		schema, err := p.SchemaResolver(ctx)
+		if err != nil {
+			return nil, nil, &Result{
+				Errors: gqlerrors.FormatErrors(err),
+			}
+		}
+		p.Schema = *schema
+		if p.DocumentCache != nil {
+			p.DocumentCache = schemaDocumentCache{cache: p.DocumentCache, hash: schema.Hash()}
 		}
 	}
+	if p.VariablesJSON != nil {
+		vars, err := DecodeVariables(p.VariablesJSON, p.VariablesLimits)
+		if err != nil {
+			return nil, nil, &Result{
+				Errors: gqlerrors.FormatErrors(gqlerrors.NewError(gqlerrors.ErrorTypeInvalidInput, err.Error(), nil, "", nil, nil, err)),
+			}
+		}
+		p.VariableValues = vars
+	}
+	rewrite := p.DocumentRewriter
+	if p.SchemaRouter != nil {
+		rewrite = p.SchemaRouter.rewriter(&p.Schema, p.OperationName, rewrite)
+	}
+	var doc *ast.Document
+	var errs []gqlerrors.FormattedError
+	if p.DocumentCache != nil && !p.KeepComments && !p.FoldConstantConditionals && !p.ClientControlledNullability && !p.VariableWidthUnicodeEscapes && rewrite == nil {
+		doc, errs = prepareDocument(&p.Schema, p.RequestString, p.DocumentCache, p.FragmentLibrary, p.MaxExpandedSelections)
+	} else {
+		if p.FragmentLibrary != nil {
+			// The fragments are copied as the document may be modified
+			rewrite = p.FragmentLibrary.rewriter(rewrite)
+		}
+		opts := parser.ParseOptions{
+			KeepComments:                p.KeepComments,
+			ClientControlledNullability: p.ClientControlledNullability,
+			VariableWidthUnicodeEscapes: p.VariableWidthUnicodeEscapes,
+		}
+		doc, errs = parseRewriteAndValidate(ctx, &p.Schema, p.RequestString, opts, rewrite, p.MaxExpandedSelections)
+	}
+	if len(errs) != 0 {
+		return doc, nil, &Result{
+			Errors: errs,
+		}
+	}
+
+	var warnings []gqlerrors.FormattedError
+	if p.FoldConstantConditionals {
+		warnings = FoldConstantConditionals(doc)
+	}
+	return doc, warnings, nil
+}
 
-	return Execute(ctx, ExecuteParams{
-		Schema:        p.Schema,
-		Root:          p.RootObject,
-		AST:           ast,
-		OperationName: p.OperationName,
-		Args:          p.VariableValues,
-		Tracer:        p.Tracer,
-	})
+// executeParams returns the parameters to execute the parsed request.
+func (p *Params) executeParams(doc *ast.Document) ExecuteParams {
+	ep := ExecuteParams{
+		Schema:                    p.Schema,
+		AST:                       doc,
+		OperationName:             p.OperationName,
+		Args:                      p.VariableValues,
+		Tracer:                    p.Tracer,
+		RestrictedTypes:           p.RestrictedTypes,
+		UseJSONNumber:             p.UseJSONNumber,
+		Explain:                   p.Explain,
+		OrderedData:               p.OrderedData,
+		CacheControl:              p.CacheControl,
+		CacheControlDefaultMaxAge: p.CacheControlDefaultMaxAge,
+		Deterministic:             p.Deterministic,
+		OperationHook:             p.OperationHook,
+		RateLimiter:               p.RateLimiter,
+		DeprecationWarnings:       p.DeprecationWarnings,
+		MaxErrors:                 p.MaxErrors,
+		Capture:                   p.Capture,
+		MaxExpandedSelections:     p.MaxExpandedSelections,
+		Timeout:                   p.Timeout,
+		Logger:                    p.Logger,
+		MaxResultBytes:            p.MaxResultBytes,
+		StrictJSON:                p.StrictJSON,
+		MaxAliasesPerField:        p.MaxAliasesPerField,
+		DedupeAliasedFields:       p.DedupeAliasedFields,
+		OperationLog:              p.OperationLog,
+		ExecutionStrategies:       p.ExecutionStrategies,
+		StreamStrings:             p.StreamStrings,
+		GroupErrors:               p.GroupErrors,
+		StrictVariables:           p.StrictVariables,
+		HTTPMethod:                p.HTTPMethod,
+		AllowMutationsOverGET:     p.AllowMutationsOverGET,
+		Checkpoint:                p.Checkpoint,
+		Analysis:                  p.Analysis,
+		ExtensionMergers:          p.ExtensionMergers,
+		Deduplicator:              p.Deduplicator,
+		ResolverOverrides:         p.ResolverOverrides,
+		GoroutineAudit:            p.GoroutineAudit,
+		ShareArgs:                 p.ShareArgs,
+		Masking:                   p.Masking,
+		Sunset:                    p.Sunset,
+		FieldHook:                 p.FieldHook,
+	}
+	// A nil map would be a non-nil root value
+	if p.RootObject != nil {
+		ep.Root = p.RootObject
+	}
+	return ep
 }
 
 // RequestTypeNames rewrites an ast document to include __typename
@@ -74,11 +397,7 @@ func requestTypeNamesInSelectionSet(ss *ast.SelectionSet) {
 	if ss == nil {
 		return
 	}
-	ss.Selections = append(ss.Selections, &ast.Field{
-		Name: &ast.Name{
-			Value: "__typename",
-		},
-	})
+	ss.Selections = append(ss.Selections, astbuilder.NewField("__typename").Build())
 	for _, selections := range ss.Selections {
 		requestTypeNamesInSelectionSet(selections.GetSelectionSet())
 	}
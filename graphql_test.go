@@ -9,6 +9,7 @@ import (
 	"context"
 
 	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/language/ast"
 	"github.com/sprucehealth/graphql/testutil"
 )
 
@@ -334,3 +335,46 @@ func TestTracing(t *testing.T) {
 		tr.Recycle()
 	}
 }
+
+func TestDoWithDocument(t *testing.T) {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"a": &graphql.Field{Type: graphql.String},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		query    string
+		parsed   bool
+		valid    bool
+		hasError bool
+	}{
+		{query: `query Q { a }`, parsed: true, valid: true},
+		{query: `query Q { b }`, parsed: true, hasError: true},
+		{query: `query Q {`, hasError: true},
+	}
+	for _, c := range cases {
+		result, doc := graphql.DoWithDocument(context.Background(), graphql.Params{
+			Schema:        schema,
+			RequestString: c.query,
+			RootObject:    map[string]any{"a": "a"},
+		})
+		if (len(result.Errors) != 0) != c.hasError {
+			t.Fatalf("%s: unexpected errors %v", c.query, result.Errors)
+		}
+		if (doc.Document != nil) != c.parsed || doc.Valid != c.valid {
+			t.Fatalf("%s: unexpected document %+v", c.query, doc)
+		}
+		if doc.Document != nil {
+			if name := doc.Document.Definitions[0].(*ast.OperationDefinition).Name.Value; name != "Q" {
+				t.Fatalf("%s: unexpected operation name %q", c.query, name)
+			}
+		}
+	}
+}
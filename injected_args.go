@@ -0,0 +1,55 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sprucehealth/graphql/gqlerrors"
+)
+
+// ArgumentInjectorFn returns the value of an injected argument from the context
+// (e.g. the organization ID of the authenticated account). Returning an error
+// fails the field with the error.
+type ArgumentInjectorFn func(ctx context.Context) (any, error)
+
+// injectedArgument is an argument of a field that's provided by an injector.
+type injectedArgument struct {
+	name     string
+	injector string
+}
+
+// checkArgumentInjectors returns an error if an injected argument of a field uses
+// an injector that isn't registered.
+func checkArgumentInjectors(typeMap TypeMap, injectors map[string]ArgumentInjectorFn) error {
+	for _, typeName := range sortedKeys(typeMap) {
+		ttype, ok := typeMap[typeName].(*Object)
+		if !ok {
+			continue
+		}
+		fields := ttype.Fields()
+		for _, fieldName := range sortedKeys(fields) {
+			for _, arg := range fields[fieldName].injectedArgs {
+				if _, ok := injectors[arg.injector]; !ok {
+					return gqlerrors.NewFormattedError(fmt.Sprintf(`%v.%v(%v:) uses unknown argument injector "%v".`, typeName, fieldName, arg.name, arg.injector))
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// injectArguments returns the arguments with the values of the injected arguments
+// replacing any value of the same name. Errors are raised as panics.
+func injectArguments(ctx context.Context, injectors map[string]ArgumentInjectorFn, injected []injectedArgument, args map[string]any) map[string]any {
+	if args == nil {
+		args = make(map[string]any, len(injected))
+	}
+	for _, arg := range injected {
+		v, err := injectors[arg.injector](ctx)
+		if err != nil {
+			panic(gqlerrors.FormatError(err))
+		}
+		args[arg.name] = v
+	}
+	return args
+}
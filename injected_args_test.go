@@ -0,0 +1,123 @@
+package graphql_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+type testOrganizationKey struct{}
+
+func TestInjectedArguments(t *testing.T) {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"patients": &graphql.Field{
+					Type: graphql.String,
+					Args: graphql.FieldConfigArgument{
+						"organizationID": &graphql.ArgumentConfig{Injected: "organization"},
+						"first":          &graphql.ArgumentConfig{Type: graphql.Int},
+					},
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						return fmt.Sprintf("%v %v", p.Args["organizationID"], p.Args["first"]), nil
+					},
+				},
+			},
+		}),
+		ArgumentInjectors: map[string]graphql.ArgumentInjectorFn{
+			"organization": func(ctx context.Context) (any, error) {
+				if id, ok := ctx.Value(testOrganizationKey{}).(string); ok {
+					return id, nil
+				}
+				return nil, errors.New("Not authenticated.")
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authCtx := context.WithValue(context.Background(), testOrganizationKey{}, "org1")
+	cases := []struct {
+		name     string
+		ctx      context.Context
+		query    string
+		expected any
+		errors   []string
+	}{
+		{
+			name:     "injected",
+			ctx:      authCtx,
+			query:    `{ patients(first: 2) }`,
+			expected: map[string]any{"patients": "org1 2"},
+		},
+		{
+			name:     "injector error",
+			ctx:      context.Background(),
+			query:    `{ patients }`,
+			expected: map[string]any{"patients": nil},
+			errors:   []string{"Not authenticated."},
+		},
+		{
+			name:   "provided by client",
+			ctx:    authCtx,
+			query:  `{ patients(organizationID: "org2") }`,
+			errors: []string{`Unknown argument "organizationID" on field "patients" of type "Query".`},
+		},
+		{
+			name:     "not introspected",
+			ctx:      authCtx,
+			query:    `{ __type(name: "Query") { fields { args { name } } } }`,
+			expected: map[string]any{"__type": map[string]any{"fields": []any{map[string]any{"args": []any{map[string]any{"name": "first"}}}}}},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			result := graphql.Do(c.ctx, graphql.Params{
+				Schema:        schema,
+				RequestString: c.query,
+			})
+			var errs []string
+			for _, e := range result.Errors {
+				errs = append(errs, e.Message)
+			}
+			if !reflect.DeepEqual(errs, c.errors) {
+				t.Fatalf("Unexpected errors: %v", errs)
+			}
+			if c.expected != nil && !reflect.DeepEqual(result.Data, c.expected) {
+				t.Fatal(testutil.Diff(c.expected, result.Data))
+			}
+		})
+	}
+
+	if s := graphql.PrintSchema(&schema); strings.Contains(s, "organizationID") {
+		t.Fatalf("Injected argument is printed:\n%s", s)
+	}
+}
+
+func TestInjectedArguments_UnknownInjector(t *testing.T) {
+	_, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"patients": &graphql.Field{
+					Type: graphql.String,
+					Args: graphql.FieldConfigArgument{
+						"organizationID": &graphql.ArgumentConfig{Injected: "organization"},
+					},
+				},
+			},
+		}),
+	})
+	expected := `Query.patients(organizationID:) uses unknown argument injector "organization".`
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected error %q, got %v", expected, err)
+	}
+}
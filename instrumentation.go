@@ -0,0 +1,38 @@
+package graphql
+
+import (
+	"context"
+)
+
+// ResolverInstrumentation is notified of calls to the resolvers generated by
+// graphql2go with resolver instrumentation enabled (e.g. to record per resolver
+// metrics or trace spans). It's set on the context of a request with
+// WithResolverInstrumentation.
+type ResolverInstrumentation interface {
+	// StartResolver is called before the resolver of the field is called with the
+	// field as "Type.field". The returned context is passed to the resolver and the
+	// returned function is called with the error returned by the resolver once it
+	// returns.
+	StartResolver(ctx context.Context, field string) (context.Context, func(err error))
+}
+
+type resolverInstrumentationKey struct{}
+
+// WithResolverInstrumentation returns a context that notifies the instrumentation
+// of calls to generated resolvers.
+func WithResolverInstrumentation(ctx context.Context, instrumentation ResolverInstrumentation) context.Context {
+	return context.WithValue(ctx, resolverInstrumentationKey{}, instrumentation)
+}
+
+// StartResolver notifies the instrumentation of the context that the resolver of
+// the field is called. The returned function must be called with the error
+// returned by the resolver. It does nothing if the context has no instrumentation.
+func StartResolver(ctx context.Context, field string) (context.Context, func(err error)) {
+	instrumentation, ok := ctx.Value(resolverInstrumentationKey{}).(ResolverInstrumentation)
+	if !ok || instrumentation == nil {
+		return ctx, endResolverNoop
+	}
+	return instrumentation.StartResolver(ctx, field)
+}
+
+func endResolverNoop(error) {}
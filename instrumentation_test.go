@@ -0,0 +1,42 @@
+package graphql_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+)
+
+type testResolverInstrumentation struct {
+	started []string
+	errs    []error
+}
+
+func (i *testResolverInstrumentation) StartResolver(ctx context.Context, field string) (context.Context, func(error)) {
+	i.started = append(i.started, field)
+	return ctx, func(err error) {
+		i.errs = append(i.errs, err)
+	}
+}
+
+func TestStartResolver(t *testing.T) {
+	// No instrumentation
+	ctx, end := graphql.StartResolver(context.Background(), "User.name")
+	if ctx == nil || end == nil {
+		t.Fatal("Expected a context and end function")
+	}
+	end(nil)
+
+	instrumentation := &testResolverInstrumentation{}
+	ctx = graphql.WithResolverInstrumentation(context.Background(), instrumentation)
+	_, end = graphql.StartResolver(ctx, "User.name")
+	errFailed := errors.New("failed")
+	end(errFailed)
+	if len(instrumentation.started) != 1 || instrumentation.started[0] != "User.name" {
+		t.Fatalf("Unexpected started fields %v", instrumentation.started)
+	}
+	if len(instrumentation.errs) != 1 || instrumentation.errs[0] != errFailed {
+		t.Fatalf("Unexpected errors %v", instrumentation.errs)
+	}
+}
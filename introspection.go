@@ -11,6 +11,12 @@ import (
 	"github.com/sprucehealth/graphql/language/printer"
 )
 
+// Introspection results are ordered so that snapshots of the introspection of a
+// schema only differ when the schema does: types, fields, arguments, input
+// fields, enum values, and the implementations of interfaces are sorted by name
+// as they're configured with maps, while the interfaces of objects, the members
+// of unions, and directives keep the order in which they're configured.
+
 const (
 	TypeKindScalar      = "SCALAR"
 	TypeKindObject      = "OBJECT"
@@ -57,6 +63,10 @@ var TypeMetaFieldDef *FieldDefinition
 // TypeNameMetaFieldDef Meta field definition for type names
 var TypeNameMetaFieldDef *FieldDefinition
 
+// SchemaVersionMetaFieldDef Meta field definition for the schema version which
+// is only available when enabled in the schema config.
+var SchemaVersionMetaFieldDef *FieldDefinition
+
 func init() {
 	TypeKindEnumType = NewEnum(EnumConfig{
 		Name:        "__TypeKind",
@@ -227,6 +237,15 @@ func init() {
 			"description": &Field{
 				Type: String,
 			},
+			"specifiedByURL": &Field{
+				Type: String,
+				Resolve: func(ctx context.Context, p ResolveParams) (any, error) {
+					if scalar, ok := p.Source.(*Scalar); ok && scalar.SpecifiedByURL() != "" {
+						return scalar.SpecifiedByURL(), nil
+					}
+					return nil, nil
+				},
+			},
 			"fields":        &Field{},
 			"interfaces":    &Field{},
 			"possibleTypes": &Field{},
@@ -276,6 +295,21 @@ func init() {
 					return nil, nil
 				},
 			},
+			"isDeprecated": &Field{
+				Type: NewNonNull(Boolean),
+				Resolve: func(ctx context.Context, p ResolveParams) (any, error) {
+					return inputValueDeprecationReason(p.Source) != "", nil
+				},
+			},
+			"deprecationReason": &Field{
+				Type: String,
+				Resolve: func(ctx context.Context, p ResolveParams) (any, error) {
+					if reason := inputValueDeprecationReason(p.Source); reason != "" {
+						return reason, nil
+					}
+					return nil, nil
+				},
+			},
 		},
 	})
 
@@ -292,13 +326,16 @@ func init() {
 			},
 			"args": &Field{
 				Type: NewNonNull(NewList(NewNonNull(InputValueType))),
+				Args: FieldConfigArgument{
+					"includeDeprecated": &ArgumentConfig{
+						Type:         Boolean,
+						DefaultValue: false,
+					},
+				},
 				Resolve: func(ctx context.Context, p ResolveParams) (any, error) {
 					if field, ok := p.Source.(*FieldDefinition); ok {
-						args := append([]*Argument(nil), field.Args...)
-						sort.Slice(args, func(i, j int) bool {
-							return args[i].Name() < args[j].Name()
-						})
-						return args, nil
+						includeDeprecated, _ := p.Args["includeDeprecated"].(bool)
+						return introspectionArgs(field.Args, includeDeprecated), nil
 					}
 					return []any{}, nil
 				},
@@ -345,6 +382,22 @@ func init() {
 				Type: NewNonNull(NewList(
 					NewNonNull(InputValueType),
 				)),
+				Args: FieldConfigArgument{
+					"includeDeprecated": &ArgumentConfig{
+						Type:         Boolean,
+						DefaultValue: false,
+					},
+				},
+				Resolve: func(ctx context.Context, p ResolveParams) (any, error) {
+					if dir, ok := p.Source.(*Directive); ok {
+						includeDeprecated, _ := p.Args["includeDeprecated"].(bool)
+						return introspectionArgs(dir.Args, includeDeprecated), nil
+					}
+					return []any{}, nil
+				},
+			},
+			"isRepeatable": &Field{
+				Type: NewNonNull(Boolean),
 			},
 			// NOTE: the following three fields are deprecated and are no longer part
 			// of the GraphQL specification.
@@ -412,6 +465,15 @@ func init() {
 			`It exposes all available types and directives on the server, as well as ` +
 			`the entry points for query, mutation, and subscription operations.`,
 		Fields: Fields{
+			"description": &Field{
+				Type: String,
+				Resolve: func(ctx context.Context, p ResolveParams) (any, error) {
+					if schema, ok := p.Source.(Schema); ok && schema.Description() != "" {
+						return schema.Description(), nil
+					}
+					return nil, nil
+				},
+			},
 			"types": &Field{
 				Description: "A list of all types supported by this server.",
 				Type: NewNonNull(NewList(
@@ -528,7 +590,7 @@ func init() {
 				}
 				var fields []*FieldDefinition
 				for _, field := range ttype.Fields() {
-					if !includeDeprecated && field.DeprecationReason != "" {
+					if !includeDeprecated && field.DeprecationReason != "" || !field.IsVisible(ctx) {
 						continue
 					}
 					fields = append(fields, field)
@@ -543,7 +605,7 @@ func init() {
 				}
 				var fields []*FieldDefinition
 				for _, field := range ttype.Fields() {
-					if !includeDeprecated && field.DeprecationReason != "" {
+					if !includeDeprecated && field.DeprecationReason != "" || !field.IsVisible(ctx) {
 						continue
 					}
 					fields = append(fields, field)
@@ -591,10 +653,11 @@ func init() {
 			switch ttype := p.Source.(type) {
 			case *Enum:
 				if includeDeprecated {
-					return ttype.Values(), nil
+					// Values are sorted by name when the enum is defined.
+					return visibleEnumValues(ctx, ttype.Values()), nil
 				}
 				values := []*EnumValueDefinition{}
-				for _, value := range ttype.Values() {
+				for _, value := range visibleEnumValues(ctx, ttype.Values()) {
 					if value.DeprecationReason != "" {
 						continue
 					}
@@ -610,11 +673,21 @@ func init() {
 	})
 	TypeType.AddFieldConfig("inputFields", &Field{
 		Type: NewList(NewNonNull(InputValueType)),
+		Args: FieldConfigArgument{
+			"includeDeprecated": &ArgumentConfig{
+				Type:         Boolean,
+				DefaultValue: false,
+			},
+		},
 		Resolve: func(ctx context.Context, p ResolveParams) (any, error) {
+			includeDeprecated, _ := p.Args["includeDeprecated"].(bool)
 			switch ttype := p.Source.(type) {
 			case *InputObject:
 				fields := []*InputObjectField{}
 				for _, field := range ttype.Fields() {
+					if !includeDeprecated && field.DeprecationReason != "" {
+						continue
+					}
 					fields = append(fields, field)
 				}
 				sort.Slice(fields, func(i, j int) bool {
@@ -669,6 +742,42 @@ func init() {
 		},
 	}
 
+	SchemaVersionMetaFieldDef = &FieldDefinition{
+		Name:        "_schemaVersion",
+		Type:        NewNonNull(String),
+		Description: "A stable hash of the current type schema of this server.",
+		Args:        []*Argument{},
+		Resolve: func(ctx context.Context, p ResolveParams) (any, error) {
+			return p.Info.Schema.Hash(), nil
+		},
+	}
+
+}
+
+// introspectionArgs returns the arguments sorted by name optionally
+// filtering out deprecated arguments.
+func introspectionArgs(args []*Argument, includeDeprecated bool) []*Argument {
+	results := make([]*Argument, 0, len(args))
+	for _, arg := range args {
+		if !includeDeprecated && arg.DeprecationReason != "" {
+			continue
+		}
+		results = append(results, arg)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Name() < results[j].Name()
+	})
+	return results
+}
+
+func inputValueDeprecationReason(source any) string {
+	switch v := source.(type) {
+	case *Argument:
+		return v.DeprecationReason
+	case *InputObjectField:
+		return v.DeprecationReason
+	}
+	return ""
 }
 
 // Produces a GraphQL Value AST given a Golang value.
@@ -0,0 +1,90 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Introspection pagination is a non-standard extension enabled with
+// SchemaConfig.IntrospectionPagination. It adds `first: Int` and `after: String`
+// arguments to `__Schema.types` and `__Type.fields` so that tooling can page
+// through very large schemas. Types and fields are sorted by name and the cursor
+// is the name of the last type or field of the previous page.
+
+var (
+	pagedIntrospectionOnce   sync.Once
+	pagedSchemaTypesFieldDef *FieldDefinition
+	pagedTypeFieldsFieldDef  *FieldDefinition
+)
+
+// pagedIntrospectionFieldDef returns the paginated variant of an introspection
+// field or nil if the field isn't paginated.
+func pagedIntrospectionFieldDef(parentType Type, fieldName string) *FieldDefinition {
+	if parentType != SchemaType && parentType != TypeType {
+		return nil
+	}
+	pagedIntrospectionOnce.Do(func() {
+		pagedSchemaTypesFieldDef = newPagedFieldDef(SchemaType.Fields()["types"])
+		pagedTypeFieldsFieldDef = newPagedFieldDef(TypeType.Fields()["fields"])
+	})
+	switch {
+	case parentType == SchemaType && fieldName == "types":
+		return pagedSchemaTypesFieldDef
+	case parentType == TypeType && fieldName == "fields":
+		return pagedTypeFieldsFieldDef
+	}
+	return nil
+}
+
+func newPagedFieldDef(fieldDef *FieldDefinition) *FieldDefinition {
+	paged := *fieldDef
+	paged.Args = append(append([]*Argument(nil), fieldDef.Args...),
+		&Argument{
+			PrivateName:        "first",
+			Type:               Int,
+			PrivateDescription: "The maximum number of items to return.",
+		},
+		&Argument{
+			PrivateName:        "after",
+			Type:               String,
+			PrivateDescription: "Only return items with a name after the name of this item.",
+		},
+	)
+	resolve := fieldDef.Resolve
+	paged.Resolve = func(ctx context.Context, p ResolveParams) (any, error) {
+		result, err := resolve(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+		first, hasFirst := p.Args["first"].(int)
+		if hasFirst && first < 0 {
+			return nil, errors.New("first must not be negative")
+		}
+		after, _ := p.Args["after"].(string)
+		switch items := result.(type) {
+		case []Type:
+			return paginateByName(items, func(t Type) string { return t.Name() }, after, first, hasFirst), nil
+		case []*FieldDefinition:
+			return paginateByName(items, func(f *FieldDefinition) string { return f.Name }, after, first, hasFirst), nil
+		}
+		return result, nil
+	}
+	return &paged
+}
+
+// paginateByName returns the items with a name after the cursor up to first items.
+// The items must be sorted by name.
+func paginateByName[T any](items []T, name func(T) string, after string, first int, hasFirst bool) []T {
+	start := 0
+	if after != "" {
+		for start < len(items) && name(items[start]) <= after {
+			start++
+		}
+	}
+	items = items[start:]
+	if hasFirst && first < len(items) {
+		items = items[:first]
+	}
+	return items
+}
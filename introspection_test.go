@@ -1253,6 +1253,7 @@ func TestIntrospection_FailsAsExpectedOnThe__TypeRootFieldWithoutAnArg(t *testin
 				Locations: []location.SourceLocation{
 					{Line: 3, Column: 9},
 				},
+				Extensions: map[string]any{"rule": "ProvidedNonNullArgumentsRule"},
 			},
 		},
 	}
@@ -1481,3 +1482,329 @@ func TestIntrospection_ArgumentOrder(t *testing.T) {
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
 	}
 }
+
+func TestIntrospection_ExposesSpecFieldsAddedIn2021(t *testing.T) {
+	dateScalar := graphql.NewScalar(graphql.ScalarConfig{
+		Name:           "Date",
+		SpecifiedByURL: "https://tools.ietf.org/html/rfc3339",
+		Serialize:      func(value any) any { return value },
+	})
+	filterInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "Filter",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"after": &graphql.InputObjectFieldConfig{
+				Type: dateScalar,
+			},
+			"since": &graphql.InputObjectFieldConfig{
+				Type:              dateScalar,
+				DeprecationReason: "Use after",
+			},
+		},
+	})
+	queryRoot := graphql.NewObject(graphql.ObjectConfig{
+		Name: "QueryRoot",
+		Fields: graphql.Fields{
+			"events": &graphql.Field{
+				Type: graphql.String,
+				Args: graphql.FieldConfigArgument{
+					"filter": &graphql.ArgumentConfig{
+						Type: filterInput,
+					},
+					"limit": &graphql.ArgumentConfig{
+						Type:              graphql.Int,
+						DeprecationReason: "No longer supported",
+					},
+				},
+			},
+		},
+	})
+	tagDirective := graphql.NewDirective(graphql.DirectiveConfig{
+		Name:         "tag",
+		Locations:    []string{graphql.DirectiveLocationField},
+		IsRepeatable: true,
+		Args: graphql.FieldConfigArgument{
+			"name": &graphql.ArgumentConfig{
+				Type: graphql.String,
+			},
+			"label": &graphql.ArgumentConfig{
+				Type:              graphql.String,
+				DeprecationReason: "Use name",
+			},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query:       queryRoot,
+		Description: "Event schema",
+		Directives:  append([]*graphql.Directive{tagDirective}, graphql.SpecifiedDirectives...),
+	})
+	if err != nil {
+		t.Fatalf("Error creating Schema: %v", err.Error())
+	}
+	query := `
+      {
+        __schema {
+          description
+          directives {
+            name
+            isRepeatable
+            args(includeDeprecated: true) {
+              name
+              isDeprecated
+              deprecationReason
+            }
+          }
+        }
+        date: __type(name: "Date") {
+          specifiedByURL
+        }
+        filter: __type(name: "Filter") {
+          specifiedByURL
+          inputFields {
+            name
+          }
+          allInputFields: inputFields(includeDeprecated: true) {
+            name
+            isDeprecated
+          }
+        }
+        queryRoot: __type(name: "QueryRoot") {
+          fields {
+            args {
+              name
+            }
+            allArgs: args(includeDeprecated: true) {
+              name
+              isDeprecated
+              deprecationReason
+            }
+          }
+        }
+      }
+    `
+	expected := map[string]any{
+		"__schema": map[string]any{
+			"description": "Event schema",
+			"directives": []any{
+				map[string]any{
+					"name":         "tag",
+					"isRepeatable": true,
+					"args": []any{
+						map[string]any{
+							"name":              "label",
+							"isDeprecated":      true,
+							"deprecationReason": "Use name",
+						},
+						map[string]any{
+							"name":              "name",
+							"isDeprecated":      false,
+							"deprecationReason": nil,
+						},
+					},
+				},
+				map[string]any{
+					"name":         "include",
+					"isRepeatable": false,
+					"args": []any{
+						map[string]any{
+							"name":              "if",
+							"isDeprecated":      false,
+							"deprecationReason": nil,
+						},
+					},
+				},
+				map[string]any{
+					"name":         "skip",
+					"isRepeatable": false,
+					"args": []any{
+						map[string]any{
+							"name":              "if",
+							"isDeprecated":      false,
+							"deprecationReason": nil,
+						},
+					},
+				},
+				map[string]any{
+					"name":         "deprecated",
+					"isRepeatable": false,
+					"args": []any{
+						map[string]any{
+							"name":              "reason",
+							"isDeprecated":      false,
+							"deprecationReason": nil,
+						},
+					},
+				},
+			},
+		},
+		"date": map[string]any{
+			"specifiedByURL": "https://tools.ietf.org/html/rfc3339",
+		},
+		"filter": map[string]any{
+			"specifiedByURL": nil,
+			"inputFields": []any{
+				map[string]any{"name": "after"},
+			},
+			"allInputFields": []any{
+				map[string]any{"name": "after", "isDeprecated": false},
+				map[string]any{"name": "since", "isDeprecated": true},
+			},
+		},
+		"queryRoot": map[string]any{
+			"fields": []any{
+				map[string]any{
+					"args": []any{
+						map[string]any{"name": "filter"},
+					},
+					"allArgs": []any{
+						map[string]any{
+							"name":              "filter",
+							"isDeprecated":      false,
+							"deprecationReason": nil,
+						},
+						map[string]any{
+							"name":              "limit",
+							"isDeprecated":      true,
+							"deprecationReason": "No longer supported",
+						},
+					},
+				},
+			},
+		},
+	}
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: query,
+	})
+	if len(result.Errors) != 0 {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.Data))
+	}
+}
+
+func TestIntrospection_Pagination(t *testing.T) {
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"a": &graphql.Field{Type: graphql.String},
+			"b": &graphql.Field{Type: graphql.String},
+			"c": &graphql.Field{Type: graphql.String},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query:                   query,
+		IntrospectionPagination: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	request := `{
+		__schema { types(first: 2, after: "Boolean") { name } }
+		__type(name: "Query") { fields(after: "a") { name } }
+	}`
+	expected := &graphql.Result{
+		Data: map[string]any{
+			"__schema": map[string]any{
+				"types": []any{
+					map[string]any{"name": "Query"},
+					map[string]any{"name": "String"},
+				},
+			},
+			"__type": map[string]any{
+				"fields": []any{
+					map[string]any{"name": "b"},
+					map[string]any{"name": "c"},
+				},
+			},
+		},
+	}
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: request,
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	// The arguments are unknown without the extension
+	schema, err = graphql.NewSchema(graphql.SchemaConfig{Query: query})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result = graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: request,
+	})
+	if len(result.Errors) != 3 {
+		t.Fatalf("Expected 3 validation errors, got %+v", result.Errors)
+	}
+}
+
+func TestIntrospection_StableOrder(t *testing.T) {
+	nodeType := graphql.NewInterface(graphql.InterfaceConfig{
+		Name: "Node",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.ID},
+		},
+	})
+	colorType := graphql.NewEnum(graphql.EnumConfig{
+		Name: "Color",
+		Values: graphql.EnumValueConfigMap{
+			"RED":    &graphql.EnumValueConfig{},
+			"GREEN":  &graphql.EnumValueConfig{},
+			"BLUE":   &graphql.EnumValueConfig{},
+			"PURPLE": &graphql.EnumValueConfig{DeprecationReason: "Use RED"},
+			"AMBER":  &graphql.EnumValueConfig{},
+		},
+	})
+	var types []graphql.Type
+	for _, name := range []string{"Zebra", "Apple", "Mango", "Kiwi", "Banana"} {
+		types = append(types, graphql.NewObject(graphql.ObjectConfig{
+			Name:       name,
+			Interfaces: []*graphql.Interface{nodeType},
+			Fields: graphql.Fields{
+				"id":    &graphql.Field{Type: graphql.ID},
+				"color": &graphql.Field{Type: colorType},
+			},
+		}))
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"node": &graphql.Field{Type: nodeType},
+			},
+		}),
+		Types: types,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema: schema,
+		RequestString: `{
+			node: __type(name: "Node") { possibleTypes { name } }
+			color: __type(name: "Color") { enumValues(includeDeprecated: true) { name } }
+		}`,
+	})
+	if len(result.Errors) != 0 {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+	names := func(names ...string) []any {
+		items := make([]any, len(names))
+		for i, name := range names {
+			items[i] = map[string]any{"name": name}
+		}
+		return items
+	}
+	expected := map[string]any{
+		"node":  map[string]any{"possibleTypes": names("Apple", "Banana", "Kiwi", "Mango", "Zebra")},
+		"color": map[string]any{"enumValues": names("AMBER", "BLUE", "GREEN", "PURPLE", "RED")},
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatal(testutil.Diff(expected, result.Data))
+	}
+}
@@ -27,6 +27,8 @@ type OperationDefinition struct {
 	VariableDefinitions []*VariableDefinition
 	Directives          []*Directive
 	SelectionSet        *SelectionSet
+	// Doc is the comment group immediately preceding the operation if parsed with comments.
+	Doc *CommentGroup
 }
 
 func (op *OperationDefinition) GetLoc() Location {
@@ -120,10 +122,12 @@ func (def *TypeExtensionDefinition) GetOperation() string {
 
 // DirectiveDefinition implements Node, Definition
 type DirectiveDefinition struct {
-	Loc       Location
-	Name      *Name
-	Arguments []*InputValueDefinition
-	Locations []*Name
+	Loc         Location
+	Description *StringValue
+	Name        *Name
+	Arguments   []*InputValueDefinition
+	Repeatable  bool
+	Locations   []*Name
 }
 
 func (def *DirectiveDefinition) GetLoc() Location {
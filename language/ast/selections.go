@@ -10,6 +10,13 @@ var _ Selection = (*Field)(nil)
 var _ Selection = (*FragmentSpread)(nil)
 var _ Selection = (*InlineFragment)(nil)
 
+// Client controlled nullability designators of a field. They're an experimental
+// non-spec addition that's only parsed with ParseOptions.ClientControlledNullability.
+const (
+	NullabilityRequired = "!"
+	NullabilityOptional = "?"
+)
+
 // Field implements Node, Selection
 type Field struct {
 	Loc          Location
@@ -18,6 +25,11 @@ type Field struct {
 	Arguments    []*Argument
 	Directives   []*Directive
 	SelectionSet *SelectionSet
+	// Nullability is the client controlled nullability designator of the field
+	// (NullabilityRequired or NullabilityOptional) or empty if there's none.
+	Nullability string
+	// Doc is the comment group immediately preceding the field if parsed with comments.
+	Doc *CommentGroup
 }
 
 func (f *Field) GetLoc() Location {
@@ -66,6 +66,8 @@ func (v *FloatValue) GetValue() any {
 type StringValue struct {
 	Loc   Location
 	Value string
+	// Block is true if the value was a block string ("""...""").
+	Block bool
 }
 
 func (v *StringValue) GetLoc() Location {
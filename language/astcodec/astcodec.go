@@ -0,0 +1,637 @@
+// Package astcodec encodes executable GraphQL documents in a compact binary form
+// for caches and persisted operation stores where a JSON encoding of the AST is too
+// large and parsing the query again is too slow.
+//
+// Names and other strings are stored once in a string table and referenced by
+// index, and node locations are only stored when the document has a source, which
+// is stored as well so that errors reported against a decoded document have line
+// and column numbers. Comments aren't encoded. Only operations and fragments can
+// be encoded; documents with type system definitions return an error.
+package astcodec
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/sprucehealth/graphql/language/ast"
+	"github.com/sprucehealth/graphql/language/source"
+)
+
+// version is the version of the format. It's incremented when the format changes
+// so that documents encoded by another version fail to decode instead of decoding
+// to the wrong document.
+const version = 1
+
+var magic = []byte("GQLD")
+
+// ErrInvalidData is returned when decoding data that isn't an encoded document or
+// was encoded by another version of the format.
+var ErrInvalidData = errors.New("astcodec: invalid data")
+
+const (
+	flagLocations = 1 << iota
+)
+
+const (
+	kindNil byte = iota
+	kindOperation
+	kindFragment
+	kindField
+	kindFragmentSpread
+	kindInlineFragment
+	kindVariable
+	kindInt
+	kindFloat
+	kindString
+	kindBlockString
+	kindBoolean
+	kindEnum
+	kindList
+	kindObject
+	kindNamed
+	kindListType
+	kindNonNull
+)
+
+// Encode returns the binary encoding of the document.
+func Encode(doc *ast.Document) ([]byte, error) {
+	e := &encoder{strings: make(map[string]int)}
+	src := doc.Loc.Source
+	e.locations = src != nil
+	e.document(doc)
+	if e.err != nil {
+		return nil, e.err
+	}
+
+	out := make([]byte, 0, len(magic)+2+len(e.buf)+len(e.table)*8)
+	out = append(out, magic...)
+	out = append(out, version)
+	var flags byte
+	if e.locations {
+		flags |= flagLocations
+	}
+	out = append(out, flags)
+	if e.locations {
+		out = appendString(out, src.Name())
+		out = appendString(out, src.Body())
+	}
+	out = binary.AppendUvarint(out, uint64(len(e.table)))
+	for _, s := range e.table {
+		out = appendString(out, s)
+	}
+	return append(out, e.buf...), nil
+}
+
+func appendString(b []byte, s string) []byte {
+	b = binary.AppendUvarint(b, uint64(len(s)))
+	return append(b, s...)
+}
+
+type encoder struct {
+	buf       []byte
+	strings   map[string]int
+	table     []string
+	locations bool
+	err       error
+}
+
+func (e *encoder) byte(b byte) {
+	e.buf = append(e.buf, b)
+}
+
+func (e *encoder) uvarint(v int) {
+	e.buf = binary.AppendUvarint(e.buf, uint64(v))
+}
+
+func (e *encoder) string(s string) {
+	i, ok := e.strings[s]
+	if !ok {
+		i = len(e.table)
+		e.strings[s] = i
+		e.table = append(e.table, s)
+	}
+	e.uvarint(i)
+}
+
+func (e *encoder) loc(loc ast.Location) {
+	if e.locations {
+		e.uvarint(loc.Start)
+		e.uvarint(loc.End - loc.Start)
+	}
+}
+
+func (e *encoder) document(doc *ast.Document) {
+	e.loc(doc.Loc)
+	e.uvarint(len(doc.Definitions))
+	for _, def := range doc.Definitions {
+		switch def := def.(type) {
+		case *ast.OperationDefinition:
+			e.byte(kindOperation)
+			e.loc(def.Loc)
+			e.string(def.Operation)
+			e.name(def.Name)
+			e.variableDefinitions(def.VariableDefinitions)
+			e.directives(def.Directives)
+			e.selectionSet(def.SelectionSet)
+		case *ast.FragmentDefinition:
+			e.byte(kindFragment)
+			e.loc(def.Loc)
+			e.name(def.Name)
+			e.variableDefinitions(def.VariableDefinitions)
+			e.named(def.TypeCondition)
+			e.directives(def.Directives)
+			e.selectionSet(def.SelectionSet)
+		default:
+			if e.err == nil {
+				e.err = fmt.Errorf("astcodec: can't encode a %T", def)
+			}
+		}
+	}
+}
+
+func (e *encoder) name(name *ast.Name) {
+	if name == nil {
+		e.byte(kindNil)
+		return
+	}
+	e.byte(1)
+	e.loc(name.Loc)
+	e.string(name.Value)
+}
+
+func (e *encoder) named(named *ast.Named) {
+	if named == nil {
+		e.byte(kindNil)
+		return
+	}
+	e.byte(kindNamed)
+	e.loc(named.Loc)
+	e.name(named.Name)
+}
+
+func (e *encoder) variableDefinitions(defs []*ast.VariableDefinition) {
+	e.uvarint(len(defs))
+	for _, def := range defs {
+		e.loc(def.Loc)
+		e.value(def.Variable)
+		e.typ(def.Type)
+		e.value(def.DefaultValue)
+	}
+}
+
+func (e *encoder) directives(directives []*ast.Directive) {
+	e.uvarint(len(directives))
+	for _, dir := range directives {
+		e.loc(dir.Loc)
+		e.name(dir.Name)
+		e.arguments(dir.Arguments)
+	}
+}
+
+func (e *encoder) arguments(args []*ast.Argument) {
+	e.uvarint(len(args))
+	for _, arg := range args {
+		e.loc(arg.Loc)
+		e.name(arg.Name)
+		e.value(arg.Value)
+	}
+}
+
+func (e *encoder) selectionSet(ss *ast.SelectionSet) {
+	if ss == nil {
+		e.byte(kindNil)
+		return
+	}
+	e.byte(1)
+	e.loc(ss.Loc)
+	e.uvarint(len(ss.Selections))
+	for _, sel := range ss.Selections {
+		switch sel := sel.(type) {
+		case *ast.Field:
+			e.byte(kindField)
+			e.loc(sel.Loc)
+			e.name(sel.Alias)
+			e.name(sel.Name)
+			e.arguments(sel.Arguments)
+			e.directives(sel.Directives)
+			e.selectionSet(sel.SelectionSet)
+			e.string(sel.Nullability)
+		case *ast.FragmentSpread:
+			e.byte(kindFragmentSpread)
+			e.loc(sel.Loc)
+			e.name(sel.Name)
+			e.directives(sel.Directives)
+		case *ast.InlineFragment:
+			e.byte(kindInlineFragment)
+			e.loc(sel.Loc)
+			e.named(sel.TypeCondition)
+			e.directives(sel.Directives)
+			e.selectionSet(sel.SelectionSet)
+		default:
+			if e.err == nil {
+				e.err = fmt.Errorf("astcodec: can't encode a %T", sel)
+			}
+		}
+	}
+}
+
+func (e *encoder) value(value ast.Value) {
+	switch value := value.(type) {
+	case nil:
+		e.byte(kindNil)
+	case *ast.Variable:
+		e.byte(kindVariable)
+		e.loc(value.Loc)
+		e.name(value.Name)
+	case *ast.IntValue:
+		e.byte(kindInt)
+		e.loc(value.Loc)
+		e.string(value.Value)
+	case *ast.FloatValue:
+		e.byte(kindFloat)
+		e.loc(value.Loc)
+		e.string(value.Value)
+	case *ast.StringValue:
+		if value.Block {
+			e.byte(kindBlockString)
+		} else {
+			e.byte(kindString)
+		}
+		e.loc(value.Loc)
+		e.string(value.Value)
+	case *ast.BooleanValue:
+		e.byte(kindBoolean)
+		e.loc(value.Loc)
+		if value.Value {
+			e.byte(1)
+		} else {
+			e.byte(0)
+		}
+	case *ast.EnumValue:
+		e.byte(kindEnum)
+		e.loc(value.Loc)
+		e.string(value.Value)
+	case *ast.ListValue:
+		e.byte(kindList)
+		e.loc(value.Loc)
+		e.uvarint(len(value.Values))
+		for _, v := range value.Values {
+			e.value(v)
+		}
+	case *ast.ObjectValue:
+		e.byte(kindObject)
+		e.loc(value.Loc)
+		e.uvarint(len(value.Fields))
+		for _, f := range value.Fields {
+			e.loc(f.Loc)
+			e.name(f.Name)
+			e.value(f.Value)
+		}
+	default:
+		if e.err == nil {
+			e.err = fmt.Errorf("astcodec: can't encode a %T", value)
+		}
+	}
+}
+
+func (e *encoder) typ(t ast.Type) {
+	switch t := t.(type) {
+	case nil:
+		e.byte(kindNil)
+	case *ast.Named:
+		e.named(t)
+	case *ast.List:
+		e.byte(kindListType)
+		e.loc(t.Loc)
+		e.typ(t.Type)
+	case *ast.NonNull:
+		e.byte(kindNonNull)
+		e.loc(t.Loc)
+		e.typ(t.Type)
+	default:
+		if e.err == nil {
+			e.err = fmt.Errorf("astcodec: can't encode a %T", t)
+		}
+	}
+}
+
+// Decode returns the document of the binary encoding returned by Encode. It
+// returns ErrInvalidData if the data isn't a valid encoding.
+func Decode(data []byte) (*ast.Document, error) {
+	if len(data) < len(magic)+2 || string(data[:len(magic)]) != string(magic) || data[len(magic)] != version {
+		return nil, ErrInvalidData
+	}
+	// Strings are sliced from a single copy of the data.
+	d := &decoder{data: data, text: string(data), pos: len(magic) + 1}
+	flags := d.byte()
+	if flags&flagLocations != 0 {
+		name := d.rawString()
+		body := d.rawString()
+		d.source = source.New(name, body)
+	}
+	d.table = make([]string, d.length())
+	for i := range d.table {
+		d.table[i] = d.rawString()
+	}
+	doc := d.document()
+	if d.err != nil {
+		return nil, d.err
+	}
+	if d.pos != len(d.data) {
+		return nil, ErrInvalidData
+	}
+	return doc, nil
+}
+
+type decoder struct {
+	data   []byte
+	text   string
+	pos    int
+	table  []string
+	source *source.Source
+	err    error
+}
+
+func (d *decoder) fail() {
+	if d.err == nil {
+		d.err = ErrInvalidData
+	}
+	// Stop reading so that decoding finishes quickly.
+	d.pos = len(d.data)
+}
+
+func (d *decoder) byte() byte {
+	if d.pos >= len(d.data) {
+		d.fail()
+		return 0
+	}
+	b := d.data[d.pos]
+	d.pos++
+	return b
+}
+
+func (d *decoder) uvarint() int {
+	v, n := binary.Uvarint(d.data[d.pos:])
+	if n <= 0 || v > math.MaxInt32 {
+		d.fail()
+		return 0
+	}
+	d.pos += n
+	return int(v)
+}
+
+// length reads the length of a list. As every item takes at least a byte it
+// can't be larger than the remaining data.
+func (d *decoder) length() int {
+	n := d.uvarint()
+	if n > len(d.data)-d.pos {
+		d.fail()
+		return 0
+	}
+	return n
+}
+
+func (d *decoder) rawString() string {
+	n := d.length()
+	s := d.text[d.pos : d.pos+n]
+	d.pos += n
+	return s
+}
+
+func (d *decoder) string() string {
+	i := d.uvarint()
+	if i >= len(d.table) {
+		d.fail()
+		return ""
+	}
+	return d.table[i]
+}
+
+func (d *decoder) loc() ast.Location {
+	if d.source == nil {
+		return ast.Location{}
+	}
+	start := d.uvarint()
+	return ast.Location{Start: start, End: start + d.uvarint(), Source: d.source}
+}
+
+func (d *decoder) document() *ast.Document {
+	doc := &ast.Document{Loc: d.loc()}
+	n := d.length()
+	doc.Definitions = make([]ast.Node, 0, n)
+	for i := 0; i < n; i++ {
+		switch d.byte() {
+		case kindOperation:
+			op := &ast.OperationDefinition{Loc: d.loc()}
+			op.Operation = d.string()
+			op.Name = d.name()
+			op.VariableDefinitions = d.variableDefinitions()
+			op.Directives = d.directives()
+			op.SelectionSet = d.selectionSet()
+			doc.Definitions = append(doc.Definitions, op)
+		case kindFragment:
+			frag := &ast.FragmentDefinition{Loc: d.loc()}
+			frag.Name = d.name()
+			frag.VariableDefinitions = d.variableDefinitions()
+			frag.TypeCondition = d.named()
+			frag.Directives = d.directives()
+			frag.SelectionSet = d.selectionSet()
+			doc.Definitions = append(doc.Definitions, frag)
+		default:
+			d.fail()
+			return nil
+		}
+	}
+	return doc
+}
+
+func (d *decoder) name() *ast.Name {
+	switch d.byte() {
+	case kindNil:
+		return nil
+	case 1:
+		name := &ast.Name{Loc: d.loc()}
+		name.Value = d.string()
+		return name
+	}
+	d.fail()
+	return nil
+}
+
+func (d *decoder) named() *ast.Named {
+	switch d.byte() {
+	case kindNil:
+		return nil
+	case kindNamed:
+		return d.namedBody()
+	}
+	d.fail()
+	return nil
+}
+
+func (d *decoder) namedBody() *ast.Named {
+	named := &ast.Named{Loc: d.loc()}
+	named.Name = d.name()
+	return named
+}
+
+func (d *decoder) variableDefinitions() []*ast.VariableDefinition {
+	n := d.length()
+	if n == 0 {
+		return nil
+	}
+	defs := make([]*ast.VariableDefinition, n)
+	for i := range defs {
+		def := &ast.VariableDefinition{Loc: d.loc()}
+		def.Variable, _ = d.value().(*ast.Variable)
+		def.Type = d.typ()
+		def.DefaultValue = d.value()
+		defs[i] = def
+	}
+	return defs
+}
+
+func (d *decoder) directives() []*ast.Directive {
+	n := d.length()
+	if n == 0 {
+		return nil
+	}
+	directives := make([]*ast.Directive, n)
+	for i := range directives {
+		dir := &ast.Directive{Loc: d.loc()}
+		dir.Name = d.name()
+		dir.Arguments = d.arguments()
+		directives[i] = dir
+	}
+	return directives
+}
+
+func (d *decoder) arguments() []*ast.Argument {
+	n := d.length()
+	if n == 0 {
+		return nil
+	}
+	args := make([]*ast.Argument, n)
+	for i := range args {
+		arg := &ast.Argument{Loc: d.loc()}
+		arg.Name = d.name()
+		arg.Value = d.value()
+		args[i] = arg
+	}
+	return args
+}
+
+func (d *decoder) selectionSet() *ast.SelectionSet {
+	switch d.byte() {
+	case kindNil:
+		return nil
+	case 1:
+	default:
+		d.fail()
+		return nil
+	}
+	ss := &ast.SelectionSet{Loc: d.loc()}
+	n := d.length()
+	ss.Selections = make([]ast.Selection, 0, n)
+	for i := 0; i < n; i++ {
+		switch d.byte() {
+		case kindField:
+			field := &ast.Field{Loc: d.loc()}
+			field.Alias = d.name()
+			field.Name = d.name()
+			field.Arguments = d.arguments()
+			field.Directives = d.directives()
+			field.SelectionSet = d.selectionSet()
+			field.Nullability = d.string()
+			ss.Selections = append(ss.Selections, field)
+		case kindFragmentSpread:
+			spread := &ast.FragmentSpread{Loc: d.loc()}
+			spread.Name = d.name()
+			spread.Directives = d.directives()
+			ss.Selections = append(ss.Selections, spread)
+		case kindInlineFragment:
+			frag := &ast.InlineFragment{Loc: d.loc()}
+			frag.TypeCondition = d.named()
+			frag.Directives = d.directives()
+			frag.SelectionSet = d.selectionSet()
+			ss.Selections = append(ss.Selections, frag)
+		default:
+			d.fail()
+			return nil
+		}
+	}
+	return ss
+}
+
+func (d *decoder) value() ast.Value {
+	switch kind := d.byte(); kind {
+	case kindNil:
+		return nil
+	case kindVariable:
+		v := &ast.Variable{Loc: d.loc()}
+		v.Name = d.name()
+		return v
+	case kindInt:
+		v := &ast.IntValue{Loc: d.loc()}
+		v.Value = d.string()
+		return v
+	case kindFloat:
+		v := &ast.FloatValue{Loc: d.loc()}
+		v.Value = d.string()
+		return v
+	case kindString, kindBlockString:
+		v := &ast.StringValue{Block: kind == kindBlockString, Loc: d.loc()}
+		v.Value = d.string()
+		return v
+	case kindBoolean:
+		v := &ast.BooleanValue{Loc: d.loc()}
+		v.Value = d.byte() == 1
+		return v
+	case kindEnum:
+		v := &ast.EnumValue{Loc: d.loc()}
+		v.Value = d.string()
+		return v
+	case kindList:
+		v := &ast.ListValue{Loc: d.loc()}
+		n := d.length()
+		v.Values = make([]ast.Value, n)
+		for i := range v.Values {
+			v.Values[i] = d.value()
+		}
+		return v
+	case kindObject:
+		v := &ast.ObjectValue{Loc: d.loc()}
+		n := d.length()
+		v.Fields = make([]*ast.ObjectField, n)
+		for i := range v.Fields {
+			f := &ast.ObjectField{Loc: d.loc()}
+			f.Name = d.name()
+			f.Value = d.value()
+			v.Fields[i] = f
+		}
+		return v
+	}
+	d.fail()
+	return nil
+}
+
+func (d *decoder) typ() ast.Type {
+	switch d.byte() {
+	case kindNil:
+		return nil
+	case kindNamed:
+		return d.namedBody()
+	case kindListType:
+		t := &ast.List{Loc: d.loc()}
+		t.Type = d.typ()
+		return t
+	case kindNonNull:
+		t := &ast.NonNull{Loc: d.loc()}
+		t.Type = d.typ()
+		return t
+	}
+	d.fail()
+	return nil
+}
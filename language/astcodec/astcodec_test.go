@@ -0,0 +1,152 @@
+package astcodec
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/sprucehealth/graphql/language/ast"
+	"github.com/sprucehealth/graphql/language/parser"
+	"github.com/sprucehealth/graphql/language/printer"
+	"github.com/sprucehealth/graphql/language/source"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+func parse(t testing.TB, query string, noSource bool) *ast.Document {
+	t.Helper()
+	doc, err := parser.Parse(parser.ParseParams{
+		Source:  source.New("GraphQL request", query),
+		Options: parser.ParseOptions{NoSource: noSource},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func kitchenSink(t testing.TB) string {
+	t.Helper()
+	b, err := os.ReadFile("../../kitchen-sink.graphql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b) + `
+query blocks($n: [Int!]! = [1, 2]) {
+  node(text: """block""", f: 1.5, e: ENUM) @include(if: true) {
+    ...frag
+  }
+}
+`
+}
+
+func TestRoundTrip(t *testing.T) {
+	query := kitchenSink(t)
+	for _, noSource := range []bool{false, true} {
+		doc := parse(t, query, noSource)
+		b, err := Encode(doc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		decoded, err := Decode(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if noSource {
+			// Locations aren't encoded without a source.
+			if expected, got := printer.Print(doc), printer.Print(decoded); expected != got {
+				t.Fatalf("Unexpected document, Diff: %v", testutil.Diff(expected, got))
+			}
+			continue
+		}
+		if !reflect.DeepEqual(doc, decoded) {
+			t.Fatalf("Unexpected document, Diff: %v", testutil.Diff(doc, decoded))
+		}
+	}
+}
+
+func TestEncodingIsSmallerThanJSON(t *testing.T) {
+	doc := parse(t, kitchenSink(t), true)
+	b, err := Encode(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	js, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b)*10 > len(js) {
+		t.Fatalf("Expected the encoding (%d bytes) to be at least 10x smaller than JSON (%d bytes)", len(b), len(js))
+	}
+}
+
+func TestEncodeTypeSystemDefinition(t *testing.T) {
+	doc := parse(t, `type Query { a: String }`, true)
+	if _, err := Encode(doc); err == nil {
+		t.Fatal("Expected an error")
+	}
+}
+
+func TestDecodeInvalidData(t *testing.T) {
+	b, err := Encode(parse(t, kitchenSink(t), false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Every truncation and corruption must fail without panicking.
+	for i := 0; i < len(b); i++ {
+		if _, err := Decode(b[:i]); !errors.Is(err, ErrInvalidData) {
+			t.Fatalf("Expected ErrInvalidData for %d bytes, got %v", i, err)
+		}
+		corrupted := append([]byte(nil), b...)
+		corrupted[i] ^= 0xff
+		Decode(corrupted)
+	}
+}
+
+func BenchmarkEncode(b *testing.B) {
+	doc := parse(b, kitchenSink(b), false)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := Encode(doc); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecode(b *testing.B) {
+	data, err := Encode(parse(b, kitchenSink(b), false))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := Decode(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkParse is the baseline for BenchmarkDecode.
+func BenchmarkParse(b *testing.B) {
+	query := kitchenSink(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		parse(b, query, false)
+	}
+}
+
+// BenchmarkEncodeJSON is the baseline for BenchmarkEncode.
+func BenchmarkEncodeJSON(b *testing.B) {
+	doc := parse(b, kitchenSink(b), true)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := json.Marshal(doc); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
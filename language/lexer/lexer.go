@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 	"unicode"
+	"unicode/utf16"
 	"unicode/utf8"
 
 	"github.com/sprucehealth/graphql/gqlerrors"
@@ -31,6 +32,8 @@ const (
 	STRING
 	COMMENT
 	AMPERSAND
+	BLOCK_STRING
+	QUESTION_MARK
 )
 
 var tokenDescription map[int]string
@@ -57,10 +60,12 @@ func init() {
 	tokenDescription[STRING] = "String"
 	tokenDescription[COMMENT] = "Comment"
 	tokenDescription[AMPERSAND] = "&"
+	tokenDescription[BLOCK_STRING] = "BlockString"
+	tokenDescription[QUESTION_MARK] = "?"
 }
 
 // Token is a representation of a lexed Token. Value only appears for non-punctuation
-// tokens: NAME, INT, FLOAT, and STRING.
+// tokens: NAME, INT, FLOAT, STRING, and BLOCK_STRING.
 type Token struct {
 	Kind  int
 	Start int
@@ -78,6 +83,10 @@ type Lexer struct {
 	offset   offset
 	rdOffset offset
 	ch       rune
+	// questionMark enables QUESTION_MARK tokens.
+	questionMark bool
+	// variableWidthEscapes enables \u{...} escape sequences in strings.
+	variableWidthEscapes bool
 }
 
 type offset struct {
@@ -94,6 +103,20 @@ func New(s *source.Source) *Lexer {
 	return lex
 }
 
+// EnableQuestionMark makes the lexer return "?" as a QUESTION_MARK token instead of
+// failing with an unexpected character error. It's used by the experimental client
+// controlled nullability syntax.
+func (l *Lexer) EnableQuestionMark() {
+	l.questionMark = true
+}
+
+// EnableVariableWidthUnicodeEscapes makes the lexer accept escape sequences of
+// one or more hexadecimal digits in braces (e.g. "\u{1F600}") in strings in
+// addition to the fixed width "\uXXXX" form.
+func (l *Lexer) EnableVariableWidthUnicodeEscapes() {
+	l.variableWidthEscapes = true
+}
+
 func (l *Lexer) NextToken() (Token, error) {
 	token, err := l.readToken()
 	if err != nil {
@@ -240,18 +263,9 @@ func (l *Lexer) readString() (Token, error) {
 			case 't':
 				value = append(value, "\t")
 			case 'u':
-				offs := l.rdOffset
-				l.nextRune()
-				u1 := l.ch
-				l.nextRune()
-				u2 := l.ch
-				l.nextRune()
-				u3 := l.ch
-				l.nextRune()
-				u4 := l.ch
-				charCode := uniCharCode(u1, u2, u3, u4)
-				if charCode < 0 {
-					return Token{}, gqlerrors.NewSyntaxError(l.src, offs.runes-1, fmt.Sprintf(`Invalid character escape sequence: \u%s`, l.sliceBody(offs, l.rdOffset)))
+				charCode, err := l.readUnicodeEscape()
+				if err != nil {
+					return Token{}, err
 				}
 				value = append(value, string(charCode))
 			default:
@@ -270,6 +284,155 @@ func (l *Lexer) readString() (Token, error) {
 	return makeToken(STRING, start, l.offset, strings.Join(value, "")), nil
 }
 
+// readUnicodeEscape reads the code point of a unicode escape sequence in a
+// string once the "\u" has been read. A leading surrogate must be followed by the
+// escape sequence of a trailing surrogate and the pair is combined into a single
+// code point. Unpaired surrogates are invalid since they can't be encoded.
+func (l *Lexer) readUnicodeEscape() (rune, error) {
+	offs := l.rdOffset
+	if l.variableWidthEscapes && l.rdOffset.bytes < len(l.body) && l.body[l.rdOffset.bytes] == '{' {
+		return l.readVariableWidthUnicodeEscape()
+	}
+	l.nextRune()
+	u1 := l.ch
+	l.nextRune()
+	u2 := l.ch
+	l.nextRune()
+	u3 := l.ch
+	l.nextRune()
+	u4 := l.ch
+	charCode := uniCharCode(u1, u2, u3, u4)
+	switch {
+	case charCode < 0, utf16.IsSurrogate(charCode) && charCode >= 0xDC00:
+		return 0, l.invalidUnicodeEscape(offs)
+	case utf16.IsSurrogate(charCode):
+		rest := l.body[l.rdOffset.bytes:]
+		if len(rest) < 6 || rest[0] != '\\' || rest[1] != 'u' {
+			return 0, l.invalidUnicodeEscape(offs)
+		}
+		trail := uniCharCode(rune(rest[2]), rune(rest[3]), rune(rest[4]), rune(rest[5]))
+		if trail < 0xDC00 || trail > 0xDFFF {
+			return 0, l.invalidUnicodeEscape(offs)
+		}
+		for i := 0; i < 6; i++ {
+			l.nextRune()
+		}
+		return utf16.DecodeRune(charCode, trail), nil
+	}
+	return charCode, nil
+}
+
+// readVariableWidthUnicodeEscape reads an escape sequence of the form "\u{...}"
+// once the "\u" has been read.
+func (l *Lexer) readVariableWidthUnicodeEscape() (rune, error) {
+	offs := l.rdOffset
+	l.nextRune()
+	var charCode rune
+	digits := 0
+	for {
+		l.nextRune()
+		if l.ch == '}' {
+			break
+		}
+		h := char2hex(l.ch)
+		if h < 0 || charCode > unicode.MaxRune {
+			charCode = -1
+			break
+		}
+		charCode = charCode<<4 | rune(h)
+		digits++
+	}
+	if charCode < 0 || digits == 0 || charCode > unicode.MaxRune || utf16.IsSurrogate(charCode) {
+		return 0, l.invalidUnicodeEscape(offs)
+	}
+	return charCode, nil
+}
+
+// invalidUnicodeEscape returns the error of an invalid unicode escape sequence
+// that starts at offs (after the "\u").
+func (l *Lexer) invalidUnicodeEscape(offs offset) error {
+	return gqlerrors.NewSyntaxError(l.src, offs.runes-1, fmt.Sprintf(`Invalid character escape sequence: \u%s`, l.sliceBody(offs, l.rdOffset)))
+}
+
+// readBlockString reads a block string token ("""...""") from the source.
+// The only escape sequence is \""" and the value is the raw string with
+// the common indentation and the leading and trailing blank lines removed.
+func (l *Lexer) readBlockString() (Token, error) {
+	start := l.offset
+	l.nextRune()
+	l.nextRune()
+	l.nextRune()
+	chunkStart := l.offset
+	var raw strings.Builder
+	for l.ch != 0 {
+		rest := l.body[l.offset.bytes:]
+		if strings.HasPrefix(rest, `"""`) {
+			raw.WriteString(l.sliceBody(chunkStart, l.offset))
+			l.nextRune()
+			l.nextRune()
+			l.nextRune()
+			return makeToken(BLOCK_STRING, start, l.offset, BlockStringValue(raw.String())), nil
+		}
+		if strings.HasPrefix(rest, `\"""`) {
+			raw.WriteString(l.sliceBody(chunkStart, l.offset))
+			raw.WriteString(`"""`)
+			for i := 0; i < 4; i++ {
+				l.nextRune()
+			}
+			chunkStart = l.offset
+			continue
+		}
+		if l.ch < 0x0020 && l.ch != 0x0009 && l.ch != 0x000A && l.ch != 0x000D {
+			return Token{}, gqlerrors.NewSyntaxError(l.src, l.offset.runes, fmt.Sprintf(`Invalid character within String: %v.`, printCharCode(l.ch)))
+		}
+		l.nextRune()
+	}
+	return Token{}, gqlerrors.NewSyntaxError(l.src, l.offset.runes, "Unterminated string.")
+}
+
+// BlockStringValue returns the value of the raw content of a block string. It
+// removes the indentation common to all lines but the first as well as the
+// leading and trailing blank lines.
+func BlockStringValue(raw string) string {
+	lines := strings.Split(strings.ReplaceAll(strings.ReplaceAll(raw, "\r\n", "\n"), "\r", "\n"), "\n")
+
+	commonIndent := -1
+	for _, line := range lines[1:] {
+		indent := leadingWhitespace(line)
+		if indent == len(line) {
+			continue
+		}
+		if commonIndent < 0 || indent < commonIndent {
+			commonIndent = indent
+		}
+	}
+	if commonIndent > 0 {
+		for i := 1; i < len(lines); i++ {
+			if len(lines[i]) < commonIndent {
+				lines[i] = ""
+			} else {
+				lines[i] = lines[i][commonIndent:]
+			}
+		}
+	}
+
+	for len(lines) != 0 && leadingWhitespace(lines[0]) == len(lines[0]) {
+		lines = lines[1:]
+	}
+	for len(lines) != 0 && leadingWhitespace(lines[len(lines)-1]) == len(lines[len(lines)-1]) {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
+
+func leadingWhitespace(s string) int {
+	i := 0
+	for i < len(s) && (s[i] == ' ' || s[i] == '\t') {
+		i++
+	}
+	return i
+}
+
 // Converts four hexadecimal chars to the integer that the
 // string represents. For example, uniCharCode('0','0','0','f')
 // will return 15, and uniCharCode('0','0','f','f') returns 255.
@@ -331,6 +494,9 @@ func (l *Lexer) readToken() (Token, error) {
 	case isDigit(ch) || ch == '-':
 		return l.readNumber()
 	case ch == '"':
+		if strings.HasPrefix(l.body[l.offset.bytes:], `"""`) {
+			return l.readBlockString()
+		}
 		return l.readString()
 	default:
 		l.nextRune() // always make progress
@@ -377,6 +543,10 @@ func (l *Lexer) readToken() (Token, error) {
 			return makeToken(COMMENT, startOffset, l.offset, strings.TrimSpace(l.sliceBody(startOffset, l.offset))), nil
 		case '&':
 			return makeToken(AMPERSAND, startOffset, l.offset, ""), nil
+		case '?':
+			if l.questionMark {
+				return makeToken(QUESTION_MARK, startOffset, l.offset, ""), nil
+			}
 		}
 	}
 	description := fmt.Sprintf("Unexpected character %v.", printCharCode(ch))
@@ -3,7 +3,9 @@ package lexer
 import (
 	"reflect"
 	"strconv"
+	"strings"
 	"testing"
+	"unicode/utf8"
 
 	"github.com/sprucehealth/graphql/language/source"
 )
@@ -289,6 +291,15 @@ func TestLexer_LexesStrings(t *testing.T) {
 				Value: "unicode \u1234\u5678\u90AB\uCDEF",
 			},
 		},
+		{
+			Body: `"surrogate pair \uD83D\uDE00 \ud83d\ude00"`,
+			Expected: Token{
+				Kind:  STRING,
+				Start: 0,
+				End:   42,
+				Value: "surrogate pair \U0001F600 \U0001F600",
+			},
+		},
 		{
 			Body: "\"unicode фы世界\"",
 			Expected: Token{
@@ -330,6 +341,72 @@ func TestLexer_LexesStrings(t *testing.T) {
 	}
 }
 
+func TestLexer_LexesBlockStrings(t *testing.T) {
+	tests := []Test{
+		{
+			Body: `"""simple"""`,
+			Expected: Token{
+				Kind:  BLOCK_STRING,
+				Start: 0,
+				End:   12,
+				Value: "simple",
+			},
+		},
+		{
+			Body: `"""contains " quote"""`,
+			Expected: Token{
+				Kind:  BLOCK_STRING,
+				Start: 0,
+				End:   22,
+				Value: `contains " quote`,
+			},
+		},
+		{
+			Body: `"""contains \""" triple-quote"""`,
+			Expected: Token{
+				Kind:  BLOCK_STRING,
+				Start: 0,
+				End:   32,
+				Value: `contains """ triple-quote`,
+			},
+		},
+		{
+			Body: `"""unescaped \n\r\b\t\f\u1234"""`,
+			Expected: Token{
+				Kind:  BLOCK_STRING,
+				Start: 0,
+				End:   32,
+				Value: `unescaped \n\r\b\t\f\u1234`,
+			},
+		},
+		{
+			Body: "\"\"\"\n\n    spans\n      multiple\n    lines\n\n  \"\"\"",
+			Expected: Token{
+				Kind:  BLOCK_STRING,
+				Start: 0,
+				End:   46,
+				Value: "spans\n  multiple\nlines",
+			},
+		},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			token, err := New(source.New("", test.Body)).NextToken()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(token, test.Expected) {
+				t.Fatalf("unexpected token, expected: %v, got: %v", test.Expected, token)
+			}
+		})
+	}
+
+	_, err := New(source.New("", `"""no end`)).NextToken()
+	if err == nil || !strings.Contains(err.Error(), "Unterminated string.") {
+		t.Fatalf("expected unterminated string error, got %v", err)
+	}
+}
+
 func TestLexer_ReportsUsefulStringErrors(t *testing.T) {
 	tests := []Test{
 		{
@@ -420,6 +497,38 @@ func TestLexer_ReportsUsefulStringErrors(t *testing.T) {
 
 1: "bad \u123
          ^
+`,
+		},
+		{
+			Body: `"bad \uD83D esc"`,
+			Expected: `Syntax Error GraphQL (1:7) Invalid character escape sequence: \uD83D
+
+1: "bad \uD83D esc"
+         ^
+`,
+		},
+		{
+			Body: `"bad \uD83D\u0041 esc"`,
+			Expected: `Syntax Error GraphQL (1:7) Invalid character escape sequence: \uD83D
+
+1: "bad \uD83D\u0041 esc"
+         ^
+`,
+		},
+		{
+			Body: `"bad \uDE00\uD83D esc"`,
+			Expected: `Syntax Error GraphQL (1:7) Invalid character escape sequence: \uDE00
+
+1: "bad \uDE00\uD83D esc"
+         ^
+`,
+		},
+		{
+			Body: `"bad \u{1F600} esc"`,
+			Expected: `Syntax Error GraphQL (1:7) Invalid character escape sequence: \u{1F6
+
+1: "bad \u{1F600} esc"
+         ^
 `,
 		},
 		{
@@ -446,6 +555,115 @@ func TestLexer_ReportsUsefulStringErrors(t *testing.T) {
 	}
 }
 
+func TestLexer_LexesVariableWidthUnicodeEscapes(t *testing.T) {
+	tests := []Test{
+		{
+			Body: `"\u{1F600} \u{41}\u{0000000e9} \uD83D\uDE00"`,
+			Expected: Token{
+				Kind:  STRING,
+				Start: 0,
+				End:   44,
+				Value: "\U0001F600 A\u00e9 \U0001F600",
+			},
+		},
+		{
+			Body: `"\u{10FFFF}"`,
+			Expected: Token{
+				Kind:  STRING,
+				Start: 0,
+				End:   12,
+				Value: "\U0010FFFF",
+			},
+		},
+		{
+			Body: `"bad \u{} esc"`,
+			Expected: `Syntax Error GraphQL (1:7) Invalid character escape sequence: \u{}
+
+1: "bad \u{} esc"
+         ^
+`,
+		},
+		{
+			Body: `"bad \u{110000} esc"`,
+			Expected: `Syntax Error GraphQL (1:7) Invalid character escape sequence: \u{110000}
+
+1: "bad \u{110000} esc"
+         ^
+`,
+		},
+		{
+			Body: `"bad \u{D83D} esc"`,
+			Expected: `Syntax Error GraphQL (1:7) Invalid character escape sequence: \u{D83D}
+
+1: "bad \u{D83D} esc"
+         ^
+`,
+		},
+		{
+			Body: `"bad \u{1F6Z0} esc"`,
+			Expected: `Syntax Error GraphQL (1:7) Invalid character escape sequence: \u{1F6Z
+
+1: "bad \u{1F6Z0} esc"
+         ^
+`,
+		},
+		{
+			Body: `"bad \u{1F600`,
+			Expected: `Syntax Error GraphQL (1:7) Invalid character escape sequence: \u{1F600
+
+1: "bad \u{1F600
+         ^
+`,
+		},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			lex := New(createSource(test.Body))
+			lex.EnableVariableWidthUnicodeEscapes()
+			token, err := lex.NextToken()
+			if expectedErr, ok := test.Expected.(string); ok {
+				if err == nil || err.Error() != expectedErr {
+					t.Fatalf("unexpected error.\nexpected:\n%v\n\ngot:\n%v", expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(token, test.Expected) {
+				t.Fatalf("unexpected token, expected: %v, got: %v", test.Expected, token)
+			}
+		})
+	}
+}
+
+func FuzzLexer(f *testing.F) {
+	for _, body := range []string{
+		`{ a(s: "\u00e9\uD83D\uDE00") }`,
+		`"\u{1F600}\u{}\u{110000}"`,
+		`"\uD83D\u0041"`,
+		`"""block \""" string"""`,
+	} {
+		f.Add(body, false)
+		f.Add(body, true)
+	}
+	f.Fuzz(func(t *testing.T, body string, variableWidthEscapes bool) {
+		lex := New(createSource(body))
+		if variableWidthEscapes {
+			lex.EnableVariableWidthUnicodeEscapes()
+		}
+		for {
+			tok, err := lex.NextToken()
+			if err != nil || tok.Kind == EOF {
+				return
+			}
+			if tok.Kind == STRING && utf8.ValidString(body) && !utf8.ValidString(tok.Value) {
+				t.Fatalf("invalid UTF-8 string value %q", tok.Value)
+			}
+		}
+	})
+}
+
 func TestLexer_LexesNumbers(t *testing.T) {
 	tests := []Test{
 		{
@@ -14,6 +14,12 @@ type parseFn func() (any, error)
 type ParseOptions struct {
 	NoSource     bool
 	KeepComments bool
+	// ClientControlledNullability enables the experimental `field!` and `field?`
+	// designators that change the nullability of a field in the response.
+	ClientControlledNullability bool
+	// VariableWidthUnicodeEscapes enables `\u{...}` escape sequences of any code
+	// point in strings in addition to `\uXXXX`.
+	VariableWidthUnicodeEscapes bool
 }
 
 type ParseParams struct {
@@ -71,6 +77,12 @@ func makeParser(s *source.Source, opts ParseOptions) (*Parser, error) {
 		Source:  s,
 		Options: opts,
 	}
+	if opts.ClientControlledNullability {
+		p.Lexer.EnableQuestionMark()
+	}
+	if opts.VariableWidthUnicodeEscapes {
+		p.Lexer.EnableVariableWidthUnicodeEscapes()
+	}
 	return p, p.next()
 }
 
@@ -92,8 +104,22 @@ func (p *Parser) parseDocument() (*ast.Document, error) {
 				return nil, err
 			}
 			nodes = append(nodes, node)
-		case p.peek(lexer.NAME):
-			switch p.tok.Value {
+		case p.peek(lexer.NAME), p.peekDescription():
+			keyword := p.tok.Value
+			if !p.peek(lexer.NAME) {
+				// Descriptions may only precede type system definitions
+				description := p.tok
+				tok, err := p.lookahead()
+				if err != nil {
+					return nil, err
+				}
+				switch tok.Value {
+				case "query", "mutation", "subscription", "fragment", "extend":
+					return nil, p.unexpected(description)
+				}
+				keyword = tok.Value
+			}
+			switch keyword {
 			case "query", "mutation", "subscription": // Note: subscription is an experimental non-spec addition.
 				node, err := p.parseOperationDefinition()
 				if err != nil {
@@ -182,6 +208,8 @@ func (p *Parser) parseDocument() (*ast.Document, error) {
 /* Implements the parsing rules in the Operations section. */
 
 func (p *Parser) parseOperationDefinition() (*ast.OperationDefinition, error) {
+	docComment := p.leadComment
+
 	start := p.tok.Start
 	if p.peek(lexer.BRACE_L) {
 		selectionSet, err := p.parseSelectionSet()
@@ -192,6 +220,7 @@ func (p *Parser) parseOperationDefinition() (*ast.OperationDefinition, error) {
 			Operation:    ast.OperationTypeQuery,
 			SelectionSet: selectionSet,
 			Loc:          p.loc(start),
+			Doc:          docComment,
 		}, nil
 	}
 	operation, err := p.parseOperationType()
@@ -224,6 +253,7 @@ func (p *Parser) parseOperationDefinition() (*ast.OperationDefinition, error) {
 		Directives:          directives,
 		SelectionSet:        selectionSet,
 		Loc:                 p.loc(start),
+		Doc:                 docComment,
 	}, nil
 }
 
@@ -338,6 +368,8 @@ func (p *Parser) parseSelection() (any, error) {
 }
 
 func (p *Parser) parseField() (*ast.Field, error) {
+	docComment := p.leadComment
+
 	start := p.tok.Start
 	nameOrAlias, err := p.parseName()
 	if err != nil {
@@ -363,6 +395,10 @@ func (p *Parser) parseField() (*ast.Field, error) {
 	if err != nil {
 		return nil, err
 	}
+	nullability, err := p.parseNullabilityDesignator()
+	if err != nil {
+		return nil, err
+	}
 	directives, err := p.parseDirectives()
 	if err != nil {
 		return nil, err
@@ -379,12 +415,33 @@ func (p *Parser) parseField() (*ast.Field, error) {
 		Alias:        alias,
 		Name:         name,
 		Arguments:    arguments,
+		Nullability:  nullability,
 		Directives:   directives,
 		SelectionSet: selectionSet,
 		Loc:          p.loc(start),
+		Doc:          docComment,
 	}, nil
 }
 
+// parseNullabilityDesignator parses the optional `!` or `?` following the
+// arguments of a field when client controlled nullability is enabled.
+func (p *Parser) parseNullabilityDesignator() (string, error) {
+	if !p.Options.ClientControlledNullability {
+		return "", nil
+	}
+	if skp, err := p.skip(lexer.BANG); err != nil {
+		return "", err
+	} else if skp {
+		return ast.NullabilityRequired, nil
+	}
+	if skp, err := p.skip(lexer.QUESTION_MARK); err != nil {
+		return "", err
+	} else if skp {
+		return ast.NullabilityOptional, nil
+	}
+	return "", nil
+}
+
 func (p *Parser) parseArguments() ([]*ast.Argument, error) {
 	if !p.peek(lexer.PAREN_L) {
 		return nil, nil
@@ -545,14 +602,8 @@ func (p *Parser) parseValueLiteral(isConst bool) (ast.Value, error) {
 			Value: token.Value,
 			Loc:   p.loc(token.Start),
 		}, nil
-	case lexer.STRING:
-		if err := p.advance(); err != nil {
-			return nil, err
-		}
-		return &ast.StringValue{
-			Value: token.Value,
-			Loc:   p.loc(token.Start),
-		}, nil
+	case lexer.STRING, lexer.BLOCK_STRING:
+		return p.parseStringLiteral()
 	case lexer.NAME:
 		if token.Value == "true" || token.Value == "false" {
 			if err := p.advance(); err != nil {
@@ -575,6 +626,15 @@ func (p *Parser) parseValueLiteral(isConst bool) (ast.Value, error) {
 		if !isConst {
 			return p.parseVariable()
 		}
+		variable, err := p.parseVariable()
+		if err != nil {
+			return nil, err
+		}
+		name := ""
+		if variable.Name != nil {
+			name = variable.Name.Value
+		}
+		return nil, gqlerrors.NewSyntaxError(p.Source, token.Start, fmt.Sprintf(`Unexpected variable "$%s" in constant value.`, name))
 	}
 	return nil, p.unexpected(lexer.Token{})
 }
@@ -587,6 +647,18 @@ func (p *Parser) parseValueValue() (any, error) {
 	return p.parseValueLiteral(false)
 }
 
+func (p *Parser) parseStringLiteral() (*ast.StringValue, error) {
+	token := p.tok
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	return &ast.StringValue{
+		Value: token.Value,
+		Block: token.Kind == lexer.BLOCK_STRING,
+		Loc:   p.loc(token.Start),
+	}, nil
+}
+
 func (p *Parser) parseList(isConst bool) (*ast.ListValue, error) {
 	start := p.tok.Start
 	var item parseFn
@@ -748,7 +820,11 @@ func (p *Parser) parseNamed() (*ast.Named, error) {
 // SchemaDefinition : schema { OperationTypeDefinition+ }
 func (p *Parser) parseSchemaDefinition() (*ast.SchemaDefinition, error) {
 	start := p.tok.Start
-	_, err := p.expectKeyWord("schema")
+	description, err := p.parseDescription()
+	if err != nil {
+		return nil, err
+	}
+	_, err = p.expectKeyWord("schema")
 	if err != nil {
 		return nil, err
 	}
@@ -767,6 +843,7 @@ func (p *Parser) parseSchemaDefinition() (*ast.SchemaDefinition, error) {
 		}
 	}
 	return &ast.SchemaDefinition{
+		Description:    description,
 		OperationTypes: operationTypes,
 		Directives:     directives,
 		Loc:            p.loc(start),
@@ -796,12 +873,26 @@ func (p *Parser) parseOperationTypeDefinition() (any, error) {
 
 /* Implements the parsing rules in the Type Definition section. */
 
+/**
+ * Description : StringValue
+ */
+func (p *Parser) parseDescription() (*ast.StringValue, error) {
+	if !p.peekDescription() {
+		return nil, nil
+	}
+	return p.parseStringLiteral()
+}
+
 /**
  * ScalarTypeDefinition : scalar Name Directives?
  */
 func (p *Parser) parseScalarTypeDefinition() (*ast.ScalarDefinition, error) {
 	start := p.tok.Start
-	_, err := p.expectKeyWord("scalar")
+	description, err := p.parseDescription()
+	if err != nil {
+		return nil, err
+	}
+	_, err = p.expectKeyWord("scalar")
 	if err != nil {
 		return nil, err
 	}
@@ -814,9 +905,10 @@ func (p *Parser) parseScalarTypeDefinition() (*ast.ScalarDefinition, error) {
 		return nil, err
 	}
 	def := &ast.ScalarDefinition{
-		Name:       name,
-		Directives: directives,
-		Loc:        p.loc(start),
+		Description: description,
+		Name:        name,
+		Directives:  directives,
+		Loc:         p.loc(start),
 	}
 	return def, nil
 }
@@ -828,7 +920,11 @@ func (p *Parser) parseObjectTypeDefinition() (*ast.ObjectDefinition, error) {
 	docComment := p.leadComment
 
 	start := p.tok.Start
-	_, err := p.expectKeyWord("type")
+	description, err := p.parseDescription()
+	if err != nil {
+		return nil, err
+	}
+	_, err = p.expectKeyWord("type")
 
 	if err != nil {
 		return nil, err
@@ -856,12 +952,13 @@ func (p *Parser) parseObjectTypeDefinition() (*ast.ObjectDefinition, error) {
 		}
 	}
 	return &ast.ObjectDefinition{
-		Name:       name,
-		Loc:        p.loc(start),
-		Interfaces: interfaces,
-		Directives: directives,
-		Fields:     fields,
-		Doc:        docComment,
+		Description: description,
+		Name:        name,
+		Loc:         p.loc(start),
+		Interfaces:  interfaces,
+		Directives:  directives,
+		Fields:      fields,
+		Doc:         docComment,
 	}, nil
 }
 
@@ -896,6 +993,10 @@ func (p *Parser) parseFieldDefinition() (any, error) {
 	docComment := p.leadComment
 
 	start := p.tok.Start
+	description, err := p.parseDescription()
+	if err != nil {
+		return nil, err
+	}
 	name, err := p.parseName()
 	if err != nil {
 		return nil, err
@@ -917,13 +1018,14 @@ func (p *Parser) parseFieldDefinition() (any, error) {
 		return nil, err
 	}
 	return &ast.FieldDefinition{
-		Name:       name,
-		Arguments:  args,
-		Type:       ttype,
-		Directives: directives,
-		Loc:        p.loc(start),
-		Doc:        docComment,
-		Comment:    p.lineComment,
+		Description: description,
+		Name:        name,
+		Arguments:   args,
+		Type:        ttype,
+		Directives:  directives,
+		Loc:         p.loc(start),
+		Doc:         docComment,
+		Comment:     p.lineComment,
 	}, nil
 }
 
@@ -950,6 +1052,10 @@ func (p *Parser) parseArgumentDefs() ([]*ast.InputValueDefinition, error) {
 func (p *Parser) parseInputValueDef() (any, error) {
 	docComment := p.leadComment
 	start := p.tok.Start
+	description, err := p.parseDescription()
+	if err != nil {
+		return nil, err
+	}
 	name, err := p.parseName()
 	if err != nil {
 		return nil, err
@@ -979,6 +1085,7 @@ func (p *Parser) parseInputValueDef() (any, error) {
 		return nil, err
 	}
 	return &ast.InputValueDefinition{
+		Description:  description,
 		Name:         name,
 		Type:         ttype,
 		DefaultValue: defaultValue,
@@ -992,7 +1099,11 @@ func (p *Parser) parseInputValueDef() (any, error) {
 func (p *Parser) parseInterfaceTypeDefinition() (*ast.InterfaceDefinition, error) {
 	docComment := p.leadComment
 	start := p.tok.Start
-	_, err := p.expectKeyWord("interface")
+	description, err := p.parseDescription()
+	if err != nil {
+		return nil, err
+	}
+	_, err = p.expectKeyWord("interface")
 	if err != nil {
 		return nil, err
 	}
@@ -1015,11 +1126,12 @@ func (p *Parser) parseInterfaceTypeDefinition() (*ast.InterfaceDefinition, error
 		}
 	}
 	return &ast.InterfaceDefinition{
-		Name:       name,
-		Directives: directives,
-		Loc:        p.loc(start),
-		Fields:     fields,
-		Doc:        docComment,
+		Description: description,
+		Name:        name,
+		Directives:  directives,
+		Loc:         p.loc(start),
+		Fields:      fields,
+		Doc:         docComment,
 	}, nil
 }
 
@@ -1027,7 +1139,11 @@ func (p *Parser) parseInterfaceTypeDefinition() (*ast.InterfaceDefinition, error
 func (p *Parser) parseUnionTypeDefinition() (*ast.UnionDefinition, error) {
 	docComment := p.leadComment
 	start := p.tok.Start
-	_, err := p.expectKeyWord("union")
+	description, err := p.parseDescription()
+	if err != nil {
+		return nil, err
+	}
+	_, err = p.expectKeyWord("union")
 	if err != nil {
 		return nil, err
 	}
@@ -1048,12 +1164,13 @@ func (p *Parser) parseUnionTypeDefinition() (*ast.UnionDefinition, error) {
 		return nil, err
 	}
 	return &ast.UnionDefinition{
-		Name:       name,
-		Directives: directives,
-		Loc:        p.loc(start),
-		Types:      types,
-		Doc:        docComment,
-		Comment:    p.lineComment,
+		Description: description,
+		Name:        name,
+		Directives:  directives,
+		Loc:         p.loc(start),
+		Types:       types,
+		Doc:         docComment,
+		Comment:     p.lineComment,
 	}, nil
 }
 
@@ -1077,7 +1194,11 @@ func (p *Parser) parseUnionMembers() ([]*ast.Named, error) {
 func (p *Parser) parseEnumTypeDefinition() (*ast.EnumDefinition, error) {
 	docComment := p.leadComment
 	start := p.tok.Start
-	_, err := p.expectKeyWord("enum")
+	description, err := p.parseDescription()
+	if err != nil {
+		return nil, err
+	}
+	_, err = p.expectKeyWord("enum")
 	if err != nil {
 		return nil, err
 	}
@@ -1100,17 +1221,22 @@ func (p *Parser) parseEnumTypeDefinition() (*ast.EnumDefinition, error) {
 		}
 	}
 	return &ast.EnumDefinition{
-		Name:       name,
-		Directives: directives,
-		Loc:        p.loc(start),
-		Values:     values,
-		Doc:        docComment,
+		Description: description,
+		Name:        name,
+		Directives:  directives,
+		Loc:         p.loc(start),
+		Values:      values,
+		Doc:         docComment,
 	}, nil
 }
 
 func (p *Parser) parseEnumValueDefinition() (any, error) {
 	docComment := p.leadComment
 	start := p.tok.Start
+	description, err := p.parseDescription()
+	if err != nil {
+		return nil, err
+	}
 	name, err := p.parseName()
 	if err != nil {
 		return nil, err
@@ -1120,18 +1246,23 @@ func (p *Parser) parseEnumValueDefinition() (any, error) {
 		return nil, err
 	}
 	return &ast.EnumValueDefinition{
-		Name:       name,
-		Directives: directives,
-		Loc:        p.loc(start),
-		Doc:        docComment,
-		Comment:    p.lineComment,
+		Description: description,
+		Name:        name,
+		Directives:  directives,
+		Loc:         p.loc(start),
+		Doc:         docComment,
+		Comment:     p.lineComment,
 	}, nil
 }
 
 func (p *Parser) parseInputObjectTypeDefinition() (*ast.InputObjectDefinition, error) {
 	docComment := p.leadComment
 	start := p.tok.Start
-	_, err := p.expectKeyWord("input")
+	description, err := p.parseDescription()
+	if err != nil {
+		return nil, err
+	}
+	_, err = p.expectKeyWord("input")
 	if err != nil {
 		return nil, err
 	}
@@ -1154,11 +1285,12 @@ func (p *Parser) parseInputObjectTypeDefinition() (*ast.InputObjectDefinition, e
 		}
 	}
 	return &ast.InputObjectDefinition{
-		Name:       name,
-		Directives: directives,
-		Loc:        p.loc(start),
-		Fields:     fields,
-		Doc:        docComment,
+		Description: description,
+		Name:        name,
+		Directives:  directives,
+		Loc:         p.loc(start),
+		Fields:      fields,
+		Doc:         docComment,
 	}, nil
 }
 
@@ -1185,7 +1317,11 @@ func (p *Parser) parseTypeExtensionDefinition() (*ast.TypeExtensionDefinition, e
  */
 func (p *Parser) parseDirectiveDefinition() (*ast.DirectiveDefinition, error) {
 	start := p.tok.Start
-	_, err := p.expectKeyWord("directive")
+	description, err := p.parseDescription()
+	if err != nil {
+		return nil, err
+	}
+	_, err = p.expectKeyWord("directive")
 	if err != nil {
 		return nil, err
 	}
@@ -1201,6 +1337,13 @@ func (p *Parser) parseDirectiveDefinition() (*ast.DirectiveDefinition, error) {
 	if err != nil {
 		return nil, err
 	}
+	var repeatable bool
+	if p.peek(lexer.NAME) && p.tok.Value == "repeatable" {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		repeatable = true
+	}
 	_, err = p.expectKeyWord("on")
 	if err != nil {
 		return nil, err
@@ -1211,10 +1354,12 @@ func (p *Parser) parseDirectiveDefinition() (*ast.DirectiveDefinition, error) {
 	}
 
 	return &ast.DirectiveDefinition{
-		Loc:       p.loc(start),
-		Name:      name,
-		Arguments: args,
-		Locations: locations,
+		Description: description,
+		Loc:         p.loc(start),
+		Name:        name,
+		Arguments:   args,
+		Repeatable:  repeatable,
+		Locations:   locations,
 	}, nil
 }
 
@@ -1369,6 +1514,23 @@ func (p *Parser) peek(Kind int) bool {
 	return p.tok.Kind == Kind
 }
 
+// peekDescription determines if the next token is a description.
+func (p *Parser) peekDescription() bool {
+	return p.peek(lexer.STRING) || p.peek(lexer.BLOCK_STRING)
+}
+
+// lookahead returns the token after the next one, skipping over comments,
+// without changing the parser state.
+func (p *Parser) lookahead() (lexer.Token, error) {
+	lex := *p.Lexer
+	for {
+		tok, err := lex.NextToken()
+		if err != nil || tok.Kind != lexer.COMMENT {
+			return tok, err
+		}
+	}
+}
+
 // If the next token is of the given kind, return true after advancing
 // the parser. Otherwise, do not change the parser state and return false.
 func (p *Parser) skip(Kind int) (bool, error) {
@@ -295,7 +295,7 @@ func TestParsesVariableInlineValues(t *testing.T) {
 func TestParsesConstantDefaultValues(t *testing.T) {
 	test := errorMessageTest{
 		`query Foo($x: Complex = { a: { b: [ $var ] } }) { field }`,
-		`Syntax Error GraphQL (1:37) Unexpected $`,
+		`Syntax Error GraphQL (1:37) Unexpected variable "$var" in constant value.`,
 		false,
 	}
 	testErrorMessage(t, test)
@@ -513,6 +513,64 @@ func TestParsesExperimentalSubscriptionFeature(t *testing.T) {
 	}
 }
 
+func TestParsesVariableWidthUnicodeEscapes(t *testing.T) {
+	source := `{ a(s: "\u{1F600}") }`
+	if _, err := Parse(ParseParams{Source: source}); err == nil {
+		t.Fatal("expected a parse error without VariableWidthUnicodeEscapes")
+	}
+	doc, err := Parse(ParseParams{Source: source, Options: ParseOptions{VariableWidthUnicodeEscapes: true}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The printer doesn't use the variable width form
+	expected := `{
+  a(s: "😀")
+}
+`
+	if printed := printer.Print(doc); printed != expected {
+		t.Fatalf("expected %s, got %s", expected, printed)
+	}
+}
+
+func TestParsesClientControlledNullability(t *testing.T) {
+	source := `{ a! b(x: 1)? @include(if: true) c { d? e } }`
+	if _, err := Parse(ParseParams{Source: source}); err == nil {
+		t.Fatal("expected a parse error without ClientControlledNullability")
+	}
+	doc, err := Parse(ParseParams{Source: source, Options: ParseOptions{ClientControlledNullability: true}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	nullability := make(map[string]string)
+	var walk func(ss *ast.SelectionSet)
+	walk = func(ss *ast.SelectionSet) {
+		for _, sel := range ss.Selections {
+			field := sel.(*ast.Field)
+			nullability[field.Name.Value] = field.Nullability
+			if field.SelectionSet != nil {
+				walk(field.SelectionSet)
+			}
+		}
+	}
+	walk(doc.Definitions[0].(*ast.OperationDefinition).SelectionSet)
+	expected := map[string]string{
+		"a": ast.NullabilityRequired,
+		"b": ast.NullabilityOptional,
+		"c": "",
+		"d": ast.NullabilityOptional,
+		"e": "",
+	}
+	if !reflect.DeepEqual(expected, nullability) {
+		t.Fatalf("expected %v, got %v", expected, nullability)
+	}
+	if len(doc.Definitions[0].(*ast.OperationDefinition).SelectionSet.Selections[1].(*ast.Field).Directives) != 1 {
+		t.Fatal("expected the directive of b to be parsed")
+	}
+	if printed, expected := printer.Print(doc), "b(x: 1)? @include(if: true)"; !strings.Contains(printed, expected) {
+		t.Fatalf("expected %q to contain %q", printed, expected)
+	}
+}
+
 func TestParsesAnonymousMutationOperations(t *testing.T) {
 	source := `
 		mutation {
@@ -3,6 +3,7 @@ package parser
 import (
 	"encoding/json"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/sprucehealth/graphql/gqlerrors"
@@ -741,6 +742,87 @@ input Hello {
 	}
 }
 
+func TestSchemaParser_Descriptions(t *testing.T) {
+	body := `
+"""
+A greeting.
+"""
+type Hello {
+  "The world."
+  world(
+    """Who to greet."""
+    who: String
+  ): String
+}
+
+"Shade."
+enum Color {
+  "Reddish."
+  RED
+}
+
+"Skips fields."
+directive @skip(if: Boolean!) on FIELD
+
+"The schema."
+schema {
+  query: Hello
+}
+`
+	astDoc := parse(t, body)
+	hello := astDoc.Definitions[0].(*ast.ObjectDefinition)
+	if hello.Description == nil || hello.Description.Value != "A greeting." || !hello.Description.Block {
+		t.Fatalf("unexpected type description: %v", hello.Description)
+	}
+	if hello.Loc.Start != 1 {
+		t.Fatalf("expected type location to start at the description, got %d", hello.Loc.Start)
+	}
+	world := hello.Fields[0]
+	if world.Description == nil || world.Description.Value != "The world." || world.Description.Block {
+		t.Fatalf("unexpected field description: %v", world.Description)
+	}
+	if d := world.Arguments[0].Description; d == nil || d.Value != "Who to greet." {
+		t.Fatalf("unexpected argument description: %v", d)
+	}
+	color := astDoc.Definitions[1].(*ast.EnumDefinition)
+	if color.Description == nil || color.Description.Value != "Shade." {
+		t.Fatalf("unexpected enum description: %v", color.Description)
+	}
+	if d := color.Values[0].Description; d == nil || d.Value != "Reddish." {
+		t.Fatalf("unexpected enum value description: %v", d)
+	}
+	skip := astDoc.Definitions[2].(*ast.DirectiveDefinition)
+	if skip.Description == nil || skip.Description.Value != "Skips fields." {
+		t.Fatalf("unexpected directive description: %v", skip.Description)
+	}
+	schema := astDoc.Definitions[3].(*ast.SchemaDefinition)
+	if schema.Description == nil || schema.Description.Value != "The schema." {
+		t.Fatalf("unexpected schema description: %v", schema.Description)
+	}
+}
+
+func TestSchemaParser_RepeatableDirectiveDefinition(t *testing.T) {
+	astDoc := parse(t, `directive @tag(name: String) repeatable on FIELD | OBJECT
+directive @once on FIELD`)
+	tag := astDoc.Definitions[0].(*ast.DirectiveDefinition)
+	if !tag.Repeatable || len(tag.Locations) != 2 {
+		t.Fatalf("unexpected directive definition: %s", jsonString(tag))
+	}
+	if once := astDoc.Definitions[1].(*ast.DirectiveDefinition); once.Repeatable {
+		t.Fatalf("expected directive to not be repeatable: %s", jsonString(once))
+	}
+}
+
+func TestSchemaParser_DescriptionBeforeOperation(t *testing.T) {
+	_, err := Parse(ParseParams{Source: `"Not allowed." query { a }`})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if e, ok := err.(*gqlerrors.Error); !ok || e.Locations[0] != (location.SourceLocation{Line: 1, Column: 1}) || !strings.Contains(e.Message, `Unexpected String "Not allowed."`) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
 func jsonString(v any) string {
 	b, _ := json.MarshalIndent(v, "", "  ")
 	return string(b)
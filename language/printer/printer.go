@@ -5,6 +5,9 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
 
 	"github.com/sprucehealth/graphql/language/ast"
 )
@@ -39,6 +42,7 @@ func indent(s string) string {
 }
 
 type walker struct {
+	opts PrintOptions
 }
 
 func (w *walker) walkASTSlice(sl any) []string {
@@ -111,7 +115,7 @@ func (w *walker) walkAST(root ast.Node) string {
 		selectionSet := w.walkAST(node.SelectionSet)
 		return join(
 			[]string{
-				wrap("", alias, ": ") + name + wrap("(", args, ")"),
+				wrap("", alias, ": ") + name + wrap("(", args, ")") + node.Nullability,
 				directives,
 				selectionSet,
 			},
@@ -144,7 +148,10 @@ func (w *walker) walkAST(root ast.Node) string {
 	case *ast.FloatValue:
 		return node.Value
 	case *ast.StringValue:
-		return strconv.Quote(node.Value)
+		if node.Block {
+			return printBlockString(node.Value)
+		}
+		return printString(node.Value)
 	case *ast.BooleanValue:
 		return strconv.FormatBool(node.Value)
 	case *ast.EnumValue:
@@ -173,26 +180,26 @@ func (w *walker) walkAST(root ast.Node) string {
 	case *ast.SchemaDefinition:
 		operationTypesBlock := w.walkASTSliceAndBlock(node.OperationTypes)
 		directives := w.walkASTSliceAndJoin(node.Directives, " ")
-		return join([]string{"schema", directives, operationTypesBlock}, " ")
+		return w.description(node.Description) + join([]string{"schema", directives, operationTypesBlock}, " ")
 	case *ast.OperationTypeDefinition:
 		return fmt.Sprintf("%v: %v", node.Operation, node.Type)
 	case *ast.ScalarDefinition:
 		name := w.walkAST(node.Name)
 		directives := w.walkASTSliceAndJoin(node.Directives, " ")
-		return join([]string{"scalar", name, directives}, " ")
+		return w.description(node.Description) + join([]string{"scalar", name, directives}, " ")
 	case *ast.ObjectDefinition:
 		name := w.walkAST(node.Name)
 		interfaces := w.walkASTSliceAndJoin(node.Interfaces, ", ")
 		fields := w.walkASTSliceAndBlock(node.Fields)
 		directives := w.walkASTSliceAndJoin(node.Directives, " ")
-		return join([]string{joinComments(node.Doc, "", "\n") + "type", name, wrap("implements ", interfaces, ""), directives, fields}, " ")
+		return join([]string{w.docAndDescription(node.Doc, node.Description) + "type", name, wrap("implements ", interfaces, ""), directives, fields}, " ")
 	case *ast.FieldDefinition:
 		name := w.walkAST(node.Name)
 		ttype := w.walkAST(node.Type)
-		args := w.walkASTSliceAndJoin(node.Arguments, ", ")
+		args := w.argumentDefinitions(node.Arguments)
 		directives := w.walkASTSliceAndJoin(node.Directives, " ")
 		return join([]string{
-			joinComments(node.Doc, "", "\n") + name + wrap("(", args, ")") + ":",
+			w.docAndDescription(node.Doc, node.Description) + name + args + ":",
 			ttype, directives, joinComments(node.Comment, "", "")}, " ")
 	case *ast.InputValueDefinition:
 		name := w.walkAST(node.Name)
@@ -200,39 +207,39 @@ func (w *walker) walkAST(root ast.Node) string {
 		defaultValue := w.walkAST(node.DefaultValue)
 		directives := w.walkASTSliceAndJoin(node.Directives, " ")
 		return join([]string{
-			joinComments(node.Doc, "", "\n") + name + ":",
+			w.docAndDescription(node.Doc, node.Description) + name + ":",
 			ttype, wrap("= ", defaultValue, ""), directives + joinComments(node.Comment, "", "")}, " ")
 	case *ast.InterfaceDefinition:
 		name := w.walkAST(node.Name)
 		fields := w.walkASTSliceAndBlock(node.Fields)
 		directives := w.walkASTSliceAndJoin(node.Directives, " ")
 		return join([]string{
-			joinComments(node.Doc, "", "\n") + "interface",
+			w.docAndDescription(node.Doc, node.Description) + "interface",
 			name, directives, fields}, " ")
 	case *ast.UnionDefinition:
 		name := w.walkAST(node.Name)
 		types := w.walkASTSliceAndJoin(node.Types, " | ")
 		directives := w.walkASTSliceAndJoin(node.Directives, " ")
 		return join([]string{
-			joinComments(node.Doc, "", "\n") + "union",
+			w.docAndDescription(node.Doc, node.Description) + "union",
 			name, directives, "=", types + joinComments(node.Comment, " ", "")}, " ")
 	case *ast.EnumDefinition:
 		name := w.walkAST(node.Name)
 		values := w.walkASTSliceAndBlock(node.Values)
 		directives := w.walkASTSliceAndJoin(node.Directives, " ")
 		return join([]string{
-			joinComments(node.Doc, "", "\n") + "enum",
+			w.docAndDescription(node.Doc, node.Description) + "enum",
 			name, directives, values}, " ")
 	case *ast.EnumValueDefinition:
 		directives := w.walkASTSliceAndJoin(node.Directives, " ")
 		return join([]string{
-			joinComments(node.Doc, "", "\n") + w.walkAST(node.Name), directives, joinComments(node.Comment, "", "")}, " ")
+			w.docAndDescription(node.Doc, node.Description) + w.walkAST(node.Name), directives, joinComments(node.Comment, "", "")}, " ")
 	case *ast.InputObjectDefinition:
 		name := w.walkAST(node.Name)
 		fields := w.walkASTSliceAndBlock(node.Fields)
 		directives := w.walkASTSliceAndJoin(node.Directives, " ")
 		return join([]string{
-			joinComments(node.Doc, "", "\n") + "input", name, directives, fields}, " ")
+			w.docAndDescription(node.Doc, node.Description) + "input", name, directives, fields}, " ")
 	case *ast.TypeExtensionDefinition:
 		return "extend " + w.walkAST(node.Definition)
 	case *ast.CommentGroup:
@@ -244,8 +251,12 @@ func (w *walker) walkAST(root ast.Node) string {
 		return strings.Join(lines, "\n")
 	case *ast.DirectiveDefinition:
 		name := w.walkAST(node.Name)
-		args := wrap("(", w.walkASTSliceAndJoin(node.Arguments, ", "), ")")
-		return fmt.Sprintf("directive @%v%v on %v", name, args, w.walkASTSliceAndJoin(node.Locations, " | "))
+		args := w.argumentDefinitions(node.Arguments)
+		repeatable := ""
+		if node.Repeatable {
+			repeatable = " repeatable"
+		}
+		return w.description(node.Description) + fmt.Sprintf("directive @%v%v%v on %v", name, args, repeatable, w.walkASTSliceAndJoin(node.Locations, " | "))
 	case ast.Type:
 		return node.String()
 	case ast.Value:
@@ -254,6 +265,101 @@ func (w *walker) walkAST(root ast.Node) string {
 	return fmt.Sprintf("[Unknown node type %T]", root)
 }
 
+// description returns the printed description followed by a newline or an
+// empty string if there's no description.
+func (w *walker) description(desc *ast.StringValue) string {
+	if desc == nil {
+		return ""
+	}
+	return w.walkAST(desc) + "\n"
+}
+
+// argumentDefinitions returns the printed argument definitions. Arguments are
+// printed on separate lines if any of them has a description or doc comments.
+func (w *walker) argumentDefinitions(args []*ast.InputValueDefinition) string {
+	for _, arg := range args {
+		if arg.Description != nil || arg.Doc != nil {
+			return "(" + indent("\n"+w.walkASTSliceAndJoin(args, "\n")) + "\n)"
+		}
+	}
+	return wrap("(", w.walkASTSliceAndJoin(args, ", "), ")")
+}
+
+// printString prints a value as a quoted string. Unlike strconv.Quote only the
+// escape sequences of GraphQL are used: code points that aren't printable are
+// printed as \uXXXX escapes, with a surrogate pair for supplementary code points,
+// so the string is parsed back to the same value.
+func printString(value string) string {
+	var b strings.Builder
+	b.Grow(len(value) + 2)
+	b.WriteByte('"')
+	for i := 0; i < len(value); {
+		r, w := utf8.DecodeRuneInString(value[i:])
+		switch {
+		case r == utf8.RuneError && w == 1:
+			// Invalid UTF-8 is kept as is like in the source
+			b.WriteByte(value[i])
+		case r == '"':
+			b.WriteString(`\"`)
+		case r == '\\':
+			b.WriteString(`\\`)
+		case r == '\b':
+			b.WriteString(`\b`)
+		case r == '\f':
+			b.WriteString(`\f`)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case unicode.IsPrint(r):
+			b.WriteString(value[i : i+w])
+		case r > 0xFFFF:
+			r1, r2 := utf16.EncodeRune(r)
+			fmt.Fprintf(&b, `\u%04x\u%04x`, r1, r2)
+		default:
+			fmt.Fprintf(&b, `\u%04x`, r)
+		}
+		i += w
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// printBlockString prints a value as a block string. Values that fit on a
+// single line are printed inline.
+func printBlockString(value string) string {
+	escaped := strings.ReplaceAll(value, `"""`, `\"""`)
+	if !strings.Contains(value, "\n") && !strings.HasPrefix(value, " ") && !strings.HasPrefix(value, "\t") && !strings.HasSuffix(value, `"`) {
+		return `"""` + escaped + `"""`
+	}
+	return "\"\"\"\n" + escaped + "\n\"\"\""
+}
+
+// docAndDescription returns the printed doc comments and description of a type
+// system definition.
+func (w *walker) docAndDescription(doc *ast.CommentGroup, desc *ast.StringValue) string {
+	if w.opts.CommentDescriptions && desc == nil && doc != nil {
+		return w.description(commentsDescription(doc))
+	}
+	return joinComments(doc, "", "\n") + w.description(desc)
+}
+
+// commentsDescription returns the text of the comments without the leading # as a
+// description. Multi-line text is a block string.
+func commentsDescription(cg *ast.CommentGroup) *ast.StringValue {
+	lines := make([]string, len(cg.List))
+	for i, c := range cg.List {
+		text := strings.TrimPrefix(c.Text, "#")
+		lines[i] = strings.TrimPrefix(text, " ")
+	}
+	return &ast.StringValue{
+		Value: strings.Join(lines, "\n"),
+		Block: len(lines) > 1,
+	}
+}
+
 func joinComments(cg *ast.CommentGroup, prefix, suffix string) string {
 	if cg == nil {
 		return ""
@@ -265,6 +371,19 @@ func joinComments(cg *ast.CommentGroup, prefix, suffix string) string {
 	return prefix + strings.Join(lines, "\n") + suffix
 }
 
+// PrintOptions are the options of PrintWithOptions.
+type PrintOptions struct {
+	// CommentDescriptions prints the doc comments of type system definitions that
+	// don't have a description as their description instead (e.g. to migrate a
+	// schema documented with comments to descriptions).
+	CommentDescriptions bool
+}
+
 func Print(node ast.Node) string {
 	return (&walker{}).walkAST(node)
 }
+
+// PrintWithOptions prints the node like Print with the options.
+func PrintWithOptions(node ast.Node, opts PrintOptions) string {
+	return (&walker{opts: opts}).walkAST(node)
+}
@@ -57,6 +57,38 @@ func TestPrintsMinimalAST(t *testing.T) {
 	}
 }
 
+func TestPrintsStrings(t *testing.T) {
+	for value, expected := range map[string]string{
+		"plain":             `"plain"`,
+		"quote \" slash \\": `"quote \" slash \\"`,
+		"\b\f\n\r\t":        `"\b\f\n\r\t"`,
+		"\x00\a\v\x7f":      `"\u0000\u0007\u000b\u007f"`,
+		"фы世界 \U0001F600":   `"фы世界 😀"`,
+		"\u2028 \U000E0001": `"\u2028 \udb40\udc01"`,
+	} {
+		printed := printer.Print(&ast.StringValue{Value: value})
+		if printed != expected {
+			t.Errorf("Unexpected result for %q, Diff: %v", value, testutil.Diff(expected, printed))
+		}
+	}
+}
+
+// FuzzPrintStringRoundTrip checks that printed strings are parsed back to the
+// same value.
+func FuzzPrintStringRoundTrip(f *testing.F) {
+	for _, value := range []string{"", "plain", "\x00\a\\\"", "\U0001F600\U000E0001\u2028", "\xff"} {
+		f.Add(value)
+	}
+	f.Fuzz(func(t *testing.T, value string) {
+		printed := printer.Print(&ast.StringValue{Value: value})
+		doc := parse(t, "{ a(s: "+printed+") }")
+		field := doc.Definitions[0].(*ast.OperationDefinition).SelectionSet.Selections[0].(*ast.Field)
+		if parsed := field.Arguments[0].Value.(*ast.StringValue).Value; parsed != value {
+			t.Fatalf("Expected %q to round trip through %s, got %q", value, printed, parsed)
+		}
+	})
+}
+
 func TestPrinter_CorrectlyPrintsNonQueryOperationsWithoutName(t *testing.T) {
 	// Test #1
 	queryAstShorthanded := `query { id, name }`
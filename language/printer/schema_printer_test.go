@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/sprucehealth/graphql/language/ast"
+	"github.com/sprucehealth/graphql/language/parser"
 	"github.com/sprucehealth/graphql/language/printer"
 	"github.com/sprucehealth/graphql/testutil"
 )
@@ -140,3 +141,98 @@ directive @include(if: Boolean!) on FIELD | FRAGMENT_SPREAD | INLINE_FRAGMENT
 		t.Fatalf("Unexpected result")
 	}
 }
+
+func TestSchemaPrinter_PrintsDescriptions(t *testing.T) {
+	query := `"""
+A greeting.
+
+Over multiple lines.
+"""
+type Hello {
+  "The world."
+  world(
+    """Who to greet."""
+    who: String
+    times: Int
+  ): String
+  other: String
+}
+
+"""Shade."""
+enum Color {
+  "Reddish."
+  RED
+}
+
+"Skips fields."
+directive @skip(if: Boolean!) on FIELD
+
+"The schema."
+schema {
+  query: Hello
+}
+`
+	results := printer.Print(parse(t, query))
+	if results != query {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(query, results))
+	}
+	// Printing must be stable
+	if again := printer.Print(parse(t, results)); again != results {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(results, again))
+	}
+}
+
+func TestSchemaPrinter_PrintsCommentDescriptions(t *testing.T) {
+	query := `scalar Time
+
+# A greeting.
+#
+# Over multiple lines.
+type Hello {
+	# The world.
+	world(
+		# Who to greet.
+		who: String
+	): String
+	"Kept."
+	other: String
+}
+
+# Shade.
+enum Color {
+	# Reddish.
+	RED # line comment
+}
+`
+	document, err := parser.Parse(parser.ParseParams{Source: query, Options: parser.ParseOptions{NoSource: true, KeepComments: true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `scalar Time
+
+"""
+A greeting.
+
+Over multiple lines.
+"""
+type Hello {
+  "The world."
+  world(
+    "Who to greet."
+    who: String
+  ): String
+  "Kept."
+  other: String
+}
+
+"Shade."
+enum Color {
+  "Reddish."
+  RED # line comment
+}
+`
+	results := printer.PrintWithOptions(document, printer.PrintOptions{CommentDescriptions: true})
+	if results != expected {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, results))
+	}
+}
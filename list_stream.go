@@ -0,0 +1,93 @@
+package graphql
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/sprucehealth/graphql/gqlerrors"
+	"github.com/sprucehealth/graphql/language/ast"
+)
+
+// The resolver of a list field may return a channel instead of a slice (e.g. to
+// complete the items of a large export while they're read from a database). The
+// contract between the resolver (the producer) and the executor (the consumer) is:
+//
+//   - Items are received and completed one at a time in the order they're sent
+//     and the list ends when the channel is closed. The capacity of the channel
+//     is the number of items the producer may get ahead of the executor, so a
+//     producer that sends on an unbuffered or full channel waits for the
+//     executor.
+//   - A received value that's an error fails the field with the error. The list
+//     is null and the items received before the error are discarded, as for any
+//     other field error. Errors completing an item only fail the item (or the
+//     list if the item type is non-null).
+//   - Once the context of the request is done (the client disconnected or the
+//     operation timed out) the executor stops receiving and the field fails with
+//     the error of the context. The producer must stop sending when the context
+//     is done or it leaks.
+//   - The context passed to the resolver is canceled once the field is completed,
+//     including when the executor stops receiving early because the list failed
+//     (e.g. a non-null item is null or the result is too large).
+//
+// The whole list is completed before the result is returned: incremental
+// delivery (@stream) isn't supported. StreamList implements the producer side of
+// the contract.
+
+// StreamList returns a channel for the resolver of a list field that receives the
+// values produced by produce. produce is called in a new goroutine (see Go) with
+// a send function that waits while buffer values are waiting to be completed,
+// which bounds the memory used by a producer that's faster than the executor.
+// send returns the error of the context once it's done and produce should then
+// return. An error returned by produce fails the field after the values sent
+// before it.
+func StreamList(ctx context.Context, buffer int, produce func(ctx context.Context, send func(value any) error) error) <-chan any {
+	ch := make(chan any, buffer)
+	send := func(value any) error {
+		select {
+		case ch <- value:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	Go(ctx, "StreamList", func(ctx context.Context) {
+		defer close(ch)
+		if err := produce(ctx, send); err != nil && ctx.Err() == nil {
+			_ = send(err)
+		}
+	})
+	return ch
+}
+
+// completeChanListValue completes the items received from the channel returned by
+// the resolver of a list field until it's closed or the context is done.
+func completeChanListValue(ctx context.Context, eCtx *ExecutionContext, returnType *List, fieldASTs []*ast.Field, info ResolveInfo, resultVal reflect.Value, path []string) any {
+	if resultVal.Type().ChanDir()&reflect.RecvDir == 0 {
+		parentTypeName := ""
+		if info.ParentType != nil {
+			parentTypeName = info.ParentType.Name()
+		}
+		panic(gqlerrors.FormatError(gqlerrors.CodeChannelExpected.New(nil, nil, parentTypeName, info.FieldName)))
+	}
+	cases := []reflect.SelectCase{
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())},
+		{Dir: reflect.SelectRecv, Chan: resultVal},
+	}
+	itemType := returnType.OfType
+	completedResults := []any{}
+	for i := 0; ; i++ {
+		chosen, val, ok := reflect.Select(cases)
+		if chosen == 0 {
+			panic(gqlerrors.FormatError(context.Cause(ctx)))
+		}
+		if !ok {
+			return completedResults
+		}
+		item := val.Interface()
+		if err, ok := item.(error); ok {
+			panic(gqlerrors.FormatError(err))
+		}
+		eCtx.resultSize.add(resultValueOverhead, fieldASTs, path)
+		completedResults = append(completedResults, completeListItemCatchingError(ctx, eCtx, itemType, fieldASTs, info, item, path, i))
+	}
+}
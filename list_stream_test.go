@@ -0,0 +1,222 @@
+package graphql_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+// listStreamSchema returns a schema with an items field resolved by the resolver
+// and a sibling field that always resolves.
+func listStreamSchema(t *testing.T, itemType graphql.Output, resolve graphql.FieldResolveFn) graphql.Schema {
+	t.Helper()
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"items": &graphql.Field{Type: graphql.NewList(itemType), Resolve: resolve},
+				"other": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						return "other", nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+func TestListStream_Order(t *testing.T) {
+	schema := listStreamSchema(t, graphql.Int, func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+		ch := make(chan int)
+		go func() {
+			defer close(ch)
+			for i := 0; i < 5; i++ {
+				ch <- i
+			}
+		}()
+		return ch, nil
+	})
+	result := graphql.Do(context.Background(), graphql.Params{Schema: schema, RequestString: `{ items }`})
+	expected := &graphql.Result{Data: map[string]any{"items": []any{0, 1, 2, 3, 4}}}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestListStream_Backpressure(t *testing.T) {
+	const buffer = 2
+	var sent, maxLead atomic.Int64
+	itemType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Item",
+		Fields: graphql.Fields{
+			"n": &graphql.Field{
+				Type: graphql.Int,
+				Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+					n := p.Source.(int)
+					// Give the producer time to get ahead as far as it can
+					time.Sleep(time.Millisecond)
+					if lead := sent.Load() - int64(n); lead > maxLead.Load() {
+						maxLead.Store(lead)
+					}
+					return n, nil
+				},
+			},
+		},
+	})
+	schema := listStreamSchema(t, itemType, func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+		return graphql.StreamList(ctx, buffer, func(ctx context.Context, send func(any) error) error {
+			for i := 0; i < 10; i++ {
+				if err := send(i); err != nil {
+					return err
+				}
+				sent.Add(1)
+			}
+			return nil
+		}), nil
+	})
+	result := graphql.Do(context.Background(), graphql.Params{Schema: schema, RequestString: `{ items { n } }`})
+	if len(result.Errors) != 0 || len(result.Data.(map[string]any)["items"].([]any)) != 10 {
+		t.Fatalf("Unexpected result %+v", result)
+	}
+	// While item n is completed the producer has sent at most the items up to n,
+	// the item that's been received, and the buffered items.
+	if lead := maxLead.Load(); lead > buffer+1 {
+		t.Fatalf("Expected the producer to be at most %d items ahead, got %d", buffer+1, lead)
+	}
+}
+
+func TestListStream_Errors(t *testing.T) {
+	errProducer := errors.New("producer failed")
+	schema := listStreamSchema(t, graphql.String, func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+		return graphql.StreamList(ctx, 0, func(ctx context.Context, send func(any) error) error {
+			for _, v := range []string{"a", "b"} {
+				if err := send(v); err != nil {
+					return err
+				}
+			}
+			return errProducer
+		}), nil
+	})
+	result := graphql.Do(context.Background(), graphql.Params{Schema: schema, RequestString: `{ items other }`})
+	if !reflect.DeepEqual(map[string]any{"items": nil, "other": "other"}, result.Data) {
+		t.Fatalf("Expected the list to be null, got %+v", result.Data)
+	}
+	if len(result.Errors) != 1 || result.Errors[0].Message != errProducer.Error() {
+		t.Fatalf("Expected the error of the producer, got %#v", result.Errors)
+	}
+
+	// An item that can't be completed only fails the list if the item type is non-null
+	for itemType, items := range map[graphql.Output]any{
+		graphql.String:                     []any{"a", nil},
+		graphql.NewNonNull(graphql.String): nil,
+	} {
+		schema := listStreamSchema(t, itemType, func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+			ch := make(chan any, 2)
+			ch <- "a"
+			ch <- nil
+			close(ch)
+			return ch, nil
+		})
+		result := graphql.Do(context.Background(), graphql.Params{Schema: schema, RequestString: `{ items }`})
+		if !reflect.DeepEqual(map[string]any{"items": items}, result.Data) {
+			t.Errorf("%s: unexpected data %+v", itemType, result.Data)
+		}
+	}
+}
+
+func TestListStream_Cancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	produced := make(chan error, 1)
+	itemType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Item",
+		Fields: graphql.Fields{
+			"n": &graphql.Field{
+				Type: graphql.Int,
+				Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+					// The client disconnects while the list is completed
+					if n := p.Source.(int); n == 3 {
+						cancel()
+					}
+					return p.Source, nil
+				},
+			},
+		},
+	})
+	schema := listStreamSchema(t, itemType, func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+		return graphql.StreamList(ctx, 1, func(ctx context.Context, send func(any) error) error {
+			for i := 0; ; i++ {
+				if err := send(i); err != nil {
+					produced <- err
+					return err
+				}
+			}
+		}), nil
+	})
+	result := graphql.Do(ctx, graphql.Params{Schema: schema, RequestString: `{ items { n } }`})
+	if len(result.Errors) == 0 {
+		t.Fatalf("Expected an error, got %+v", result)
+	}
+	select {
+	case err := <-produced:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("Expected the producer to stop with context.Canceled, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the producer to stop")
+	}
+
+	// A producer that never sends fails the operation once it times out
+	schema = listStreamSchema(t, graphql.Int, func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+		return make(chan int), nil
+	})
+	result = graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: `{ items }`,
+		Timeout:       10 * time.Millisecond,
+	})
+	if len(result.Errors) != 1 || !errors.Is(result.Errors[0].OriginalError, graphql.ErrOperationTimeout) {
+		t.Fatalf("Expected a timeout error, got %+v", result)
+	}
+}
+
+func TestListStream_ProducerStopsWhenListFails(t *testing.T) {
+	stopped := make(chan error, 1)
+	schema := listStreamSchema(t, graphql.NewNonNull(graphql.String), func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+		return graphql.StreamList(ctx, 0, func(ctx context.Context, send func(any) error) error {
+			// The second item is null which fails the list while the producer
+			// still has items to send.
+			for _, v := range []any{"a", nil, "b"} {
+				if err := send(v); err != nil {
+					stopped <- err
+					return err
+				}
+			}
+			stopped <- nil
+			return nil
+		}), nil
+	})
+	result := graphql.Do(context.Background(), graphql.Params{Schema: schema, RequestString: `{ items other }`})
+	if !reflect.DeepEqual(map[string]any{"items": nil, "other": "other"}, result.Data) {
+		t.Fatalf("Expected the list to be null, got %+v", result.Data)
+	}
+	select {
+	case err := <-stopped:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("Expected the producer to stop with context.Canceled, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the producer to stop")
+	}
+}
@@ -264,6 +264,7 @@ func TestLists_NonNullListOfNullableObjectsReturnsNull(t *testing.T) {
 		Errors: []gqlerrors.FormattedError{
 			{
 				Message: "Cannot return null for non-nullable field DataType.test.",
+				Code:    gqlerrors.CodeNonNullViolation,
 				Locations: []location.SourceLocation{
 					{
 						Line:   1,
@@ -334,6 +335,7 @@ func TestLists_NonNullListOfNullableFunc_ReturnsNull(t *testing.T) {
 		Errors: []gqlerrors.FormattedError{
 			{
 				Message: "Cannot return null for non-nullable field DataType.test.",
+				Code:    gqlerrors.CodeNonNullViolation,
 				Locations: []location.SourceLocation{
 					{
 						Line:   1,
@@ -430,12 +432,14 @@ func TestLists_NullableListOfNonNullObjects_ContainsNull(t *testing.T) {
 		Errors: []gqlerrors.FormattedError{
 			{
 				Message: "Cannot return null for non-nullable field DataType.test.",
+				Code:    gqlerrors.CodeNonNullViolation,
 				Locations: []location.SourceLocation{
 					{
 						Line:   1,
 						Column: 10,
 					},
 				},
+				Path: []any{"nest", "test", 1},
 			},
 		},
 	}
@@ -495,12 +499,14 @@ func TestLists_NullableListOfNonNullFunc_ContainsNull(t *testing.T) {
 		Errors: []gqlerrors.FormattedError{
 			{
 				Message: "Cannot return null for non-nullable field DataType.test.",
+				Code:    gqlerrors.CodeNonNullViolation,
 				Locations: []location.SourceLocation{
 					{
 						Line:   1,
 						Column: 10,
 					},
 				},
+				Path: []any{"nest", "test", 1},
 			},
 		},
 	}
@@ -606,12 +612,14 @@ func TestLists_NonNullListOfNonNullObjects_ContainsNull(t *testing.T) {
 		Errors: []gqlerrors.FormattedError{
 			{
 				Message: "Cannot return null for non-nullable field DataType.test.",
+				Code:    gqlerrors.CodeNonNullViolation,
 				Locations: []location.SourceLocation{
 					{
 						Line:   1,
 						Column: 10,
 					},
 				},
+				Path: []any{"nest", "test", 1},
 			},
 		},
 	}
@@ -627,6 +635,7 @@ func TestLists_NonNullListOfNonNullObjects_ReturnsNull(t *testing.T) {
 		Errors: []gqlerrors.FormattedError{
 			{
 				Message: "Cannot return null for non-nullable field DataType.test.",
+				Code:    gqlerrors.CodeNonNullViolation,
 				Locations: []location.SourceLocation{
 					{
 						Line:   1,
@@ -678,12 +687,14 @@ func TestLists_NonNullListOfNonNullFunc_ContainsNull(t *testing.T) {
 		Errors: []gqlerrors.FormattedError{
 			{
 				Message: "Cannot return null for non-nullable field DataType.test.",
+				Code:    gqlerrors.CodeNonNullViolation,
 				Locations: []location.SourceLocation{
 					{
 						Line:   1,
 						Column: 10,
 					},
 				},
+				Path: []any{"nest", "test", 1},
 			},
 		},
 	}
@@ -704,6 +715,7 @@ func TestLists_NonNullListOfNonNullFunc_ReturnsNull(t *testing.T) {
 		Errors: []gqlerrors.FormattedError{
 			{
 				Message: "Cannot return null for non-nullable field DataType.test.",
+				Code:    gqlerrors.CodeNonNullViolation,
 				Locations: []location.SourceLocation{
 					{
 						Line:   1,
@@ -782,9 +794,188 @@ func TestLists_UserErrorExpectIterableButDidNotGetOne(t *testing.T) {
 			{
 				Type:      "INTERNAL",
 				Message:   "User Error: expected iterable, but did not find one for field DataType.test.",
+				Code:      gqlerrors.CodeListExpected,
 				Locations: []location.SourceLocation{},
 			},
 		},
 	}
 	checkList(t, ttype, data, expected)
 }
+
+func TestLists_AbstractItemErrors(t *testing.T) {
+	type dog struct {
+		Name string `json:"name"`
+	}
+	type cat struct {
+		Name *string `json:"name"`
+	}
+	garfield := "Garfield"
+	var petType *graphql.Interface
+	dogType := graphql.NewObject(graphql.ObjectConfig{
+		Name:       "Dog",
+		Interfaces: graphql.InterfacesThunk(func() []*graphql.Interface { return []*graphql.Interface{petType} }),
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.String},
+		},
+	})
+	catType := graphql.NewObject(graphql.ObjectConfig{
+		Name:       "Cat",
+		Interfaces: graphql.InterfacesThunk(func() []*graphql.Interface { return []*graphql.Interface{petType} }),
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		},
+	})
+	petType = graphql.NewInterface(graphql.InterfaceConfig{
+		Name: "Pet",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.String},
+		},
+		ResolveType: func(ctx context.Context, p graphql.ResolveTypeParams) *graphql.Object {
+			switch v := p.Value.(type) {
+			case dog:
+				return dogType
+			case cat:
+				return catType
+			case string:
+				panic("unknown pet " + v)
+			}
+			return nil
+		},
+	})
+	pets := []any{dog{Name: "Odie"}, 1, cat{Name: &garfield}, "fish", cat{}}
+	listField := func(ttype graphql.Output) *graphql.Field {
+		return &graphql.Field{
+			Type: ttype,
+			Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+				return pets, nil
+			},
+		}
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"pets":              listField(graphql.NewList(petType)),
+				"nonNullPets":       listField(graphql.NewList(graphql.NewNonNull(petType))),
+				"nonNullListOfPets": listField(graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(petType)))),
+				"otherPets":         listField(graphql.NewList(petType)),
+			},
+		}),
+		Types: []graphql.Type{dogType, catType},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resolveTypeError := func(index int, value string) gqlerrors.FormattedError {
+		return gqlerrors.FormattedError{
+			Type: gqlerrors.ErrorTypeInternal,
+			Message: `Abstract type Pet must resolve to an Object type at runtime for field Query.pets with value "` +
+				value + `", received "<nil>".`,
+			Code:      gqlerrors.CodeUnresolvedAbstractType,
+			Locations: []location.SourceLocation{{Line: 1, Column: 3}},
+			Path:      []any{"pets", index},
+		}
+	}
+	cases := []struct {
+		name     string
+		query    string
+		expected *graphql.Result
+	}{
+		{
+			name:  "nullable items",
+			query: `{ pets { name } }`,
+			expected: &graphql.Result{
+				Data: map[string]any{"pets": []any{
+					map[string]any{"name": "Odie"},
+					nil,
+					map[string]any{"name": "Garfield"},
+					nil,
+					nil,
+				}},
+				Errors: []gqlerrors.FormattedError{
+					resolveTypeError(1, "1"),
+					{
+						Type:      gqlerrors.ErrorTypeInternal,
+						Message:   "unknown pet fish",
+						Locations: []location.SourceLocation{{Line: 1, Column: 3}},
+						Path:      []any{"pets", 3},
+					},
+					{
+						Type:      gqlerrors.ErrorTypeInternal,
+						Message:   "Cannot return null for non-nullable field Cat.name.",
+						Code:      gqlerrors.CodeNonNullViolation,
+						Locations: []location.SourceLocation{{Line: 1, Column: 10}},
+						Path:      []any{"pets", 4},
+					},
+				},
+			},
+		},
+		{
+			name:  "non-null items",
+			query: `{ nonNullPets { name } pets: otherPets { __typename } }`,
+			expected: &graphql.Result{
+				Data: map[string]any{
+					"nonNullPets": nil,
+					"pets":        []any{map[string]any{"__typename": "Dog"}, nil, map[string]any{"__typename": "Cat"}, nil, map[string]any{"__typename": "Cat"}},
+				},
+				Errors: []gqlerrors.FormattedError{
+					{
+						Type: gqlerrors.ErrorTypeInternal,
+						Message: `Abstract type Pet must resolve to an Object type at runtime for field Query.nonNullPets with value "1", ` +
+							`received "<nil>".`,
+						Code:      gqlerrors.CodeUnresolvedAbstractType,
+						Locations: []location.SourceLocation{{Line: 1, Column: 3}},
+						Path:      []any{"nonNullPets", 1},
+					},
+					{
+						Type: gqlerrors.ErrorTypeInternal,
+						Message: `Abstract type Pet must resolve to an Object type at runtime for field Query.otherPets with value "1", ` +
+							`received "<nil>".`,
+						Code:      gqlerrors.CodeUnresolvedAbstractType,
+						Locations: []location.SourceLocation{{Line: 1, Column: 24}},
+						Path:      []any{"otherPets", 1},
+					},
+					{
+						Type:      gqlerrors.ErrorTypeInternal,
+						Message:   "unknown pet fish",
+						Locations: []location.SourceLocation{{Line: 1, Column: 24}},
+						Path:      []any{"otherPets", 3},
+					},
+				},
+			},
+		},
+		{
+			name:  "non-null list",
+			query: `{ nonNullListOfPets { name } }`,
+			expected: &graphql.Result{
+				Errors: []gqlerrors.FormattedError{
+					{
+						Type: gqlerrors.ErrorTypeInternal,
+						Message: `Abstract type Pet must resolve to an Object type at runtime for field Query.nonNullListOfPets with value "1", ` +
+							`received "<nil>".`,
+						Code:      gqlerrors.CodeUnresolvedAbstractType,
+						Locations: []location.SourceLocation{{Line: 1, Column: 3}},
+						Path:      []any{"nonNullListOfPets", 1},
+					},
+				},
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			result := graphql.Do(context.Background(), graphql.Params{
+				Schema:        schema,
+				RequestString: c.query,
+				Deterministic: true,
+			})
+			for i := range result.Errors {
+				result.Errors[i].OriginalError = nil
+				result.Errors[i].StackTrace = ""
+			}
+			if !reflect.DeepEqual(c.expected, result) {
+				t.Fatal(testutil.Diff(c.expected, result))
+			}
+		})
+	}
+}
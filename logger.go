@@ -0,0 +1,40 @@
+package graphql
+
+import (
+	"context"
+	"reflect"
+)
+
+// Logger receives warnings about anomalies that don't fail a request but likely
+// point at a problem in the schema or resolvers (e.g. a value that can't be
+// serialized as its scalar type). Arguments are alternating keys and values
+// which means a *slog.Logger can be used directly.
+type Logger interface {
+	WarnContext(ctx context.Context, msg string, args ...any)
+}
+
+// defaultResolveAnomaly returns a warning if the default resolver can never find
+// the field on the source because the source isn't a map or doesn't have the
+// field. It returns an empty string otherwise.
+func defaultResolveAnomaly(source any, fieldName string, caseFallback bool) string {
+	if _, ok := source.(map[string]any); ok {
+		return ""
+	}
+	sourceVal := reflect.ValueOf(source)
+	for sourceVal.Kind() == reflect.Ptr || sourceVal.Kind() == reflect.Interface {
+		if sourceVal.IsNil() {
+			return ""
+		}
+		sourceVal = sourceVal.Elem()
+	}
+	if !sourceVal.IsValid() {
+		return ""
+	}
+	if sourceVal.Kind() != reflect.Struct {
+		return "graphql: default resolver source is not a map or struct"
+	}
+	if _, ok := lookupStructField(fieldInfoForStruct(sourceVal.Type()), fieldName, caseFallback); !ok {
+		return "graphql: default resolver source has no field with the name"
+	}
+	return ""
+}
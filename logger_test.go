@@ -0,0 +1,98 @@
+package graphql_test
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+)
+
+type testLogger struct {
+	warnings []string
+}
+
+func (l *testLogger) WarnContext(ctx context.Context, msg string, args ...any) {
+	l.warnings = append(l.warnings, fmt.Sprintf("%s %v", msg, args))
+}
+
+func TestLogger(t *testing.T) {
+	type account struct {
+		Name string `json:"name"`
+	}
+	nodeType := graphql.NewInterface(graphql.InterfaceConfig{
+		Name: "Node",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.ID},
+		},
+	})
+	accountType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Account",
+		Fields: graphql.Fields{
+			"name":  &graphql.Field{Type: graphql.String},
+			"email": &graphql.Field{Type: graphql.String},
+			"age": &graphql.Field{
+				Type: graphql.Int,
+				Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+					return "unknown", nil
+				},
+			},
+		},
+	})
+	schemaLogger := &testLogger{}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"account": &graphql.Field{
+					Type: accountType,
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						return &account{Name: "a"}, nil
+					},
+				},
+				"node": &graphql.Field{Type: nodeType},
+			},
+		}),
+		Types:  []graphql.Type{nil},
+		Logger: schemaLogger,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"graphql: ignoring nil type in schema config [index 0]"}
+	if !reflect.DeepEqual(expected, schemaLogger.warnings) {
+		t.Fatalf("Expected warnings %q, got %q", expected, schemaLogger.warnings)
+	}
+
+	// The schema logger is used for validation and execution
+	schemaLogger.warnings = nil
+	graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: `{ node { name } }`,
+	})
+	expected = []string{"graphql: abstract type has no possible types [type Node]"}
+	if !reflect.DeepEqual(expected, schemaLogger.warnings) {
+		t.Fatalf("Expected warnings %q, got %q", expected, schemaLogger.warnings)
+	}
+
+	// The request logger takes precedence
+	schemaLogger.warnings = nil
+	logger := &testLogger{}
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: `{ account { name email age } }`,
+		Logger:        logger,
+		Deterministic: true,
+	})
+	if len(result.Errors) != 0 {
+		t.Fatalf("Unexpected errors %+v", result.Errors)
+	}
+	expected = []string{
+		"graphql: default resolver source has no field with the name [type Account field email path [account email] sourceType *graphql_test.account]",
+		"graphql: value could not be serialized [type Int path [account age] valueType string]",
+	}
+	if !reflect.DeepEqual(expected, logger.warnings) || len(schemaLogger.warnings) != 0 {
+		t.Fatalf("Expected warnings %q, got %q and %q", expected, logger.warnings, schemaLogger.warnings)
+	}
+}
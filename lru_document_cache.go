@@ -0,0 +1,132 @@
+package graphql
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/sprucehealth/graphql/language/ast"
+)
+
+// DefaultLRUDocumentCacheSize is the default value for LRUDocumentCache.MaxEntries.
+const DefaultLRUDocumentCacheSize = 1000
+
+// LRUDocumentCache is a DocumentCache that evicts the least recently used
+// document once it holds MaxEntries documents and expires documents TTL after
+// they're added. Unlike MapDocumentCache it keeps the documents of the current
+// traffic which suits services that receive arbitrary or automatically
+// persisted queries. The zero value is ready to use.
+type LRUDocumentCache struct {
+	// MaxEntries is the maximum number of cached documents. Defaults to
+	// DefaultLRUDocumentCacheSize if 0. A negative value doesn't limit the size.
+	MaxEntries int
+	// TTL if greater than 0 is the time after which a document is removed from
+	// the cache regardless of how often it's used.
+	TTL time.Duration
+
+	mu      sync.Mutex
+	ll      *list.List
+	entries map[string]*list.Element
+	stats   DocumentCacheStats
+}
+
+// DocumentCacheStats are the counters of an LRUDocumentCache since it was
+// created (e.g. to export as metrics).
+type DocumentCacheStats struct {
+	// Entries is the current number of cached documents.
+	Entries int
+	Hits    int64
+	Misses  int64
+	// Evictions is the number of documents removed to make room for new ones.
+	Evictions int64
+	// Expirations is the number of documents removed because their TTL passed.
+	Expirations int64
+}
+
+type lruDocumentEntry struct {
+	query   string
+	doc     *ast.Document
+	expires time.Time
+}
+
+// Get implements DocumentCache.
+func (c *LRUDocumentCache) Get(query string) (*ast.Document, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.entries[query]
+	if !ok {
+		c.stats.Misses++
+		return nil, false
+	}
+	entry := el.Value.(*lruDocumentEntry)
+	if !entry.expires.IsZero() && time.Now().After(entry.expires) {
+		c.remove(el)
+		c.stats.Expirations++
+		c.stats.Misses++
+		return nil, false
+	}
+	c.ll.MoveToFront(el)
+	c.stats.Hits++
+	return entry.doc, true
+}
+
+// Add implements DocumentCache.
+func (c *LRUDocumentCache) Add(query string, doc *ast.Document) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil {
+		c.ll = list.New()
+		c.entries = make(map[string]*list.Element)
+	}
+	var expires time.Time
+	if c.TTL > 0 {
+		expires = time.Now().Add(c.TTL)
+	}
+	if el, ok := c.entries[query]; ok {
+		entry := el.Value.(*lruDocumentEntry)
+		entry.doc = doc
+		entry.expires = expires
+		c.ll.MoveToFront(el)
+		return
+	}
+	c.entries[query] = c.ll.PushFront(&lruDocumentEntry{query: query, doc: doc, expires: expires})
+	maxEntries := c.MaxEntries
+	if maxEntries == 0 {
+		maxEntries = DefaultLRUDocumentCacheSize
+	}
+	for maxEntries > 0 && c.ll.Len() > maxEntries {
+		c.remove(c.ll.Back())
+		c.stats.Evictions++
+	}
+}
+
+// Remove removes the document of the query from the cache.
+func (c *LRUDocumentCache) Remove(query string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[query]; ok {
+		c.remove(el)
+	}
+}
+
+// Len returns the number of cached documents including expired documents that
+// haven't been requested since they expired.
+func (c *LRUDocumentCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// Stats returns the counters of the cache.
+func (c *LRUDocumentCache) Stats() DocumentCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.stats
+	stats.Entries = len(c.entries)
+	return stats
+}
+
+func (c *LRUDocumentCache) remove(el *list.Element) {
+	c.ll.Remove(el)
+	delete(c.entries, el.Value.(*lruDocumentEntry).query)
+}
@@ -0,0 +1,74 @@
+package graphql_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/language/ast"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+func TestLRUDocumentCache(t *testing.T) {
+	cache := &graphql.LRUDocumentCache{MaxEntries: 2}
+	a, b, c := &ast.Document{}, &ast.Document{}, &ast.Document{}
+	cache.Add("a", a)
+	cache.Add("b", b)
+	if doc, ok := cache.Get("a"); !ok || doc != a {
+		t.Fatal("Expected a to be cached")
+	}
+	// b is the least recently used document
+	cache.Add("c", c)
+	if _, ok := cache.Get("b"); ok {
+		t.Fatal("Expected b to be evicted")
+	}
+	if doc, ok := cache.Get("c"); !ok || doc != c {
+		t.Fatal("Expected c to be cached")
+	}
+	cache.Remove("a")
+	expected := graphql.DocumentCacheStats{Entries: 1, Hits: 2, Misses: 1, Evictions: 1}
+	if stats := cache.Stats(); !reflect.DeepEqual(expected, stats) {
+		t.Fatalf("Unexpected stats, Diff: %v", testutil.Diff(expected, stats))
+	}
+
+	cache = &graphql.LRUDocumentCache{TTL: 10 * time.Millisecond}
+	cache.Add("a", a)
+	if _, ok := cache.Get("a"); !ok {
+		t.Fatal("Expected a to be cached")
+	}
+	time.Sleep(20 * time.Millisecond)
+	if _, ok := cache.Get("a"); ok {
+		t.Fatal("Expected a to expire")
+	}
+	expected = graphql.DocumentCacheStats{Hits: 1, Misses: 1, Expirations: 1}
+	if stats := cache.Stats(); !reflect.DeepEqual(expected, stats) {
+		t.Fatalf("Unexpected stats, Diff: %v", testutil.Diff(expected, stats))
+	}
+}
+
+func TestDo_LRUDocumentCache(t *testing.T) {
+	schema := testSchema(t, &graphql.Field{
+		Type: graphql.String,
+		Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+			return "ok", nil
+		},
+	})
+	cache := &graphql.LRUDocumentCache{}
+	for i := 0; i < 3; i++ {
+		result := graphql.Do(context.Background(), graphql.Params{
+			Schema:        schema,
+			RequestString: `{ test }`,
+			DocumentCache: cache,
+		})
+		expected := &graphql.Result{Data: map[string]any{"test": "ok"}}
+		if !reflect.DeepEqual(expected, result) {
+			t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+		}
+	}
+	expected := graphql.DocumentCacheStats{Entries: 1, Hits: 2, Misses: 1}
+	if stats := cache.Stats(); !reflect.DeepEqual(expected, stats) {
+		t.Fatalf("Unexpected stats, Diff: %v", testutil.Diff(expected, stats))
+	}
+}
@@ -0,0 +1,123 @@
+package graphql
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/sprucehealth/graphql/gqlerrors"
+	"github.com/sprucehealth/graphql/language/ast"
+)
+
+// MaskingPolicy masks the values of fields in the result of a request (e.g. to
+// redact PHI for callers that aren't clinicians) so that compliance rules are
+// applied in one place rather than in every resolver. The rules are applied to
+// the completed value of every field that isn't null, once the parent object and
+// field definition are known, and a record of the masked fields is passed to
+// Audit. See ExecuteParams.Masking.
+type MaskingPolicy struct {
+	// Rules are applied in order and the first rule that masks a value wins.
+	Rules []MaskRule
+	// Audit if set is called after the request is executed with the record of the
+	// masked fields if any field was masked.
+	Audit func(ctx context.Context, record *MaskAudit)
+}
+
+// MaskRule is a named masking rule of a MaskingPolicy.
+type MaskRule struct {
+	// Name identifies the rule in the audit record (e.g. "phi").
+	Name string
+	// Mask returns the value that replaces the completed value of the field and
+	// true if the value is masked for the request. The replacement must be a
+	// completed value of the type of the field (e.g. RedactedValue for a String or
+	// nil for a nullable field). A null replacement for a non-null field fails the
+	// field as if it had resolved to null.
+	Mask MaskFn
+}
+
+// MaskFn decides whether the completed value of a field is masked. Information
+// about the caller is usually taken from the context.
+type MaskFn func(ctx context.Context, parent *Object, field *FieldDefinition, value any) (replacement any, masked bool)
+
+// MaskAudit is the record of the fields masked in the result of a request.
+type MaskAudit struct {
+	OperationName string
+	// Masked are the masked fields sorted by path.
+	Masked []MaskedField
+}
+
+// MaskedField is a field masked by a rule.
+type MaskedField struct {
+	// Path is the response path of the field without list indices.
+	Path []string
+	// Rule is the name of the rule that masked the field.
+	Rule string
+	// Count is the number of values of the field that were masked (e.g. once per
+	// item of a list).
+	Count int
+}
+
+// masking is the state of the masking of a request.
+type masking struct {
+	policy *MaskingPolicy
+	mu     sync.Mutex
+	masked map[string]*MaskedField
+}
+
+func newMasking(policy *MaskingPolicy) *masking {
+	return &masking{policy: policy, masked: make(map[string]*MaskedField)}
+}
+
+// apply returns the completed value of the field masked by the first rule that
+// masks it.
+func (m *masking) apply(ctx context.Context, parent *Object, fieldDef *FieldDefinition, returnType Type, fieldASTs []*ast.Field, path []string, completed any) any {
+	if isNullish(completed) {
+		return completed
+	}
+	for _, rule := range m.policy.Rules {
+		replacement, masked := rule.Mask(ctx, parent, fieldDef, completed)
+		if !masked {
+			continue
+		}
+		m.record(rule.Name, path)
+		if _, ok := returnType.(*NonNull); ok && isNullish(replacement) {
+			panic(gqlerrors.FormatError(gqlerrors.CodeNonNullViolation.New(FieldASTsToNodeASTs(fieldASTs), nil, parent, fieldDef.Name)))
+		}
+		return replacement
+	}
+	return completed
+}
+
+func (m *masking) record(rule string, path []string) {
+	key := rule + "\x00" + strings.Join(path, "\x00")
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if f := m.masked[key]; f != nil {
+		f.Count++
+		return
+	}
+	m.masked[key] = &MaskedField{Path: slices.Clone(path), Rule: rule, Count: 1}
+}
+
+// audit passes the record of the masked fields to the audit function of the
+// policy if any field was masked.
+func (m *masking) audit(ctx context.Context, operation ast.Definition) {
+	if m.policy.Audit == nil || len(m.masked) == 0 {
+		return
+	}
+	record := &MaskAudit{}
+	if op, ok := operation.(*ast.OperationDefinition); ok && op.GetName() != nil {
+		record.OperationName = op.GetName().Value
+	}
+	for _, f := range m.masked {
+		record.Masked = append(record.Masked, *f)
+	}
+	slices.SortFunc(record.Masked, func(a, b MaskedField) int {
+		if c := slices.Compare(a.Path, b.Path); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Rule, b.Rule)
+	})
+	m.policy.Audit(ctx, record)
+}
@@ -0,0 +1,106 @@
+package graphql_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+type clinicianKey struct{}
+
+func TestMasking(t *testing.T) {
+	phi := map[string]any{"phi": true}
+	patient := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Patient",
+		Fields: graphql.Fields{
+			"id":   &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"name": &graphql.Field{Type: graphql.NewNonNull(graphql.String), Metadata: phi},
+			"dob":  &graphql.Field{Type: graphql.String, Metadata: phi},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"patients": &graphql.Field{
+					Type: graphql.NewList(patient),
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						return []any{
+							map[string]any{"id": "1", "name": "Ann", "dob": "1970-01-01"},
+							map[string]any{"id": "2", "name": "Bob"},
+						}, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var audits []*graphql.MaskAudit
+	policy := &graphql.MaskingPolicy{
+		Rules: []graphql.MaskRule{
+			{
+				Name: "phi",
+				Mask: func(ctx context.Context, parent *graphql.Object, field *graphql.FieldDefinition, value any) (any, bool) {
+					if field.Metadata["phi"] != true || ctx.Value(clinicianKey{}) != nil {
+						return nil, false
+					}
+					if field.Name == "name" {
+						return graphql.RedactedValue, true
+					}
+					return nil, true
+				},
+			},
+		},
+		Audit: func(ctx context.Context, record *graphql.MaskAudit) {
+			audits = append(audits, record)
+		},
+	}
+	query := `query Patients { patients { id name dob } }`
+
+	result := graphql.Do(context.Background(), graphql.Params{Schema: schema, RequestString: query, Masking: policy})
+	expected := &graphql.Result{
+		Data: map[string]any{
+			"patients": []any{
+				map[string]any{"id": "1", "name": graphql.RedactedValue, "dob": nil},
+				map[string]any{"id": "2", "name": graphql.RedactedValue, "dob": nil},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	expectedAudits := []*graphql.MaskAudit{{
+		OperationName: "Patients",
+		Masked: []graphql.MaskedField{
+			// The dob of the second patient is null so there's nothing to mask
+			{Path: []string{"patients", "dob"}, Rule: "phi", Count: 1},
+			{Path: []string{"patients", "name"}, Rule: "phi", Count: 2},
+		},
+	}}
+	if !reflect.DeepEqual(expectedAudits, audits) {
+		t.Fatalf("Unexpected audits, Diff: %v", testutil.Diff(expectedAudits, audits))
+	}
+
+	// Nothing is masked or audited for clinicians
+	audits = nil
+	ctx := context.WithValue(context.Background(), clinicianKey{}, true)
+	result = graphql.Do(ctx, graphql.Params{Schema: schema, RequestString: query, Masking: policy})
+	if name := result.Data.(map[string]any)["patients"].([]any)[0].(map[string]any)["name"]; name != "Ann" || len(audits) != 0 {
+		t.Fatalf("Expected nothing to be masked, got %+v and audits %+v", result, audits)
+	}
+
+	// A null replacement for a non-null field nulls the parent
+	policy.Rules[0].Mask = func(ctx context.Context, parent *graphql.Object, field *graphql.FieldDefinition, value any) (any, bool) {
+		return nil, field.Name == "name"
+	}
+	result = graphql.Do(context.Background(), graphql.Params{Schema: schema, RequestString: `{ patients { name } }`, Masking: policy})
+	if !reflect.DeepEqual(map[string]any{"patients": []any{nil, nil}}, result.Data) || len(result.Errors) != 2 {
+		t.Fatalf("Expected the patients to be null, got %+v", result)
+	}
+}
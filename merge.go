@@ -0,0 +1,171 @@
+package graphql
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// MergeConflictError is returned when merging results that provide different
+// values for the same response path.
+type MergeConflictError struct {
+	Path []any
+}
+
+func (e *MergeConflictError) Error() string {
+	return fmt.Sprintf("conflicting values at path %v", e.Path)
+}
+
+// MergeResults deep merges the provided results at the root into a new result.
+// See Result.MergeAt for the merge semantics. The data of the provided results
+// is reused so it may be modified by the merge.
+func MergeResults(results ...*Result) (*Result, error) {
+	merged := &Result{}
+	for _, r := range results {
+		if err := merged.MergeAt(nil, r); err != nil {
+			return merged, err
+		}
+	}
+	return merged, nil
+}
+
+// MergeAt deep merges the data and errors of other into the result at the given
+// response path. Path elements are strings for object fields and ints for list
+// indexes. This is used to combine partial results (e.g. from delegated or
+// batched executions) into a single response. Data from other is not copied so
+// it should not be modified after merging.
+//
+// The merge semantics are:
+//   - A field missing or null in the result is set to the value from other.
+//   - Two objects (map[string]any) are merged recursively.
+//   - Two lists ([]any) of the same length are merged element by element.
+//   - Otherwise the values must be equal or a *MergeConflictError is returned.
+//
+// Errors from other are appended with their path prefixed by the merge path.
+// Objects missing along the path are created, but the path may not traverse a
+// null value since a null parent means the data was discarded.
+func (r *Result) MergeAt(path []any, other *Result) error {
+	if other == nil {
+		return nil
+	}
+	for _, err := range other.Errors {
+		if len(path) != 0 {
+			err.Path = append(append(make([]any, 0, len(path)+len(err.Path)), path...), err.Path...)
+		}
+		r.Errors = append(r.Errors, err)
+	}
+	if other.Data == nil {
+		return nil
+	}
+	if len(path) == 0 {
+		merged, err := mergeValues(nil, r.Data, other.Data)
+		if err != nil {
+			return err
+		}
+		r.Data = merged
+		return nil
+	}
+
+	if r.Data == nil {
+		r.Data = map[string]any{}
+	}
+	parent := r.Data
+	for i, elem := range path[:len(path)-1] {
+		child, err := pathChild(parent, elem, path[:i+1])
+		if err != nil {
+			return err
+		}
+		parent = child
+	}
+	last := path[len(path)-1]
+	switch key := last.(type) {
+	case string:
+		m, ok := parent.(map[string]any)
+		if !ok {
+			return fmt.Errorf("path %v does not reference an object", path[:len(path)-1])
+		}
+		merged, err := mergeValues(path, m[key], other.Data)
+		if err != nil {
+			return err
+		}
+		m[key] = merged
+	case int:
+		l, ok := parent.([]any)
+		if !ok || key < 0 || key >= len(l) {
+			return fmt.Errorf("path %v does not reference a list item", path)
+		}
+		merged, err := mergeValues(path, l[key], other.Data)
+		if err != nil {
+			return err
+		}
+		l[key] = merged
+	default:
+		return fmt.Errorf("invalid path element %v of type %T", last, last)
+	}
+	return nil
+}
+
+func pathChild(parent, elem any, path []any) (any, error) {
+	var child any
+	switch key := elem.(type) {
+	case string:
+		m, ok := parent.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("path %v does not reference an object", path[:len(path)-1])
+		}
+		child, ok = m[key]
+		if !ok {
+			child = map[string]any{}
+			m[key] = child
+		}
+	case int:
+		l, ok := parent.([]any)
+		if !ok || key < 0 || key >= len(l) {
+			return nil, fmt.Errorf("path %v does not reference a list item", path)
+		}
+		child = l[key]
+	default:
+		return nil, fmt.Errorf("invalid path element %v of type %T", elem, elem)
+	}
+	if child == nil {
+		return nil, fmt.Errorf("path %v references a null value", path)
+	}
+	return child, nil
+}
+
+func mergeValues(path []any, dst, src any) (any, error) {
+	if dst == nil {
+		return src, nil
+	}
+	switch d := dst.(type) {
+	case map[string]any:
+		if s, ok := src.(map[string]any); ok {
+			for k, v := range s {
+				merged, err := mergeValues(appendPath(path, k), d[k], v)
+				if err != nil {
+					return dst, err
+				}
+				d[k] = merged
+			}
+			return d, nil
+		}
+	case []any:
+		if s, ok := src.([]any); ok && len(s) == len(d) {
+			for i, v := range s {
+				merged, err := mergeValues(appendPath(path, i), d[i], v)
+				if err != nil {
+					return dst, err
+				}
+				d[i] = merged
+			}
+			return d, nil
+		}
+	}
+	if !reflect.DeepEqual(dst, src) {
+		return dst, &MergeConflictError{Path: path}
+	}
+	return dst, nil
+}
+
+func appendPath(path []any, elem any) []any {
+	return append(append(make([]any, 0, len(path)+1), path...), elem)
+}
@@ -0,0 +1,91 @@
+package graphql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/gqlerrors"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+func TestMergeResults(t *testing.T) {
+	merged, err := graphql.MergeResults(
+		&graphql.Result{
+			Data: map[string]any{
+				"a": "a",
+				"b": map[string]any{"c": 1},
+				"l": []any{map[string]any{"x": 1}, nil},
+			},
+		},
+		&graphql.Result{
+			Data: map[string]any{
+				"a": "a",
+				"b": map[string]any{"d": 2},
+				"l": []any{map[string]any{"y": 2}, map[string]any{"z": 3}},
+			},
+			Errors: []gqlerrors.FormattedError{{Message: "boom"}},
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &graphql.Result{
+		Data: map[string]any{
+			"a": "a",
+			"b": map[string]any{"c": 1, "d": 2},
+			"l": []any{map[string]any{"x": 1, "y": 2}, map[string]any{"z": 3}},
+		},
+		Errors: []gqlerrors.FormattedError{{Message: "boom"}},
+	}
+	if !reflect.DeepEqual(expected, merged) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, merged))
+	}
+}
+
+func TestMergeResults_Conflict(t *testing.T) {
+	_, err := graphql.MergeResults(
+		&graphql.Result{Data: map[string]any{"a": map[string]any{"b": 1}}},
+		&graphql.Result{Data: map[string]any{"a": map[string]any{"b": 2}}},
+	)
+	conflict, ok := err.(*graphql.MergeConflictError)
+	if !ok {
+		t.Fatalf("Expected *MergeConflictError, got %T %v", err, err)
+	}
+	if !reflect.DeepEqual([]any{"a", "b"}, conflict.Path) {
+		t.Fatalf("Unexpected conflict path %v", conflict.Path)
+	}
+}
+
+func TestResultMergeAt(t *testing.T) {
+	result := &graphql.Result{
+		Data: map[string]any{
+			"list": []any{map[string]any{"id": 1}},
+			"null": nil,
+		},
+	}
+	err := result.MergeAt([]any{"list", 0, "friend"}, &graphql.Result{
+		Data:   map[string]any{"name": "Foo"},
+		Errors: []gqlerrors.FormattedError{{Message: "boom", Path: []any{"age"}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &graphql.Result{
+		Data: map[string]any{
+			"list": []any{map[string]any{"id": 1, "friend": map[string]any{"name": "Foo"}}},
+			"null": nil,
+		},
+		Errors: []gqlerrors.FormattedError{{Message: "boom", Path: []any{"list", 0, "friend", "age"}}},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	if err := result.MergeAt([]any{"null", "a"}, &graphql.Result{Data: 1}); err == nil {
+		t.Fatal("Expected error merging under a null value")
+	}
+	if err := result.MergeAt([]any{"list", 1}, &graphql.Result{Data: 1}); err == nil {
+		t.Fatal("Expected error merging at an out of range index")
+	}
+}
@@ -0,0 +1,105 @@
+package graphql
+
+import (
+	"context"
+	"reflect"
+	"runtime/debug"
+	"sync"
+)
+
+// HealthMetaFieldDef is the meta field definition of the health check which is
+// only available when enabled in the schema config.
+var HealthMetaFieldDef = &FieldDefinition{
+	Name:        "_health",
+	Type:        NewNonNull(Boolean),
+	Description: "Always true when the service executes requests.",
+	Args:        []*Argument{},
+	Resolve: func(ctx context.Context, p ResolveParams) (any, error) {
+		return true, nil
+	},
+}
+
+// SchemaInfoType is the type of the schema info meta field.
+var SchemaInfoType = NewObject(ObjectConfig{
+	Name:        "_SchemaInfo",
+	Description: "Build metadata of the service.",
+	Fields: Fields{
+		"hash": &Field{
+			Type:        NewNonNull(String),
+			Description: "A stable hash of the current type schema of this server.",
+		},
+		"packageVersion": &Field{
+			Type:        NewNonNull(String),
+			Description: "The version of the GraphQL package used by the server.",
+		},
+		"buildVersion": &Field{
+			Type:        String,
+			Description: "The version of the service if it's configured.",
+		},
+		"features": &Field{
+			Type:        NewNonNull(NewList(NewNonNull(String))),
+			Description: "The features enabled for the request.",
+		},
+	},
+})
+
+// SchemaInfoMetaFieldDef is the meta field definition of the schema info which is
+// only available when enabled in the schema config.
+var SchemaInfoMetaFieldDef = &FieldDefinition{
+	Name:        "_schemaInfo",
+	Type:        NewNonNull(SchemaInfoType),
+	Description: "Build metadata of the service.",
+	Args:        []*Argument{},
+	Resolve: func(ctx context.Context, p ResolveParams) (any, error) {
+		var buildVersion any
+		if p.Info.Schema.buildVersion != "" {
+			buildVersion = p.Info.Schema.buildVersion
+		}
+		return map[string]any{
+			"hash":           p.Info.Schema.Hash(),
+			"packageVersion": packageVersion(),
+			"buildVersion":   buildVersion,
+			"features":       enabledFeatures(ctx),
+		}, nil
+	},
+}
+
+// optInMetaFieldDef returns the definition of the meta field of the query type
+// with the name if it's enabled in the schema config.
+func optInMetaFieldDef(schema *Schema, name string) *FieldDefinition {
+	switch {
+	case name == SchemaVersionMetaFieldDef.Name && schema.schemaVersionField:
+		return SchemaVersionMetaFieldDef
+	case name == HealthMetaFieldDef.Name && schema.healthField:
+		return HealthMetaFieldDef
+	case name == SchemaInfoMetaFieldDef.Name && schema.schemaInfoField:
+		return SchemaInfoMetaFieldDef
+	}
+	return nil
+}
+
+// packageVersion returns the version of the module of the package from the build
+// info of the binary, or "(devel)" if it's unknown (e.g. the package is part of
+// the main module or replaced by a local directory).
+var packageVersion = sync.OnceValue(func() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "(devel)"
+	}
+	modulePath := reflect.TypeOf(Schema{}).PkgPath()
+	if info.Main.Path == modulePath && info.Main.Version != "" {
+		return info.Main.Version
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == modulePath {
+			if dep.Replace != nil {
+				dep = dep.Replace
+			}
+			if dep.Version != "" {
+				return dep.Version
+			}
+			break
+		}
+	}
+	return "(devel)"
+})
@@ -0,0 +1,59 @@
+package graphql_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+func TestMetaFields(t *testing.T) {
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"a": &graphql.Field{Type: graphql.String},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query:           query,
+		HealthField:     true,
+		SchemaInfoField: true,
+		BuildVersion:    "v1.2.3",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := graphql.WithFeatures(context.Background(), "beta", "alpha", "beta")
+	result := graphql.Do(ctx, graphql.Params{
+		Schema:        schema,
+		RequestString: `{ _health _schemaInfo { hash packageVersion buildVersion features } }`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]any{
+			"_health": true,
+			"_schemaInfo": map[string]any{
+				"hash":           schema.Hash(),
+				"packageVersion": "(devel)",
+				"buildVersion":   "v1.2.3",
+				"features":       []any{"alpha", "beta"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	// The fields aren't part of the schema unless they're enabled
+	schema, err = graphql.NewSchema(graphql.SchemaConfig{Query: query})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, q := range []string{`{ _health }`, `{ _schemaInfo { hash } }`} {
+		result := graphql.Do(context.Background(), graphql.Params{Schema: schema, RequestString: q})
+		if len(result.Errors) != 1 {
+			t.Errorf("%s: expected a validation error, got %+v", q, result)
+		}
+	}
+}
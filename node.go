@@ -0,0 +1,169 @@
+package graphql
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/sprucehealth/graphql/gqlerrors"
+)
+
+// NodeInterface is the Relay Node interface of objects that can be fetched by a
+// global ID through the node field added by SchemaConfig.Node. Object types
+// registered with a NodeFetcher must implement it.
+var NodeInterface = NewInterface(InterfaceConfig{
+	Name:        "Node",
+	Description: "An object with a globally unique ID.",
+	Fields: Fields{
+		"id": &Field{
+			Type:        NewNonNull(ID),
+			Description: "The globally unique ID of the object.",
+		},
+	},
+})
+
+// NodeFetcher returns the object with the given ID. The ID is the type specific
+// part of the global ID. Returning nil resolves the node field to null.
+type NodeFetcher func(ctx context.Context, id string) (any, error)
+
+// NodeConfig configures the node(id: ID!) field of the query type.
+type NodeConfig struct {
+	// Fetchers maps the names of object types that implement NodeInterface to the
+	// function that fetches objects of the type by ID.
+	Fetchers map[string]NodeFetcher
+}
+
+// ToGlobalID returns the global ID of an object from the name of its type and
+// its type specific ID.
+func ToGlobalID(typeName, id string) string {
+	return base64.StdEncoding.EncodeToString([]byte(typeName + ":" + id))
+}
+
+// FromGlobalID returns the type name and type specific ID of a global ID.
+func FromGlobalID(globalID string) (typeName, id string, err error) {
+	b, err := base64.StdEncoding.DecodeString(globalID)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid global ID %q", globalID)
+	}
+	typeName, id, ok := strings.Cut(string(b), ":")
+	if !ok || typeName == "" {
+		return "", "", fmt.Errorf("invalid global ID %q", globalID)
+	}
+	return typeName, id, nil
+}
+
+// GlobalIDField returns an id field that resolves to the global ID of the object
+// using idFn to get the type specific ID from the source.
+func GlobalIDField(typeName string, idFn func(ctx context.Context, source any) (string, error)) *Field {
+	return &Field{
+		Type:        NewNonNull(ID),
+		Description: "The globally unique ID of the object.",
+		Resolve: func(ctx context.Context, p ResolveParams) (any, error) {
+			id, err := idFn(ctx, p.Source)
+			if err != nil {
+				return nil, err
+			}
+			return ToGlobalID(typeName, id), nil
+		},
+	}
+}
+
+// nodeValue is the value returned by the node field. It carries the object type
+// of the value as the type is known from the global ID.
+type nodeValue struct {
+	typ   *Object
+	value any
+}
+
+// withNodeField returns a copy of the query type with the node field added. The
+// query type itself isn't changed so that it can be shared by schemas with and
+// without a node field. The field looks up the fetchers in the schema being
+// executed so that schemas sharing the query type use their own fetchers.
+func withNodeField(query *Object) (*Object, error) {
+	fields := query.Fields()
+	if err := query.Error(); err != nil {
+		return nil, err
+	}
+	if _, ok := fields["node"]; ok {
+		return nil, gqlerrors.NewFormattedError("Query type already has a node field.")
+	}
+	nodeField := &Field{
+		Type:        NodeInterface,
+		Description: "Fetches an object given its globally unique ID.",
+		Args: FieldConfigArgument{
+			"id": &ArgumentConfig{
+				Type:        NewNonNull(ID),
+				Description: "The globally unique ID of the object.",
+			},
+		},
+		Resolve: func(ctx context.Context, p ResolveParams) (any, error) {
+			globalID, _ := p.Args["id"].(string)
+			typeName, id, err := FromGlobalID(globalID)
+			if err != nil {
+				return nil, err
+			}
+			fetch := p.Info.Schema.nodeFetchers[typeName]
+			if fetch == nil {
+				return nil, fmt.Errorf("invalid global ID %q", globalID)
+			}
+			value, err := fetch(ctx, id)
+			if err != nil || isNullish(value) {
+				return nil, err
+			}
+			typ, _ := p.Info.Schema.Type(typeName).(*Object)
+			return &nodeValue{typ: typ, value: value}, nil
+		},
+	}
+
+	copied := &Object{
+		PrivateName:        query.PrivateName,
+		PrivateDescription: query.PrivateDescription,
+		IsTypeOf:           query.IsTypeOf,
+		BatchResolve:       query.BatchResolve,
+		OnResolved:         query.OnResolved,
+		Bulkhead:           query.Bulkhead,
+		typeConfig:         query.typeConfig,
+		interfaces:         query.Interfaces(),
+		dependentFields:    query.hasDependentFields(),
+	}
+	copied.setErr(nil)
+	// The field configs are copied too as AddFieldConfig on the copy must not add
+	// fields to the query type.
+	switch configFields := query.typeConfig.Fields.(type) {
+	case Fields:
+		configFields = maps.Clone(configFields)
+		configFields["node"] = nodeField
+		copied.typeConfig.Fields = configFields
+	case FieldsThunk:
+		copied.typeConfig.Fields = FieldsThunk(func() Fields {
+			fields := maps.Clone(configFields())
+			fields["node"] = nodeField
+			return fields
+		})
+	}
+	// The definitions of the other fields are shared with the query type.
+	nodeFields, err := defineFieldMap(copied, Fields{"node": nodeField})
+	if err != nil {
+		return nil, err
+	}
+	copied.fields = maps.Clone(fields)
+	copied.fields["node"] = nodeFields["node"]
+	return copied, nil
+}
+
+// validateNodeFetchers checks that all types with a fetcher are object types that
+// implement the Node interface.
+func validateNodeFetchers(schema *Schema, config *NodeConfig) error {
+	for _, typeName := range sortedKeys(config.Fetchers) {
+		obj, ok := schema.Type(typeName).(*Object)
+		if !ok {
+			return gqlerrors.NewFormattedError(fmt.Sprintf(`Node fetcher registered for "%v" which is not an object type in the schema.`, typeName))
+		}
+		if !schema.IsPossibleType(NodeInterface, obj) {
+			return gqlerrors.NewFormattedError(fmt.Sprintf(`Node fetcher registered for "%v" which does not implement Node.`, typeName))
+		}
+	}
+	return nil
+}
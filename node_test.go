@@ -0,0 +1,222 @@
+package graphql_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+)
+
+func TestGlobalID(t *testing.T) {
+	globalID := graphql.ToGlobalID("User", "1:2")
+	typeName, id, err := graphql.FromGlobalID(globalID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if typeName != "User" || id != "1:2" {
+		t.Fatalf("Expected User and 1:2, got %s and %s", typeName, id)
+	}
+	for _, invalid := range []string{"", "not base64", graphql.ToGlobalID("", "1")} {
+		if _, _, err := graphql.FromGlobalID(invalid); err == nil {
+			t.Errorf("Expected error for %q", invalid)
+		}
+	}
+}
+
+func TestNodeField(t *testing.T) {
+	type user struct {
+		ID   string
+		Name string `json:"name"`
+	}
+	userType := graphql.NewObject(graphql.ObjectConfig{
+		Name:       "User",
+		Interfaces: []*graphql.Interface{graphql.NodeInterface},
+		Fields: graphql.Fields{
+			"id": graphql.GlobalIDField("User", func(ctx context.Context, source any) (string, error) {
+				return source.(*user).ID, nil
+			}),
+			"name": &graphql.Field{Type: graphql.String},
+		},
+	})
+	postType := graphql.NewObject(graphql.ObjectConfig{
+		Name:       "Post",
+		Interfaces: []*graphql.Interface{graphql.NodeInterface},
+		Fields: graphql.Fields{
+			"id": graphql.GlobalIDField("Post", func(ctx context.Context, source any) (string, error) {
+				return source.(map[string]any)["id"].(string), nil
+			}),
+			"title": &graphql.Field{Type: graphql.String},
+		},
+	})
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"viewer": &graphql.Field{Type: userType},
+		},
+	})
+	nodeConfig := &graphql.NodeConfig{
+		Fetchers: map[string]graphql.NodeFetcher{
+			"User": func(ctx context.Context, id string) (any, error) {
+				if id != "1" {
+					return nil, nil
+				}
+				return &user{ID: id, Name: "Alice"}, nil
+			},
+			"Post": func(ctx context.Context, id string) (any, error) {
+				return map[string]any{"id": id, "title": "Hello"}, nil
+			},
+		},
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: queryType,
+		Types: []graphql.Type{postType},
+		Node:  nodeConfig,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	userID := graphql.ToGlobalID("User", "1")
+	postID := graphql.ToGlobalID("Post", "7")
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema: schema,
+		RequestString: `query ($user: ID!, $post: ID!, $missing: ID!) {
+			user: node(id: $user) { id ... on User { name } }
+			post: node(id: $post) { __typename id ... on Post { title } }
+			missing: node(id: $missing) { id }
+		}`,
+		VariableValues: map[string]any{
+			"user":    userID,
+			"post":    postID,
+			"missing": graphql.ToGlobalID("User", "2"),
+		},
+	})
+	if len(result.Errors) != 0 {
+		t.Fatalf("Unexpected errors %+v", result.Errors)
+	}
+	expected := map[string]any{
+		"user":    map[string]any{"id": userID, "name": "Alice"},
+		"post":    map[string]any{"__typename": "Post", "id": postID, "title": "Hello"},
+		"missing": nil,
+	}
+	if !reflect.DeepEqual(expected, result.Data) {
+		t.Fatalf("Expected %v, got %v", expected, result.Data)
+	}
+
+	// Unregistered types are invalid IDs
+	result = graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: `{ node(id: "` + graphql.ToGlobalID("Comment", "1") + `") { id } }`,
+	})
+	if len(result.Errors) != 1 {
+		t.Fatalf("Expected invalid ID error, got %+v", result.Errors)
+	}
+
+	// The query type can be reused for another schema with its own fetchers
+	other, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: queryType,
+		Types: []graphql.Type{postType},
+		Node: &graphql.NodeConfig{
+			Fetchers: map[string]graphql.NodeFetcher{
+				"Post": func(ctx context.Context, id string) (any, error) {
+					return map[string]any{"id": id, "title": "Other"}, nil
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for s, title := range map[*graphql.Schema]string{&schema: "Hello", &other: "Other"} {
+		result := graphql.Do(context.Background(), graphql.Params{
+			Schema:        *s,
+			RequestString: `{ node(id: "` + postID + `") { ... on Post { title } } }`,
+		})
+		expected := map[string]any{"node": map[string]any{"title": title}}
+		if len(result.Errors) != 0 || !reflect.DeepEqual(expected, result.Data) {
+			t.Fatalf("Expected %v, got %+v", expected, result)
+		}
+	}
+
+	// Fetchers must be registered for types that implement Node
+	_, err = graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name:   "Query",
+			Fields: graphql.Fields{"viewer": &graphql.Field{Type: graphql.String}},
+		}),
+		Node: &graphql.NodeConfig{
+			Fetchers: map[string]graphql.NodeFetcher{"Query": nil},
+		},
+	})
+	if err == nil || err.Error() != `Node fetcher registered for "Query" which does not implement Node.` {
+		t.Fatalf("Expected error for type that does not implement Node, got %v", err)
+	}
+}
+
+func TestNodeField_SharedQueryType(t *testing.T) {
+	postType := graphql.NewObject(graphql.ObjectConfig{
+		Name:       "Post",
+		Interfaces: []*graphql.Interface{graphql.NodeInterface},
+		Fields: graphql.Fields{
+			"id": graphql.GlobalIDField("Post", func(ctx context.Context, source any) (string, error) {
+				return source.(string), nil
+			}),
+		},
+	})
+	var queryType *graphql.Object
+	queryType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"post":  &graphql.Field{Type: postType},
+				"query": &graphql.Field{Type: queryType},
+			}
+		}),
+	})
+	nodeConfig := &graphql.NodeConfig{
+		Fetchers: map[string]graphql.NodeFetcher{
+			"Post": func(ctx context.Context, id string) (any, error) {
+				return id, nil
+			},
+		},
+	}
+
+	// Schemas with and without the node field can be built in any order
+	for i := 0; i < 2; i++ {
+		withNode, err := graphql.NewSchema(graphql.SchemaConfig{Query: queryType, Types: []graphql.Type{postType}, Node: nodeConfig})
+		if err != nil {
+			t.Fatal(err)
+		}
+		withoutNode, err := graphql.NewSchema(graphql.SchemaConfig{Query: queryType, Types: []graphql.Type{postType}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := queryType.Fields()["node"]; ok {
+			t.Fatal("Expected the query type not to have a node field")
+		}
+		if _, ok := withoutNode.QueryType().Fields()["node"]; ok {
+			t.Fatal("Expected the schema without a node config not to have a node field")
+		}
+
+		postID := graphql.ToGlobalID("Post", "1")
+		result := graphql.Do(context.Background(), graphql.Params{
+			Schema:        withNode,
+			RequestString: `{ node(id: "` + postID + `") { id } query { post { id } } }`,
+		})
+		expected := map[string]any{
+			"node":  map[string]any{"id": postID},
+			"query": nil,
+		}
+		if len(result.Errors) != 0 || !reflect.DeepEqual(expected, result.Data) {
+			t.Fatalf("Expected %v, got %+v", expected, result)
+		}
+		result = graphql.Do(context.Background(), graphql.Params{
+			Schema:        withoutNode,
+			RequestString: `{ node(id: "` + postID + `") { id } }`,
+		})
+		if len(result.Errors) != 1 {
+			t.Fatalf("Expected an error for the node field, got %+v", result)
+		}
+	}
+}
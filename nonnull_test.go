@@ -719,6 +719,7 @@ func TestNonNull_NullsASynchronouslyReturnedObjectThatContainsANonNullableFieldT
 			{
 				Type:    gqlerrors.ErrorTypeInternal,
 				Message: `Cannot return null for non-nullable field DataType.nonNullSync.`,
+				Code:    gqlerrors.CodeNonNullViolation,
 				Locations: []location.SourceLocation{
 					{Line: 4, Column: 11},
 				},
@@ -759,6 +760,7 @@ func TestNonNull_NullsASynchronouslyReturnedObjectThatContainsANonNullableFieldT
 			{
 				Type:    gqlerrors.ErrorTypeInternal,
 				Message: `Cannot return null for non-nullable field DataType.nonNullPromise.`,
+				Code:    gqlerrors.CodeNonNullViolation,
 				Locations: []location.SourceLocation{
 					{Line: 4, Column: 11},
 				},
@@ -800,6 +802,7 @@ func TestNonNull_NullsAnObjectReturnedInAPromiseThatContainsANonNullableFieldTha
 			{
 				Type:    gqlerrors.ErrorTypeInternal,
 				Message: `Cannot return null for non-nullable field DataType.nonNullSync.`,
+				Code:    gqlerrors.CodeNonNullViolation,
 				Locations: []location.SourceLocation{
 					{Line: 4, Column: 11},
 				},
@@ -840,6 +843,7 @@ func TestNonNull_NullsAnObjectReturnedInAPromiseThatContainsANonNullableFieldTha
 			{
 				Type:    gqlerrors.ErrorTypeInternal,
 				Message: `Cannot return null for non-nullable field DataType.nonNullPromise.`,
+				Code:    gqlerrors.CodeNonNullViolation,
 				Locations: []location.SourceLocation{
 					{Line: 4, Column: 11},
 				},
@@ -1001,6 +1005,7 @@ func TestNonNull_NullsTheFirstNullableObjectAfterAFieldReturnsNullInALongChainOf
 			{
 				Type:    gqlerrors.ErrorTypeInternal,
 				Message: `Cannot return null for non-nullable field DataType.nonNullSync.`,
+				Code:    gqlerrors.CodeNonNullViolation,
 				Locations: []location.SourceLocation{
 					{Line: 8, Column: 19},
 				},
@@ -1008,6 +1013,7 @@ func TestNonNull_NullsTheFirstNullableObjectAfterAFieldReturnsNullInALongChainOf
 			{
 				Type:    gqlerrors.ErrorTypeInternal,
 				Message: `Cannot return null for non-nullable field DataType.nonNullSync.`,
+				Code:    gqlerrors.CodeNonNullViolation,
 				Locations: []location.SourceLocation{
 					{Line: 19, Column: 19},
 				},
@@ -1015,6 +1021,7 @@ func TestNonNull_NullsTheFirstNullableObjectAfterAFieldReturnsNullInALongChainOf
 			{
 				Type:    gqlerrors.ErrorTypeInternal,
 				Message: `Cannot return null for non-nullable field DataType.nonNullPromise.`,
+				Code:    gqlerrors.CodeNonNullViolation,
 				Locations: []location.SourceLocation{
 					{Line: 30, Column: 19},
 				},
@@ -1022,6 +1029,7 @@ func TestNonNull_NullsTheFirstNullableObjectAfterAFieldReturnsNullInALongChainOf
 			{
 				Type:    gqlerrors.ErrorTypeInternal,
 				Message: `Cannot return null for non-nullable field DataType.nonNullPromise.`,
+				Code:    gqlerrors.CodeNonNullViolation,
 				Locations: []location.SourceLocation{
 					{Line: 41, Column: 19},
 				},
@@ -1132,6 +1140,7 @@ func TestNonNull_NullsTheTopLevelIfSyncNonNullableFieldReturnsNull(t *testing.T)
 			{
 				Type:    gqlerrors.ErrorTypeInternal,
 				Message: `Cannot return null for non-nullable field DataType.nonNullSync.`,
+				Code:    gqlerrors.CodeNonNullViolation,
 				Locations: []location.SourceLocation{
 					{Line: 2, Column: 17},
 				},
@@ -1166,6 +1175,7 @@ func TestNonNull_NullsTheTopLevelIfSyncNonNullableFieldResolvesNull(t *testing.T
 			{
 				Type:    gqlerrors.ErrorTypeInternal,
 				Message: `Cannot return null for non-nullable field DataType.nonNullPromise.`,
+				Code:    gqlerrors.CodeNonNullViolation,
 				Locations: []location.SourceLocation{
 					{Line: 2, Column: 17},
 				},
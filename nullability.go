@@ -0,0 +1,23 @@
+package graphql
+
+import (
+	"github.com/sprucehealth/graphql/language/ast"
+)
+
+// fieldNullabilityType returns the type of a field in the response after applying
+// its client controlled nullability designator. A required field is non-null so a
+// null value or error propagates to the parent field, and an optional field is
+// nullable so an error stops at the field.
+func fieldNullabilityType(ttype Type, nullability string) Type {
+	switch nullability {
+	case ast.NullabilityRequired:
+		if _, ok := ttype.(*NonNull); !ok && ttype != nil {
+			return NewNonNull(ttype)
+		}
+	case ast.NullabilityOptional:
+		if nonNull, ok := ttype.(*NonNull); ok {
+			return nonNull.OfType
+		}
+	}
+	return ttype
+}
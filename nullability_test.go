@@ -0,0 +1,114 @@
+package graphql_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/language/parser"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+func TestClientControlledNullability(t *testing.T) {
+	objType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Obj",
+		Fields: graphql.Fields{
+			"x": &graphql.Field{Type: graphql.String},
+			"y": &graphql.Field{Type: graphql.String},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"obj": &graphql.Field{
+					Type: objType,
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						return map[string]any{"y": "y"}, nil
+					},
+				},
+				"fails": &graphql.Field{
+					Type: graphql.NewNonNull(graphql.String),
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						return nil, errors.New("failed")
+					},
+				},
+				"ok": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						return "ok", nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		query    string
+		data     any
+		messages []string
+	}{
+		{
+			query: `{ obj { x y } ok }`,
+			data:  map[string]any{"obj": map[string]any{"x": nil, "y": "y"}, "ok": "ok"},
+		},
+		{
+			query:    `{ obj { x! y } ok }`,
+			data:     map[string]any{"obj": nil, "ok": "ok"},
+			messages: []string{"Cannot return null for non-nullable field Obj.x."},
+		},
+		{
+			query:    `{ fails? ok }`,
+			data:     map[string]any{"fails": nil, "ok": "ok"},
+			messages: []string{"failed"},
+		},
+	}
+	for _, c := range cases {
+		result := graphql.Do(context.Background(), graphql.Params{
+			Schema:                      schema,
+			RequestString:               c.query,
+			ClientControlledNullability: true,
+		})
+		var data any
+		if result.Data != nil {
+			data = result.Data
+		}
+		if !reflect.DeepEqual(c.data, data) {
+			t.Errorf("%s: unexpected data, Diff: %v", c.query, testutil.Diff(c.data, data))
+		}
+		var messages []string
+		for _, err := range result.Errors {
+			messages = append(messages, err.Message)
+		}
+		if !reflect.DeepEqual(c.messages, messages) {
+			t.Errorf("%s: expected errors %v, got %v", c.query, c.messages, messages)
+		}
+	}
+
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: `{ fails? ok }`,
+	})
+	if len(result.Errors) != 1 || result.Data != nil {
+		t.Fatalf("Expected a syntax error without ClientControlledNullability, got %+v", result)
+	}
+
+	doc, err := parser.Parse(parser.ParseParams{
+		Source:  `{ x: ok! x: ok }`,
+		Options: parser.ParseOptions{ClientControlledNullability: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := graphql.ValidateDocument(&schema, doc, []graphql.ValidationRuleFn{graphql.OverlappingFieldsCanBeMergedRule})
+	expected := `Fields "x" conflict because they return conflicting types String! and String. ` +
+		`Use different aliases on the fields to fetch both if this was intentional.`
+	if len(r.Errors) != 1 || r.Errors[0].Message != expected {
+		t.Fatalf("Expected the conflict %q, got %+v", expected, r.Errors)
+	}
+}
@@ -0,0 +1,103 @@
+package graphql
+
+import (
+	"context"
+	"sort"
+
+	"github.com/sprucehealth/graphql/gqlerrors"
+	"github.com/sprucehealth/graphql/language/ast"
+)
+
+// OperationInfo describes the operation selected for execution. It lets
+// middleware classify a request (e.g. to enforce the HTTP method or to route
+// read-only requests to a replica) without parsing the document again.
+type OperationInfo struct {
+	// Type is the operation type (ast.OperationTypeQuery, ast.OperationTypeMutation,
+	// or ast.OperationTypeSubscription).
+	Type string
+	// Name is the name of the operation if it's named.
+	Name string
+	// NonIdempotentDirectives are the names of the directives marked NonIdempotent
+	// that are used by the operation, sorted by name.
+	NonIdempotentDirectives []string
+}
+
+// ReadOnly returns true if the operation is a query that doesn't use any non-idempotent directives.
+func (o *OperationInfo) ReadOnly() bool {
+	return o.Type == ast.OperationTypeQuery && len(o.NonIdempotentDirectives) == 0
+}
+
+// checkGETOperation returns a METHOD_NOT_ALLOWED error if the operation isn't a
+// query as it may not be sent with an HTTP GET request.
+func checkGETOperation(operation ast.Definition) error {
+	if operation.GetOperation() == ast.OperationTypeQuery {
+		return nil
+	}
+	return gqlerrors.FormatError(gqlerrors.CodeMethodNotAllowed.New([]ast.Node{operation}, nil, operation.GetOperation()))
+}
+
+// OperationHookFn is called with the selected operation before it's executed.
+// Returning an error aborts the request with the error.
+type OperationHookFn func(ctx context.Context, info *OperationInfo) error
+
+func newOperationInfo(schema *Schema, operation ast.Definition, fragments map[string]*ast.FragmentDefinition) *OperationInfo {
+	info := &OperationInfo{Type: operation.GetOperation()}
+
+	found := make(map[string]struct{})
+	checkDirectives := func(directives []*ast.Directive) {
+		for _, d := range directives {
+			if d == nil || d.Name == nil {
+				continue
+			}
+			if dir := schema.Directive(d.Name.Value); dir != nil && dir.NonIdempotent {
+				found[dir.Name] = struct{}{}
+			}
+		}
+	}
+	visitedFragments := make(map[string]struct{})
+	var visit func(ss *ast.SelectionSet)
+	visit = func(ss *ast.SelectionSet) {
+		if ss == nil {
+			return
+		}
+		for _, sel := range ss.Selections {
+			switch sel := sel.(type) {
+			case *ast.Field:
+				checkDirectives(sel.Directives)
+				visit(sel.SelectionSet)
+			case *ast.InlineFragment:
+				checkDirectives(sel.Directives)
+				visit(sel.SelectionSet)
+			case *ast.FragmentSpread:
+				checkDirectives(sel.Directives)
+				if sel.Name == nil {
+					continue
+				}
+				if _, ok := visitedFragments[sel.Name.Value]; ok {
+					continue
+				}
+				visitedFragments[sel.Name.Value] = struct{}{}
+				if fragment := fragments[sel.Name.Value]; fragment != nil {
+					checkDirectives(fragment.Directives)
+					visit(fragment.SelectionSet)
+				}
+			}
+		}
+	}
+	if op, ok := operation.(*ast.OperationDefinition); ok {
+		if op.Name != nil {
+			info.Name = op.Name.Value
+		}
+		checkDirectives(op.Directives)
+	}
+	visit(operation.GetSelectionSet())
+
+	if len(found) != 0 {
+		info.NonIdempotentDirectives = make([]string, 0, len(found))
+		for name := range found {
+			info.NonIdempotentDirectives = append(info.NonIdempotentDirectives, name)
+		}
+		sort.Strings(info.NonIdempotentDirectives)
+	}
+	return info
+}
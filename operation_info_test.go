@@ -0,0 +1,174 @@
+package graphql_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/gqlerrors"
+	"github.com/sprucehealth/graphql/language/ast"
+	"github.com/sprucehealth/graphql/language/location"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+func TestOperationHook(t *testing.T) {
+	auditDirective := graphql.NewDirective(graphql.DirectiveConfig{
+		Name:          "audit",
+		Locations:     []string{graphql.DirectiveLocationField},
+		NonIdempotent: true,
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"a": &graphql.Field{Type: graphql.String},
+			},
+		}),
+		Mutation: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Mutation",
+			Fields: graphql.Fields{
+				"b": &graphql.Field{Type: graphql.String},
+			},
+		}),
+		Directives: []*graphql.Directive{graphql.IncludeDirective, graphql.SkipDirective, auditDirective},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		query    string
+		expected graphql.OperationInfo
+		readOnly bool
+	}{
+		{
+			query:    `{ a }`,
+			expected: graphql.OperationInfo{Type: ast.OperationTypeQuery},
+			readOnly: true,
+		},
+		{
+			query:    `mutation M { b }`,
+			expected: graphql.OperationInfo{Type: ast.OperationTypeMutation, Name: "M"},
+		},
+		{
+			query: `query Q { ...F } fragment F on Query { a @audit @include(if: true) }`,
+			expected: graphql.OperationInfo{
+				Type:                    ast.OperationTypeQuery,
+				Name:                    "Q",
+				NonIdempotentDirectives: []string{"audit"},
+			},
+		},
+	}
+	for _, c := range cases {
+		var info *graphql.OperationInfo
+		result := graphql.Do(context.Background(), graphql.Params{
+			Schema:        schema,
+			RequestString: c.query,
+			OperationHook: func(ctx context.Context, i *graphql.OperationInfo) error {
+				info = i
+				return nil
+			},
+		})
+		if len(result.Errors) != 0 {
+			t.Fatalf("%s: unexpected errors: %v", c.query, result.Errors)
+		}
+		if info == nil || !reflect.DeepEqual(c.expected, *info) {
+			t.Fatalf("%s: unexpected operation info, Diff: %v", c.query, testutil.Diff(c.expected, info))
+		}
+		if info.ReadOnly() != c.readOnly {
+			t.Fatalf("%s: expected ReadOnly %t", c.query, c.readOnly)
+		}
+	}
+
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: `mutation { b }`,
+		RootObject: map[string]any{
+			"b": func() any {
+				t.Fatal("Mutation should not be executed")
+				return nil
+			},
+		},
+		OperationHook: func(ctx context.Context, info *graphql.OperationInfo) error {
+			if !info.ReadOnly() {
+				return errors.New("Mutations are not allowed in GET requests.")
+			}
+			return nil
+		},
+	})
+	if result.Data != nil || len(result.Errors) != 1 || result.Errors[0].Message != "Mutations are not allowed in GET requests." {
+		t.Fatalf("Unexpected result %+v", result)
+	}
+}
+
+func TestHTTPMethod(t *testing.T) {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"a": &graphql.Field{Type: graphql.String},
+			},
+		}),
+		Mutation: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Mutation",
+			Fields: graphql.Fields{
+				"b": &graphql.Field{Type: graphql.String},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		query    string
+		method   string
+		allowGET bool
+		expected *graphql.Result
+	}{
+		{
+			query:    `{ a }`,
+			method:   "GET",
+			expected: &graphql.Result{Data: map[string]any{"a": "a"}},
+		},
+		{
+			query:    `mutation { b }`,
+			method:   "POST",
+			expected: &graphql.Result{Data: map[string]any{"b": "b"}},
+		},
+		{
+			query:    `mutation { b }`,
+			method:   "GET",
+			allowGET: true,
+			expected: &graphql.Result{Data: map[string]any{"b": "b"}},
+		},
+		{
+			query:  `mutation { b }`,
+			method: "get",
+			expected: &graphql.Result{
+				Errors: []gqlerrors.FormattedError{
+					{
+						Type:      gqlerrors.ErrorTypeMethodNotAllowed,
+						Code:      gqlerrors.CodeMethodNotAllowed,
+						Message:   "Can only perform a mutation operation from a POST request.",
+						Locations: []location.SourceLocation{{Line: 1, Column: 1}},
+					},
+				},
+			},
+		},
+	}
+	for _, c := range cases {
+		result := graphql.Do(context.Background(), graphql.Params{
+			Schema:                schema,
+			RequestString:         c.query,
+			RootObject:            map[string]any{"a": "a", "b": "b"},
+			HTTPMethod:            c.method,
+			AllowMutationsOverGET: c.allowGET,
+		})
+		if !reflect.DeepEqual(c.expected, result) {
+			t.Errorf("%s %s: unexpected result, Diff: %v", c.method, c.query, testutil.Diff(c.expected, result))
+		}
+	}
+}
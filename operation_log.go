@@ -0,0 +1,98 @@
+package graphql
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/rand/v2"
+	"time"
+
+	"github.com/sprucehealth/graphql/language/ast"
+	"github.com/sprucehealth/graphql/language/printer"
+)
+
+// OperationLogMessage is the message of the entries logged for operations.
+const OperationLogMessage = "graphql operation"
+
+// OperationLogger receives a structured entry for every logged operation.
+// Arguments are alternating keys and values which means a *slog.Logger can be
+// used directly.
+type OperationLogger interface {
+	InfoContext(ctx context.Context, msg string, args ...any)
+}
+
+// OperationLogPolicy configures the logging of executed operations. Every entry
+// has the keys "operationType", "operationName", "signature" (a hash of the
+// printed operation), "duration", "errors" (the number of errors in the result),
+// "complexity" (the number of fields the operation selects with fragments
+// counted every time they're spread), and "variables" followed by the caller
+// metadata.
+type OperationLogPolicy struct {
+	// Logger receives the entries. It's required.
+	Logger OperationLogger
+	// SampleRate if set returns the fraction of requests of the operation that are
+	// logged between 0 (none) and 1 (all). All requests are logged if it's not set.
+	SampleRate func(ctx context.Context, info *OperationInfo) float64
+	// LogErrors if true logs every request that has errors regardless of sampling.
+	LogErrors bool
+	// Redact if set is called for every variable and input object field of the
+	// variables. Values for which it returns true are logged as RedactedValue.
+	// See CapturePolicy.Redact.
+	Redact func(path []string) bool
+	// CallerMetadata if set returns alternating keys and values that identify the
+	// caller (e.g. the account and the client version) which are added to the entry.
+	CallerMetadata func(ctx context.Context) []any
+}
+
+// operationLogEntry holds what's known about the operation before execution.
+type operationLogEntry struct {
+	info       *OperationInfo
+	signature  string
+	complexity int
+	sampled    bool
+}
+
+func newOperationLogEntry(ctx context.Context, policy *OperationLogPolicy, schema *Schema, operation ast.Definition, fragments map[string]*ast.FragmentDefinition) *operationLogEntry {
+	info := newOperationInfo(schema, operation, fragments)
+	sampled := true
+	if policy.SampleRate != nil {
+		rate := policy.SampleRate(ctx, info)
+		sampled = rate >= 1 || rate > 0 && rand.Float64() < rate
+	}
+	return &operationLogEntry{
+		info:       info,
+		signature:  operationHash(operation),
+		complexity: operationCost(operation, fragments),
+		sampled:    sampled,
+	}
+}
+
+// logOperation logs the entry for an executed operation. The entry is nil if the
+// request failed before an operation was selected.
+func logOperation(ctx context.Context, policy *OperationLogPolicy, entry *operationLogEntry, vars map[string]any, result *Result, duration time.Duration) {
+	if entry == nil {
+		entry = &operationLogEntry{info: &OperationInfo{}, sampled: true}
+	}
+	if !entry.sampled && (!policy.LogErrors || len(result.Errors) == 0) {
+		return
+	}
+	args := []any{
+		"operationType", entry.info.Type,
+		"operationName", entry.info.Name,
+		"signature", entry.signature,
+		"duration", duration,
+		"errors", len(result.Errors),
+		"complexity", entry.complexity,
+		"variables", redactVariables(vars, policy.Redact),
+	}
+	if policy.CallerMetadata != nil {
+		args = append(args, policy.CallerMetadata(ctx)...)
+	}
+	policy.Logger.InfoContext(ctx, OperationLogMessage, args...)
+}
+
+// operationHash returns a hash of the printed operation.
+func operationHash(operation ast.Definition) string {
+	h := sha256.Sum256([]byte(printer.Print(operation)))
+	return hex.EncodeToString(h[:8])
+}
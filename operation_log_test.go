@@ -0,0 +1,115 @@
+package graphql_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+)
+
+type operationLogEntry struct {
+	msg  string
+	args map[string]any
+}
+
+type testOperationLogger struct {
+	entries []operationLogEntry
+}
+
+func (l *testOperationLogger) InfoContext(ctx context.Context, msg string, args ...any) {
+	e := operationLogEntry{msg: msg, args: make(map[string]any)}
+	for i := 0; i+1 < len(args); i += 2 {
+		e.args[args[i].(string)] = args[i+1]
+	}
+	l.entries = append(l.entries, e)
+}
+
+func TestOperationLog(t *testing.T) {
+	schema := testSchema(t, &graphql.Field{
+		Type: graphql.String,
+		Args: graphql.FieldConfigArgument{
+			"password": &graphql.ArgumentConfig{Type: graphql.String},
+		},
+		Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+			if _, ok := p.Args["password"]; !ok {
+				return nil, errors.New("missing password")
+			}
+			return "ok", nil
+		},
+	})
+
+	logger := &testOperationLogger{}
+	policy := &graphql.OperationLogPolicy{
+		Logger: logger,
+		SampleRate: func(ctx context.Context, info *graphql.OperationInfo) float64 {
+			if info.Name == "Sampled" {
+				return 1
+			}
+			return 0
+		},
+		LogErrors: true,
+		Redact: func(path []string) bool {
+			return path[0] == "password"
+		},
+		CallerMetadata: func(ctx context.Context) []any {
+			return []any{"account", "a1"}
+		},
+	}
+
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema:         schema,
+		RequestString:  `query Sampled($password: String) { a: test(password: $password) b: test(password: $password) }`,
+		VariableValues: map[string]any{"password": "secret"},
+		OperationLog:   policy,
+	})
+	if len(result.Errors) != 0 {
+		t.Fatalf("Unexpected errors %+v", result.Errors)
+	}
+	if len(logger.entries) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(logger.entries))
+	}
+	e := logger.entries[0]
+	if e.msg != graphql.OperationLogMessage {
+		t.Fatalf("Unexpected message %q", e.msg)
+	}
+	if e.args["operationType"] != "query" || e.args["operationName"] != "Sampled" || e.args["signature"] == "" ||
+		e.args["errors"] != 0 || e.args["complexity"] != 2 || e.args["account"] != "a1" {
+		t.Fatalf("Unexpected entry %+v", e.args)
+	}
+	if vars := e.args["variables"].(map[string]any); vars["password"] != graphql.RedactedValue {
+		t.Fatalf("Expected password to be redacted, got %+v", vars)
+	}
+
+	// Not sampled
+	logger.entries = nil
+	graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: `query Other { test(password: "x") }`,
+		OperationLog:  policy,
+	})
+	if len(logger.entries) != 0 {
+		t.Fatalf("Expected no entries, got %+v", logger.entries)
+	}
+
+	// Requests with errors are logged regardless of sampling
+	graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: `query Other { test }`,
+		OperationLog:  policy,
+	})
+	if len(logger.entries) != 1 || logger.entries[0].args["errors"] != 1 {
+		t.Fatalf("Expected 1 entry with an error, got %+v", logger.entries)
+	}
+
+	// Invalid requests are logged without the operation
+	logger.entries = nil
+	graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: `{ unknown }`,
+		OperationLog:  policy,
+	})
+	if len(logger.entries) != 1 || logger.entries[0].args["errors"] != 1 || logger.entries[0].args["operationName"] != "" {
+		t.Fatalf("Expected 1 entry for the invalid request, got %+v", logger.entries)
+	}
+}
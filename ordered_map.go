@@ -0,0 +1,63 @@
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// KeyValue is a single entry of an OrderedMap.
+type KeyValue struct {
+	Key   string
+	Value any
+}
+
+// OrderedMap is an object that preserves the insertion order of its keys. It's
+// used for objects in Result.Data when ExecuteParams.OrderedData is set so that
+// fields are returned and encoded in the order they were requested.
+type OrderedMap []KeyValue
+
+// Get returns the value for the key and whether it was found.
+func (m OrderedMap) Get(key string) (any, bool) {
+	for _, kv := range m {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+	return nil, false
+}
+
+// Map returns the entries as a map[string]any. Nested values are not converted.
+func (m OrderedMap) Map() map[string]any {
+	out := make(map[string]any, len(m))
+	for _, kv := range m {
+		out[kv.Key] = kv.Value
+	}
+	return out
+}
+
+// MarshalJSON implements json.Marshaler encoding the entries as an object in order.
+func (m OrderedMap) MarshalJSON() ([]byte, error) {
+	if m == nil {
+		return []byte("null"), nil
+	}
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, kv := range m {
+		if i != 0 {
+			buf.WriteByte(',')
+		}
+		b, err := json.Marshal(kv.Key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(b)
+		buf.WriteByte(':')
+		b, err = json.Marshal(kv.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(b)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
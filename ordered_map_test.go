@@ -0,0 +1,71 @@
+package graphql_test
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+func TestOrderedData(t *testing.T) {
+	query := `
+		query HeroNameAndFriends {
+			hero {
+				name
+				id
+				... on Droid { primaryFunction }
+				friends { name }
+			}
+			a: hero(episode: EMPIRE) { name }
+			__typename
+		}
+	`
+	expected := graphql.OrderedMap{
+		{Key: "hero", Value: graphql.OrderedMap{
+			{Key: "name", Value: "R2-D2"},
+			{Key: "id", Value: "2001"},
+			{Key: "primaryFunction", Value: "Astromech"},
+			{Key: "friends", Value: []any{
+				graphql.OrderedMap{{Key: "name", Value: "Luke Skywalker"}},
+				graphql.OrderedMap{{Key: "name", Value: "Han Solo"}},
+				graphql.OrderedMap{{Key: "name", Value: "Leia Organa"}},
+			}},
+		}},
+		{Key: "a", Value: graphql.OrderedMap{
+			{Key: "name", Value: "Luke Skywalker"},
+		}},
+		{Key: "__typename", Value: "Query"},
+	}
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema:        testutil.StarWarsSchema,
+		RequestString: query,
+		OrderedData:   true,
+	})
+	if len(result.Errors) != 0 {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.Data))
+	}
+
+	b, err := json.Marshal(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const expectedJSON = `{"data":{"hero":{"name":"R2-D2","id":"2001","primaryFunction":"Astromech",` +
+		`"friends":[{"name":"Luke Skywalker"},{"name":"Han Solo"},{"name":"Leia Organa"}]},` +
+		`"a":{"name":"Luke Skywalker"},"__typename":"Query"}}`
+	if string(b) != expectedJSON {
+		t.Fatalf("Expected %s, got %s", expectedJSON, b)
+	}
+
+	if v, ok := expected.Get("__typename"); !ok || v != "Query" {
+		t.Fatalf("Expected Query for __typename, got %v", v)
+	}
+	if _, ok := expected.Get("missing"); ok {
+		t.Fatal("Expected missing key to not be found")
+	}
+}
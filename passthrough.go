@@ -0,0 +1,215 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sprucehealth/graphql/gqlerrors"
+	"github.com/sprucehealth/graphql/language/ast"
+)
+
+// completePassthroughValueCatchingError is the equivalent of completeValueCatchingError
+// for the value of a field marked as Passthrough. Errors are reported with the path
+// of the value.
+func completePassthroughValueCatchingError(ctx context.Context, eCtx *ExecutionContext, returnType Type, fieldASTs []*ast.Field, info ResolveInfo, result any, path []string) (completed any) {
+	defer func() {
+		if r := recover(); r != nil {
+			recoverPassthroughError(eCtx, returnType, r, path)
+			completed = nil
+		}
+	}()
+	return completePassthroughValue(ctx, eCtx, returnType, fieldASTs, info, result, path)
+}
+
+// completePassthroughFieldCatchingError completes a field of a passthrough object
+// and applies the masking policy to it as resolveField does for other fields.
+func completePassthroughFieldCatchingError(ctx context.Context, eCtx *ExecutionContext, parentType *Object, fieldDef *FieldDefinition, fieldASTs []*ast.Field, info ResolveInfo, result any, path []string) (completed any) {
+	defer func() {
+		if r := recover(); r != nil {
+			recoverPassthroughError(eCtx, fieldDef.Type, r, path)
+			completed = nil
+		}
+	}()
+	completed = completePassthroughValue(ctx, eCtx, fieldDef.Type, fieldASTs, info, result, path)
+	if eCtx.masking != nil {
+		completed = eCtx.masking.apply(ctx, parentType, fieldDef, fieldDef.Type, fieldASTs, path, completed)
+	}
+	return completed
+}
+
+// completePassthroughItemCatchingError completes an item of a passthrough list. As
+// for completeListItemCatchingError an error only nulls the item unless the item
+// type is non-null.
+func completePassthroughItemCatchingError(ctx context.Context, eCtx *ExecutionContext, itemType Type, fieldASTs []*ast.Field, info ResolveInfo, item any, path []string, index int) (completed any) {
+	defer func() {
+		if r := recover(); r != nil {
+			recoverPassthroughError(eCtx, itemType, r, path, index)
+			completed = nil
+		}
+	}()
+	return completePassthroughValue(ctx, eCtx, itemType, fieldASTs, info, item, path)
+}
+
+// recoverPassthroughError reports the recovered panic of completing a passthrough
+// value with the path of the value (and the index of the list item if any) unless
+// it already has a path. The error is raised again if the type is non-null.
+func recoverPassthroughError(eCtx *ExecutionContext, returnType Type, r any, path []string, index ...int) {
+	err := gqlerrors.FormatPanic(r)
+	if err.Path == nil {
+		err.Path = make([]any, 0, len(path)+len(index))
+		for _, p := range path {
+			err.Path = append(err.Path, p)
+		}
+		for _, i := range index {
+			err.Path = append(err.Path, i)
+		}
+	}
+	if _, ok := returnType.(*NonNull); ok {
+		panic(err)
+	}
+	eCtx.addError(err)
+}
+
+// completePassthroughValue completes a pre-resolved value (e.g. the JSON decoded
+// result of a request proxied to another GraphQL API) without calling any resolvers.
+// Objects are expected to be map[string]any keyed by response name and lists to be
+// []any. Leaf values are serialized by their type and enum values must be names of
+// the enum. Only the requested fields are copied to the result, and __typename is
+// injected when requested but not present in the value. Restricted types, the
+// result size limit, and the masking policy apply as for other fields.
+func completePassthroughValue(ctx context.Context, eCtx *ExecutionContext, returnType Type, fieldASTs []*ast.Field, info ResolveInfo, result any, path []string) any {
+	if err := ctx.Err(); err != nil {
+		panic(gqlerrors.FormatError(err))
+	}
+
+	if returnType, ok := returnType.(*NonNull); ok {
+		completed := completePassthroughValue(ctx, eCtx, returnType.OfType, fieldASTs, info, result, path)
+		if completed == nil {
+			err := gqlerrors.CodeNonNullViolation.New(FieldASTsToNodeASTs(fieldASTs), nil, info.ParentType, info.FieldName)
+			panic(gqlerrors.FormatError(err))
+		}
+		return completed
+	}
+
+	if isNullish(result) {
+		return nil
+	}
+
+	switch returnType := returnType.(type) {
+	case *List:
+		values, ok := result.([]any)
+		if !ok {
+			panic(gqlerrors.FormatError(gqlerrors.CodePassthroughTypeMismatch.New(FieldASTsToNodeASTs(fieldASTs), nil,
+				"[]any", info.ParentType, info.FieldName, result)))
+		}
+		eCtx.resultSize.add(len(values)*resultValueOverhead, fieldASTs, path)
+		completed := make([]any, 0, len(values))
+		for i, value := range values {
+			completed = append(completed, completePassthroughItemCatchingError(ctx, eCtx, returnType.OfType, fieldASTs, info, value, path, i))
+		}
+		return completed
+	case *Scalar:
+		completed := completeLeafValue(ctx, eCtx, returnType, result, path)
+		eCtx.resultSize.add(leafValueSize(completed), fieldASTs, path)
+		return completed
+	case *Enum:
+		// The value is already serialized so it's the name of an enum value
+		completed := completeLeafValue(ctx, eCtx, returnType, returnType.ParseValue(result), path)
+		eCtx.resultSize.add(leafValueSize(completed), fieldASTs, path)
+		return completed
+	}
+
+	value, ok := result.(map[string]any)
+	if !ok {
+		panic(gqlerrors.FormatError(gqlerrors.CodePassthroughTypeMismatch.New(FieldASTsToNodeASTs(fieldASTs), nil,
+			"map[string]any", info.ParentType, info.FieldName, result)))
+	}
+
+	var runtimeType *Object
+	switch returnType := returnType.(type) {
+	case *Object:
+		runtimeType = returnType
+	case Abstract:
+		typeName, _ := value[TypeNameMetaFieldDef.Name].(string)
+		runtimeType, _ = eCtx.Schema.Type(typeName).(*Object)
+		if runtimeType == nil {
+			panic(gqlerrors.FormatError(gqlerrors.CodeUnresolvedPassthroughType.New(FieldASTsToNodeASTs(fieldASTs), nil,
+				returnType, info.ParentType, info.FieldName, typeName)))
+		}
+		if eCtx.Schema.IsRestrictedType(runtimeType) {
+			panic(gqlerrors.FormatError(gqlerrors.CodeRestrictedType.New(FieldASTsToNodeASTs(fieldASTs), nil, runtimeType, returnType)))
+		}
+		if !eCtx.Schema.IsPossibleType(returnType, runtimeType) {
+			panic(gqlerrors.FormatError(gqlerrors.CodeImpossibleType.New(FieldASTsToNodeASTs(fieldASTs), nil, runtimeType, returnType)))
+		}
+	default:
+		panic(gqlerrors.NewFormattedError(fmt.Sprintf(`Cannot complete value of unexpected type "%v."`, returnType)))
+	}
+
+	subFieldASTs := make(map[string][]*ast.Field)
+	visitedFragmentNames := make(map[string]struct{})
+	var responseNames *[]string
+	if eCtx.OrderedData {
+		responseNames = &[]string{}
+	}
+	for _, fieldAST := range fieldASTs {
+		if fieldAST == nil || fieldAST.SelectionSet == nil {
+			continue
+		}
+		subFieldASTs = collectFields(CollectFieldsParams{
+			ExeContext:           eCtx,
+			RuntimeType:          runtimeType,
+			SelectionSet:         fieldAST.SelectionSet,
+			Fields:               subFieldASTs,
+			VisitedFragmentNames: visitedFragmentNames,
+			ResponseNames:        responseNames,
+		})
+	}
+	if eCtx.resultSize != nil {
+		var size int
+		for name := range subFieldASTs {
+			size += len(name) + resultValueOverhead
+		}
+		eCtx.resultSize.add(size, fieldASTs, path)
+	}
+
+	completed := make(map[string]any, len(subFieldASTs))
+	for responseName, subFieldASTs := range subFieldASTs {
+		fieldName := ""
+		if subFieldASTs[0].Name != nil {
+			fieldName = subFieldASTs[0].Name.Value
+		}
+		if fieldName == TypeNameMetaFieldDef.Name {
+			if typeName, ok := value[responseName]; ok {
+				completed[responseName] = typeName
+			} else {
+				completed[responseName] = runtimeType.Name()
+			}
+			continue
+		}
+		fieldDef := getFieldDef(eCtx.Schema, runtimeType, fieldName, true)
+		if fieldDef == nil {
+			panic(gqlerrors.FormatError(NewLocatedError(
+				fmt.Sprintf(`Cannot query field "%s" on type "%s".`, fieldName, runtimeType.Name()),
+				FieldASTsToNodeASTs(subFieldASTs),
+			)))
+		}
+		subInfo := info
+		subInfo.FieldName = fieldName
+		subInfo.FieldASTs = subFieldASTs
+		subInfo.ReturnType = fieldDef.Type
+		subInfo.ParentType = runtimeType
+		subInfo.Metadata = fieldDef.Metadata
+		// The path is copied as it's kept by errors
+		subPath := append(path[:len(path):len(path)], fieldName)
+		completed[responseName] = completePassthroughFieldCatchingError(ctx, eCtx, runtimeType, fieldDef, subFieldASTs, subInfo, value[responseName], subPath)
+	}
+	if responseNames != nil {
+		ordered := make(OrderedMap, 0, len(*responseNames))
+		for _, responseName := range *responseNames {
+			ordered = append(ordered, KeyValue{Key: responseName, Value: completed[responseName]})
+		}
+		return ordered
+	}
+	return completed
+}
@@ -0,0 +1,224 @@
+package graphql_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/gqlerrors"
+	"github.com/sprucehealth/graphql/language/location"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+func TestPassthroughField(t *testing.T) {
+	failResolve := func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+		return nil, errors.New("resolver should not be called")
+	}
+	dogType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Dog",
+		Fields: graphql.Fields{
+			"barks": &graphql.Field{Type: graphql.Boolean, Resolve: failResolve},
+		},
+	})
+	petType := graphql.NewUnion(graphql.UnionConfig{
+		Name:  "Pet",
+		Types: []*graphql.Object{dogType},
+		ResolveType: func(ctx context.Context, p graphql.ResolveTypeParams) *graphql.Object {
+			return dogType
+		},
+	})
+	remoteType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Remote",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.String, Resolve: failResolve},
+			"id":   &graphql.Field{Type: graphql.NewNonNull(graphql.ID), Resolve: failResolve},
+			"pets": &graphql.Field{Type: graphql.NewList(petType), Resolve: failResolve},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"remote": &graphql.Field{
+					Type:        remoteType,
+					Passthrough: true,
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						return map[string]any{
+							"name":  "Foo",
+							"alias": "Bar",
+							"extra": "not requested",
+							"pets": []any{
+								map[string]any{"__typename": "Dog", "barks": true},
+							},
+						}, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema: schema,
+		RequestString: `{
+  remote {
+    __typename
+    name
+    alias: name
+    pets { ... on Dog { barks } }
+  }
+}`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]any{
+			"remote": map[string]any{
+				"__typename": "Remote",
+				"name":       "Foo",
+				"alias":      "Bar",
+				"pets": []any{
+					map[string]any{"barks": true},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	result = graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: `{ remote { id } }`,
+	})
+	expected = &graphql.Result{
+		Data: map[string]any{
+			"remote": nil,
+		},
+		Errors: []gqlerrors.FormattedError{
+			{
+				Message:   "Cannot return null for non-nullable field Remote.id.",
+				Code:      gqlerrors.CodeNonNullViolation,
+				Type:      gqlerrors.ErrorTypeInternal,
+				Locations: []location.SourceLocation{{Line: 1, Column: 12}},
+				Path:      []any{"remote", "id"},
+			},
+		},
+	}
+	if len(result.Errors) != 0 {
+		result.Errors[0].OriginalError = nil
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestPassthroughField_Completion(t *testing.T) {
+	colorType := graphql.NewEnum(graphql.EnumConfig{
+		Name:   "Color",
+		Values: graphql.EnumValueConfigMap{"RED": &graphql.EnumValueConfig{Value: 0}},
+	})
+	dogType := graphql.NewObject(graphql.ObjectConfig{
+		Name:   "Dog",
+		Fields: graphql.Fields{"name": &graphql.Field{Type: graphql.String}},
+	})
+	petType := graphql.NewUnion(graphql.UnionConfig{
+		Name:  "Pet",
+		Types: []*graphql.Object{dogType},
+		ResolveType: func(ctx context.Context, p graphql.ResolveTypeParams) *graphql.Object {
+			return dogType
+		},
+	})
+	remoteType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Remote",
+		Fields: graphql.Fields{
+			"count":  &graphql.Field{Type: graphql.Int},
+			"colors": &graphql.Field{Type: graphql.NewList(colorType)},
+			"pets":   &graphql.Field{Type: graphql.NewList(petType)},
+			"secret": &graphql.Field{Type: graphql.String},
+			"pet":    &graphql.Field{Type: petType},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"remote": &graphql.Field{
+					Type:        remoteType,
+					Passthrough: true,
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						return map[string]any{
+							"count":  float64(5),
+							"colors": []any{"RED", "BLUE"},
+							"pets":   []any{map[string]any{"__typename": "Dog"}, "Rex"},
+							"secret": "s3cr3t",
+							"pet":    map[string]any{"__typename": "Dog", "name": "Rex"},
+						}, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Leaf values are serialized, unknown enum values are null, and errors have the
+	// path of the value.
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: `{ remote { count colors pets { __typename } secret } }`,
+		Masking: &graphql.MaskingPolicy{Rules: []graphql.MaskRule{{
+			Name: "secret",
+			Mask: func(ctx context.Context, parent *graphql.Object, field *graphql.FieldDefinition, value any) (any, bool) {
+				return "***", field.Name == "secret"
+			},
+		}}},
+	})
+	expected := &graphql.Result{
+		Data: map[string]any{
+			"remote": map[string]any{
+				"count":  5,
+				"colors": []any{"RED", nil},
+				"pets":   []any{map[string]any{"__typename": "Dog"}, nil},
+				"secret": "***",
+			},
+		},
+		Errors: []gqlerrors.FormattedError{
+			{
+				Message:   "User Error: expected passthrough value of type map[string]any for field Remote.pets but got string.",
+				Code:      gqlerrors.CodePassthroughTypeMismatch,
+				Type:      gqlerrors.ErrorTypeInternal,
+				Locations: []location.SourceLocation{{Line: 1, Column: 25}},
+				Path:      []any{"remote", "pets", 1},
+			},
+		},
+	}
+	for i := range result.Errors {
+		result.Errors[i].OriginalError = nil
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	// Restricted types and the result size limit apply
+	result = graphql.Do(context.Background(), graphql.Params{
+		Schema:          schema,
+		RequestString:   `{ remote { pet { ... on Dog { name } } } }`,
+		RestrictedTypes: []string{"Dog"},
+	})
+	if len(result.Errors) != 1 || result.Errors[0].Code != gqlerrors.CodeRestrictedType ||
+		!reflect.DeepEqual(result.Errors[0].Path, []any{"remote", "pet"}) {
+		t.Fatalf("Expected a restricted type error, got %+v", result.Errors)
+	}
+	result = graphql.Do(context.Background(), graphql.Params{
+		Schema:         schema,
+		RequestString:  `{ remote { pets { __typename } } }`,
+		MaxResultBytes: 10,
+	})
+	if len(result.Errors) != 1 || result.Errors[0].Code != gqlerrors.CodeResultTooLarge {
+		t.Fatalf("Expected a result too large error, got %+v", result.Errors)
+	}
+}
@@ -0,0 +1,122 @@
+package graphql
+
+import (
+	"strings"
+
+	"github.com/sprucehealth/graphql/language/ast"
+)
+
+// Projection is the tree of fields selected below a field. Leaf fields map to a
+// nil projection and fields with a selection set map to the projection of their
+// selections. It's meant for resolvers that build a database query (e.g. the
+// columns of a SELECT or the projection of an aggregation pipeline).
+type Projection map[string]Projection
+
+// ColumnNames maps a type name to a map of GraphQL field names to the names to
+// use in a projection (e.g. database column names). Fields without an entry
+// keep their GraphQL name.
+type ColumnNames map[string]map[string]string
+
+// Projection returns the fields selected below the field being resolved. Fields
+// selected through fragments and inline fragments are merged regardless of the
+// type condition and aliased fields are keyed by their name, so the projection
+// covers everything the field's resolver may need to fetch. Fields excluded by
+// @skip or @include and introspection fields (e.g. __typename) are omitted.
+// If columns is not nil then field names are mapped to column names by the type
+// that defines the field.
+func (info ResolveInfo) Projection(columns ColumnNames) Projection {
+	pb := &projectionBuilder{
+		eCtx:      &ExecutionContext{Schema: info.Schema, VariableValues: info.VariableValues},
+		fragments: info.Fragments,
+		columns:   columns,
+	}
+	p := Projection{}
+	var parent Type
+	if info.ReturnType != nil {
+		parent, _ = GetNamed(info.ReturnType).(Type)
+	}
+	for _, f := range info.FieldASTs {
+		pb.project(p, parent, f.SelectionSet, map[string]bool{})
+	}
+	return p
+}
+
+type projectionBuilder struct {
+	eCtx      *ExecutionContext
+	fragments map[string]*ast.FragmentDefinition
+	columns   ColumnNames
+}
+
+func (pb *projectionBuilder) project(p Projection, parent Type, ss *ast.SelectionSet, visitedFragments map[string]bool) {
+	if ss == nil {
+		return
+	}
+	for _, sel := range ss.Selections {
+		switch sel := sel.(type) {
+		case *ast.Field:
+			if sel.Name == nil || strings.HasPrefix(sel.Name.Value, "__") || !shouldIncludeNode(pb.eCtx, sel.Directives) {
+				continue
+			}
+			name := sel.Name.Value
+			var fieldType Type
+			if fieldDef := projectionFieldDef(parent, name); fieldDef != nil {
+				fieldType, _ = GetNamed(fieldDef.Type).(Type)
+			}
+			if parent != nil {
+				if column := pb.columns[parent.Name()][name]; column != "" {
+					name = column
+				}
+			}
+			if sel.SelectionSet == nil {
+				if _, ok := p[name]; !ok {
+					p[name] = nil
+				}
+				continue
+			}
+			child := p[name]
+			if child == nil {
+				child = Projection{}
+				p[name] = child
+			}
+			pb.project(child, fieldType, sel.SelectionSet, visitedFragments)
+		case *ast.InlineFragment:
+			if !shouldIncludeNode(pb.eCtx, sel.Directives) {
+				continue
+			}
+			pb.project(p, pb.conditionType(parent, sel.TypeCondition), sel.SelectionSet, visitedFragments)
+		case *ast.FragmentSpread:
+			if sel.Name == nil || visitedFragments[sel.Name.Value] || !shouldIncludeNode(pb.eCtx, sel.Directives) {
+				continue
+			}
+			fragment := pb.fragments[sel.Name.Value]
+			if fragment == nil {
+				continue
+			}
+			visitedFragments[sel.Name.Value] = true
+			pb.project(p, pb.conditionType(parent, fragment.TypeCondition), fragment.SelectionSet, visitedFragments)
+			visitedFragments[sel.Name.Value] = false
+		}
+	}
+}
+
+// conditionType returns the type of a fragment's type condition or the parent
+// type if the fragment doesn't have one.
+func (pb *projectionBuilder) conditionType(parent Type, condition *ast.Named) Type {
+	if condition == nil || condition.Name == nil {
+		return parent
+	}
+	if t := pb.eCtx.Schema.Type(condition.Name.Value); t != nil {
+		return t
+	}
+	return parent
+}
+
+func projectionFieldDef(parent Type, name string) *FieldDefinition {
+	switch parent := parent.(type) {
+	case *Object:
+		return parent.Fields()[name]
+	case *Interface:
+		return parent.Fields()[name]
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package graphql_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+func TestResolveInfo_Projection(t *testing.T) {
+	postType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Post",
+		Fields: graphql.Fields{
+			"id":    &graphql.Field{Type: graphql.ID},
+			"title": &graphql.Field{Type: graphql.String},
+		},
+	})
+	userType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"id":    &graphql.Field{Type: graphql.ID},
+			"name":  &graphql.Field{Type: graphql.String},
+			"email": &graphql.Field{Type: graphql.String},
+			"posts": &graphql.Field{Type: graphql.NewList(postType)},
+		},
+	})
+	columns := graphql.ColumnNames{
+		"User": {"name": "full_name"},
+		"Post": {"title": "post_title"},
+	}
+	var projection graphql.Projection
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"user": &graphql.Field{
+					Type: userType,
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						projection = p.Info.Projection(columns)
+						return nil, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema: schema,
+		RequestString: `query ($skip: Boolean!) {
+			user {
+				__typename
+				userName: name
+				email @skip(if: $skip)
+				...UserPosts
+				... on User {
+					id
+					posts { id }
+				}
+			}
+		}
+		fragment UserPosts on User {
+			posts { title }
+		}`,
+		VariableValues: map[string]any{"skip": true},
+	})
+	if len(result.Errors) != 0 {
+		t.Fatalf("Unexpected errors %+v", result.Errors)
+	}
+	expected := graphql.Projection{
+		"full_name": nil,
+		"id":        nil,
+		"posts": graphql.Projection{
+			"id":         nil,
+			"post_title": nil,
+		},
+	}
+	if !reflect.DeepEqual(expected, projection) {
+		t.Fatalf("Unexpected projection, Diff: %v", testutil.Diff(expected, projection))
+	}
+}
@@ -0,0 +1,16 @@
+module github.com/sprucehealth/graphql/protogql
+
+go 1.23
+
+require (
+	github.com/sprucehealth/graphql v0.0.0
+	google.golang.org/protobuf v1.36.6
+)
+
+require (
+	github.com/kr/pretty v0.3.1 // indirect
+	github.com/kr/text v0.2.0 // indirect
+	github.com/rogpeppe/go-internal v1.13.1 // indirect
+)
+
+replace github.com/sprucehealth/graphql => ../
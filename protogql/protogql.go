@@ -0,0 +1,645 @@
+// Package protogql builds GraphQL types from protobuf message and enum descriptors
+// so that services whose backends are defined in protobuf don't have to maintain
+// parallel GraphQL types by hand.
+//
+// Messages map to object types (and input objects for arguments), enums to enum
+// types, and fields to fields named after their JSON name. The fields of the
+// object types resolve from proto.Message (or protoreflect.Message) values so a
+// resolver can return the response of a backend as is, and UnmarshalInput sets
+// the fields of a message from the value of an input object argument.
+//
+// Scalars map as in the protobuf JSON mapping: 64-bit integers are strings, bytes
+// are base64 encoded strings, google.protobuf.Timestamp is an RFC 3339 string, and
+// the wrapper types are nullable scalars. Map fields are lists of key/value
+// entries. Fields without presence (proto3 fields that aren't optional, message,
+// or oneof fields) are non-null.
+//
+// The package is a separate module so that the graphql module doesn't depend on
+// protobuf.
+package protogql
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sprucehealth/graphql"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// Config controls how types are built.
+type Config struct {
+	// TypeName if set returns the name of the GraphQL type of a message or enum.
+	// The default is the name of the message or enum prefixed with the names of
+	// the messages it's nested in (e.g. Order.Item is OrderItem). Input objects
+	// have the name of the object type suffixed with Input.
+	TypeName func(desc protoreflect.Descriptor) string
+	// FieldName if set returns the name of the GraphQL field of a message field.
+	// The default is the JSON name of the field.
+	FieldName func(fd protoreflect.FieldDescriptor) string
+	// Exclude if set returns true for the message fields that aren't part of the
+	// GraphQL types (e.g. internal fields).
+	Exclude func(fd protoreflect.FieldDescriptor) bool
+
+	// TypeNameOption if set is a string extension of google.protobuf.MessageOptions
+	// or google.protobuf.EnumOptions that annotates a message or enum with the name
+	// of its GraphQL type. It takes precedence over TypeName.
+	TypeNameOption protoreflect.ExtensionType
+	// FieldNameOption if set is a string extension of google.protobuf.FieldOptions
+	// that annotates a field with the name of its GraphQL field. It takes
+	// precedence over FieldName.
+	FieldNameOption protoreflect.ExtensionType
+	// ExcludeOption if set is a bool extension of google.protobuf.FieldOptions
+	// that annotates the fields that are excluded as with Exclude.
+	ExcludeOption protoreflect.ExtensionType
+}
+
+// Builder builds the GraphQL types of protobuf descriptors. Types are built once
+// per descriptor so messages can refer to each other (or themselves). A Builder
+// isn't safe for concurrent use but the types it returns are.
+type Builder struct {
+	cfg     Config
+	objects map[protoreflect.FullName]*graphql.Object
+	inputs  map[protoreflect.FullName]*graphql.InputObject
+	enums   map[protoreflect.FullName]*graphql.Enum
+	// fields maps GraphQL field names to message fields by message.
+	fields map[protoreflect.FullName]map[string]protoreflect.FieldDescriptor
+}
+
+// NewBuilder returns a builder that builds types with the config.
+func NewBuilder(cfg Config) *Builder {
+	return &Builder{
+		cfg:     cfg,
+		objects: make(map[protoreflect.FullName]*graphql.Object),
+		inputs:  make(map[protoreflect.FullName]*graphql.InputObject),
+		enums:   make(map[protoreflect.FullName]*graphql.Enum),
+		fields:  make(map[protoreflect.FullName]map[string]protoreflect.FieldDescriptor),
+	}
+}
+
+// timestampName is the full name of google.protobuf.Timestamp.
+const timestampName protoreflect.FullName = "google.protobuf.Timestamp"
+
+// wrapperNames are the full names of the wrapper types.
+var wrapperNames = map[protoreflect.FullName]bool{
+	"google.protobuf.DoubleValue": true,
+	"google.protobuf.FloatValue":  true,
+	"google.protobuf.Int64Value":  true,
+	"google.protobuf.UInt64Value": true,
+	"google.protobuf.Int32Value":  true,
+	"google.protobuf.UInt32Value": true,
+	"google.protobuf.BoolValue":   true,
+	"google.protobuf.StringValue": true,
+	"google.protobuf.BytesValue":  true,
+}
+
+// Object returns the object type of a message.
+func (b *Builder) Object(md protoreflect.MessageDescriptor) *graphql.Object {
+	if obj := b.objects[md.FullName()]; obj != nil {
+		return obj
+	}
+	fullName := md.FullName()
+	obj := graphql.NewObject(graphql.ObjectConfig{
+		Name:        b.typeName(md),
+		Description: description(md),
+		IsTypeOf: func(p graphql.IsTypeOfParams) bool {
+			if entry, ok := p.Value.(mapEntry); ok {
+				return entry.desc.FullName() == fullName
+			}
+			m, err := sourceMessage(p.Value)
+			return err == nil && m.Descriptor().FullName() == fullName
+		},
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			fields := make(graphql.Fields)
+			for name, fd := range b.messageFields(md) {
+				fields[name] = &graphql.Field{
+					Type:              b.outputType(fd),
+					Description:       description(fd),
+					DeprecationReason: deprecationReason(fd),
+					Resolve:           b.resolveField(fd),
+				}
+			}
+			return fields
+		}),
+	})
+	b.objects[fullName] = obj
+	return obj
+}
+
+// InputObject returns the input object type of a message.
+func (b *Builder) InputObject(md protoreflect.MessageDescriptor) *graphql.InputObject {
+	if input := b.inputs[md.FullName()]; input != nil {
+		return input
+	}
+	input := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:        b.typeName(md) + "Input",
+		Description: description(md),
+		Fields: graphql.InputObjectConfigFieldMapThunk(func() graphql.InputObjectConfigFieldMap {
+			fields := make(graphql.InputObjectConfigFieldMap)
+			for name, fd := range b.messageFields(md) {
+				fields[name] = &graphql.InputObjectFieldConfig{
+					Type:        b.inputType(fd),
+					Description: description(fd),
+				}
+			}
+			return fields
+		}),
+	})
+	b.inputs[md.FullName()] = input
+	return input
+}
+
+// Enum returns the enum type of an enum. The values of the enum type are the
+// numbers of the enum values.
+func (b *Builder) Enum(ed protoreflect.EnumDescriptor) *graphql.Enum {
+	if enum := b.enums[ed.FullName()]; enum != nil {
+		return enum
+	}
+	values := make(graphql.EnumValueConfigMap)
+	for i := 0; i < ed.Values().Len(); i++ {
+		vd := ed.Values().Get(i)
+		value := &graphql.EnumValueConfig{
+			Value:       vd.Number(),
+			Description: description(vd),
+		}
+		if opts, ok := vd.Options().(*descriptorpb.EnumValueOptions); ok && opts.GetDeprecated() {
+			value.DeprecationReason = graphql.DefaultDeprecationReason
+		}
+		values[string(vd.Name())] = value
+	}
+	enum := graphql.NewEnum(graphql.EnumConfig{
+		Name:        b.typeName(ed),
+		Description: description(ed),
+		Values:      values,
+	})
+	b.enums[ed.FullName()] = enum
+	return enum
+}
+
+// Types returns the types built so far sorted by name (e.g. for
+// SchemaConfig.Types).
+func (b *Builder) Types() []graphql.Type {
+	var types []graphql.Type
+	for _, obj := range b.objects {
+		types = append(types, obj)
+	}
+	for _, input := range b.inputs {
+		types = append(types, input)
+	}
+	for _, enum := range b.enums {
+		types = append(types, enum)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		return types[i].Name() < types[j].Name()
+	})
+	return types
+}
+
+// SDL returns the definitions of the types built so far in the schema definition
+// language. The types that are referred to by the fields of the types are built
+// first so that they're included.
+func (b *Builder) SDL() string {
+	for n := -1; n != len(b.objects)+len(b.inputs)+len(b.enums); {
+		n = len(b.objects) + len(b.inputs) + len(b.enums)
+		for _, t := range b.Types() {
+			// Building the fields builds the types they refer to
+			switch t := t.(type) {
+			case *graphql.Object:
+				t.Fields()
+			case *graphql.InputObject:
+				t.Fields()
+			}
+		}
+	}
+	types := b.Types()
+	defs := make([]string, len(types))
+	for i, t := range types {
+		defs[i] = graphql.PrintType(t)
+	}
+	return strings.Join(defs, "\n\n") + "\n"
+}
+
+// UnmarshalInput sets the fields of the message from the value of an argument of
+// the input object type of the message. Fields that aren't in the value or that
+// are null are left unchanged.
+func (b *Builder) UnmarshalInput(value map[string]any, m proto.Message) error {
+	return b.setMessage(m.ProtoReflect(), value)
+}
+
+func (b *Builder) typeName(desc protoreflect.Descriptor) string {
+	if name, ok := stringOption(desc.Options(), b.cfg.TypeNameOption); ok {
+		return name
+	}
+	if b.cfg.TypeName != nil {
+		return b.cfg.TypeName(desc)
+	}
+	name := string(desc.FullName())
+	if pkg := string(desc.ParentFile().Package()); pkg != "" {
+		name = strings.TrimPrefix(name, pkg+".")
+	}
+	return strings.ReplaceAll(name, ".", "")
+}
+
+// messageFields returns the fields of a message that aren't excluded keyed by the
+// name of their GraphQL field.
+func (b *Builder) messageFields(md protoreflect.MessageDescriptor) map[string]protoreflect.FieldDescriptor {
+	if fields, ok := b.fields[md.FullName()]; ok {
+		return fields
+	}
+	fields := make(map[string]protoreflect.FieldDescriptor)
+	for i := 0; i < md.Fields().Len(); i++ {
+		fd := md.Fields().Get(i)
+		if exclude, _ := boolOption(fd.Options(), b.cfg.ExcludeOption); exclude || (b.cfg.Exclude != nil && b.cfg.Exclude(fd)) {
+			continue
+		}
+		name, ok := stringOption(fd.Options(), b.cfg.FieldNameOption)
+		if !ok {
+			if b.cfg.FieldName != nil {
+				name = b.cfg.FieldName(fd)
+			} else {
+				name = fd.JSONName()
+			}
+		}
+		fields[name] = fd
+	}
+	b.fields[md.FullName()] = fields
+	return fields
+}
+
+// outputType returns the type of the field of an object type.
+func (b *Builder) outputType(fd protoreflect.FieldDescriptor) graphql.Output {
+	if fd.IsMap() {
+		return graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(b.Object(fd.Message()))))
+	}
+	var t graphql.Output
+	switch fd.Kind() {
+	case protoreflect.EnumKind:
+		t = b.Enum(fd.Enum())
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		if scalar := messageScalar(fd.Message()); scalar != nil {
+			t = scalar
+		} else {
+			t = b.Object(fd.Message())
+		}
+	default:
+		t = kindScalar(fd.Kind())
+	}
+	if fd.IsList() {
+		return graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(t)))
+	}
+	if !fd.HasPresence() || fd.Cardinality() == protoreflect.Required {
+		return graphql.NewNonNull(t)
+	}
+	return t
+}
+
+// inputType returns the type of the field of an input object type. Every field is
+// optional.
+func (b *Builder) inputType(fd protoreflect.FieldDescriptor) graphql.Input {
+	if fd.IsMap() {
+		return graphql.NewList(graphql.NewNonNull(b.InputObject(fd.Message())))
+	}
+	var t graphql.Input
+	switch fd.Kind() {
+	case protoreflect.EnumKind:
+		t = b.Enum(fd.Enum())
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		if scalar := messageScalar(fd.Message()); scalar != nil {
+			t = scalar
+		} else {
+			t = b.InputObject(fd.Message())
+		}
+	default:
+		t = kindScalar(fd.Kind())
+	}
+	if fd.IsList() {
+		return graphql.NewList(graphql.NewNonNull(t))
+	}
+	return t
+}
+
+// kindScalar returns the scalar type of a field kind that isn't an enum or
+// message.
+func kindScalar(kind protoreflect.Kind) *graphql.Scalar {
+	switch kind {
+	case protoreflect.BoolKind:
+		return graphql.Boolean
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return graphql.Int
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.FloatKind, protoreflect.DoubleKind:
+		// uint32 doesn't fit in Int but all its values are exact as a Float
+		return graphql.Float
+	}
+	return graphql.String
+}
+
+// messageScalar returns the scalar type of the well-known messages that map to a
+// scalar, or nil for other messages.
+func messageScalar(md protoreflect.MessageDescriptor) *graphql.Scalar {
+	if md.FullName() == timestampName {
+		return graphql.String
+	}
+	if wrapperNames[md.FullName()] {
+		return kindScalar(md.Fields().ByName("value").Kind())
+	}
+	return nil
+}
+
+// mapEntry is the source of the fields of the entry type of a map field.
+type mapEntry struct {
+	desc  protoreflect.MessageDescriptor
+	key   protoreflect.MapKey
+	value protoreflect.Value
+}
+
+func (b *Builder) resolveField(fd protoreflect.FieldDescriptor) graphql.FieldResolveFn {
+	return func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+		if entry, ok := p.Source.(mapEntry); ok {
+			if fd.Name() == "key" {
+				return outputValue(fd, entry.key.Value())
+			}
+			return outputValue(fd, entry.value)
+		}
+		m, err := sourceMessage(p.Source)
+		if err != nil {
+			return nil, err
+		}
+		if fd.HasPresence() && !m.Has(fd) {
+			return nil, nil
+		}
+		v := m.Get(fd)
+		switch {
+		case fd.IsMap():
+			entries := make([]mapEntry, 0, v.Map().Len())
+			v.Map().Range(func(key protoreflect.MapKey, value protoreflect.Value) bool {
+				entries = append(entries, mapEntry{desc: fd.Message(), key: key, value: value})
+				return true
+			})
+			// Map iteration order is random
+			sort.Slice(entries, func(i, j int) bool {
+				return lessMapKey(entries[i].key, entries[j].key)
+			})
+			values := make([]any, len(entries))
+			for i, entry := range entries {
+				values[i] = entry
+			}
+			return values, nil
+		case fd.IsList():
+			values := make([]any, v.List().Len())
+			for i := range values {
+				value, err := outputValue(fd, v.List().Get(i))
+				if err != nil {
+					return nil, err
+				}
+				values[i] = value
+			}
+			return values, nil
+		}
+		return outputValue(fd, v)
+	}
+}
+
+// sourceMessage returns the message of the source of a field.
+func sourceMessage(source any) (protoreflect.Message, error) {
+	switch source := source.(type) {
+	case protoreflect.Message:
+		return source, nil
+	case proto.Message:
+		return source.ProtoReflect(), nil
+	}
+	return nil, fmt.Errorf("protogql: expected a proto.Message, got %T", source)
+}
+
+func lessMapKey(a, b protoreflect.MapKey) bool {
+	switch a.Interface().(type) {
+	case string:
+		return a.String() < b.String()
+	case bool:
+		return !a.Bool() && b.Bool()
+	case int32, int64:
+		return a.Int() < b.Int()
+	}
+	return a.Uint() < b.Uint()
+}
+
+// outputValue returns the value of a singular field, or item of a repeated
+// field, for the type of the GraphQL field.
+func outputValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) (any, error) {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return v.Bool(), nil
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return int(v.Int()), nil
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return float64(v.Uint()), nil
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return v.Float(), nil
+	case protoreflect.StringKind:
+		return v.String(), nil
+	case protoreflect.BytesKind:
+		return base64.StdEncoding.EncodeToString(v.Bytes()), nil
+	case protoreflect.EnumKind:
+		return v.Enum(), nil
+	}
+	m := v.Message()
+	md := m.Descriptor()
+	if md.FullName() == timestampName {
+		seconds := m.Get(md.Fields().ByName("seconds")).Int()
+		nanos := m.Get(md.Fields().ByName("nanos")).Int()
+		return time.Unix(seconds, nanos).UTC().Format(time.RFC3339Nano), nil
+	}
+	if wrapperNames[md.FullName()] {
+		value := md.Fields().ByName("value")
+		return outputValue(value, m.Get(value))
+	}
+	return m, nil
+}
+
+// setMessage sets the fields of a message from the value of its input object.
+func (b *Builder) setMessage(m protoreflect.Message, value map[string]any) error {
+	for name, fd := range b.messageFields(m.Descriptor()) {
+		v, ok := value[name]
+		if !ok || v == nil {
+			continue
+		}
+		items, isList := v.([]any)
+		switch {
+		case fd.IsMap():
+			if !isList {
+				return fmt.Errorf("protogql: expected a list of entries for field %s, got %T", fd.FullName(), v)
+			}
+			mv := m.Mutable(fd).Map()
+			for _, item := range items {
+				entry, ok := item.(map[string]any)
+				if !ok {
+					return fmt.Errorf("protogql: expected an entry for field %s, got %T", fd.FullName(), item)
+				}
+				key, err := b.inputValue(fd.MapKey(), entry["key"], nil)
+				if err != nil {
+					return err
+				}
+				if entry["value"] == nil {
+					continue
+				}
+				value, err := b.inputValue(fd.MapValue(), entry["value"], func() protoreflect.Message {
+					return mv.NewValue().Message()
+				})
+				if err != nil {
+					return err
+				}
+				mv.Set(key.MapKey(), value)
+			}
+		case fd.IsList():
+			if !isList {
+				return fmt.Errorf("protogql: expected a list for field %s, got %T", fd.FullName(), v)
+			}
+			list := m.Mutable(fd).List()
+			for _, item := range items {
+				value, err := b.inputValue(fd, item, func() protoreflect.Message {
+					return list.NewElement().Message()
+				})
+				if err != nil {
+					return err
+				}
+				list.Append(value)
+			}
+		default:
+			value, err := b.inputValue(fd, v, func() protoreflect.Message {
+				return m.NewField(fd).Message()
+			})
+			if err != nil {
+				return err
+			}
+			m.Set(fd, value)
+		}
+	}
+	return nil
+}
+
+// inputValue returns the protobuf value of a singular field, or item of a
+// repeated field, from the value of its GraphQL field. newMessage returns a new
+// message for the value of a message field.
+func (b *Builder) inputValue(fd protoreflect.FieldDescriptor, v any, newMessage func() protoreflect.Message) (protoreflect.Value, error) {
+	invalid := func() (protoreflect.Value, error) {
+		return protoreflect.Value{}, fmt.Errorf("protogql: invalid value %v (%T) for field %s", v, v, fd.FullName())
+	}
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		if v, ok := v.(bool); ok {
+			return protoreflect.ValueOfBool(v), nil
+		}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		if v, ok := v.(int); ok && v >= math.MinInt32 && v <= math.MaxInt32 {
+			return protoreflect.ValueOfInt32(int32(v)), nil
+		}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		if s, ok := v.(string); ok {
+			if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+				return protoreflect.ValueOfInt64(n), nil
+			}
+		}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		if f, ok := v.(float64); ok && f >= 0 && f <= math.MaxUint32 && f == math.Trunc(f) {
+			return protoreflect.ValueOfUint32(uint32(f)), nil
+		}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		if s, ok := v.(string); ok {
+			if n, err := strconv.ParseUint(s, 10, 64); err == nil {
+				return protoreflect.ValueOfUint64(n), nil
+			}
+		}
+	case protoreflect.FloatKind:
+		if f, ok := v.(float64); ok {
+			return protoreflect.ValueOfFloat32(float32(f)), nil
+		}
+	case protoreflect.DoubleKind:
+		if f, ok := v.(float64); ok {
+			return protoreflect.ValueOfFloat64(f), nil
+		}
+	case protoreflect.StringKind:
+		if s, ok := v.(string); ok {
+			return protoreflect.ValueOfString(s), nil
+		}
+	case protoreflect.BytesKind:
+		if s, ok := v.(string); ok {
+			if data, err := base64.StdEncoding.DecodeString(s); err == nil {
+				return protoreflect.ValueOfBytes(data), nil
+			}
+		}
+	case protoreflect.EnumKind:
+		if n, ok := v.(protoreflect.EnumNumber); ok {
+			return protoreflect.ValueOfEnum(n), nil
+		}
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		m := newMessage()
+		md := m.Descriptor()
+		switch {
+		case md.FullName() == timestampName:
+			s, ok := v.(string)
+			if !ok {
+				return invalid()
+			}
+			t, err := time.Parse(time.RFC3339Nano, s)
+			if err != nil {
+				return invalid()
+			}
+			m.Set(md.Fields().ByName("seconds"), protoreflect.ValueOfInt64(t.Unix()))
+			m.Set(md.Fields().ByName("nanos"), protoreflect.ValueOfInt32(int32(t.Nanosecond())))
+		case wrapperNames[md.FullName()]:
+			value, err := b.inputValue(md.Fields().ByName("value"), v, nil)
+			if err != nil {
+				return protoreflect.Value{}, err
+			}
+			m.Set(md.Fields().ByName("value"), value)
+		default:
+			fields, ok := v.(map[string]any)
+			if !ok {
+				return invalid()
+			}
+			if err := b.setMessage(m, fields); err != nil {
+				return protoreflect.Value{}, err
+			}
+		}
+		return protoreflect.ValueOfMessage(m), nil
+	}
+	return invalid()
+}
+
+func description(desc protoreflect.Descriptor) string {
+	loc := desc.ParentFile().SourceLocations().ByDescriptor(desc)
+	return strings.TrimSpace(loc.LeadingComments)
+}
+
+func deprecationReason(fd protoreflect.FieldDescriptor) string {
+	if opts, ok := fd.Options().(*descriptorpb.FieldOptions); ok && opts.GetDeprecated() {
+		return graphql.DefaultDeprecationReason
+	}
+	return ""
+}
+
+func stringOption(opts proto.Message, xt protoreflect.ExtensionType) (string, bool) {
+	if xt == nil || opts == nil || !proto.HasExtension(opts, xt) {
+		return "", false
+	}
+	s, ok := proto.GetExtension(opts, xt).(string)
+	return s, ok && s != ""
+}
+
+func boolOption(opts proto.Message, xt protoreflect.ExtensionType) (bool, bool) {
+	if xt == nil || opts == nil || !proto.HasExtension(opts, xt) {
+		return false, false
+	}
+	v, ok := proto.GetExtension(opts, xt).(bool)
+	return v, ok
+}
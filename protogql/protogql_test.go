@@ -0,0 +1,276 @@
+package protogql
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/testutil"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	_ "google.golang.org/protobuf/types/known/wrapperspb" // registers wrappers.proto
+)
+
+// testDescriptors returns the descriptor of a test message and the extension
+// types of the annotations used by its fields.
+func testDescriptors(t *testing.T) (protoreflect.MessageDescriptor, protoreflect.ExtensionType, protoreflect.ExtensionType) {
+	t.Helper()
+	optionsFile, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:       proto.String("test/options.proto"),
+		Package:    proto.String("test"),
+		Dependency: []string{"google/protobuf/descriptor.proto"},
+		Extension: []*descriptorpb.FieldDescriptorProto{
+			{
+				Name:     proto.String("gql_name"),
+				Number:   proto.Int32(50000),
+				Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+				Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+				Extendee: proto.String(".google.protobuf.FieldOptions"),
+			},
+			{
+				Name:     proto.String("gql_exclude"),
+				Number:   proto.Int32(50001),
+				Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+				Type:     descriptorpb.FieldDescriptorProto_TYPE_BOOL.Enum(),
+				Extendee: proto.String(".google.protobuf.FieldOptions"),
+			},
+		},
+	}, protoregistry.GlobalFiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nameOption := dynamicpb.NewExtensionType(optionsFile.Extensions().ByName("gql_name"))
+	excludeOption := dynamicpb.NewExtensionType(optionsFile.Extensions().ByName("gql_exclude"))
+	renamed := &descriptorpb.FieldOptions{}
+	proto.SetExtension(renamed, nameOption, "displayName")
+	excluded := &descriptorpb.FieldOptions{}
+	proto.SetExtension(excluded, excludeOption, true)
+
+	field := func(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type, typeName string) *descriptorpb.FieldDescriptorProto {
+		fd := &descriptorpb.FieldDescriptorProto{
+			Name:   proto.String(name),
+			Number: proto.Int32(number),
+			Label:  descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:   typ.Enum(),
+		}
+		if typeName != "" {
+			fd.TypeName = proto.String(typeName)
+		}
+		return fd
+	}
+	repeated := func(fd *descriptorpb.FieldDescriptorProto) *descriptorpb.FieldDescriptorProto {
+		fd.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+		return fd
+	}
+	withOptions := func(fd *descriptorpb.FieldDescriptorProto, opts *descriptorpb.FieldOptions) *descriptorpb.FieldDescriptorProto {
+		fd.Options = opts
+		return fd
+	}
+
+	file, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:       proto.String("test/order.proto"),
+		Package:    proto.String("test"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"google/protobuf/timestamp.proto", "google/protobuf/wrappers.proto", "test/options.proto"},
+		EnumType: []*descriptorpb.EnumDescriptorProto{{
+			Name: proto.String("Status"),
+			Value: []*descriptorpb.EnumValueDescriptorProto{
+				{Name: proto.String("STATUS_UNKNOWN"), Number: proto.Int32(0)},
+				{Name: proto.String("STATUS_SHIPPED"), Number: proto.Int32(1)},
+			},
+		}},
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Order"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				field("id", 1, descriptorpb.FieldDescriptorProto_TYPE_INT64, ""),
+				field("status", 2, descriptorpb.FieldDescriptorProto_TYPE_ENUM, ".test.Status"),
+				repeated(field("items", 3, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".test.Order.Item")),
+				field("created_at", 4, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".google.protobuf.Timestamp"),
+				field("note", 5, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".google.protobuf.StringValue"),
+				withOptions(field("name", 6, descriptorpb.FieldDescriptorProto_TYPE_STRING, ""), renamed),
+				withOptions(field("secret", 7, descriptorpb.FieldDescriptorProto_TYPE_STRING, ""), excluded),
+				repeated(field("labels", 8, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".test.Order.LabelsEntry")),
+				field("parent", 9, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".test.Order"),
+			},
+			NestedType: []*descriptorpb.DescriptorProto{
+				{
+					Name: proto.String("Item"),
+					Field: []*descriptorpb.FieldDescriptorProto{
+						field("sku", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, ""),
+						field("quantity", 2, descriptorpb.FieldDescriptorProto_TYPE_UINT32, ""),
+					},
+				},
+				{
+					Name: proto.String("LabelsEntry"),
+					Field: []*descriptorpb.FieldDescriptorProto{
+						field("key", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, ""),
+						field("value", 2, descriptorpb.FieldDescriptorProto_TYPE_INT32, ""),
+					},
+					Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+				},
+			},
+		}},
+	}, resolverWith(t, optionsFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return file.Messages().ByName("Order"), nameOption, excludeOption
+}
+
+// resolverWith returns the global files with an additional file.
+func resolverWith(t *testing.T, fd protoreflect.FileDescriptor) *protoregistry.Files {
+	t.Helper()
+	files := new(protoregistry.Files)
+	protoregistry.GlobalFiles.RangeFiles(func(f protoreflect.FileDescriptor) bool {
+		_ = files.RegisterFile(f)
+		return true
+	})
+	if err := files.RegisterFile(fd); err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
+
+func TestBuilder(t *testing.T) {
+	md, nameOption, excludeOption := testDescriptors(t)
+	b := NewBuilder(Config{FieldNameOption: nameOption, ExcludeOption: excludeOption})
+	order := b.Object(md)
+	orderInput := b.InputObject(md)
+	if b.Object(md) != order {
+		t.Fatal("Expected the object of a message to be built once")
+	}
+
+	expected := `type Order {
+  createdAt: String
+  displayName: String!
+  id: String!
+  items: [OrderItem!]!
+  labels: [OrderLabelsEntry!]!
+  note: String
+  parent: Order
+  status: Status!
+}
+
+input OrderInput {
+  createdAt: String
+  displayName: String
+  id: String
+  items: [OrderItemInput!]
+  labels: [OrderLabelsEntryInput!]
+  note: String
+  parent: OrderInput
+  status: Status
+}
+
+type OrderItem {
+  quantity: Float!
+  sku: String!
+}
+
+input OrderItemInput {
+  quantity: Float
+  sku: String
+}
+
+type OrderLabelsEntry {
+  key: String!
+  value: Int!
+}
+
+input OrderLabelsEntryInput {
+  key: String
+  value: Int
+}
+
+enum Status {
+  STATUS_SHIPPED
+  STATUS_UNKNOWN
+}
+`
+	if sdl := b.SDL(); sdl != expected {
+		t.Fatalf("Unexpected SDL, Diff: %v", testutil.Diff(expected, sdl))
+	}
+
+	// Fields resolve from messages and arguments set the fields of messages
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"echo": &graphql.Field{
+					Type: order,
+					Args: graphql.FieldConfigArgument{
+						"order": &graphql.ArgumentConfig{Type: graphql.NewNonNull(orderInput)},
+					},
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						m := dynamicpb.NewMessage(md)
+						if err := b.UnmarshalInput(p.Args["order"].(map[string]any), m); err != nil {
+							return nil, err
+						}
+						return m, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema: schema,
+		RequestString: `{
+			echo(order: {
+				id: "9007199254740993"
+				status: STATUS_SHIPPED
+				items: [{sku: "a", quantity: 2}, {sku: "b"}]
+				createdAt: "2024-01-02T03:04:05.5Z"
+				note: "fragile"
+				displayName: "Gift"
+				labels: [{key: "z", value: 1}, {key: "a", value: 2}]
+				parent: {id: "1"}
+			}) {
+				id status items { sku quantity } createdAt note displayName labels { key value }
+				parent { id note createdAt }
+			}
+		}`,
+	})
+	expectedResult := &graphql.Result{
+		Data: map[string]any{
+			"echo": map[string]any{
+				"id":          "9007199254740993",
+				"status":      "STATUS_SHIPPED",
+				"items":       []any{map[string]any{"sku": "a", "quantity": 2.0}, map[string]any{"sku": "b", "quantity": 0.0}},
+				"createdAt":   "2024-01-02T03:04:05.5Z",
+				"note":        "fragile",
+				"displayName": "Gift",
+				"labels":      []any{map[string]any{"key": "a", "value": 2}, map[string]any{"key": "z", "value": 1}},
+				"parent":      map[string]any{"id": "1", "note": nil, "createdAt": nil},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expectedResult, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expectedResult, result))
+	}
+}
+
+func TestGeneratedMessages(t *testing.T) {
+	// Generated messages resolve like dynamic ones
+	b := NewBuilder(Config{})
+	ts := &timestamppb.Timestamp{Seconds: 1700000000, Nanos: 5}
+	fields := b.Object(ts.ProtoReflect().Descriptor()).Fields()
+	for name, expected := range map[string]any{"seconds": "1700000000", "nanos": 5} {
+		value, err := fields[name].Resolve(context.Background(), graphql.ResolveParams{Source: ts})
+		if err != nil || value != expected {
+			t.Errorf("%s: expected %v, got %v, %v", name, expected, value, err)
+		}
+	}
+	if _, err := fields["seconds"].Resolve(context.Background(), graphql.ResolveParams{Source: "s"}); err == nil {
+		t.Error("Expected an error for a source that isn't a message")
+	}
+
+}
@@ -0,0 +1,142 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+type providerKey struct {
+	t reflect.Type
+}
+
+// RegisterProvider registers a dependency in the schema config that resolvers can
+// retrieve by its type using Provider or MustProvider. Interface types should be
+// given explicitly (e.g. RegisterProvider[UserResolvers](&cfg, impl)) as the
+// provider is keyed by the type parameter rather than the dynamic type.
+func RegisterProvider[T any](config *SchemaConfig, provider T) {
+	if config.Providers == nil {
+		config.Providers = make(map[reflect.Type]any)
+	}
+	config.Providers[reflect.TypeFor[T]()] = provider
+}
+
+// WithProvider returns a context that overrides the provider for type T registered
+// in the schema. It's useful to replace dependencies per request (e.g. in tests).
+func WithProvider[T any](ctx context.Context, provider T) context.Context {
+	return context.WithValue(ctx, providerKey{t: reflect.TypeFor[T]()}, provider)
+}
+
+// Provider returns the provider for type T from the context if overridden,
+// otherwise from the version of the schema's ProviderRegistry the request started
+// with, and otherwise from the schema.
+func Provider[T any](ctx context.Context, schema Schema) (T, bool) {
+	t := reflect.TypeFor[T]()
+	if v, ok := ctx.Value(providerKey{t: t}).(T); ok {
+		return v, true
+	}
+	if set := requestProviderSet(ctx, schema.providerRegistry); set != nil {
+		if v, ok := set.providers[t].(T); ok {
+			return v, true
+		}
+	}
+	v, ok := schema.providers[t].(T)
+	return v, ok
+}
+
+// MustProvider returns the provider for type T for the field being resolved. It
+// panics if there's no provider which the executor reports as a field error.
+func MustProvider[T any](ctx context.Context, p ResolveParams) T {
+	v, ok := Provider[T](ctx, p.Info.Schema)
+	if !ok {
+		panic(fmt.Sprintf("No provider registered for type %s.", reflect.TypeFor[T]()))
+	}
+	return v
+}
+
+// ProviderRegistry holds a set of providers that can be replaced atomically while
+// a schema is in use (e.g. to change the resolvers used by generated code from
+// configuration without rebuilding the schema). Every replacement creates a new
+// version of the set and executions keep the version they started with.
+type ProviderRegistry struct {
+	mu      sync.Mutex
+	current atomic.Pointer[providerSet]
+}
+
+type providerSet struct {
+	version   int
+	providers map[reflect.Type]any
+}
+
+type providerSetKey struct{}
+
+// SetProvider replaces the provider for type T in the registry and returns the
+// new version of the set.
+func SetProvider[T any](r *ProviderRegistry, provider T) int {
+	return r.update(func(providers map[reflect.Type]any) {
+		providers[reflect.TypeFor[T]()] = provider
+	})
+}
+
+// Replace replaces all the providers of the registry and returns the new version
+// of the set. The keys are the provider types as with SchemaConfig.Providers.
+func (r *ProviderRegistry) Replace(providers map[reflect.Type]any) int {
+	return r.update(func(p map[reflect.Type]any) {
+		clear(p)
+		maps.Copy(p, providers)
+	})
+}
+
+// Version returns the current version of the set. It's 0 until a provider is set.
+func (r *ProviderRegistry) Version() int {
+	if set := r.current.Load(); set != nil {
+		return set.version
+	}
+	return 0
+}
+
+func (r *ProviderRegistry) update(fn func(providers map[reflect.Type]any)) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	set := &providerSet{version: 1, providers: make(map[reflect.Type]any)}
+	if cur := r.current.Load(); cur != nil {
+		set.version = cur.version + 1
+		maps.Copy(set.providers, cur.providers)
+	}
+	fn(set.providers)
+	r.current.Store(set)
+	return set.version
+}
+
+// withProviderSet returns a context that pins the current version of the
+// registry for the rest of the request.
+func withProviderSet(ctx context.Context, r *ProviderRegistry) context.Context {
+	if r == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, providerSetKey{}, r.current.Load())
+}
+
+// requestProviderSet returns the version of the registry pinned by the request
+// or the current version outside of a request.
+func requestProviderSet(ctx context.Context, r *ProviderRegistry) *providerSet {
+	if r == nil {
+		return nil
+	}
+	if set, ok := ctx.Value(providerSetKey{}).(*providerSet); ok {
+		return set
+	}
+	return r.current.Load()
+}
+
+// ProviderVersion returns the version of the schema's ProviderRegistry used by
+// the request or 0 if the schema has no registry.
+func ProviderVersion(ctx context.Context, schema Schema) int {
+	if set := requestProviderSet(ctx, schema.providerRegistry); set != nil {
+		return set.version
+	}
+	return 0
+}
@@ -0,0 +1,152 @@
+package graphql_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+)
+
+type greeter interface {
+	Greet() string
+}
+
+type staticGreeter string
+
+func (g staticGreeter) Greet() string { return string(g) }
+
+func TestProviders(t *testing.T) {
+	cfg := graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"greeting": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						return graphql.MustProvider[greeter](ctx, p).Greet(), nil
+					},
+				},
+				"count": &graphql.Field{
+					Type: graphql.Int,
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						return graphql.MustProvider[int](ctx, p), nil
+					},
+				},
+			},
+		}),
+	}
+	graphql.RegisterProvider[greeter](&cfg, staticGreeter("hello"))
+	schema, err := graphql.NewSchema(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: `{ greeting }`,
+	})
+	expected := map[string]any{"greeting": "hello"}
+	if len(result.Errors) != 0 || !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("Expected %v, got %v %v", expected, result.Data, result.Errors)
+	}
+
+	// Per request override
+	ctx := graphql.WithProvider[greeter](context.Background(), staticGreeter("hi"))
+	result = graphql.Do(ctx, graphql.Params{
+		Schema:        schema,
+		RequestString: `{ greeting }`,
+	})
+	expected = map[string]any{"greeting": "hi"}
+	if len(result.Errors) != 0 || !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("Expected %v, got %v %v", expected, result.Data, result.Errors)
+	}
+
+	// Missing provider
+	result = graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: `{ count }`,
+	})
+	if len(result.Errors) != 1 || result.Errors[0].Message != "No provider registered for type int." {
+		t.Fatalf("Expected missing provider error, got %v", result.Errors)
+	}
+
+	if _, ok := graphql.Provider[staticGreeter](context.Background(), schema); ok {
+		t.Fatal("Expected providers to be keyed by the registered type")
+	}
+}
+
+func TestProviderRegistry(t *testing.T) {
+	registry := &graphql.ProviderRegistry{}
+	started := make(chan struct{})
+	release := make(chan struct{})
+	cfg := graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"greeting": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						if wait, _ := p.Args["wait"].(bool); wait {
+							close(started)
+							<-release
+						}
+						return graphql.MustProvider[greeter](ctx, p).Greet(), nil
+					},
+					Args: graphql.FieldConfigArgument{
+						"wait": &graphql.ArgumentConfig{Type: graphql.Boolean},
+					},
+				},
+			},
+		}),
+		ProviderRegistry: registry,
+	}
+	graphql.RegisterProvider[greeter](&cfg, staticGreeter("hello"))
+	schema, err := graphql.NewSchema(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	greeting := func(query string) any {
+		result := graphql.Do(context.Background(), graphql.Params{
+			Schema:        schema,
+			RequestString: query,
+		})
+		if len(result.Errors) != 0 {
+			t.Errorf("Unexpected errors: %v", result.Errors)
+		}
+		return result.Data
+	}
+
+	// The schema providers are used until the registry has a provider
+	if expected := map[string]any{"greeting": "hello"}; !reflect.DeepEqual(expected, greeting(`{ greeting }`)) {
+		t.Fatalf("Expected %v", expected)
+	}
+	if v := graphql.SetProvider[greeter](registry, staticGreeter("hi")); v != 1 {
+		t.Fatalf("Expected version 1, got %d", v)
+	}
+
+	// An execution in flight keeps the version it started with
+	inFlight := make(chan any)
+	go func() {
+		inFlight <- greeting(`{ greeting(wait: true) }`)
+	}()
+	<-started
+	if v := graphql.SetProvider[greeter](registry, staticGreeter("hey")); v != 2 {
+		t.Fatalf("Expected version 2, got %d", v)
+	}
+	close(release)
+	if expected, data := map[string]any{"greeting": "hi"}, <-inFlight; !reflect.DeepEqual(expected, data) {
+		t.Fatalf("Expected %v, got %v", expected, data)
+	}
+	if expected := map[string]any{"greeting": "hey"}; !reflect.DeepEqual(expected, greeting(`{ greeting }`)) {
+		t.Fatalf("Expected %v", expected)
+	}
+
+	// Replacing the set falls back to the schema providers for missing types
+	if v := registry.Replace(nil); v != 3 || graphql.ProviderVersion(context.Background(), schema) != 3 {
+		t.Fatalf("Expected version 3, got %d", v)
+	}
+	if expected := map[string]any{"greeting": "hello"}; !reflect.DeepEqual(expected, greeting(`{ greeting }`)) {
+		t.Fatalf("Expected %v", expected)
+	}
+}
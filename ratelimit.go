@@ -0,0 +1,73 @@
+package graphql
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/sprucehealth/graphql/gqlerrors"
+	"github.com/sprucehealth/graphql/language/ast"
+	"github.com/sprucehealth/graphql/language/location"
+)
+
+// RetryAfterExtensionKey is the key of the error extension that holds the
+// number of seconds after which a rate limited request may be retried.
+const RetryAfterExtensionKey = "retryAfter"
+
+// RateLimiter is consulted before an operation is executed. The caller is
+// expected to be identified from the context (e.g. the authenticated account).
+type RateLimiter interface {
+	// Allow returns nil if the operation may be executed. The signature
+	// identifies the operation (its type and name, or a hash of the operation
+	// if it's anonymous) and cost is the number of fields the operation selects.
+	// Returning a *RateLimitError reports when the request may be retried.
+	Allow(ctx context.Context, opSignature string, cost int) error
+}
+
+// RateLimitError is returned by a RateLimiter when a request isn't allowed.
+type RateLimitError struct {
+	// RetryAfter is how long the caller should wait before retrying. It's
+	// rounded up to seconds in the error extensions.
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return gqlerrors.CodeRateLimited.Message()
+}
+
+// rateLimit consults the rate limiter and returns a RESOURCE_EXHAUSTED error if
+// the operation isn't allowed.
+func rateLimit(ctx context.Context, limiter RateLimiter, operation ast.Definition, fragments map[string]*ast.FragmentDefinition) error {
+	err := limiter.Allow(ctx, operationSignature(operation), operationCost(operation, fragments))
+	if err == nil {
+		return nil
+	}
+	fe := gqlerrors.FormattedError{
+		Type:          gqlerrors.ErrorTypeResourceExhausted,
+		Code:          gqlerrors.CodeRateLimited,
+		Message:       err.Error(),
+		Locations:     []location.SourceLocation{},
+		OriginalError: err,
+	}
+	if rle, ok := err.(*RateLimitError); ok && rle.RetryAfter > 0 {
+		fe.Extensions = map[string]any{
+			RetryAfterExtensionKey: int(math.Ceil(rle.RetryAfter.Seconds())),
+		}
+	}
+	return fe
+}
+
+// operationSignature returns the type and name of the operation or the type and
+// the hash of the printed operation if it's anonymous.
+func operationSignature(operation ast.Definition) string {
+	if op, ok := operation.(*ast.OperationDefinition); ok && op.Name != nil && op.Name.Value != "" {
+		return op.Operation + " " + op.Name.Value
+	}
+	return operation.GetOperation() + " " + operationHash(operation)
+}
+
+// operationCost returns the number of fields selected by the operation. Fields
+// of a fragment are counted every time the fragment is spread.
+func operationCost(operation ast.Definition, fragments map[string]*ast.FragmentDefinition) int {
+	return newSelectionCounter(fragments, 0).count(operation.GetSelectionSet())
+}
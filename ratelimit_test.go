@@ -0,0 +1,90 @@
+package graphql_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/gqlerrors"
+)
+
+type testRateLimiter struct {
+	signatures []string
+	costs      []int
+	err        error
+}
+
+func (l *testRateLimiter) Allow(ctx context.Context, opSignature string, cost int) error {
+	l.signatures = append(l.signatures, opSignature)
+	l.costs = append(l.costs, cost)
+	return l.err
+}
+
+func TestRateLimiter(t *testing.T) {
+	var resolved bool
+	schema := testSchema(t, &graphql.Field{
+		Type: graphql.String,
+		Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+			resolved = true
+			return "ok", nil
+		},
+	})
+
+	limiter := &testRateLimiter{}
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: `query Q { test a: test ...F } fragment F on Query { b: test __typename }`,
+		RateLimiter:   limiter,
+	})
+	if len(result.Errors) != 0 || !resolved {
+		t.Fatalf("Unexpected result %+v", result)
+	}
+	if !reflect.DeepEqual([]string{"query Q"}, limiter.signatures) || !reflect.DeepEqual([]int{4}, limiter.costs) {
+		t.Fatalf("Unexpected signatures %v and costs %v", limiter.signatures, limiter.costs)
+	}
+
+	// Anonymous operations are identified by their hash
+	limiter = &testRateLimiter{}
+	for i := 0; i < 2; i++ {
+		graphql.Do(context.Background(), graphql.Params{
+			Schema:        schema,
+			RequestString: `{ test }`,
+			RateLimiter:   limiter,
+		})
+	}
+	if len(limiter.signatures) != 2 || limiter.signatures[0] != limiter.signatures[1] || !strings.HasPrefix(limiter.signatures[0], "query ") {
+		t.Fatalf("Unexpected signatures %v", limiter.signatures)
+	}
+
+	resolved = false
+	limiter = &testRateLimiter{err: &graphql.RateLimitError{RetryAfter: 1500 * time.Millisecond}}
+	result = graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: `{ test }`,
+		RateLimiter:   limiter,
+	})
+	if resolved || result.Data != nil || len(result.Errors) != 1 {
+		t.Fatalf("Expected request to be rejected, got %+v", result)
+	}
+	e := result.Errors[0]
+	if e.Type != gqlerrors.ErrorTypeResourceExhausted || e.Message != "Rate limit exceeded." {
+		t.Fatalf("Unexpected error %+v", e)
+	}
+	if !reflect.DeepEqual(map[string]any{graphql.RetryAfterExtensionKey: 2}, e.Extensions) {
+		t.Fatalf("Unexpected extensions %v", e.Extensions)
+	}
+
+	limiter = &testRateLimiter{err: errors.New("Quota exceeded.")}
+	result = graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: `{ test }`,
+		RateLimiter:   limiter,
+	})
+	if len(result.Errors) != 1 || result.Errors[0].Type != gqlerrors.ErrorTypeResourceExhausted || result.Errors[0].Extensions != nil {
+		t.Fatalf("Unexpected errors %+v", result.Errors)
+	}
+}
@@ -0,0 +1,50 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/sprucehealth/graphql/gqlerrors"
+	"github.com/sprucehealth/graphql/language/ast"
+)
+
+// RawJSON is an already encoded JSON value returned by the resolver of a JSON
+// scalar field (a scalar named JSON, or any scalar field with Field.RawJSON set),
+// e.g. the payload of a proxied service. It's checked to be valid JSON but
+// otherwise isn't decoded or serialized by the scalar, and WriteJSON embeds it in
+// the response verbatim. encoding/json embeds it as well but compacts it.
+type RawJSON []byte
+
+// MarshalJSON implements json.Marshaler.
+func (r RawJSON) MarshalJSON() ([]byte, error) {
+	if r == nil {
+		return []byte("null"), nil
+	}
+	return r, nil
+}
+
+// acceptsRawJSON returns true if RawJSON values are accepted for the field of the
+// scalar type.
+func acceptsRawJSON(scalar *Scalar, info ResolveInfo) bool {
+	if scalar.Name() == "JSON" {
+		return true
+	}
+	parentType, ok := info.ParentType.(*Object)
+	if !ok {
+		return false
+	}
+	fieldDef := parentType.Fields()[info.FieldName]
+	return fieldDef != nil && fieldDef.rawJSON
+}
+
+// completeRawJSONValue completes a RawJSON value, raising an error if it isn't
+// valid JSON.
+func completeRawJSONValue(info ResolveInfo, fieldASTs []*ast.Field, value RawJSON) any {
+	if !json.Valid(value) {
+		panic(gqlerrors.FormatError(NewLocatedError(
+			fmt.Sprintf("Invalid raw JSON value for field %v.%v.", info.ParentType, info.FieldName),
+			FieldASTsToNodeASTs(fieldASTs),
+		)))
+	}
+	return value
+}
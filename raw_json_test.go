@@ -0,0 +1,73 @@
+package graphql_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+)
+
+func TestRawJSON(t *testing.T) {
+	jsonScalar := graphql.NewScalar(graphql.ScalarConfig{
+		Name: "JSON",
+		Serialize: func(value any) any {
+			return value
+		},
+	})
+	payload := graphql.RawJSON(`{"b": [1, 2], "a": "x"}`)
+	resolve := func(value any) graphql.FieldResolveFn {
+		return func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+			return value, nil
+		}
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"json":    &graphql.Field{Type: jsonScalar, Resolve: resolve(payload)},
+				"list":    &graphql.Field{Type: graphql.NewList(jsonScalar), Resolve: resolve([]any{payload, graphql.RawJSON("null")})},
+				"invalid": &graphql.Field{Type: jsonScalar, Resolve: resolve(graphql.RawJSON(`{"a":`))},
+				"optIn":   &graphql.Field{Type: graphql.String, RawJSON: true, Resolve: resolve(graphql.RawJSON(`"s"`))},
+				"string":  &graphql.Field{Type: graphql.String, Resolve: resolve(graphql.RawJSON(`"s"`))},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := graphql.Do(context.Background(), graphql.Params{Schema: schema, RequestString: `{ json list optIn }`})
+	if len(result.Errors) != 0 {
+		t.Fatalf("Unexpected errors %v", result.Errors)
+	}
+	var buf bytes.Buffer
+	if err := graphql.WriteJSON(&buf, result); err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"data":{"json":{"b": [1, 2], "a": "x"},"list":[{"b": [1, 2], "a": "x"},null],"optIn":"s"}}`
+	if buf.String() != expected {
+		t.Fatalf("Expected %s, got %s", expected, buf.String())
+	}
+	b, err := json.Marshal(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = `{"data":{"json":{"b":[1,2],"a":"x"},"list":[{"b":[1,2],"a":"x"},null],"optIn":"s"}}`
+	if string(b) != expected {
+		t.Fatalf("Expected %s, got %s", expected, b)
+	}
+
+	// Invalid JSON fails the field
+	result = graphql.Do(context.Background(), graphql.Params{Schema: schema, RequestString: `{ invalid }`})
+	if len(result.Errors) != 1 || result.Errors[0].Message != "Invalid raw JSON value for field Query.invalid." {
+		t.Fatalf("Expected an invalid JSON error, got %+v", result)
+	}
+
+	// Other scalar fields serialize the value as usual
+	result = graphql.Do(context.Background(), graphql.Params{Schema: schema, RequestString: `{ string }`})
+	if _, ok := result.Data.(map[string]any)["string"].(graphql.RawJSON); ok {
+		t.Fatalf("Expected the value to be serialized, got %+v", result)
+	}
+}
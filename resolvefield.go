@@ -0,0 +1,91 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sprucehealth/graphql/astbuilder"
+	"github.com/sprucehealth/graphql/gqlerrors"
+	"github.com/sprucehealth/graphql/language/ast"
+)
+
+// ResolveFieldForTest evaluates a single field of an object type using the same
+// argument coercion, middleware, and completion as full execution. It's meant for
+// unit testing individual resolvers without having to construct a query string.
+//
+// Arguments are provided as they would be for variables and are coerced to the
+// argument types. Leaf values (and lists of leaf values) are completed and
+// serialized. Since there's no selection set, the resolved value of a field
+// returning a composite type is returned without completion.
+func ResolveFieldForTest(ctx context.Context, schema Schema, typeName, fieldName string, source any, args map[string]any) (result any, err error) {
+	parentType, ok := schema.Type(typeName).(*Object)
+	if !ok {
+		return nil, fmt.Errorf("Unknown object type %q.", typeName)
+	}
+	fieldDef := getFieldDef(schema, parentType, fieldName, false)
+	if fieldDef == nil {
+		return nil, fmt.Errorf("Cannot query field %q on type %q.", fieldName, typeName)
+	}
+
+	// Pass arguments through variables to get the same coercion as a request.
+	field := astbuilder.NewField(fieldName)
+	variableValues := make(map[string]any, len(args))
+	for _, argDef := range fieldDef.Args {
+		value, ok := args[argDef.PrivateName]
+		if !ok {
+			if _, isNonNull := argDef.Type.(*NonNull); isNonNull && argDef.DefaultValue == nil {
+				return nil, gqlerrors.NewError(gqlerrors.ErrorTypeInvalidInput,
+					fmt.Sprintf("Argument %q of required type %q was not provided.", argDef.PrivateName, argDef.Type), nil, "", nil, nil, nil)
+			}
+			continue
+		}
+		if ok, messages := isValidInputValue(value, argDef.Type, schema.strictIDInput); !ok {
+			msg := fmt.Sprintf("Argument %q has invalid value %v.", argDef.PrivateName, value)
+			if len(messages) != 0 {
+				msg += "\n" + messages[0]
+			}
+			return nil, gqlerrors.NewError(gqlerrors.ErrorTypeInvalidInput, msg, nil, "", nil, nil, nil)
+		}
+		variableValues[argDef.PrivateName] = coerceValue(argDef.Type, value)
+		field.Arg(argDef.PrivateName, astbuilder.Variable(argDef.PrivateName))
+	}
+	for name := range args {
+		found := false
+		for _, argDef := range fieldDef.Args {
+			if argDef.PrivateName == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("Unknown argument %q on field %q of type %q.", name, fieldName, typeName)
+		}
+	}
+
+	eCtx := &ExecutionContext{
+		Schema:         schema,
+		Fragments:      map[string]*ast.FragmentDefinition{},
+		Root:           source,
+		Operation:      &ast.OperationDefinition{Operation: ast.OperationTypeQuery},
+		VariableValues: variableValues,
+	}
+	fieldASTs := []*ast.Field{field.Build()}
+	path := []string{fieldName}
+
+	defer func() {
+		if r := recover(); r != nil {
+			result = nil
+			err = gqlerrors.FormatPanic(r)
+		}
+	}()
+
+	result, info := resolveFieldValue(ctx, eCtx, parentType, fieldDef, source, fieldASTs, path, nil, nil)
+	if !IsLeafType(fieldDef.Type) {
+		return result, nil
+	}
+	result = completeValueCatchingError(ctx, eCtx, fieldDef.Type, fieldASTs, info, result, path)
+	if errs := eCtx.errors(); len(errs) != 0 {
+		return nil, errs[0]
+	}
+	return result, nil
+}
@@ -0,0 +1,47 @@
+package graphql_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+)
+
+func TestResolveFieldForTest(t *testing.T) {
+	schema := testSchema(t, &graphql.Field{
+		Type: graphql.NewNonNull(graphql.String),
+		Args: graphql.FieldConfigArgument{
+			"count":  &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+			"suffix": &graphql.ArgumentConfig{Type: graphql.String, DefaultValue: "!"},
+		},
+		Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+			if p.Args["count"].(int) == 0 {
+				return nil, nil
+			}
+			return p.Source.(string) + p.Args["suffix"].(string), nil
+		},
+	})
+
+	result, err := graphql.ResolveFieldForTest(context.Background(), schema, "Query", "test", "hello", map[string]any{"count": 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result != "hello!" {
+		t.Fatalf("Expected hello!, got %v", result)
+	}
+
+	if _, err := graphql.ResolveFieldForTest(context.Background(), schema, "Query", "test", "hello", map[string]any{"count": "foo"}); err == nil {
+		t.Fatal("Expected error for invalid argument")
+	}
+	if _, err := graphql.ResolveFieldForTest(context.Background(), schema, "Query", "test", "hello", nil); err == nil {
+		t.Fatal("Expected error for missing required argument")
+	}
+	if _, err := graphql.ResolveFieldForTest(context.Background(), schema, "Query", "test", "hello", map[string]any{"count": 0}); err == nil {
+		t.Fatal("Expected error for null non-null result")
+	} else if err.Error() != "Cannot return null for non-nullable field Query.test." {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := graphql.ResolveFieldForTest(context.Background(), schema, "Query", "missing", nil, nil); err == nil {
+		t.Fatal("Expected error for unknown field")
+	}
+}
@@ -0,0 +1,19 @@
+package graphql
+
+import (
+	"fmt"
+	"strings"
+)
+
+// checkResolverOverrides returns an error if a resolver override is keyed by a
+// field that isn't a field of an object type of the schema, which is likely a typo.
+func checkResolverOverrides(schema *Schema, overrides map[string]FieldResolveFn) error {
+	for _, key := range sortedKeys(overrides) {
+		typeName, fieldName, _ := strings.Cut(key, ".")
+		ttype, ok := schema.Type(typeName).(*Object)
+		if !ok || ttype.Fields()[fieldName] == nil {
+			return fmt.Errorf("Resolver override for unknown field %q.", key)
+		}
+	}
+	return nil
+}
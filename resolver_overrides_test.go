@@ -0,0 +1,74 @@
+package graphql_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+func TestResolverOverrides(t *testing.T) {
+	userType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.String},
+			"score": &graphql.Field{
+				Type: graphql.Int,
+				Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+					return 1, nil
+				},
+			},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"user": &graphql.Field{
+					Type: userType,
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						return map[string]any{"name": "Ann"}, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	overrides := map[string]graphql.FieldResolveFn{
+		"User.score": func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+			return 2, nil
+		},
+		"User.name": func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+			return p.Source.(map[string]any)["name"].(string) + " (canary)", nil
+		},
+	}
+	const query = `{ user { name score } }`
+	result := graphql.Do(context.Background(), graphql.Params{Schema: schema, RequestString: query, ResolverOverrides: overrides})
+	expected := &graphql.Result{Data: map[string]any{"user": map[string]any{"name": "Ann (canary)", "score": 2}}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatal(testutil.Diff(expected, result))
+	}
+
+	// Other requests use the resolvers of the schema.
+	result = graphql.Do(context.Background(), graphql.Params{Schema: schema, RequestString: query})
+	expected = &graphql.Result{Data: map[string]any{"user": map[string]any{"name": "Ann", "score": 1}}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatal(testutil.Diff(expected, result))
+	}
+
+	result = graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: query,
+		ResolverOverrides: map[string]graphql.FieldResolveFn{
+			"User.scroe": overrides["User.score"],
+		},
+	})
+	if len(result.Errors) != 1 || result.Errors[0].Message != `Resolver override for unknown field "User.scroe".` {
+		t.Fatalf("Unexpected result %+v", result)
+	}
+}
@@ -0,0 +1,109 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"reflect"
+)
+
+// DiagnosticResolverType is a field whose resolver returns a Go type that can't
+// be completed as the type of the field (e.g. a string for a list). It's only
+// reported when SchemaConfig.CheckResolverTypes is set.
+const DiagnosticResolverType SchemaDiagnosticKind = "resolverType"
+
+// TypedResolver sets the resolver of the field and records the Go type it returns
+// in Field.ResultType so that it can be checked against the type of the field
+// when SchemaConfig.CheckResolverTypes is set.
+func TypedResolver[T any](field *Field, resolve func(ctx context.Context, p ResolveParams) (T, error)) *Field {
+	field.Resolve = func(ctx context.Context, p ResolveParams) (any, error) {
+		return resolve(ctx, p)
+	}
+	field.ResultType = reflect.TypeFor[T]()
+	return field
+}
+
+var (
+	stringerType = reflect.TypeFor[fmt.Stringer]()
+	bigIntType   = reflect.TypeFor[big.Int]()
+)
+
+// resolverTypeDiagnostics checks that the result types of the fields of object
+// types are plausibly compatible with the types of the fields. Values of
+// interface types and custom scalars are assumed to be compatible.
+func resolverTypeDiagnostics(typeMap TypeMap) []SchemaDiagnostic {
+	var diagnostics []SchemaDiagnostic
+	for _, typeName := range sortedKeys(typeMap) {
+		ttype, ok := typeMap[typeName].(*Object)
+		if !ok {
+			continue
+		}
+		fields := ttype.Fields()
+		for _, fieldName := range sortedKeys(fields) {
+			fieldDef := fields[fieldName]
+			if fieldDef.resultType == nil {
+				continue
+			}
+			if reason := incompatibleResultType(fieldDef.resultType, fieldDef.Type); reason != "" {
+				diagnostics = append(diagnostics, SchemaDiagnostic{
+					Kind:    DiagnosticResolverType,
+					Type:    typeName,
+					Field:   fieldName,
+					Message: fmt.Sprintf("%s.%s of type %s resolves to %s: %s.", typeName, fieldName, fieldDef.Type, fieldDef.resultType, reason),
+				})
+			}
+		}
+	}
+	return diagnostics
+}
+
+// incompatibleResultType returns why values of the Go type can't be completed as
+// the GraphQL type or an empty string if they plausibly can.
+func incompatibleResultType(goType reflect.Type, ttype Type) string {
+	for goType.Kind() == reflect.Pointer {
+		goType = goType.Elem()
+	}
+	if goType.Kind() == reflect.Interface {
+		return ""
+	}
+	switch ttype := ttype.(type) {
+	case *NonNull:
+		return incompatibleResultType(goType, ttype.OfType)
+	case *List:
+		if goType.Kind() != reflect.Slice && goType.Kind() != reflect.Array {
+			return "expected a slice or array"
+		}
+		return incompatibleResultType(goType.Elem(), ttype.OfType)
+	case *Object, *Interface, *Union:
+		switch goType.Kind() {
+		case reflect.Struct, reflect.Map:
+			return ""
+		}
+		return "expected a struct or map"
+	case *Scalar:
+		if !isCompositeKind(goType.Kind()) {
+			return ""
+		}
+		switch ttype {
+		case Int, Float, Boolean:
+			if goType == bigIntType {
+				return ""
+			}
+			return "expected a number or boolean"
+		case String, ID:
+			if goType.Implements(stringerType) || reflect.PointerTo(goType).Implements(stringerType) {
+				return ""
+			}
+			return "expected a string, number, or fmt.Stringer"
+		}
+	}
+	return ""
+}
+
+func isCompositeKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array, reflect.Func, reflect.Chan:
+		return true
+	}
+	return false
+}
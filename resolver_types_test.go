@@ -0,0 +1,74 @@
+package graphql_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+)
+
+type testResolverTypesUser struct {
+	Name string
+}
+
+func TestCheckResolverTypes(t *testing.T) {
+	userType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.String},
+		},
+	})
+	config := func() graphql.SchemaConfig {
+		return graphql.SchemaConfig{
+			Query: graphql.NewObject(graphql.ObjectConfig{
+				Name: "Query",
+				Fields: graphql.Fields{
+					"scores": graphql.TypedResolver(&graphql.Field{Type: graphql.NewList(graphql.Int)},
+						func(ctx context.Context, p graphql.ResolveParams) (string, error) {
+							return "1,2", nil
+						}),
+					"user": graphql.TypedResolver(&graphql.Field{Type: graphql.NewNonNull(userType)},
+						func(ctx context.Context, p graphql.ResolveParams) (*testResolverTypesUser, error) {
+							return &testResolverTypesUser{Name: "Ann"}, nil
+						}),
+					"users": graphql.TypedResolver(&graphql.Field{Type: graphql.NewList(userType)},
+						func(ctx context.Context, p graphql.ResolveParams) ([]string, error) {
+							return nil, nil
+						}),
+					"ids": graphql.TypedResolver(&graphql.Field{Type: graphql.NewList(graphql.ID)},
+						func(ctx context.Context, p graphql.ResolveParams) ([]int64, error) {
+							return []int64{1}, nil
+						}),
+					"anything": graphql.TypedResolver(&graphql.Field{Type: userType},
+						func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+							return nil, nil
+						}),
+				},
+			}),
+			CheckResolverTypes: true,
+		}
+	}
+
+	expected := "Query.scores of type [Int] resolves to string: expected a slice or array."
+	if _, err := graphql.NewSchema(config()); err == nil || err.Error() != expected {
+		t.Fatalf("Expected error %q, got %v", expected, err)
+	}
+
+	diagnostics := graphql.DiagnoseSchema(config())
+	expectedDiagnostics := "resolverType: Query.scores of type [Int] resolves to string: expected a slice or array.\n" +
+		"resolverType: Query.users of type [User] resolves to []string: expected a struct or map."
+	if s := diagnostics.String(); s != expectedDiagnostics {
+		t.Fatalf("Unexpected diagnostics:\n%s", s)
+	}
+
+	c := config()
+	c.CheckResolverTypes = false
+	schema, err := graphql.NewSchema(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := graphql.Do(context.Background(), graphql.Params{Schema: schema, RequestString: `{ user { name } ids }`})
+	if len(result.Errors) != 0 {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+}
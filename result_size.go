@@ -0,0 +1,90 @@
+package graphql
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/sprucehealth/graphql/gqlerrors"
+	"github.com/sprucehealth/graphql/language/ast"
+)
+
+// resultValueOverhead is the approximate size of a value in the result other than
+// the length of strings (e.g. numbers, list items, and the separators and quotes
+// in the encoded response).
+const resultValueOverhead = 8
+
+// ResultSizeError is returned when the result of an operation grows larger than
+// ExecuteParams.MaxResultBytes.
+type ResultSizeError struct {
+	// Limit is the maximum size of the result in bytes.
+	Limit int
+}
+
+func (e *ResultSizeError) Error() string {
+	return gqlerrors.CodeResultTooLarge.Message(e.Limit)
+}
+
+// resultSize tracks the approximate size of the result during execution. A nil
+// *resultSize doesn't track anything.
+type resultSize struct {
+	limit    int
+	mu       sync.Mutex
+	bytes    int
+	reported bool
+}
+
+// add adds n bytes to the size of the result and raises a *ResultSizeError if
+// the result is larger than the limit.
+func (s *resultSize) add(n int, fieldASTs []*ast.Field, path []string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	s.bytes += n
+	s.mu.Unlock()
+	s.check(fieldASTs, path)
+}
+
+// check raises a *ResultSizeError if the result is larger than the limit.
+func (s *resultSize) check(fieldASTs []*ast.Field, path []string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	exceeded := s.bytes > s.limit
+	s.mu.Unlock()
+	if !exceeded {
+		return
+	}
+	sizeErr := &ResultSizeError{Limit: s.limit}
+	fe := gqlerrors.FormatError(gqlerrors.CodeResultTooLarge.New(FieldASTsToNodeASTs(fieldASTs), sizeErr, s.limit))
+	fe.Path = make([]any, len(path))
+	for i, p := range path {
+		fe.Path[i] = p
+	}
+	panic(fe)
+}
+
+// shouldReport returns false for all but the first *ResultSizeError as every field
+// completed after the limit is reached fails with the same error.
+func (s *resultSize) shouldReport(err gqlerrors.FormattedError) bool {
+	var sizeErr *ResultSizeError
+	if s == nil || !errors.As(err.OriginalError, &sizeErr) {
+		return true
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.reported {
+		return false
+	}
+	s.reported = true
+	return true
+}
+
+// leafValueSize returns the approximate size of a serialized leaf value.
+func leafValueSize(v any) int {
+	if s, ok := v.(string); ok {
+		return len(s) + resultValueOverhead
+	}
+	return resultValueOverhead
+}
@@ -0,0 +1,91 @@
+package graphql_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/gqlerrors"
+)
+
+func TestMaxResultBytes(t *testing.T) {
+	var calls int
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"a": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						calls++
+						return "hello", nil
+					},
+				},
+				"items": &graphql.Field{
+					Type: graphql.NewList(graphql.String),
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						calls++
+						items := make([]string, 10)
+						for i := range items {
+							items[i] = strings.Repeat("x", 20)
+						}
+						return items, nil
+					},
+				},
+				"z": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						calls++
+						return "after", nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema:         schema,
+		RequestString:  `{ a items z }`,
+		Deterministic:  true,
+		MaxResultBytes: 200,
+	})
+	if len(result.Errors) != 1 {
+		t.Fatalf("Expected 1 error, got %+v", result.Errors)
+	}
+	var sizeErr *graphql.ResultSizeError
+	if e := result.Errors[0]; e.Type != gqlerrors.ErrorTypeResourceExhausted || !errors.As(e.OriginalError, &sizeErr) || sizeErr.Limit != 200 {
+		t.Fatalf("Unexpected error %+v", e)
+	}
+	if !reflect.DeepEqual([]any{"items"}, result.Errors[0].Path) {
+		t.Fatalf("Unexpected error path %v", result.Errors[0].Path)
+	}
+	// The list and items complete until the limit is reached
+	x := strings.Repeat("x", 20)
+	expected := map[string]any{
+		"a":     "hello",
+		"items": []any{x, x, x, nil, nil, nil, nil, nil, nil, nil},
+		"z":     nil,
+	}
+	if !reflect.DeepEqual(expected, result.Data) {
+		t.Fatalf("Expected %v, got %v", expected, result.Data)
+	}
+	// Fields after the limit is reached aren't resolved
+	if calls != 2 {
+		t.Fatalf("Expected 2 resolver calls, got %d", calls)
+	}
+
+	result = graphql.Do(context.Background(), graphql.Params{
+		Schema:         schema,
+		RequestString:  `{ a items z }`,
+		MaxResultBytes: 1000,
+	})
+	if len(result.Errors) != 0 {
+		t.Fatalf("Unexpected errors %+v", result.Errors)
+	}
+}
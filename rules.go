@@ -1,6 +1,7 @@
 package graphql
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"sort"
@@ -16,6 +17,7 @@ import (
 var SpecifiedRules = []ValidationRuleFn{
 	ArgumentsOfCorrectTypeRule,
 	DefaultValuesOfCorrectTypeRule,
+	ExecutableDefinitionsRule,
 	FieldsOnCorrectTypeRule,
 	FragmentsOnCompositeTypesRule,
 	KnownArgumentNamesRule,
@@ -64,6 +66,31 @@ func reportErrorAndReturn(context *ValidationContext, message string, nodes []as
 	return visitor.ActionNoChange, nil
 }
 
+// reportDuplicateNames reports a single error for each name that occurs more than
+// once which includes the locations of all occurrences. Errors are reported in
+// order of the first occurrence of each name.
+func reportDuplicateNames(context *ValidationContext, names []*ast.Name, messageFormat string) {
+	if len(names) < 2 {
+		return
+	}
+	occurrences := make(map[string][]ast.Node, len(names))
+	var order []string
+	for _, name := range names {
+		if name == nil {
+			continue
+		}
+		if _, ok := occurrences[name.Value]; !ok {
+			order = append(order, name.Value)
+		}
+		occurrences[name.Value] = append(occurrences[name.Value], name)
+	}
+	for _, name := range order {
+		if nodes := occurrences[name]; len(nodes) > 1 {
+			context.ReportError(newValidationError(fmt.Sprintf(messageFormat, name), nodes))
+		}
+	}
+}
+
 // ArgumentsOfCorrectTypeRule Argument values of correct type
 //
 // A GraphQL document is only valid if all field argument literal values are
@@ -75,7 +102,7 @@ func ArgumentsOfCorrectTypeRule(context *ValidationContext) *ValidationRuleInsta
 				value := argAST.Value
 				argDef := context.Argument()
 				if argDef != nil {
-					isValid, messages := isValidLiteralValue(argDef.Type, value)
+					isValid, messages := isValidLiteralValue(argDef.Type, value, context.Schema().strictIDInput)
 					if !isValid {
 						argNameValue := ""
 						if argAST.Name != nil {
@@ -116,16 +143,19 @@ func DefaultValuesOfCorrectTypeRule(context *ValidationContext) *ValidationRuleI
 				defaultValue := node.DefaultValue
 				ttype := context.InputType()
 
-				if ttype, ok := ttype.(*NonNull); ok && defaultValue != nil {
+				// Default values must be constant. The parser rejects variables
+				// in default values but documents may be built without it.
+				if variable := findVariable(defaultValue); variable != nil {
 					return reportErrorAndReturn(
 						context,
-						fmt.Sprintf(`Variable "$%v" of type "%v" is required and will not use the default value. Perhaps you meant to use type "%v".`,
-							name, ttype, ttype.OfType),
-						[]ast.Node{defaultValue},
+						fmt.Sprintf(`Variable "$%v" has a default value that references variable "$%v" which is not allowed.`,
+							name, variableName(variable)),
+						[]ast.Node{variable},
 					)
 				}
+
 				if ttype != nil && defaultValue != nil {
-					isValid, messages := isValidLiteralValue(ttype, defaultValue)
+					isValid, messages := isValidLiteralValue(ttype, defaultValue, context.Schema().strictIDInput)
 					if ttype != nil && defaultValue != nil && !isValid {
 						var messagesStr string
 						if len(messages) > 0 {
@@ -149,6 +179,38 @@ func DefaultValuesOfCorrectTypeRule(context *ValidationContext) *ValidationRuleI
 		},
 	}
 }
+
+// findVariable returns the first variable in a value or nil if it's constant.
+func findVariable(value ast.Value) *ast.Variable {
+	switch value := value.(type) {
+	case *ast.Variable:
+		return value
+	case *ast.ListValue:
+		for _, v := range value.Values {
+			if variable := findVariable(v); variable != nil {
+				return variable
+			}
+		}
+	case *ast.ObjectValue:
+		for _, f := range value.Fields {
+			if f == nil {
+				continue
+			}
+			if variable := findVariable(f.Value); variable != nil {
+				return variable
+			}
+		}
+	}
+	return nil
+}
+
+func variableName(variable *ast.Variable) string {
+	if variable.Name == nil {
+		return ""
+	}
+	return variable.Name.Value
+}
+
 func quoteStrings(slice []string) []string {
 	quoted := []string{}
 	for _, s := range slice {
@@ -176,6 +238,55 @@ func quotedOrList(slice []string) string {
 	}
 	return quoted[0]
 }
+
+// ExecutableDefinitionsRule Executable definitions
+//
+// A GraphQL document is only valid for execution if all definitions are either
+// operation or fragment definitions.
+func ExecutableDefinitionsRule(context *ValidationContext) *ValidationRuleInstance {
+	return &ValidationRuleInstance{
+		Enter: func(p visitor.VisitFuncParams) (string, any) {
+			node, ok := p.Node.(*ast.Document)
+			if !ok {
+				return visitor.ActionNoChange, nil
+			}
+			for _, definition := range node.Definitions {
+				switch definition := definition.(type) {
+				case *ast.OperationDefinition, *ast.FragmentDefinition:
+				default:
+					context.ReportError(newValidationError(
+						NonExecutableDefinitionMessage(definitionName(definition)),
+						[]ast.Node{definition},
+					))
+				}
+			}
+			return visitor.ActionSkip, nil
+		},
+	}
+}
+
+func NonExecutableDefinitionMessage(defName string) string {
+	return fmt.Sprintf(`The "%v" definition is not executable.`, defName)
+}
+
+// definitionName returns the name of a type system definition or "schema" for
+// the schema definition.
+func definitionName(definition ast.Node) string {
+	switch definition := definition.(type) {
+	case *ast.SchemaDefinition:
+		return "schema"
+	case *ast.TypeExtensionDefinition:
+		if definition.Definition != nil && definition.Definition.Name != nil {
+			return definition.Definition.Name.Value
+		}
+	case interface{ GetName() *ast.Name }:
+		if name := definition.GetName(); name != nil {
+			return name.Value
+		}
+	}
+	return safeNodeType(definition)
+}
+
 func UndefinedFieldMessage(fieldName string, ttypeName string, suggestedTypeNames []string, suggestedFieldNames []string) string {
 	message := fmt.Sprintf(`Cannot query field "%v" on type "%v".`, fieldName, ttypeName)
 	if len(suggestedTypeNames) > 0 {
@@ -199,7 +310,7 @@ func FieldsOnCorrectTypeRule(context *ValidationContext) *ValidationRuleInstance
 				if ttype != nil {
 					fieldDef := context.FieldDef()
 					// This isn't valid. Let's find suggestions, if any.
-					if fieldDef == nil {
+					if fieldDef == nil || fieldDef.hidden {
 						nodeName := ""
 						if node.Name != nil {
 							nodeName = node.Name.Value
@@ -230,6 +341,9 @@ func FieldsOnCorrectTypeRule(context *ValidationContext) *ValidationRuleInstance
 func getSuggestedTypeNames(schema *Schema, ttype Output, fieldName string) []string {
 
 	possibleTypes := schema.PossibleTypes(ttype)
+	if len(possibleTypes) == 0 && schema.logger != nil && IsAbstractType(ttype) {
+		schema.logger.WarnContext(context.Background(), "graphql: abstract type has no possible types", "type", ttype.Name())
+	}
 
 	var suggestedObjectTypes []string
 	var suggestedInterfaces []*suggestedInterface
@@ -304,8 +418,10 @@ func getSuggestedFieldNames(ttype Output, fieldName string) []string {
 		return []string{}
 	}
 	possibleFieldNames := make([]string, 0, len(fields))
-	for possibleFieldName := range fields {
-		possibleFieldNames = append(possibleFieldNames, possibleFieldName)
+	for possibleFieldName, fieldDef := range fields {
+		if !fieldDef.isGated() {
+			possibleFieldNames = append(possibleFieldNames, possibleFieldName)
+		}
 	}
 	return suggestionList(fieldName, possibleFieldNames)
 }
@@ -417,7 +533,7 @@ func KnownArgumentNamesRule(context *ValidationContext) *ValidationRuleInstance
 					var fieldArgDef *Argument
 					for _, arg := range fieldDef.Args {
 						argNames = append(argNames, arg.Name())
-						if arg.Name() == nodeName {
+						if arg.hasName(nodeName) {
 							fieldArgDef = arg
 						}
 					}
@@ -446,7 +562,7 @@ func KnownArgumentNamesRule(context *ValidationContext) *ValidationRuleInstance
 					var directiveArgDef *Argument
 					for _, arg := range directive.Args {
 						argNames = append(argNames, arg.Name())
-						if arg.Name() == nodeName {
+						if arg.hasName(nodeName) {
 							directiveArgDef = arg
 						}
 					}
@@ -1270,10 +1386,10 @@ func findConflict(context *ValidationContext, parentFieldsAreMutuallyExclusive b
 	var type1 Type
 	var type2 Type
 	if def1 != nil {
-		type1 = def1.Type
+		type1 = fieldNullabilityType(def1.Type, ast1.Nullability)
 	}
 	if def2 != nil {
-		type2 = def2.Type
+		type2 = fieldNullabilityType(def2.Type, ast2.Nullability)
 	}
 
 	// If it is known that two fields could not possibly apply at the same
@@ -1523,7 +1639,7 @@ func ProvidedNonNullArgumentsRule(context *ValidationContext) *ValidationRuleIns
 					argASTMap[name] = arg
 				}
 				for _, argDef := range fieldDef.Args {
-					if argAST := argASTMap[argDef.Name()]; argAST == nil {
+					if argAST, _ := lookupAlias(argASTMap, argDef.Name(), argDef.Aliases); argAST == nil {
 						if argDefType, ok := argDef.Type.(*NonNull); ok {
 							fieldName := ""
 							if fieldAST.Name != nil {
@@ -1559,7 +1675,7 @@ func ProvidedNonNullArgumentsRule(context *ValidationContext) *ValidationRuleIns
 				}
 
 				for _, argDef := range directiveDef.Args {
-					if argAST := argASTMap[argDef.Name()]; argAST == nil {
+					if argAST, _ := lookupAlias(argASTMap, argDef.Name(), argDef.Aliases); argAST == nil {
 						if argDefType, ok := argDef.Type.(*NonNull); ok {
 							directiveName := ""
 							if directiveAST.Name != nil {
@@ -1619,33 +1735,23 @@ func ScalarLeafsRule(context *ValidationContext) *ValidationRuleInstance {
 // A GraphQL field or directive is only valid if all supplied arguments are
 // uniquely named.
 func UniqueArgumentNamesRule(context *ValidationContext) *ValidationRuleInstance {
-	knownArgNames := make(map[string]*ast.Name)
-
 	return &ValidationRuleInstance{
 		Enter: func(p visitor.VisitFuncParams) (string, any) {
+			var args []*ast.Argument
 			switch node := p.Node.(type) {
 			case *ast.Field:
-				if len(knownArgNames) != 0 {
-					knownArgNames = make(map[string]*ast.Name)
-				}
+				args = node.Arguments
 			case *ast.Directive:
-				if len(knownArgNames) != 0 {
-					knownArgNames = make(map[string]*ast.Name)
-				}
-			case *ast.Argument:
-				argName := ""
-				if node.Name != nil {
-					argName = node.Name.Value
-				}
-				if nameAST, ok := knownArgNames[argName]; ok {
-					return reportErrorAndReturn(
-						context,
-						fmt.Sprintf(`There can be only one argument named "%v".`, argName),
-						[]ast.Node{nameAST, node.Name},
-					)
+				args = node.Arguments
+			default:
+				return visitor.ActionNoChange, nil
+			}
+			if len(args) > 1 {
+				names := make([]*ast.Name, len(args))
+				for i, arg := range args {
+					names[i] = arg.Name
 				}
-				knownArgNames[argName] = node.Name
-				return visitor.ActionSkip, nil
+				reportDuplicateNames(context, names, `There can be only one argument named "%v".`)
 			}
 			return visitor.ActionNoChange, nil
 		},
@@ -1656,27 +1762,18 @@ func UniqueArgumentNamesRule(context *ValidationContext) *ValidationRuleInstance
 //
 // A GraphQL document is only valid if all defined fragments have unique names.
 func UniqueFragmentNamesRule(context *ValidationContext) *ValidationRuleInstance {
-	knownFragmentNames := make(map[string]*ast.Name)
 	return &ValidationRuleInstance{
 		Enter: func(p visitor.VisitFuncParams) (string, any) {
-			switch node := p.Node.(type) {
-			case *ast.OperationDefinition:
-				return visitor.ActionSkip, nil
-			case *ast.FragmentDefinition:
-				fragmentName := ""
-				if node.Name != nil {
-					fragmentName = node.Name.Value
-				}
-				if nameAST, ok := knownFragmentNames[fragmentName]; ok {
-					context.ReportError(newValidationError(
-						fmt.Sprintf(`There can only be one fragment named "%v".`, fragmentName),
-						[]ast.Node{nameAST, node.Name}))
-				} else {
-					knownFragmentNames[fragmentName] = node.Name
+			if node, ok := p.Node.(*ast.Document); ok {
+				var names []*ast.Name
+				for _, def := range node.Definitions {
+					if def, ok := def.(*ast.FragmentDefinition); ok {
+						names = append(names, def.Name)
+					}
 				}
-				return visitor.ActionSkip, nil
+				reportDuplicateNames(context, names, `There can only be one fragment named "%v".`)
 			}
-			return visitor.ActionNoChange, nil
+			return visitor.ActionSkip, nil
 		},
 	}
 }
@@ -1686,37 +1783,14 @@ func UniqueFragmentNamesRule(context *ValidationContext) *ValidationRuleInstance
 // A GraphQL input object value is only valid if all supplied fields are
 // uniquely named.
 func UniqueInputFieldNamesRule(context *ValidationContext) *ValidationRuleInstance {
-	var knownNameStack []map[string]*ast.Name
-	knownNames := make(map[string]*ast.Name)
-
 	return &ValidationRuleInstance{
 		Enter: func(p visitor.VisitFuncParams) (string, any) {
-			switch node := p.Node.(type) {
-			case *ast.ObjectValue:
-				knownNameStack = append(knownNameStack, knownNames)
-				knownNames = make(map[string]*ast.Name)
-				return visitor.ActionNoChange, nil
-			case *ast.ObjectField:
-				var fieldName string
-				if node.Name != nil {
-					fieldName = node.Name.Value
-				}
-				if knownNameAST, ok := knownNames[fieldName]; ok {
-					context.ReportError(newValidationError(
-						fmt.Sprintf(`There can be only one input field named "%v".`, fieldName),
-						[]ast.Node{knownNameAST, node.Name}))
-				} else {
-					knownNames[fieldName] = node.Name
+			if node, ok := p.Node.(*ast.ObjectValue); ok && len(node.Fields) > 1 {
+				names := make([]*ast.Name, len(node.Fields))
+				for i, field := range node.Fields {
+					names[i] = field.Name
 				}
-
-				return visitor.ActionSkip, nil
-			}
-			return visitor.ActionNoChange, nil
-		},
-		Leave: func(p visitor.VisitFuncParams) (string, any) {
-			switch p.Node.(type) {
-			case *ast.ObjectValue:
-				knownNames, knownNameStack = knownNameStack[len(knownNameStack)-1], knownNameStack[:len(knownNameStack)-1]
+				reportDuplicateNames(context, names, `There can be only one input field named "%v".`)
 			}
 			return visitor.ActionNoChange, nil
 		},
@@ -1727,27 +1801,19 @@ func UniqueInputFieldNamesRule(context *ValidationContext) *ValidationRuleInstan
 //
 // A GraphQL document is only valid if all defined operations have unique names.
 func UniqueOperationNamesRule(context *ValidationContext) *ValidationRuleInstance {
-	knownOperationNames := make(map[string]*ast.Name)
 	return &ValidationRuleInstance{
 		Enter: func(p visitor.VisitFuncParams) (string, any) {
-			switch node := p.Node.(type) {
-			case *ast.OperationDefinition:
-				var operationName string
-				if node.Name != nil {
-					operationName = node.Name.Value
-				}
-				if nameAST, ok := knownOperationNames[operationName]; ok {
-					context.ReportError(newValidationError(
-						fmt.Sprintf(`There can only be one operation named "%v".`, operationName),
-						[]ast.Node{nameAST, node.Name}))
-				} else {
-					knownOperationNames[operationName] = node.Name
+			if node, ok := p.Node.(*ast.Document); ok {
+				var names []*ast.Name
+				for _, def := range node.Definitions {
+					// Anonymous operations are handled by LoneAnonymousOperationRule.
+					if def, ok := def.(*ast.OperationDefinition); ok && def.Name != nil {
+						names = append(names, def.Name)
+					}
 				}
-				return visitor.ActionSkip, nil
-			case *ast.FragmentDefinition:
-				return visitor.ActionNoChange, nil
+				reportDuplicateNames(context, names, `There can only be one operation named "%v".`)
 			}
-			return visitor.ActionNoChange, nil
+			return visitor.ActionSkip, nil
 		},
 	}
 }
@@ -1758,23 +1824,14 @@ func UniqueOperationNamesRule(context *ValidationContext) *ValidationRuleInstanc
 func UniqueVariableNamesRule(context *ValidationContext) *ValidationRuleInstance {
 	return &ValidationRuleInstance{
 		Enter: func(p visitor.VisitFuncParams) (string, any) {
-			if node, ok := p.Node.(*ast.OperationDefinition); ok {
-				knownVariableNames := make(map[string]*ast.Name)
+			if node, ok := p.Node.(*ast.OperationDefinition); ok && len(node.VariableDefinitions) > 1 {
+				names := make([]*ast.Name, 0, len(node.VariableDefinitions))
 				for _, def := range node.VariableDefinitions {
-					var variableName string
-					var variableNameAST *ast.Name
-					if def.Variable != nil && def.Variable.Name != nil {
-						variableNameAST = def.Variable.Name
-						variableName = def.Variable.Name.Value
-					}
-					if nameAST, ok := knownVariableNames[variableName]; ok {
-						context.ReportError(newValidationError(
-							fmt.Sprintf(`There can only be one variable named "%v".`, variableName),
-							[]ast.Node{nameAST, variableNameAST}))
-					} else if variableNameAST != nil {
-						knownVariableNames[variableName] = variableNameAST
+					if def.Variable != nil {
+						names = append(names, def.Variable.Name)
 					}
 				}
+				reportDuplicateNames(context, names, `There can only be one variable named "%v".`)
 			}
 			return visitor.ActionNoChange, nil
 		},
@@ -1810,15 +1867,22 @@ func VariablesAreInputTypesRule(context *ValidationContext) *ValidationRuleInsta
 	}
 }
 
-// If a variable definition has a default value, it's effectively non-null.
-func effectiveType(varType Type, varDef *ast.VariableDefinition) Type {
-	if varDef.DefaultValue == nil {
-		return varType
-	}
-	if _, ok := varType.(*NonNull); ok {
-		return varType
+// allowedVariableUsage returns whether a variable may be used in a location. A
+// nullable variable may be used in a non-null location if the variable or the
+// location has a default value (as in the
+// June 2018 specification) in which case an explicit null is rejected when the
+// operation is executed.
+func allowedVariableUsage(schema *Schema, varType Type, varDef *ast.VariableDefinition, locationType Type, locationDefaultValue any) bool {
+	if nonNullLocation, ok := locationType.(*NonNull); ok {
+		if _, ok := varType.(*NonNull); !ok {
+			// The parser doesn't accept null literals so a default value is never null
+			if varDef.DefaultValue == nil && locationDefaultValue == nil {
+				return false
+			}
+			return isTypeSubTypeOf(schema, varType, nonNullLocation.OfType)
+		}
 	}
-	return NewNonNull(varType)
+	return isTypeSubTypeOf(schema, varType, locationType)
 }
 
 // VariablesInAllowedPositionRule Variables passed to field arguments conform to type
@@ -1855,7 +1919,7 @@ func VariablesInAllowedPositionRule(context *ValidationContext) *ValidationRuleI
 						if err != nil {
 							varType = nil
 						}
-						if varType != nil && !isTypeSubTypeOf(context.Schema(), effectiveType(varType, varDef), usage.Type) {
+						if varType != nil && !allowedVariableUsage(context.Schema(), varType, varDef, usage.Type, usage.DefaultValue) {
 							context.ReportError(newValidationError(
 								fmt.Sprintf(`Variable "$%v" of type "%v" used in position `+
 									`expecting type "%v".`, varName, varType, usage.Type),
@@ -1873,8 +1937,9 @@ func VariablesInAllowedPositionRule(context *ValidationContext) *ValidationRuleI
 // an input type.
 //
 // Note that this only validates literal values, variables are assumed to
-// provide values of the correct type.
-func isValidLiteralValue(ttype Input, valueAST ast.Value) (bool, []string) {
+// provide values of the correct type. strictID if true only accepts strings for
+// the ID type (see SchemaConfig.StrictIDInput).
+func isValidLiteralValue(ttype Input, valueAST ast.Value, strictID bool) (bool, []string) {
 	// A value must be provided if the type is non-null.
 	if ttype, ok := ttype.(*NonNull); ok {
 		if valueAST == nil {
@@ -1884,7 +1949,7 @@ func isValidLiteralValue(ttype Input, valueAST ast.Value) (bool, []string) {
 			return false, []string{"Expected non-null value, found null."}
 		}
 		ofType, _ := ttype.OfType.(Input)
-		return isValidLiteralValue(ofType, valueAST)
+		return isValidLiteralValue(ofType, valueAST, strictID)
 	}
 
 	if valueAST == nil {
@@ -1903,14 +1968,14 @@ func isValidLiteralValue(ttype Input, valueAST ast.Value) (bool, []string) {
 		if valueAST, ok := valueAST.(*ast.ListValue); ok {
 			var messagesReduce []string
 			for _, value := range valueAST.Values {
-				_, messages := isValidLiteralValue(itemType, value)
+				_, messages := isValidLiteralValue(itemType, value, strictID)
 				for idx, message := range messages {
 					messagesReduce = append(messagesReduce, fmt.Sprintf(`In element #%v: %v`, idx+1, message))
 				}
 			}
 			return len(messagesReduce) == 0, messagesReduce
 		}
-		return isValidLiteralValue(itemType, valueAST)
+		return isValidLiteralValue(itemType, valueAST, strictID)
 
 	}
 
@@ -1935,18 +2000,17 @@ func isValidLiteralValue(ttype Input, valueAST ast.Value) (bool, []string) {
 			fieldASTMap[fieldASTName] = fieldAST
 
 			// check if field is defined
-			field, ok := fields[fieldASTName]
-			if !ok || field == nil {
+			if findInputField(ttype, fieldASTName) == nil {
 				messagesReduce = append(messagesReduce, fmt.Sprintf(`In field "%v": Unknown field.`, fieldASTName))
 			}
 		}
 		for fieldName, field := range fields {
-			fieldAST := fieldASTMap[fieldName]
+			fieldAST, _ := lookupAlias(fieldASTMap, fieldName, field.Aliases)
 			var fieldASTValue ast.Value
 			if fieldAST != nil {
 				fieldASTValue = fieldAST.Value
 			}
-			if isValid, messages := isValidLiteralValue(field.Type, fieldASTValue); !isValid {
+			if isValid, messages := isValidLiteralValue(field.Type, fieldASTValue, strictID); !isValid {
 				for _, message := range messages {
 					messagesReduce = append(messagesReduce, fmt.Sprintf("In field \"%v\": %v", fieldName, message))
 				}
@@ -1956,7 +2020,14 @@ func isValidLiteralValue(ttype Input, valueAST ast.Value) (bool, []string) {
 	}
 
 	if ttype, ok := ttype.(*Scalar); ok {
-		if isNullish(ttype.ParseLiteral(valueAST)) {
+		if _, ok := valueAST.(*ast.StringValue); !ok && strictID && ttype == ID {
+			return false, []string{fmt.Sprintf(`Expected type "%v", found %v.`, ttype.Name(), printer.Print(valueAST))}
+		}
+		parsed := ttype.ParseLiteral(valueAST)
+		if reason := scalarParseErrorReason(parsed); reason != "" {
+			return false, []string{fmt.Sprintf(`Expected type "%v", found %v: %s.`, ttype.Name(), printer.Print(valueAST), reason)}
+		}
+		if isNullish(parsed) {
 			return false, []string{fmt.Sprintf(`Expected type "%v", found %v.`, ttype.Name(), printer.Print(valueAST))}
 		}
 	}
@@ -1982,6 +2053,10 @@ func (s suggestionListResult) Swap(i, j int) {
 	s.Options[i], s.Options[j] = s.Options[j], s.Options[i]
 }
 func (s suggestionListResult) Less(i, j int) bool {
+	if s.Distances[i] == s.Distances[j] {
+		// Break ties by name since options often come from map iteration.
+		return s.Options[i] < s.Options[j]
+	}
 	return s.Distances[i] < s.Distances[j]
 }
 
@@ -1,10 +1,13 @@
 package graphql_test
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/sprucehealth/graphql"
 	"github.com/sprucehealth/graphql/gqlerrors"
+	"github.com/sprucehealth/graphql/language/ast"
+	"github.com/sprucehealth/graphql/language/parser"
 	"github.com/sprucehealth/graphql/testutil"
 )
 
@@ -33,24 +36,12 @@ func TestValidate_VariableDefaultValuesOfCorrectType_VariablesWithValidDefaultVa
       }
     `)
 }
-func TestValidate_VariableDefaultValuesOfCorrectType_NoRequiredVariablesWithDefaultValues(t *testing.T) {
-	testutil.ExpectFailsRule(t, graphql.DefaultValuesOfCorrectTypeRule, `
-      query UnreachableDefaultValues($a: Int! = 3, $b: String! = "default") {
+func TestValidate_VariableDefaultValuesOfCorrectType_RequiredVariablesWithDefaultValues(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.DefaultValuesOfCorrectTypeRule, `
+      query DefaultValues($a: Int! = 3, $b: String! = "default") {
         dog { name }
       }
-    `,
-		[]gqlerrors.FormattedError{
-			testutil.RuleError(
-				`Variable "$a" of type "Int!" is required and will not `+
-					`use the default value. Perhaps you meant to use type "Int".`,
-				2, 49,
-			),
-			testutil.RuleError(
-				`Variable "$b" of type "String!" is required and will not `+
-					`use the default value. Perhaps you meant to use type "String".`,
-				2, 66,
-			),
-		})
+    `)
 }
 func TestValidate_VariableDefaultValuesOfCorrectType_VariablesWithInvalidDefaultValues(t *testing.T) {
 	testutil.ExpectFailsRule(t, graphql.DefaultValuesOfCorrectTypeRule, `
@@ -101,3 +92,34 @@ func TestValidate_VariableDefaultValuesOfCorrectType_ListVariablesWithInvalidIte
 				2, 40),
 		})
 }
+
+func TestValidate_VariableDefaultValuesOfCorrectType_VariablesInDefaultValues(t *testing.T) {
+	// The parser rejects variables in default values so replace a constant in
+	// the parsed document to check documents that weren't parsed.
+	doc, err := parser.Parse(parser.ParseParams{Source: `
+      query WithVariableDefault($a: Int, $b: ComplexInput = { requiredField: true, intField: 1 }) {
+        dog { name }
+      }
+    `})
+	if err != nil {
+		t.Fatal(err)
+	}
+	op := doc.Definitions[0].(*ast.OperationDefinition)
+	field := op.VariableDefinitions[1].DefaultValue.(*ast.ObjectValue).Fields[1]
+	field.Value = &ast.Variable{
+		Name: &ast.Name{Value: "a"},
+		Loc:  field.Value.GetLoc(),
+	}
+
+	result := graphql.ValidateDocument(testutil.TestSchema, doc, []graphql.ValidationRuleFn{graphql.DefaultValuesOfCorrectTypeRule})
+	expected := []gqlerrors.FormattedError{
+		testutil.RuleError(`Variable "$b" has a default value that references variable "$a" which is not allowed.`, 2, 94),
+	}
+	expected[0].Type = gqlerrors.ErrorTypeBadQuery
+	expected[0].Extensions = map[string]any{
+		graphql.ValidationRuleExtensionKey: graphql.ValidationRuleName(graphql.DefaultValuesOfCorrectTypeRule),
+	}
+	if result.IsValid || !reflect.DeepEqual(expected, result.Errors) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.Errors))
+	}
+}
@@ -87,8 +87,7 @@ func TestValidate_UniqueArgumentNames_ManyDuplicateFieldArguments(t *testing.T)
         field(arg1: "value", arg1: "value", arg1: "value")
       }
     `, []gqlerrors.FormattedError{
-		testutil.RuleError(`There can be only one argument named "arg1".`, 3, 15, 3, 30),
-		testutil.RuleError(`There can be only one argument named "arg1".`, 3, 15, 3, 45),
+		testutil.RuleError(`There can be only one argument named "arg1".`, 3, 15, 3, 30, 3, 45),
 	})
 }
 func TestValidate_UniqueArgumentNames_DuplicateDirectiveArguments(t *testing.T) {
@@ -106,7 +105,6 @@ func TestValidate_UniqueArgumentNames_ManyDuplicateDirectiveArguments(t *testing
         field @directive(arg1: "value", arg1: "value", arg1: "value")
       }
     `, []gqlerrors.FormattedError{
-		testutil.RuleError(`There can be only one argument named "arg1".`, 3, 26, 3, 41),
-		testutil.RuleError(`There can be only one argument named "arg1".`, 3, 26, 3, 56),
+		testutil.RuleError(`There can be only one argument named "arg1".`, 3, 26, 3, 41, 3, 56),
 	})
 }
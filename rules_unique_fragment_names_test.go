@@ -93,3 +93,18 @@ func TestValidate_UniqueFragmentNames_FragmentsNamedTheSameWithoutBeingReference
 		testutil.RuleError(`There can only be one fragment named "fragA".`, 2, 16, 5, 16),
 	})
 }
+func TestValidate_UniqueFragmentNames_ManyFragmentsNamedTheSame(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.UniqueFragmentNamesRule, `
+      fragment fragA on Type {
+        fieldA
+      }
+      fragment fragA on Type {
+        fieldB
+      }
+      fragment fragA on Type {
+        fieldC
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`There can only be one fragment named "fragA".`, 2, 16, 5, 16, 8, 16),
+	})
+}
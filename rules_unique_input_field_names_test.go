@@ -59,7 +59,6 @@ func TestValidate_UniqueInputFieldNames_ManyDuplicateInputObjectFields(t *testin
         field(arg: { f1: "value", f1: "value", f1: "value" })
       }
     `, []gqlerrors.FormattedError{
-		testutil.RuleError(`There can be only one input field named "f1".`, 3, 22, 3, 35),
-		testutil.RuleError(`There can be only one input field named "f1".`, 3, 22, 3, 48),
+		testutil.RuleError(`There can be only one input field named "f1".`, 3, 22, 3, 35, 3, 48),
 	})
 }
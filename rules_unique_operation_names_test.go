@@ -102,3 +102,15 @@ func TestValidate_UniqueOperationNames_MultipleOperationsOfSameNameOfDifferentTy
 		testutil.RuleError(`There can only be one operation named "Foo".`, 2, 13, 5, 20),
 	})
 }
+func TestValidate_UniqueOperationNames_ManyOperationsOfSameName(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.UniqueOperationNamesRule, `
+      query Foo { fieldA }
+      query Foo { fieldB }
+      mutation Foo { fieldC }
+      query Bar { fieldD }
+      query Bar { fieldE }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`There can only be one operation named "Foo".`, 2, 13, 3, 13, 4, 16),
+		testutil.RuleError(`There can only be one operation named "Bar".`, 5, 13, 6, 13),
+	})
+}
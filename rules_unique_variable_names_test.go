@@ -20,8 +20,7 @@ func TestValidate_UniqueVariableNames_DuplicateVariableNames(t *testing.T) {
       query B($x: String, $x: Int) { __typename }
       query C($x: Int, $x: Int) { __typename }
     `, []gqlerrors.FormattedError{
-		testutil.RuleError(`There can only be one variable named "x".`, 2, 16, 2, 25),
-		testutil.RuleError(`There can only be one variable named "x".`, 2, 16, 2, 34),
+		testutil.RuleError(`There can only be one variable named "x".`, 2, 16, 2, 25, 2, 34),
 		testutil.RuleError(`There can only be one variable named "x".`, 3, 16, 3, 28),
 		testutil.RuleError(`There can only be one variable named "x".`, 4, 16, 4, 25),
 	})
@@ -0,0 +1,74 @@
+package graphql
+
+// SanitizeFn returns the sanitized form of a coerced input value (e.g. a string
+// with surrounding whitespace trimmed). It's only called with non-null values
+// and should be idempotent as a value may be sanitized more than once.
+type SanitizeFn func(value any) any
+
+// Sanitizers configure the sanitization of input values. Arguments are sanitized
+// after they're coerced and before they're passed to resolvers so that resolvers
+// don't each have to sanitize their input. Values from variables are sanitized
+// as part of the arguments that use them.
+type Sanitizers struct {
+	// Scalars maps scalar type names (e.g. "String") to the sanitizer for all input
+	// values of the scalar.
+	Scalars map[string]SanitizeFn
+	// InputFields maps input object fields by "TypeName.fieldName" to the sanitizer
+	// for the field's value. It's applied after the sanitizers of the field's type.
+	InputFields map[string]SanitizeFn
+}
+
+// sanitizeArgs returns the arguments with sanitizers applied. The args map is
+// modified in place.
+func sanitizeArgs(s *Sanitizers, argDefs []*Argument, args map[string]any) map[string]any {
+	if s == nil || len(args) == 0 {
+		return args
+	}
+	for _, argDef := range argDefs {
+		if value, ok := args[argDef.PrivateName]; ok {
+			args[argDef.PrivateName] = s.sanitize(argDef.Type, value)
+		}
+	}
+	return args
+}
+
+func (s *Sanitizers) sanitize(ttype Input, value any) any {
+	if isNullish(value) {
+		return value
+	}
+	switch ttype := ttype.(type) {
+	case *NonNull:
+		return s.sanitize(ttype.OfType, value)
+	case *List:
+		values, ok := value.([]any)
+		if !ok {
+			return value
+		}
+		sanitized := make([]any, len(values))
+		for i, v := range values {
+			sanitized[i] = s.sanitize(ttype.OfType, v)
+		}
+		return sanitized
+	case *InputObject:
+		obj, ok := value.(map[string]any)
+		if !ok {
+			return value
+		}
+		sanitized := make(map[string]any, len(obj))
+		for name, v := range obj {
+			if field := ttype.Fields()[name]; field != nil {
+				v = s.sanitize(field.Type, v)
+				if fn := s.InputFields[ttype.Name()+"."+name]; fn != nil && !isNullish(v) {
+					v = fn(v)
+				}
+			}
+			sanitized[name] = v
+		}
+		return sanitized
+	case *Scalar:
+		if fn := s.Scalars[ttype.Name()]; fn != nil {
+			return fn(value)
+		}
+	}
+	return value
+}
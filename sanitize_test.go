@@ -0,0 +1,88 @@
+package graphql_test
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/gqldecode"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+func TestSanitizers(t *testing.T) {
+	contactType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "ContactInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"name":  &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"phone": &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"tags":  &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.String)},
+		},
+	})
+	var args map[string]any
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"test": &graphql.Field{
+					Type: graphql.String,
+					Args: graphql.FieldConfigArgument{
+						"contact": &graphql.ArgumentConfig{Type: contactType},
+						"note":    &graphql.ArgumentConfig{Type: graphql.String},
+						"count":   &graphql.ArgumentConfig{Type: graphql.Int},
+					},
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						args = p.Args
+						return "ok", nil
+					},
+				},
+			},
+		}),
+		Sanitizers: &graphql.Sanitizers{
+			Scalars: map[string]graphql.SanitizeFn{
+				"String": func(v any) any {
+					return strings.TrimSpace(gqldecode.SanitizeUnicode(v).(string))
+				},
+			},
+			InputFields: map[string]graphql.SanitizeFn{
+				"ContactInput.phone": func(v any) any {
+					return strings.NewReplacer("-", "", " ", "", "(", "", ")", "").Replace(v.(string))
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Literals and variables are sanitized the same way
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema: schema,
+		RequestString: `query ($contact: ContactInput) {
+			test(contact: $contact, note: " \uFEFFhello ", count: 1)
+		}`,
+		VariableValues: map[string]any{
+			"contact": map[string]any{
+				"name":  " Alice ",
+				"phone": " (555) 123-4567 ",
+				"tags":  []any{" a ", "b "},
+			},
+		},
+	})
+	if len(result.Errors) != 0 {
+		t.Fatalf("Unexpected errors %+v", result.Errors)
+	}
+	expected := map[string]any{
+		"contact": map[string]any{
+			"name":  "Alice",
+			"phone": "5551234567",
+			"tags":  []any{"a", "b"},
+		},
+		"note":  "hello",
+		"count": 1,
+	}
+	if !reflect.DeepEqual(expected, args) {
+		t.Fatalf("Unexpected args, Diff: %v", testutil.Diff(expected, args))
+	}
+}
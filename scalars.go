@@ -1,9 +1,12 @@
 package graphql
 
 import (
+	"encoding/json"
 	"fmt"
 	"math"
+	"math/big"
 	"strconv"
+	"strings"
 
 	"github.com/sprucehealth/graphql/language/ast"
 )
@@ -60,6 +63,20 @@ func coerceInt(value any) any {
 			return nil
 		}
 		return int(val)
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return coerceInt(i)
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return nil
+		}
+		return coerceInt(f)
+	case *big.Int:
+		if !v.IsInt64() {
+			return nil
+		}
+		return coerceInt(v.Int64())
 	}
 
 	// If the value cannot be transformed into an int, return nil instead of '0'
@@ -112,6 +129,15 @@ func coerceFloat64(value any) any {
 			return nil
 		}
 		return val
+	case json.Number:
+		val, err := v.Float64()
+		if err != nil {
+			return nil
+		}
+		return val
+	case *big.Int:
+		val, _ := new(big.Float).SetInt(v).Float64()
+		return val
 	}
 	return float64(0)
 }
@@ -157,6 +183,10 @@ func coerceString(value any) any {
 		return strconv.FormatFloat(float64(v), 'f', -1, 32)
 	case float64:
 		return strconv.FormatFloat(v, 'f', -1, 64)
+	case json.Number:
+		return string(v)
+	case *big.Int:
+		return v.String()
 	}
 	return fmt.Sprintf("%v", value)
 }
@@ -207,6 +237,11 @@ func coerceBool(value any) any {
 		return v != 0
 	case uint64:
 		return v != 0
+	case json.Number:
+		f, err := v.Float64()
+		return err == nil && f != 0
+	case *big.Int:
+		return v.Sign() != 0
 	}
 	return false
 }
@@ -226,6 +261,55 @@ var Boolean = NewScalar(ScalarConfig{
 	},
 })
 
+// coerceIDInput coerces a variable value of the ID type. Integers, including
+// integral floats as decoded from JSON and json.Number values in the integer
+// form, are accepted as their decimal string. Other values (e.g. 4.5 or true)
+// are invalid. Schemas with SchemaConfig.StrictIDInput reject integers when the
+// input is validated.
+func coerceIDInput(value any) any {
+	switch v := value.(type) {
+	case string:
+		return v
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprint(v)
+	case *big.Int:
+		if v != nil {
+			return v.String()
+		}
+	case float32:
+		return coerceIntegralFloat(float64(v))
+	case float64:
+		return coerceIntegralFloat(v)
+	case json.Number:
+		if isIntegerDigits(string(v)) {
+			return string(v)
+		}
+	}
+	return nil
+}
+
+// isIntegerDigits returns true if s is an optional minus sign followed by
+// decimal digits.
+func isIntegerDigits(s string) bool {
+	s = strings.TrimPrefix(s, "-")
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func coerceIntegralFloat(v float64) any {
+	if math.IsInf(v, 0) || v != math.Trunc(v) {
+		return nil
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
 // ID is the GraphQL id type definition
 var ID = NewScalar(ScalarConfig{
 	Name: "ID",
@@ -235,7 +319,7 @@ var ID = NewScalar(ScalarConfig{
 		"When expected as an input type, any string (such as `\"4\"`) or integer " +
 		"(such as `4`) input value will be accepted as an ID.",
 	Serialize:  coerceString,
-	ParseValue: coerceString,
+	ParseValue: coerceIDInput,
 	ParseLiteral: func(valueAST ast.Value) any {
 		switch valueAST := valueAST.(type) {
 		case *ast.IntValue:
@@ -0,0 +1,123 @@
+package graphql_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+)
+
+func idInputSchema(t *testing.T, strict bool) graphql.Schema {
+	t.Helper()
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		StrictIDInput: strict,
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"id": &graphql.Field{
+					Type: graphql.String,
+					Args: graphql.FieldConfigArgument{
+						"id": &graphql.ArgumentConfig{Type: graphql.ID},
+					},
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						return p.Args["id"], nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+func TestTypeSystem_Scalar_CoercesIDInput(t *testing.T) {
+	schema := idInputSchema(t, false)
+	// Literals and variables accept the same values
+	tests := []struct {
+		Literal  string
+		Variable any
+		Expected any
+	}{
+		{`"abc"`, "abc", "abc"},
+		{`"4"`, "4", "4"},
+		{`4`, 4, "4"},
+		{`-4`, float64(-4), "-4"},
+		{`9007199254740993`, json.Number("9007199254740993"), "9007199254740993"},
+		{`123456789012345678901234567890`, json.Number("123456789012345678901234567890"), "123456789012345678901234567890"},
+		{`-123456789012345678901234567890`, json.Number("-123456789012345678901234567890"), "-123456789012345678901234567890"},
+		{`4.0`, json.Number("4.0"), nil},
+		{`4e2`, json.Number("4e2"), nil},
+		{`4.5`, 4.5, nil},
+		{`true`, true, nil},
+	}
+	for _, test := range tests {
+		literal := graphql.Do(context.Background(), graphql.Params{
+			Schema:        schema,
+			RequestString: `{ id(id: ` + test.Literal + `) }`,
+		})
+		variable := graphql.Do(context.Background(), graphql.Params{
+			Schema:         schema,
+			RequestString:  `query Q($id: ID) { id(id: $id) }`,
+			VariableValues: map[string]any{"id": test.Variable},
+		})
+		for name, result := range map[string]*graphql.Result{"literal": literal, "variable": variable} {
+			if test.Expected == nil {
+				if len(result.Errors) != 1 {
+					t.Errorf("%s %s: expected an error, got %+v", name, test.Literal, result)
+				}
+				continue
+			}
+			if len(result.Errors) != 0 || result.Data.(map[string]any)["id"] != test.Expected {
+				t.Errorf("%s %s: expected %v, got %+v", name, test.Literal, test.Expected, result)
+			}
+		}
+	}
+}
+
+func TestTypeSystem_Scalar_CoercesIDInputIntegerKinds(t *testing.T) {
+	schema := idInputSchema(t, false)
+	for value, expected := range map[any]string{
+		int(-1): "-1", int8(-2): "-2", int16(-3): "-3", int32(-4): "-4", int64(-5): "-5",
+		uint(1): "1", uint8(2): "2", uint16(3): "3", uint32(4): "4", uint64(5): "5",
+	} {
+		result := graphql.Do(context.Background(), graphql.Params{
+			Schema:         schema,
+			RequestString:  `query Q($id: ID) { id(id: $id) }`,
+			VariableValues: map[string]any{"id": value},
+		})
+		if len(result.Errors) != 0 || result.Data.(map[string]any)["id"] != expected {
+			t.Errorf("%T: expected %q, got %+v", value, expected, result)
+		}
+	}
+}
+
+func TestTypeSystem_Scalar_StrictIDInput(t *testing.T) {
+	schema := idInputSchema(t, true)
+
+	for _, request := range []graphql.Params{
+		{RequestString: `{ id(id: 4) }`},
+		{RequestString: `query Q($id: ID) { id(id: $id) }`, VariableValues: map[string]any{"id": 4}},
+	} {
+		request.Schema = schema
+		if result := graphql.Do(context.Background(), request); len(result.Errors) != 1 {
+			t.Errorf("%s: expected an error for an integer ID, got %+v", request.RequestString, result)
+		}
+	}
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema:         schema,
+		RequestString:  `query Q($id: ID) { a: id(id: "4") b: id(id: $id) }`,
+		VariableValues: map[string]any{"id": "5"},
+	})
+	if len(result.Errors) != 0 || result.Data.(map[string]any)["a"] != "4" || result.Data.(map[string]any)["b"] != "5" {
+		t.Fatalf("Expected string IDs to be accepted, got %+v", result)
+	}
+
+	// Other schemas still accept integers
+	result = graphql.Do(context.Background(), graphql.Params{Schema: idInputSchema(t, false), RequestString: `{ id(id: 4) }`})
+	if len(result.Errors) != 0 || result.Data.(map[string]any)["id"] != "4" {
+		t.Fatalf("Expected an integer ID to be accepted, got %+v", result)
+	}
+}
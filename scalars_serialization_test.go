@@ -1,9 +1,13 @@
 package graphql_test
 
 import (
+	"context"
+	"encoding/json"
 	"math"
+	"math/big"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/sprucehealth/graphql"
 )
@@ -62,6 +66,12 @@ func TestTypeSystem_Scalar_SerializesOutputInt(t *testing.T) {
 		{uint64(math.MaxInt64) + uint64(1), nil},
 		{byte(127), 127},
 		{'世', int('世')},
+		{json.Number("12"), 12},
+		{json.Number("-1.1"), -1},
+		{json.Number("9876504321"), nil},
+		{json.Number("one"), nil},
+		{big.NewInt(5), 5},
+		{new(big.Int).Lsh(big.NewInt(1), 70), nil},
 		// testing types that don't match a value in the array.
 		{[]int{}, nil},
 	}
@@ -87,6 +97,9 @@ func TestTypeSystem_Scalar_SerializesOutputFloat(t *testing.T) {
 		{"one", nil},
 		{false, float64(0.0)},
 		{true, float64(1.0)},
+		{json.Number("-1.1"), float64(-1.1)},
+		{json.Number("one"), nil},
+		{big.NewInt(3), float64(3.0)},
 	}
 
 	for i, test := range tests {
@@ -105,6 +118,8 @@ func TestTypeSystem_Scalar_SerializesOutputStrings(t *testing.T) {
 		{float64(-1.1), "-1.1"},
 		{true, "true"},
 		{false, "false"},
+		{json.Number("12345678901234567890"), "12345678901234567890"},
+		{new(big.Int).Lsh(big.NewInt(1), 70), "1180591620717411303424"},
 	}
 
 	for _, test := range tests {
@@ -126,6 +141,9 @@ func TestTypeSystem_Scalar_SerializesOutputBoolean(t *testing.T) {
 		{int(0), false},
 		{true, true},
 		{false, false},
+		{json.Number("0"), false},
+		{json.Number("2"), true},
+		{big.NewInt(0), false},
 	}
 
 	for _, test := range tests {
@@ -136,3 +154,56 @@ func TestTypeSystem_Scalar_SerializesOutputBoolean(t *testing.T) {
 		}
 	}
 }
+
+type timeZoneKey struct{}
+
+func TestTypeSystem_Scalar_SerializeCtx(t *testing.T) {
+	dateTime := graphql.NewScalar(graphql.ScalarConfig{
+		Name: "DateTime",
+		SerializeCtx: func(ctx context.Context, value any) any {
+			tm, ok := value.(time.Time)
+			if !ok {
+				return nil
+			}
+			if loc, ok := ctx.Value(timeZoneKey{}).(*time.Location); ok {
+				tm = tm.In(loc)
+			}
+			return tm.Format(time.RFC3339)
+		},
+	})
+	if err := dateTime.Error(); err != nil {
+		t.Fatal(err)
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"now": &graphql.Field{
+					Type: dateTime,
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						return time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC), nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loc := time.FixedZone("UTC-8", -8*60*60)
+	ctx := context.WithValue(context.Background(), timeZoneKey{}, loc)
+	result := graphql.Do(ctx, graphql.Params{
+		Schema:        schema,
+		RequestString: `{ now }`,
+	})
+	expected := map[string]any{"now": "2020-01-01T19:04:05-08:00"}
+	if len(result.Errors) != 0 || !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("Expected %v, got %v %v", expected, result.Data, result.Errors)
+	}
+
+	// Serialize falls back to SerializeCtx with a background context.
+	if val := dateTime.Serialize(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)); val != "2020-01-02T03:04:05Z" {
+		t.Fatalf("Expected 2020-01-02T03:04:05Z, got %v", val)
+	}
+}
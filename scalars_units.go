@@ -0,0 +1,174 @@
+package graphql
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sprucehealth/graphql/language/ast"
+)
+
+// ScalarParseError may be returned by the ParseValue and ParseLiteral functions of
+// a scalar instead of nil to explain why a value is invalid. The reason is
+// included in the validation error.
+type ScalarParseError struct {
+	Reason string
+}
+
+func (e *ScalarParseError) Error() string {
+	return e.Reason
+}
+
+// scalarParseErrorReason returns the reason of a parse error or an empty string if
+// the parsed value isn't an error.
+func scalarParseErrorReason(parsed any) string {
+	if err, ok := parsed.(*ScalarParseError); ok && err != nil {
+		return err.Reason
+	}
+	return ""
+}
+
+// Duration is an optional scalar for time.Duration values represented as strings
+// such as "30s", "5m", or "1h30m" as parsed by time.ParseDuration. It must be
+// added to the schema types or used by a field to be part of a schema.
+var Duration = NewScalar(ScalarConfig{
+	Name: "Duration",
+	Description: "The `Duration` scalar type represents a length of time as a sequence of " +
+		"decimal numbers with a unit suffix such as `\"30s\"`, `\"5m\"`, or `\"1h30m\"`. " +
+		"Valid units are \"ns\", \"us\", \"ms\", \"s\", \"m\", and \"h\".",
+	Serialize: func(value any) any {
+		switch v := value.(type) {
+		case time.Duration:
+			return v.String()
+		case *time.Duration:
+			if v != nil {
+				return v.String()
+			}
+		}
+		return nil
+	},
+	ParseValue: func(value any) any {
+		switch v := value.(type) {
+		case string:
+			return parseDuration(v)
+		case time.Duration:
+			return v
+		}
+		return nil
+	},
+	ParseLiteral: func(valueAST ast.Value) any {
+		if v, ok := valueAST.(*ast.StringValue); ok {
+			return parseDuration(v.Value)
+		}
+		return nil
+	},
+})
+
+func parseDuration(s string) any {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return &ScalarParseError{Reason: `expected a number with a unit such as "30s" or "5m"`}
+	}
+	return d
+}
+
+// ByteSize is a number of bytes. It's the Go type of the ByteSize scalar.
+type ByteSize int64
+
+var byteSizeUnits = []struct {
+	suffix string
+	size   ByteSize
+}{
+	{"TiB", 1 << 40},
+	{"TB", 1e12},
+	{"GiB", 1 << 30},
+	{"GB", 1e9},
+	{"MiB", 1 << 20},
+	{"MB", 1e6},
+	{"KiB", 1 << 10},
+	{"KB", 1e3},
+	{"B", 1},
+}
+
+// String returns the size with the largest unit that represents it exactly
+// (e.g. "10MB" or "512KiB").
+func (b ByteSize) String() string {
+	if b != 0 {
+		for _, unit := range byteSizeUnits {
+			if b%unit.size == 0 {
+				return strconv.FormatInt(int64(b/unit.size), 10) + unit.suffix
+			}
+		}
+	}
+	return strconv.FormatInt(int64(b), 10) + "B"
+}
+
+// ParseByteSize parses a non-negative integer followed by one of the units B,
+// KB, MB, GB, TB (powers of 1000) or KiB, MiB, GiB, TiB (powers of 1024) such as
+// "10MB". Units are case sensitive and spaces aren't allowed.
+func ParseByteSize(s string) (ByteSize, error) {
+	i := strings.IndexFunc(s, func(r rune) bool { return r < '0' || r > '9' })
+	if i <= 0 {
+		return 0, fmt.Errorf("expected an integer followed by a unit such as \"10MB\"")
+	}
+	n, err := strconv.ParseInt(s[:i], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("size %s is too large", s)
+	}
+	for _, unit := range byteSizeUnits {
+		if s[i:] == unit.suffix {
+			if n > math.MaxInt64/int64(unit.size) {
+				return 0, fmt.Errorf("size %s is too large", s)
+			}
+			return ByteSize(n) * unit.size, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown unit %q, expected one of B, KB, MB, GB, TB, KiB, MiB, GiB, or TiB", s[i:])
+}
+
+func parseByteSize(s string) any {
+	b, err := ParseByteSize(s)
+	if err != nil {
+		return &ScalarParseError{Reason: err.Error()}
+	}
+	return b
+}
+
+// ByteSizeScalar is an optional scalar for ByteSize values represented as strings
+// such as "10MB" as parsed by ParseByteSize. It must be added to the schema types
+// or used by a field to be part of a schema.
+var ByteSizeScalar = NewScalar(ScalarConfig{
+	Name: "ByteSize",
+	Description: "The `ByteSize` scalar type represents a number of bytes as an integer " +
+		"followed by a unit such as `\"10MB\"` or `\"512KiB\"`. Valid units are \"B\", " +
+		"\"KB\", \"MB\", \"GB\", \"TB\" (powers of 1000) and \"KiB\", \"MiB\", \"GiB\", \"TiB\" " +
+		"(powers of 1024).",
+	Serialize: func(value any) any {
+		switch v := value.(type) {
+		case ByteSize:
+			return v.String()
+		case *ByteSize:
+			if v != nil {
+				return v.String()
+			}
+		}
+		return nil
+	},
+	ParseValue: func(value any) any {
+		switch v := value.(type) {
+		case string:
+			return parseByteSize(v)
+		case ByteSize:
+			return v
+		}
+		return nil
+	},
+	ParseLiteral: func(valueAST ast.Value) any {
+		if v, ok := valueAST.(*ast.StringValue); ok {
+			return parseByteSize(v.Value)
+		}
+		return nil
+	},
+})
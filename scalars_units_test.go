@@ -0,0 +1,110 @@
+package graphql_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/gqlerrors"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+func TestDurationAndByteSizeScalars(t *testing.T) {
+	testutil.RoundTripScalar(t, graphql.Duration, []any{"30s", "5m", "1h30m", "0s", "1.5s"})
+	testutil.RoundTripScalar(t, graphql.ByteSizeScalar, []any{"0B", "10MB", "512KiB", "1500B", "3TiB"})
+
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"config": &graphql.Field{
+					Type: graphql.String,
+					Args: graphql.FieldConfigArgument{
+						"timeout": &graphql.ArgumentConfig{Type: graphql.Duration},
+						"maxSize": &graphql.ArgumentConfig{Type: graphql.ByteSizeScalar},
+					},
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						timeout := p.Args["timeout"].(time.Duration)
+						maxSize := p.Args["maxSize"].(graphql.ByteSize)
+						return timeout.String() + " " + maxSize.String(), nil
+					},
+				},
+				"timeout": &graphql.Field{
+					Type: graphql.Duration,
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						return 90 * time.Second, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema:         schema,
+		RequestString:  `query ($maxSize: ByteSize) { config(timeout: "5m", maxSize: $maxSize) timeout }`,
+		VariableValues: map[string]any{"maxSize": "2000KB"},
+	})
+	expected := &graphql.Result{
+		Data: map[string]any{"config": "5m0s 2MB", "timeout": "1m30s"},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	result = graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: `{ config(timeout: "5 minutes", maxSize: "1MB") }`,
+	})
+	expectedErrors := []gqlerrors.FormattedError{{
+		Message: `Argument "timeout" has invalid value "5 minutes".` + "\n" +
+			`Expected type "Duration", found "5 minutes": expected a number with a unit such as "30s" or "5m".`,
+	}}
+	if len(result.Errors) != 1 || result.Errors[0].Message != expectedErrors[0].Message {
+		t.Fatalf("Unexpected errors, Diff: %v", testutil.Diff(expectedErrors, result.Errors))
+	}
+
+	result = graphql.Do(context.Background(), graphql.Params{
+		Schema:         schema,
+		RequestString:  `query ($maxSize: ByteSize) { config(timeout: "5m", maxSize: $maxSize) }`,
+		VariableValues: map[string]any{"maxSize": "10mb"},
+	})
+	expectedErrors = []gqlerrors.FormattedError{{
+		Message: `Variable "$maxSize" got invalid value "10mb".` + "\n" +
+			`Expected type "ByteSize", found "10mb": unknown unit "mb", expected one of B, KB, MB, GB, TB, KiB, MiB, GiB, or TiB.`,
+	}}
+	if len(result.Errors) != 1 || result.Errors[0].Message != expectedErrors[0].Message {
+		t.Fatalf("Unexpected errors, Diff: %v", testutil.Diff(expectedErrors, result.Errors))
+	}
+}
+
+func TestParseByteSize(t *testing.T) {
+	cases := []struct {
+		s    string
+		size graphql.ByteSize
+		err  string
+	}{
+		{s: "10MB", size: 10_000_000},
+		{s: "1KiB", size: 1024},
+		{s: "0B", size: 0},
+		{s: "MB", err: `expected an integer followed by a unit such as "10MB"`},
+		{s: "-1MB", err: `expected an integer followed by a unit such as "10MB"`},
+		{s: "1.5GB", err: `unknown unit ".5GB", expected one of B, KB, MB, GB, TB, KiB, MiB, GiB, or TiB`},
+		{s: "10 MB", err: `unknown unit " MB", expected one of B, KB, MB, GB, TB, KiB, MiB, GiB, or TiB`},
+		{s: "10000000TiB", err: `size 10000000TiB is too large`},
+	}
+	for _, c := range cases {
+		size, err := graphql.ParseByteSize(c.s)
+		if c.err != "" {
+			if err == nil || err.Error() != c.err {
+				t.Errorf("ParseByteSize(%q) = %v, expected error %q", c.s, err, c.err)
+			}
+		} else if err != nil || size != c.size {
+			t.Errorf("ParseByteSize(%q) = %d, %v, expected %d", c.s, size, err, c.size)
+		}
+	}
+}
@@ -1,18 +1,93 @@
 package graphql
 
 import (
+	"context"
 	"fmt"
+	"maps"
+	"reflect"
 	"sync"
 
 	"github.com/sprucehealth/graphql/gqlerrors"
 )
 
 type SchemaConfig struct {
+	Description  string
 	Query        *Object
 	Mutation     *Object
 	Subscription *Object
 	Types        []Type
 	Directives   []*Directive
+	// SchemaVersionField if true adds a `_schemaVersion` field to the query type
+	// that returns the schema hash.
+	SchemaVersionField bool
+	// Metadata is arbitrary machine-readable data attached to the schema.
+	Metadata map[string]any
+	// Providers are dependencies available to resolvers by type. Use RegisterProvider
+	// to add to it.
+	Providers map[reflect.Type]any
+	// ProviderRegistry if set holds providers that can be replaced while the schema
+	// is in use. They take precedence over Providers.
+	ProviderRegistry *ProviderRegistry
+	// Logger if set receives warnings about anomalies in the schema and during
+	// validation and execution. ExecuteParams.Logger takes precedence.
+	Logger Logger
+	// Node if set adds a `node(id: ID!): Node` field to the query type that fetches
+	// objects by global ID using the registered fetchers. The field is added to a
+	// copy of Query (returned by Schema.QueryType) so Query itself is unchanged.
+	Node *NodeConfig
+	// Sanitizers if set sanitize argument values after they're coerced.
+	Sanitizers *Sanitizers
+	// IsTypeOfByGoType if true declares that the IsTypeOf functions of objects only
+	// depend on the Go type of the value and not its contents. Interfaces and unions
+	// without a ResolveType function then cache the resolved object type per Go type
+	// for the life of the schema instead of calling IsTypeOf. Map values are never
+	// cached.
+	IsTypeOfByGoType bool
+	// IntrospectionPagination if true adds the non-standard `first` and `after`
+	// arguments to `__Schema.types` and `__Type.fields` to page through the types
+	// and fields of very large schemas. The cursor is the name of the last type or
+	// field of the previous page.
+	IntrospectionPagination bool
+	// ValidateConnections if true fails to create the schema if an object type named
+	// like a Relay connection ("*Connection"), edge ("*Edge"), or "PageInfo" doesn't
+	// have the fields required by the Relay cursor connections specification. The
+	// problems are reported by DiagnoseSchema as well.
+	ValidateConnections bool
+	// TypeRegistry if set adds the registered types visible to TypeVisibility to the
+	// types of the schema. Creating the schema fails if a type of the schema has
+	// the name of a registered type but is a different instance or if it includes
+	// a registered type that isn't visible to it.
+	TypeRegistry *TypeRegistry
+	// TypeVisibility is the visibility of the schema for TypeRegistry.
+	TypeVisibility string
+	// ArgumentInjectors provide the values of injected arguments keyed by the name
+	// used in ArgumentConfig.Injected.
+	ArgumentInjectors map[string]ArgumentInjectorFn
+	// FieldNameCaseFallback if true makes the default resolver look for the
+	// snake_case form of a camelCase field name (and the camelCase form of a
+	// snake_case name) in maps and structs when there's no exact match. For
+	// structs the exported Go field name (e.g. "FirstName") is tried as well.
+	FieldNameCaseFallback bool
+	// CheckResolverTypes if true fails to create the schema if the Go type returned
+	// by the resolver of a field (see Field.ResultType) can't be completed as the
+	// type of the field (e.g. a string for a list of integers). The problems are
+	// reported by DiagnoseSchema as well.
+	CheckResolverTypes bool
+	// HealthField if true adds a `_health: Boolean!` field to the query type that
+	// always returns true so that probes can check that the service executes
+	// requests.
+	HealthField bool
+	// SchemaInfoField if true adds a `_schemaInfo` field to the query type that
+	// returns the schema hash, the version of this package, BuildVersion, and the
+	// features enabled for the request (see WithFeatures).
+	SchemaInfoField bool
+	// BuildVersion is the version of the service returned by `_schemaInfo`.
+	BuildVersion string
+	// StrictIDInput if true only accepts strings as ID inputs. By default both
+	// strings and integers are accepted in literals and variables as required by
+	// the spec, and an integer is coerced to its decimal string. Serialization
+	// isn't affected.
+	StrictIDInput bool
 }
 
 type TypeMap map[string]Type
@@ -42,10 +117,32 @@ type Schema struct {
 	directives []*Directive
 
 	queryType        *Object
+	configQueryType  *Object // the query type of the config if queryType is a copy
 	mutationType     *Object
 	subscriptionType *Object
 	implementations  map[string][]*Object
 	possibleTypeMap  *sync.Map // abstract type name -> map[string]struct{}
+
+	hash               string
+	schemaVersionField bool
+	metadata           map[string]any
+	restrictedTypes    map[string]struct{}
+	description        string
+	providers          map[reflect.Type]any
+	providerRegistry   *ProviderRegistry
+	argumentInjectors  map[string]ArgumentInjectorFn
+	logger             Logger
+	sanitizers         *Sanitizers
+	nodeFetchers       map[string]NodeFetcher
+	isTypeOfCache      *sync.Map // isTypeOfKey -> *Object
+
+	introspectionPagination bool
+	fieldNameCaseFallback   bool
+	gatedFields             bool
+	healthField             bool
+	schemaInfoField         bool
+	buildVersion            string
+	strictIDInput           bool
 }
 
 func NewSchema(config SchemaConfig) (Schema, error) {
@@ -68,6 +165,23 @@ func NewSchema(config SchemaConfig) (Schema, error) {
 	schema.queryType = config.Query
 	schema.mutationType = config.Mutation
 	schema.subscriptionType = config.Subscription
+	schema.schemaVersionField = config.SchemaVersionField
+	schema.healthField = config.HealthField
+	schema.schemaInfoField = config.SchemaInfoField
+	schema.buildVersion = config.BuildVersion
+	schema.introspectionPagination = config.IntrospectionPagination
+	schema.metadata = config.Metadata
+	schema.description = config.Description
+	schema.providers = config.Providers
+	schema.providerRegistry = config.ProviderRegistry
+	schema.argumentInjectors = config.ArgumentInjectors
+	schema.fieldNameCaseFallback = config.FieldNameCaseFallback
+	schema.strictIDInput = config.StrictIDInput
+	schema.logger = config.Logger
+	schema.sanitizers = config.Sanitizers
+	if config.IsTypeOfByGoType {
+		schema.isTypeOfCache = &sync.Map{}
+	}
 
 	// Provide specified directives (e.g. @include and @skip) by default.
 	schema.directives = config.Directives
@@ -81,6 +195,19 @@ func NewSchema(config SchemaConfig) (Schema, error) {
 		}
 	}
 
+	if config.Node != nil {
+		// The node field is added to a copy of the query type so that the type
+		// can be shared with other schemas. Fields that return the query type
+		// refer to the original which doesn't have the node field.
+		query, err := withNodeField(config.Query)
+		if err != nil {
+			return schema, err
+		}
+		schema.queryType = query
+		schema.configQueryType = config.Query
+		schema.nodeFetchers = maps.Clone(config.Node.Fetchers)
+	}
+
 	// Build type map now to detect any errors within this schema.
 	typeMap := TypeMap{}
 	initialTypes := make([]Type, 0, 4+len(config.Types))
@@ -97,9 +224,14 @@ func NewSchema(config SchemaConfig) (Schema, error) {
 		initialTypes = append(initialTypes, SchemaType)
 	}
 
-	for _, ttype := range config.Types {
+	if config.TypeRegistry != nil {
+		initialTypes = append(initialTypes, config.TypeRegistry.Types(config.TypeVisibility)...)
+	}
+	for i, ttype := range config.Types {
 		if ttype != nil {
 			initialTypes = append(initialTypes, ttype)
+		} else if schema.logger != nil {
+			schema.logger.WarnContext(context.Background(), "graphql: ignoring nil type in schema config", "index", i)
 		}
 	}
 
@@ -114,14 +246,21 @@ func NewSchema(config SchemaConfig) (Schema, error) {
 		}
 	}
 
+	if config.TypeRegistry != nil {
+		if err := config.TypeRegistry.checkSchemaTypes(typeMap, config.TypeVisibility); err != nil {
+			return schema, err
+		}
+	}
+
 	schema.typeMap = typeMap
 
-	// Keep track of all implementations by interface name.
+	// Keep track of all implementations by interface name sorted by name so that
+	// introspection and the order in which IsTypeOf is tried are stable.
 	if schema.implementations == nil {
 		schema.implementations = map[string][]*Object{}
 	}
-	for _, ttype := range schema.typeMap {
-		if ttype, ok := ttype.(*Object); ok {
+	for _, typeName := range sortedKeys(schema.typeMap) {
+		if ttype, ok := schema.typeMap[typeName].(*Object); ok {
 			for _, iface := range ttype.Interfaces() {
 				schema.implementations[iface.Name()] = append(schema.implementations[iface.Name()], ttype)
 			}
@@ -140,6 +279,31 @@ func NewSchema(config SchemaConfig) (Schema, error) {
 		}
 	}
 
+	if config.Node != nil {
+		if err := validateNodeFetchers(&schema, config.Node); err != nil {
+			return schema, err
+		}
+	}
+
+	if err := checkArgumentInjectors(schema.typeMap, config.ArgumentInjectors); err != nil {
+		return schema, err
+	}
+	schema.gatedFields = hasGatedFields(schema.typeMap)
+
+	if config.ValidateConnections {
+		if diagnostics := connectionDiagnostics(schema.typeMap); len(diagnostics) != 0 {
+			return schema, gqlerrors.NewFormattedError(diagnostics[0].Message)
+		}
+	}
+
+	if config.CheckResolverTypes {
+		if diagnostics := resolverTypeDiagnostics(schema.typeMap); len(diagnostics) != 0 {
+			return schema, gqlerrors.NewFormattedError(diagnostics[0].Message)
+		}
+	}
+
+	schema.hash = computeSchemaHash(&schema)
+
 	return schema, nil
 }
 
@@ -168,6 +332,16 @@ func (gq *Schema) Directive(name string) *Directive {
 	return nil
 }
 
+// Description returns the description of the schema.
+func (gq *Schema) Description() string {
+	return gq.description
+}
+
+// Metadata returns the metadata provided in the schema config.
+func (gq *Schema) Metadata() map[string]any {
+	return gq.metadata
+}
+
 func (gq *Schema) TypeMap() TypeMap {
 	return gq.typeMap
 }
@@ -177,6 +351,20 @@ func (gq *Schema) Type(name string) Type {
 }
 
 func (gq *Schema) PossibleTypes(abstractType Abstract) []*Object {
+	possibleTypes := gq.allPossibleTypes(abstractType)
+	if len(gq.restrictedTypes) == 0 {
+		return possibleTypes
+	}
+	filtered := make([]*Object, 0, len(possibleTypes))
+	for _, possibleType := range possibleTypes {
+		if !gq.IsRestrictedType(possibleType) {
+			filtered = append(filtered, possibleType)
+		}
+	}
+	return filtered
+}
+
+func (gq *Schema) allPossibleTypes(abstractType Abstract) []*Object {
 	switch abstractType := abstractType.(type) {
 	case *Union:
 		return abstractType.Types()
@@ -187,13 +375,36 @@ func (gq *Schema) PossibleTypes(abstractType Abstract) []*Object {
 	}
 	return []*Object{}
 }
+
+// IsRestrictedType returns true if the object type has been restricted for the
+// current request and so may not be returned for an abstract type.
+func (gq *Schema) IsRestrictedType(objectType *Object) bool {
+	_, ok := gq.restrictedTypes[objectType.Name()]
+	return ok
+}
+
+// withRestrictedTypes returns a copy of the schema where the named object types
+// are excluded from the possible types of all abstract types.
+func (gq Schema) withRestrictedTypes(typeNames []string) Schema {
+	gq.restrictedTypes = make(map[string]struct{}, len(typeNames))
+	for _, name := range typeNames {
+		gq.restrictedTypes[name] = struct{}{}
+	}
+	return gq
+}
+
 func (gq *Schema) IsPossibleType(abstractType Abstract, possibleType *Object) bool {
+	if gq.IsRestrictedType(possibleType) {
+		return false
+	}
+
 	name := abstractType.Name()
 	typeMapVal, _ := gq.possibleTypeMap.Load(name)
 	typeMap, ok := typeMapVal.(map[string]struct{})
 
 	if !ok {
-		possibleTypes := gq.PossibleTypes(abstractType)
+		// The cache is shared by all copies of the schema so always use the unrestricted types.
+		possibleTypes := gq.allPossibleTypes(abstractType)
 		typeMap = make(map[string]struct{}, len(possibleTypes))
 		for _, possibleType := range possibleTypes {
 			typeMap[possibleType.Name()] = struct{}{}
@@ -226,7 +437,7 @@ func typeMapReducer(schema *Schema, typeMap TypeMap, objectType Type) (TypeMap,
 	}
 
 	if mappedObjectType, ok := typeMap[objectType.Name()]; ok {
-		if mappedObjectType != objectType {
+		if mappedObjectType != objectType && (objectType != schema.configQueryType || mappedObjectType != schema.queryType) {
 			return typeMap, gqlerrors.NewFormattedError(fmt.Sprintf(`Schema must contain unique named types but contains multiple types named "%v".`, objectType.Name()))
 		}
 		return typeMap, nil
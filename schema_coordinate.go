@@ -0,0 +1,125 @@
+package graphql
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// SchemaCoordinate is a reference to an element of a schema as defined by the
+// Schema Coordinates spec: a type ("User"), a field, input field, or enum value
+// ("User.friends"), an argument of a field ("User.friends(first:)"), a directive
+// ("@deprecated"), or an argument of a directive ("@deprecated(reason:)").
+type SchemaCoordinate struct {
+	// Directive is true if the coordinate refers to a directive or one of its
+	// arguments in which case Name is the name of the directive.
+	Directive bool
+	// Name is the name of the type or directive.
+	Name string
+	// Member is the name of the field, input field, or enum value of the type.
+	Member string
+	// Argument is the name of the argument of the field or directive.
+	Argument string
+}
+
+var schemaCoordinateRegExp = regexp.MustCompile(`^(@?)([_a-zA-Z][_a-zA-Z0-9]*)(?:\.([_a-zA-Z][_a-zA-Z0-9]*))?(?:\(([_a-zA-Z][_a-zA-Z0-9]*):\))?$`)
+
+// ParseSchemaCoordinate parses a schema coordinate such as "User.friends(first:)".
+func ParseSchemaCoordinate(coordinate string) (SchemaCoordinate, error) {
+	m := schemaCoordinateRegExp.FindStringSubmatch(coordinate)
+	c := SchemaCoordinate{}
+	if m != nil {
+		c = SchemaCoordinate{Directive: m[1] == "@", Name: m[2], Member: m[3], Argument: m[4]}
+	}
+	// Directives don't have members and only fields have arguments.
+	if m == nil || (c.Directive && c.Member != "") || (!c.Directive && c.Argument != "" && c.Member == "") {
+		return SchemaCoordinate{}, fmt.Errorf("Invalid schema coordinate %q.", coordinate)
+	}
+	return c, nil
+}
+
+// String returns the coordinate in its textual form.
+func (c SchemaCoordinate) String() string {
+	s := c.Name
+	if c.Directive {
+		s = "@" + s
+	}
+	if c.Member != "" {
+		s += "." + c.Member
+	}
+	if c.Argument != "" {
+		s += "(" + c.Argument + ":)"
+	}
+	return s
+}
+
+// LookupCoordinate returns the element of the schema that the coordinate refers
+// to. It's a Type, *FieldDefinition, *InputObjectField, *EnumValueDefinition,
+// *Argument, or *Directive. It returns nil if the schema doesn't have the element
+// and an error if the coordinate is invalid or refers to a member of a type that
+// can't have members (e.g. a scalar).
+func (gq *Schema) LookupCoordinate(coordinate string) (any, error) {
+	c, err := ParseSchemaCoordinate(coordinate)
+	if err != nil {
+		return nil, err
+	}
+	if c.Directive {
+		directive := gq.Directive(c.Name)
+		if directive == nil {
+			return nil, nil
+		}
+		if c.Argument == "" {
+			return directive, nil
+		}
+		return coordinateArgument(directive.Args, c.Argument), nil
+	}
+	ttype := gq.Type(c.Name)
+	if ttype == nil {
+		return nil, nil
+	}
+	if c.Member == "" {
+		return ttype, nil
+	}
+	switch ttype := ttype.(type) {
+	case *Object:
+		return lookupFieldCoordinate(ttype.Fields(), c), nil
+	case *Interface:
+		return lookupFieldCoordinate(ttype.Fields(), c), nil
+	case *InputObject:
+		if field := ttype.Fields()[c.Member]; field != nil && c.Argument == "" {
+			return field, nil
+		}
+		return nil, nil
+	case *Enum:
+		if c.Argument == "" {
+			for _, value := range ttype.Values() {
+				if value.Name == c.Member {
+					return value, nil
+				}
+			}
+		}
+		return nil, nil
+	}
+	return nil, fmt.Errorf("Schema coordinate %q refers to a member of %s which has no members.", coordinate, ttype.Name())
+}
+
+func lookupFieldCoordinate(fields FieldDefinitionMap, c SchemaCoordinate) any {
+	field := fields[c.Member]
+	if field == nil {
+		return nil
+	}
+	if c.Argument == "" {
+		return field
+	}
+	return coordinateArgument(field.Args, c.Argument)
+}
+
+// coordinateArgument returns the argument with the name or nil. Unlike
+// findArgument it ignores aliases since they aren't part of the schema.
+func coordinateArgument(args []*Argument, name string) any {
+	for _, arg := range args {
+		if arg.PrivateName == name {
+			return arg
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package graphql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+func TestParseSchemaCoordinate(t *testing.T) {
+	cases := map[string]graphql.SchemaCoordinate{
+		"User":                  {Name: "User"},
+		"User.friends":          {Name: "User", Member: "friends"},
+		"User.friends(first:)":  {Name: "User", Member: "friends", Argument: "first"},
+		"@deprecated":           {Directive: true, Name: "deprecated"},
+		"@deprecated(reason:)":  {Directive: true, Name: "deprecated", Argument: "reason"},
+		"_private.__typename":   {Name: "_private", Member: "__typename"},
+		"Episode.NEW_HOPE":      {Name: "Episode", Member: "NEW_HOPE"},
+		"Query.user(id_2:)":     {Name: "Query", Member: "user", Argument: "id_2"},
+		"Mutation.a(b:)":        {Name: "Mutation", Member: "a", Argument: "b"},
+		"@include(if:)":         {Directive: true, Name: "include", Argument: "if"},
+		"Connection.edges":      {Name: "Connection", Member: "edges"},
+		"Type.field(argument:)": {Name: "Type", Member: "field", Argument: "argument"},
+	}
+	for coordinate, expected := range cases {
+		c, err := graphql.ParseSchemaCoordinate(coordinate)
+		if err != nil {
+			t.Errorf("%s: %s", coordinate, err)
+		} else if !reflect.DeepEqual(expected, c) {
+			t.Errorf("%s: unexpected coordinate, Diff: %v", coordinate, testutil.Diff(expected, c))
+		} else if c.String() != coordinate {
+			t.Errorf("Expected %s, got %s", coordinate, c.String())
+		}
+	}
+
+	for _, coordinate := range []string{
+		"", "User.", ".friends", "User.friends.name", "User(first:)", "@deprecated.reason",
+		"User.friends(first)", "User.friends(first:", "User.friends()", " User", "1User", "@", "User.friends(first:)x",
+	} {
+		if _, err := graphql.ParseSchemaCoordinate(coordinate); err == nil {
+			t.Errorf("Expected %q to be invalid", coordinate)
+		}
+	}
+}
+
+func TestSchemaLookupCoordinate(t *testing.T) {
+	schema, err := testutil.SchemaFromSDL(`
+		directive @auth(role: String) on FIELD_DEFINITION
+		scalar Date
+		enum Role { ADMIN USER }
+		input UserFilter { role: Role }
+		interface Node { id: ID! }
+		type User implements Node {
+			id: ID!
+			friends(first: Int, filter: UserFilter): [User]
+		}
+		union SearchResult = User
+		type Query { node(id: ID!): Node search: [SearchResult] }
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := schema.Type("User").(*graphql.Object)
+	node := schema.Type("Node").(*graphql.Interface)
+	filter := schema.Type("UserFilter").(*graphql.InputObject)
+	role := schema.Type("Role").(*graphql.Enum)
+	auth := schema.Directive("auth")
+	var filterArg *graphql.Argument
+	for _, arg := range user.Fields()["friends"].Args {
+		if arg.Name() == "filter" {
+			filterArg = arg
+		}
+	}
+
+	cases := map[string]any{
+		"User":                   user,
+		"User.friends":           user.Fields()["friends"],
+		"User.friends(filter:)":  filterArg,
+		"Node.id":                node.Fields()["id"],
+		"UserFilter.role":        filter.Fields()["role"],
+		"Role.ADMIN":             role.Values()[0],
+		"@auth":                  auth,
+		"@auth(role:)":           auth.Args[0],
+		"@include(if:)":          graphql.IncludeDirective.Args[0],
+		"Unknown":                nil,
+		"User.unknown":           nil,
+		"User.friends(unknown:)": nil,
+		"UserFilter.unknown":     nil,
+		"UserFilter.role(x:)":    nil,
+		"Role.UNKNOWN":           nil,
+		"@unknown":               nil,
+		"@auth(unknown:)":        nil,
+	}
+	for coordinate, expected := range cases {
+		element, err := schema.LookupCoordinate(coordinate)
+		if err != nil {
+			t.Errorf("%s: %s", coordinate, err)
+		} else if element != expected {
+			t.Errorf("%s: expected %v, got %v", coordinate, expected, element)
+		}
+	}
+
+	// Scalars and unions don't have members
+	for _, coordinate := range []string{"Date.value", "SearchResult.User", "String.length", "User.friends("} {
+		if element, err := schema.LookupCoordinate(coordinate); err == nil {
+			t.Errorf("Expected an error for %s, got %v", coordinate, element)
+		}
+	}
+}
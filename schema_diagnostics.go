@@ -0,0 +1,429 @@
+package graphql
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// SchemaDiagnosticKind is the kind of problem found by DiagnoseSchema.
+type SchemaDiagnosticKind string
+
+const (
+	// DiagnosticTypeError is a type that failed to build.
+	DiagnosticTypeError SchemaDiagnosticKind = "typeError"
+	// DiagnosticNilType is a field, argument, interface, or union member whose type is nil.
+	DiagnosticNilType SchemaDiagnosticKind = "nilType"
+	// DiagnosticDuplicateType is a type name used by more than one type.
+	DiagnosticDuplicateType SchemaDiagnosticKind = "duplicateType"
+	// DiagnosticUnreachableType is a type in SchemaConfig.Types that can't be reached from a root type.
+	DiagnosticUnreachableType SchemaDiagnosticKind = "unreachableType"
+	// DiagnosticInputCycle is a cycle of non-null input object fields that no value can satisfy.
+	DiagnosticInputCycle SchemaDiagnosticKind = "inputCycle"
+)
+
+// SchemaDiagnostic is a single problem found in a schema configuration.
+type SchemaDiagnostic struct {
+	Kind SchemaDiagnosticKind
+	// Type is the name of the type with the problem.
+	Type string
+	// Field is the name of the field with the problem if any.
+	Field      string
+	Message    string
+	Suggestion string
+}
+
+func (d SchemaDiagnostic) String() string {
+	s := string(d.Kind) + ": " + d.Message
+	if d.Suggestion != "" {
+		s += " " + d.Suggestion
+	}
+	return s
+}
+
+// SchemaDiagnostics is the report returned by DiagnoseSchema.
+type SchemaDiagnostics []SchemaDiagnostic
+
+func (ds SchemaDiagnostics) String() string {
+	lines := make([]string, len(ds))
+	for i, d := range ds {
+		lines[i] = d.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// DiagnoseSchema inspects a schema configuration and reports all problems it
+// finds rather than only the first as NewSchema does. Besides the errors NewSchema
+// returns it reports types that are never reachable from a root type and cycles
+// of required input object fields, with SchemaConfig.ValidateConnections the
+// Relay connection types that don't follow the specification, and with
+// SchemaConfig.CheckResolverTypes the resolvers that return incompatible Go types. It's meant for debugging large programmatically
+// built schemas and is not needed when NewSchema succeeds.
+func DiagnoseSchema(config SchemaConfig) SchemaDiagnostics {
+	d := &schemaDiagnoser{types: make(map[string]Type)}
+	var roots []Type
+	for _, root := range []*Object{config.Query, config.Mutation, config.Subscription} {
+		if root != nil {
+			roots = append(roots, root)
+		}
+	}
+	if config.Query == nil {
+		d.add(SchemaDiagnostic{
+			Kind:       DiagnosticNilType,
+			Message:    "Schema query type is nil.",
+			Suggestion: "Set SchemaConfig.Query.",
+		})
+	}
+	for _, t := range roots {
+		d.collect(t)
+	}
+	for _, t := range config.Types {
+		if !isNilType(t) {
+			d.collect(t)
+		}
+	}
+	d.unreachable(roots, config.Types)
+	d.inputCycles()
+	if config.ValidateConnections {
+		d.diagnostics = append(d.diagnostics, connectionDiagnostics(d.types)...)
+	}
+	if config.CheckResolverTypes {
+		d.diagnostics = append(d.diagnostics, resolverTypeDiagnostics(d.types)...)
+	}
+	return d.diagnostics
+}
+
+type schemaDiagnoser struct {
+	types       map[string]Type
+	diagnostics SchemaDiagnostics
+}
+
+func (d *schemaDiagnoser) add(diag SchemaDiagnostic) {
+	d.diagnostics = append(d.diagnostics, diag)
+}
+
+// diagnosticField is a field of a type as configured including fields that failed to build.
+type diagnosticField struct {
+	name string
+	typ  Type
+	args map[string]Type
+}
+
+// collect records all types reachable from t through fields, arguments,
+// interfaces, and union members.
+func (d *schemaDiagnoser) collect(t Type) {
+	named := namedOrNil(t)
+	if named == nil || named.Name() == "" {
+		return
+	}
+	if existing, ok := d.types[named.Name()]; ok {
+		if existing != named {
+			d.add(SchemaDiagnostic{
+				Kind:       DiagnosticDuplicateType,
+				Type:       named.Name(),
+				Message:    fmt.Sprintf("Multiple types are named %q.", named.Name()),
+				Suggestion: "Create the type once and reuse the same value wherever it's referenced.",
+			})
+		}
+		return
+	}
+	d.types[named.Name()] = named
+	if err := named.Error(); err != nil {
+		d.add(SchemaDiagnostic{
+			Kind:    DiagnosticTypeError,
+			Type:    named.Name(),
+			Message: err.Error(),
+		})
+	}
+
+	switch named := named.(type) {
+	case *Object:
+		for _, iface := range named.Interfaces() {
+			d.collect(iface)
+		}
+	case *Union:
+		for i, member := range named.typeConfig.Types {
+			if isNilType(member) {
+				d.add(SchemaDiagnostic{
+					Kind:       DiagnosticNilType,
+					Type:       named.Name(),
+					Message:    fmt.Sprintf("%s member %d is nil.", named.Name(), i),
+					Suggestion: nilTypeSuggestion,
+				})
+				continue
+			}
+			d.collect(member)
+		}
+	}
+
+	for _, f := range diagnosticFields(named) {
+		if isNilType(f.typ) {
+			d.add(SchemaDiagnostic{
+				Kind:       DiagnosticNilType,
+				Type:       named.Name(),
+				Field:      f.name,
+				Message:    fmt.Sprintf("%s.%s has a nil type.", named.Name(), f.name),
+				Suggestion: nilTypeSuggestion,
+			})
+		} else {
+			d.collect(f.typ)
+		}
+		for _, argName := range sortedKeys(f.args) {
+			argType := f.args[argName]
+			if isNilType(argType) {
+				d.add(SchemaDiagnostic{
+					Kind:       DiagnosticNilType,
+					Type:       named.Name(),
+					Field:      f.name,
+					Message:    fmt.Sprintf("%s.%s(%s:) has a nil type.", named.Name(), f.name, argName),
+					Suggestion: nilTypeSuggestion,
+				})
+				continue
+			}
+			d.collect(argType)
+		}
+	}
+}
+
+const nilTypeSuggestion = "The type is most likely a package level variable that is not initialized yet " +
+	"because of a reference cycle. Add the field in an init function using AddFieldConfig or define " +
+	"the fields with a FieldsThunk."
+
+// unreachable reports types in SchemaConfig.Types that can't be reached from a root type.
+func (d *schemaDiagnoser) unreachable(roots []Type, types []Type) {
+	implementations := make(map[string][]*Object)
+	for _, name := range sortedKeys(d.types) {
+		if obj, ok := d.types[name].(*Object); ok {
+			for _, iface := range obj.Interfaces() {
+				implementations[iface.Name()] = append(implementations[iface.Name()], obj)
+			}
+		}
+	}
+
+	reachable := make(map[string]bool)
+	var visit func(t Type)
+	visit = func(t Type) {
+		named := namedOrNil(t)
+		if named == nil || reachable[named.Name()] {
+			return
+		}
+		reachable[named.Name()] = true
+		switch named := named.(type) {
+		case *Object:
+			for _, iface := range named.Interfaces() {
+				visit(iface)
+			}
+		case *Interface:
+			for _, impl := range implementations[named.Name()] {
+				visit(impl)
+			}
+		case *Union:
+			for _, member := range named.typeConfig.Types {
+				if !isNilType(member) {
+					visit(member)
+				}
+			}
+		}
+		for _, f := range diagnosticFields(named) {
+			if !isNilType(f.typ) {
+				visit(f.typ)
+			}
+			for _, argType := range f.args {
+				if !isNilType(argType) {
+					visit(argType)
+				}
+			}
+		}
+	}
+	for _, root := range roots {
+		visit(root)
+	}
+
+	reported := make(map[string]bool)
+	for _, t := range types {
+		named := namedOrNil(t)
+		if named == nil || reachable[named.Name()] || reported[named.Name()] {
+			continue
+		}
+		reported[named.Name()] = true
+		d.add(SchemaDiagnostic{
+			Kind:       DiagnosticUnreachableType,
+			Type:       named.Name(),
+			Message:    fmt.Sprintf("%s is not reachable from a root type.", named.Name()),
+			Suggestion: "Reference it from a field or remove it from SchemaConfig.Types.",
+		})
+	}
+}
+
+// inputCycles reports cycles of non-null input object fields. A value for any of
+// the input objects in such a cycle would have to be infinitely deep.
+func (d *schemaDiagnoser) inputCycles() {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int)
+	var stack []string
+	var visit func(obj *InputObject)
+	visit = func(obj *InputObject) {
+		state[obj.Name()] = visiting
+		for _, f := range diagnosticFields(obj) {
+			nonNull, ok := f.typ.(*NonNull)
+			if !ok || isNilType(nonNull.OfType) {
+				continue
+			}
+			next, ok := nonNull.OfType.(*InputObject)
+			if !ok {
+				continue
+			}
+			stack = append(stack, obj.Name()+"."+f.name)
+			switch state[next.Name()] {
+			case unvisited:
+				visit(next)
+			case visiting:
+				var start int
+				for i, s := range stack {
+					if strings.HasPrefix(s, next.Name()+".") {
+						start = i
+						break
+					}
+				}
+				path := append(append([]string(nil), stack[start:]...), next.Name())
+				d.add(SchemaDiagnostic{
+					Kind:       DiagnosticInputCycle,
+					Type:       next.Name(),
+					Message:    fmt.Sprintf("Input objects form a cycle of non-null fields: %s.", strings.Join(path, " -> ")),
+					Suggestion: "Make at least one of the fields in the cycle nullable.",
+				})
+			}
+			stack = stack[:len(stack)-1]
+		}
+		state[obj.Name()] = done
+	}
+	for _, name := range sortedKeys(d.types) {
+		if obj, ok := d.types[name].(*InputObject); ok && state[name] == unvisited {
+			visit(obj)
+		}
+	}
+}
+
+// diagnosticFields returns the fields of a type sorted by name. Fields are taken
+// from the configuration when possible so that fields that failed to build are
+// included.
+func diagnosticFields(t Type) []diagnosticField {
+	var fields []diagnosticField
+	switch t := t.(type) {
+	case *Object:
+		t.mu.RLock()
+		config, ok := t.typeConfig.Fields.(Fields)
+		t.mu.RUnlock()
+		if ok {
+			fields = configFields(config)
+		} else {
+			fields = definedFields(t.Fields())
+		}
+	case *Interface:
+		t.mu.RLock()
+		config, ok := t.typeConfig.Fields.(Fields)
+		t.mu.RUnlock()
+		if ok {
+			fields = configFields(config)
+		} else {
+			fields = definedFields(t.Fields())
+		}
+	case *InputObject:
+		t.mu.RLock()
+		config, ok := t.typeConfig.Fields.(InputObjectConfigFieldMap)
+		t.mu.RUnlock()
+		if ok {
+			for name, f := range config {
+				if f != nil {
+					fields = append(fields, diagnosticField{name: name, typ: f.Type})
+				}
+			}
+		} else {
+			for name, f := range t.Fields() {
+				fields = append(fields, diagnosticField{name: name, typ: f.Type})
+			}
+		}
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].name < fields[j].name
+	})
+	return fields
+}
+
+func configFields(config Fields) []diagnosticField {
+	fields := make([]diagnosticField, 0, len(config))
+	for name, f := range config {
+		if f == nil {
+			continue
+		}
+		df := diagnosticField{name: name, typ: f.Type}
+		for argName, arg := range f.Args {
+			if df.args == nil {
+				df.args = make(map[string]Type, len(f.Args))
+			}
+			if arg == nil {
+				df.args[argName] = nil
+			} else {
+				df.args[argName] = arg.Type
+			}
+		}
+		fields = append(fields, df)
+	}
+	return fields
+}
+
+func definedFields(defs FieldDefinitionMap) []diagnosticField {
+	fields := make([]diagnosticField, 0, len(defs))
+	for name, f := range defs {
+		df := diagnosticField{name: name, typ: f.Type}
+		for _, arg := range f.Args {
+			if df.args == nil {
+				df.args = make(map[string]Type, len(f.Args))
+			}
+			df.args[arg.Name()] = arg.Type
+		}
+		fields = append(fields, df)
+	}
+	return fields
+}
+
+// namedOrNil unwraps lists and non-nulls and returns nil if any type along the way is nil.
+func namedOrNil(t Type) Type {
+	for {
+		if isNilType(t) {
+			return nil
+		}
+		switch tt := t.(type) {
+		case *List:
+			t = tt.OfType
+		case *NonNull:
+			t = tt.OfType
+		default:
+			return t
+		}
+	}
+}
+
+// isNilType returns true for nil and for typed nil pointers (e.g. a *Object
+// variable that is not initialized yet).
+func isNilType(t any) bool {
+	if t == nil {
+		return true
+	}
+	v := reflect.ValueOf(t)
+	return v.Kind() == reflect.Pointer && v.IsNil()
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
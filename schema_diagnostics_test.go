@@ -0,0 +1,188 @@
+package graphql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+)
+
+func TestDiagnoseSchema(t *testing.T) {
+	var uninitialized *graphql.Object
+	inputA := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "InputA",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"name": &graphql.InputObjectFieldConfig{Type: graphql.String},
+		},
+	})
+	inputB := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "InputB",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"a": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(inputA)},
+		},
+	})
+	inputA.AddInputField("b", &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(inputB)})
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"broken": &graphql.Field{Type: uninitialized},
+			"first": &graphql.Field{
+				Type: graphql.NewObject(graphql.ObjectConfig{
+					Name:   "Thing",
+					Fields: graphql.Fields{"id": &graphql.Field{Type: graphql.ID}},
+				}),
+			},
+			"second": &graphql.Field{
+				Type: graphql.NewObject(graphql.ObjectConfig{
+					Name:   "Thing",
+					Fields: graphql.Fields{"id": &graphql.Field{Type: graphql.ID}},
+				}),
+				Args: graphql.FieldConfigArgument{
+					"input": &graphql.ArgumentConfig{Type: inputB},
+				},
+			},
+		},
+	})
+	unused := graphql.NewObject(graphql.ObjectConfig{
+		Name:   "Unused",
+		Fields: graphql.Fields{"id": &graphql.Field{Type: graphql.ID}},
+	})
+
+	diagnostics := graphql.DiagnoseSchema(graphql.SchemaConfig{
+		Query: query,
+		Types: []graphql.Type{unused},
+	})
+	var kinds []graphql.SchemaDiagnosticKind
+	for _, d := range diagnostics {
+		kinds = append(kinds, d.Kind)
+	}
+	expected := []graphql.SchemaDiagnosticKind{
+		graphql.DiagnosticNilType,
+		graphql.DiagnosticDuplicateType,
+		graphql.DiagnosticUnreachableType,
+		graphql.DiagnosticInputCycle,
+	}
+	if !reflect.DeepEqual(expected, kinds) {
+		t.Fatalf("Expected diagnostics %v, got:\n%s", expected, diagnostics)
+	}
+	if d := diagnostics[0]; d.Type != "Query" || d.Field != "broken" || d.Suggestion == "" {
+		t.Errorf("Unexpected nil type diagnostic %+v", d)
+	}
+	if d := diagnostics[1]; d.Type != "Thing" {
+		t.Errorf("Unexpected duplicate type diagnostic %+v", d)
+	}
+	if d := diagnostics[2]; d.Type != "Unused" {
+		t.Errorf("Unexpected unreachable type diagnostic %+v", d)
+	}
+	if d := diagnostics[3]; d.Message != "Input objects form a cycle of non-null fields: InputA.b -> InputB.a -> InputA." {
+		t.Errorf("Unexpected input cycle diagnostic %+v", d)
+	}
+}
+
+func TestDiagnoseSchema_Valid(t *testing.T) {
+	iface := graphql.NewInterface(graphql.InterfaceConfig{
+		Name:   "Node",
+		Fields: graphql.Fields{"id": &graphql.Field{Type: graphql.ID}},
+	})
+	impl := graphql.NewObject(graphql.ObjectConfig{
+		Name:       "Impl",
+		Interfaces: []*graphql.Interface{iface},
+		Fields:     graphql.Fields{"id": &graphql.Field{Type: graphql.ID}},
+	})
+	diagnostics := graphql.DiagnoseSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name:   "Query",
+			Fields: graphql.Fields{"node": &graphql.Field{Type: iface}},
+		}),
+		Types: []graphql.Type{impl},
+	})
+	if len(diagnostics) != 0 {
+		t.Fatalf("Expected no diagnostics, got:\n%s", diagnostics)
+	}
+}
+
+func TestValidateConnections(t *testing.T) {
+	pageInfo := graphql.NewObject(graphql.ObjectConfig{
+		Name: "PageInfo",
+		Fields: graphql.Fields{
+			"hasPreviousPage": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"hasNextPage":     &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"startCursor":     &graphql.Field{Type: graphql.String},
+			"endCursor":       &graphql.Field{Type: graphql.String},
+		},
+	})
+	user := graphql.NewObject(graphql.ObjectConfig{
+		Name:   "User",
+		Fields: graphql.Fields{"id": &graphql.Field{Type: graphql.ID}},
+	})
+	userEdge := graphql.NewObject(graphql.ObjectConfig{
+		Name: "UserEdge",
+		Fields: graphql.Fields{
+			"node":   &graphql.Field{Type: user},
+			"cursor": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		},
+	})
+	userConnection := graphql.NewObject(graphql.ObjectConfig{
+		Name: "UserConnection",
+		Fields: graphql.Fields{
+			"edges":    &graphql.Field{Type: graphql.NewList(userEdge)},
+			"pageInfo": &graphql.Field{Type: graphql.NewNonNull(pageInfo)},
+		},
+	})
+	groupEdge := graphql.NewObject(graphql.ObjectConfig{
+		Name: "GroupEdge",
+		Fields: graphql.Fields{
+			"node":   &graphql.Field{Type: graphql.NewList(user)},
+			"cursor": &graphql.Field{Type: graphql.Int},
+		},
+	})
+	groupConnection := graphql.NewObject(graphql.ObjectConfig{
+		Name: "GroupConnection",
+		Fields: graphql.Fields{
+			"edges":    &graphql.Field{Type: groupEdge},
+			"pageInfo": &graphql.Field{Type: pageInfo},
+		},
+	})
+	config := func(fields graphql.Fields) graphql.SchemaConfig {
+		return graphql.SchemaConfig{
+			Query: graphql.NewObject(graphql.ObjectConfig{
+				Name:   "Query",
+				Fields: fields,
+			}),
+			ValidateConnections: true,
+		}
+	}
+
+	valid := config(graphql.Fields{"users": &graphql.Field{Type: userConnection}})
+	if _, err := graphql.NewSchema(valid); err != nil {
+		t.Fatal(err)
+	}
+	if diagnostics := graphql.DiagnoseSchema(valid); len(diagnostics) != 0 {
+		t.Fatalf("Expected no diagnostics, got %s", diagnostics)
+	}
+
+	invalid := config(graphql.Fields{
+		"users":  &graphql.Field{Type: userConnection},
+		"groups": &graphql.Field{Type: groupConnection},
+	})
+	if _, err := graphql.NewSchema(invalid); err == nil || err.Error() != "GroupConnection.edges must return a list but returns GroupEdge." {
+		t.Fatalf("Expected an error for GroupConnection.edges, got %v", err)
+	}
+	var messages []string
+	for _, d := range graphql.DiagnoseSchema(invalid) {
+		if d.Kind != graphql.DiagnosticConnectionShape {
+			t.Fatalf("Unexpected diagnostic %s", d)
+		}
+		messages = append(messages, d.Message)
+	}
+	expected := []string{
+		"GroupConnection.edges must return a list but returns GroupEdge.",
+		"GroupConnection.pageInfo must return PageInfo! but returns PageInfo.",
+		"GroupEdge.node must not return a list but returns [User].",
+		"GroupEdge.cursor must return a String or a custom scalar but returns Int.",
+	}
+	if !reflect.DeepEqual(expected, messages) {
+		t.Fatalf("Expected diagnostics %q, got %q", expected, messages)
+	}
+}
@@ -0,0 +1,150 @@
+package graphql
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"sort"
+
+	"github.com/sprucehealth/graphql/language/printer"
+)
+
+// SchemaVersionHeader is the HTTP response header conventionally used to
+// report the schema hash to clients.
+const SchemaVersionHeader = "X-GraphQL-Schema-Version"
+
+// Hash returns a stable hex encoded digest of the type system. It covers the
+// names, kinds, fields, arguments, default values, deprecation reasons, and
+// directives of the schema but not descriptions. Two schemas that are
+// structurally identical will always have the same hash regardless of the
+// order in which their types or fields were defined.
+func (gq *Schema) Hash() string {
+	return gq.hash
+}
+
+func computeSchemaHash(schema *Schema) string {
+	h := sha256.New()
+
+	typeNames := make([]string, 0, len(schema.typeMap))
+	for name := range schema.typeMap {
+		typeNames = append(typeNames, name)
+	}
+	sort.Strings(typeNames)
+
+	writeRootType := func(op string, t *Object) {
+		if t != nil {
+			fmt.Fprintf(h, "schema %s: %s\n", op, t.Name())
+		}
+	}
+	writeRootType("query", schema.queryType)
+	writeRootType("mutation", schema.mutationType)
+	writeRootType("subscription", schema.subscriptionType)
+
+	for _, name := range typeNames {
+		switch t := schema.typeMap[name].(type) {
+		case *Scalar:
+			fmt.Fprintf(h, "scalar %s\n", t.Name())
+		case *Object:
+			fmt.Fprintf(h, "type %s", t.Name())
+			ifaceNames := make([]string, 0, len(t.Interfaces()))
+			for _, iface := range t.Interfaces() {
+				ifaceNames = append(ifaceNames, iface.Name())
+			}
+			sort.Strings(ifaceNames)
+			for _, iface := range ifaceNames {
+				fmt.Fprintf(h, " & %s", iface)
+			}
+			io.WriteString(h, "\n")
+			hashFieldDefinitions(h, t.Fields())
+		case *Interface:
+			fmt.Fprintf(h, "interface %s\n", t.Name())
+			hashFieldDefinitions(h, t.Fields())
+		case *Union:
+			fmt.Fprintf(h, "union %s", t.Name())
+			memberNames := make([]string, 0, len(t.Types()))
+			for _, member := range t.Types() {
+				memberNames = append(memberNames, member.Name())
+			}
+			sort.Strings(memberNames)
+			for _, member := range memberNames {
+				fmt.Fprintf(h, " | %s", member)
+			}
+			io.WriteString(h, "\n")
+		case *Enum:
+			fmt.Fprintf(h, "enum %s\n", t.Name())
+			values := append([]*EnumValueDefinition(nil), t.Values()...)
+			sort.Slice(values, func(i, j int) bool {
+				return values[i].Name < values[j].Name
+			})
+			for _, v := range values {
+				fmt.Fprintf(h, "  %s %q\n", v.Name, v.DeprecationReason)
+			}
+		case *InputObject:
+			fmt.Fprintf(h, "input %s\n", t.Name())
+			fields := t.Fields()
+			fieldNames := make([]string, 0, len(fields))
+			for name := range fields {
+				fieldNames = append(fieldNames, name)
+			}
+			sort.Strings(fieldNames)
+			for _, name := range fieldNames {
+				f := fields[name]
+				fmt.Fprintf(h, "  %s: %s = %s\n", name, f.Type, hashDefaultValue(f.DefaultValue))
+			}
+		}
+	}
+
+	directives := append([]*Directive(nil), schema.directives...)
+	sort.Slice(directives, func(i, j int) bool {
+		return directives[i].Name < directives[j].Name
+	})
+	for _, d := range directives {
+		locations := append([]string(nil), d.Locations...)
+		sort.Strings(locations)
+		fmt.Fprintf(h, "directive @%s %v\n", d.Name, locations)
+		hashArguments(h, d.Args)
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func hashFieldDefinitions(h hash.Hash, fields FieldDefinitionMap) {
+	fieldNames := make([]string, 0, len(fields))
+	for name := range fields {
+		fieldNames = append(fieldNames, name)
+	}
+	sort.Strings(fieldNames)
+	for _, name := range fieldNames {
+		f := fields[name]
+		fmt.Fprintf(h, "  %s: %s %q", name, f.Type, f.DeprecationReason)
+		for _, d := range f.Directives {
+			fmt.Fprintf(h, " %s", printer.Print(d))
+		}
+		io.WriteString(h, "\n")
+		hashArguments(h, f.Args)
+	}
+}
+
+func hashArguments(h hash.Hash, args []*Argument) {
+	sorted := append([]*Argument(nil), args...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].PrivateName < sorted[j].PrivateName
+	})
+	for _, arg := range sorted {
+		fmt.Fprintf(h, "    %s: %s = %s\n", arg.PrivateName, arg.Type, hashDefaultValue(arg.DefaultValue))
+	}
+}
+
+// hashDefaultValue returns a canonical form of a default value that doesn't
+// depend on the process (e.g. pointer addresses). JSON dereferences pointers and
+// sorts the keys of maps.
+func hashDefaultValue(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
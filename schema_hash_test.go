@@ -0,0 +1,111 @@
+package graphql_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+func hashTestSchema(t *testing.T, argType graphql.Input, description string, versionField bool) graphql.Schema {
+	t.Helper()
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name:        "Query",
+			Description: description,
+			Fields: graphql.Fields{
+				"a": &graphql.Field{Type: graphql.String},
+				"b": &graphql.Field{
+					Type:        graphql.Int,
+					Description: description,
+					Args: graphql.FieldConfigArgument{
+						"x": &graphql.ArgumentConfig{Type: argType},
+					},
+				},
+			},
+		}),
+		SchemaVersionField: versionField,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+func TestSchemaHash(t *testing.T) {
+	s1 := hashTestSchema(t, graphql.String, "", false)
+	s2 := hashTestSchema(t, graphql.String, "different description", true)
+	if s1.Hash() == "" {
+		t.Fatal("Expected non-empty hash")
+	}
+	if s1.Hash() != s2.Hash() {
+		t.Fatalf("Expected identical type systems to have the same hash: %s != %s", s1.Hash(), s2.Hash())
+	}
+	s3 := hashTestSchema(t, graphql.NewNonNull(graphql.String), "", false)
+	if s1.Hash() == s3.Hash() {
+		t.Fatal("Expected changing an argument type to change the hash")
+	}
+}
+
+func TestSchemaHash_DefaultValues(t *testing.T) {
+	// Default values behind pointers and maps hash by their content
+	newSchema := func() graphql.Schema {
+		limit := 10
+		filter := graphql.NewInputObject(graphql.InputObjectConfig{
+			Name: "Filter",
+			Fields: graphql.InputObjectConfigFieldMap{
+				"tags": &graphql.InputObjectFieldConfig{
+					Type:         graphql.NewList(graphql.String),
+					DefaultValue: map[string]any{"b": []string{"x"}, "a": &limit},
+				},
+			},
+		})
+		schema, err := graphql.NewSchema(graphql.SchemaConfig{
+			Query: graphql.NewObject(graphql.ObjectConfig{
+				Name: "Query",
+				Fields: graphql.Fields{
+					"a": &graphql.Field{
+						Type: graphql.String,
+						Args: graphql.FieldConfigArgument{
+							"limit":  &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: &limit},
+							"filter": &graphql.ArgumentConfig{Type: filter},
+						},
+					},
+				},
+			}),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return schema
+	}
+	if s1, s2 := newSchema(), newSchema(); s1.Hash() != s2.Hash() {
+		t.Fatalf("Expected identical default values to have the same hash: %s != %s", s1.Hash(), s2.Hash())
+	}
+}
+
+func TestSchemaVersionMetaField(t *testing.T) {
+	schema := hashTestSchema(t, graphql.String, "", true)
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: `{ _schemaVersion }`,
+	})
+	if len(result.Errors) != 0 {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+	expected := map[string]any{"_schemaVersion": schema.Hash()}
+	if !reflect.DeepEqual(expected, result.Data) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.Data))
+	}
+
+	schema = hashTestSchema(t, graphql.String, "", false)
+	result = graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: `{ _schemaVersion }`,
+	})
+	if len(result.Errors) != 1 {
+		t.Fatalf("Expected an error when the schema version field is not enabled, got %v", result.Errors)
+	}
+}
@@ -0,0 +1,364 @@
+package graphql
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sprucehealth/graphql/language/ast"
+	"github.com/sprucehealth/graphql/language/printer"
+)
+
+// PrintSchema returns the schema in the schema definition language. Types,
+// fields, arguments, and directives are sorted by name so the output is stable
+// for a given schema. Introspection types, built-in scalars, and the specified
+// directives are omitted.
+func PrintSchema(schema *Schema) string {
+	return PrintSchemaWithOptions(schema, PrintSchemaOptions{})
+}
+
+// PrintSchemaOptions controls which parts of the schema are printed by
+// PrintSchemaWithOptions.
+type PrintSchemaOptions struct {
+	// ExcludeDirective if set is the name (without the @) of a directive that marks
+	// private fields (e.g. "internal"). Fields with the directive and the definition
+	// of the directive are omitted.
+	ExcludeDirective string
+	// FieldFilter if set is called for every field of object and interface types
+	// and the field is omitted if it returns false.
+	FieldFilter func(parent Type, field *FieldDefinition) bool
+}
+
+// PrintSchemaWithOptions returns the schema in the schema definition language
+// like PrintSchema but omits the fields excluded by the options. It's meant to
+// generate a public schema from an internal one. Types that are only reachable
+// through omitted fields are omitted as well.
+func PrintSchemaWithOptions(schema *Schema, opts PrintSchemaOptions) string {
+	var includeField func(parent Type, field *FieldDefinition) bool
+	if opts.ExcludeDirective != "" || opts.FieldFilter != nil {
+		includeField = func(parent Type, field *FieldDefinition) bool {
+			for _, d := range field.Directives {
+				if d.Name != nil && d.Name.Value == opts.ExcludeDirective {
+					return false
+				}
+			}
+			return opts.FieldFilter == nil || opts.FieldFilter(parent, field)
+		}
+	}
+
+	var defs []string
+	if def := printSchemaDefinition(schema); def != "" {
+		defs = append(defs, def)
+	}
+
+	directives := append([]*Directive(nil), schema.Directives()...)
+	sort.Slice(directives, func(i, j int) bool {
+		return directives[i].Name < directives[j].Name
+	})
+	for _, d := range directives {
+		if isSpecifiedDirective(d) || d.Name == opts.ExcludeDirective {
+			continue
+		}
+		defs = append(defs, printDirectiveDefinition(d))
+	}
+
+	var omitted map[string]bool
+	if includeField != nil {
+		// Omit the types that are reachable in the full schema but not once fields
+		// are filtered. Types that aren't reachable at all (e.g. added to the schema
+		// explicitly) are kept.
+		all := reachableTypes(schema, nil, opts.ExcludeDirective)
+		filtered := reachableTypes(schema, includeField, opts.ExcludeDirective)
+		omitted = make(map[string]bool)
+		for name := range all {
+			if !filtered[name] {
+				omitted[name] = true
+			}
+		}
+	}
+
+	typeMap := schema.TypeMap()
+	names := make([]string, 0, len(typeMap))
+	for name := range typeMap {
+		if strings.HasPrefix(name, "__") || isBuiltInScalarName(name) || omitted[name] {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		defs = append(defs, printTypeDefinition(typeMap[name], includeField))
+	}
+	return strings.Join(defs, "\n\n") + "\n"
+}
+
+// PrintType returns the definition of a named type in the schema definition
+// language (e.g. to print types that are built outside of a schema). Fields are
+// sorted by name as with PrintSchema.
+func PrintType(t Type) string {
+	return printTypeDefinition(t, nil)
+}
+
+// reachableTypes returns the names of the types reachable from the root types and
+// the directives of the schema through the fields accepted by includeField.
+func reachableTypes(schema *Schema, includeField func(Type, *FieldDefinition) bool, excludeDirective string) map[string]bool {
+	reachable := make(map[string]bool)
+	var visit func(t Type)
+	visitFields := func(parent Type, fields FieldDefinitionMap) {
+		for _, f := range fields {
+			if includeField != nil && !includeField(parent, f) {
+				continue
+			}
+			visit(f.Type)
+			for _, arg := range f.Args {
+				visit(arg.Type)
+			}
+		}
+	}
+	visit = func(t Type) {
+		named := GetNamed(t)
+		if named == nil || reachable[named.String()] {
+			return
+		}
+		reachable[named.String()] = true
+		switch t := named.(type) {
+		case *Object:
+			for _, iface := range t.Interfaces() {
+				visit(iface)
+			}
+			visitFields(t, t.Fields())
+		case *Interface:
+			visitFields(t, t.Fields())
+			for _, pt := range schema.PossibleTypes(t) {
+				visit(pt)
+			}
+		case *Union:
+			for _, member := range t.Types() {
+				visit(member)
+			}
+		case *InputObject:
+			for _, f := range t.Fields() {
+				visit(f.Type)
+			}
+		}
+	}
+	for _, root := range []*Object{schema.QueryType(), schema.MutationType(), schema.SubscriptionType()} {
+		if root != nil {
+			visit(root)
+		}
+	}
+	for _, d := range schema.Directives() {
+		if d.Name == excludeDirective {
+			continue
+		}
+		for _, arg := range d.Args {
+			visit(arg.Type)
+		}
+	}
+	return reachable
+}
+
+func isSpecifiedDirective(d *Directive) bool {
+	for _, sd := range SpecifiedDirectives {
+		if sd.Name == d.Name {
+			return true
+		}
+	}
+	return false
+}
+
+func isBuiltInScalarName(name string) bool {
+	switch name {
+	case "String", "Int", "Float", "Boolean", "ID":
+		return true
+	}
+	return false
+}
+
+// printSchemaDefinition returns the schema definition which is only needed if
+// the schema has a description or the root types don't have the default names.
+func printSchemaDefinition(schema *Schema) string {
+	query, mutation, subscription := schema.QueryType(), schema.MutationType(), schema.SubscriptionType()
+	if schema.Description() == "" &&
+		(query == nil || query.Name() == "Query") &&
+		(mutation == nil || mutation.Name() == "Mutation") &&
+		(subscription == nil || subscription.Name() == "Subscription") {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(printDescription(schema.Description(), ""))
+	b.WriteString("schema {\n")
+	if query != nil {
+		fmt.Fprintf(&b, "  query: %s\n", query.Name())
+	}
+	if mutation != nil {
+		fmt.Fprintf(&b, "  mutation: %s\n", mutation.Name())
+	}
+	if subscription != nil {
+		fmt.Fprintf(&b, "  subscription: %s\n", subscription.Name())
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+func printDirectiveDefinition(d *Directive) string {
+	var b strings.Builder
+	b.WriteString(printDescription(d.Description, ""))
+	b.WriteString("directive @" + d.Name)
+	b.WriteString(printArgumentDefinitions(d.Args))
+	if d.IsRepeatable {
+		b.WriteString(" repeatable")
+	}
+	b.WriteString(" on " + strings.Join(d.Locations, " | "))
+	return b.String()
+}
+
+func printTypeDefinition(t Type, includeField func(Type, *FieldDefinition) bool) string {
+	description := t.Description()
+	if obj, ok := t.(*Object); ok {
+		// Object.Description doesn't return the description
+		description = obj.PrivateDescription
+	}
+	var b strings.Builder
+	b.WriteString(printDescription(description, ""))
+	switch t := t.(type) {
+	case *Scalar:
+		b.WriteString("scalar " + t.Name())
+		if url := t.SpecifiedByURL(); url != "" {
+			fmt.Fprintf(&b, " @specifiedBy(url: %s)", printString(url))
+		}
+	case *Object:
+		b.WriteString("type " + t.Name())
+		if len(t.Interfaces()) != 0 {
+			names := make([]string, len(t.Interfaces()))
+			for i, iface := range t.Interfaces() {
+				names[i] = iface.Name()
+			}
+			sort.Strings(names)
+			b.WriteString(" implements " + strings.Join(names, " & "))
+		}
+		b.WriteString(printFieldDefinitions(t, t.Fields(), includeField))
+	case *Interface:
+		b.WriteString("interface " + t.Name())
+		b.WriteString(printFieldDefinitions(t, t.Fields(), includeField))
+	case *Union:
+		names := make([]string, len(t.Types()))
+		for i, member := range t.Types() {
+			names[i] = member.Name()
+		}
+		sort.Strings(names)
+		b.WriteString("union " + t.Name() + " = " + strings.Join(names, " | "))
+	case *Enum:
+		b.WriteString("enum " + t.Name() + " {\n")
+		values := append([]*EnumValueDefinition(nil), t.Values()...)
+		sort.Slice(values, func(i, j int) bool {
+			return values[i].Name < values[j].Name
+		})
+		for _, v := range values {
+			b.WriteString(printDescription(v.Description, "  "))
+			b.WriteString("  " + v.Name + printDeprecated(v.DeprecationReason) + "\n")
+		}
+		b.WriteString("}")
+	case *InputObject:
+		b.WriteString("input " + t.Name() + " {\n")
+		fields := t.Fields()
+		names := make([]string, 0, len(fields))
+		for name := range fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			f := fields[name]
+			b.WriteString(printDescription(f.Description(), "  "))
+			b.WriteString("  " + printInputValue(name, f.Type, f.DefaultValue, f.DeprecationReason) + "\n")
+		}
+		b.WriteString("}")
+	}
+	return b.String()
+}
+
+func printFieldDefinitions(parent Type, fields FieldDefinitionMap, includeField func(Type, *FieldDefinition) bool) string {
+	names := make([]string, 0, len(fields))
+	for name, f := range fields {
+		if includeField != nil && !includeField(parent, f) {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	b.WriteString(" {\n")
+	for _, name := range names {
+		f := fields[name]
+		b.WriteString(printDescription(f.Description, "  "))
+		fmt.Fprintf(&b, "  %s%s: %s%s\n", name, printArgumentDefinitions(f.Args), f.Type, printFieldDeprecated(f))
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+func printArgumentDefinitions(args []*Argument) string {
+	if len(args) == 0 {
+		return ""
+	}
+	args = append([]*Argument(nil), args...)
+	sort.Slice(args, func(i, j int) bool {
+		return args[i].Name() < args[j].Name()
+	})
+	printed := make([]string, len(args))
+	for i, arg := range args {
+		printed[i] = printInputValue(arg.Name(), arg.Type, arg.DefaultValue, arg.DeprecationReason)
+		if arg.Description() != "" {
+			printed[i] = printString(arg.Description()) + " " + printed[i]
+		}
+	}
+	return "(" + strings.Join(printed, ", ") + ")"
+}
+
+func printInputValue(name string, t Input, defaultValue any, deprecationReason string) string {
+	s := name + ": " + t.String()
+	if defaultValue != nil {
+		if v := astFromValue(defaultValue, t); v != nil {
+			s += " = " + printer.Print(v)
+		}
+	}
+	return s + printDeprecated(deprecationReason)
+}
+
+func printDeprecated(reason string) string {
+	switch reason {
+	case "":
+		return ""
+	case DefaultDeprecationReason:
+		return " @deprecated"
+	}
+	return " @deprecated(reason: " + printString(reason) + ")"
+}
+
+// printFieldDeprecated is printDeprecated for a field that may have a sunset date.
+func printFieldDeprecated(f *FieldDefinition) string {
+	if f.DeprecationSunset == "" {
+		return printDeprecated(f.DeprecationReason)
+	}
+	return " @deprecated(reason: " + printString(f.DeprecationReason) + ", sunset: " + printString(f.DeprecationSunset) + ")"
+}
+
+func printDescription(description, indent string) string {
+	if description == "" {
+		return ""
+	}
+	if !strings.Contains(description, "\n") {
+		return indent + printString(description) + "\n"
+	}
+	lines := strings.Split(strings.ReplaceAll(description, `"""`, `\"""`), "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = indent + line
+		}
+	}
+	return indent + `"""` + "\n" + strings.Join(lines, "\n") + "\n" + indent + `"""` + "\n"
+}
+
+func printString(s string) string {
+	return printer.Print(&ast.StringValue{Value: s})
+}
@@ -0,0 +1,204 @@
+package graphql_test
+
+import (
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/language/ast"
+	"github.com/sprucehealth/graphql/language/parser"
+)
+
+func TestPrintSchema(t *testing.T) {
+	namedType := graphql.NewInterface(graphql.InterfaceConfig{
+		Name: "Named",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.String},
+		},
+	})
+	colorType := graphql.NewEnum(graphql.EnumConfig{
+		Name: "Color",
+		Values: graphql.EnumValueConfigMap{
+			"RED":   &graphql.EnumValueConfig{Value: 0, Description: "The color red"},
+			"GREEN": &graphql.EnumValueConfig{Value: 1, DeprecationReason: graphql.DefaultDeprecationReason},
+		},
+	})
+	filterType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "Filter",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"limit": &graphql.InputObjectFieldConfig{Type: graphql.Int, DefaultValue: 10},
+			"color": &graphql.InputObjectFieldConfig{Type: colorType, DeprecationReason: "Use colors"},
+		},
+	})
+	petType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Pet",
+		Description: "A pet.\nIt has a name.",
+		Interfaces:  []*graphql.Interface{namedType},
+		IsTypeOf:    func(p graphql.IsTypeOfParams) bool { return true },
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.String},
+			"color": &graphql.Field{
+				Type:              colorType,
+				DeprecationReason: "No colors",
+			},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Root",
+			Fields: graphql.Fields{
+				"pets": &graphql.Field{
+					Type: graphql.NewList(graphql.NewNonNull(petType)),
+					Args: graphql.FieldConfigArgument{
+						"filter": &graphql.ArgumentConfig{Type: filterType},
+						"first":  &graphql.ArgumentConfig{Type: graphql.Int, Description: "Max pets"},
+					},
+				},
+				"search": &graphql.Field{Type: graphql.NewUnion(graphql.UnionConfig{
+					Name:  "Result",
+					Types: []*graphql.Object{petType},
+				})},
+				"date": &graphql.Field{Type: graphql.NewScalar(graphql.ScalarConfig{
+					Name:           "Date",
+					SpecifiedByURL: "https://example.com/date",
+					Serialize:      func(v any) any { return v },
+				})},
+			},
+		}),
+		Directives: append([]*graphql.Directive{
+			graphql.NewDirective(graphql.DirectiveConfig{
+				Name:         "tag",
+				Locations:    []string{graphql.DirectiveLocationField, graphql.DirectiveLocationFragmentSpread},
+				IsRepeatable: true,
+				Args: graphql.FieldConfigArgument{
+					"name": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+			}),
+		}, graphql.SpecifiedDirectives...),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `schema {
+  query: Root
+}
+
+directive @tag(name: String!) repeatable on FIELD | FRAGMENT_SPREAD
+
+enum Color {
+  GREEN @deprecated
+  "The color red"
+  RED
+}
+
+scalar Date @specifiedBy(url: "https://example.com/date")
+
+input Filter {
+  color: Color @deprecated(reason: "Use colors")
+  limit: Int = 10
+}
+
+interface Named {
+  name: String
+}
+
+"""
+A pet.
+It has a name.
+"""
+type Pet implements Named {
+  color: Color @deprecated(reason: "No colors")
+  name: String
+}
+
+union Result = Pet
+
+type Root {
+  date: Date
+  pets(filter: Filter, "Max pets" first: Int): [Pet!]
+  search: Result
+}
+`
+	sdl := graphql.PrintSchema(&schema)
+	if sdl != expected {
+		t.Fatalf("Expected:\n%s\nGot:\n%s", expected, sdl)
+	}
+	if _, err := parser.Parse(parser.ParseParams{Source: sdl}); err != nil {
+		t.Fatalf("Failed to parse printed schema: %s", err)
+	}
+	breaking, dangerous, err := graphql.FindBreakingChanges(sdl, &schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(breaking) != 0 || len(dangerous) != 0 {
+		t.Fatalf("Expected no changes against the printed schema, got %v %v", breaking, dangerous)
+	}
+}
+
+func TestPrintSchemaWithOptions(t *testing.T) {
+	internal := []*ast.Directive{{Name: &ast.Name{Value: "internal"}}}
+	auditType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Audit",
+		Fields: graphql.Fields{
+			"actor": &graphql.Field{Type: graphql.String},
+		},
+	})
+	userType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"name":  &graphql.Field{Type: graphql.String},
+			"audit": &graphql.Field{Type: auditType, Directives: internal},
+			"email": &graphql.Field{Type: graphql.String},
+		},
+	})
+	extraType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Extra",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.ID},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"user":   &graphql.Field{Type: userType},
+				"audits": &graphql.Field{Type: graphql.NewList(auditType), Directives: internal},
+			},
+		}),
+		Types: []graphql.Type{extraType},
+		Directives: append([]*graphql.Directive{
+			graphql.NewDirective(graphql.DirectiveConfig{
+				Name:      "internal",
+				Locations: []string{graphql.DirectiveLocationFieldDefinition},
+			}),
+		}, graphql.SpecifiedDirectives...),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `type Extra {
+  id: ID
+}
+
+type Query {
+  user: User
+}
+
+type User {
+  name: String
+}
+`
+	sdl := graphql.PrintSchemaWithOptions(&schema, graphql.PrintSchemaOptions{
+		ExcludeDirective: "internal",
+		FieldFilter: func(parent graphql.Type, field *graphql.FieldDefinition) bool {
+			return !(parent.Name() == "User" && field.Name == "email")
+		},
+	})
+	if sdl != expected {
+		t.Fatalf("Expected:\n%s\nGot:\n%s", expected, sdl)
+	}
+	if _, err := parser.Parse(parser.ParseParams{Source: sdl}); err != nil {
+		t.Fatalf("Failed to parse printed schema: %s", err)
+	}
+}
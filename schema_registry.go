@@ -0,0 +1,63 @@
+package graphql
+
+import (
+	"context"
+)
+
+// SchemaRecord is a version of a schema published to a schema registry.
+type SchemaRecord struct {
+	// SDL is the schema in the schema definition language as returned by PrintSchema.
+	SDL string `json:"sdl"`
+	// Hash is the hash of the schema as returned by Schema.Hash.
+	Hash string `json:"hash"`
+}
+
+// SchemaRegistry stores the published versions of a schema. Implementations
+// are clients of an external registry (e.g. Apollo Studio or an internal service).
+type SchemaRegistry interface {
+	// Publish stores a new version of the schema.
+	Publish(ctx context.Context, record *SchemaRecord) error
+	// Latest returns the most recently published version of the schema or nil
+	// if no version has been published.
+	Latest(ctx context.Context) (*SchemaRecord, error)
+}
+
+// NewSchemaRecord returns the record of a schema to publish to a registry.
+func NewSchemaRecord(schema *Schema) *SchemaRecord {
+	return &SchemaRecord{
+		SDL:  PrintSchema(schema),
+		Hash: schema.Hash(),
+	}
+}
+
+// PublishSchema publishes the schema to the registry unless the latest published
+// version has the same hash. It returns true if a new version was published. It's
+// meant to be called on startup.
+func PublishSchema(ctx context.Context, registry SchemaRegistry, schema *Schema) (bool, error) {
+	latest, err := registry.Latest(ctx)
+	if err != nil {
+		return false, err
+	}
+	if latest != nil && latest.Hash == schema.Hash() {
+		return false, nil
+	}
+	if err := registry.Publish(ctx, NewSchemaRecord(schema)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// CompareSchema compares the schema against the latest version published to the
+// registry. It returns the breaking and dangerous changes as FindBreakingChanges
+// does. Nothing is returned if no version has been published or if the latest
+// version has the same hash. It's meant to be called before deploying.
+func CompareSchema(ctx context.Context, registry SchemaRegistry, schema *Schema) (breaking, dangerous []SchemaChange, err error) {
+	latest, err := registry.Latest(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if latest == nil || latest.Hash == schema.Hash() {
+		return nil, nil, nil
+	}
+	return FindBreakingChanges(latest.SDL, schema)
+}
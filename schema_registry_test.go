@@ -0,0 +1,68 @@
+package graphql_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+)
+
+type testSchemaRegistry struct {
+	records []*graphql.SchemaRecord
+}
+
+func (r *testSchemaRegistry) Publish(ctx context.Context, record *graphql.SchemaRecord) error {
+	r.records = append(r.records, record)
+	return nil
+}
+
+func (r *testSchemaRegistry) Latest(ctx context.Context) (*graphql.SchemaRecord, error) {
+	if len(r.records) == 0 {
+		return nil, nil
+	}
+	return r.records[len(r.records)-1], nil
+}
+
+func TestSchemaRegistry(t *testing.T) {
+	newSchema := func(fields graphql.Fields) *graphql.Schema {
+		schema, err := graphql.NewSchema(graphql.SchemaConfig{
+			Query: graphql.NewObject(graphql.ObjectConfig{Name: "Query", Fields: fields}),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &schema
+	}
+	v1 := newSchema(graphql.Fields{
+		"name": &graphql.Field{Type: graphql.String},
+		"age":  &graphql.Field{Type: graphql.Int},
+	})
+	v2 := newSchema(graphql.Fields{
+		"name": &graphql.Field{Type: graphql.String},
+	})
+	ctx := context.Background()
+	registry := &testSchemaRegistry{}
+
+	// Nothing to compare against before the first version is published
+	if breaking, _, err := graphql.CompareSchema(ctx, registry, v2); err != nil || len(breaking) != 0 {
+		t.Fatalf("Expected no changes, got %v %v", breaking, err)
+	}
+	if published, err := graphql.PublishSchema(ctx, registry, v1); err != nil || !published {
+		t.Fatalf("Expected schema to be published, got %t %v", published, err)
+	}
+	if r := registry.records[0]; r.Hash != v1.Hash() || r.SDL != graphql.PrintSchema(v1) {
+		t.Fatalf("Unexpected record %+v", r)
+	}
+	// The same schema isn't published again
+	if published, err := graphql.PublishSchema(ctx, registry, v1); err != nil || published {
+		t.Fatalf("Expected schema to not be published, got %t %v", published, err)
+	}
+
+	breaking, _, err := graphql.CompareSchema(ctx, registry, v2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(breaking) != 1 || breaking[0].Type != graphql.SchemaChangeFieldRemoved {
+		t.Fatalf("Expected removed field, got %+v", breaking)
+	}
+}
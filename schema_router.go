@@ -0,0 +1,101 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sprucehealth/graphql/gqlerrors"
+	"github.com/sprucehealth/graphql/language/ast"
+)
+
+// SchemaRoute selects the schema of the operations that match it. An operation
+// matches if it has the directive or its name starts with the prefix.
+type SchemaRoute struct {
+	Schema *Schema
+	// Directive if set is the name of a directive (e.g. "internal" for
+	// `query Q @internal { ... }`) that selects the schema. The directive is
+	// removed from the operation before it's validated so the schema doesn't need
+	// to declare it.
+	Directive string
+	// OperationNamePrefix if set is the prefix of the names of the operations
+	// that select the schema.
+	OperationNamePrefix string
+}
+
+// SchemaRouter selects the schema of a request from its operation (e.g. while two
+// generations of a schema coexist during a migration). The operation is parsed
+// once and validated and executed with the selected schema.
+type SchemaRouter struct {
+	// Routes are tried in order and the first that matches the operation selects
+	// the schema.
+	Routes []SchemaRoute
+	// Default is the schema of the operations that match no route. If it's nil
+	// such operations fail.
+	Default *Schema
+}
+
+// Route returns the schema for the operation of the document with the name, or
+// the only operation if the name is empty, and the route that matched it or nil
+// if none did.
+func (r *SchemaRouter) Route(doc *ast.Document, operationName string) (*Schema, *SchemaRoute) {
+	operation, _ := selectOperation(doc, operationName)
+	if op, ok := operation.(*ast.OperationDefinition); ok {
+		for i, route := range r.Routes {
+			if route.matches(op) {
+				return route.Schema, &r.Routes[i]
+			}
+		}
+	}
+	return r.Default, nil
+}
+
+func (route *SchemaRoute) matches(op *ast.OperationDefinition) bool {
+	if route.Directive != "" {
+		for _, d := range op.Directives {
+			if d.Name != nil && d.Name.Value == route.Directive {
+				return true
+			}
+		}
+	}
+	return route.OperationNamePrefix != "" && op.Name != nil && strings.HasPrefix(op.Name.Value, route.OperationNamePrefix)
+}
+
+// rewriter returns a DocumentRewriterFn that sets the schema the document is
+// validated with to the schema of its operation and removes the directive of the
+// route before calling next if not nil.
+func (r *SchemaRouter) rewriter(schema *Schema, operationName string, next DocumentRewriterFn) DocumentRewriterFn {
+	return func(ctx context.Context, doc *ast.Document) (*ast.Document, error) {
+		routed, route := r.Route(doc, operationName)
+		if routed == nil {
+			name := "(anonymous)"
+			operation, _ := selectOperation(doc, operationName)
+			if op, ok := operation.(*ast.OperationDefinition); ok && op.Name != nil {
+				name = op.Name.Value
+			}
+			return nil, gqlerrors.NewFormattedError(fmt.Sprintf("No schema for operation %s.", name))
+		}
+		*schema = *routed
+		if route != nil && route.Directive != "" {
+			for _, def := range doc.Definitions {
+				if op, ok := def.(*ast.OperationDefinition); ok {
+					op.Directives = removeDirective(op.Directives, route.Directive)
+				}
+			}
+		}
+		if next != nil {
+			return next(ctx, doc)
+		}
+		return doc, nil
+	}
+}
+
+func removeDirective(directives []*ast.Directive, name string) []*ast.Directive {
+	kept := directives[:0]
+	for _, d := range directives {
+		if d.Name == nil || d.Name.Value != name {
+			kept = append(kept, d)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,62 @@
+package graphql_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+func TestDo_SchemaRouter(t *testing.T) {
+	newSchema := func(generation string) *graphql.Schema {
+		schema := testSchema(t, &graphql.Field{
+			Type: graphql.String,
+			Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+				return generation, nil
+			},
+		})
+		return &schema
+	}
+	v1, v2, internal := newSchema("v1"), newSchema("v2"), newSchema("internal")
+	router := &graphql.SchemaRouter{
+		Routes: []graphql.SchemaRoute{
+			{Schema: internal, Directive: "internal"},
+			{Schema: v2, OperationNamePrefix: "V2_"},
+		},
+		Default: v1,
+	}
+	cases := []struct {
+		query         string
+		operationName string
+		expected      string
+	}{
+		{query: `{ test }`, expected: "v1"},
+		{query: `query V2_Test { test }`, expected: "v2"},
+		{query: `query V2_Test @internal { test }`, expected: "internal"},
+		{query: `query A { test } query V2_B { test }`, operationName: "V2_B", expected: "v2"},
+		{query: `query A { test } query V2_B { test }`, operationName: "A", expected: "v1"},
+	}
+	for _, c := range cases {
+		result := graphql.Do(context.Background(), graphql.Params{
+			RequestString: c.query,
+			OperationName: c.operationName,
+			SchemaRouter:  router,
+		})
+		expected := &graphql.Result{Data: map[string]any{"test": c.expected}}
+		if !reflect.DeepEqual(expected, result) {
+			t.Errorf("%s: unexpected result, Diff: %v", c.query, testutil.Diff(expected, result))
+		}
+	}
+
+	// Without a default schema operations that match no route fail
+	router.Default = nil
+	result := graphql.Do(context.Background(), graphql.Params{
+		RequestString: `query Test { test }`,
+		SchemaRouter:  router,
+	})
+	if len(result.Errors) != 1 || result.Errors[0].Message != "No schema for operation Test." {
+		t.Fatalf("Expected routing error, got %+v", result)
+	}
+}
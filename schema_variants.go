@@ -0,0 +1,64 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sprucehealth/graphql/language/ast"
+)
+
+// SchemaResolverFn returns the schema to execute a request with (e.g. the variant
+// of the schema with the types enabled for the tenant of the request).
+type SchemaResolverFn func(ctx context.Context) (*Schema, error)
+
+// SchemaVariants builds and caches variants of a schema keyed by a variant name
+// (e.g. the visibility of a TypeRegistry or a set of enabled features). Its
+// Resolve method is a SchemaResolverFn.
+type SchemaVariants struct {
+	// Variant returns the name of the variant for the request. It's required.
+	Variant func(ctx context.Context) (string, error)
+	// Build returns the schema of a variant. It's called once per variant unless
+	// it fails. It's required.
+	Build func(variant string) (Schema, error)
+
+	mu       sync.Mutex
+	variants map[string]*Schema
+}
+
+// Resolve returns the schema of the variant of the request building it the first
+// time the variant is requested.
+func (v *SchemaVariants) Resolve(ctx context.Context) (*Schema, error) {
+	variant, err := v.Variant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if schema, ok := v.variants[variant]; ok {
+		return schema, nil
+	}
+	schema, err := v.Build(variant)
+	if err != nil {
+		return nil, err
+	}
+	if v.variants == nil {
+		v.variants = make(map[string]*Schema)
+	}
+	v.variants[variant] = &schema
+	return &schema, nil
+}
+
+// schemaDocumentCache keys a document cache by schema hash so that a cache can
+// be shared by requests executed with different schemas.
+type schemaDocumentCache struct {
+	cache DocumentCache
+	hash  string
+}
+
+func (c schemaDocumentCache) Get(query string) (*ast.Document, bool) {
+	return c.cache.Get(c.hash + "\x00" + query)
+}
+
+func (c schemaDocumentCache) Add(query string, doc *ast.Document) {
+	c.cache.Add(c.hash+"\x00"+query, doc)
+}
@@ -0,0 +1,95 @@
+package graphql_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/gqlerrors"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+type tenantKey struct{}
+
+func TestDo_SchemaResolver(t *testing.T) {
+	var builds []string
+	variants := &graphql.SchemaVariants{
+		Variant: func(ctx context.Context) (string, error) {
+			tenant, _ := ctx.Value(tenantKey{}).(string)
+			if tenant == "" {
+				return "", errors.New("unknown tenant")
+			}
+			return tenant, nil
+		},
+		Build: func(variant string) (graphql.Schema, error) {
+			builds = append(builds, variant)
+			fields := graphql.Fields{
+				"name": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						return variant, nil
+					},
+				},
+			}
+			if variant == "beta" {
+				fields["beta"] = &graphql.Field{
+					Type: graphql.Boolean,
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						return true, nil
+					},
+				}
+			}
+			return graphql.NewSchema(graphql.SchemaConfig{
+				Query: graphql.NewObject(graphql.ObjectConfig{
+					Name:   "Query",
+					Fields: fields,
+				}),
+			})
+		},
+	}
+	cache := &graphql.MapDocumentCache{MaxSize: 10}
+	do := func(tenant, query string) *graphql.Result {
+		ctx := context.Background()
+		if tenant != "" {
+			ctx = context.WithValue(ctx, tenantKey{}, tenant)
+		}
+		return graphql.Do(ctx, graphql.Params{
+			SchemaResolver: variants.Resolve,
+			RequestString:  query,
+			DocumentCache:  cache,
+		})
+	}
+
+	result := do("beta", `{ name beta }`)
+	expected := &graphql.Result{Data: map[string]any{"name": "beta", "beta": true}}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	// The document cached for the beta schema isn't used for the stable schema
+	result = do("stable", `{ name beta }`)
+	if len(result.Errors) != 1 || result.Errors[0].Message != `Cannot query field "beta" on type "Query".` {
+		t.Fatalf("Expected validation error, got %+v", result)
+	}
+	result = do("stable", `{ name }`)
+	expected = &graphql.Result{Data: map[string]any{"name": "stable"}}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	if cache.Len() != 2 {
+		t.Fatalf("Expected 2 cached documents, got %d", cache.Len())
+	}
+
+	// Variants are only built once
+	do("beta", `{ name }`)
+	if expected := []string{"beta", "stable"}; !reflect.DeepEqual(expected, builds) {
+		t.Fatalf("Expected builds %v, got %v", expected, builds)
+	}
+
+	result = do("", `{ name }`)
+	if len(result.Errors) != 1 || result.Errors[0].Message != "unknown tenant" || result.Errors[0].Type != gqlerrors.ErrorTypeInternal || result.Data != nil {
+		t.Fatalf("Expected schema resolver error, got %+v", result)
+	}
+}
@@ -0,0 +1,52 @@
+package graphql
+
+import "github.com/sprucehealth/graphql/language/ast"
+
+// SelectedFieldNames returns the names of the fields that are executed below the
+// field being resolved when its value has the given runtime type, in the order
+// they're requested. Fragments are only included if their type condition applies
+// to the runtime type and fields excluded by @skip or @include are omitted. Fields
+// selected more than once (e.g. under different aliases) are only returned once.
+//
+// It's meant for resolvers of interface and union fields that know the concrete
+// type before loading a value. For example a selection such as
+// "{ __typename ... on Dog { name } ... on Cat { meows } }" only needs __typename
+// when the runtime type is neither Dog nor Cat.
+func (info ResolveInfo) SelectedFieldNames(runtimeType *Object) []string {
+	eCtx := &ExecutionContext{
+		Schema:         info.Schema,
+		Fragments:      info.Fragments,
+		VariableValues: info.VariableValues,
+	}
+	fields := make(map[string][]*ast.Field)
+	visitedFragmentNames := make(map[string]struct{})
+	var responseNames []string
+	for _, fieldAST := range info.FieldASTs {
+		if fieldAST == nil || fieldAST.SelectionSet == nil {
+			continue
+		}
+		collectFields(CollectFieldsParams{
+			ExeContext:           eCtx,
+			RuntimeType:          runtimeType,
+			SelectionSet:         fieldAST.SelectionSet,
+			Fields:               fields,
+			VisitedFragmentNames: visitedFragmentNames,
+			ResponseNames:        &responseNames,
+		})
+	}
+	names := make([]string, 0, len(responseNames))
+	seen := make(map[string]struct{}, len(responseNames))
+	for _, responseName := range responseNames {
+		fieldAST := fields[responseName][0]
+		if fieldAST.Name == nil {
+			continue
+		}
+		name := fieldAST.Name.Value
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		names = append(names, name)
+	}
+	return names
+}
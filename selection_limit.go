@@ -0,0 +1,131 @@
+package graphql
+
+import (
+	"math"
+
+	"github.com/sprucehealth/graphql/gqlerrors"
+	"github.com/sprucehealth/graphql/language/ast"
+)
+
+// SelectionLimitError is returned when an operation selects more fields than
+// allowed once its fragments are expanded.
+type SelectionLimitError struct {
+	// Operation is the name of the operation. It's empty for anonymous operations.
+	Operation string
+	// Limit is the maximum number of expanded selections.
+	Limit int
+}
+
+func (e *SelectionLimitError) Error() string {
+	return gqlerrors.CodeSelectionLimit.Message(e.Limit)
+}
+
+// CheckExpandedSelections returns a *SelectionLimitError if any operation of the
+// document selects more than max fields once fragment spreads are expanded. A
+// fragment is counted every time it's spread, so small documents can expand to
+// a very large number of fields. The count is memoized per fragment which keeps
+// the check cheap regardless of how large the expansion is.
+func CheckExpandedSelections(doc *ast.Document, max int) error {
+	fragments := make(map[string]*ast.FragmentDefinition)
+	for _, def := range doc.Definitions {
+		if frag, ok := def.(*ast.FragmentDefinition); ok && frag.Name != nil {
+			fragments[frag.Name.Value] = frag
+		}
+	}
+	counter := newSelectionCounter(fragments, max)
+	for _, def := range doc.Definitions {
+		if op, ok := def.(*ast.OperationDefinition); ok {
+			if err := checkExpandedSelections(counter, op); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func checkExpandedSelections(counter *selectionCounter, operation ast.Definition) error {
+	if counter.count(operation.GetSelectionSet()) <= counter.max {
+		return nil
+	}
+	err := &SelectionLimitError{Limit: counter.max}
+	if op, ok := operation.(*ast.OperationDefinition); ok && op.Name != nil {
+		err.Operation = op.Name.Value
+	}
+	return err
+}
+
+// selectionLimitError returns a BAD_QUERY error for a SelectionLimitError.
+func selectionLimitError(err error) gqlerrors.FormattedError {
+	e := gqlerrors.NewError(gqlerrors.ErrorTypeBadQuery, err.Error(), nil, "", nil, nil, err)
+	e.Code = gqlerrors.CodeSelectionLimit
+	return gqlerrors.FormatError(e)
+}
+
+// selectionCounter counts the fields in selection sets including the fields of
+// spread fragments. Counts are capped at max+1 to avoid overflowing.
+type selectionCounter struct {
+	fragments map[string]*ast.FragmentDefinition
+	max       int
+	memo      map[string]int
+	// visiting tracks the fragments being counted in case the document hasn't
+	// been validated and contains cycles.
+	visiting map[string]bool
+}
+
+func newSelectionCounter(fragments map[string]*ast.FragmentDefinition, max int) *selectionCounter {
+	if max <= 0 || max >= math.MaxInt {
+		max = math.MaxInt - 1
+	}
+	return &selectionCounter{
+		fragments: fragments,
+		max:       max,
+		memo:      make(map[string]int),
+		visiting:  make(map[string]bool),
+	}
+}
+
+func (c *selectionCounter) add(a, b int) int {
+	if b > c.max || a > c.max-b {
+		return c.max + 1
+	}
+	return a + b
+}
+
+func (c *selectionCounter) count(ss *ast.SelectionSet) int {
+	if ss == nil {
+		return 0
+	}
+	var n int
+	for _, sel := range ss.Selections {
+		switch sel := sel.(type) {
+		case *ast.Field:
+			n = c.add(n, c.add(1, c.count(sel.SelectionSet)))
+		case *ast.InlineFragment:
+			n = c.add(n, c.count(sel.SelectionSet))
+		case *ast.FragmentSpread:
+			if sel.Name == nil || c.visiting[sel.Name.Value] {
+				continue
+			}
+			n = c.add(n, c.countFragment(sel.Name.Value))
+		}
+		if n > c.max {
+			return n
+		}
+	}
+	return n
+}
+
+func (c *selectionCounter) countFragment(name string) int {
+	if n, ok := c.memo[name]; ok {
+		return n
+	}
+	fragment := c.fragments[name]
+	if fragment == nil {
+		return 0
+	}
+	c.visiting[name] = true
+	n := c.count(fragment.SelectionSet)
+	c.visiting[name] = false
+	c.memo[name] = n
+	return n
+}
@@ -0,0 +1,69 @@
+package graphql_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/gqlerrors"
+	"github.com/sprucehealth/graphql/language/parser"
+)
+
+func TestMaxExpandedSelections(t *testing.T) {
+	schema := testSchema(t, &graphql.Field{
+		Type: graphql.String,
+		Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+			return "ok", nil
+		},
+	})
+
+	// Every fragment spreads the previous one twice which expands to 2^40 fields
+	var b strings.Builder
+	b.WriteString("query Big { ...F40 }\nfragment F0 on Query { test }\n")
+	for i := 1; i <= 40; i++ {
+		fmt.Fprintf(&b, "fragment F%d on Query { ...F%d a%d: test ...F%d }\n", i, i-1, i, i-1)
+	}
+	query := b.String()
+
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema:                schema,
+		RequestString:         query,
+		MaxExpandedSelections: 1000,
+	})
+	if len(result.Errors) != 1 {
+		t.Fatalf("Expected 1 error, got %+v", result.Errors)
+	}
+	var limitErr *graphql.SelectionLimitError
+	if e := result.Errors[0]; e.Type != gqlerrors.ErrorTypeBadQuery || !errors.As(e.OriginalError, &limitErr) {
+		t.Fatalf("Unexpected error %+v", e)
+	}
+	if limitErr.Limit != 1000 || limitErr.Operation != "Big" {
+		t.Fatalf("Unexpected limit error %+v", limitErr)
+	}
+
+	result = graphql.Do(context.Background(), graphql.Params{
+		Schema:                schema,
+		RequestString:         `{ test ...F } fragment F on Query { a: test b: test }`,
+		MaxExpandedSelections: 3,
+	})
+	if len(result.Errors) != 0 {
+		t.Fatalf("Unexpected errors %+v", result.Errors)
+	}
+
+	// Execution checks the selected operation
+	doc, err := parser.Parse(parser.ParseParams{Source: query})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result = graphql.Execute(context.Background(), graphql.ExecuteParams{
+		Schema:                schema,
+		AST:                   doc,
+		MaxExpandedSelections: 1000,
+	})
+	if len(result.Errors) != 1 || !errors.As(result.Errors[0].OriginalError, &limitErr) {
+		t.Fatalf("Expected selection limit error, got %+v", result.Errors)
+	}
+}
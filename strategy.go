@@ -0,0 +1,108 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/sprucehealth/graphql/language/ast"
+)
+
+// ExecutionStrategy decides how the fields of a selection set are executed. It's
+// used for every selection set of an operation, including the selection sets of
+// nested objects. Strategies are selected per operation type with
+// ExecuteParams.ExecutionStrategies.
+type ExecutionStrategy interface {
+	// ExecuteFields must call executeField once for every response name and must
+	// not return before all the calls have returned. The response names are in the
+	// order they're requested when the operation is executed with OrderedData or
+	// Deterministic and in map order otherwise. The result data has the same shape
+	// regardless of the order in which fields are executed. A strategy is free to
+	// choose the order, the context, and the goroutine of the calls, and calls may
+	// overlap (e.g. to execute fields in parallel). Once a field fails in a way
+	// that nulls the whole selection set (e.g. a non-null field resolves to null)
+	// the remaining calls return immediately and the failure is raised once
+	// ExecuteFields returns. Resolvers of fields executed concurrently must be safe
+	// for concurrent use.
+	ExecuteFields(ctx context.Context, responseNames []string, executeField func(ctx context.Context, responseName string))
+}
+
+// SerialExecutionStrategy executes fields one after the other in the order of the
+// response names. It's the strategy used when none is configured.
+type SerialExecutionStrategy struct{}
+
+var _ ExecutionStrategy = SerialExecutionStrategy{}
+
+// ExecuteFields implements ExecutionStrategy.
+func (SerialExecutionStrategy) ExecuteFields(ctx context.Context, responseNames []string, executeField func(ctx context.Context, responseName string)) {
+	for _, responseName := range responseNames {
+		executeField(ctx, responseName)
+	}
+}
+
+// executeFieldsWithStrategy executes the fields with the strategy of the execution
+// context and builds the result data in the order of the response names.
+func executeFieldsWithStrategy(ctx context.Context, p ExecuteFieldsParams, executeField func(context.Context, string, []*ast.Field) (any, bool)) *Result {
+	responseNames := p.ResponseNames
+	if responseNames == nil {
+		responseNames = make([]string, 0, len(p.Fields))
+		for responseName := range p.Fields {
+			responseNames = append(responseNames, responseName)
+		}
+	}
+	type fieldResult struct {
+		resolved any
+		executed atomic.Bool
+		ok       bool
+	}
+	results := make(map[string]*fieldResult, len(responseNames))
+	for _, responseName := range responseNames {
+		results[responseName] = &fieldResult{}
+	}
+	// A panic nulls the selection set so it's recovered in the goroutine of the
+	// field and raised again in the goroutine of the selection set.
+	var failed atomic.Bool
+	var failure any
+	var failOnce sync.Once
+	p.ExecutionContext.strategy.ExecuteFields(ctx, responseNames, func(ctx context.Context, responseName string) {
+		r := results[responseName]
+		if r == nil || failed.Load() || !r.executed.CompareAndSwap(false, true) {
+			return
+		}
+		defer func() {
+			if v := recover(); v != nil {
+				failOnce.Do(func() {
+					failure = v
+					failed.Store(true)
+				})
+			}
+		}()
+		r.resolved, r.ok = executeField(ctx, responseName, p.Fields[responseName])
+	})
+	if failed.Load() {
+		panic(failure)
+	}
+
+	if p.ExecutionContext.OrderedData && p.ResponseNames != nil {
+		orderedResults := make(OrderedMap, 0, len(responseNames))
+		for _, responseName := range responseNames {
+			if r := results[responseName]; r.ok {
+				orderedResults = append(orderedResults, KeyValue{Key: responseName, Value: r.resolved})
+			}
+		}
+		return &Result{
+			Data:   orderedResults,
+			Errors: p.ExecutionContext.errors(),
+		}
+	}
+	finalResults := make(map[string]any, len(responseNames))
+	for _, responseName := range responseNames {
+		if r := results[responseName]; r.ok {
+			finalResults[responseName] = r.resolved
+		}
+	}
+	return &Result{
+		Data:   finalResults,
+		Errors: p.ExecutionContext.errors(),
+	}
+}
@@ -0,0 +1,214 @@
+package graphql_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/language/ast"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+type reverseStrategyKey struct{}
+
+// reverseStrategy executes fields in reverse order and marks the context.
+type reverseStrategy struct{}
+
+func (reverseStrategy) ExecuteFields(ctx context.Context, responseNames []string, executeField func(ctx context.Context, responseName string)) {
+	ctx = context.WithValue(ctx, reverseStrategyKey{}, true)
+	for i := len(responseNames) - 1; i >= 0; i-- {
+		executeField(ctx, responseNames[i])
+	}
+}
+
+func TestExecutionStrategies(t *testing.T) {
+	var calls []string
+	resolve := func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+		name := p.Info.FieldASTs[0].Alias.Value
+		if ctx.Value(reverseStrategyKey{}) != nil {
+			name += "*"
+		}
+		calls = append(calls, name)
+		return name, nil
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"f": &graphql.Field{Type: graphql.String, Resolve: resolve},
+			},
+		}),
+		Mutation: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Mutation",
+			Fields: graphql.Fields{
+				"f": &graphql.Field{Type: graphql.String, Resolve: resolve},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	strategies := map[string]graphql.ExecutionStrategy{
+		ast.OperationTypeQuery:    reverseStrategy{},
+		ast.OperationTypeMutation: graphql.SerialExecutionStrategy{},
+	}
+
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema:              schema,
+		RequestString:       `{ a: f b: f c: f }`,
+		OrderedData:         true,
+		ExecutionStrategies: strategies,
+	})
+	expected := &graphql.Result{
+		Data: graphql.OrderedMap{
+			{Key: "a", Value: "a*"},
+			{Key: "b", Value: "b*"},
+			{Key: "c", Value: "c*"},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	if want := []string{"c*", "b*", "a*"}; !reflect.DeepEqual(calls, want) {
+		t.Fatalf("Expected calls %v, got %v", want, calls)
+	}
+
+	calls = nil
+	result = graphql.Do(context.Background(), graphql.Params{
+		Schema:              schema,
+		RequestString:       `mutation { a: f b: f c: f }`,
+		Deterministic:       true,
+		ExecutionStrategies: strategies,
+	})
+	expected = &graphql.Result{
+		Data: map[string]any{"a": "a", "b": "b", "c": "c"},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(calls, want) {
+		t.Fatalf("Expected calls %v, got %v", want, calls)
+	}
+}
+
+// parallelStrategy executes every field in its own goroutine.
+type parallelStrategy struct{}
+
+func (parallelStrategy) ExecuteFields(ctx context.Context, responseNames []string, executeField func(ctx context.Context, responseName string)) {
+	var wg sync.WaitGroup
+	for _, responseName := range responseNames {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			executeField(ctx, responseName)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestExecutionStrategies_Concurrent(t *testing.T) {
+	var itemsCalls atomic.Int32
+	named := graphql.NewInterface(graphql.InterfaceConfig{
+		Name:   "Named",
+		Fields: graphql.Fields{"name": &graphql.Field{Type: graphql.String}},
+	})
+	item := graphql.NewObject(graphql.ObjectConfig{
+		Name:       "Item",
+		Interfaces: []*graphql.Interface{named},
+		IsTypeOf: func(p graphql.IsTypeOfParams) bool {
+			_, ok := p.Value.(map[string]any)
+			return ok
+		},
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.String},
+			"fail": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+					return nil, errors.New("failed")
+				},
+			},
+			"required": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+					return nil, errors.New("required failed")
+				},
+			},
+			"items": &graphql.Field{
+				Type: graphql.NewList(graphql.Int),
+				Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+					itemsCalls.Add(1)
+					return []int{1, 2, 3}, nil
+				},
+			},
+			"total": &graphql.Field{
+				Type:      graphql.Int,
+				DependsOn: []string{"items"},
+				Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+					var total int
+					for _, v := range p.Dependencies["items"].([]int) {
+						total += v
+					}
+					return total, nil
+				},
+			},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"item": &graphql.Field{
+					Type: named,
+					Args: graphql.FieldConfigArgument{"name": &graphql.ArgumentConfig{Type: graphql.String}},
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						return map[string]any{"name": p.Args["name"]}, nil
+					},
+				},
+			},
+		}),
+		Types: []graphql.Type{item},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	strategies := map[string]graphql.ExecutionStrategy{ast.OperationTypeQuery: parallelStrategy{}}
+
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema: schema,
+		RequestString: `{
+			a: item(name: "a") { name ... on Item { fail total items } }
+			b: item(name: "b") { name ... on Item { fail total } }
+			c: item(name: "c") { name ... on Item { required } }
+			d: item(name: "d") { name ... on Item { x: fail y: fail } }
+		}`,
+		DedupeAliasedFields: true,
+		ExecutionStrategies: strategies,
+	})
+	expected := map[string]any{
+		"a": map[string]any{"name": "a", "fail": nil, "total": 6, "items": []any{1, 2, 3}},
+		"b": map[string]any{"name": "b", "fail": nil, "total": 6},
+		"c": nil,
+		"d": map[string]any{"name": "d", "x": nil, "y": nil},
+	}
+	if !reflect.DeepEqual(expected, result.Data) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.Data))
+	}
+	var messages []string
+	for _, err := range result.Errors {
+		messages = append(messages, err.Message)
+	}
+	sort.Strings(messages)
+	expectedMessages := []string{"failed", "failed", "failed", "required failed"}
+	if !reflect.DeepEqual(expectedMessages, messages) {
+		t.Fatalf("Expected errors %v, got %v", expectedMessages, messages)
+	}
+	// The value of a field is shared with the fields that depend on it
+	if n := itemsCalls.Load(); n != 2 {
+		t.Fatalf("Expected items to be resolved once per item, got %d", n)
+	}
+}
@@ -0,0 +1,220 @@
+package graphql
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"sort"
+	"unicode/utf8"
+)
+
+// StreamEncoding is how the content of an io.Reader returned by the resolver of a
+// String field is encoded in the response. See ExecuteParams.StreamStrings.
+type StreamEncoding int
+
+const (
+	// StreamEncodingNone doesn't stream readers. It's the default.
+	StreamEncodingNone StreamEncoding = iota
+	// StreamEncodingText encodes the content, which must be UTF-8 text, as a
+	// JSON string.
+	StreamEncodingText
+	// StreamEncodingBase64 encodes the content with standard base64 encoding.
+	StreamEncodingBase64
+)
+
+// streamChunkSize is the size of the chunks read from a streamed reader.
+const streamChunkSize = 32 << 10
+
+// StreamedString is the value of a String field that resolved to an io.Reader when
+// streaming is enabled. The content of the reader is only read once the value is
+// encoded: WriteJSON streams it to the writer while encoding/json buffers it. The
+// reader is closed after it's read if it's an io.Closer. As the reader can only be
+// read once, the value can only be encoded once.
+type StreamedString struct {
+	Reader   io.Reader
+	Encoding StreamEncoding
+}
+
+// MarshalJSON implements json.Marshaler by buffering the encoded content.
+func (s *StreamedString) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := s.WriteJSONTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// WriteJSONTo writes the content of the reader to w as a JSON string.
+func (s *StreamedString) WriteJSONTo(w io.Writer) (err error) {
+	if c, ok := s.Reader.(io.Closer); ok {
+		defer func() {
+			if e := c.Close(); err == nil {
+				err = e
+			}
+		}()
+	}
+	if _, err := io.WriteString(w, `"`); err != nil {
+		return err
+	}
+	if s.Encoding == StreamEncodingBase64 {
+		enc := base64.NewEncoder(base64.StdEncoding, w)
+		if _, err := io.Copy(enc, s.Reader); err != nil {
+			return err
+		}
+		if err := enc.Close(); err != nil {
+			return err
+		}
+	} else if err := writeEscapedJSONText(w, s.Reader); err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, `"`)
+	return err
+}
+
+// writeEscapedJSONText escapes the content of the reader the way encoding/json
+// escapes strings one chunk at a time. A rune that's split between reads is held
+// back until the next read so that it's escaped as a whole.
+func writeEscapedJSONText(w io.Writer, r io.Reader) error {
+	buf := make([]byte, streamChunkSize)
+	pending := 0
+	for {
+		n, err := r.Read(buf[pending:])
+		n += pending
+		end := n
+		if err == nil {
+			for i := 1; i < utf8.UTFMax && i <= n; i++ {
+				if utf8.RuneStart(buf[n-i]) {
+					if !utf8.FullRune(buf[n-i : n]) {
+						end = n - i
+					}
+					break
+				}
+			}
+		}
+		if end > 0 {
+			b, mErr := json.Marshal(string(buf[:end]))
+			if mErr != nil {
+				return mErr
+			}
+			// Strip the quotes of the encoded chunk.
+			if _, wErr := w.Write(b[1 : len(b)-1]); wErr != nil {
+				return wErr
+			}
+		}
+		pending = copy(buf, buf[end:n])
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
+// WriteJSON encodes a result, or a value of the data of a result, to w like
+// encoding/json but streams the content of StreamedString values instead of
+// buffering them and embeds RawJSON values verbatim.
+func WriteJSON(w io.Writer, v any) error {
+	bw := bufio.NewWriter(w)
+	if err := writeJSON(bw, v); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+func writeJSON(w *bufio.Writer, v any) error {
+	switch v := v.(type) {
+	case *StreamedString:
+		return v.WriteJSONTo(w)
+	case RawJSON:
+		if v == nil {
+			_, err := w.WriteString("null")
+			return err
+		}
+		_, err := w.Write(v)
+		return err
+	case *Result:
+		w.WriteString(`{"data":`)
+		if err := writeJSON(w, v.Data); err != nil {
+			return err
+		}
+		if len(v.Errors) != 0 {
+			w.WriteString(`,"errors":`)
+			if err := writeJSON(w, v.Errors); err != nil {
+				return err
+			}
+		}
+		if len(v.Extensions) != 0 {
+			w.WriteString(`,"extensions":`)
+			if err := writeJSON(w, v.Extensions); err != nil {
+				return err
+			}
+		}
+		return w.WriteByte('}')
+	case map[string]any:
+		if v == nil {
+			_, err := w.WriteString("null")
+			return err
+		}
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		w.WriteByte('{')
+		for i, k := range keys {
+			if err := writeJSONKey(w, i, k); err != nil {
+				return err
+			}
+			if err := writeJSON(w, v[k]); err != nil {
+				return err
+			}
+		}
+		return w.WriteByte('}')
+	case OrderedMap:
+		w.WriteByte('{')
+		for i, kv := range v {
+			if err := writeJSONKey(w, i, kv.Key); err != nil {
+				return err
+			}
+			if err := writeJSON(w, kv.Value); err != nil {
+				return err
+			}
+		}
+		return w.WriteByte('}')
+	case []any:
+		if v == nil {
+			_, err := w.WriteString("null")
+			return err
+		}
+		w.WriteByte('[')
+		for i, item := range v {
+			if i != 0 {
+				w.WriteByte(',')
+			}
+			if err := writeJSON(w, item); err != nil {
+				return err
+			}
+		}
+		return w.WriteByte(']')
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+func writeJSONKey(w *bufio.Writer, i int, key string) error {
+	if i != 0 {
+		w.WriteByte(',')
+	}
+	b, err := json.Marshal(key)
+	if err != nil {
+		return err
+	}
+	w.Write(b)
+	return w.WriteByte(':')
+}
@@ -0,0 +1,102 @@
+package graphql_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/sprucehealth/graphql"
+)
+
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestStreamStrings(t *testing.T) {
+	content := strings.Repeat("héllo \"wörld\" <tag>\n  ✓ ", 5000)
+	var readers []*closeRecorder
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"export": &graphql.Field{
+					Type: graphql.NewNonNull(graphql.String),
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						// Reading one byte at a time splits multi-byte runes between reads.
+						r := &closeRecorder{Reader: iotest.OneByteReader(strings.NewReader(content))}
+						readers = append(readers, r)
+						return r, nil
+					},
+				},
+				"name": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						return "name", nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []struct {
+		encoding graphql.StreamEncoding
+		expected string
+	}{
+		{encoding: graphql.StreamEncodingText, expected: content},
+		{encoding: graphql.StreamEncodingBase64, expected: base64.StdEncoding.EncodeToString([]byte(content))},
+	} {
+		expected, err := json.Marshal(&graphql.Result{
+			Data: map[string]any{"export": c.expected, "name": "name"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, encode := range []func(*graphql.Result) ([]byte, error){
+			func(r *graphql.Result) ([]byte, error) {
+				var buf bytes.Buffer
+				err := graphql.WriteJSON(&buf, r)
+				return buf.Bytes(), err
+			},
+			func(r *graphql.Result) ([]byte, error) {
+				return json.Marshal(r)
+			},
+		} {
+			readers = nil
+			result := graphql.Do(context.Background(), graphql.Params{
+				Schema:        schema,
+				RequestString: `{ export name }`,
+				StreamStrings: c.encoding,
+			})
+			if len(result.Errors) != 0 {
+				t.Fatal(result.Errors)
+			}
+			if _, ok := result.Data.(map[string]any)["export"].(*graphql.StreamedString); !ok {
+				t.Fatalf("Expected a *StreamedString, got %T", result.Data.(map[string]any)["export"])
+			}
+			b, err := encode(result)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(expected, b) {
+				t.Fatalf("Expected %.200s..., got %.200s...", expected, b)
+			}
+			if len(readers) != 1 || !readers[0].closed {
+				t.Fatal("Expected the reader to be closed")
+			}
+		}
+	}
+}
@@ -0,0 +1,388 @@
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/sprucehealth/graphql/gqlerrors"
+)
+
+// MarshalStrictJSON encodes a value the way graphql-js encodes a response with
+// JSON.stringify so that responses can be compared byte for byte with a graphql-js
+// service. It differs from encoding/json in that:
+//
+//   - A Result is encoded with its fields in the order errors, data, extensions.
+//   - Floats are formatted like JavaScript numbers (e.g. -0 is encoded as 0) and
+//     NaN and infinite values are encoded as null.
+//   - Strings only escape quotes, backslashes, and control characters. HTML
+//     characters and U+2028 and U+2029 aren't escaped.
+//
+// Objects built from maps are encoded with sorted keys and OrderedMap keeps the
+// order of its entries. Other values are encoded with encoding/json and then
+// re-encoded to apply the same formatting. It's meant to be used with results of
+// operations executed with ExecuteParams.StrictJSON.
+func MarshalStrictJSON(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := encodeStrictJSON(&buf, v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func encodeStrictJSON(buf *bytes.Buffer, v any) error {
+	switch v := v.(type) {
+	case nil, NullValue:
+		buf.WriteString("null")
+	case bool:
+		buf.WriteString(strconv.FormatBool(v))
+	case string:
+		writeStrictJSONString(buf, v)
+	case float64:
+		writeStrictJSONFloat(buf, v)
+	case float32:
+		writeStrictJSONFloat(buf, float64(v))
+	case int:
+		buf.WriteString(strconv.Itoa(v))
+	case int32:
+		buf.WriteString(strconv.FormatInt(int64(v), 10))
+	case int64:
+		buf.WriteString(strconv.FormatInt(v, 10))
+	case json.Number:
+		writeStrictJSONNumber(buf, v)
+	case []any:
+		buf.WriteByte('[')
+		for i, item := range v {
+			if i != 0 {
+				buf.WriteByte(',')
+			}
+			if err := encodeStrictJSON(buf, item); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case map[string]any:
+		if v == nil {
+			buf.WriteString("null")
+			return nil
+		}
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		buf.WriteByte('{')
+		for i, k := range keys {
+			if i != 0 {
+				buf.WriteByte(',')
+			}
+			writeStrictJSONString(buf, k)
+			buf.WriteByte(':')
+			if err := encodeStrictJSON(buf, v[k]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case OrderedMap:
+		if v == nil {
+			buf.WriteString("null")
+			return nil
+		}
+		buf.WriteByte('{')
+		for i, kv := range v {
+			if i != 0 {
+				buf.WriteByte(',')
+			}
+			writeStrictJSONString(buf, kv.Key)
+			buf.WriteByte(':')
+			if err := encodeStrictJSON(buf, kv.Value); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case *Result:
+		if v == nil {
+			buf.WriteString("null")
+			return nil
+		}
+		return encodeStrictJSONResult(buf, v)
+	case Result:
+		return encodeStrictJSONResult(buf, &v)
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		return reencodeStrictJSON(buf, b)
+	}
+	return nil
+}
+
+func encodeStrictJSONResult(buf *bytes.Buffer, r *Result) error {
+	buf.WriteByte('{')
+	if len(r.Errors) != 0 {
+		buf.WriteString(`"errors":[`)
+		for i, e := range r.Errors {
+			if i != 0 {
+				buf.WriteByte(',')
+			}
+			if err := encodeStrictJSONError(buf, e); err != nil {
+				return err
+			}
+		}
+		buf.WriteString(`],`)
+	}
+	buf.WriteString(`"data":`)
+	if err := encodeStrictJSON(buf, r.Data); err != nil {
+		return err
+	}
+	if len(r.Extensions) != 0 {
+		buf.WriteString(`,"extensions":`)
+		if err := encodeStrictJSON(buf, r.Extensions); err != nil {
+			return err
+		}
+	}
+	buf.WriteByte('}')
+	return nil
+}
+
+// encodeStrictJSONError encodes an error with its fields in the order used by
+// graphql-js (message, locations, path, extensions) followed by the fields that
+// are specific to this package.
+func encodeStrictJSONError(buf *bytes.Buffer, e gqlerrors.FormattedError) error {
+	buf.WriteString(`{"message":`)
+	writeStrictJSONString(buf, e.Message)
+	if len(e.Locations) != 0 {
+		buf.WriteString(`,"locations":[`)
+		for i, l := range e.Locations {
+			if i != 0 {
+				buf.WriteByte(',')
+			}
+			fmt.Fprintf(buf, `{"line":%d,"column":%d}`, l.Line, l.Column)
+		}
+		buf.WriteByte(']')
+	}
+	if len(e.Path) != 0 {
+		buf.WriteString(`,"path":`)
+		if err := encodeStrictJSON(buf, e.Path); err != nil {
+			return err
+		}
+	}
+	if len(e.Extensions) != 0 {
+		buf.WriteString(`,"extensions":`)
+		if err := encodeStrictJSON(buf, e.Extensions); err != nil {
+			return err
+		}
+	}
+	if e.Type != "" {
+		buf.WriteString(`,"type":`)
+		writeStrictJSONString(buf, string(e.Type))
+	}
+	if e.UserMessage != "" {
+		buf.WriteString(`,"userMessage":`)
+		writeStrictJSONString(buf, e.UserMessage)
+	}
+	buf.WriteByte('}')
+	return nil
+}
+
+// reencodeStrictJSON re-encodes JSON produced by encoding/json keeping the order
+// of object keys.
+func reencodeStrictJSON(buf *bytes.Buffer, b []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	return reencodeStrictJSONValue(buf, dec, tok)
+}
+
+func reencodeStrictJSONValue(buf *bytes.Buffer, dec *json.Decoder, tok json.Token) error {
+	switch tok := tok.(type) {
+	case json.Delim:
+		buf.WriteByte(byte(tok))
+		for i := 0; dec.More(); i++ {
+			if i != 0 {
+				buf.WriteByte(',')
+			}
+			if tok == '{' {
+				key, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				writeStrictJSONString(buf, key.(string))
+				buf.WriteByte(':')
+			}
+			value, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			if err := reencodeStrictJSONValue(buf, dec, value); err != nil {
+				return err
+			}
+		}
+		// Closing delimiter
+		end, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		buf.WriteByte(byte(end.(json.Delim)))
+	case string:
+		writeStrictJSONString(buf, tok)
+	case json.Number:
+		writeStrictJSONNumber(buf, tok)
+	case bool:
+		buf.WriteString(strconv.FormatBool(tok))
+	case nil:
+		buf.WriteString("null")
+	}
+	return nil
+}
+
+func writeStrictJSONNumber(buf *bytes.Buffer, n json.Number) {
+	s := n.String()
+	if !strings.ContainsAny(s, ".eE") {
+		buf.WriteString(s)
+		return
+	}
+	f, err := n.Float64()
+	if err != nil {
+		buf.WriteString(s)
+		return
+	}
+	writeStrictJSONFloat(buf, f)
+}
+
+// writeStrictJSONFloat formats a float the way JavaScript formats numbers.
+func writeStrictJSONFloat(buf *bytes.Buffer, f float64) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		buf.WriteString("null")
+		return
+	}
+	if f == 0 {
+		// Includes negative zero
+		buf.WriteByte('0')
+		return
+	}
+	abs := math.Abs(f)
+	if abs >= 1e-6 && abs < 1e21 {
+		buf.WriteString(strconv.FormatFloat(f, 'f', -1, 64))
+		return
+	}
+	s := strconv.FormatFloat(f, 'e', -1, 64)
+	// Go zero pads the exponent to two digits (e.g. 1e-07) where JavaScript doesn't
+	if i := strings.IndexByte(s, 'e'); i >= 0 && len(s) >= i+4 && s[i+2] == '0' {
+		s = s[:i+2] + s[i+3:]
+	}
+	buf.WriteString(s)
+}
+
+const hexDigits = "0123456789abcdef"
+
+// writeStrictJSONString quotes a string the way JSON.stringify does. Invalid UTF-8
+// is replaced with U+FFFD.
+func writeStrictJSONString(buf *bytes.Buffer, s string) {
+	buf.WriteByte('"')
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c < utf8.RuneSelf {
+			switch c {
+			case '"':
+				buf.WriteString(`\"`)
+			case '\\':
+				buf.WriteString(`\\`)
+			case '\b':
+				buf.WriteString(`\b`)
+			case '\f':
+				buf.WriteString(`\f`)
+			case '\n':
+				buf.WriteString(`\n`)
+			case '\r':
+				buf.WriteString(`\r`)
+			case '\t':
+				buf.WriteString(`\t`)
+			default:
+				if c < 0x20 {
+					buf.WriteString(`\u00`)
+					buf.WriteByte(hexDigits[c>>4])
+					buf.WriteByte(hexDigits[c&0xf])
+				} else {
+					buf.WriteByte(c)
+				}
+			}
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			buf.WriteString("\ufffd")
+		} else {
+			buf.WriteString(s[i : i+size])
+		}
+		i += size
+	}
+	buf.WriteByte('"')
+}
+
+// serializeEnumStrict serializes an enum value like Enum.Serialize but also
+// accepts values of a different type than the internal value of the enum value
+// as long as they have the same kind and value (e.g. a named string type or an
+// int32 for an enum defined with int values).
+func serializeEnumStrict(enum *Enum, value any) any {
+	if name := enum.Serialize(value); name != nil {
+		return name
+	}
+	key, ok := enumValueKey(value)
+	if !ok {
+		return nil
+	}
+	for _, v := range enum.Values() {
+		if k, ok := enumValueKey(v.Value); ok && k == key {
+			return v.Name
+		}
+	}
+	return nil
+}
+
+// enumValueKey returns a comparable value for strings, integers, and floats
+// regardless of their named type.
+func enumValueKey(value any) (any, bool) {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.String:
+		return rv.String(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if u := rv.Uint(); u <= math.MaxInt64 {
+			return int64(u), true
+		}
+		return rv.Uint(), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return nil, false
+}
+
+// checkStrictFloat raises the error graphql-js raises when serializing a float
+// that isn't finite as it can't be represented in JSON.
+func checkStrictFloat(value any) {
+	f, ok := value.(float64)
+	if !ok || !math.IsNaN(f) && !math.IsInf(f, 0) {
+		return
+	}
+	s := "NaN"
+	if math.IsInf(f, 1) {
+		s = "Infinity"
+	} else if math.IsInf(f, -1) {
+		s = "-Infinity"
+	}
+	panic(gqlerrors.NewFormattedError("Float cannot represent non numeric value: " + s))
+}
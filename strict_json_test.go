@@ -0,0 +1,109 @@
+package graphql_test
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+)
+
+type strictJSONColor string
+
+func TestMarshalStrictJSON(t *testing.T) {
+	cases := []struct {
+		value    any
+		expected string
+	}{
+		{value: nil, expected: `null`},
+		{value: 1.0, expected: `1`},
+		{value: 1.5, expected: `1.5`},
+		{value: math.Copysign(0, -1), expected: `0`},
+		{value: 1e-7, expected: `1e-7`},
+		{value: 0.000001, expected: `0.000001`},
+		{value: 1e21, expected: `1e+21`},
+		{value: 123456789012345680000.0, expected: `123456789012345680000`},
+		{value: math.NaN(), expected: `null`},
+		{value: "<a href=\"x\">&</a>", expected: `"<a href=\"x\">&</a>"`},
+		{value: "\u2028\u2029", expected: "\"\u2028\u2029\""},
+		{value: "\b\f\n\r\t\x01\x1f\\", expected: `"\b\f\n\r\t\u0001\u001f\\"`},
+		{value: "\xff", expected: "\"\ufffd\""},
+		{value: []any{1, "a", true, nil}, expected: `[1,"a",true,null]`},
+		{value: map[string]any{"b": 1, "a": 2}, expected: `{"a":2,"b":1}`},
+		{value: graphql.OrderedMap{{Key: "b", Value: 1}, {Key: "a", Value: 2}}, expected: `{"b":1,"a":2}`},
+		{value: struct {
+			B float64 `json:"b"`
+			A string  `json:"a"`
+		}{B: 1e-7, A: "<"}, expected: `{"b":1e-7,"a":"<"}`},
+		{value: &graphql.Result{Data: map[string]any{"a": 1}}, expected: `{"data":{"a":1}}`},
+	}
+	for _, c := range cases {
+		b, err := graphql.MarshalStrictJSON(c.value)
+		if err != nil {
+			t.Fatalf("Failed to marshal %#v: %s", c.value, err)
+		}
+		if string(b) != c.expected {
+			t.Errorf("Expected %#v to encode as %s, got %s", c.value, c.expected, b)
+		}
+	}
+}
+
+func TestStrictJSONExecution(t *testing.T) {
+	colorType := graphql.NewEnum(graphql.EnumConfig{
+		Name: "Color",
+		Values: graphql.EnumValueConfigMap{
+			"RED":  &graphql.EnumValueConfig{Value: "red"},
+			"BLUE": &graphql.EnumValueConfig{Value: "blue"},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"color": &graphql.Field{
+					Type: colorType,
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						return strictJSONColor("blue"), nil
+					},
+				},
+				"ratio": &graphql.Field{
+					Type: graphql.Float,
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						return math.Inf(1), nil
+					},
+				},
+				"small": &graphql.Field{
+					Type: graphql.Float,
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						return 1e-7, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: `{ color small }`,
+	})
+	if len(result.Errors) != 0 || result.Data.(map[string]any)["color"] != nil {
+		t.Fatalf("Expected named enum type to not serialize without strict JSON, got %+v", result)
+	}
+
+	result = graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: `{ color ratio small }`,
+		StrictJSON:    true,
+	})
+	b, err := graphql.MarshalStrictJSON(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"errors":[{"message":"Float cannot represent non numeric value: Infinity","type":"INTERNAL"}],"data":{"color":"BLUE","ratio":null,"small":1e-7}}`
+	if string(b) != expected {
+		t.Fatalf("Expected %s, got %s", expected, b)
+	}
+}
@@ -0,0 +1,157 @@
+package graphql
+
+import (
+	"context"
+	"time"
+
+	"github.com/sprucehealth/graphql/gqlerrors"
+	"github.com/sprucehealth/graphql/language/ast"
+)
+
+// SubscribeFn returns the source stream of events for a subscription field. The
+// context is cancelled when the subscription ends (e.g. the client disconnects)
+// and the function should then stop sending events and release its resources.
+// Closing the channel ends the subscription.
+type SubscribeFn func(ctx context.Context, p ResolveParams) (<-chan any, error)
+
+// SubscribeParams are the parameters of a subscription.
+type SubscribeParams struct {
+	Params
+
+	// OnSubscribe if set is called with the operation before the source stream is
+	// created. Returning an error rejects the subscription.
+	OnSubscribe func(ctx context.Context, operation *OperationInfo) error
+
+	// OnUnsubscribe if set is called once the subscription has ended for any reason
+	// and its context has been cancelled. It's not called if the subscription was
+	// rejected. The context passed to it isn't cancelled so it can be used for cleanup.
+	OnUnsubscribe func(ctx context.Context)
+
+	// KeepAlive if greater than 0 is the interval at which results with KeepAlive set
+	// are sent while the subscription is idle. The interval restarts after every
+	// event so keep-alives aren't sent while events are delivered more often.
+	KeepAlive time.Duration
+}
+
+// Subscribe parses and validates a subscription operation, creates the source
+// stream of its root field, and returns a channel of the results of executing
+// the operation for every event of the stream. The channel is closed when the
+// stream is closed or the context is done, which is how a disconnected client
+// should end a subscription. Errors that prevent subscribing are sent as a single
+// result before the channel is closed.
+func Subscribe(ctx context.Context, p SubscribeParams) <-chan *Result {
+	out := make(chan *Result, 1)
+	doc, _, result := p.prepare(ctx)
+	if result != nil {
+		out <- result
+		close(out)
+		return out
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	events, err := subscribe(ctx, p, doc)
+	if err != nil {
+		cancel()
+		out <- &Result{Errors: gqlerrors.FormatErrors(err)}
+		close(out)
+		return out
+	}
+
+	go func() {
+		defer close(out)
+		if p.OnUnsubscribe != nil {
+			defer p.OnUnsubscribe(context.WithoutCancel(ctx))
+		}
+		defer cancel()
+
+		var ticker *time.Ticker
+		var keepAlive <-chan time.Time
+		if p.KeepAlive > 0 {
+			ticker = time.NewTicker(p.KeepAlive)
+			defer ticker.Stop()
+			keepAlive = ticker.C
+		}
+		for {
+			var result *Result
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-events:
+				if !ok {
+					return
+				}
+				ep := p.executeParams(doc)
+				ep.Root = event
+				result = Execute(ctx, ep)
+				if ticker != nil {
+					ticker.Reset(p.KeepAlive)
+				}
+			case <-keepAlive:
+				result = &Result{KeepAlive: true}
+			}
+			select {
+			case out <- result:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// subscribe calls the subscribe function of the root field of the operation.
+func subscribe(ctx context.Context, p SubscribeParams, doc *ast.Document) (<-chan any, error) {
+	eCtx, err := buildExecutionContext(BuildExecutionCtxParams{
+		Schema:        p.Schema,
+		Root:          p.RootObject,
+		AST:           doc,
+		OperationName: p.OperationName,
+		Args:          p.VariableValues,
+		UseJSONNumber: p.UseJSONNumber,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if eCtx.Operation.GetOperation() != ast.OperationTypeSubscription {
+		return nil, gqlerrors.CodeNotSubscription.New([]ast.Node{eCtx.Operation}, nil)
+	}
+	rootType, err := getOperationRootType(eCtx.Schema, eCtx.Operation)
+	if err != nil {
+		return nil, err
+	}
+	fields := collectFields(CollectFieldsParams{
+		ExeContext:   eCtx,
+		RuntimeType:  rootType,
+		SelectionSet: eCtx.Operation.GetSelectionSet(),
+	})
+	if len(fields) != 1 {
+		return nil, gqlerrors.CodeSubscriptionFieldCount.New([]ast.Node{eCtx.Operation}, nil)
+	}
+	var fieldASTs []*ast.Field
+	for _, f := range fields {
+		fieldASTs = f
+	}
+	var fieldName string
+	if fieldASTs[0].Name != nil {
+		fieldName = fieldASTs[0].Name.Value
+	}
+	fieldDef := getFieldDef(eCtx.Schema, rootType, fieldName, eCtx.DisallowIntrospection)
+	if fieldDef == nil || fieldDef.Subscribe == nil {
+		return nil, gqlerrors.CodeMissingSubscribe.New(FieldASTsToNodeASTs(fieldASTs), nil, fieldName)
+	}
+
+	if p.OnSubscribe != nil {
+		if err := p.OnSubscribe(ctx, newOperationInfo(&eCtx.Schema, eCtx.Operation, eCtx.Fragments)); err != nil {
+			return nil, err
+		}
+	}
+	args, err := fieldArgumentValues(ctx, eCtx, fieldDef, fieldASTs, nil)
+	if err != nil {
+		return nil, gqlerrors.NewError(gqlerrors.ErrorTypeInvalidInput, err.Error(), FieldASTsToNodeASTs(fieldASTs), "", nil, nil, err)
+	}
+	return fieldDef.Subscribe(ctx, ResolveParams{
+		Source: p.RootObject,
+		Args:   args,
+		Info:   newResolveInfo(eCtx, rootType, fieldDef, fieldASTs),
+	})
+}
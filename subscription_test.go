@@ -0,0 +1,243 @@
+package graphql_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sprucehealth/graphql"
+)
+
+func subscriptionTestSchema(t *testing.T, subscribe graphql.SubscribeFn) graphql.Schema {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"a": &graphql.Field{Type: graphql.String},
+			},
+		}),
+		Subscription: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Subscription",
+			Fields: graphql.Fields{
+				"counter": &graphql.Field{
+					Type: graphql.Int,
+					Args: graphql.FieldConfigArgument{
+						"start": &graphql.ArgumentConfig{Type: graphql.Int},
+					},
+					Subscribe: subscribe,
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						return p.Source, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+func TestSubscribe(t *testing.T) {
+	var unsubscribed bool
+	streamDone := make(chan struct{})
+	schema := subscriptionTestSchema(t, func(ctx context.Context, p graphql.ResolveParams) (<-chan any, error) {
+		start := p.Args["start"].(int)
+		ch := make(chan any)
+		go func() {
+			defer close(ch)
+			for i := start; i < start+3; i++ {
+				select {
+				case ch <- i:
+				case <-ctx.Done():
+					return
+				}
+			}
+			<-ctx.Done()
+			close(streamDone)
+		}()
+		return ch, nil
+	})
+
+	var operationType string
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	results := graphql.Subscribe(ctx, graphql.SubscribeParams{
+		Params: graphql.Params{
+			Schema:        schema,
+			RequestString: `subscription { counter(start: 5) }`,
+		},
+		OnSubscribe: func(ctx context.Context, op *graphql.OperationInfo) error {
+			operationType = op.Type
+			return nil
+		},
+		OnUnsubscribe: func(ctx context.Context) {
+			unsubscribed = true
+		},
+		KeepAlive: 10 * time.Millisecond,
+	})
+	if operationType != "subscription" {
+		t.Fatalf("Expected OnSubscribe to be called with a subscription, got %q", operationType)
+	}
+	var counts []any
+	var keepAlives int
+	for r := range results {
+		if r.KeepAlive {
+			keepAlives++
+			if keepAlives == 2 {
+				// Disconnect
+				cancel()
+			}
+			continue
+		}
+		if len(r.Errors) != 0 {
+			t.Fatalf("Unexpected errors %+v", r.Errors)
+		}
+		counts = append(counts, r.Data.(map[string]any)["counter"])
+	}
+	if !reflect.DeepEqual([]any{5, 6, 7}, counts) {
+		t.Fatalf("Unexpected events %v", counts)
+	}
+	if !unsubscribed {
+		t.Fatal("Expected OnUnsubscribe to be called")
+	}
+	select {
+	case <-streamDone:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the stream context to be cancelled")
+	}
+}
+
+func TestSubscribeKeepAliveWhileActive(t *testing.T) {
+	const events = 15
+	schema := subscriptionTestSchema(t, func(ctx context.Context, p graphql.ResolveParams) (<-chan any, error) {
+		ch := make(chan any)
+		go func() {
+			defer close(ch)
+			for i := range events {
+				select {
+				case ch <- i:
+				case <-ctx.Done():
+					return
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+			<-ctx.Done()
+		}()
+		return ch, nil
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	results := graphql.Subscribe(ctx, graphql.SubscribeParams{
+		Params: graphql.Params{
+			Schema:        schema,
+			RequestString: `subscription { counter }`,
+		},
+		KeepAlive: 60 * time.Millisecond,
+	})
+	var received int
+	for r := range results {
+		if r.KeepAlive {
+			// Keep-alives are only sent once the stream is idle
+			if received != events {
+				t.Fatalf("Expected no keep-alive while events are delivered, got one after %d events", received)
+			}
+			cancel()
+			continue
+		}
+		received++
+	}
+	if received != events {
+		t.Fatalf("Expected %d events, got %d", events, received)
+	}
+}
+
+func TestSubscribeErrors(t *testing.T) {
+	var subscribed bool
+	schema := subscriptionTestSchema(t, func(ctx context.Context, p graphql.ResolveParams) (<-chan any, error) {
+		subscribed = true
+		return nil, errors.New("no stream")
+	})
+	cases := []struct {
+		query       string
+		onSubscribe func(context.Context, *graphql.OperationInfo) error
+		message     string
+		subscribed  bool
+	}{
+		{query: `{ a }`, message: "Subscribe can only execute subscription operations."},
+		{query: `subscription { counter a: counter }`, message: "Subscription operations must select exactly one top level field."},
+		{
+			query:       `subscription { counter }`,
+			onSubscribe: func(context.Context, *graphql.OperationInfo) error { return errors.New("rejected") },
+			message:     "rejected",
+		},
+		{query: `subscription { counter }`, message: "no stream", subscribed: true},
+	}
+	for _, c := range cases {
+		subscribed = false
+		var results []*graphql.Result
+		for r := range graphql.Subscribe(context.Background(), graphql.SubscribeParams{
+			Params:        graphql.Params{Schema: schema, RequestString: c.query},
+			OnSubscribe:   c.onSubscribe,
+			OnUnsubscribe: func(context.Context) { t.Errorf("Unexpected unsubscribe for %s", c.query) },
+		}) {
+			results = append(results, r)
+		}
+		if len(results) != 1 || len(results[0].Errors) != 1 || results[0].Errors[0].Message != c.message {
+			t.Fatalf("Expected error %q for %s, got %+v", c.message, c.query, results)
+		}
+		if subscribed != c.subscribed {
+			t.Fatalf("Expected subscribed=%t for %s", c.subscribed, c.query)
+		}
+	}
+}
+
+func TestSubscribeValidatesArguments(t *testing.T) {
+	var subscribed bool
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"a": &graphql.Field{Type: graphql.String},
+			},
+		}),
+		Subscription: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Subscription",
+			Fields: graphql.Fields{
+				"counter": &graphql.Field{
+					Type: graphql.Int,
+					Args: graphql.FieldConfigArgument{
+						"start": &graphql.ArgumentConfig{Type: graphql.Int},
+					},
+					ValidateArgs: func(ctx context.Context, args map[string]any) error {
+						if start, _ := args["start"].(int); start < 0 {
+							return errors.New("start must not be negative")
+						}
+						return nil
+					},
+					Subscribe: func(ctx context.Context, p graphql.ResolveParams) (<-chan any, error) {
+						subscribed = true
+						return nil, errors.New("no stream")
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var results []*graphql.Result
+	for r := range graphql.Subscribe(context.Background(), graphql.SubscribeParams{
+		Params: graphql.Params{Schema: schema, RequestString: `subscription { counter(start: -1) }`},
+	}) {
+		results = append(results, r)
+	}
+	if len(results) != 1 || len(results[0].Errors) != 1 || results[0].Errors[0].Message != "start must not be negative" {
+		t.Fatalf("Expected invalid argument error, got %+v", results)
+	}
+	if subscribed {
+		t.Fatal("Expected subscribe not to be called")
+	}
+}
@@ -0,0 +1,116 @@
+package testutil
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/language/ast"
+)
+
+// Cancellation cancels the context of a request once the executor reaches a
+// field to test how resolvers and callers handle a cancellation in the middle of
+// an execution without racing a real timeout. Use Context as the context of the
+// request and Hook as its field hook:
+//
+//	c := testutil.CancelAt(ctx, "Query.order.items.name")
+//	result := graphql.Do(c.Context(), graphql.Params{..., FieldHook: c.Hook})
+//
+// The context is canceled before the resolver of the field is called so the
+// resolver and every field resolved after it see a done context.
+type Cancellation struct {
+	ctx     *cancellationContext
+	cancel  context.CancelCauseFunc
+	root    string
+	fields  []string
+	reached atomic.Bool
+}
+
+// CancelAt returns a Cancellation that cancels a context derived from ctx, as
+// when a client disconnects, once the field at path is reached. The path starts
+// with the name of the root type followed by the response keys of the fields
+// (e.g. "Query.a.b"). A field of the items of a list matches for every item
+// and only the first field that matches the path cancels the context.
+func CancelAt(ctx context.Context, path string) *Cancellation {
+	return newCancellation(ctx, path, context.Canceled)
+}
+
+// DeadlineAt is CancelAt but the error of the context is
+// context.DeadlineExceeded as when the deadline of the request is reached (e.g.
+// to test ExecuteParams.TimeoutWait).
+func DeadlineAt(ctx context.Context, path string) *Cancellation {
+	return newCancellation(ctx, path, context.DeadlineExceeded)
+}
+
+func newCancellation(ctx context.Context, path string, err error) *Cancellation {
+	inner, cancel := context.WithCancelCause(ctx)
+	segments := strings.Split(path, ".")
+	c := &Cancellation{
+		cancel: cancel,
+		root:   segments[0],
+		fields: segments[1:],
+	}
+	c.ctx = &cancellationContext{Context: inner, parent: ctx, err: err, reached: &c.reached}
+	return c
+}
+
+// Context returns the context that's canceled once the field is reached.
+func (c *Cancellation) Context() context.Context {
+	return c.ctx
+}
+
+// Hook is a graphql.FieldHookFn that cancels the context once the field is reached.
+func (c *Cancellation) Hook(ctx context.Context, info graphql.ResolveInfo, path []string) {
+	if c.reached.Load() || rootTypeName(info) != c.root || !slices.Equal(c.fields, path) {
+		return
+	}
+	if c.reached.CompareAndSwap(false, true) {
+		c.cancel(c.ctx.err)
+	}
+}
+
+// Reached returns true once the field has been reached and the context canceled.
+func (c *Cancellation) Reached() bool {
+	return c.reached.Load()
+}
+
+// cancellationContext reports the error of the simulated cancellation instead of
+// context.Canceled once the field has been reached.
+type cancellationContext struct {
+	context.Context
+	parent  context.Context
+	err     error
+	reached *atomic.Bool
+}
+
+func (c *cancellationContext) Err() error {
+	err := c.Context.Err()
+	if err != nil && c.reached.Load() {
+		return c.err
+	}
+	return err
+}
+
+// Value hides the embedded cancelable context from the context package so that
+// the contexts derived from this one are canceled with the error returned by Err.
+func (c *cancellationContext) Value(key any) any {
+	return c.parent.Value(key)
+}
+
+func rootTypeName(info graphql.ResolveInfo) string {
+	var root *graphql.Object
+	switch info.Operation.GetOperation() {
+	case ast.OperationTypeQuery:
+		root = info.Schema.QueryType()
+	case ast.OperationTypeMutation:
+		root = info.Schema.MutationType()
+	case ast.OperationTypeSubscription:
+		root = info.Schema.SubscriptionType()
+	}
+	if root == nil {
+		return ""
+	}
+	return root.Name()
+}
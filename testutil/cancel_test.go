@@ -0,0 +1,139 @@
+package testutil_test
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/language/parser"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+// contextErrors records the error of the context of resolvers.
+type contextErrors struct {
+	mu       sync.Mutex
+	errs     map[string]error
+	resolved map[string]chan struct{}
+}
+
+// wait waits until the resolver of the field has been called and returns the
+// recorded errors.
+func (e *contextErrors) wait(field string) map[string]error {
+	<-e.resolved[field]
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return maps.Clone(e.errs)
+}
+
+// cancelSchema returns a schema with the fields Query.a.b, Query.a.c, and
+// Query.d whose resolvers record the error of their context.
+func cancelSchema(t *testing.T) (graphql.Schema, *contextErrors) {
+	t.Helper()
+	errs := &contextErrors{errs: make(map[string]error), resolved: make(map[string]chan struct{})}
+	for _, name := range []string{"a", "b", "c", "d"} {
+		errs.resolved[name] = make(chan struct{})
+	}
+	resolve := func(name string) graphql.FieldResolveFn {
+		return func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+			errs.mu.Lock()
+			errs.errs[name] = ctx.Err()
+			errs.mu.Unlock()
+			close(errs.resolved[name])
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			if name == "a" {
+				return map[string]any{}, nil
+			}
+			return name, nil
+		}
+	}
+	a := graphql.NewObject(graphql.ObjectConfig{
+		Name: "A",
+		Fields: graphql.Fields{
+			"b": &graphql.Field{Type: graphql.String, Resolve: resolve("b")},
+			"c": &graphql.Field{Type: graphql.String, Resolve: resolve("c")},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"a": &graphql.Field{Type: a, Resolve: resolve("a")},
+				"d": &graphql.Field{Type: graphql.String, Resolve: resolve("d")},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schema, errs
+}
+
+func TestCancelAt(t *testing.T) {
+	schema, errs := cancelSchema(t)
+	c := testutil.CancelAt(context.Background(), "Query.a.b")
+	result := graphql.Do(c.Context(), graphql.Params{
+		Schema:        schema,
+		RequestString: `{ a { b c } d }`,
+		Deterministic: true,
+		FieldHook:     c.Hook,
+	})
+	if !c.Reached() {
+		t.Fatal("Expected the field to be reached")
+	}
+	if len(result.Errors) == 0 || !errors.Is(result.Errors[0].OriginalError, context.Canceled) {
+		t.Fatalf("Expected a canceled error, got %+v", result.Errors)
+	}
+	// The fields resolved before the field see a live context and the field sees
+	// a canceled one. The fields after it aren't resolved. The executor may still
+	// be resolving the field when Do returns.
+	expected := map[string]error{"a": nil, "b": context.Canceled}
+	if got := errs.wait("b"); !reflect.DeepEqual(expected, got) {
+		t.Fatalf("Expected context errors %v, got %v", expected, got)
+	}
+
+	// A path that's not selected doesn't cancel the context
+	schema, _ = cancelSchema(t)
+	c = testutil.CancelAt(context.Background(), "Mutation.a.b")
+	result = graphql.Do(c.Context(), graphql.Params{
+		Schema:        schema,
+		RequestString: `{ a { b } }`,
+		FieldHook:     c.Hook,
+	})
+	if c.Reached() || len(result.Errors) != 0 || c.Context().Err() != nil {
+		t.Fatalf("Expected the context not to be canceled, got %+v", result)
+	}
+}
+
+func TestDeadlineAt(t *testing.T) {
+	schema, errs := cancelSchema(t)
+	doc, err := parser.Parse(parser.ParseParams{Source: `{ d a { b c } }`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := testutil.DeadlineAt(context.Background(), "Query.a.c")
+	// With TimeoutWait the result includes the fields resolved before the deadline
+	result := graphql.Execute(c.Context(), graphql.ExecuteParams{
+		Schema:        schema,
+		AST:           doc,
+		Deterministic: true,
+		TimeoutWait:   time.Second,
+		FieldHook:     c.Hook,
+	})
+	if !errors.Is(c.Context().Err(), context.DeadlineExceeded) {
+		t.Fatalf("Expected the context to be past its deadline, got %v", c.Context().Err())
+	}
+	if len(result.Errors) == 0 || !errors.Is(result.Errors[0].OriginalError, context.DeadlineExceeded) {
+		t.Fatalf("Expected a deadline exceeded error, got %+v", result.Errors)
+	}
+	expected := map[string]error{"d": nil, "a": nil, "b": nil, "c": context.DeadlineExceeded}
+	if got := errs.wait("c"); !reflect.DeepEqual(expected, got) {
+		t.Fatalf("Expected context errors %v, got %v", expected, got)
+	}
+}
@@ -0,0 +1,68 @@
+package testutil
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/gqlerrors"
+)
+
+// RuleTestCase is a query fixture of a RuleTester.
+type RuleTestCase struct {
+	// Name is the name of the subtest. The index of the case is used if it's empty.
+	Name  string
+	Query string
+	// Errors are the errors the rule is expected to report (see RuleError). The
+	// query is expected to be valid if there are none.
+	Errors []gqlerrors.FormattedError
+}
+
+// RuleTester validates query fixtures with a single validation rule and checks
+// the reported errors in the manner of the validation tests of graphql-js:
+//
+//	testutil.RuleTester{
+//		Rule: graphql.UniqueOperationNamesRule,
+//		SDL:  `type Query { field: String }`,
+//		Cases: []testutil.RuleTestCase{
+//			{Name: "one operation", Query: `query Foo { field }`},
+//			{Name: "two operations with the same name", Query: `...`, Errors: []gqlerrors.FormattedError{
+//				testutil.RuleError(`There can only be one operation named "Foo".`, 2, 13, 5, 13),
+//			}},
+//		},
+//	}.Run(t)
+type RuleTester struct {
+	Rule graphql.ValidationRuleFn
+	// SDL if set is the schema the queries are validated against in the schema
+	// definition language (see SchemaFromSDL). TestSchema is used otherwise.
+	SDL   string
+	Cases []RuleTestCase
+}
+
+// Run validates every case in a subtest.
+func (rt RuleTester) Run(t *testing.T) {
+	t.Helper()
+	schema := TestSchema
+	if rt.SDL != "" {
+		var err error
+		schema, err = SchemaFromSDL(rt.SDL)
+		if err != nil {
+			t.Fatalf("Invalid schema: %s", err)
+		}
+	}
+	rules := []graphql.ValidationRuleFn{rt.Rule}
+	for i, c := range rt.Cases {
+		name := c.Name
+		if name == "" {
+			name = strconv.Itoa(i)
+		}
+		t.Run(name, func(t *testing.T) {
+			t.Helper()
+			if len(c.Errors) == 0 {
+				expectValidRule(t, schema, rules, c.Query)
+				return
+			}
+			expectInvalidRule(t, schema, rules, c.Query, append([]gqlerrors.FormattedError(nil), c.Errors...))
+		})
+	}
+}
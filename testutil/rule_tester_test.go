@@ -0,0 +1,117 @@
+package testutil_test
+
+import (
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/gqlerrors"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+const ruleTesterSDL = `
+"Rules."
+schema { query: Root }
+
+directive @onField(reason: String) on FIELD
+
+interface Pet { name: String }
+
+type Dog implements Pet {
+  name: String
+  barks: Boolean @deprecated(reason: "Use sound.")
+  sound(loud: Boolean = false): String
+}
+
+type Cat implements Pet { name: String }
+
+union CatOrDog = Cat | Dog
+
+enum Color { RED GREEN }
+
+input Filter { color: Color!, names: [String!] }
+
+type Root {
+  pet(filter: Filter): Pet
+  catOrDog: CatOrDog
+}
+`
+
+func TestRuleTester(t *testing.T) {
+	testutil.RuleTester{
+		Rule: graphql.FieldsOnCorrectTypeRule,
+		SDL:  ruleTesterSDL,
+		Cases: []testutil.RuleTestCase{
+			{Name: "interface field", Query: `{ pet { name } }`},
+			{Name: "inline fragment", Query: `{ catOrDog { ... on Dog { barks sound(loud: true) } } }`},
+			{Name: "unknown field", Query: `{ pet { barks } }`, Errors: []gqlerrors.FormattedError{
+				testutil.RuleError(`Cannot query field "barks" on type "Pet". Did you mean to use an inline fragment on "Dog"?`, 1, 9),
+			}},
+		},
+	}.Run(t)
+
+	testutil.RuleTester{
+		Rule: graphql.ArgumentsOfCorrectTypeRule,
+		SDL:  ruleTesterSDL,
+		Cases: []testutil.RuleTestCase{
+			{Query: `{ pet(filter: {color: RED, names: ["Rex"]}) { name } }`},
+			{Query: `{ pet(filter: {color: BLUE}) { name } }`, Errors: []gqlerrors.FormattedError{
+				testutil.RuleError("Argument \"filter\" has invalid value {color: BLUE}.\nIn field \"color\": Expected type \"Color\", found BLUE.", 1, 15),
+			}},
+		},
+	}.Run(t)
+
+	testutil.RuleTester{
+		Rule: graphql.KnownDirectivesRule,
+		SDL:  ruleTesterSDL,
+		Cases: []testutil.RuleTestCase{
+			{Query: `{ pet @onField { name @include(if: true) } }`},
+			{Query: `query Q @onField { pet { name } }`, Errors: []gqlerrors.FormattedError{
+				testutil.RuleError(`Directive "onField" may not be used on QUERY.`, 1, 9),
+			}},
+		},
+	}.Run(t)
+
+	// TestSchema is used without a schema definition
+	testutil.RuleTester{
+		Rule: graphql.UniqueOperationNamesRule,
+		Cases: []testutil.RuleTestCase{
+			{Query: `query Foo { dog { name } } query Bar { dog { name } }`},
+			{Query: `query Foo { dog { name } } query Foo { dog { name } }`, Errors: []gqlerrors.FormattedError{
+				testutil.RuleError(`There can only be one operation named "Foo".`, 1, 7, 1, 34),
+			}},
+		},
+	}.Run(t)
+}
+
+func TestSchemaFromSDL(t *testing.T) {
+	schema, err := testutil.SchemaFromSDL(ruleTesterSDL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if schema.QueryType().Name() != "Root" {
+		t.Fatalf("Expected query type Root, got %s", schema.QueryType().Name())
+	}
+	if schema.Description() != "Rules." {
+		t.Fatalf("Expected schema description, got %q", schema.Description())
+	}
+	dog, ok := schema.Type("Dog").(*graphql.Object)
+	if !ok {
+		t.Fatalf("Expected object Dog, got %T", schema.Type("Dog"))
+	}
+	if reason := dog.Fields()["barks"].DeprecationReason; reason != "Use sound." {
+		t.Fatalf("Expected deprecation reason, got %q", reason)
+	}
+	if arg := dog.Fields()["sound"].Args[0]; arg.DefaultValue != false {
+		t.Fatalf("Expected default value false, got %v", arg.DefaultValue)
+	}
+	if union, ok := schema.Type("CatOrDog").(*graphql.Union); !ok || len(union.Types()) != 2 {
+		t.Fatalf("Expected union of 2 types, got %v", schema.Type("CatOrDog"))
+	}
+	if schema.Directive("onField") == nil {
+		t.Fatal("Expected directive onField")
+	}
+
+	if _, err := testutil.SchemaFromSDL(`type Foo { bar: String }`); err == nil {
+		t.Fatal("Expected error for missing query type")
+	}
+}
@@ -545,6 +545,7 @@ func init() {
 
 }
 func expectValidRule(t *testing.T, schema *graphql.Schema, rules []graphql.ValidationRuleFn, queryString string) {
+	t.Helper()
 	source := source.New("", queryString)
 	AST, err := parser.Parse(parser.ParseParams{Source: source})
 	if err != nil {
@@ -563,6 +564,11 @@ func expectInvalidRule(t *testing.T, schema *graphql.Schema, rules []graphql.Val
 	t.Helper()
 	for i := range expectedErrors {
 		expectedErrors[i].Type = gqlerrors.ErrorTypeBadQuery
+		if len(rules) == 1 && expectedErrors[i].Extensions == nil {
+			expectedErrors[i].Extensions = map[string]any{
+				graphql.ValidationRuleExtensionKey: graphql.ValidationRuleName(rules[0]),
+			}
+		}
 	}
 	source := source.New("", queryString)
 	AST, err := parser.Parse(parser.ParseParams{Source: source})
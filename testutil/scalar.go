@@ -0,0 +1,137 @@
+package testutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/language/ast"
+)
+
+// RoundTripScalar checks that a custom scalar is symmetric for each sample. A
+// sample is an input value as received in variables (e.g. "2020-01-02" for a
+// date). For every sample the parsed value must serialize to a non-null value
+// that parses back to the same value, both with ParseValue and with
+// ParseLiteral when the serialized value is written as a literal.
+func RoundTripScalar(t testing.TB, scalar *graphql.Scalar, samples []any) {
+	t.Helper()
+	for _, sample := range samples {
+		parsed := scalar.ParseValue(sample)
+		if parsed == nil {
+			t.Errorf("%s: ParseValue(%#v) is null", scalar.Name(), sample)
+			continue
+		}
+		if err, ok := parsed.(*graphql.ScalarParseError); ok {
+			t.Errorf("%s: ParseValue(%#v) is invalid: %v", scalar.Name(), sample, err)
+			continue
+		}
+		if msg := checkScalarRoundTrip(scalar, sample); msg != "" {
+			t.Error(msg)
+		}
+	}
+}
+
+// FuzzScalar fuzzes the round trip of a custom scalar (see RoundTripScalar) with
+// JSON encoded input values seeded with the samples. Values that the scalar
+// doesn't accept are skipped.
+//
+//	func FuzzDate(f *testing.F) {
+//		testutil.FuzzScalar(f, Date, []any{"2020-01-02"})
+//	}
+func FuzzScalar(f *testing.F, scalar *graphql.Scalar, samples []any) {
+	for _, sample := range samples {
+		b, err := json.Marshal(sample)
+		if err != nil {
+			f.Fatalf("Failed to encode sample %#v: %v", sample, err)
+		}
+		f.Add(b)
+	}
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var sample any
+		if err := json.Unmarshal(b, &sample); err != nil || isInvalidScalarValue(scalar.ParseValue(sample)) {
+			t.Skip()
+		}
+		if msg := checkScalarRoundTrip(scalar, sample); msg != "" {
+			t.Fatal(msg)
+		}
+	})
+}
+
+// isInvalidScalarValue returns true if a parsed value is null or a
+// *graphql.ScalarParseError.
+func isInvalidScalarValue(parsed any) bool {
+	_, isErr := parsed.(*graphql.ScalarParseError)
+	return parsed == nil || isErr
+}
+
+// checkScalarRoundTrip returns a description of the asymmetry of the scalar for
+// the sample or an empty string if there's none.
+func checkScalarRoundTrip(scalar *graphql.Scalar, sample any) string {
+	parsed := scalar.ParseValue(sample)
+	serialized := scalar.Serialize(parsed)
+	if serialized == nil {
+		return fmt.Sprintf("%s: Serialize(%#v) of sample %#v is null", scalar.Name(), parsed, sample)
+	}
+	if reparsed := scalar.ParseValue(serialized); !reflect.DeepEqual(reparsed, parsed) {
+		return fmt.Sprintf("%s: ParseValue(Serialize(%#v)) = %#v for sample %#v", scalar.Name(), parsed, reparsed, sample)
+	}
+	literal, ok := literalFromValue(serialized)
+	if !ok {
+		return ""
+	}
+	if fromLiteral := scalar.ParseLiteral(literal); !reflect.DeepEqual(fromLiteral, parsed) {
+		return fmt.Sprintf("%s: ParseLiteral(%#v) = %#v but ParseValue = %#v for sample %#v", scalar.Name(), serialized, fromLiteral, parsed, sample)
+	}
+	return ""
+}
+
+// literalFromValue returns the literal of a serialized value or false if the
+// value has no literal form.
+func literalFromValue(value any) (ast.Value, bool) {
+	switch v := value.(type) {
+	case string:
+		return &ast.StringValue{Value: v}, true
+	case bool:
+		return &ast.BooleanValue{Value: v}, true
+	case int:
+		return &ast.IntValue{Value: strconv.Itoa(v)}, true
+	case int32:
+		return &ast.IntValue{Value: strconv.FormatInt(int64(v), 10)}, true
+	case int64:
+		return &ast.IntValue{Value: strconv.FormatInt(v, 10)}, true
+	case float32:
+		return &ast.FloatValue{Value: strconv.FormatFloat(float64(v), 'g', -1, 32)}, true
+	case float64:
+		return &ast.FloatValue{Value: strconv.FormatFloat(v, 'g', -1, 64)}, true
+	case []any:
+		list := &ast.ListValue{}
+		for _, item := range v {
+			itemLiteral, ok := literalFromValue(item)
+			if !ok {
+				return nil, false
+			}
+			list.Values = append(list.Values, itemLiteral)
+		}
+		return list, true
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		obj := &ast.ObjectValue{}
+		for _, k := range keys {
+			fieldLiteral, ok := literalFromValue(v[k])
+			if !ok {
+				return nil, false
+			}
+			obj.Fields = append(obj.Fields, &ast.ObjectField{Name: &ast.Name{Value: k}, Value: fieldLiteral})
+		}
+		return obj, true
+	}
+	return nil, false
+}
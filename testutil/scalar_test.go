@@ -0,0 +1,93 @@
+package testutil_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/language/ast"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+var dateScalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name: "Date",
+	Serialize: func(value any) any {
+		if t, ok := value.(time.Time); ok {
+			return t.Format(time.DateOnly)
+		}
+		return nil
+	},
+	ParseValue: func(value any) any {
+		if s, ok := value.(string); ok {
+			if t, err := time.Parse(time.DateOnly, s); err == nil {
+				return t
+			}
+		}
+		return nil
+	},
+	ParseLiteral: func(valueAST ast.Value) any {
+		if v, ok := valueAST.(*ast.StringValue); ok {
+			if t, err := time.Parse(time.DateOnly, v.Value); err == nil {
+				return t
+			}
+		}
+		return nil
+	},
+})
+
+type recordingTB struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Error(args ...any) {
+	r.errors = append(r.errors, fmt.Sprint(args...))
+}
+
+func (r *recordingTB) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestRoundTripScalar(t *testing.T) {
+	testutil.RoundTripScalar(t, graphql.Int, []any{0, -3, 42})
+	testutil.RoundTripScalar(t, graphql.Float, []any{1.5, -0.25})
+	testutil.RoundTripScalar(t, graphql.String, []any{"", "héllo"})
+	testutil.RoundTripScalar(t, dateScalar, []any{"2020-01-02"})
+
+	// Lower cases when parsing values but not literals.
+	asymmetric := graphql.NewScalar(graphql.ScalarConfig{
+		Name: "Code",
+		Serialize: func(value any) any {
+			return value
+		},
+		ParseValue: func(value any) any {
+			if s, ok := value.(string); ok {
+				return strings.ToLower(s)
+			}
+			return nil
+		},
+		ParseLiteral: func(valueAST ast.Value) any {
+			if v, ok := valueAST.(*ast.StringValue); ok {
+				return v.Value + "!"
+			}
+			return nil
+		},
+	})
+	r := &recordingTB{TB: t}
+	testutil.RoundTripScalar(r, asymmetric, []any{"ABC", 1})
+	expected := []string{
+		`Code: ParseLiteral("abc") = "abc!" but ParseValue = "abc" for sample "ABC"`,
+		`Code: ParseValue(1) is null`,
+	}
+	if strings.Join(r.errors, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("Unexpected errors:\n%s", strings.Join(r.errors, "\n"))
+	}
+}
+
+func FuzzScalar_Date(f *testing.F) {
+	testutil.FuzzScalar(f, dateScalar, []any{"2020-01-02", "1999-12-31"})
+}
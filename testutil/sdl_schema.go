@@ -0,0 +1,273 @@
+package testutil
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/language/ast"
+	"github.com/sprucehealth/graphql/language/parser"
+	"github.com/sprucehealth/graphql/language/source"
+)
+
+// SchemaFromSDL builds a schema from its definition in the schema definition
+// language for use in tests. Fields have no resolvers and custom scalars accept
+// any value so the schema is only suited to validation. The root types are the
+// ones of the schema definition or else the types named Query, Mutation, and
+// Subscription.
+func SchemaFromSDL(sdl string) (*graphql.Schema, error) {
+	doc, err := parser.Parse(parser.ParseParams{Source: source.New("SDL", sdl)})
+	if err != nil {
+		return nil, err
+	}
+	b := &sdlBuilder{types: map[string]graphql.Type{
+		graphql.String.Name():  graphql.String,
+		graphql.Int.Name():     graphql.Int,
+		graphql.Float.Name():   graphql.Float,
+		graphql.Boolean.Name(): graphql.Boolean,
+		graphql.ID.Name():      graphql.ID,
+	}}
+	roots := map[string]string{
+		ast.OperationTypeQuery:        "Query",
+		ast.OperationTypeMutation:     "Mutation",
+		ast.OperationTypeSubscription: "Subscription",
+	}
+	var names []string
+	var unions []*ast.UnionDefinition
+	var directives []*ast.DirectiveDefinition
+	var schemaDescription string
+	for _, def := range doc.Definitions {
+		var t graphql.Type
+		switch def := def.(type) {
+		case *ast.SchemaDefinition:
+			for _, op := range def.OperationTypes {
+				roots[op.Operation] = op.Type.Name.Value
+			}
+			schemaDescription = description(def.Description)
+			continue
+		case *ast.DirectiveDefinition:
+			directives = append(directives, def)
+			continue
+		case *ast.UnionDefinition:
+			// Unions are created once all objects exist since their members
+			// can't be defined lazily.
+			unions = append(unions, def)
+			names = append(names, def.Name.Value)
+			continue
+		case *ast.ScalarDefinition:
+			t = graphql.NewScalar(graphql.ScalarConfig{
+				Name:         def.Name.Value,
+				Description:  description(def.Description),
+				Serialize:    func(value any) any { return value },
+				ParseValue:   func(value any) any { return value },
+				ParseLiteral: func(valueAST ast.Value) any { return valueAST.GetValue() },
+			})
+		case *ast.ObjectDefinition:
+			t = graphql.NewObject(graphql.ObjectConfig{
+				Name:        def.Name.Value,
+				Description: description(def.Description),
+				Interfaces: graphql.InterfacesThunk(func() []*graphql.Interface {
+					interfaces := make([]*graphql.Interface, 0, len(def.Interfaces))
+					for _, named := range def.Interfaces {
+						if iface, ok := b.types[named.Name.Value].(*graphql.Interface); ok {
+							interfaces = append(interfaces, iface)
+						}
+					}
+					return interfaces
+				}),
+				Fields: graphql.FieldsThunk(func() graphql.Fields { return b.fields(def.Fields) }),
+				// Abstract types must be resolvable for the schema to be valid
+				// even though it's never executed.
+				IsTypeOf: func(graphql.IsTypeOfParams) bool { return false },
+			})
+		case *ast.InterfaceDefinition:
+			t = graphql.NewInterface(graphql.InterfaceConfig{
+				Name:        def.Name.Value,
+				Description: description(def.Description),
+				Fields:      graphql.FieldsThunk(func() graphql.Fields { return b.fields(def.Fields) }),
+			})
+		case *ast.EnumDefinition:
+			values := make(graphql.EnumValueConfigMap, len(def.Values))
+			for _, v := range def.Values {
+				values[v.Name.Value] = &graphql.EnumValueConfig{
+					Value:             v.Name.Value,
+					Description:       description(v.Description),
+					DeprecationReason: deprecationReason(v.Directives),
+				}
+			}
+			t = graphql.NewEnum(graphql.EnumConfig{
+				Name:        def.Name.Value,
+				Description: description(def.Description),
+				Values:      values,
+			})
+		case *ast.InputObjectDefinition:
+			t = graphql.NewInputObject(graphql.InputObjectConfig{
+				Name:        def.Name.Value,
+				Description: description(def.Description),
+				Fields: graphql.InputObjectConfigFieldMapThunk(func() graphql.InputObjectConfigFieldMap {
+					fields := make(graphql.InputObjectConfigFieldMap, len(def.Fields))
+					for _, f := range def.Fields {
+						fields[f.Name.Value] = &graphql.InputObjectFieldConfig{
+							Type:              b.inputType(f.Type),
+							DefaultValue:      sdlValue(f.DefaultValue),
+							Description:       description(f.Description),
+							DeprecationReason: deprecationReason(f.Directives),
+						}
+					}
+					return fields
+				}),
+			})
+		default:
+			continue
+		}
+		b.types[t.Name()] = t
+		names = append(names, t.Name())
+	}
+	for _, def := range unions {
+		var members []*graphql.Object
+		for _, named := range def.Types {
+			if obj, ok := b.types[named.Name.Value].(*graphql.Object); ok {
+				members = append(members, obj)
+			}
+		}
+		b.types[def.Name.Value] = graphql.NewUnion(graphql.UnionConfig{
+			Name:        def.Name.Value,
+			Description: description(def.Description),
+			Types:       members,
+		})
+	}
+
+	config := graphql.SchemaConfig{
+		Description: schemaDescription,
+		Directives:  append([]*graphql.Directive(nil), graphql.SpecifiedDirectives...),
+	}
+	config.Query, _ = b.types[roots[ast.OperationTypeQuery]].(*graphql.Object)
+	config.Mutation, _ = b.types[roots[ast.OperationTypeMutation]].(*graphql.Object)
+	config.Subscription, _ = b.types[roots[ast.OperationTypeSubscription]].(*graphql.Object)
+	if config.Query == nil {
+		return nil, fmt.Errorf("query type %q is not defined", roots[ast.OperationTypeQuery])
+	}
+	for _, name := range names {
+		config.Types = append(config.Types, b.types[name])
+	}
+	for _, def := range directives {
+		locations := make([]string, len(def.Locations))
+		for i, loc := range def.Locations {
+			locations[i] = loc.Value
+		}
+		config.Directives = append(config.Directives, graphql.NewDirective(graphql.DirectiveConfig{
+			Name:         def.Name.Value,
+			Description:  description(def.Description),
+			Locations:    locations,
+			Args:         b.args(def.Arguments),
+			IsRepeatable: def.Repeatable,
+		}))
+	}
+	schema, err := graphql.NewSchema(config)
+	if err != nil {
+		return nil, err
+	}
+	return &schema, nil
+}
+
+type sdlBuilder struct {
+	types map[string]graphql.Type
+}
+
+func (b *sdlBuilder) fields(defs []*ast.FieldDefinition) graphql.Fields {
+	fields := make(graphql.Fields, len(defs))
+	for _, def := range defs {
+		t, _ := b.typ(def.Type).(graphql.Output)
+		fields[def.Name.Value] = &graphql.Field{
+			Type:              t,
+			Args:              b.args(def.Arguments),
+			Description:       description(def.Description),
+			DeprecationReason: deprecationReason(def.Directives),
+			Directives:        def.Directives,
+		}
+	}
+	return fields
+}
+
+func (b *sdlBuilder) args(defs []*ast.InputValueDefinition) graphql.FieldConfigArgument {
+	args := make(graphql.FieldConfigArgument, len(defs))
+	for _, def := range defs {
+		args[def.Name.Value] = &graphql.ArgumentConfig{
+			Type:              b.inputType(def.Type),
+			DefaultValue:      sdlValue(def.DefaultValue),
+			Description:       description(def.Description),
+			DeprecationReason: deprecationReason(def.Directives),
+		}
+	}
+	return args
+}
+
+func (b *sdlBuilder) inputType(t ast.Type) graphql.Input {
+	input, _ := b.typ(t).(graphql.Input)
+	return input
+}
+
+func (b *sdlBuilder) typ(t ast.Type) graphql.Type {
+	switch t := t.(type) {
+	case *ast.List:
+		if inner := b.typ(t.Type); inner != nil {
+			return graphql.NewList(inner)
+		}
+	case *ast.NonNull:
+		if inner := b.typ(t.Type); inner != nil {
+			return graphql.NewNonNull(inner)
+		}
+	case *ast.Named:
+		return b.types[t.Name.Value]
+	}
+	return nil
+}
+
+func description(s *ast.StringValue) string {
+	if s == nil {
+		return ""
+	}
+	return s.Value
+}
+
+func deprecationReason(directives []*ast.Directive) string {
+	for _, d := range directives {
+		if d.Name.Value != graphql.DeprecatedDirective.Name {
+			continue
+		}
+		for _, arg := range d.Arguments {
+			if s, ok := arg.Value.(*ast.StringValue); ok && arg.Name.Value == "reason" {
+				return s.Value
+			}
+		}
+		return graphql.DefaultDeprecationReason
+	}
+	return ""
+}
+
+// sdlValue returns the Go value of a default value literal.
+func sdlValue(v ast.Value) any {
+	switch v := v.(type) {
+	case *ast.IntValue:
+		i, _ := strconv.Atoi(v.Value)
+		return i
+	case *ast.FloatValue:
+		f, _ := strconv.ParseFloat(v.Value, 64)
+		return f
+	case *ast.ListValue:
+		values := make([]any, len(v.Values))
+		for i, item := range v.Values {
+			values[i] = sdlValue(item)
+		}
+		return values
+	case *ast.ObjectValue:
+		fields := make(map[string]any, len(v.Fields))
+		for _, f := range v.Fields {
+			fields[f.Name.Value] = sdlValue(f.Value)
+		}
+		return fields
+	case nil:
+		return nil
+	}
+	return v.GetValue()
+}
@@ -18,10 +18,13 @@ type TypeInfo struct {
 	typeStack       []Output
 	parentTypeStack []Composite
 	inputTypeStack  []Input
-	fieldDefStack   []*FieldDefinition
-	directive       *Directive
-	argument        *Argument
-	getFieldDef     fieldDefFn
+	// defaultValueStack holds the default value of the argument or input field for
+	// every input type in inputTypeStack.
+	defaultValueStack []any
+	fieldDefStack     []*FieldDefinition
+	directive         *Directive
+	argument          *Argument
+	getFieldDef       fieldDefFn
 }
 
 type TypeInfoConfig struct {
@@ -64,6 +67,15 @@ func (ti *TypeInfo) InputType() Input {
 	}
 	return nil
 }
+
+// DefaultValue returns the default value of the argument or input object field
+// that's being visited. It's nil for variable definitions and list items.
+func (ti *TypeInfo) DefaultValue() any {
+	if len(ti.defaultValueStack) == 0 {
+		return nil
+	}
+	return ti.defaultValueStack[len(ti.defaultValueStack)-1]
+}
 func (ti *TypeInfo) FieldDef() *FieldDefinition {
 	if len(ti.fieldDefStack) > 0 {
 		return ti.fieldDefStack[len(ti.fieldDefStack)-1]
@@ -134,33 +146,29 @@ func (ti *TypeInfo) Enter(node ast.Node) {
 	case *ast.VariableDefinition:
 		ttype, _ = typeFromAST(*schema, node.Type)
 		ti.inputTypeStack = append(ti.inputTypeStack, ttype)
+		ti.defaultValueStack = append(ti.defaultValueStack, nil)
 	case *ast.Argument:
 		nameVal := ""
 		if node.Name != nil {
 			nameVal = node.Name.Value
 		}
 		var argType Input
+		var argDefault any
 		var argDef *Argument
 		directive := ti.Directive()
 		fieldDef := ti.FieldDef()
 		if directive != nil {
-			for _, arg := range directive.Args {
-				if arg.Name() == nameVal {
-					argDef = arg
-				}
-			}
+			argDef = findArgument(directive.Args, nameVal)
 		} else if fieldDef != nil {
-			for _, arg := range fieldDef.Args {
-				if arg.Name() == nameVal {
-					argDef = arg
-				}
-			}
+			argDef = findArgument(fieldDef.Args, nameVal)
 		}
 		if argDef != nil {
 			argType = argDef.Type
+			argDefault = argDef.DefaultValue
 		}
 		ti.argument = argDef
 		ti.inputTypeStack = append(ti.inputTypeStack, argType)
+		ti.defaultValueStack = append(ti.defaultValueStack, argDefault)
 	case *ast.ListValue:
 		listType := GetNullable(ti.InputType())
 		if list, ok := listType.(*List); ok {
@@ -168,8 +176,10 @@ func (ti *TypeInfo) Enter(node ast.Node) {
 		} else {
 			ti.inputTypeStack = append(ti.inputTypeStack, nil)
 		}
+		ti.defaultValueStack = append(ti.defaultValueStack, nil)
 	case *ast.ObjectField:
 		var fieldType Input
+		var fieldDefault any
 		objectType := GetNamed(ti.InputType())
 
 		if objectType, ok := objectType.(*InputObject); ok {
@@ -177,11 +187,13 @@ func (ti *TypeInfo) Enter(node ast.Node) {
 			if node.Name != nil {
 				nameVal = node.Name.Value
 			}
-			if inputField, ok := objectType.Fields()[nameVal]; ok {
+			if inputField := findInputField(objectType, nameVal); inputField != nil {
 				fieldType = inputField.Type
+				fieldDefault = inputField.DefaultValue
 			}
 		}
 		ti.inputTypeStack = append(ti.inputTypeStack, fieldType)
+		ti.defaultValueStack = append(ti.defaultValueStack, fieldDefault)
 	}
 }
 func (ti *TypeInfo) Leave(node ast.Node) {
@@ -200,18 +212,21 @@ func (ti *TypeInfo) Leave(node ast.Node) {
 		// pop ti.typeStack
 		_, ti.typeStack = ti.typeStack[len(ti.typeStack)-1], ti.typeStack[:len(ti.typeStack)-1]
 	case *ast.VariableDefinition:
-		// pop ti.inputTypeStack
-		_, ti.inputTypeStack = ti.inputTypeStack[len(ti.inputTypeStack)-1], ti.inputTypeStack[:len(ti.inputTypeStack)-1]
+		ti.popInputType()
 	case *ast.Argument:
 		ti.argument = nil
-		// pop ti.inputTypeStack
-		_, ti.inputTypeStack = ti.inputTypeStack[len(ti.inputTypeStack)-1], ti.inputTypeStack[:len(ti.inputTypeStack)-1]
+		ti.popInputType()
 	case *ast.ObjectField, *ast.ListValue:
-		// pop ti.inputTypeStack
-		_, ti.inputTypeStack = ti.inputTypeStack[len(ti.inputTypeStack)-1], ti.inputTypeStack[:len(ti.inputTypeStack)-1]
+		ti.popInputType()
 	}
 }
 
+// popInputType pops the input type and its default value.
+func (ti *TypeInfo) popInputType() {
+	ti.inputTypeStack = ti.inputTypeStack[:len(ti.inputTypeStack)-1]
+	ti.defaultValueStack = ti.defaultValueStack[:len(ti.defaultValueStack)-1]
+}
+
 // DefaultTypeInfoFieldDef Not exactly the same as the executor's definition of FieldDef, in this
 // statically evaluated environment we do not always have an Object type,
 // and need to handle Interface and Union types.
@@ -226,6 +241,11 @@ func DefaultTypeInfoFieldDef(schema *Schema, parentType Type, fieldAST *ast.Fiel
 	if name == TypeMetaFieldDef.Name && schema.QueryType() == parentType {
 		return TypeMetaFieldDef
 	}
+	if schema.QueryType() == parentType {
+		if fieldDef := optInMetaFieldDef(schema, name); fieldDef != nil {
+			return fieldDef
+		}
+	}
 	if name == TypeNameMetaFieldDef.Name {
 		switch v := parentType.(type) {
 		case *Object:
@@ -243,6 +263,11 @@ func DefaultTypeInfoFieldDef(schema *Schema, parentType Type, fieldAST *ast.Fiel
 		}
 	}
 
+	if schema.introspectionPagination {
+		if fieldDef := pagedIntrospectionFieldDef(parentType, name); fieldDef != nil {
+			return fieldDef
+		}
+	}
 	if parentType, ok := parentType.(*Object); ok && parentType != nil {
 		return parentType.Fields()[name]
 	}
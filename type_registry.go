@@ -0,0 +1,150 @@
+package graphql
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/sprucehealth/graphql/gqlerrors"
+)
+
+// TypeRegistry holds named types that are shared by several schemas in a process
+// (e.g. an admin and a public schema) so that every schema uses the same instance
+// of a type instead of defining it again. A type may be restricted to the schemas
+// of some visibilities. A schema uses a registry by setting SchemaConfig.TypeRegistry
+// and SchemaConfig.TypeVisibility. It's safe for concurrent use.
+type TypeRegistry struct {
+	mu    sync.Mutex
+	types map[string]*registeredType
+}
+
+type registeredType struct {
+	ttype Type
+	// visibility is the visibilities of the schemas the type is visible to or nil if
+	// it's visible to all schemas.
+	visibility []string
+}
+
+// NewTypeRegistry returns an empty registry.
+func NewTypeRegistry() *TypeRegistry {
+	return &TypeRegistry{types: make(map[string]*registeredType)}
+}
+
+// Register adds a named type to the registry visible to the schemas with one of
+// the visibilities or to all schemas if there's none. Registering the type again
+// adds to its visibilities. It returns an error if a different type with the same
+// name is registered.
+func (r *TypeRegistry) Register(ttype Type, visibility ...string) error {
+	if ttype == nil || ttype.Name() == "" {
+		return fmt.Errorf("graphql: can't register a type without a name")
+	}
+	if ttype.Error() != nil {
+		return ttype.Error()
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rt, ok := r.types[ttype.Name()]
+	if !ok {
+		r.types[ttype.Name()] = &registeredType{ttype: ttype, visibility: slices.Clone(visibility)}
+		return nil
+	}
+	if rt.ttype != ttype {
+		return fmt.Errorf("graphql: a different type named %q is already registered", ttype.Name())
+	}
+	if rt.visibility != nil {
+		if len(visibility) == 0 {
+			rt.visibility = nil
+		} else {
+			for _, v := range visibility {
+				if !slices.Contains(rt.visibility, v) {
+					rt.visibility = append(rt.visibility, v)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// MustRegister is like Register but panics on error.
+func (r *TypeRegistry) MustRegister(ttype Type, visibility ...string) {
+	if err := r.Register(ttype, visibility...); err != nil {
+		panic(err)
+	}
+}
+
+// Lookup returns the registered type with the name or nil if there's none.
+func (r *TypeRegistry) Lookup(name string) Type {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if rt, ok := r.types[name]; ok {
+		return rt.ttype
+	}
+	return nil
+}
+
+// Types returns the registered types visible to the schemas with the visibility
+// sorted by name.
+func (r *TypeRegistry) Types(visibility string) []Type {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var types []Type
+	for _, rt := range r.types {
+		if rt.visibleTo(visibility) {
+			types = append(types, rt.ttype)
+		}
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i].Name() < types[j].Name() })
+	return types
+}
+
+// RegisteredType returns the type of the registry with the name, creating and
+// registering it with the visibilities if it's not registered yet, so that code
+// building several schemas creates each shared type once. It panics if the
+// registered type isn't a T.
+//
+//	money := graphql.RegisteredType(registry, "Money", func() *graphql.Object {
+//		return graphql.NewObject(graphql.ObjectConfig{Name: "Money", ...})
+//	})
+func RegisteredType[T Type](r *TypeRegistry, name string, create func() T, visibility ...string) T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if rt, ok := r.types[name]; ok {
+		t, ok := rt.ttype.(T)
+		if !ok {
+			panic(fmt.Sprintf("graphql: registered type %q is a %T", name, rt.ttype))
+		}
+		return t
+	}
+	t := create()
+	if t.Name() != name {
+		panic(fmt.Sprintf("graphql: created type %q is registered as %q", t.Name(), name))
+	}
+	r.types[name] = &registeredType{ttype: t, visibility: slices.Clone(visibility)}
+	return t
+}
+
+func (rt *registeredType) visibleTo(visibility string) bool {
+	return rt.visibility == nil || slices.Contains(rt.visibility, visibility)
+}
+
+// checkSchemaTypes returns an error if a type of the schema has the name of a
+// registered type but is a different instance, or is a registered type that isn't
+// visible to the schema (e.g. it's reachable through a field of a shared type).
+func (r *TypeRegistry) checkSchemaTypes(typeMap TypeMap, visibility string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, name := range sortedKeys(typeMap) {
+		rt, ok := r.types[name]
+		if !ok {
+			continue
+		}
+		if rt.ttype != typeMap[name] {
+			return gqlerrors.NewFormattedError(fmt.Sprintf(`Schema type "%s" must be the type of the registry with the same name.`, name))
+		}
+		if !rt.visibleTo(visibility) {
+			return gqlerrors.NewFormattedError(fmt.Sprintf(`Registered type "%s" is not visible to schemas with visibility %q.`, name, visibility))
+		}
+	}
+	return nil
+}
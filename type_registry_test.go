@@ -0,0 +1,117 @@
+package graphql_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+func TestTypeRegistry(t *testing.T) {
+	registry := graphql.NewTypeRegistry()
+	newMoney := func() *graphql.Object {
+		return graphql.NewObject(graphql.ObjectConfig{
+			Name: "Money",
+			Fields: graphql.Fields{
+				"amount": &graphql.Field{Type: graphql.Int},
+			},
+		})
+	}
+	money := graphql.RegisteredType(registry, "Money", newMoney)
+	if again := graphql.RegisteredType(registry, "Money", newMoney); again != money {
+		t.Fatal("Expected the registered type to be reused")
+	}
+	audit := graphql.NewObject(graphql.ObjectConfig{
+		Name: "AuditEntry",
+		Fields: graphql.Fields{
+			"action": &graphql.Field{Type: graphql.String},
+		},
+	})
+	registry.MustRegister(audit, "admin")
+	if err := registry.Register(newMoney()); err == nil {
+		t.Fatal("Expected an error registering a different type with the same name")
+	}
+	if registry.Lookup("Money") != money {
+		t.Fatal("Expected Lookup to return the registered type")
+	}
+
+	newQuery := func() *graphql.Object {
+		return graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"balance": &graphql.Field{Type: money},
+			},
+		})
+	}
+	typeNames := func(schema graphql.Schema) []string {
+		var names []string
+		for _, name := range []string{"AuditEntry", "Money"} {
+			if schema.Type(name) != nil {
+				names = append(names, name)
+			}
+		}
+		return names
+	}
+
+	t.Run("visibility", func(t *testing.T) {
+		for visibility, expected := range map[string][]string{
+			"admin":  {"AuditEntry", "Money"},
+			"public": {"Money"},
+		} {
+			schema, err := graphql.NewSchema(graphql.SchemaConfig{
+				Query:          newQuery(),
+				TypeRegistry:   registry,
+				TypeVisibility: visibility,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if names := typeNames(schema); !reflect.DeepEqual(expected, names) {
+				t.Fatalf("Unexpected types for %s, Diff: %v", visibility, testutil.Diff(expected, names))
+			}
+			result := graphql.Do(context.Background(), graphql.Params{
+				Schema:        schema,
+				RequestString: `{ balance { amount } }`,
+				RootObject:    map[string]any{"balance": map[string]any{"amount": 5}},
+			})
+			if len(result.Errors) != 0 {
+				t.Fatal(result.Errors)
+			}
+		}
+	})
+
+	t.Run("different instance", func(t *testing.T) {
+		_, err := graphql.NewSchema(graphql.SchemaConfig{
+			Query: graphql.NewObject(graphql.ObjectConfig{
+				Name: "Query",
+				Fields: graphql.Fields{
+					"balance": &graphql.Field{Type: newMoney()},
+				},
+			}),
+			TypeRegistry: registry,
+		})
+		expected := `Schema must contain unique named types but contains multiple types named "Money".`
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error %q, got %v", expected, err)
+		}
+	})
+
+	t.Run("hidden type", func(t *testing.T) {
+		_, err := graphql.NewSchema(graphql.SchemaConfig{
+			Query: graphql.NewObject(graphql.ObjectConfig{
+				Name: "Query",
+				Fields: graphql.Fields{
+					"audit": &graphql.Field{Type: graphql.NewList(audit)},
+				},
+			}),
+			TypeRegistry:   registry,
+			TypeVisibility: "public",
+		})
+		expected := `Registered type "AuditEntry" is not visible to schemas with visibility "public".`
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error %q, got %v", expected, err)
+		}
+	})
+}
@@ -0,0 +1,104 @@
+package graphql_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+)
+
+type nilTestAccount struct {
+	Name  string          `json:"name"`
+	Owner *nilTestAccount `json:"owner"`
+}
+
+type nilTestNode interface {
+	node()
+}
+
+func (*nilTestAccount) node() {}
+
+func TestTypedNilsAreNull(t *testing.T) {
+	nodeType := graphql.NewInterface(graphql.InterfaceConfig{
+		Name: "Node",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.String},
+		},
+	})
+	accountType := graphql.NewObject(graphql.ObjectConfig{
+		Name:       "Account",
+		Interfaces: []*graphql.Interface{nodeType},
+		IsTypeOf: func(p graphql.IsTypeOfParams) bool {
+			return true
+		},
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.String},
+		},
+	})
+	accountType.AddFieldConfig("owner", &graphql.Field{Type: accountType})
+
+	var nilAccount *nilTestAccount
+	account := &nilTestAccount{Name: "a"}
+	var nilNode nilTestNode = nilAccount
+
+	cases := []struct {
+		name     string
+		typ      graphql.Output
+		value    any
+		expected any
+		errors   int
+		query    string
+	}{
+		{name: "nil pointer", typ: accountType, value: nilAccount, expected: nil},
+		{name: "nil pointer to non-null", typ: graphql.NewNonNull(accountType), value: nilAccount, errors: 1},
+		{name: "pointer to nil pointer", typ: accountType, value: &nilAccount, expected: nil},
+		{name: "pointer to pointer", typ: accountType, value: &account, expected: map[string]any{"name": "a"}},
+		{name: "nil pointer field", typ: accountType, value: &nilTestAccount{Name: "b"}, expected: map[string]any{"name": "b", "owner": nil}, query: `{ test { name owner { name } } }`},
+		{name: "interface holding nil pointer", typ: nodeType, value: nilNode, expected: nil},
+		{name: "slice of pointers", typ: graphql.NewList(accountType), value: []*nilTestAccount{account, nil}, expected: []any{map[string]any{"name": "a"}, nil}},
+		{name: "slice of interfaces", typ: graphql.NewList(nodeType), value: []nilTestNode{nilNode, nil}, expected: []any{nil, nil}},
+		{name: "slice of non-null pointers", typ: graphql.NewList(graphql.NewNonNull(accountType)), value: []*nilTestAccount{nil}, expected: nil, errors: 1},
+		{name: "nil map", typ: accountType, value: map[string]any(nil), expected: nil},
+		{name: "nil func", typ: accountType, value: (func() any)(nil), expected: nil},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			schema, err := graphql.NewSchema(graphql.SchemaConfig{
+				Query: graphql.NewObject(graphql.ObjectConfig{
+					Name: "Query",
+					Fields: graphql.Fields{
+						"test": &graphql.Field{
+							Type: c.typ,
+							Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+								return c.value, nil
+							},
+						},
+					},
+				}),
+				Types: []graphql.Type{accountType},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			query := c.query
+			if query == "" {
+				query = `{ test { name } }`
+			}
+			result := graphql.Do(context.Background(), graphql.Params{
+				Schema:        schema,
+				RequestString: query,
+			})
+			if len(result.Errors) != c.errors {
+				t.Fatalf("Expected %d errors, got %v", c.errors, result.Errors)
+			}
+			if c.errors != 0 && result.Data == nil {
+				return
+			}
+			expected := map[string]any{"test": c.expected}
+			if !reflect.DeepEqual(expected, result.Data) {
+				t.Fatalf("Expected %v, got %v", expected, result.Data)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package graphql
+
+import (
+	"github.com/sprucehealth/graphql/language/ast"
+)
+
+// TypenameProbe answers an operation that only requests `__typename` on the root
+// type (e.g. `{ __typename }` as sent by gateways and load balancers to check
+// that a server is up) without executing it. It returns false if the operation
+// requests anything else, uses directives or fragments, or if the document
+// doesn't have the operation. The document is expected to be valid.
+func TypenameProbe(schema *Schema, doc *ast.Document, operationName string) (*Result, bool) {
+	operation, _ := selectOperation(doc, operationName)
+	op, ok := operation.(*ast.OperationDefinition)
+	if !ok || len(op.Directives) != 0 || !isTypenameSelectionSet(op.SelectionSet) {
+		return nil, false
+	}
+	root, err := getOperationRootType(*schema, op)
+	if err != nil {
+		return nil, false
+	}
+	data := make(map[string]any, len(op.SelectionSet.Selections))
+	for _, sel := range op.SelectionSet.Selections {
+		data[getFieldEntryKey(sel.(*ast.Field))] = root.Name()
+	}
+	return &Result{Data: data}, true
+}
+
+// selectsOnlyTypename returns true if the selection sets of the fields only
+// request __typename without directives or fragments.
+func selectsOnlyTypename(fieldASTs []*ast.Field) bool {
+	for _, fieldAST := range fieldASTs {
+		if fieldAST != nil && !isTypenameSelectionSet(fieldAST.SelectionSet) {
+			return false
+		}
+	}
+	return true
+}
+
+func isTypenameSelectionSet(selectionSet *ast.SelectionSet) bool {
+	if selectionSet == nil || len(selectionSet.Selections) == 0 {
+		return false
+	}
+	for _, sel := range selectionSet.Selections {
+		field, ok := sel.(*ast.Field)
+		if !ok || field.Name == nil || field.Name.Value != TypeNameMetaFieldDef.Name || len(field.Directives) != 0 {
+			return false
+		}
+	}
+	return true
+}
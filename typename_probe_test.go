@@ -0,0 +1,86 @@
+package graphql_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/language/parser"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+func TestTypenameProbe(t *testing.T) {
+	var isTypeOfCalls int
+	userType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.String},
+		},
+		IsTypeOf: func(p graphql.IsTypeOfParams) bool {
+			isTypeOfCalls++
+			return true
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"user": &graphql.Field{
+					Type: userType,
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						return map[string]any{"name": "Ann"}, nil
+					},
+				},
+			},
+		}),
+		Mutation: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Mutation",
+			Fields: graphql.Fields{
+				"noop": &graphql.Field{Type: graphql.Boolean},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]*graphql.Result{
+		`{ __typename }`: {Data: map[string]any{"__typename": "Query"}},
+		`query Probe { a: __typename __typename }`: {Data: map[string]any{"a": "Query", "__typename": "Query"}},
+		`mutation { __typename }`:                  {Data: map[string]any{"__typename": "Mutation"}},
+		`{ __typename user { name } }`:             nil,
+		`{ __typename @skip(if: true) }`:           nil,
+		`{ ... on Query { __typename } }`:          nil,
+	}
+	for query, expected := range cases {
+		doc, err := parser.Parse(parser.ParseParams{Source: query})
+		if err != nil {
+			t.Fatal(err)
+		}
+		result, ok := graphql.TypenameProbe(&schema, doc, "")
+		if ok != (expected != nil) || !reflect.DeepEqual(expected, result) {
+			t.Errorf("%s: unexpected result %v, %t, Diff: %v", query, result, ok, testutil.Diff(expected, result))
+		}
+	}
+
+	// The type of an object isn't checked when only __typename is requested
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: `{ user { __typename } }`,
+	})
+	expected := &graphql.Result{Data: map[string]any{"user": map[string]any{"__typename": "User"}}}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	if isTypeOfCalls != 0 {
+		t.Fatalf("Expected no IsTypeOf calls, got %d", isTypeOfCalls)
+	}
+	graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: `{ user { __typename name } }`,
+	})
+	if isTypeOfCalls != 1 {
+		t.Fatalf("Expected 1 IsTypeOf call, got %d", isTypeOfCalls)
+	}
+}
@@ -7,8 +7,27 @@ import (
 // type Schema any
 
 type Result struct {
-	Data   any                        `json:"data"`
-	Errors []gqlerrors.FormattedError `json:"errors,omitempty"`
+	Data       any                        `json:"data"`
+	Errors     []gqlerrors.FormattedError `json:"errors,omitempty"`
+	Extensions map[string]any             `json:"extensions,omitempty"`
+	// CachePolicy is the overall cache policy of the response when cache control is enabled.
+	CachePolicy *CachePolicy `json:"-"`
+	// KeepAlive is true for the results Subscribe sends to keep a connection alive.
+	// They have no data and should be sent as a keepalive message of the protocol.
+	KeepAlive bool `json:"-"`
+}
+
+// NullValue is the type of Null.
+type NullValue struct{}
+
+// Null can be returned by resolvers (or used as a value in a map or struct
+// source) to explicitly resolve a field to null. It's never omitted or coerced to
+// another value, and for a non-null field it results in the usual null error.
+var Null = NullValue{}
+
+// MarshalJSON implements json.Marshaler.
+func (NullValue) MarshalJSON() ([]byte, error) {
+	return []byte("null"), nil
 }
 
 func (r *Result) HasErrors() bool {
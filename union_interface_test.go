@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/gqlerrors"
+	"github.com/sprucehealth/graphql/language/location"
 	"github.com/sprucehealth/graphql/testutil"
 )
 
@@ -585,3 +587,168 @@ func TestUnionIntersectionTypes_GetsExecutionInfoInResolver(t *testing.T) {
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(schema2, encounteredSchema))
 	}
 }
+
+func TestUnionIntersectionTypes_RestrictedTypes(t *testing.T) {
+	doc := `
+      {
+        pets {
+          ... on Cat { name }
+          ... on Dog { name }
+        }
+        Pet: __type(name: "Pet") {
+          possibleTypes { name }
+        }
+      }
+	`
+	expected := &graphql.Result{
+		Data: map[string]any{
+			"pets": []any{
+				nil,
+				map[string]any{
+					"name": "Odie",
+				},
+			},
+			"Pet": map[string]any{
+				"possibleTypes": []any{
+					map[string]any{
+						"name": "Dog",
+					},
+				},
+			},
+		},
+		Errors: []gqlerrors.FormattedError{
+			{
+				Message:   `Runtime Object type "Cat" is restricted for "Pet" in this request.`,
+				Type:      gqlerrors.ErrorTypeRestrictedType,
+				Code:      gqlerrors.CodeRestrictedType,
+				Locations: []location.SourceLocation{{Line: 3, Column: 9}},
+				Path:      []any{"pets", 0},
+			},
+		},
+	}
+	ep := graphql.ExecuteParams{
+		Schema:          unionInterfaceTestSchema,
+		AST:             testutil.TestParse(t, doc),
+		Root:            john,
+		RestrictedTypes: []string{"Cat"},
+	}
+	result := testutil.TestExecute(t, context.Background(), ep)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	// Restrictions only apply to the request they're provided for.
+	ep.RestrictedTypes = nil
+	result = testutil.TestExecute(t, context.Background(), ep)
+	if len(result.Errors) != 0 {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+}
+
+func TestUnionIntersectionTypes_AliasedTypenameUnderAbstractTypes(t *testing.T) {
+	doc := `
+      {
+        pets {
+          kind: __typename
+          ... on Dog {
+            __typename
+            dogKind: __typename
+            name
+          }
+          ... on Cat {
+            catKind: __typename
+            meows
+          }
+        }
+        friends {
+          __typename
+          ... on Named {
+            kind: __typename
+          }
+        }
+      }
+	`
+	expected := &graphql.Result{
+		Data: map[string]any{
+			"pets": []any{
+				map[string]any{
+					"kind":    "Cat",
+					"catKind": "Cat",
+					"meows":   false,
+				},
+				map[string]any{
+					"kind":       "Dog",
+					"__typename": "Dog",
+					"dogKind":    "Dog",
+					"name":       "Odie",
+				},
+			},
+			"friends": []any{
+				map[string]any{
+					"__typename": "Person",
+					"kind":       "Person",
+				},
+				map[string]any{
+					"__typename": "Dog",
+					"kind":       "Dog",
+				},
+			},
+		},
+	}
+	ep := graphql.ExecuteParams{
+		Schema: unionInterfaceTestSchema,
+		AST:    testutil.TestParse(t, doc),
+		Root:   john,
+	}
+	result := testutil.TestExecute(t, context.Background(), ep)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestUnionIntersectionTypes_SelectedFieldNames(t *testing.T) {
+	var selected map[string][]string
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"pet": &graphql.Field{
+					Type: petType,
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						selected = map[string][]string{
+							"Dog": p.Info.SelectedFieldNames(dogType),
+							"Cat": p.Info.SelectedFieldNames(catType),
+						}
+						return odie, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema: schema,
+		RequestString: `query ($skip: Boolean!) {
+			pet {
+				kind: __typename
+				... on Dog { name n: name barks @skip(if: $skip) }
+				...CatFields
+				... on Named { name }
+			}
+		}
+		fragment CatFields on Cat { meows __typename }`,
+		VariableValues: map[string]any{"skip": true},
+	})
+	if len(result.Errors) != 0 {
+		t.Fatalf("Unexpected errors %+v", result.Errors)
+	}
+	expected := map[string][]string{
+		"Dog": {"__typename", "name"},
+		"Cat": {"__typename", "meows", "name"},
+	}
+	if !reflect.DeepEqual(expected, selected) {
+		t.Fatalf("Unexpected selected fields, Diff: %v", testutil.Diff(expected, selected))
+	}
+}
@@ -0,0 +1,48 @@
+package graphql
+
+import (
+	"github.com/sprucehealth/graphql/gqlerrors"
+	"github.com/sprucehealth/graphql/language/ast"
+)
+
+// ValidateVariables checks the variables of a request against the variable
+// definitions of the operation with the name, or the only operation if the name
+// is empty, without executing it (e.g. so that an edge service can reject a bad
+// payload before forwarding the request). It returns an INVALID_INPUT error for
+// every variable that can't be coerced to its type in the same way as Execute
+// does, or an error if the document doesn't have the operation. The document is
+// expected to be valid (see ValidateDocument).
+func ValidateVariables(schema *Schema, doc *ast.Document, operationName string, variables map[string]any) []gqlerrors.FormattedError {
+	operation, _ := selectOperation(doc, operationName)
+	op, ok := operation.(*ast.OperationDefinition)
+	if !ok {
+		switch {
+		case operationName != "":
+			return gqlerrors.FormatErrors(gqlerrors.CodeUnknownOperation.New(nil, nil, operationName))
+		case hasOperations(doc):
+			return gqlerrors.FormatErrors(gqlerrors.CodeOperationNameRequired.New(nil, nil))
+		}
+		return gqlerrors.FormatErrors(gqlerrors.CodeMissingOperation.New(nil, nil))
+	}
+	var errs []gqlerrors.FormattedError
+	for _, defAST := range op.VariableDefinitions {
+		if defAST == nil || defAST.Variable == nil || defAST.Variable.Name == nil {
+			continue
+		}
+		input, provided := variables[defAST.Variable.Name.Value]
+		if _, err := getVariableValue(*schema, defAST, input, provided); err != nil {
+			errs = append(errs, gqlerrors.FormatError(err))
+		}
+	}
+	return errs
+}
+
+// hasOperations returns true if the document has an operation definition.
+func hasOperations(doc *ast.Document) bool {
+	for _, def := range doc.Definitions {
+		if _, ok := def.(*ast.OperationDefinition); ok {
+			return true
+		}
+	}
+	return false
+}
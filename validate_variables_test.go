@@ -0,0 +1,63 @@
+package graphql_test
+
+import (
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/gqlerrors"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+func TestValidateVariables(t *testing.T) {
+	schema, err := testutil.SchemaFromSDL(`
+		enum Color { RED GREEN }
+		input Filter { color: Color!, limit: Int }
+		type Query { items(filter: Filter, ids: [ID!]): [String] }
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := testutil.TestParse(t, `
+		query Items($filter: Filter!, $ids: [ID!], $limit: Int = 10) { items(filter: $filter, ids: $ids) }
+		query Other { items }
+	`)
+
+	valid := []map[string]any{
+		{"filter": map[string]any{"color": "RED"}},
+		{"filter": map[string]any{"color": "GREEN", "limit": 5}, "ids": []any{"1", 2}, "limit": nil},
+	}
+	for _, variables := range valid {
+		if errs := graphql.ValidateVariables(schema, doc, "Items", variables); len(errs) != 0 {
+			t.Errorf("%v: unexpected errors %v", variables, errs)
+		}
+	}
+
+	invalid := map[string]map[string]any{
+		`Variable "$filter" of required type "Filter!" was not provided.`: {},
+		`Variable "$filter" got invalid value {"color":"BLUE"}.` + "\nIn field \"color\": Expected type \"Color\", found \"BLUE\".": {
+			"filter": map[string]any{"color": "BLUE"},
+		},
+	}
+	for message, variables := range invalid {
+		errs := graphql.ValidateVariables(schema, doc, "Items", variables)
+		if len(errs) != 1 || errs[0].Message != message || errs[0].Type != gqlerrors.ErrorTypeInvalidInput {
+			t.Errorf("%v: expected error %q, got %+v", variables, message, errs)
+		}
+	}
+
+	// Every invalid variable is reported
+	errs := graphql.ValidateVariables(schema, doc, "Items", map[string]any{"ids": []any{nil}, "limit": "ten"})
+	if len(errs) != 3 {
+		t.Fatalf("Expected 3 errors, got %+v", errs)
+	}
+
+	for name, message := range map[string]string{
+		"":        "Must provide operation name if query contains multiple operations.",
+		"Unknown": `Unknown operation named "Unknown".`,
+	} {
+		errs := graphql.ValidateVariables(schema, doc, name, nil)
+		if len(errs) != 1 || errs[0].Message != message {
+			t.Errorf("%q: expected error %q, got %+v", name, message, errs)
+		}
+	}
+}
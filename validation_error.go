@@ -0,0 +1,44 @@
+package graphql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/sprucehealth/graphql/gqlerrors"
+)
+
+// ValidationError is returned by DoE with all the errors of a request that
+// produced no data (e.g. it failed to parse or validate) or, when
+// Params.FieldErrorsAsError is set, of a request whose fields failed.
+type ValidationError struct {
+	Errors []gqlerrors.FormattedError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Message
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the errors so that errors.Is and errors.As match any of them
+// or the errors they wrap.
+func (e *ValidationError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+	return errs
+}
+
+// DoE is like Do but returns a *ValidationError instead of a result if the
+// request produced no data. Otherwise the result is returned even if some of
+// its fields failed unless Params.FieldErrorsAsError is set.
+func DoE(ctx context.Context, p Params) (*Result, error) {
+	result := Do(ctx, p)
+	if len(result.Errors) != 0 && (result.Data == nil || p.FieldErrorsAsError) {
+		return nil, &ValidationError{Errors: result.Errors}
+	}
+	return result, nil
+}
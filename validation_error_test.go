@@ -0,0 +1,67 @@
+package graphql_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+)
+
+var errTestField = errors.New("test failed")
+
+func TestDoE(t *testing.T) {
+	schema := testSchema(t, &graphql.Field{
+		Type: graphql.String,
+		Args: graphql.FieldConfigArgument{
+			"fail": &graphql.ArgumentConfig{Type: graphql.Boolean},
+		},
+		Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+			if fail, _ := p.Args["fail"].(bool); fail {
+				return nil, errTestField
+			}
+			return "ok", nil
+		},
+	})
+
+	result, err := graphql.DoE(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: `{ test }`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := map[string]any{"test": "ok"}; !reflect.DeepEqual(expected, result.Data) {
+		t.Fatalf("Expected data %v, got %v", expected, result.Data)
+	}
+
+	result, err = graphql.DoE(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: `{ unknown other }`,
+	})
+	var verr *graphql.ValidationError
+	if result != nil || !errors.As(err, &verr) || len(verr.Errors) != 2 {
+		t.Fatalf("Expected validation error with 2 errors, got %v and %v", result, err)
+	}
+	if expected := `Cannot query field "unknown" on type "Query".; Cannot query field "other" on type "Query".`; err.Error() != expected {
+		t.Fatalf("Expected error %q, got %q", expected, err.Error())
+	}
+
+	// Field errors are returned in the result unless FieldErrorsAsError is set
+	result, err = graphql.DoE(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: `{ test(fail: true) }`,
+	})
+	if err != nil || len(result.Errors) != 1 {
+		t.Fatalf("Expected result with a field error, got %v and %v", result, err)
+	}
+	result, err = graphql.DoE(context.Background(), graphql.Params{
+		Schema:             schema,
+		RequestString:      `{ test(fail: true) }`,
+		FieldErrorsAsError: true,
+	})
+	if result != nil || !errors.Is(err, errTestField) {
+		t.Fatalf("Expected error wrapping the field error, got %v and %v", result, err)
+	}
+}
@@ -1,6 +1,10 @@
 package graphql
 
 import (
+	"reflect"
+	"runtime"
+	"strings"
+
 	"github.com/sprucehealth/graphql/gqlerrors"
 	"github.com/sprucehealth/graphql/language/ast"
 	"github.com/sprucehealth/graphql/language/visitor"
@@ -100,11 +104,42 @@ func VisitUsingRules(schema *Schema, typeInfo *TypeInfo, astDoc *ast.Document, r
 	}
 
 	for _, rule := range rules {
+		n := len(context.errors)
 		visitInstance(astDoc, rule(context))
+		if len(context.errors) > n {
+			name := ValidationRuleName(rule)
+			for i := n; i < len(context.errors); i++ {
+				context.errors[i].Extensions = withRuleExtension(context.errors[i].Extensions, name)
+			}
+		}
 	}
 	return context.Errors()
 }
 
+// ValidationRuleExtensionKey is the key in FormattedError.Extensions of validation
+// errors that holds the name of the rule that reported the error.
+const ValidationRuleExtensionKey = "rule"
+
+// ValidationRuleName returns the name of a validation rule as reported in the
+// extensions of its errors (e.g. "FieldsOnCorrectTypeRule").
+func ValidationRuleName(rule ValidationRuleFn) string {
+	fn := runtime.FuncForPC(reflect.ValueOf(rule).Pointer())
+	if fn == nil {
+		return ""
+	}
+	name := fn.Name()
+	return name[strings.LastIndexByte(name, '.')+1:]
+}
+
+func withRuleExtension(ext map[string]any, rule string) map[string]any {
+	m := make(map[string]any, len(ext)+1)
+	for k, v := range ext {
+		m[k] = v
+	}
+	m[ValidationRuleExtensionKey] = rule
+	return m
+}
+
 type HasSelectionSet interface {
 	GetLoc() ast.Location
 	GetSelectionSet() *ast.SelectionSet
@@ -116,6 +151,9 @@ var _ HasSelectionSet = (*ast.FragmentDefinition)(nil)
 type VariableUsage struct {
 	Node *ast.Variable
 	Type Input
+	// DefaultValue is the default value of the argument or input object field the
+	// variable is used for.
+	DefaultValue any
 }
 
 type ValidationContext struct {
@@ -273,8 +311,9 @@ func (ctx *ValidationContext) VariableUsages(node HasSelectionSet) []*VariableUs
 					return visitor.ActionSkip, nil
 				case *ast.Variable:
 					usages = append(usages, &VariableUsage{
-						Node: node,
-						Type: typeInfo.InputType(),
+						Node:         node,
+						Type:         typeInfo.InputType(),
+						DefaultValue: typeInfo.DefaultValue(),
 					})
 				}
 			}
@@ -164,6 +164,7 @@ func TestValidator_SupportsFullValidation_ValidatesUsingACustomTypeInfo(t *testi
 			Locations: []location.SourceLocation{
 				{Line: 3, Column: 9},
 			},
+			Extensions: map[string]any{"rule": "FieldsOnCorrectTypeRule"},
 		},
 		{
 			Type:    gqlerrors.ErrorTypeBadQuery,
@@ -171,6 +172,7 @@ func TestValidator_SupportsFullValidation_ValidatesUsingACustomTypeInfo(t *testi
 			Locations: []location.SourceLocation{
 				{Line: 5, Column: 13},
 			},
+			Extensions: map[string]any{"rule": "FieldsOnCorrectTypeRule"},
 		},
 		{
 			Type:    gqlerrors.ErrorTypeBadQuery,
@@ -178,6 +180,7 @@ func TestValidator_SupportsFullValidation_ValidatesUsingACustomTypeInfo(t *testi
 			Locations: []location.SourceLocation{
 				{Line: 8, Column: 13},
 			},
+			Extensions: map[string]any{"rule": "FieldsOnCorrectTypeRule"},
 		},
 	}
 	if !reflect.DeepEqual(expectedErrors, errors) {
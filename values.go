@@ -6,7 +6,7 @@ import (
 	"math"
 	"reflect"
 	"sort"
-	"strings"
+	"strconv"
 
 	"github.com/sprucehealth/graphql/gqlerrors"
 	"github.com/sprucehealth/graphql/language/ast"
@@ -23,15 +23,110 @@ func getVariableValues(schema Schema, definitionASTs []*ast.VariableDefinition,
 			continue
 		}
 		varName := defAST.Variable.Name.Value
-		varValue, err := getVariableValue(schema, defAST, inputs[varName])
+		input, provided := inputs[varName]
+		varValue, err := getVariableValue(schema, defAST, input, provided)
 		if err != nil {
 			return values, err
 		}
-		values[varName] = varValue
+		// Variables that aren't provided and have no default value are left out so
+		// that the default values of arguments are used instead.
+		if provided || defAST.DefaultValue != nil {
+			values[varName] = varValue
+		}
 	}
 	return values, nil
 }
 
+// unknownVariableErrors returns an error for each input that isn't a variable
+// defined by the operation, sorted by name.
+func unknownVariableErrors(operation *ast.OperationDefinition, inputs map[string]any) []gqlerrors.FormattedError {
+	var unknown []string
+	for name := range inputs {
+		if !definesVariable(operation.VariableDefinitions, name) {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	sort.Strings(unknown)
+	operationName := ""
+	if operation.Name != nil {
+		operationName = fmt.Sprintf(" %q", operation.Name.Value)
+	}
+	errs := make([]gqlerrors.FormattedError, len(unknown))
+	for i, name := range unknown {
+		errs[i] = gqlerrors.FormatError(gqlerrors.CodeUndefinedVariable.New([]ast.Node{operation}, nil, name, operationName))
+	}
+	return errs
+}
+
+func definesVariable(definitionASTs []*ast.VariableDefinition, name string) bool {
+	for _, defAST := range definitionASTs {
+		if defAST != nil && defAST.Variable != nil && defAST.Variable.Name != nil && defAST.Variable.Name.Value == name {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonNumberVariables returns a copy of the variables with all numeric values
+// converted to json.Number.
+func jsonNumberVariables(inputs map[string]any) map[string]any {
+	if inputs == nil {
+		return nil
+	}
+	values := make(map[string]any, len(inputs))
+	for name, value := range inputs {
+		values[name] = jsonNumberValue(value)
+	}
+	return values
+}
+
+func jsonNumberValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		return jsonNumberVariables(v)
+	case []any:
+		values := make([]any, len(v))
+		for i, item := range v {
+			values[i] = jsonNumberValue(item)
+		}
+		return values
+	case int:
+		return json.Number(strconv.Itoa(v))
+	case int8:
+		return json.Number(strconv.FormatInt(int64(v), 10))
+	case int16:
+		return json.Number(strconv.FormatInt(int64(v), 10))
+	case int32:
+		return json.Number(strconv.FormatInt(int64(v), 10))
+	case int64:
+		return json.Number(strconv.FormatInt(v, 10))
+	case uint:
+		return json.Number(strconv.FormatUint(uint64(v), 10))
+	case uint8:
+		return json.Number(strconv.FormatUint(uint64(v), 10))
+	case uint16:
+		return json.Number(strconv.FormatUint(uint64(v), 10))
+	case uint32:
+		return json.Number(strconv.FormatUint(uint64(v), 10))
+	case uint64:
+		return json.Number(strconv.FormatUint(v, 10))
+	case float32:
+		if math.IsNaN(float64(v)) || math.IsInf(float64(v), 0) {
+			return value
+		}
+		return json.Number(strconv.FormatFloat(float64(v), 'f', -1, 32))
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return value
+		}
+		return json.Number(strconv.FormatFloat(v, 'f', -1, 64))
+	}
+	return value
+}
+
 // Prepares an object map of argument values given a list of argument
 // definitions and list of argument AST nodes.
 func getArgumentValues(argDefs []*Argument, argASTs []*ast.Argument, variableVariables map[string]any) map[string]any {
@@ -45,11 +140,11 @@ func getArgumentValues(argDefs []*Argument, argASTs []*ast.Argument, variableVar
 	for _, argDef := range argDefs {
 		name := argDef.PrivateName
 		var valueAST ast.Value
-		if argAST, ok := argASTMap[name]; ok {
+		if argAST, ok := lookupAlias(argASTMap, name, argDef.Aliases); ok {
 			valueAST = argAST.Value
 		}
 		value := valueFromAST(valueAST, argDef.Type, variableVariables)
-		if isNullish(value) {
+		if isNullish(value) && !isNullVariable(valueAST, variableVariables) {
 			value = argDef.DefaultValue
 		}
 		if !isNullish(value) {
@@ -59,9 +154,72 @@ func getArgumentValues(argDefs []*Argument, argASTs []*ast.Argument, variableVar
 	return results
 }
 
+// copyArgValues replaces the input object and list values of the arguments with
+// copies so that resolvers can't modify the values of variables and default
+// values shared with other fields.
+func copyArgValues(args map[string]any) {
+	for name, value := range args {
+		switch value.(type) {
+		case map[string]any, []any:
+			args[name] = copyArgValue(value)
+		}
+	}
+}
+
+func copyArgValue(value any) any {
+	switch value := value.(type) {
+	case map[string]any:
+		m := make(map[string]any, len(value))
+		for k, v := range value {
+			m[k] = copyArgValue(v)
+		}
+		return m
+	case []any:
+		s := make([]any, len(value))
+		for i, v := range value {
+			s[i] = copyArgValue(v)
+		}
+		return s
+	}
+	return value
+}
+
+// isNullVariable returns true if the value is a variable that's explicitly set to
+// null in which case the default value of the argument isn't used.
+func isNullVariable(valueAST ast.Value, variables map[string]any) bool {
+	variable, ok := valueAST.(*ast.Variable)
+	if !ok || variable.Name == nil {
+		return false
+	}
+	value, ok := variables[variable.Name.Value]
+	return ok && isNullish(value)
+}
+
+// checkNullVariableArguments returns an error if an argument of a non-null type
+// is set to a variable that's explicitly null. Validation allows a nullable
+// variable with a default value in a non-null position so the null is only
+// caught when the arguments are coerced.
+func checkNullVariableArguments(argDefs []*Argument, argASTs []*ast.Argument, variables map[string]any) error {
+	for _, argAST := range argASTs {
+		if argAST.Name == nil || !isNullVariable(argAST.Value, variables) {
+			continue
+		}
+		for _, argDef := range argDefs {
+			if !argDef.hasName(argAST.Name.Value) {
+				continue
+			}
+			if _, ok := argDef.Type.(*NonNull); ok {
+				return fmt.Errorf(`Argument "%s" of non-null type "%s" must not be null.`, argDef.PrivateName, argDef.Type)
+			}
+		}
+	}
+	return nil
+}
+
 // Given a variable definition, and any value of input, return a value which
-// adheres to the variable definition, or throw an error.
-func getVariableValue(schema Schema, definitionAST *ast.VariableDefinition, input any) (any, error) {
+// adheres to the variable definition, or throw an error. The default value of the
+// variable is only used if no value is provided as an explicit null overrides it.
+func getVariableValue(schema Schema, definitionAST *ast.VariableDefinition, input any, provided bool) (any, error) {
 	ttype, err := typeFromAST(schema, definitionAST.Type)
 	if err != nil {
 		return nil, err
@@ -69,41 +227,21 @@ func getVariableValue(schema Schema, definitionAST *ast.VariableDefinition, inpu
 	variable := definitionAST.Variable
 
 	if ttype == nil || !IsInputType(ttype) {
-		return "", gqlerrors.NewError(
-			gqlerrors.ErrorTypeInvalidInput,
-			fmt.Sprintf(`Variable "$%v" expected value of type `+
-				`"%v" which cannot be used as an input type.`, variable.Name.Value, printer.Print(definitionAST.Type)),
-			[]ast.Node{definitionAST},
-			"",
-			nil,
-			[]int{},
-			nil,
-		)
-	}
-
-	isValid, messages := isValidInputValue(input, ttype)
-	if isValid {
-		if isNullish(input) {
-			defaultValue := definitionAST.DefaultValue
-			if defaultValue != nil {
-				variables := map[string]any{}
-				val := valueFromAST(defaultValue, ttype, variables)
-				return val, nil
-			}
-		}
+		return "", gqlerrors.CodeVariableNotInputType.New([]ast.Node{definitionAST}, nil, variable.Name.Value, printer.Print(definitionAST.Type))
+	}
+
+	if !provided && definitionAST.DefaultValue != nil {
+		return valueFromAST(definitionAST.DefaultValue, ttype, map[string]any{}), nil
+	}
+	problems := inputValueProblems(input, ttype, schema.strictIDInput)
+	if len(problems) == 0 {
 		return coerceValue(ttype, input), nil
 	}
+	if provided && input == nil {
+		return "", gqlerrors.CodeNullVariable.New([]ast.Node{definitionAST}, nil, variable.Name.Value, printer.Print(definitionAST.Type))
+	}
 	if isNullish(input) {
-		return "", gqlerrors.NewError(
-			gqlerrors.ErrorTypeInvalidInput,
-			fmt.Sprintf(`Variable "$%v" of required type `+
-				`"%v" was not provided.`, variable.Name.Value, printer.Print(definitionAST.Type)),
-			[]ast.Node{definitionAST},
-			"",
-			nil,
-			[]int{},
-			nil,
-		)
+		return "", gqlerrors.CodeMissingVariable.New([]ast.Node{definitionAST}, nil, variable.Name.Value, printer.Print(definitionAST.Type))
 	}
 	// convert input interface into string for error message
 	var inputStr string
@@ -112,19 +250,29 @@ func getVariableValue(schema Schema, definitionAST *ast.VariableDefinition, inpu
 		inputStr = string(b)
 	}
 	messagesStr := ""
-	if len(messages) > 0 {
-		messagesStr = "\n" + strings.Join(messages, "\n")
-	}
-	return "", gqlerrors.NewError(
-		gqlerrors.ErrorTypeInvalidInput,
-		fmt.Sprintf(`Variable "$%v" got invalid value `+
-			`%v.%v`, variable.Name.Value, inputStr, messagesStr),
-		[]ast.Node{definitionAST},
-		"",
-		nil,
-		[]int{},
-		nil,
-	)
+	inputErrors := make([]map[string]any, len(problems))
+	for i, p := range problems {
+		messagesStr += "\n" + p.message
+		inputErrors[i] = map[string]any{
+			"inputPath": inputPath(p.path),
+			"message":   p.message,
+		}
+	}
+	gqlErr := gqlerrors.CodeInvalidVariable.New([]ast.Node{definitionAST}, nil, variable.Name.Value, inputStr, messagesStr)
+	// Provide the paths to the invalid values so clients can map errors to inputs.
+	gqlErr.Extensions = map[string]any{
+		"inputPath":   inputPath(problems[0].path),
+		"inputErrors": inputErrors,
+	}
+	return "", gqlErr
+}
+
+// inputPath returns a non-nil path so it's encoded as an empty list for the variable itself.
+func inputPath(path []any) []any {
+	if path == nil {
+		return []any{}
+	}
+	return path
 }
 
 // Given a type and any value, return a runtime value coerced to match the type.
@@ -158,7 +306,7 @@ func coerceValue(ttype Input, value any) any {
 
 		obj := map[string]any{}
 		for fieldName, field := range ttype.Fields() {
-			value := valueMap[fieldName]
+			value, _ := lookupAlias(valueMap, fieldName, field.Aliases)
 			fieldValue := coerceValue(field.Type, value)
 			if isNullish(fieldValue) {
 				fieldValue = field.DefaultValue
@@ -221,19 +369,41 @@ func typeFromAST(schema Schema, inputTypeAST ast.Type) (Type, error) {
 // Given a value and a GraphQL type, determine if the value will be
 // accepted for that type. This is primarily useful for validating the
 // runtime values of query variables.
-func isValidInputValue(value any, ttype Input) (bool, []string) {
+func isValidInputValue(value any, ttype Input, strictID bool) (bool, []string) {
+	problems := inputValueProblems(value, ttype, strictID)
+	if len(problems) == 0 {
+		return true, nil
+	}
+	messages := make([]string, len(problems))
+	for i, p := range problems {
+		messages[i] = p.message
+	}
+	return false, messages
+}
+
+// inputProblem is a reason an input value is invalid along with the path of
+// input object field names and list indexes to the invalid value.
+type inputProblem struct {
+	path    []any
+	message string
+}
+
+// inputValueProblems returns the reasons the value isn't valid for the type.
+// strictID if true only accepts strings for the ID type (see
+// SchemaConfig.StrictIDInput).
+func inputValueProblems(value any, ttype Input, strictID bool) []inputProblem {
 	if ttype, ok := ttype.(*NonNull); ok {
 		if isNullish(value) {
 			if ttype.OfType.Name() != "" {
-				return false, []string{fmt.Sprintf(`Expected "%v!", found null.`, ttype.OfType.Name())}
+				return []inputProblem{{message: fmt.Sprintf(`Expected "%v!", found null.`, ttype.OfType.Name())}}
 			}
-			return false, []string{"Expected non-null value, found null."}
+			return []inputProblem{{message: "Expected non-null value, found null."}}
 		}
-		return isValidInputValue(value, ttype.OfType)
+		return inputValueProblems(value, ttype.OfType, strictID)
 	}
 
 	if isNullish(value) {
-		return true, nil
+		return nil
 	}
 
 	switch ttype := ttype.(type) {
@@ -244,22 +414,24 @@ func isValidInputValue(value any, ttype Input) (bool, []string) {
 			valType = valType.Elem()
 		}
 		if valType.Kind() == reflect.Slice {
-			var messagesReduce []string
+			var problemsReduce []inputProblem
 			for i := 0; i < valType.Len(); i++ {
 				val := valType.Index(i).Interface()
-				_, messages := isValidInputValue(val, itemType)
-				for idx, message := range messages {
-					messagesReduce = append(messagesReduce, fmt.Sprintf(`In element #%v: %v`, idx+1, message))
+				for idx, p := range inputValueProblems(val, itemType, strictID) {
+					problemsReduce = append(problemsReduce, inputProblem{
+						path:    append([]any{i}, p.path...),
+						message: fmt.Sprintf(`In element #%v: %v`, idx+1, p.message),
+					})
 				}
 			}
-			return len(messagesReduce) == 0, messagesReduce
+			return problemsReduce
 		}
-		return isValidInputValue(value, itemType)
+		return inputValueProblems(value, itemType, strictID)
 
 	case *InputObject:
 		valueMap, ok := value.(map[string]any)
 		if !ok {
-			return false, []string{fmt.Sprintf(`Expected "%v", found not an object.`, ttype.Name())}
+			return []inputProblem{{message: fmt.Sprintf(`Expected "%v", found not an object.`, ttype.Name())}}
 		}
 		fields := ttype.Fields()
 
@@ -277,55 +449,72 @@ func isValidInputValue(value any, ttype Input) (bool, []string) {
 		}
 		sort.Strings(valueMapFieldNames)
 
-		var messagesReduce []string
+		var problemsReduce []inputProblem
 
 		// Ensure every provided field is defined.
 		for _, fieldName := range valueMapFieldNames {
-			if _, ok := fields[fieldName]; !ok {
-				messagesReduce = append(messagesReduce, fmt.Sprintf(`In field "%v": Unknown field.`, fieldName))
+			if findInputField(ttype, fieldName) == nil {
+				problemsReduce = append(problemsReduce, inputProblem{
+					path:    []any{fieldName},
+					message: fmt.Sprintf(`In field "%v": Unknown field.`, fieldName),
+				})
 			}
 		}
 		// Ensure every defined field is valid.
 		for _, fieldName := range fieldNames {
-			_, messages := isValidInputValue(valueMap[fieldName], fields[fieldName].Type)
-			for _, message := range messages {
-				messagesReduce = append(messagesReduce, fmt.Sprintf(`In field "%v": %v`, fieldName, message))
+			value, _ := lookupAlias(valueMap, fieldName, fields[fieldName].Aliases)
+			for _, p := range inputValueProblems(value, fields[fieldName].Type, strictID) {
+				problemsReduce = append(problemsReduce, inputProblem{
+					path:    append([]any{fieldName}, p.path...),
+					message: fmt.Sprintf(`In field "%v": %v`, fieldName, p.message),
+				})
 			}
 		}
 
-		return len(messagesReduce) == 0, messagesReduce
+		return problemsReduce
 	}
 
 	switch ttype := ttype.(type) {
 	case *Scalar:
+		if _, ok := value.(string); !ok && strictID && ttype == ID {
+			return []inputProblem{{message: fmt.Sprintf(`Expected type "%v", found "%v".`, ttype.Name(), value)}}
+		}
 		parsedVal := ttype.ParseValue(value)
+		if reason := scalarParseErrorReason(parsedVal); reason != "" {
+			return []inputProblem{{message: fmt.Sprintf(`Expected type "%v", found "%v": %s.`, ttype.Name(), value, reason)}}
+		}
 		if isNullish(parsedVal) {
-			return false, []string{fmt.Sprintf(`Expected type "%v", found "%v".`, ttype.Name(), value)}
+			return []inputProblem{{message: fmt.Sprintf(`Expected type "%v", found "%v".`, ttype.Name(), value)}}
 		}
-		return true, nil
-
 	case *Enum:
 		parsedVal := ttype.ParseValue(value)
 		if isNullish(parsedVal) {
-			return false, []string{fmt.Sprintf(`Expected type "%v", found "%v".`, ttype.Name(), value)}
+			return []inputProblem{{message: fmt.Sprintf(`Expected type "%v", found "%v".`, ttype.Name(), value)}}
 		}
-		return true, nil
 	}
-	return true, nil
+	return nil
 }
 
 // Returns true if a value is null, undefined, or NaN.
 func isNullish(value any) bool {
 	switch v := value.(type) {
-	case nil:
+	case nil, NullValue, *ScalarParseError:
 		return true
 	case float32:
 		return math.IsNaN(float64(v))
 	case float64:
 		return math.IsNaN(v)
 	}
-	// The any can hide an underlying nil ptr
-	if v := reflect.ValueOf(value); v.Kind() == reflect.Ptr {
+	// The any can hide a typed nil (e.g. a nil pointer, or a pointer to a nil pointer)
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return true
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Map, reflect.Func:
 		return v.IsNil()
 	}
 	return false
@@ -422,7 +611,7 @@ func valueFromAST(valueAST ast.Value, ttype Input, variables map[string]any) any
 		}
 		obj := make(map[string]any)
 		for fieldName, field := range ttype.Fields() {
-			fieldAST, ok := fieldASTs[fieldName]
+			fieldAST, ok := lookupAlias(fieldASTs, fieldName, field.Aliases)
 			if !ok || fieldAST == nil {
 				continue
 			}
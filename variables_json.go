@@ -0,0 +1,155 @@
+package graphql
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	// DefaultMaxVariablesBytes is the default maximum size of JSON encoded variables.
+	DefaultMaxVariablesBytes = 10 << 20
+	// DefaultMaxVariablesDepth is the default maximum nesting of objects and lists in JSON encoded variables.
+	DefaultMaxVariablesDepth = 32
+)
+
+// VariablesLimits are the limits applied when decoding JSON encoded variables.
+type VariablesLimits struct {
+	// MaxBytes is the maximum size of the encoded variables. Defaults to DefaultMaxVariablesBytes.
+	MaxBytes int64
+	// MaxDepth is the maximum nesting of objects and lists. Defaults to DefaultMaxVariablesDepth.
+	MaxDepth int
+}
+
+// DecodeVariables decodes JSON encoded variables from r. Numbers are decoded as
+// json.Number so that integers that don't fit a float64 aren't truncated. A null
+// or empty input returns nil variables. The input must be a single object that
+// doesn't exceed the limits.
+func DecodeVariables(r io.Reader, limits VariablesLimits) (map[string]any, error) {
+	if limits.MaxBytes <= 0 {
+		limits.MaxBytes = DefaultMaxVariablesBytes
+	}
+	if limits.MaxDepth <= 0 {
+		limits.MaxDepth = DefaultMaxVariablesDepth
+	}
+	lr := &io.LimitedReader{R: r, N: limits.MaxBytes + 1}
+	dec := json.NewDecoder(lr)
+	dec.UseNumber()
+	d := &variablesDecoder{dec: dec, maxDepth: limits.MaxDepth}
+
+	tok, err := dec.Token()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, d.wrapErr(lr, err)
+	}
+	var vars map[string]any
+	switch tok {
+	case nil:
+	case json.Delim('{'):
+		vars, err = d.object(1)
+		if err != nil {
+			return nil, d.wrapErr(lr, err)
+		}
+	default:
+		return nil, errors.New("Variables must be a JSON object.")
+	}
+	if _, err := dec.Token(); err == nil {
+		return nil, errors.New("Variables must be a single JSON value.")
+	} else if err != io.EOF {
+		return nil, d.wrapErr(lr, err)
+	}
+	if lr.N <= 0 {
+		return nil, d.tooLarge()
+	}
+	return vars, nil
+}
+
+type variablesDecoder struct {
+	dec      *json.Decoder
+	maxDepth int
+}
+
+func (d *variablesDecoder) tooLarge() error {
+	return errors.New("Variables exceed the maximum size.")
+}
+
+func (d *variablesDecoder) wrapErr(lr *io.LimitedReader, err error) error {
+	if lr.N <= 0 {
+		return d.tooLarge()
+	}
+	var depthErr variablesDepthError
+	if errors.As(err, &depthErr) {
+		return err
+	}
+	return fmt.Errorf("Variables are not valid JSON: %w", err)
+}
+
+type variablesDepthError int
+
+func (e variablesDepthError) Error() string {
+	return fmt.Sprintf("Variables exceed the maximum depth of %d.", int(e))
+}
+
+// value decodes the value that starts with tok.
+func (d *variablesDecoder) value(tok json.Token, depth int) (any, error) {
+	switch tok {
+	case json.Delim('{'):
+		return d.object(depth + 1)
+	case json.Delim('['):
+		return d.list(depth + 1)
+	}
+	return tok, nil
+}
+
+func (d *variablesDecoder) object(depth int) (map[string]any, error) {
+	if depth > d.maxDepth {
+		return nil, variablesDepthError(d.maxDepth)
+	}
+	obj := make(map[string]any)
+	for d.dec.More() {
+		tok, err := d.dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := tok.(string)
+		tok, err = d.dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		obj[key], err = d.value(tok, depth)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// Closing delimiter
+	if _, err := d.dec.Token(); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+func (d *variablesDecoder) list(depth int) ([]any, error) {
+	if depth > d.maxDepth {
+		return nil, variablesDepthError(d.maxDepth)
+	}
+	list := []any{}
+	for d.dec.More() {
+		tok, err := d.dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		v, err := d.value(tok, depth)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, v)
+	}
+	// Closing delimiter
+	if _, err := d.dec.Token(); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
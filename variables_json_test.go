@@ -0,0 +1,93 @@
+package graphql_test
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/gqlerrors"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+func TestDecodeVariables(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		limits   graphql.VariablesLimits
+		expected map[string]any
+		err      string
+	}{
+		{name: "empty", input: ""},
+		{name: "null", input: "null"},
+		{
+			name:  "values",
+			input: `{"a": 9007199254740993, "b": [1, "x", null, true], "c": {"d": 1.5}}`,
+			expected: map[string]any{
+				"a": json.Number("9007199254740993"),
+				"b": []any{json.Number("1"), "x", nil, true},
+				"c": map[string]any{"d": json.Number("1.5")},
+			},
+		},
+		{name: "not an object", input: `[1]`, err: "Variables must be a JSON object."},
+		{name: "trailing value", input: `{} {}`, err: "Variables must be a single JSON value."},
+		{name: "invalid", input: `{"a": }`, err: "Variables are not valid JSON: "},
+		{name: "too large", input: `{"a": "0123456789"}`, limits: graphql.VariablesLimits{MaxBytes: 10}, err: "Variables exceed the maximum size."},
+		{name: "too deep", input: `{"a": [[{"b": 1}]]}`, limits: graphql.VariablesLimits{MaxDepth: 3}, err: "Variables exceed the maximum depth of 3."},
+		{name: "max depth", input: `{"a": [{"b": 1}]}`, limits: graphql.VariablesLimits{MaxDepth: 3}, expected: map[string]any{
+			"a": []any{map[string]any{"b": json.Number("1")}},
+		}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			vars, err := graphql.DecodeVariables(strings.NewReader(c.input), c.limits)
+			if c.err != "" {
+				// The message of JSON syntax errors depends on the Go version so only the prefix is checked.
+				if err == nil || !strings.HasPrefix(err.Error(), c.err) {
+					t.Fatalf("Expected error %q, got %v", c.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(c.expected, vars) {
+				t.Fatalf("Unexpected variables, Diff: %v", testutil.Diff(c.expected, vars))
+			}
+		})
+	}
+}
+
+func TestDo_VariablesJSON(t *testing.T) {
+	schema := testSchema(t, &graphql.Field{
+		Type: graphql.String,
+		Args: graphql.FieldConfigArgument{
+			"n": &graphql.ArgumentConfig{Type: graphql.Int},
+		},
+		Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+			return p.Args["n"], nil
+		},
+	})
+
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema:        schema,
+		RequestString: `query Q($n: Int) { test(n: $n) }`,
+		VariablesJSON: strings.NewReader(`{"n": 42}`),
+	})
+	expected := map[string]any{"test": "42"}
+	if len(result.Errors) != 0 || !reflect.DeepEqual(expected, result.Data) {
+		t.Fatalf("Unexpected result %+v", result)
+	}
+
+	result = graphql.Do(context.Background(), graphql.Params{
+		Schema:          schema,
+		RequestString:   `query Q($n: Int) { test(n: $n) }`,
+		VariablesJSON:   strings.NewReader(`{"n": 42}`),
+		VariablesLimits: graphql.VariablesLimits{MaxBytes: 4},
+	})
+	if len(result.Errors) != 1 || result.Errors[0].Type != gqlerrors.ErrorTypeInvalidInput {
+		t.Fatalf("Expected invalid input error, got %v", result.Errors)
+	}
+}
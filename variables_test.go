@@ -3,7 +3,10 @@ package graphql_test
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"math/big"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/sprucehealth/graphql"
@@ -422,11 +425,18 @@ func TestVariables_ObjectsAndNullability_UsingVariables_ErrorsOnNullForNestedNon
 				Type: gqlerrors.ErrorTypeInvalidInput,
 				Message: `Variable "$input" got invalid value {"a":"foo","b":"bar","c":null}.` +
 					"\nIn field \"c\": Expected \"String!\", found null.",
+				Code: gqlerrors.CodeInvalidVariable,
 				Locations: []location.SourceLocation{
 					{
 						Line: 2, Column: 17,
 					},
 				},
+				Extensions: map[string]any{
+					"inputPath": []any{"c"},
+					"inputErrors": []map[string]any{
+						{"inputPath": []any{"c"}, "message": `In field "c": Expected "String!", found null.`},
+					},
+				},
 			},
 		},
 	}
@@ -457,11 +467,18 @@ func TestVariables_ObjectsAndNullability_UsingVariables_ErrorsOnIncorrectType(t
 			{
 				Type:    gqlerrors.ErrorTypeInvalidInput,
 				Message: "Variable \"$input\" got invalid value \"foo bar\".\nExpected \"TestInputObject\", found not an object.",
+				Code:    gqlerrors.CodeInvalidVariable,
 				Locations: []location.SourceLocation{
 					{
 						Line: 2, Column: 17,
 					},
 				},
+				Extensions: map[string]any{
+					"inputPath": []any{},
+					"inputErrors": []map[string]any{
+						{"inputPath": []any{}, "message": `Expected "TestInputObject", found not an object.`},
+					},
+				},
 			},
 		},
 	}
@@ -496,11 +513,18 @@ func TestVariables_ObjectsAndNullability_UsingVariables_ErrorsOnOmissionOfNested
 				Type: gqlerrors.ErrorTypeInvalidInput,
 				Message: `Variable "$input" got invalid value {"a":"foo","b":"bar"}.` +
 					"\nIn field \"c\": Expected \"String!\", found null.",
+				Code: gqlerrors.CodeInvalidVariable,
 				Locations: []location.SourceLocation{
 					{
 						Line: 2, Column: 17,
 					},
 				},
+				Extensions: map[string]any{
+					"inputPath": []any{"c"},
+					"inputErrors": []map[string]any{
+						{"inputPath": []any{"c"}, "message": `In field "c": Expected "String!", found null.`},
+					},
+				},
 			},
 		},
 	}
@@ -537,11 +561,19 @@ func TestVariables_ObjectsAndNullability_UsingVariables_ErrorsOnDeepNestedErrors
 				Message: `Variable "$input" got invalid value {"na":{"a":"foo"}}.` +
 					"\nIn field \"na\": In field \"c\": Expected \"String!\", found null." +
 					"\nIn field \"nb\": Expected \"String!\", found null.",
+				Code: gqlerrors.CodeInvalidVariable,
 				Locations: []location.SourceLocation{
 					{
 						Line: 2, Column: 19,
 					},
 				},
+				Extensions: map[string]any{
+					"inputPath": []any{"na", "c"},
+					"inputErrors": []map[string]any{
+						{"inputPath": []any{"na", "c"}, "message": `In field "na": In field "c": Expected "String!", found null.`},
+						{"inputPath": []any{"nb"}, "message": `In field "nb": Expected "String!", found null.`},
+					},
+				},
 			},
 		},
 	}
@@ -583,11 +615,18 @@ func TestVariables_ObjectsAndNullability_UsingVariables_ErrorsOnAdditionOfUnknow
 				Type: gqlerrors.ErrorTypeInvalidInput,
 				Message: `Variable "$input" got invalid value {"a":"foo","b":"bar","c":"baz","extra":"dog"}.` +
 					"\nIn field \"extra\": Unknown field.",
+				Code: gqlerrors.CodeInvalidVariable,
 				Locations: []location.SourceLocation{
 					{
 						Line: 2, Column: 17,
 					},
 				},
+				Extensions: map[string]any{
+					"inputPath": []any{"extra"},
+					"inputErrors": []map[string]any{
+						{"inputPath": []any{"extra"}, "message": `In field "extra": Unknown field.`},
+					},
+				},
 			},
 		},
 	}
@@ -794,6 +833,7 @@ func TestVariables_NonNullableScalars_DoesNotAllowNonNullableInputsToBeOmittedIn
 			{
 				Type:    gqlerrors.ErrorTypeInvalidInput,
 				Message: `Variable "$value" of required type "String!" was not provided.`,
+				Code:    gqlerrors.CodeMissingVariable,
 				Locations: []location.SourceLocation{
 					{
 						Line: 2, Column: 31,
@@ -833,7 +873,8 @@ func TestVariables_NonNullableScalars_DoesNotAllowNonNullableInputsToBeSetToNull
 		Errors: []gqlerrors.FormattedError{
 			{
 				Type:    gqlerrors.ErrorTypeInvalidInput,
-				Message: `Variable "$value" of required type "String!" was not provided.`,
+				Message: `Variable "$value" of non-null type "String!" must not be null.`,
+				Code:    gqlerrors.CodeNullVariable,
 				Locations: []location.SourceLocation{
 					{
 						Line: 2, Column: 31,
@@ -1063,6 +1104,7 @@ func TestVariables_ListsAndNullability_DoesNotAllowNonNullListsToBeNull(t *testi
 			{
 				Type:    gqlerrors.ErrorTypeInvalidInput,
 				Message: `Variable "$input" of required type "[String]!" was not provided.`,
+				Code:    gqlerrors.CodeMissingVariable,
 				Locations: []location.SourceLocation{
 					{
 						Line: 2, Column: 17,
@@ -1223,11 +1265,18 @@ func TestVariables_ListsAndNullability_DoesNotAllowListOfNonNullsToContainNull(t
 				Message: `Variable "$input" got invalid value ` +
 					`["A",null,"B"].` +
 					"\nIn element #1: Expected \"String!\", found null.",
+				Code: gqlerrors.CodeInvalidVariable,
 				Locations: []location.SourceLocation{
 					{
 						Line: 2, Column: 17,
 					},
 				},
+				Extensions: map[string]any{
+					"inputPath": []any{1},
+					"inputErrors": []map[string]any{
+						{"inputPath": []any{1}, "message": `In element #1: Expected "String!", found null.`},
+					},
+				},
 			},
 		},
 	}
@@ -1261,7 +1310,8 @@ func TestVariables_ListsAndNullability_DoesNotAllowNonNullListOfNonNullsToBeNull
 		Errors: []gqlerrors.FormattedError{
 			{
 				Type:    gqlerrors.ErrorTypeInvalidInput,
-				Message: `Variable "$input" of required type "[String!]!" was not provided.`,
+				Message: `Variable "$input" of non-null type "[String!]!" must not be null.`,
+				Code:    gqlerrors.CodeNullVariable,
 				Locations: []location.SourceLocation{
 					{
 						Line: 2, Column: 17,
@@ -1333,11 +1383,18 @@ func TestVariables_ListsAndNullability_DoesNotAllowNonNullListOfNonNullsToContai
 				Message: `Variable "$input" got invalid value ` +
 					`["A",null,"B"].` +
 					"\nIn element #1: Expected \"String!\", found null.",
+				Code: gqlerrors.CodeInvalidVariable,
 				Locations: []location.SourceLocation{
 					{
 						Line: 2, Column: 17,
 					},
 				},
+				Extensions: map[string]any{
+					"inputPath": []any{1},
+					"inputErrors": []map[string]any{
+						{"inputPath": []any{1}, "message": `In element #1: Expected "String!", found null.`},
+					},
+				},
 			},
 		},
 	}
@@ -1374,6 +1431,7 @@ func TestVariables_ListsAndNullability_DoesNotAllowInvalidTypesToBeUsedAsValues(
 			{
 				Type:    gqlerrors.ErrorTypeInvalidInput,
 				Message: `Variable "$input" expected value of type "TestType!" which cannot be used as an input type.`,
+				Code:    gqlerrors.CodeVariableNotInputType,
 				Locations: []location.SourceLocation{
 					{
 						Line: 2, Column: 17,
@@ -1413,6 +1471,7 @@ func TestVariables_ListsAndNullability_DoesNotAllowUnknownTypesToBeUsedAsValues(
 			{
 				Type:    gqlerrors.ErrorTypeInvalidInput,
 				Message: `Variable "$input" expected value of type "UnknownType!" which cannot be used as an input type.`,
+				Code:    gqlerrors.CodeVariableNotInputType,
 				Locations: []location.SourceLocation{
 					{
 						Line: 2, Column: 17,
@@ -1516,3 +1575,298 @@ func TestVariables_UsesArgumentDefaultValues_WhenArgumentProvidedCannotBeParsed(
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
 	}
 }
+
+func TestVariables_UseJSONNumber_PreservesLargeIntegers(t *testing.T) {
+	bigIntScalar := graphql.NewScalar(graphql.ScalarConfig{
+		Name: "BigInt",
+		Serialize: func(value any) any {
+			if v, ok := value.(*big.Int); ok {
+				return v.String()
+			}
+			return nil
+		},
+		ParseValue: func(value any) any {
+			n, ok := value.(json.Number)
+			if !ok {
+				return nil
+			}
+			v, ok := new(big.Int).SetString(string(n), 10)
+			if !ok {
+				return nil
+			}
+			return v
+		},
+		ParseLiteral: func(valueAST ast.Value) any {
+			if v, ok := valueAST.(*ast.IntValue); ok {
+				if i, ok := new(big.Int).SetString(v.Value, 10); ok {
+					return i
+				}
+			}
+			return nil
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"double": &graphql.Field{
+					Type: bigIntScalar,
+					Args: graphql.FieldConfigArgument{
+						"value": &graphql.ArgumentConfig{Type: bigIntScalar},
+					},
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						return new(big.Int).Lsh(p.Args["value"].(*big.Int), 1), nil
+					},
+				},
+				"id": &graphql.Field{
+					Type: graphql.ID,
+					Args: graphql.FieldConfigArgument{
+						"value": &graphql.ArgumentConfig{Type: graphql.ID},
+					},
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						return p.Args["value"], nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var variables map[string]any
+	dec := json.NewDecoder(strings.NewReader(`{"a": 12345678901234567890, "b": 9007199254740993}`))
+	dec.UseNumber()
+	if err := dec.Decode(&variables); err != nil {
+		t.Fatal(err)
+	}
+	expected := &graphql.Result{
+		Data: map[string]any{
+			"double": "24691357802469135780",
+			"id":     "9007199254740993",
+		},
+	}
+	result := graphql.Do(context.Background(), graphql.Params{
+		Schema:         schema,
+		RequestString:  `query ($a: BigInt, $b: ID) { double(value: $a) id(value: $b) }`,
+		VariableValues: variables,
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	// Without UseJSONNumber Go numeric values are passed to ParseValue as is.
+	result = graphql.Do(context.Background(), graphql.Params{
+		Schema:         schema,
+		RequestString:  `query ($a: BigInt) { double(value: $a) }`,
+		VariableValues: map[string]any{"a": 21},
+	})
+	if len(result.Errors) != 1 {
+		t.Fatalf("Expected 1 error, got %v", result.Errors)
+	}
+	expected = &graphql.Result{
+		Data: map[string]any{
+			"double": "42",
+		},
+	}
+	result = graphql.Do(context.Background(), graphql.Params{
+		Schema:         schema,
+		RequestString:  `query ($a: BigInt) { double(value: $a) }`,
+		VariableValues: map[string]any{"a": 21},
+		UseJSONNumber:  true,
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestVariables_DefaultValues(t *testing.T) {
+	resolve := func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+		if v, ok := p.Args["input"]; ok {
+			return fmt.Sprintf("%v", v), nil
+		}
+		return "<absent>", nil
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"nullable": &graphql.Field{
+					Type: graphql.String,
+					Args: graphql.FieldConfigArgument{
+						"input": &graphql.ArgumentConfig{Type: graphql.String, DefaultValue: "argDefault"},
+					},
+					Resolve: resolve,
+				},
+				"nonNull": &graphql.Field{
+					Type: graphql.String,
+					Args: graphql.FieldConfigArgument{
+						"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String), DefaultValue: "argDefault"},
+					},
+					Resolve: resolve,
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		query    string
+		vars     map[string]any
+		expected *graphql.Result
+	}{
+		// A non-null variable with a default value may be omitted
+		{
+			query:    `query q($v: String! = "varDefault") { nonNull(input: $v) }`,
+			expected: &graphql.Result{Data: map[string]any{"nonNull": "varDefault"}},
+		},
+		// An explicit null overrides the default values of the variable and argument
+		{
+			query:    `query q($v: String = "varDefault") { nullable(input: $v) }`,
+			vars:     map[string]any{"v": nil},
+			expected: &graphql.Result{Data: map[string]any{"nullable": "<absent>"}},
+		},
+		// An omitted variable without a default value uses the default of the argument
+		{
+			query:    `query q($v: String) { nullable(input: $v) }`,
+			expected: &graphql.Result{Data: map[string]any{"nullable": "argDefault"}},
+		},
+		// A nullable variable may be used for a non-null argument with a default value
+		{
+			query:    `query q($v: String) { nonNull(input: $v) }`,
+			expected: &graphql.Result{Data: map[string]any{"nonNull": "argDefault"}},
+		},
+		{
+			query: `query q($v: String) { nonNull(input: $v) }`,
+			vars:  map[string]any{"v": nil},
+			expected: &graphql.Result{
+				Data: map[string]any{"nonNull": nil},
+				Errors: []gqlerrors.FormattedError{
+					{
+						Type:      gqlerrors.ErrorTypeInvalidInput,
+						Message:   `Argument "input" of non-null type "String!" must not be null.`,
+						Locations: []location.SourceLocation{{Line: 1, Column: 23}},
+						Path:      []any{"nonNull"},
+					},
+				},
+			},
+		},
+		{
+			query: `query q($v: String! = "varDefault") { nonNull(input: $v) }`,
+			vars:  map[string]any{"v": nil},
+			expected: &graphql.Result{
+				Errors: []gqlerrors.FormattedError{
+					{
+						Type:      gqlerrors.ErrorTypeInvalidInput,
+						Message:   `Variable "$v" of non-null type "String!" must not be null.`,
+						Code:      gqlerrors.CodeNullVariable,
+						Locations: []location.SourceLocation{{Line: 1, Column: 9}},
+					},
+				},
+			},
+		},
+	}
+	for _, c := range cases {
+		result := graphql.Do(context.Background(), graphql.Params{
+			Schema:         schema,
+			RequestString:  c.query,
+			VariableValues: c.vars,
+		})
+		for i := range result.Errors {
+			result.Errors[i].OriginalError = nil
+		}
+		if !reflect.DeepEqual(c.expected, result) {
+			t.Errorf("%s %v: unexpected result, Diff: %v", c.query, c.vars, testutil.Diff(c.expected, result))
+		}
+	}
+}
+
+func TestVariables_StrictVariables(t *testing.T) {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"echo": &graphql.Field{
+					Type: graphql.String,
+					Args: graphql.FieldConfigArgument{
+						"input": &graphql.ArgumentConfig{Type: graphql.String},
+					},
+					Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+						return p.Args["input"], nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		query    string
+		vars     map[string]any
+		strict   bool
+		expected *graphql.Result
+	}{
+		{
+			query:    `query q($v: String) { echo(input: $v) }`,
+			vars:     map[string]any{"v": "a", "input": "b"},
+			expected: &graphql.Result{Data: map[string]any{"echo": "a"}},
+		},
+		{
+			query:    `query q($v: String) { echo(input: $v) }`,
+			vars:     map[string]any{"v": "a"},
+			strict:   true,
+			expected: &graphql.Result{Data: map[string]any{"echo": "a"}},
+		},
+		{
+			query:  `query q($v: String) { echo(input: $v) }`,
+			vars:   map[string]any{"v": "a", "vv": "b", "input": "c"},
+			strict: true,
+			expected: &graphql.Result{
+				Errors: []gqlerrors.FormattedError{
+					{
+						Type:      gqlerrors.ErrorTypeInvalidInput,
+						Code:      gqlerrors.CodeUndefinedVariable,
+						Message:   `Variable "$input" is not defined by operation "q".`,
+						Locations: []location.SourceLocation{{Line: 1, Column: 1}},
+					},
+					{
+						Type:      gqlerrors.ErrorTypeInvalidInput,
+						Code:      gqlerrors.CodeUndefinedVariable,
+						Message:   `Variable "$vv" is not defined by operation "q".`,
+						Locations: []location.SourceLocation{{Line: 1, Column: 1}},
+					},
+				},
+			},
+		},
+		{
+			query:  `{ echo }`,
+			vars:   map[string]any{"v": "a"},
+			strict: true,
+			expected: &graphql.Result{
+				Errors: []gqlerrors.FormattedError{
+					{
+						Type:      gqlerrors.ErrorTypeInvalidInput,
+						Code:      gqlerrors.CodeUndefinedVariable,
+						Message:   `Variable "$v" is not defined by operation.`,
+						Locations: []location.SourceLocation{{Line: 1, Column: 1}},
+					},
+				},
+			},
+		},
+	}
+	for _, c := range cases {
+		result := graphql.Do(context.Background(), graphql.Params{
+			Schema:          schema,
+			RequestString:   c.query,
+			VariableValues:  c.vars,
+			StrictVariables: c.strict,
+		})
+		if !reflect.DeepEqual(c.expected, result) {
+			t.Errorf("%s %v: unexpected result, Diff: %v", c.query, c.vars, testutil.Diff(c.expected, result))
+		}
+	}
+}
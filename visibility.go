@@ -0,0 +1,146 @@
+package graphql
+
+import (
+	"context"
+	"slices"
+
+	"github.com/sprucehealth/graphql/gqlerrors"
+	"github.com/sprucehealth/graphql/language/ast"
+	"github.com/sprucehealth/graphql/language/visitor"
+)
+
+// HiddenDirective is used on field definitions to hide the field from clients.
+// A hidden field isn't part of introspection and querying it is a validation
+// error, but it stays part of the schema (e.g. for a field that's being built).
+// Like CacheControlDirective it must be added to the schema directives to be
+// included in introspection.
+var HiddenDirective = NewDirective(DirectiveConfig{
+	Name:        "hidden",
+	Description: "Hides the field from clients.",
+	Locations: []string{
+		DirectiveLocationFieldDefinition,
+	},
+})
+
+// FeatureDirective is used on field definitions to only show the field to the
+// requests that have the feature enabled with WithFeatures (e.g. a field behind
+// a feature flag). For other requests the field is hidden as with HiddenDirective.
+var FeatureDirective = NewDirective(DirectiveConfig{
+	Name:        "feature",
+	Description: "Shows the field only to requests with the feature enabled.",
+	Locations: []string{
+		DirectiveLocationFieldDefinition,
+	},
+	Args: FieldConfigArgument{
+		"name": &ArgumentConfig{
+			Type:        NewNonNull(String),
+			Description: "Name of the feature.",
+		},
+	},
+})
+
+type featuresKey struct{}
+
+// WithFeatures returns a context that enables the features for the request in
+// addition to the ones already enabled by the context. See FeatureDirective.
+func WithFeatures(ctx context.Context, features ...string) context.Context {
+	enabled, _ := ctx.Value(featuresKey{}).([]string)
+	return context.WithValue(ctx, featuresKey{}, append(slices.Clip(enabled), features...))
+}
+
+// FeatureEnabled returns true if the feature is enabled by the context.
+func FeatureEnabled(ctx context.Context, feature string) bool {
+	enabled, _ := ctx.Value(featuresKey{}).([]string)
+	return slices.Contains(enabled, feature)
+}
+
+// enabledFeatures returns the sorted names of the features enabled by the context.
+func enabledFeatures(ctx context.Context) []string {
+	enabled, _ := ctx.Value(featuresKey{}).([]string)
+	features := append([]string{}, enabled...)
+	slices.Sort(features)
+	return slices.Compact(features)
+}
+
+// fieldVisibility returns whether the directives of a field definition hide it
+// and the feature that shows it if any.
+func fieldVisibility(directives []*ast.Directive) (hidden bool, feature string) {
+	for _, d := range directives {
+		if d.Name == nil {
+			continue
+		}
+		switch d.Name.Value {
+		case HiddenDirective.Name:
+			hidden = true
+		case FeatureDirective.Name:
+			feature, _ = getArgumentValues(FeatureDirective.Args, d.Arguments, nil)["name"].(string)
+		}
+	}
+	return hidden, feature
+}
+
+// IsVisible returns whether the field is visible to the caller of the request.
+func (fd *FieldDefinition) IsVisible(ctx context.Context) bool {
+	return !fd.hidden && (fd.feature == "" || FeatureEnabled(ctx, fd.feature))
+}
+
+// isGated returns true if the field is hidden from some requests.
+func (fd *FieldDefinition) isGated() bool {
+	return fd.hidden || fd.feature != ""
+}
+
+// hasGatedFields returns true if a field of an object or interface is hidden from
+// some requests.
+func hasGatedFields(typeMap TypeMap) bool {
+	for _, ttype := range typeMap {
+		var fields FieldDefinitionMap
+		switch ttype := ttype.(type) {
+		case *Object:
+			fields = ttype.Fields()
+		case *Interface:
+			fields = ttype.Fields()
+		}
+		for _, field := range fields {
+			if field.isGated() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkFieldVisibility returns a validation error for every field selected by the
+// operation or its fragments that isn't visible to the request. Fields hidden by
+// HiddenDirective are rejected by validation already.
+func checkFieldVisibility(ctx context.Context, schema *Schema, operation ast.Definition, fragments map[string]*ast.FragmentDefinition) []gqlerrors.FormattedError {
+	var errs []gqlerrors.FormattedError
+	typeInfo := NewTypeInfo(&TypeInfoConfig{Schema: schema})
+	opts := &visitor.VisitorOptions{
+		Enter: func(p visitor.VisitFuncParams) (string, any) {
+			node, ok := p.Node.(ast.Node)
+			if !ok {
+				return visitor.ActionNoChange, nil
+			}
+			typeInfo.Enter(node)
+			if field, ok := node.(*ast.Field); ok {
+				if fieldDef := typeInfo.FieldDef(); fieldDef != nil && !fieldDef.IsVisible(ctx) {
+					errs = append(errs, gqlerrors.FormatError(newValidationError(
+						UndefinedFieldMessage(fieldDef.Name, typeInfo.ParentType().Name(), nil, nil),
+						[]ast.Node{field})))
+				}
+			}
+			return visitor.ActionNoChange, nil
+		},
+		Leave: func(p visitor.VisitFuncParams) (string, any) {
+			if node, ok := p.Node.(ast.Node); ok {
+				typeInfo.Leave(node)
+			}
+			return visitor.ActionNoChange, nil
+		},
+	}
+	_ = visitor.Visit(operation, opts)
+	for _, name := range sortedKeys(fragments) {
+		_ = visitor.Visit(fragments[name], opts)
+	}
+	return errs
+}
@@ -0,0 +1,105 @@
+package graphql_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/language/ast"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+func TestFieldVisibility(t *testing.T) {
+	resolve := func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+		return p.Info.FieldName, nil
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"public": &graphql.Field{Type: graphql.String, Resolve: resolve},
+				"secret": &graphql.Field{
+					Type:       graphql.String,
+					Resolve:    resolve,
+					Directives: []*ast.Directive{{Name: &ast.Name{Value: "hidden"}}},
+				},
+				"beta": &graphql.Field{
+					Type:    graphql.String,
+					Resolve: resolve,
+					Directives: []*ast.Directive{{
+						Name: &ast.Name{Value: "feature"},
+						Arguments: []*ast.Argument{{
+							Name:  &ast.Name{Value: "name"},
+							Value: &ast.StringValue{Value: "beta"},
+						}},
+					}},
+				},
+			},
+		}),
+		Directives: append([]*graphql.Directive{graphql.HiddenDirective, graphql.FeatureDirective}, graphql.SpecifiedDirectives...),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	betaCtx := graphql.WithFeatures(context.Background(), "alpha", "beta")
+	if !graphql.FeatureEnabled(betaCtx, "beta") || graphql.FeatureEnabled(context.Background(), "beta") {
+		t.Fatal("Expected the beta feature to be enabled by the context only")
+	}
+
+	cases := []struct {
+		ctx      context.Context
+		query    string
+		expected *graphql.Result
+	}{
+		{
+			ctx:      context.Background(),
+			query:    `{ public }`,
+			expected: &graphql.Result{Data: map[string]any{"public": "public"}},
+		},
+		{
+			ctx:      betaCtx,
+			query:    `{ public beta }`,
+			expected: &graphql.Result{Data: map[string]any{"public": "public", "beta": "beta"}},
+		},
+		{
+			ctx:   context.Background(),
+			query: `{ __type(name: "Query") { fields { name } } }`,
+			expected: &graphql.Result{Data: map[string]any{"__type": map[string]any{
+				"fields": []any{map[string]any{"name": "public"}},
+			}}},
+		},
+		{
+			ctx:   betaCtx,
+			query: `{ __type(name: "Query") { fields { name } } }`,
+			expected: &graphql.Result{Data: map[string]any{"__type": map[string]any{
+				"fields": []any{map[string]any{"name": "beta"}, map[string]any{"name": "public"}},
+			}}},
+		},
+	}
+	for _, c := range cases {
+		result := graphql.Do(c.ctx, graphql.Params{Schema: schema, RequestString: c.query})
+		if !reflect.DeepEqual(c.expected, result) {
+			t.Errorf("%s: unexpected result, Diff: %v", c.query, testutil.Diff(c.expected, result))
+		}
+	}
+
+	// Hidden fields are invalid for every request, and fields of features only for
+	// requests without the feature
+	errorCases := []struct {
+		ctx     context.Context
+		query   string
+		message string
+	}{
+		{ctx: context.Background(), query: `{ public beta }`, message: `Cannot query field "beta" on type "Query".`},
+		{ctx: context.Background(), query: `{ ...F } fragment F on Query { beta }`, message: `Cannot query field "beta" on type "Query".`},
+		{ctx: betaCtx, query: `{ secret }`, message: `Cannot query field "secret" on type "Query".`},
+		{ctx: betaCtx, query: `{ secre }`, message: `Cannot query field "secre" on type "Query".`},
+	}
+	for _, c := range errorCases {
+		result := graphql.Do(c.ctx, graphql.Params{Schema: schema, RequestString: c.query})
+		if result.Data != nil || len(result.Errors) != 1 || result.Errors[0].Message != c.message {
+			t.Errorf("%s: expected error %q, got %+v", c.query, c.message, result)
+		}
+	}
+}